@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/gitlog"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/prompt"
+	"github.com/phrazzld/resumake/snippets"
+)
+
+// runAchievements drafts resume bullets from the user's commits in the given
+// repositories and prints them to w. With -save the bullets are also saved
+// as a snippet, so they can be inserted into the notes from the TUI.
+func runAchievements(ctx context.Context, flags input.AchievementsFlags, w io.Writer) error {
+	var commits []gitlog.Commit
+	for _, repo := range flags.Repos {
+		author := flags.Author
+		if author == "" {
+			var err error
+			if author, err = gitlog.DefaultAuthor(ctx, repo); err != nil {
+				return err
+			}
+		}
+
+		found, err := gitlog.Log(ctx, repo, gitlog.Options{Author: author, Since: flags.Since, Until: flags.Until, Limit: flags.MaxCommits})
+		if err != nil {
+			return err
+		}
+		commits = append(commits, found...)
+	}
+
+	commits = newestCommits(commits, flags.MaxCommits)
+	if len(commits) == 0 {
+		return fmt.Errorf("no commits found since %s; check -author and the date range", flags.Since.Format("2006-01-02"))
+	}
+	fmt.Fprintf(w, "Summarizing %d commits...\n", len(commits))
+
+	apiKey, err := api.GetAPIKey()
+	if err != nil {
+		return fmt.Errorf("API key error: %w", err)
+	}
+
+	client, _, err := api.InitializeClientWithModel(ctx, apiKey, flags.Model)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+	defer client.Close()
+
+	model, err := api.NewAchievementsModel(client, flags.Model)
+	if err != nil {
+		return err
+	}
+
+	activity := make([]string, len(commits))
+	for i, commit := range commits {
+		activity[i] = commit.String()
+	}
+
+	response, err := api.ExecuteRequest(ctx, model, prompt.GenerateAchievementsPromptContent(activity))
+	if err != nil {
+		return err
+	}
+
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return err
+	}
+
+	bullets := prompt.ParseAchievements(text)
+	if len(bullets) == 0 {
+		return errors.New("the response contained no achievement bullets; try a wider date range")
+	}
+
+	fmt.Fprint(w, formatAchievements(bullets))
+
+	if flags.Save != "" {
+		dir, err := snippets.DefaultDir()
+		if err != nil {
+			return err
+		}
+		path, err := snippets.Save(dir, flags.Save, formatAchievements(bullets))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nSaved as the snippet %q (%s); press Ctrl+O while writing your notes to insert it.\n", flags.Save, path)
+	}
+	return nil
+}
+
+// newestCommits merges the commits from every repository, newest first, and
+// keeps at most limit of them.
+func newestCommits(commits []gitlog.Commit, limit int) []gitlog.Commit {
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Date.After(commits[j].Date)
+	})
+	if limit > 0 && len(commits) > limit {
+		commits = commits[:limit]
+	}
+	return commits
+}
+
+// formatAchievements renders the bullets as a Markdown list, ready to paste
+// into the notes.
+func formatAchievements(bullets []string) string {
+	var b strings.Builder
+	for _, bullet := range bullets {
+		b.WriteString("- " + bullet + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/gitlog"
+)
+
+func TestNewestCommits(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 12, 0, 0, 0, time.UTC) }
+	commits := []gitlog.Commit{
+		{Repo: "widgets", Subject: "Old", Date: day(1)},
+		{Repo: "widgets", Subject: "Newest", Date: day(9)},
+		{Repo: "api", Subject: "Middle", Date: day(5)},
+	}
+
+	// Commits from every repository are ordered newest first and capped
+	got := newestCommits(commits, 2)
+	if len(got) != 2 || got[0].Subject != "Newest" || got[1].Subject != "Middle" {
+		t.Errorf("Unexpected commits: %+v", got)
+	}
+}
+
+func TestFormatAchievements(t *testing.T) {
+	got := formatAchievements([]string{"Built PDF export (widgets, May 2024)", "Added SSO (auth, Feb 2024)"})
+
+	want := "- Built PDF export (widgets, May 2024)\n- Added SSO (auth, Feb 2024)\n"
+	if got != want {
+		t.Errorf("formatAchievements() = %q, want %q", got, want)
+	}
+}
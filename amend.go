@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/phrazzld/resumake/history"
+)
+
+// amendSourcePath returns the previously generated resume that -amend
+// updates: the output file when it already exists, or else the resume most
+// recently saved to the history.
+func amendSourcePath(outputPath, historyDir string) (string, error) {
+	if outputPath != "" {
+		if _, err := os.Stat(outputPath); err == nil {
+			return outputPath, nil
+		}
+	}
+
+	if historyDir != "" {
+		newest, ok, err := history.Newest(historyDir)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return newest.Path, nil
+		}
+	}
+	return "", errors.New("there is no previous resume to amend; generate one first, or name it with -output")
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/history"
+)
+
+func TestAmendSourcePath(t *testing.T) {
+	dir := t.TempDir()
+	historyDir := filepath.Join(dir, "history")
+
+	// Test case 1: Nothing to amend is an error
+	if _, err := amendSourcePath(filepath.Join(dir, "missing.md"), historyDir); err == nil {
+		t.Error("Expected an error without a previous resume")
+	}
+
+	// Test case 2: The newest resume in the history is amended
+	revision, err := history.Save(historyDir, "# Jane Doe\n\n## Experience\n- Acme", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path, err := amendSourcePath(filepath.Join(dir, "missing.md"), historyDir); err != nil || path != revision.Path {
+		t.Errorf("Expected %s, got %s (%v)", revision.Path, path, err)
+	}
+
+	// Test case 3: An existing output file is amended in place of the history
+	outputPath := filepath.Join(dir, "resume.md")
+	if err := os.WriteFile(outputPath, []byte("# Jane Doe"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if path, err := amendSourcePath(outputPath, historyDir); err != nil || path != outputPath {
+		t.Errorf("Expected %s, got %s (%v)", outputPath, path, err)
+	}
+}
@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WeakBulletScore is the highest score of a weak bullet: one that shows at
+// most one of an action verb, a metric, and an outcome.
+const WeakBulletScore = 1
+
+// impactHeadingWords mark the sections whose bullets describe work done, and
+// so are scored: experience, projects, and achievements, but not skills or
+// education.
+var impactHeadingWords = []string{"experience", "employment", "work", "project", "achievement", "accomplishment", "volunteer", "leadership"}
+
+// bulletItemRegex matches a list item, capturing its marker and its text.
+var bulletItemRegex = regexp.MustCompile(`^(\s*(?:[-*+•]|\d{1,9}[.)])\s+)(\S.*)$`)
+
+// metricRegex matches a number, or a word that stands for one.
+var metricRegex = regexp.MustCompile(`\d|(?i)\b(?:dozens|hundreds|thousands|millions|billions|doubled|tripled|halved|twice)\b`)
+
+// weakOpeners are first words that describe a duty or a role rather than
+// something done, such as "Responsible for" or "Helped with".
+var weakOpeners = map[string]bool{
+	"responsible": true, "worked": true, "helped": true, "assisted": true, "participated": true,
+	"involved": true, "tasked": true, "duties": true, "was": true, "were": true, "did": true,
+	"handled": true, "contributed": true, "supported": true, "exposure": true, "familiar": true,
+	"experience": true, "experienced": true, "knowledge": true, "member": true, "part": true,
+	"i": true, "my": true, "we": true, "the": true, "a": true, "an": true, "various": true,
+}
+
+// actionVerbs are action verbs that do not end in "ed": irregular past
+// tenses and the present tenses used for a current role.
+var actionVerbs = map[string]bool{
+	"led": true, "built": true, "ran": true, "won": true, "cut": true, "drove": true, "grew": true,
+	"wrote": true, "made": true, "set": true, "began": true, "brought": true, "taught": true,
+	"sold": true, "spoke": true, "rebuilt": true, "rewrote": true, "overtook": true, "upheld": true,
+	"lead": true, "build": true, "run": true, "own": true, "drive": true, "grow": true, "write": true,
+	"manage": true, "design": true, "develop": true, "deliver": true, "ship": true, "launch": true,
+	"create": true, "implement": true, "architect": true, "mentor": true, "coach": true, "direct": true,
+	"oversee": true, "oversaw": true, "spearhead": true, "negotiate": true, "reduce": true, "increase": true,
+	"improve": true, "automate": true, "migrate": true, "scale": true, "optimize": true, "establish": true,
+}
+
+// outcomeStems start words that state a result, such as "increasing",
+// "revenue", or "faster".
+var outcomeStems = []string{
+	"increas", "reduc", "improv", "sav", "cut", "grew", "grow", "boost", "lower", "rais", "doubl",
+	"tripl", "halv", "faster", "revenue", "cost", "profit", "retention", "conversion", "efficien",
+	"latency", "uptime", "satisfaction", "adoption", "accelerat", "eliminat", "prevent", "enabl",
+	"result", "won", "award", "exceed", "surpass", "shorten", "speed", "recover",
+}
+
+// outcomePhrases state a result in more than one word.
+var outcomePhrases = []string{"leading to", "so that", "which allowed", "allowing"}
+
+// BulletScore is how a bullet rates on the impact rubric: whether it starts
+// with an action verb, includes a metric, and states an outcome.
+type BulletScore struct {
+	Text    string // The bullet, without its list marker
+	Line    int    // The line the bullet is on, counting from 1
+	Verb    bool   // Whether it starts with an action verb, such as "Led" or "Cut"
+	Metric  bool   // Whether it includes a number, such as "40%" or "3 teams"
+	Outcome bool   // Whether it states a result, such as "reducing costs"
+}
+
+// Score returns how many parts of the rubric the bullet meets, from 0 to 3.
+func (b BulletScore) Score() int {
+	score := 0
+	for _, met := range []bool{b.Verb, b.Metric, b.Outcome} {
+		if met {
+			score++
+		}
+	}
+	return score
+}
+
+// Weak reports whether the bullet meets at most WeakBulletScore parts of the rubric.
+func (b BulletScore) Weak() bool {
+	return b.Score() <= WeakBulletScore
+}
+
+// Missing names the parts of the rubric the bullet does not meet, such as
+// "a metric", in rubric order.
+func (b BulletScore) Missing() []string {
+	var missing []string
+	if !b.Verb {
+		missing = append(missing, "an action verb")
+	}
+	if !b.Metric {
+		missing = append(missing, "a metric")
+	}
+	if !b.Outcome {
+		missing = append(missing, "an outcome")
+	}
+	return missing
+}
+
+// ImpactSection reports whether a section's bullets describe work done, and
+// so are worth scoring, going by its heading.
+//
+// Parameters:
+//   - heading: The section heading, such as "Professional Experience"
+//
+// Returns:
+//   - bool: True for experience, project, and achievement sections
+func ImpactSection(heading string) bool {
+	heading = strings.ToLower(heading)
+	for _, word := range impactHeadingWords {
+		if strings.Contains(heading, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScoreBullets rates each bullet in Markdown text on the impact rubric:
+// an action verb to start, a metric, and an outcome. Bold and italic
+// markers are ignored; code blocks and lines that are not list items are
+// skipped.
+//
+// Parameters:
+//   - markdown: A resume section, or a whole resume
+//
+// Returns:
+//   - []BulletScore: The score of each bullet, in the order they appear
+//
+// Example:
+//
+//	for _, bullet := range analysis.ScoreBullets(section.Body) {
+//	    if bullet.Weak() {
+//	        fmt.Printf("%q lacks %s\n", bullet.Text, strings.Join(bullet.Missing(), " and "))
+//	    }
+//	}
+func ScoreBullets(markdown string) []BulletScore {
+	var scores []BulletScore
+	inFence := false
+	for i, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		match := bulletItemRegex.FindStringSubmatch(line)
+		if inFence || match == nil {
+			continue
+		}
+
+		text := strings.TrimSpace(match[2])
+		plain := strings.NewReplacer("**", "", "__", "", "*", "", "_", "").Replace(text)
+		words := Words(plain)
+		if len(words) == 0 {
+			continue
+		}
+		scores = append(scores, BulletScore{
+			Text:    text,
+			Line:    i + 1,
+			Verb:    isActionVerb(words[0]),
+			Metric:  metricRegex.MatchString(plain),
+			Outcome: statesOutcome(strings.ToLower(plain), words),
+		})
+	}
+	return scores
+}
+
+// isActionVerb reports whether a bullet's first word, in lowercase, is an
+// action verb: a known one, or a past tense that is not a weak opener.
+func isActionVerb(word string) bool {
+	if weakOpeners[word] {
+		return false
+	}
+	return actionVerbs[word] || (len(word) > 4 && strings.HasSuffix(word, "ed"))
+}
+
+// statesOutcome reports whether a bullet, in lowercase and split into words,
+// states a result.
+func statesOutcome(lower string, words []string) bool {
+	for _, phrase := range outcomePhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	for _, word := range words {
+		for _, stem := range outcomeStems {
+			if strings.HasPrefix(word, stem) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoreBullets(t *testing.T) {
+	markdown := "### Acme\n\n" +
+		"- Cut build times by 40%, saving 200 engineering hours a month\n" +
+		"- Responsible for the deployment pipeline\n" +
+		"* **Led** a team of 5 engineers\n" +
+		"1. Worked on billing, reducing failed payments\n" +
+		"Acme is a payments company.\n\n" +
+		"```\n- not a bullet\n```"
+
+	want := []BulletScore{
+		// Test case 1: A verb, a metric, and an outcome
+		{Text: "Cut build times by 40%, saving 200 engineering hours a month", Line: 3, Verb: true, Metric: true, Outcome: true},
+		// Test case 2: A duty rather than something done
+		{Text: "Responsible for the deployment pipeline", Line: 4},
+		// Test case 3: Formatting around the verb is ignored
+		{Text: "**Led** a team of 5 engineers", Line: 5, Verb: true, Metric: true},
+		// Test case 4: A weak opener with an outcome
+		{Text: "Worked on billing, reducing failed payments", Line: 6, Outcome: true},
+	}
+	got := ScoreBullets(markdown)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ScoreBullets() = %+v, want %+v", got, want)
+	}
+
+	// Bullets meeting at most one part of the rubric are weak
+	for i, weak := range []bool{false, true, false, true} {
+		if got[i].Weak() != weak {
+			t.Errorf("Bullet %d: Weak() = %v, want %v (score %d)", i+1, got[i].Weak(), weak, got[i].Score())
+		}
+	}
+	if missing := got[3].Missing(); !reflect.DeepEqual(missing, []string{"an action verb", "a metric"}) {
+		t.Errorf("Missing() = %v, want an action verb and a metric", missing)
+	}
+}
+
+func TestImpactSection(t *testing.T) {
+	tests := map[string]bool{
+		"Professional Experience": true,
+		"Selected Projects":       true,
+		"Key Achievements":        true,
+		"Skills":                  false,
+		"Education":               false,
+	}
+	for heading, want := range tests {
+		if got := ImpactSection(heading); got != want {
+			t.Errorf("ImpactSection(%q) = %v, want %v", heading, got, want)
+		}
+	}
+}
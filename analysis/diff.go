@@ -0,0 +1,247 @@
+package analysis
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DiffKind describes how a row of a line diff differs between the two texts.
+type DiffKind int
+
+const (
+	// DiffSame is a line that appears in both texts.
+	DiffSame DiffKind = iota
+	// DiffChanged is a line of the first text replaced by a line of the second.
+	DiffChanged
+	// DiffOnlyA is a line that appears only in the first text.
+	DiffOnlyA
+	// DiffOnlyB is a line that appears only in the second text.
+	DiffOnlyB
+)
+
+// diffMarkers are the gutter markers SideBySide prints for each kind, in the
+// style of sdiff.
+var diffMarkers = map[DiffKind]string{
+	DiffSame:    " ",
+	DiffChanged: "|",
+	DiffOnlyA:   "<",
+	DiffOnlyB:   ">",
+}
+
+// DiffRow is one row of a side-by-side line diff.
+type DiffRow struct {
+	Kind DiffKind
+	A    string // The line from the first text (empty for DiffOnlyB)
+	B    string // The line from the second text (empty for DiffOnlyA)
+}
+
+// DiffLines compares two texts line by line. Lines are matched by their
+// longest common subsequence; a run of removed lines followed by a run of
+// added lines is paired up into changed rows, so that rewritten lines sit
+// next to each other. Trailing whitespace is ignored.
+//
+// Parameters:
+//   - a: The first text
+//   - b: The second text
+//
+// Returns:
+//   - []DiffRow: The rows of the diff, in order
+//
+// Example:
+//
+//	rows := analysis.DiffLines("# Jane\nBuilt X", "# Jane\nLed X")
+//	// rows[0].Kind == DiffSame, rows[1].Kind == DiffChanged
+func DiffLines(a, b string) []DiffRow {
+	linesA := splitLines(a)
+	linesB := splitLines(b)
+
+	var rows []DiffRow
+	var removed, added []string
+	flush := func() {
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, DiffRow{Kind: DiffChanged, A: removed[k], B: added[k]})
+			case k < len(removed):
+				rows = append(rows, DiffRow{Kind: DiffOnlyA, A: removed[k]})
+			default:
+				rows = append(rows, DiffRow{Kind: DiffOnlyB, B: added[k]})
+			}
+		}
+		removed, added = nil, nil
+	}
+
+	for _, op := range diffKeys(linesA, linesB) {
+		switch op.kind {
+		case DiffSame:
+			flush()
+			rows = append(rows, DiffRow{Kind: DiffSame, A: linesA[op.a], B: linesB[op.b]})
+		case DiffOnlyA:
+			removed = append(removed, linesA[op.a])
+		default:
+			added = append(added, linesB[op.b])
+		}
+	}
+	flush()
+	return rows
+}
+
+// editOp is one step of the edits that turn one list of keys into another.
+type editOp struct {
+	kind DiffKind // DiffSame, DiffOnlyA for a removed key, or DiffOnlyB for an added one
+	a    int      // The index in the first list (unused for DiffOnlyB)
+	b    int      // The index in the second list (unused for DiffOnlyA)
+}
+
+// diffKeys returns the edits that turn keys a into keys b, keeping their
+// longest common subsequence. Between two kept keys, the removed keys come
+// before the added ones, so callers can pair them up into changed rows.
+func diffKeys(a, b []string) []editOp {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []editOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, editOp{kind: DiffSame, a: i, b: j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, editOp{kind: DiffOnlyA, a: i})
+			i++
+		default:
+			ops = append(ops, editOp{kind: DiffOnlyB, b: j})
+			j++
+		}
+	}
+	return ops
+}
+
+// CountDifferences returns the number of rows that are not the same in both texts.
+//
+// Parameters:
+//   - rows: The rows returned by DiffLines
+//
+// Returns:
+//   - int: The number of changed, removed, and added rows
+func CountDifferences(rows []DiffRow) int {
+	count := 0
+	for _, row := range rows {
+		if row.Kind != DiffSame {
+			count++
+		}
+	}
+	return count
+}
+
+// SideBySide renders a line diff as two columns with a marker between them:
+// "|" for a changed line, "<" for a line only in the first text, and ">" for
+// a line only in the second. Long lines wrap within their column rather than
+// being cut off, so both texts can be read in full.
+//
+// Parameters:
+//   - rows: The rows returned by DiffLines
+//   - width: The total width of the output in characters
+//
+// Returns:
+//   - string: The rendered diff, one or more lines per row
+//
+// Example:
+//
+//	fmt.Print(analysis.SideBySide(analysis.DiffLines(resumeA, resumeB), 160))
+func SideBySide(rows []DiffRow, width int) string {
+	column := max((width-3)/2, 10)
+
+	var b strings.Builder
+	for _, row := range rows {
+		left := wrapLine(row.A, column)
+		right := wrapLine(row.B, column)
+		for k := 0; k < max(len(left), len(right)); k++ {
+			l, r := "", ""
+			if k < len(left) {
+				l = left[k]
+			}
+			if k < len(right) {
+				r = right[k]
+			}
+
+			marker := diffMarkers[row.Kind]
+			if k > 0 {
+				marker = " "
+			}
+			line := l + strings.Repeat(" ", column-utf8.RuneCountInString(l)) + " " + marker + " " + r
+			b.WriteString(strings.TrimRight(line, " ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// splitLines splits text into lines without trailing whitespace. An empty
+// text has no lines.
+func splitLines(text string) []string {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return lines
+}
+
+// wrapLine wraps a line at word boundaries to at most width characters,
+// keeping its indentation on every wrapped line. Words longer than width are
+// split. An empty line wraps to a single empty line.
+func wrapLine(line string, width int) []string {
+	indent := strings.ReplaceAll(line[:len(line)-len(strings.TrimLeft(line, " \t"))], "\t", "    ")
+	if len(indent) > width/2 {
+		indent = indent[:width/2]
+	}
+	width -= len(indent)
+
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		for utf8.RuneCountInString(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+	for i := range lines {
+		lines[i] = indent + lines[i]
+	}
+	return lines
+}
@@ -0,0 +1,123 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []DiffRow
+	}{
+		{
+			name: "Identical texts",
+			a:    "# Jane\n- Built X\n",
+			b:    "# Jane\n- Built X",
+			want: []DiffRow{
+				{Kind: DiffSame, A: "# Jane", B: "# Jane"},
+				{Kind: DiffSame, A: "- Built X", B: "- Built X"},
+			},
+		},
+		{
+			name: "Rewritten line is paired",
+			a:    "# Jane\n- Built X\n## Skills",
+			b:    "# Jane\n- Led X\n## Skills",
+			want: []DiffRow{
+				{Kind: DiffSame, A: "# Jane", B: "# Jane"},
+				{Kind: DiffChanged, A: "- Built X", B: "- Led X"},
+				{Kind: DiffSame, A: "## Skills", B: "## Skills"},
+			},
+		},
+		{
+			name: "Lines only in one text",
+			a:    "# Jane\n- Built X",
+			b:    "# Jane\n- Built X\n- Shipped Y",
+			want: []DiffRow{
+				{Kind: DiffSame, A: "# Jane", B: "# Jane"},
+				{Kind: DiffSame, A: "- Built X", B: "- Built X"},
+				{Kind: DiffOnlyB, B: "- Shipped Y"},
+			},
+		},
+		{
+			name: "More removed than added",
+			a:    "- One\n- Two\n- Three",
+			b:    "- Uno",
+			want: []DiffRow{
+				{Kind: DiffChanged, A: "- One", B: "- Uno"},
+				{Kind: DiffOnlyA, A: "- Two"},
+				{Kind: DiffOnlyA, A: "- Three"},
+			},
+		},
+		{
+			name: "Empty texts",
+			a:    "",
+			b:    "",
+			want: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DiffLines(tc.a, tc.b)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("DiffLines() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiffKeys(t *testing.T) {
+	// Kept keys are matched, and removals come before additions between them
+	got := diffKeys([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"})
+	want := []editOp{
+		{kind: DiffSame, a: 0, b: 0},
+		{kind: DiffOnlyA, a: 1},
+		{kind: DiffOnlyB, b: 1},
+		{kind: DiffSame, a: 2, b: 2},
+		{kind: DiffOnlyB, b: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffKeys() = %+v, want %+v", got, want)
+	}
+	if ops := diffKeys(nil, nil); len(ops) != 0 {
+		t.Errorf("Expected no edits for empty lists, got %+v", ops)
+	}
+}
+
+func TestCountDifferences(t *testing.T) {
+	rows := DiffLines("# Jane\n- Built X\n- Old", "# Jane\n- Led X")
+	if got := CountDifferences(rows); got != 2 {
+		t.Errorf("CountDifferences() = %d, want 2", got)
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	// Test case 1: Columns are padded and marked
+	got := SideBySide([]DiffRow{
+		{Kind: DiffSame, A: "# Jane", B: "# Jane"},
+		{Kind: DiffChanged, A: "Built X", B: "Led X"},
+		{Kind: DiffOnlyA, A: "Old"},
+		{Kind: DiffOnlyB, B: "New"},
+	}, 23)
+	want := "# Jane       # Jane\n" +
+		"Built X    | Led X\n" +
+		"Old        <\n" +
+		"           > New\n"
+	if got != want {
+		t.Errorf("SideBySide() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Test case 2: Long lines wrap within their column, keeping indentation
+	got = SideBySide([]DiffRow{
+		{Kind: DiffChanged, A: "  - one two three four", B: "short"},
+	}, 23)
+	want = "  - one    | short\n" +
+		"  two\n" +
+		"  three\n" +
+		"  four\n"
+	if got != want {
+		t.Errorf("SideBySide() =\n%q\nwant\n%q", got, want)
+	}
+}
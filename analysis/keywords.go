@@ -0,0 +1,289 @@
+// Package analysis provides local, offline analysis of generated resumes.
+//
+// It inspects resume text without calling the API: term frequencies,
+// overused buzzwords, and keyword coverage against a job description.
+// The results are intended for display in the TUI so users can judge
+// how well a resume communicates before sending it out.
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultTopTerms is the number of terms reported by Analyze.
+const DefaultTopTerms = 10
+
+// GapTerms is the number of job description terms checked for gaps in the
+// resume. It is wider than DefaultTopTerms so skills the job mentions less
+// often are still suggested.
+const GapTerms = 20
+
+// TermCount pairs a term with the number of times it occurs.
+type TermCount struct {
+	Term  string
+	Count int
+}
+
+// KeywordCoverage describes how often a job description keyword appears in the resume.
+type KeywordCoverage struct {
+	Term        string // The keyword from the job description
+	JobCount    int    // Occurrences in the job description
+	ResumeCount int    // Occurrences in the resume
+}
+
+// Covered reports whether the keyword appears in the resume at least once.
+func (k KeywordCoverage) Covered() bool {
+	return k.ResumeCount > 0
+}
+
+// Report is the result of analyzing a resume.
+type Report struct {
+	TotalWords int               // Number of words in the resume
+	TopTerms   []TermCount       // Most frequent meaningful terms
+	Buzzwords  []TermCount       // Buzzwords found in the resume
+	Keywords   []KeywordCoverage // Job description keyword coverage (empty without a job description)
+}
+
+// Density returns the share of all resume words taken up by a term count, as a percentage.
+func (r Report) Density(tc TermCount) float64 {
+	if r.TotalWords == 0 {
+		return 0
+	}
+	return float64(tc.Count) * 100 / float64(r.TotalWords)
+}
+
+// Buzzwords lists overused resume phrases that add little information.
+var Buzzwords = []string{
+	"best of breed",
+	"detail-oriented",
+	"dynamic",
+	"go-getter",
+	"hard-working",
+	"hardworking",
+	"motivated",
+	"passionate",
+	"proactive",
+	"proven track record",
+	"results-driven",
+	"results-oriented",
+	"self-starter",
+	"strategic thinker",
+	"synergy",
+	"team player",
+	"think outside the box",
+	"thought leader",
+	"value add",
+	"world-class",
+}
+
+// wordRegex matches words, keeping characters common in technology names (c++, c#, node.js).
+var wordRegex = regexp.MustCompile(`[a-z0-9][a-z0-9+#.\-]*[a-z0-9+#]|[a-z0-9]`)
+
+// stopWords are common words excluded from term frequency results.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "have": true,
+	"in": true, "into": true, "is": true, "it": true, "its": true, "of": true,
+	"on": true, "or": true, "our": true, "that": true, "the": true, "their": true,
+	"this": true, "to": true, "was": true, "we": true, "were": true, "will": true,
+	"with": true, "you": true, "your": true, "i": true, "my": true, "me": true,
+	"all": true, "also": true, "across": true, "over": true, "per": true,
+	"using": true, "including": true, "within": true, "while": true,
+}
+
+// Words splits text into lowercase words, ignoring Markdown punctuation.
+//
+// Parameters:
+//   - text: The text to tokenize
+//
+// Returns:
+//   - []string: The words in order of appearance
+func Words(text string) []string {
+	return wordRegex.FindAllString(strings.ToLower(text), -1)
+}
+
+// TopTerms returns the n most frequent meaningful terms in text.
+// Stop words and pure numbers are ignored. Ties are broken alphabetically
+// so the result is deterministic.
+//
+// Parameters:
+//   - text: The text to analyze
+//   - n: The maximum number of terms to return
+//
+// Returns:
+//   - []TermCount: The most frequent terms, most frequent first
+//
+// Example:
+//
+//	for _, tc := range analysis.TopTerms(resume, 5) {
+//	    fmt.Printf("%s: %d\n", tc.Term, tc.Count)
+//	}
+func TopTerms(text string, n int) []TermCount {
+	counts := make(map[string]int)
+	for _, word := range Words(text) {
+		if stopWords[word] || isNumber(word) {
+			continue
+		}
+		counts[word]++
+	}
+
+	terms := make([]TermCount, 0, len(counts))
+	for term, count := range counts {
+		terms = append(terms, TermCount{Term: term, Count: count})
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+
+	if n >= 0 && len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms
+}
+
+// FindBuzzwords returns the buzzwords that occur in text with their counts,
+// most frequent first.
+//
+// Parameters:
+//   - text: The text to scan
+//
+// Returns:
+//   - []TermCount: The buzzwords found
+func FindBuzzwords(text string) []TermCount {
+	normalized := " " + strings.Join(Words(text), " ") + " "
+
+	var found []TermCount
+	for _, buzzword := range Buzzwords {
+		phrase := " " + strings.Join(Words(buzzword), " ") + " "
+		if count := strings.Count(normalized, phrase); count > 0 {
+			found = append(found, TermCount{Term: buzzword, Count: count})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Count > found[j].Count
+	})
+	return found
+}
+
+// CompareKeywords reports how well the resume covers the top n terms of a job description.
+//
+// Parameters:
+//   - resume: The resume text
+//   - jobDescription: The job description text
+//   - n: The number of job description terms to compare
+//
+// Returns:
+//   - []KeywordCoverage: Coverage of each job keyword, in job description frequency order
+func CompareKeywords(resume, jobDescription string, n int) []KeywordCoverage {
+	resumeCounts := make(map[string]int)
+	for _, word := range Words(resume) {
+		resumeCounts[word]++
+	}
+
+	var coverage []KeywordCoverage
+	for _, tc := range TopTerms(jobDescription, n) {
+		coverage = append(coverage, KeywordCoverage{
+			Term:        tc.Term,
+			JobCount:    tc.Count,
+			ResumeCount: resumeCounts[tc.Term],
+		})
+	}
+	return coverage
+}
+
+// MissingKeywords returns the keywords that do not appear in the resume.
+// Matching ignores case, punctuation, and Markdown formatting, and a
+// keyword of several words must appear as the same phrase.
+//
+// Parameters:
+//   - resume: The resume text
+//   - keywords: The keywords that were asked for
+//
+// Returns:
+//   - []string: The keywords not found, in the order given
+//
+// Example:
+//
+//	missing := analysis.MissingKeywords(resume, []string{"kubernetes", "gRPC"})
+//	// missing == []string{"gRPC"} when the resume never mentions gRPC
+func MissingKeywords(resume string, keywords []string) []string {
+	normalized := " " + strings.Join(Words(resume), " ") + " "
+
+	var missing []string
+	for _, keyword := range keywords {
+		words := Words(keyword)
+		if len(words) == 0 {
+			continue
+		}
+		if !strings.Contains(normalized, " "+strings.Join(words, " ")+" ") {
+			missing = append(missing, keyword)
+		}
+	}
+	return missing
+}
+
+// JobGaps returns the top terms of a job description that the resume never
+// mentions, the skills and experience the job asks for that the candidate
+// has not shown.
+//
+// Parameters:
+//   - resume: The resume text
+//   - jobDescription: The job description text
+//   - n: The number of job description terms to check
+//
+// Returns:
+//   - []string: The terms missing from the resume, in job description frequency order
+//
+// Example:
+//
+//	gaps := analysis.JobGaps(resume, jobDescription, analysis.GapTerms)
+//	// gaps == []string{"terraform", "aws"} when the resume mentions neither
+func JobGaps(resume, jobDescription string, n int) []string {
+	var gaps []string
+	for _, coverage := range CompareKeywords(resume, jobDescription, n) {
+		if !coverage.Covered() {
+			gaps = append(gaps, coverage.Term)
+		}
+	}
+	return gaps
+}
+
+// Analyze builds a complete Report for a resume. The job description is
+// optional; when it is empty, the report has no keyword coverage.
+//
+// Parameters:
+//   - resume: The resume text
+//   - jobDescription: The job description text (can be empty)
+//
+// Returns:
+//   - Report: The analysis results
+//
+// Example:
+//
+//	report := analysis.Analyze(resume, jobDescription)
+//	fmt.Printf("%d words, %d buzzwords\n", report.TotalWords, len(report.Buzzwords))
+func Analyze(resume, jobDescription string) Report {
+	report := Report{
+		TotalWords: len(Words(resume)),
+		TopTerms:   TopTerms(resume, DefaultTopTerms),
+		Buzzwords:  FindBuzzwords(resume),
+	}
+
+	if strings.TrimSpace(jobDescription) != "" {
+		report.Keywords = CompareKeywords(resume, jobDescription, DefaultTopTerms)
+	}
+
+	return report
+}
+
+// isNumber reports whether the word consists only of digits and separators.
+func isNumber(word string) bool {
+	return strings.Trim(word, "0123456789.-+#") == ""
+}
@@ -0,0 +1,126 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	got := Words("## Skills\n- **Go**, C++, Node.js and C#!")
+	want := []string{"skills", "go", "c++", "node.js", "and", "c#"}
+
+	if len(got) != len(want) {
+		t.Fatalf("Words() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Words()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTopTerms(t *testing.T) {
+	text := "Built Go services. Go and Kubernetes. Kubernetes operators in Go, 2021."
+	got := TopTerms(text, 2)
+
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 terms, got %v", got)
+	}
+	if got[0] != (TermCount{Term: "go", Count: 3}) {
+		t.Errorf("Expected go x3 first, got %+v", got[0])
+	}
+	if got[1] != (TermCount{Term: "kubernetes", Count: 2}) {
+		t.Errorf("Expected kubernetes x2 second, got %+v", got[1])
+	}
+}
+
+func TestFindBuzzwords(t *testing.T) {
+	text := "Results-driven team player. A true team player and self-starter."
+	got := FindBuzzwords(text)
+
+	expected := map[string]int{"team player": 2, "results-driven": 1, "self-starter": 1}
+	if len(got) != len(expected) {
+		t.Fatalf("FindBuzzwords() = %v", got)
+	}
+	if got[0].Term != "team player" {
+		t.Errorf("Expected most frequent buzzword first, got %q", got[0].Term)
+	}
+	for _, tc := range got {
+		if expected[tc.Term] != tc.Count {
+			t.Errorf("Buzzword %q: expected %d, got %d", tc.Term, expected[tc.Term], tc.Count)
+		}
+	}
+}
+
+func TestCompareKeywords(t *testing.T) {
+	job := "We need Kubernetes and Terraform experience. Kubernetes is core."
+	resume := "Operated Kubernetes clusters."
+
+	got := CompareKeywords(resume, job, 3)
+	if len(got) == 0 || got[0].Term != "kubernetes" {
+		t.Fatalf("Expected kubernetes as the top job keyword, got %v", got)
+	}
+	if !got[0].Covered() || got[0].ResumeCount != 1 || got[0].JobCount != 2 {
+		t.Errorf("Unexpected kubernetes coverage: %+v", got[0])
+	}
+
+	for _, kc := range got {
+		if kc.Term == "terraform" && kc.Covered() {
+			t.Error("Expected terraform to be missing from the resume")
+		}
+	}
+}
+
+func TestMissingKeywords(t *testing.T) {
+	resume := "## Skills\n- **Kubernetes**, Go\n\n## Experience\n- Led a team building gRPC services for distributed systems."
+
+	got := MissingKeywords(resume, []string{"kubernetes", "GRPC", "leadership", "Distributed Systems", "systems design"})
+	expected := []string{"leadership", "systems design"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v to be missing, got %v", expected, got)
+	}
+
+	if got := MissingKeywords(resume, nil); len(got) != 0 {
+		t.Errorf("Expected no missing keywords without keywords, got %v", got)
+	}
+}
+
+func TestJobGaps(t *testing.T) {
+	resume := "## Skills\n- Go, Kubernetes"
+	job := "Terraform and AWS experience. Terraform modules on AWS with Kubernetes and Go. Terraform daily."
+
+	got := JobGaps(resume, job, GapTerms)
+	expected := []string{"terraform", "aws", "daily", "experience", "modules"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v as gaps, got %v", expected, got)
+	}
+
+	if got := JobGaps(resume, "", GapTerms); len(got) != 0 {
+		t.Errorf("Expected no gaps without a job description, got %v", got)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Run("without job description", func(t *testing.T) {
+		report := Analyze("# Jane\n\n- Passionate Go engineer", "")
+		if report.TotalWords != 4 {
+			t.Errorf("Expected 4 words, got %d", report.TotalWords)
+		}
+		if len(report.Keywords) != 0 {
+			t.Error("Expected no keyword coverage without a job description")
+		}
+		if len(report.Buzzwords) != 1 {
+			t.Errorf("Expected 1 buzzword, got %v", report.Buzzwords)
+		}
+		if density := report.Density(TermCount{Count: 1}); density != 25 {
+			t.Errorf("Expected density 25, got %v", density)
+		}
+	})
+
+	t.Run("with job description", func(t *testing.T) {
+		report := Analyze("Go engineer", "Go developer")
+		if len(report.Keywords) != 2 {
+			t.Errorf("Expected 2 keywords, got %v", report.Keywords)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package analysis
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minLanguageEvidence is the weight of function words a text needs before
+// its language is named, so a few keywords or a name are not enough.
+const minLanguageEvidence = 4.0
+
+// languageMargin is how many times more evidence the most likely language
+// needs than the next, so notes mixing two languages are not named.
+const languageMargin = 2.0
+
+// functionWords are the short, common words that tell languages written in
+// the Latin alphabet apart. Resumes are terse, but their bullets still join
+// phrases with these words, while names and technical terms are shared.
+var functionWords = map[string][]string{
+	"English":    {"the", "and", "of", "to", "in", "for", "with", "on", "at", "by", "from", "as", "is", "was", "an", "my", "our", "which", "that", "while"},
+	"Spanish":    {"de", "la", "el", "y", "en", "los", "las", "del", "con", "para", "por", "una", "un", "que", "al", "como", "se", "mi"},
+	"French":     {"le", "la", "les", "de", "des", "du", "et", "en", "un", "une", "pour", "avec", "dans", "sur", "au", "aux", "par", "que", "l", "d"},
+	"German":     {"der", "die", "das", "und", "von", "mit", "für", "zu", "den", "dem", "des", "ein", "eine", "im", "auf", "bei", "als", "ich", "zur", "zum"},
+	"Portuguese": {"de", "da", "do", "das", "dos", "e", "em", "com", "para", "por", "um", "uma", "na", "no", "nas", "nos", "que", "ao"},
+	"Italian":    {"di", "il", "la", "e", "in", "con", "per", "del", "della", "dei", "delle", "un", "una", "che", "nel", "nella", "al", "alla"},
+	"Dutch":      {"de", "het", "en", "van", "een", "in", "met", "voor", "op", "bij", "als", "aan", "te", "naar", "door", "ik"},
+}
+
+// languageLetters are letters used by only one of the languages above.
+var languageLetters = map[rune]string{
+	'ñ': "Spanish",
+	'ã': "Portuguese",
+	'õ': "Portuguese",
+	'ß': "German",
+	'ä': "German",
+	'ö': "German",
+	'ü': "German",
+}
+
+// wordWeights gives each function word its weight for each language using
+// it: a word shared by several languages counts for less in each.
+var wordWeights = func() map[string]map[string]float64 {
+	weights := make(map[string]map[string]float64)
+	for language, words := range functionWords {
+		for _, word := range words {
+			if weights[word] == nil {
+				weights[word] = make(map[string]float64)
+			}
+			weights[word][language] = 1
+		}
+	}
+	for _, languages := range weights {
+		for language := range languages {
+			languages[language] = 1 / float64(len(languages))
+		}
+	}
+	return weights
+}()
+
+// DetectLanguage returns the dominant language of a text, named in English,
+// such as "Spanish" or "Japanese". Languages written in their own script are
+// told apart by it; those written in the Latin alphabet by their common
+// words. A text too short to tell, or mixing languages with none clearly
+// ahead, returns an empty string.
+//
+// Parameters:
+//   - text: The text to examine, such as notes or a Markdown resume
+//
+// Returns:
+//   - string: The language of the text, or "" if it cannot be told
+//
+// Example:
+//
+//	language := analysis.DetectLanguage("Dirigí el equipo de pagos y reduje los costos")
+//	// language == "Spanish"
+func DetectLanguage(text string) string {
+	if language := scriptLanguage(text); language != "" {
+		return language
+	}
+
+	scores := make(map[string]float64)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for language, weight := range wordWeights[word] {
+			scores[language] += weight
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		if language, ok := languageLetters[r]; ok {
+			scores[language] += 0.5
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0.0, 0.0
+	for language, score := range scores {
+		switch {
+		case score > bestScore || (score == bestScore && language < best):
+			best, bestScore, runnerUp = language, score, max(runnerUp, bestScore)
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore < minLanguageEvidence || bestScore < runnerUp*languageMargin {
+		return ""
+	}
+	return best
+}
+
+// scriptLanguage returns the language of a text mostly written in a script
+// other than Latin, or "" when most of its letters are Latin.
+func scriptLanguage(text string) string {
+	var latin, kana, han, hangul, cyrillic, ukrainian, arabic, greek, hebrew, devanagari, thai int
+	for _, r := range text {
+		switch {
+		case !unicode.IsLetter(r):
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune("іїєґІЇЄҐ", r) {
+				ukrainian++
+			}
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		case unicode.Is(unicode.Greek, r):
+			greek++
+		case unicode.Is(unicode.Hebrew, r):
+			hebrew++
+		case unicode.Is(unicode.Devanagari, r):
+			devanagari++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		}
+	}
+
+	// Technical terms in Latin letters are common in any resume, so the
+	// script only has to be the more common one
+	other := kana + han + hangul + cyrillic + arabic + greek + hebrew + devanagari + thai
+	if other == 0 || other < latin {
+		return ""
+	}
+	switch max(kana+han, hangul, cyrillic, arabic, greek, hebrew, devanagari, thai) {
+	case kana + han:
+		// Japanese mixes kana with Chinese characters; Chinese has no kana
+		if kana*10 >= kana+han {
+			return "Japanese"
+		}
+		return "Chinese"
+	case hangul:
+		return "Korean"
+	case cyrillic:
+		if ukrainian > 0 {
+			return "Ukrainian"
+		}
+		return "Russian"
+	case arabic:
+		return "Arabic"
+	case greek:
+		return "Greek"
+	case hebrew:
+		return "Hebrew"
+	case devanagari:
+		return "Hindi"
+	}
+	return "Thai"
+}
@@ -0,0 +1,29 @@
+package analysis
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"English resume", "## Experience\n\n- Led the payments team of 8 engineers and cut costs by 30%\n- Built the billing service in Go with Postgres on AWS", "English"},
+		{"Spanish notes", "Dirigí el equipo de pagos y reduje los costos un 30%. Desarrollé el servicio de facturación con Go para la empresa.", "Spanish"},
+		{"French notes", "J'ai dirigé l'équipe des paiements et réduit les coûts de 30 % avec une nouvelle architecture pour le client.", "French"},
+		{"German notes", "Leitung des Zahlungsteams mit acht Entwicklern und Aufbau der Abrechnung für den Konzern auf Basis von Go.", "German"},
+		{"Portuguese notes", "Liderei a equipe de pagamentos e reduzi os custos em 30% com uma nova arquitetura para o banco. Criei o serviço de cobrança do produto.", "Portuguese"},
+		{"Japanese notes", "決済チームを率いて、コストを30%削減しました。GoとAWSで請求サービスを構築しました。", "Japanese"},
+		{"Chinese notes", "带领支付团队，将成本降低了30%。使用Go和AWS构建了计费服务。", "Chinese"},
+		{"Russian notes", "Руководил командой платежей и снизил затраты на 30%. Разработал сервис на Go и AWS.", "Russian"},
+		{"Too short to tell", "Go, Kubernetes, AWS, Acme Corp", ""},
+		{"Two languages equally", "Led the team and built the service for the bank. Dirigí el equipo y construí el servicio para el banco.", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.text); got != tt.want {
+				t.Errorf("DetectLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
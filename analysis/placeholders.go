@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// bracketRegex matches text in square or angle brackets, with the character
+// after it so Markdown links, "[text](url)", can be told apart.
+var bracketRegex = regexp.MustCompile(`\[([^\[\]\n]{1,60})\](.?)|<([^<>\n]{1,60})>`)
+
+// bracketPlaceholderRegex matches the words of template fields the model
+// leaves for the reader to fill in, such as "[Your Name]" or "<Company>".
+var bracketPlaceholderRegex = regexp.MustCompile(`(?i)^(x+|n|#+|\.\.\.|…)$|\b(your|insert|add|enter|placeholder|names?|company|employer|dates?|years?|months?|city|location|phone|email|address|title|position|university|school|degree|details|number|metric|amount|percentage|link|url)\b`)
+
+// placeholderRegexes match placeholders outside brackets: filler text,
+// markers for work left to do, template fields, and metrics to fill in.
+var placeholderRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\blorem ipsum\b`),
+	regexp.MustCompile(`\b(TODO|TBD|FIXME|XXX)\b`),
+	regexp.MustCompile(`\{\{[^{}\n]{1,40}\}\}`),
+	regexp.MustCompile(`(?i)(^|[\s(])(\$X+|X+%|X+ percent)`),
+}
+
+// FindPlaceholders returns the placeholders left in generated text, such as
+// "[Your Name]", "[Company]", "lorem ipsum", "TODO", or "X%", in the order
+// they first appear and without repeats. Markdown links and bracketed text
+// that is not a template field, such as "[Go]", are not placeholders.
+//
+// Parameters:
+//   - text: The text to scan, such as a resume or one of its sections
+//
+// Returns:
+//   - []string: The placeholders found, or nil if there are none
+//
+// Example:
+//
+//	placeholders := analysis.FindPlaceholders("# [Your Name]\n\n- Grew revenue by X%")
+//	// placeholders == []string{"[Your Name]", "X%"}
+func FindPlaceholders(text string) []string {
+	type found struct {
+		start int
+		text  string
+	}
+	var matches []found
+
+	for _, m := range bracketRegex.FindAllStringSubmatchIndex(text, -1) {
+		inner, next := "", ""
+		if m[2] >= 0 {
+			inner, next = text[m[2]:m[3]], text[m[4]:m[5]]
+		} else {
+			inner = text[m[6]:m[7]]
+		}
+		// Links and autolinks, such as [site](url) and <https://...>, are not placeholders
+		if next == "(" || strings.Contains(inner, "://") || strings.Contains(inner, "@") {
+			continue
+		}
+		if bracketPlaceholderRegex.MatchString(strings.TrimSpace(inner)) {
+			end := m[1]
+			if next != "" {
+				end -= len(next)
+			}
+			matches = append(matches, found{m[0], text[m[0]:end]})
+		}
+	}
+	for _, re := range placeholderRegexes {
+		for _, m := range re.FindAllStringIndex(text, -1) {
+			match := strings.TrimLeft(text[m[0]:m[1]], " \t\n(")
+			matches = append(matches, found{m[1] - len(match), match})
+		}
+	}
+
+	// Report placeholders in reading order, each once
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+	var placeholders []string
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if key := strings.ToLower(m.text); !seen[key] {
+			seen[key] = true
+			placeholders = append(placeholders, m.text)
+		}
+	}
+	return placeholders
+}
@@ -0,0 +1,27 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindPlaceholders(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"template fields", "# [Your Name]\n\n[City, State] | <email>\n\n**Engineer** | [Company Name] | [Dates]", []string{"[Your Name]", "[City, State]", "<email>", "[Company Name]", "[Dates]"}},
+		{"filler and markers", "## Summary\n\nLorem ipsum dolor sit amet.\n\n## Projects\n\nTODO: add projects\nTBD", []string{"Lorem ipsum", "TODO", "TBD"}},
+		{"metrics to fill in", "- Grew revenue by X% and saved $XX (XX%) for {{client}}", []string{"X%", "$XX", "XX%", "{{client}}"}},
+		{"repeats reported once", "[More details would be added based on input]\n[more details would be added based on input]", []string{"[More details would be added based on input]"}},
+		{"links and plain brackets", "[Portfolio](https://jane.dev) <https://jane.dev> <jane@example.com> [Go] - Todo app in Go, 3x faster", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindPlaceholders(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindPlaceholders() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package analysis
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// minExperienceWords is the fewest meaningful words a line needs to count as
+// an experience, so headings, dates, and contact details are skipped.
+const minExperienceWords = 4
+
+// maxRelevanceTerms is the number of shared terms kept for each experience.
+const maxRelevanceTerms = 3
+
+// listMarkerRegex matches the bullet or number that starts a list item.
+var listMarkerRegex = regexp.MustCompile(`^(?:[-*+•]|\d+[.)])\s+`)
+
+// RankedExperience is one of the candidate's experiences scored against a
+// job description.
+type RankedExperience struct {
+	Text  string   // The experience, as written in the inputs
+	Score float64  // Cosine similarity of its TF-IDF weights to the job description's, from 0 to 1
+	Terms []string // The shared terms that matched most, strongest first
+}
+
+// Experiences splits resumes and notes into the candidate's experiences: the
+// list items and lines of text with enough words to describe something done.
+// Headings, short lines such as dates and contact details, and repeated
+// lines are skipped.
+//
+// Parameters:
+//   - texts: The existing resume, the notes, or other text about the candidate
+//
+// Returns:
+//   - []string: The experiences, in the order they appear
+//
+// Example:
+//
+//	experiences := analysis.Experiences(sourceResume, notes)
+func Experiences(texts ...string) []string {
+	var experiences []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			line = listMarkerRegex.ReplaceAllString(line, "")
+			if len(meaningfulWords(line)) < minExperienceWords || seen[strings.ToLower(line)] {
+				continue
+			}
+			seen[strings.ToLower(line)] = true
+			experiences = append(experiences, line)
+		}
+	}
+	return experiences
+}
+
+// RankExperiences scores each experience by how closely its wording matches
+// the job description, using TF-IDF weights computed on this computer, and
+// returns the ones that share any terms with it, most relevant first. Terms
+// that occur in many experiences, such as the candidate's usual verbs, weigh
+// less than distinctive ones.
+//
+// Parameters:
+//   - experiences: The candidate's experiences, as returned by Experiences
+//   - jobDescription: The job description text
+//
+// Returns:
+//   - []RankedExperience: The matching experiences, most relevant first
+//
+// Example:
+//
+//	for _, ranked := range analysis.RankExperiences(experiences, job) {
+//	    fmt.Printf("%.2f %s (%s)\n", ranked.Score, ranked.Text, strings.Join(ranked.Terms, ", "))
+//	}
+func RankExperiences(experiences []string, jobDescription string) []RankedExperience {
+	if len(experiences) == 0 || strings.TrimSpace(jobDescription) == "" {
+		return nil
+	}
+
+	// The job description is one more document, so its terms have an IDF too
+	documents := make([][]string, 0, len(experiences)+1)
+	for _, experience := range experiences {
+		documents = append(documents, meaningfulWords(experience))
+	}
+	documents = append(documents, meaningfulWords(jobDescription))
+
+	frequency := make(map[string]int)
+	for _, words := range documents {
+		counted := make(map[string]bool)
+		for _, word := range words {
+			if !counted[word] {
+				frequency[word]++
+				counted[word] = true
+			}
+		}
+	}
+	weights := func(words []string) map[string]float64 {
+		vector := make(map[string]float64)
+		for _, word := range words {
+			vector[word]++
+		}
+		for word, count := range vector {
+			idf := math.Log(float64(len(documents)+1)/float64(frequency[word]+1)) + 1
+			vector[word] = count / float64(len(words)) * idf
+		}
+		return vector
+	}
+
+	job := weights(documents[len(documents)-1])
+	var ranked []RankedExperience
+	for i, experience := range experiences {
+		vector := weights(documents[i])
+		var shared []string
+		contribution := make(map[string]float64)
+		for word, weight := range vector {
+			if jobWeight, ok := job[word]; ok {
+				contribution[word] = weight * jobWeight
+				shared = append(shared, word)
+			}
+		}
+		if len(shared) == 0 {
+			continue
+		}
+
+		// Summed in a fixed order, so the scores are the same on every run
+		sort.Strings(shared)
+		var dot float64
+		for _, word := range shared {
+			dot += contribution[word]
+		}
+		sort.SliceStable(shared, func(a, b int) bool {
+			return contribution[shared[a]] > contribution[shared[b]]
+		})
+		ranked = append(ranked, RankedExperience{
+			Text:  experience,
+			Score: dot / (norm(vector) * norm(job)),
+			Terms: shared[:min(len(shared), maxRelevanceTerms)],
+		})
+	}
+
+	sort.SliceStable(ranked, func(a, b int) bool {
+		return ranked[a].Score > ranked[b].Score
+	})
+	return ranked
+}
+
+// meaningfulWords returns the words of text, leaving out stop words and
+// numbers, as TopTerms counts them.
+func meaningfulWords(text string) []string {
+	var words []string
+	for _, word := range Words(text) {
+		if !stopWords[word] && !isNumber(word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// norm returns the Euclidean length of a weight vector, summing the weights
+// in a fixed order so the result is the same on every run.
+func norm(vector map[string]float64) float64 {
+	words := make([]string, 0, len(vector))
+	for word := range vector {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	var sum float64
+	for _, word := range words {
+		sum += vector[word] * vector[word]
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,50 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExperiences(t *testing.T) {
+	resume := "# Jane Doe\njane@example.com | 555-0100\n\n## Experience\n\n### Acme, 2020 - 2024\n- Built the Kubernetes deployment pipeline for payments\n* Led a team of five engineers\n1. Mentored new hires in code review practices"
+	notes := "Built the Kubernetes deployment pipeline for payments\nAlso wrote the on-call runbooks for the platform team"
+
+	want := []string{
+		"Built the Kubernetes deployment pipeline for payments",
+		"Led a team of five engineers",
+		"Mentored new hires in code review practices",
+		"Also wrote the on-call runbooks for the platform team",
+	}
+	if got := Experiences(resume, notes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Experiences() = %q, want %q", got, want)
+	}
+}
+
+func TestRankExperiences(t *testing.T) {
+	experiences := []string{
+		"Designed marketing campaigns for retail clients",
+		"Built the Kubernetes deployment pipeline in Go",
+		"Migrated services to Kubernetes and cut deploy times",
+	}
+	job := "We need a platform engineer with Kubernetes and Go experience to own our deployment pipeline."
+
+	// Test case 1: Experiences sharing the job's terms are ranked, the closest first
+	ranked := RankExperiences(experiences, job)
+	if len(ranked) != 2 {
+		t.Fatalf("Expected the two matching experiences, got %+v", ranked)
+	}
+	if ranked[0].Text != experiences[1] || ranked[1].Text != experiences[2] {
+		t.Errorf("Unexpected order %+v", ranked)
+	}
+	if ranked[0].Score <= ranked[1].Score || ranked[0].Score > 1 {
+		t.Errorf("Expected descending scores between 0 and 1, got %v and %v", ranked[0].Score, ranked[1].Score)
+	}
+	if !reflect.DeepEqual(ranked[1].Terms, []string{"kubernetes"}) {
+		t.Errorf("Expected the shared term, got %v", ranked[1].Terms)
+	}
+
+	// Test case 2: Without a job description nothing is ranked
+	if ranked := RankExperiences(experiences, "  "); ranked != nil {
+		t.Errorf("Expected no ranking, got %+v", ranked)
+	}
+}
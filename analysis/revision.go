@@ -0,0 +1,292 @@
+package analysis
+
+import (
+	"strings"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// headerHeading names the part of a resume above its first section, such as
+// the contact details, in a revision diff.
+const headerHeading = "Header"
+
+// similarThreshold is the share of words a removed and an added item must
+// have in common to be shown as one reworded item rather than two.
+const similarThreshold = 0.5
+
+// SectionChange describes how one section differs between two revisions of
+// a resume.
+type SectionChange struct {
+	// Heading is the section's heading, as written in the newer revision
+	// when it is in both.
+	Heading string
+
+	// Kind is DiffSame when no item changed, DiffChanged when some did,
+	// DiffOnlyA when the section was removed, and DiffOnlyB when it was added.
+	Kind DiffKind
+
+	// Items compares the section's bullets, subheadings, and paragraphs, with
+	// A from the older revision and B from the newer one.
+	Items []DiffRow
+}
+
+// CompareRevisions compares two revisions of a resume section by section and
+// item by item, rather than line by line. Sections are matched by heading,
+// whatever their order. Within a section each bullet, subheading, or
+// paragraph is one item; items that differ only in formatting, spacing, or
+// case count as the same, and a removed item that shares most of its words
+// with an added one is shown as reworded.
+//
+// Sections are listed in the newer revision's order, followed by any
+// sections that were removed. The text above the first section, such as the
+// contact details, is compared as a section named "Header".
+//
+// Parameters:
+//   - older: The earlier revision in Markdown
+//   - newer: The later revision in Markdown
+//
+// Returns:
+//   - []SectionChange: One entry per section in either revision
+//
+// Example:
+//
+//	changes := analysis.CompareRevisions(previous.Content, generated)
+//	for _, change := range changes {
+//	    fmt.Println(change.Heading, change.Kind)
+//	}
+func CompareRevisions(older, newer string) []SectionChange {
+	oldSections := revisionSections(older)
+	newSections := revisionSections(newer)
+
+	oldByHeading := make(map[string]document.Section)
+	for _, section := range oldSections {
+		oldByHeading[headingKey(section.Heading)] = section
+	}
+
+	var changes []SectionChange
+	matched := make(map[string]bool)
+	for _, section := range newSections {
+		key := headingKey(section.Heading)
+		previous, ok := oldByHeading[key]
+		if !ok || matched[key] {
+			changes = append(changes, SectionChange{Heading: section.Heading, Kind: DiffOnlyB, Items: diffItems(nil, sectionItems(section.Body))})
+			continue
+		}
+		matched[key] = true
+
+		change := SectionChange{Heading: section.Heading, Items: diffItems(sectionItems(previous.Body), sectionItems(section.Body))}
+		if CountDifferences(change.Items) > 0 {
+			change.Kind = DiffChanged
+		}
+		changes = append(changes, change)
+	}
+
+	for _, section := range oldSections {
+		if key := headingKey(section.Heading); !matched[key] {
+			matched[key] = true
+			changes = append(changes, SectionChange{Heading: section.Heading, Kind: DiffOnlyA, Items: diffItems(sectionItems(section.Body), nil)})
+		}
+	}
+	return changes
+}
+
+// CountChangedItems returns the number of items that differ across all
+// sections.
+//
+// Parameters:
+//   - changes: The sections returned by CompareRevisions
+//
+// Returns:
+//   - int: The number of reworded, removed, and added items
+func CountChangedItems(changes []SectionChange) int {
+	count := 0
+	for _, change := range changes {
+		count += CountDifferences(change.Items)
+	}
+	return count
+}
+
+// revisionSections returns the sections of a resume, with the name and the
+// text above the first section as a leading Header section.
+func revisionSections(markdown string) []document.Section {
+	resume := document.Parse(markdown)
+	header := strings.TrimSpace("# " + resume.Name + "\n" + resume.Header)
+	if resume.Name == "" {
+		header = resume.Header
+	}
+
+	sections := resume.Sections
+	if header != "" {
+		sections = append([]document.Section{{Heading: headerHeading, Body: header}}, sections...)
+	}
+	return sections
+}
+
+// sectionItems splits a section body into items: each list item with its
+// continuation lines, each heading, and each paragraph.
+func sectionItems(body string) []string {
+	var items []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			items = append(items, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			items = append(items, trimmed)
+		case isListItem(trimmed):
+			flush()
+			current = append(current, trimmed)
+		default:
+			current = append(current, trimmed)
+		}
+	}
+	flush()
+	return items
+}
+
+// isListItem reports whether a trimmed line starts a Markdown list item.
+func isListItem(line string) bool {
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, marker) {
+			return true
+		}
+	}
+	digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+	return digits > 0 && (strings.HasPrefix(line[digits:], ". ") || strings.HasPrefix(line[digits:], ") "))
+}
+
+// diffItems compares two lists of items by their normalized text. Runs of
+// removed and added items are paired up when they are similar, so a
+// reworded bullet is shown as changed; the rest are shown as removed or added.
+func diffItems(older, newer []string) []DiffRow {
+	oldKeys := make([]string, len(older))
+	for i, item := range older {
+		oldKeys[i] = itemKey(item)
+	}
+	newKeys := make([]string, len(newer))
+	for i, item := range newer {
+		newKeys[i] = itemKey(item)
+	}
+
+	var rows []DiffRow
+	var removed, added []int
+	flush := func() {
+		rows = append(rows, pairSimilar(older, newer, removed, added)...)
+		removed, added = nil, nil
+	}
+
+	for _, op := range diffKeys(oldKeys, newKeys) {
+		switch op.kind {
+		case DiffSame:
+			flush()
+			rows = append(rows, DiffRow{Kind: DiffSame, A: older[op.a], B: newer[op.b]})
+		case DiffOnlyA:
+			removed = append(removed, op.a)
+		default:
+			added = append(added, op.b)
+		}
+	}
+	flush()
+	return rows
+}
+
+// pairSimilar turns a run of removed and added items into rows. Each added
+// item is paired with the most similar removed item still unpaired, if they
+// are similar enough. Unpaired removed items come first, then the added and
+// reworded items in their new order.
+func pairSimilar(older, newer []string, removed, added []int) []DiffRow {
+	pairs := make(map[int]int)
+	used := make(map[int]bool)
+	for _, j := range added {
+		best, bestScore := -1, similarThreshold
+		for _, i := range removed {
+			if used[i] {
+				continue
+			}
+			if score := similarity(older[i], newer[j]); score >= bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 {
+			pairs[j] = best
+			used[best] = true
+		}
+	}
+
+	var rows []DiffRow
+	for _, i := range removed {
+		if !used[i] {
+			rows = append(rows, DiffRow{Kind: DiffOnlyA, A: older[i]})
+		}
+	}
+	for _, j := range added {
+		if i, ok := pairs[j]; ok {
+			rows = append(rows, DiffRow{Kind: DiffChanged, A: older[i], B: newer[j]})
+		} else {
+			rows = append(rows, DiffRow{Kind: DiffOnlyB, B: newer[j]})
+		}
+	}
+	return rows
+}
+
+// similarity returns the share of distinct words two items have in common,
+// from 0 for none to 1 for all.
+func similarity(a, b string) float64 {
+	wordsA := strings.Fields(itemKey(a))
+	wordsB := strings.Fields(itemKey(b))
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool)
+	for _, word := range wordsA {
+		set[word] = true
+	}
+	union := len(set)
+	common := 0
+	seen := make(map[string]bool)
+	for _, word := range wordsB {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		if set[word] {
+			common++
+		} else {
+			union++
+		}
+	}
+	return float64(common) / float64(union)
+}
+
+// itemKey normalizes an item for comparison: list markers, emphasis, and
+// punctuation at the ends of words are dropped, spacing is collapsed, and
+// letters are lowered.
+func itemKey(item string) string {
+	item = strings.TrimSpace(item)
+	if isListItem(item) {
+		item = strings.TrimSpace(item[strings.Index(item, " "):])
+	}
+	item = strings.NewReplacer("**", "", "__", "", "`", "").Replace(item)
+
+	words := strings.Fields(strings.ToLower(item))
+	for i, word := range words {
+		words[i] = strings.Trim(word, ".,;:!?*_()[]\"'")
+	}
+	return strings.Join(words, " ")
+}
+
+// headingKey normalizes a heading so sections match whatever their case,
+// spacing, or emphasis.
+func headingKey(heading string) string {
+	return itemKey(heading)
+}
@@ -0,0 +1,126 @@
+package analysis
+
+import (
+	"testing"
+)
+
+const olderRevision = `# Jane Doe
+
+jane@example.com
+
+## Experience
+
+### Engineer, Acme (2020 - 2023)
+- Built the checkout service in Go
+- Mentored two junior engineers
+- Wrote internal documentation
+
+## Education
+
+- BS Computer Science, State University
+
+## Hobbies
+
+- Chess
+`
+
+const newerRevision = `# Jane Doe
+
+jane@example.com
+
+## Summary
+
+Backend engineer focused on payments.
+
+## Experience
+
+### Engineer, Acme (2020 - 2023)
+- **Built** the checkout service in Go.
+- Mentored three junior engineers
+- Cut p99 latency by 40%
+
+## Education
+
+- BS Computer Science, State University
+`
+
+func TestCompareRevisions(t *testing.T) {
+	changes := CompareRevisions(olderRevision, newerRevision)
+
+	byHeading := make(map[string]SectionChange)
+	var headings []string
+	for _, change := range changes {
+		byHeading[change.Heading] = change
+		headings = append(headings, change.Heading)
+	}
+
+	// Test case 1: Sections follow the newer order, then removed sections
+	expected := []string{"Header", "Summary", "Experience", "Education", "Hobbies"}
+	if len(headings) != len(expected) {
+		t.Fatalf("Expected sections %v, got %v", expected, headings)
+	}
+	for i := range expected {
+		if headings[i] != expected[i] {
+			t.Fatalf("Expected sections %v, got %v", expected, headings)
+		}
+	}
+
+	// Test case 2: Unchanged sections are the same
+	if byHeading["Header"].Kind != DiffSame || byHeading["Education"].Kind != DiffSame {
+		t.Errorf("Expected the header and education to be unchanged, got %v and %v", byHeading["Header"].Kind, byHeading["Education"].Kind)
+	}
+
+	// Test case 3: Added and removed sections are marked as such
+	if byHeading["Summary"].Kind != DiffOnlyB || byHeading["Hobbies"].Kind != DiffOnlyA {
+		t.Errorf("Expected Summary added and Hobbies removed, got %v and %v", byHeading["Summary"].Kind, byHeading["Hobbies"].Kind)
+	}
+
+	// Test case 4: Bullets are compared individually, ignoring formatting
+	experience := byHeading["Experience"]
+	if experience.Kind != DiffChanged {
+		t.Fatalf("Expected Experience to have changed, got %v", experience.Kind)
+	}
+	var kinds []DiffKind
+	for _, row := range experience.Items {
+		kinds = append(kinds, row.Kind)
+	}
+	expectedKinds := []DiffKind{DiffSame, DiffSame, DiffOnlyA, DiffChanged, DiffOnlyB}
+	if len(kinds) != len(expectedKinds) {
+		t.Fatalf("Expected item kinds %v, got %v (%+v)", expectedKinds, kinds, experience.Items)
+	}
+	for i := range expectedKinds {
+		if kinds[i] != expectedKinds[i] {
+			t.Fatalf("Expected item kinds %v, got %v (%+v)", expectedKinds, kinds, experience.Items)
+		}
+	}
+	reworded := experience.Items[3]
+	if reworded.A != "- Mentored two junior engineers" || reworded.B != "- Mentored three junior engineers" {
+		t.Errorf("Expected the mentoring bullet to be reworded, got %+v", reworded)
+	}
+
+	// Test case 5: Every differing item is counted
+	if got := CountChangedItems(changes); got != 5 {
+		t.Errorf("Expected 5 changed items, got %d", got)
+	}
+}
+
+func TestCompareRevisionsIdentical(t *testing.T) {
+	changes := CompareRevisions(olderRevision, olderRevision)
+	if CountChangedItems(changes) != 0 {
+		t.Errorf("Expected identical revisions to have no changes, got %+v", changes)
+	}
+	for _, change := range changes {
+		if change.Kind != DiffSame {
+			t.Errorf("Expected %s to be unchanged, got %v", change.Heading, change.Kind)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := similarity("- Built the API", "- **Built** the API."); got != 1 {
+		t.Errorf("Expected formatting to be ignored, got %v", got)
+	}
+	if got := similarity("Built the API", "Led hiring"); got != 0 {
+		t.Errorf("Expected unrelated items to share nothing, got %v", got)
+	}
+}
@@ -0,0 +1,151 @@
+package analysis
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ToneKind is why a phrase reads as unprofessional.
+type ToneKind string
+
+const (
+	// ToneInformal is conversational wording, such as "a lot of".
+	ToneInformal ToneKind = "informal"
+
+	// ToneSlang is slang or a jokey job title, such as "crushed it" or "rockstar".
+	ToneSlang ToneKind = "slang"
+
+	// ToneNegative is wording that dwells on failure or speaks badly of others.
+	ToneNegative ToneKind = "negative"
+
+	// ToneProfanity is a swear word.
+	ToneProfanity ToneKind = "profanity"
+)
+
+// TonePhrase is a phrase the tone check flags, with a professional alternative.
+type TonePhrase struct {
+	Phrase     string   // The phrase, matched as whole words ignoring case and punctuation
+	Kind       ToneKind // Why the phrase is flagged
+	Suggestion string   // What to write instead
+}
+
+// TonePhrases lists the phrases CheckTone flags.
+var TonePhrases = []TonePhrase{
+	{"a lot of", ToneInformal, `"many", "extensive", or a number`},
+	{"lots of", ToneInformal, `"many", "extensive", or a number`},
+	{"tons of", ToneInformal, `"many", "extensive", or a number`},
+	{"stuff", ToneInformal, "name the systems, tools, or tasks"},
+	{"kind of", ToneInformal, "remove it, or state the point directly"},
+	{"sort of", ToneInformal, "remove it, or state the point directly"},
+	{"pretty much", ToneInformal, `"nearly" or "almost"`},
+	{"basically", ToneInformal, "remove it"},
+	{"gonna", ToneInformal, `"going to", or the past tense for past work`},
+	{"wanna", ToneInformal, `"want to"`},
+	{"awesome", ToneInformal, `"excellent" or "outstanding"`},
+	{"cool", ToneInformal, `"effective" or "innovative"`},
+	{"got promoted", ToneInformal, `"was promoted" or "earned a promotion"`},
+	{"crushed it", ToneSlang, "state the result, such as the target met and by how much"},
+	{"killed it", ToneSlang, "state the result, such as the target met and by how much"},
+	{"nailed it", ToneSlang, "state the result, such as the target met and by how much"},
+	{"rockstar", ToneSlang, "the actual job title, such as \"senior engineer\""},
+	{"rock star", ToneSlang, "the actual job title, such as \"senior engineer\""},
+	{"ninja", ToneSlang, `"expert" or the actual job title`},
+	{"guru", ToneSlang, `"expert" or the actual job title`},
+	{"10x engineer", ToneSlang, "the outcomes that show the impact"},
+	{"hustle", ToneSlang, `"drive" or "initiative"`},
+	{"was fired", ToneNegative, "leave it out, or describe the move neutrally"},
+	{"got fired", ToneNegative, "leave it out, or describe the move neutrally"},
+	{"failed to", ToneNegative, "describe what was achieved or learned"},
+	{"unfortunately", ToneNegative, "remove it and state the facts"},
+	{"hated", ToneNegative, "leave out feelings about past work"},
+	{"toxic", ToneNegative, "leave out criticism of past employers"},
+	{"incompetent", ToneNegative, "leave out criticism of colleagues"},
+	{"lazy", ToneNegative, "leave out criticism of colleagues"},
+	{"terrible", ToneNegative, `"challenging", or describe the problem`},
+	{"horrible", ToneNegative, `"challenging", or describe the problem`},
+	{"blamed", ToneNegative, "describe what was fixed, not who was at fault"},
+	{"damn", ToneProfanity, "remove it"},
+	{"crap", ToneProfanity, "remove it"},
+	{"crappy", ToneProfanity, `"poor" or "unreliable"`},
+	{"shit", ToneProfanity, "remove it"},
+	{"shitty", ToneProfanity, `"poor" or "unreliable"`},
+	{"bullshit", ToneProfanity, "remove it"},
+	{"fuck", ToneProfanity, "remove it"},
+	{"fucking", ToneProfanity, "remove it"},
+	{"badass", ToneProfanity, `"exceptional" or "highly skilled"`},
+	{"kick-ass", ToneProfanity, `"exceptional" or "highly skilled"`},
+	{"pissed", ToneProfanity, `"frustrated", or leave it out`},
+}
+
+// ToneIssue is a flagged phrase found in a resume.
+type ToneIssue struct {
+	TonePhrase
+	Text string // The phrase as written in the resume
+	Line int    // The line the phrase ends on, counting from 1
+}
+
+// CheckTone finds informal, slang, negative, and profane phrases in text.
+// Phrases are matched as whole words, ignoring case, punctuation, and
+// Markdown formatting, and may be split across lines, as in wrapped text.
+//
+// Parameters:
+//   - text: The resume, as Markdown or rendered plain text
+//
+// Returns:
+//   - []ToneIssue: The phrases found, in the order they appear
+//
+// Example:
+//
+//	for _, issue := range analysis.CheckTone(resume) {
+//	    fmt.Printf("line %d: %q is %s; try %s\n", issue.Line, issue.Text, issue.Kind, issue.Suggestion)
+//	}
+func CheckTone(text string) []ToneIssue {
+	lower := strings.ToLower(text)
+	spans := wordRegex.FindAllStringIndex(lower, -1)
+	if len(lower) != len(text) {
+		// Lowercasing changed the length of some characters, so quote the lowercase text
+		text = lower
+	}
+
+	words := make([]string, len(spans))
+	lines := make([]int, len(spans))
+	line, scanned := 1, 0
+	for i, span := range spans {
+		line += strings.Count(lower[scanned:span[0]], "\n")
+		scanned = span[0]
+		words[i], lines[i] = lower[span[0]:span[1]], line
+	}
+
+	phrases := make([][]string, len(TonePhrases))
+	for i, phrase := range TonePhrases {
+		phrases[i] = Words(phrase.Phrase)
+	}
+	// Longer phrases are tried first, so the whole of an overlapping phrase is flagged
+	order := make([]int, len(TonePhrases))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(phrases[order[a]]) > len(phrases[order[b]])
+	})
+
+	var issues []ToneIssue
+	for i := 0; i < len(words); i++ {
+		for _, p := range order {
+			phrase := phrases[p]
+			if i+len(phrase) > len(words) || !slices.Equal(words[i:i+len(phrase)], phrase) {
+				continue
+			}
+			last := i + len(phrase) - 1
+			issues = append(issues, ToneIssue{
+				TonePhrase: TonePhrases[p],
+				Text:       strings.Join(strings.Fields(text[spans[i][0]:spans[last][1]]), " "),
+				Line:       lines[last],
+			})
+			i = last
+			break
+		}
+	}
+	return issues
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckTone(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []ToneIssue
+	}{
+		{
+			name: "professional text",
+			text: "# Jane Doe\n\n- Led a team of 5 engineers\n- Cut costs by 20%",
+			want: nil,
+		},
+		{
+			name: "phrases of each kind, in order",
+			text: "- Did **a lot of** testing\n- Basically a rockstar\n- Unfortunately the project was cancelled\n- Fixed crappy code",
+			want: []ToneIssue{
+				{TonePhrase: TonePhrase{"a lot of", ToneInformal, `"many", "extensive", or a number`}, Text: "a lot of", Line: 1},
+				{TonePhrase: TonePhrase{"basically", ToneInformal, "remove it"}, Text: "Basically", Line: 2},
+				{TonePhrase: TonePhrase{"rockstar", ToneSlang, "the actual job title, such as \"senior engineer\""}, Text: "rockstar", Line: 2},
+				{TonePhrase: TonePhrase{"unfortunately", ToneNegative, "remove it and state the facts"}, Text: "Unfortunately", Line: 3},
+				{TonePhrase: TonePhrase{"crappy", ToneProfanity, `"poor" or "unreliable"`}, Text: "crappy", Line: 4},
+			},
+		},
+		{
+			name: "phrase wrapped across lines",
+			text: "Owned the release and crushed\nit every quarter",
+			want: []ToneIssue{
+				{TonePhrase: TonePhrase{"crushed it", ToneSlang, "state the result, such as the target met and by how much"}, Text: "crushed it", Line: 2},
+			},
+		},
+		{
+			name: "whole words only",
+			text: "Built a coolant monitor and a Shitake ordering app",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckTone(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckTone() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
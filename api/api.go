@@ -10,21 +10,132 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 
 	"github.com/google/generative-ai-go/genai"
-	"google.golang.org/api/option"
 )
 
 // DefaultModelName is the identifier for the specific Gemini model version used.
 // This model is optimized for resume generation with strong text formatting capabilities.
 const DefaultModelName = "gemini-2.5-pro-exp-03-25"
 
+// DefaultFallbackModelName is the model retried once when the primary model fails
+// with a quota, server, or availability error. It is smaller and more widely
+// available than DefaultModelName.
+const DefaultFallbackModelName = "gemini-1.5-flash"
+
+// MaxOutputTokens is the output token limit requested for every response.
+// Prompts are trimmed so this many tokens remain free in the context window.
+const MaxOutputTokens = 8192
+
+// DefaultContextWindow is the context window assumed for models missing from
+// contextWindows. It is deliberately small so unknown models are not overrun.
+const DefaultContextWindow = 32768
+
+// contextWindows maps model name prefixes to their context window in tokens.
+// The longest matching prefix wins.
+var contextWindows = map[string]int{
+	"gemini-1.0-pro":   32760,
+	"gemini-1.5-flash": 1048576,
+	"gemini-1.5-pro":   2097152,
+	"gemini-2.0-flash": 1048576,
+	"gemini-2.5-pro":   1048576,
+	"gemini-2.5-flash": 1048576,
+}
+
+// ContextWindow returns the number of tokens, input and output combined,
+// that modelName accepts, or DefaultContextWindow for unknown models.
+//
+// Parameters:
+//   - modelName: The Gemini model identifier
+//
+// Returns:
+//   - int: The context window in tokens
+func ContextWindow(modelName string) int {
+	window, matched := DefaultContextWindow, 0
+	for prefix, size := range contextWindows {
+		if strings.HasPrefix(modelName, prefix) && len(prefix) > matched {
+			window, matched = size, len(prefix)
+		}
+	}
+	return window
+}
+
+// ResumeStartDelimiter marks the beginning of the resume in the model's response.
+// The model is instructed to place the resume between ResumeStartDelimiter and
+// ResumeEndDelimiter so that any conversational preamble can be discarded.
+const ResumeStartDelimiter = "<<<RESUME_START>>>"
+
+// ResumeEndDelimiter marks the end of the resume in the model's response.
+// It is also registered as a stop sequence, so generation halts as soon as the
+// model emits it and any trailing commentary is never produced.
+const ResumeEndDelimiter = "<<<RESUME_END>>>"
+
 // SystemInstructions defines the system instructions for the resume generation model.
 // These instructions guide the model to generate professional resumes in Markdown format
 // based on the user's input, without fabricating information not present in the input.
 const SystemInstructions = `You are an expert resume writing assistant. Your goal is to synthesize the provided existing resume information (if any) and the raw stream-of-consciousness input into a single, coherent, professional resume formatted strictly in Markdown.
 
-Prioritize clarity, conciseness, and professional language. Structure the output logically with clear headings (e.g., Summary, Experience, Projects, Skills, Education). Infer structure and dates where possible, but do not fabricate information not present in the inputs. Focus on elevating the user's actual experience. Ensure the final output is only Markdown content.`
+Prioritize clarity, conciseness, and professional language. Structure the output logically with clear headings (e.g., Summary, Experience, Projects, Skills, Education). Infer structure and dates where possible, but do not fabricate information not present in the inputs. Focus on elevating the user's actual experience. Ensure the final output is only Markdown content.
+
+Begin the resume with the line ` + ResumeStartDelimiter + ` and end it with the line ` + ResumeEndDelimiter + `. Do not write anything before or after these delimiters.`
+
+// CoverLetterInstructions defines the system instructions for the cover letter model.
+// The cover letter is generated after the resume and must stay consistent with it,
+// so the instructions forbid introducing facts that appear in neither the resume
+// nor the user's notes.
+const CoverLetterInstructions = `You are an expert career coach who writes concise, compelling cover letters. You will be given a finished resume together with the raw notes it was generated from. Write a one-page cover letter that matches the resume's tone, highlights the two or three strongest achievements, and stays fully consistent with it.
+
+Do not fabricate employers, dates, metrics, or skills that do not appear in the inputs. Format the letter in Markdown, starting with a level-one heading, and output only the letter itself.`
+
+// RewriteInstructions defines the system instructions for the bullet rewriting model.
+// Rewrites must not invent numbers, so any metric the bullet lacks is left as a
+// bracketed placeholder with a question that prompts the user to fill it in.
+const RewriteInstructions = `You are an expert resume editor. You will be given a single resume bullet point. Rewrite it into stronger alternatives that lead with a specific action verb, state the outcome, and quantify the impact.
+
+Do not invent numbers. When a metric would strengthen a bullet but is not in the original, write a placeholder such as [X%] or [N users] and add a question asking the user for the real value.
+
+Answer only with a numbered list. Put each alternative on its own line, starting with its number and a period, followed by an optional line starting with "Metric:" that asks for the value behind the placeholder.`
+
+// ResearchInstructions defines the system instructions for the company research model.
+// The research only summarizes what the model already knows or what the job posting
+// says, so the resume can echo the employer's language without inventing facts.
+const ResearchInstructions = `You are an expert career researcher. You will be given a company name or a job posting. Summarize what the employer values and the language it uses to describe the people it hires, so a resume can be tailored to it.
+
+Only report what the posting states or what is widely known about the company. If you do not recognize the company and no posting is given, say so instead of guessing.
+
+Answer with exactly these four lines:
+Company: the company name
+Values: a comma-separated list of up to six values or priorities
+Keywords: a comma-separated list of up to ten skills, terms, or phrases the employer uses
+Tone: a few words describing the tone of its writing`
+
+// AchievementsInstructions defines the system instructions for the achievements model.
+// The bullets are drafts for the user's notes, built only from commit and pull
+// request titles, so impact the titles do not show is left as a placeholder.
+const AchievementsInstructions = `You are an expert resume writer. You will be given a developer's commits and merged pull requests, one per line with its date and repository. Find the most notable work and summarize it as candidate resume achievement bullets: group related commits into one bullet, lead with a specific action verb, and name the technologies and outcomes the commits show.
+
+Ignore routine work such as dependency updates, formatting, typo fixes, and reverts. Do not invent numbers or impact the commits do not show. When a metric would strengthen a bullet, write a placeholder such as [X%] or [N users] for the developer to fill in.
+
+Answer only with a Markdown list of up to eight bullets, most notable first. Start each bullet with "- " and end it with the repository and the months it covers in parentheses, such as (widgets, Mar-May 2024).`
+
+// TranslateInstructions defines the system instructions for the translation model.
+// A translated resume must say exactly what the original does, so the model
+// localizes the wording and conventions but adds and drops nothing.
+const TranslateInstructions = `You are an expert resume translator. You will be given a resume in Markdown and a target language. Translate it into a professional resume a recruiter in that language would expect to read.
+
+Localize, rather than translate word for word: use the section headings customary in the target language (for example "Berufserfahrung" for Experience in German), write dates and date ranges in the target locale's usual format, and use the target language's established terms for job titles and degrees. Keep names of people, companies, products, and technologies as they are. Do not add, remove, or embellish any information, and keep the Markdown structure, headings, and bullets of the original.
+
+Begin the translated resume with the line ` + ResumeStartDelimiter + ` and end it with the line ` + ResumeEndDelimiter + `. Do not write anything before or after these delimiters.`
+
+// MergeInstructions defines the system instructions for the merge model.
+// The master history becomes the source of every later resume, so it keeps
+// every distinct fact from the versions it merges and invents none.
+const MergeInstructions = `You are an expert resume writer. You will be given several versions of one person's resume, written at different times. Merge them into a single master history of their whole career, to be used as the source for future resumes.
+
+Treat roles at the same employer with overlapping dates or the same title as one role, even when they are worded differently, and use the most precise dates and title any version gives. Within each role, treat bullets describing the same work as duplicates and keep one, preferring the most specific wording with the most concrete numbers. Keep every distinct role, bullet, project, skill, degree, certification, and contact detail from any version, even ones a recent resume dropped. Do not invent, embellish, or summarize away information. When versions contradict each other, keep the most recent version's fact.
+
+Write the master history in Markdown: the person's name as the heading, their contact details, then sections for experience with roles newest first, projects, skills, and education. Begin it with the line ` + ResumeStartDelimiter + ` and end it with the line ` + ResumeEndDelimiter + `. Do not write anything before or after these delimiters.`
 
 // GetAPIKey retrieves the Gemini API key from the GEMINI_API_KEY environment variable.
 // This key is required for authenticating with the Gemini API.
@@ -101,8 +212,8 @@ func InitializeClientWithModel(ctx context.Context, apiKey string, modelName str
 		return nil, nil, errors.New("API key cannot be empty")
 	}
 
-	// Initialize client
-	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
+	// Initialize client, through the API gateway when one is configured
+	client, err := ActiveClientFactory.NewClient(ctx, apiKey)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -115,13 +226,291 @@ func InitializeClientWithModel(ctx context.Context, apiKey string, modelName str
 		return nil, nil, errors.New("failed to initialize model: " + modelName)
 	}
 
-	// Configure model with system instructions
+	// Configure model with system instructions and stop sequences
+	configureResumeModel(model)
+
+	return client, model, nil
+}
+
+
+
+// NewResumeModel returns a model from the given client configured for resume
+// generation, exactly like the model created by InitializeClientWithModel.
+// It is used to switch to a different model (such as a fallback) without
+// creating a new client.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured resume model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	fallbackModel, err := api.NewResumeModel(client, api.DefaultFallbackModelName)
+func NewResumeModel(client *genai.Client, modelName string) (*genai.GenerativeModel, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	
+	model := client.GenerativeModel(modelName)
+	if model == nil {
+		return nil, errors.New("failed to initialize model: " + modelName)
+	}
+	
+	configureResumeModel(model)
+	return model, nil
+}
+
+// NewResumeModelWithInstructions returns a resume model like NewResumeModel,
+// but with its system instructions replaced by the given text. It lets
+// compare mode try an alternative prompt template against the built-in one.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//   - instructions: The system instructions (SystemInstructions when empty)
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured resume model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	model, err := api.NewResumeModelWithInstructions(client, api.DefaultModelName, customInstructions)
+func NewResumeModelWithInstructions(client *genai.Client, modelName, instructions string) (*genai.GenerativeModel, error) {
+	model, err := NewResumeModel(client, modelName)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(instructions) != "" {
+		model.SystemInstruction = &genai.Content{
+			Parts: []genai.Part{
+				genai.Text(instructions),
+			},
+		}
+	}
+	return model, nil
+}
+
+// configureResumeModel applies the resume system instructions and stop sequences to model.
+func configureResumeModel(model *genai.GenerativeModel) {
 	model.SystemInstruction = &genai.Content{
 		Parts: []genai.Part{
 			genai.Text(SystemInstructions),
 		},
 	}
+	
+	// Stop generating as soon as the resume is complete
+	model.StopSequences = []string{ResumeEndDelimiter}
+}
 
-	return client, model, nil
+// NewCoverLetterModel returns a model from the given client configured with
+// CoverLetterInstructions. It shares the client (and therefore the connection
+// and credentials) with the resume model, so a bundle run needs no extra setup.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured cover letter model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	letterModel, err := api.NewCoverLetterModel(client, api.DefaultModelName)
+func NewCoverLetterModel(client *genai.Client, modelName string) (*genai.GenerativeModel, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	
+	model := client.GenerativeModel(modelName)
+	if model == nil {
+		return nil, errors.New("failed to initialize model: " + modelName)
+	}
+	
+	model.SystemInstruction = &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(CoverLetterInstructions),
+		},
+	}
+	
+	return model, nil
+}
+
+// NewRewriteModel returns a model from the given client configured with
+// RewriteInstructions. It is used by the rewrite mode, which rewrites a
+// single bullet without running the resume pipeline.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured rewrite model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	rewriteModel, err := api.NewRewriteModel(client, api.DefaultModelName)
+func NewRewriteModel(client *genai.Client, modelName string) (*genai.GenerativeModel, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	
+	model := client.GenerativeModel(modelName)
+	if model == nil {
+		return nil, errors.New("failed to initialize model: " + modelName)
+	}
+	
+	model.SystemInstruction = &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(RewriteInstructions),
+		},
+	}
+	
+	return model, nil
+}
+
+// NewResearchModel returns a model from the given client configured with
+// ResearchInstructions. It is used by the optional company research step,
+// which runs before the resume is generated.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured research model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	researchModel, err := api.NewResearchModel(client, api.DefaultModelName)
+func NewResearchModel(client *genai.Client, modelName string) (*genai.GenerativeModel, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	
+	model := client.GenerativeModel(modelName)
+	if model == nil {
+		return nil, errors.New("failed to initialize model: " + modelName)
+	}
+	
+	model.SystemInstruction = &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(ResearchInstructions),
+		},
+	}
+	
+	return model, nil
+}
+
+// NewAchievementsModel returns a model from the given client configured with
+// AchievementsInstructions. It is used by the achievements mode, which
+// summarizes git history into candidate bullets for the user's notes.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured achievements model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	achievementsModel, err := api.NewAchievementsModel(client, api.DefaultModelName)
+func NewAchievementsModel(client *genai.Client, modelName string) (*genai.GenerativeModel, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	
+	model := client.GenerativeModel(modelName)
+	if model == nil {
+		return nil, errors.New("failed to initialize model: " + modelName)
+	}
+	
+	model.SystemInstruction = &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(AchievementsInstructions),
+		},
+	}
+	
+	return model, nil
 }
 
+// NewTranslateModel returns a model from the given client configured with
+// TranslateInstructions. It is used by the translate mode, which localizes
+// an existing resume into another language. Like the resume model, it stops
+// at ResumeEndDelimiter.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured translation model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	translateModel, err := api.NewTranslateModel(client, api.DefaultModelName)
+func NewTranslateModel(client *genai.Client, modelName string) (*genai.GenerativeModel, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	
+	model := client.GenerativeModel(modelName)
+	if model == nil {
+		return nil, errors.New("failed to initialize model: " + modelName)
+	}
+	
+	model.SystemInstruction = &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(TranslateInstructions),
+		},
+	}
+	model.StopSequences = []string{ResumeEndDelimiter}
+	
+	return model, nil
+}
+
+// NewMergeModel returns a model from the given client configured with
+// MergeInstructions. It is used by the merge-sources mode, which merges old
+// resumes into one master history. Like the resume model, it stops at
+// ResumeEndDelimiter.
+//
+// Parameters:
+//   - client: An initialized Gemini client
+//   - modelName: The Gemini model identifier to use
+//
+// Returns:
+//   - *genai.GenerativeModel: The configured merge model
+//   - error: An error if the client is nil or the model cannot be created
+//
+// Example:
+//
+//	mergeModel, err := api.NewMergeModel(client, api.DefaultModelName)
+func NewMergeModel(client *genai.Client, modelName string) (*genai.GenerativeModel, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	
+	model := client.GenerativeModel(modelName)
+	if model == nil {
+		return nil, errors.New("failed to initialize model: " + modelName)
+	}
+	
+	model.SystemInstruction = &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(MergeInstructions),
+		},
+	}
+	model.StopSequences = []string{ResumeEndDelimiter}
+	
+	return model, nil
+}
@@ -3,7 +3,10 @@ package api
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/google/generative-ai-go/genai"
 )
 
 func TestGetAPIKey(t *testing.T) {
@@ -48,6 +51,8 @@ func TestGetAPIKey(t *testing.T) {
 }
 
 func TestInitializeClient(t *testing.T) {
+	useFakeClients(t, nil)
+
 	// Save original environment to restore after tests
 	originalAPIKey := os.Getenv("GEMINI_API_KEY")
 	defer os.Setenv("GEMINI_API_KEY", originalAPIKey)
@@ -80,6 +85,11 @@ func TestInitializeClient(t *testing.T) {
 		if model.SystemInstruction == nil {
 			t.Error("Expected system instructions to be set, but they were nil")
 		}
+		
+		// Check that the resume end delimiter is registered as a stop sequence
+		if len(model.StopSequences) != 1 || model.StopSequences[0] != ResumeEndDelimiter {
+			t.Errorf("Expected stop sequences [%q], got %v", ResumeEndDelimiter, model.StopSequences)
+		}
 	})
 
 	// Test case 2: Fail to initialize client with invalid API key
@@ -126,4 +136,225 @@ func TestInitializeClient(t *testing.T) {
 			t.Error("Expected system instructions to be set, but they were nil")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestSystemInstructionsDelimiters(t *testing.T) {
+	// The system instructions must tell the model about both delimiters
+	// so the output package can extract the fenced resume
+	for _, delimiter := range []string{ResumeStartDelimiter, ResumeEndDelimiter} {
+		if !strings.Contains(SystemInstructions, delimiter) {
+			t.Errorf("Expected system instructions to mention %q", delimiter)
+		}
+	}
+}
+
+func TestNewCoverLetterModel(t *testing.T) {
+	t.Run("Nil client", func(t *testing.T) {
+		if _, err := NewCoverLetterModel(nil, DefaultModelName); err == nil {
+			t.Error("Expected error for nil client, got nil")
+		}
+	})
+
+	t.Run("Configures cover letter instructions", func(t *testing.T) {
+		useFakeClients(t, nil)
+		client, _, err := InitializeClient(context.Background(), "test-api-key-123")
+		if err != nil {
+			t.Fatalf("Failed to initialize client: %v", err)
+		}
+		defer client.Close()
+
+		model, err := NewCoverLetterModel(client, DefaultModelName)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if model.SystemInstruction == nil || len(model.SystemInstruction.Parts) == 0 {
+			t.Fatal("Expected system instructions to be set")
+		}
+		if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != CoverLetterInstructions {
+			t.Error("Expected cover letter instructions to be used")
+		}
+	})
+}
+
+func TestNewRewriteModel(t *testing.T) {
+	t.Run("Nil client", func(t *testing.T) {
+		if _, err := NewRewriteModel(nil, DefaultModelName); err == nil {
+			t.Error("Expected error for nil client, got nil")
+		}
+	})
+
+	t.Run("Configures rewrite instructions", func(t *testing.T) {
+		useFakeClients(t, nil)
+		client, _, err := InitializeClient(context.Background(), "test-api-key-123")
+		if err != nil {
+			t.Fatalf("Failed to initialize client: %v", err)
+		}
+		defer client.Close()
+
+		model, err := NewRewriteModel(client, DefaultModelName)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != RewriteInstructions {
+			t.Error("Expected rewrite instructions to be used")
+		}
+		if len(model.StopSequences) != 0 {
+			t.Error("Rewrites have no resume delimiters, so no stop sequences should be set")
+		}
+	})
+}
+
+func TestNewAchievementsModel(t *testing.T) {
+	t.Run("Nil client", func(t *testing.T) {
+		if _, err := NewAchievementsModel(nil, DefaultModelName); err == nil {
+			t.Error("Expected error for nil client, got nil")
+		}
+	})
+
+	t.Run("Configures achievements instructions", func(t *testing.T) {
+		useFakeClients(t, nil)
+		client, _, err := InitializeClient(context.Background(), "test-api-key-123")
+		if err != nil {
+			t.Fatalf("Failed to initialize client: %v", err)
+		}
+		defer client.Close()
+
+		model, err := NewAchievementsModel(client, DefaultModelName)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != AchievementsInstructions {
+			t.Error("Expected achievements instructions to be used")
+		}
+	})
+}
+
+func TestNewTranslateModel(t *testing.T) {
+	t.Run("Nil client", func(t *testing.T) {
+		if _, err := NewTranslateModel(nil, DefaultModelName); err == nil {
+			t.Error("Expected error for nil client, got nil")
+		}
+	})
+
+	t.Run("Configures translation instructions", func(t *testing.T) {
+		useFakeClients(t, nil)
+		client, _, err := InitializeClient(context.Background(), "test-api-key-123")
+		if err != nil {
+			t.Fatalf("Failed to initialize client: %v", err)
+		}
+		defer client.Close()
+
+		model, err := NewTranslateModel(client, DefaultModelName)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != TranslateInstructions {
+			t.Error("Expected translation instructions to be used")
+		}
+		if len(model.StopSequences) != 1 || model.StopSequences[0] != ResumeEndDelimiter {
+			t.Errorf("Expected the resume end delimiter as stop sequence, got %v", model.StopSequences)
+		}
+	})
+}
+
+func TestNewMergeModel(t *testing.T) {
+	t.Run("Nil client", func(t *testing.T) {
+		if _, err := NewMergeModel(nil, DefaultModelName); err == nil {
+			t.Error("Expected error for nil client, got nil")
+		}
+	})
+
+	t.Run("Configures merge instructions", func(t *testing.T) {
+		useFakeClients(t, nil)
+		client, _, err := InitializeClient(context.Background(), "test-api-key-123")
+		if err != nil {
+			t.Fatalf("Failed to initialize client: %v", err)
+		}
+		defer client.Close()
+
+		model, err := NewMergeModel(client, DefaultModelName)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != MergeInstructions {
+			t.Error("Expected merge instructions to be used")
+		}
+		if len(model.StopSequences) != 1 || model.StopSequences[0] != ResumeEndDelimiter {
+			t.Errorf("Expected the resume end delimiter as stop sequence, got %v", model.StopSequences)
+		}
+	})
+}
+
+func TestNewResearchModel(t *testing.T) {
+	t.Run("Nil client", func(t *testing.T) {
+		if _, err := NewResearchModel(nil, DefaultModelName); err == nil {
+			t.Error("Expected error for nil client, got nil")
+		}
+	})
+
+	t.Run("Configures research instructions", func(t *testing.T) {
+		useFakeClients(t, nil)
+		client, _, err := InitializeClient(context.Background(), "test-api-key-123")
+		if err != nil {
+			t.Fatalf("Failed to initialize client: %v", err)
+		}
+		defer client.Close()
+
+		model, err := NewResearchModel(client, DefaultModelName)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != ResearchInstructions {
+			t.Error("Expected research instructions to be used")
+		}
+	})
+}
+
+func TestNewResumeModelWithInstructions(t *testing.T) {
+	useFakeClients(t, nil)
+	client, _, err := InitializeClient(context.Background(), "test-api-key-123")
+	if err != nil {
+		t.Fatalf("Failed to initialize client: %v", err)
+	}
+	defer client.Close()
+
+	// Test case 1: Custom instructions replace the built-in ones
+	model, err := NewResumeModelWithInstructions(client, DefaultModelName, "Write a terse resume.")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != "Write a terse resume." {
+		t.Error("Expected the custom instructions to be used")
+	}
+	if len(model.StopSequences) != 1 || model.StopSequences[0] != ResumeEndDelimiter {
+		t.Errorf("Expected the resume stop sequence, got %v", model.StopSequences)
+	}
+
+	// Test case 2: Empty instructions keep the built-in ones
+	model, err = NewResumeModelWithInstructions(client, DefaultModelName, " ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if text, ok := model.SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != SystemInstructions {
+		t.Error("Expected the built-in instructions to be used")
+	}
+}
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		model    string
+		expected int
+	}{
+		{DefaultModelName, 1048576},
+		{DefaultFallbackModelName, 1048576},
+		{"gemini-1.5-pro-002", 2097152},
+		{"some-future-model", DefaultContextWindow},
+	}
+
+	for _, tt := range tests {
+		if got := ContextWindow(tt.model); got != tt.expected {
+			t.Errorf("ContextWindow(%q) = %d, want %d", tt.model, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/logging"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// DefaultCacheTTL is how long cached system instructions are kept. Each
+// cache is deleted when the session ends, so the TTL only matters for
+// sessions that end without cleaning up.
+const DefaultCacheTTL = 30 * time.Minute
+
+// The cached-content API refuses content below a minimum size, which
+// depends on the model.
+const (
+	minCacheTokens       = 1024  // Flash models
+	minCacheTokensPro    = 4096  // Pro models
+	minCacheTokensLegacy = 32768 // Gemini 1.0 and 1.5 models
+)
+
+// ContentCacher is the subset of genai.Client used to cache system
+// instructions. It allows tests to substitute a fake cached-content API.
+type ContentCacher interface {
+	CreateCachedContent(ctx context.Context, cc *genai.CachedContent) (*genai.CachedContent, error)
+	DeleteCachedContent(ctx context.Context, name string) error
+}
+
+// PromptCache caches the system instructions of the models used in a
+// session with the Gemini cached-content API, so requests after the first
+// refer to them instead of sending them again. A model's instructions are
+// cached the second time it is used, so a session that generates once never
+// pays for a cache. Instructions estimated to be below the model's minimum
+// size are never sent to the cache API, and when the API declines to cache
+// others, the model is used as it is.
+//
+// A nil *PromptCache caches nothing, so callers need not check for one.
+type PromptCache struct {
+	cacher ContentCacher
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	uses    map[string]int                  // How often each model and its instructions were used
+	entries map[string]*genai.CachedContent // The caches created, by model and instructions
+	failed  map[string]bool                 // Models and instructions the API would not cache
+}
+
+// NewPromptCache returns a cache that stores system instructions with
+// cacher for ttl.
+//
+// Parameters:
+//   - cacher: The cached-content API, usually the *genai.Client
+//   - ttl: How long each cache is kept, such as DefaultCacheTTL
+//
+// Returns:
+//   - *PromptCache: The cache, which is empty until a model is used twice
+//
+// Example:
+//
+//	cache := api.NewPromptCache(client, api.DefaultCacheTTL)
+//	defer cache.Close(context.Background())
+//	model = cache.Model(ctx, model, api.DefaultModelName)
+func NewPromptCache(cacher ContentCacher, ttl time.Duration) *PromptCache {
+	return &PromptCache{
+		cacher:  cacher,
+		ttl:     ttl,
+		uses:    make(map[string]int),
+		entries: make(map[string]*genai.CachedContent),
+		failed:  make(map[string]bool),
+	}
+}
+
+// CachingAvailable reports whether clients created by this package can
+// cache content. The SDK sends cache requests over its own connection,
+// around the HTTP client that carries the API gateway's credentials and a
+// FakeClientFactory's handler, so caching is only used without either.
+//
+// Returns:
+//   - bool: True if requests go straight to the Gemini API
+func CachingAvailable() bool {
+	_, direct := ActiveClientFactory.(GatewayClientFactory)
+	return direct && ActiveGateway.Endpoint == ""
+}
+
+// Model returns model ready for its next request: unchanged the first time
+// it is used, and afterwards reading its system instructions from a cache.
+// Models without system instructions, models with tools, models whose
+// instructions are below the minimum size, and models the API would not
+// cache for are always returned unchanged.
+//
+// Parameters:
+//   - ctx: The context for creating the cache
+//   - model: The configured model, with its system instructions
+//   - modelName: The model identifier, which the cache is created for
+//
+// Returns:
+//   - *genai.GenerativeModel: The model to send the request with
+func (c *PromptCache) Model(ctx context.Context, model *genai.GenerativeModel, modelName string) *genai.GenerativeModel {
+	if c == nil || model == nil || model.SystemInstruction == nil || len(model.Tools) > 0 || model.CachedContentName != "" {
+		return model
+	}
+	instructions := instructionsText(model.SystemInstruction)
+	if prompt.EstimateTokens(instructions) < minCachedTokens(modelName) {
+		return model
+	}
+	key := modelName + "\x00" + instructions
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failed[key] {
+		return model
+	}
+	entry := c.entries[key]
+	if entry == nil {
+		c.uses[key]++
+		if c.uses[key] < 2 {
+			return model
+		}
+
+		var err error
+		entry, err = c.cacher.CreateCachedContent(ctx, &genai.CachedContent{
+			Model:             modelName,
+			SystemInstruction: model.SystemInstruction,
+			Expiration:        genai.ExpireTimeOrTTL{TTL: c.ttl},
+		})
+		if err != nil {
+			logging.Debugf("Could not cache the system instructions of %s, sending them with each request: %v", modelName, err)
+			c.failed[key] = true
+			return model
+		}
+		c.entries[key] = entry
+		logging.Debugf("Cached the system instructions of %s as %s", modelName, entry.Name)
+	}
+
+	// The cache holds the instructions, which the API rejects in the request too
+	cached := *model
+	cached.SystemInstruction = nil
+	cached.CachedContentName = entry.Name
+	return &cached
+}
+
+// Close deletes every cache created, so nothing is kept after the session.
+// Failures are logged, since the caches expire on their own.
+//
+// Parameters:
+//   - ctx: The context for the deletions
+func (c *PromptCache) Close(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if err := c.cacher.DeleteCachedContent(ctx, entry.Name); err != nil {
+			logging.Debugf("Could not delete the cached instructions %s: %v", entry.Name, err)
+		}
+		delete(c.entries, key)
+	}
+}
+
+// instructionsText returns the text of system instructions.
+func instructionsText(content *genai.Content) string {
+	var b strings.Builder
+	for _, part := range content.Parts {
+		if text, ok := part.(genai.Text); ok {
+			b.WriteString(string(text))
+		}
+	}
+	return b.String()
+}
+
+// minCachedTokens returns the fewest tokens the cached-content API caches
+// for modelName.
+func minCachedTokens(modelName string) int {
+	switch name := strings.ToLower(modelName); {
+	case strings.Contains(name, "-1.0-") || strings.Contains(name, "-1.5-"):
+		return minCacheTokensLegacy
+	case strings.Contains(name, "pro"):
+		return minCacheTokensPro
+	default:
+		return minCacheTokens
+	}
+}
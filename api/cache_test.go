@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// fakeCacher records the caches created and deleted in place of the
+// cached-content API.
+type fakeCacher struct {
+	created []*genai.CachedContent
+	deleted []string
+	err     error
+}
+
+func (f *fakeCacher) CreateCachedContent(ctx context.Context, cc *genai.CachedContent) (*genai.CachedContent, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.created = append(f.created, cc)
+	return &genai.CachedContent{Name: "cachedContents/abc", Model: "models/" + cc.Model}, nil
+}
+
+func (f *fakeCacher) DeleteCachedContent(ctx context.Context, name string) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func TestPromptCache(t *testing.T) {
+	useFakeClients(t, nil)
+	client, shortModel, err := InitializeClient(context.Background(), "test-api-key-123")
+	if err != nil {
+		t.Fatalf("Failed to initialize client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	// Only instructions above the model's minimum size can be cached
+	instructions := strings.Repeat("Write every bullet in the past tense. ", 500)
+	model, err := NewResumeModelWithInstructions(client, DefaultModelName, instructions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Caches the instructions once the model is used again", func(t *testing.T) {
+		cacher := &fakeCacher{}
+		cache := NewPromptCache(cacher, DefaultCacheTTL)
+
+		// Test case 1: The first use sends the instructions as usual
+		if got := cache.Model(ctx, model, DefaultModelName); got != model || len(cacher.created) != 0 {
+			t.Fatalf("Expected the model unchanged on first use, got %+v", cacher.created)
+		}
+
+		// Test case 2: The second use caches them and refers to the cache
+		cached := cache.Model(ctx, model, DefaultModelName)
+		if len(cacher.created) != 1 || cacher.created[0].Model != DefaultModelName || cacher.created[0].Expiration.TTL != DefaultCacheTTL {
+			t.Fatalf("Expected one cache for the model, got %+v", cacher.created)
+		}
+		if text, ok := cacher.created[0].SystemInstruction.Parts[0].(genai.Text); !ok || string(text) != instructions {
+			t.Error("Expected the system instructions to be cached")
+		}
+		if cached.CachedContentName != "cachedContents/abc" || cached.SystemInstruction != nil {
+			t.Errorf("Expected the model to read its instructions from the cache, got %q", cached.CachedContentName)
+		}
+		if len(cached.StopSequences) != 1 || cached.StopSequences[0] != ResumeEndDelimiter {
+			t.Errorf("Expected the stop sequences to be kept, got %v", cached.StopSequences)
+		}
+		if model.SystemInstruction == nil || model.CachedContentName != "" {
+			t.Error("Expected the original model to be left alone")
+		}
+
+		// Test case 3: Later uses reuse the cache
+		cache.Model(ctx, model, DefaultModelName)
+		if len(cacher.created) != 1 {
+			t.Errorf("Expected the cache to be reused, got %d caches", len(cacher.created))
+		}
+
+		// Test case 4: Other instructions get a cache of their own
+		letterModel, err := NewCoverLetterModel(client, DefaultModelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache.Model(ctx, letterModel, DefaultModelName)
+		if len(cacher.created) != 1 {
+			t.Errorf("Expected the cover letter model's first use to be sent as usual, got %d caches", len(cacher.created))
+		}
+
+		// Test case 5: Closing deletes the caches
+		cache.Close(ctx)
+		if len(cacher.deleted) != 1 || cacher.deleted[0] != "cachedContents/abc" {
+			t.Errorf("Expected the cache to be deleted, got %v", cacher.deleted)
+		}
+	})
+
+	t.Run("Skips instructions below the minimum size", func(t *testing.T) {
+		cacher := &fakeCacher{}
+		cache := NewPromptCache(cacher, DefaultCacheTTL)
+		for i := 0; i < 3; i++ {
+			if got := cache.Model(ctx, shortModel, DefaultModelName); got != shortModel {
+				t.Fatal("Expected the built-in instructions to be sent with each request")
+			}
+		}
+		if len(cacher.created) != 0 {
+			t.Errorf("Expected no cache request for short instructions, got %d", len(cacher.created))
+		}
+	})
+
+	t.Run("Falls back when the API will not cache", func(t *testing.T) {
+		cacher := &fakeCacher{err: errors.New("cached content is too small")}
+		cache := NewPromptCache(cacher, DefaultCacheTTL)
+		cache.Model(ctx, model, DefaultModelName)
+		if got := cache.Model(ctx, model, DefaultModelName); got != model {
+			t.Error("Expected the model unchanged when caching fails")
+		}
+
+		// Test case 6: Caching is not tried again
+		cacher.err = nil
+		if got := cache.Model(ctx, model, DefaultModelName); got != model || len(cacher.created) != 0 {
+			t.Error("Expected no second attempt to cache")
+		}
+	})
+
+	t.Run("Nil cache", func(t *testing.T) {
+		var cache *PromptCache
+		if got := cache.Model(ctx, model, DefaultModelName); got != model {
+			t.Error("Expected a nil cache to return the model unchanged")
+		}
+		cache.Close(ctx)
+	})
+}
+
+func TestCachingAvailable(t *testing.T) {
+	// Test case 1: Clients of a FakeClientFactory cannot cache
+	useFakeClients(t, nil)
+	if CachingAvailable() {
+		t.Error("Expected no caching with a fake client factory")
+	}
+
+	// Test case 2: Requests straight to the API can
+	ActiveClientFactory = GatewayClientFactory{}
+	if !CachingAvailable() {
+		t.Error("Expected caching without a gateway")
+	}
+
+	// Test case 3: Requests through a gateway cannot
+	ActiveGateway = Gateway{Endpoint: "https://gateway.example.com"}
+	t.Cleanup(func() { ActiveGateway = Gateway{} })
+	if CachingAvailable() {
+		t.Error("Expected no caching through a gateway")
+	}
+}
+
+func TestMinCachedTokens(t *testing.T) {
+	tests := []struct {
+		modelName string
+		want      int
+	}{
+		{"gemini-2.5-flash", 1024},
+		{"gemini-2.5-pro-exp-03-25", 4096},
+		{"gemini-1.5-flash", 32768},
+		{"gemini-1.0-pro", 32768},
+	}
+	for _, tt := range tests {
+		if got := minCachedTokens(tt.modelName); got != tt.want {
+			t.Errorf("minCachedTokens(%q) = %d, want %d", tt.modelName, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// SafetyCandidateCount is the number of candidates asked for when a request
+// is retried after its response was blocked by safety filters, so that one
+// of them that passes the filters can be used instead.
+const SafetyCandidateCount = 3
+
+// candidateCountKey is the context key of the count set with WithCandidateCount.
+type candidateCountKey struct{}
+
+// WithCandidateCount returns a context whose requests ask for count
+// candidates. The SDK sends chat turns with a single candidate and rejects a
+// response when any of its candidates is blocked, so the count is set, and
+// blocked candidates are dropped from the response, by the client's
+// transport.
+//
+// Parameters:
+//   - ctx: The context for the requests
+//   - count: The number of candidates to ask for
+//
+// Returns:
+//   - context.Context: The context carrying the count
+//
+// Example:
+//
+//	response, err := model.GenerateContent(api.WithCandidateCount(ctx, 3), parts...)
+func WithCandidateCount(ctx context.Context, count int) context.Context {
+	return context.WithValue(ctx, candidateCountKey{}, count)
+}
+
+// candidateCountFromContext returns the count set with WithCandidateCount.
+func candidateCountFromContext(ctx context.Context) (int, bool) {
+	count, ok := ctx.Value(candidateCountKey{}).(int)
+	return count, ok && count > 1
+}
+
+// PickCandidate returns the candidate of a response to use: the first,
+// unless it was blocked by safety filters and a later one finished normally
+// with content, in which case that one is salvaged.
+//
+// Parameters:
+//   - response: The response from the API
+//
+// Returns:
+//   - *genai.Candidate: The candidate to use, or nil if there are none
+//
+// Example:
+//
+//	candidate := api.PickCandidate(response)
+//	if candidate.FinishReason == genai.FinishReasonSafety {
+//	    // Every candidate was blocked
+//	}
+func PickCandidate(response *genai.GenerateContentResponse) *genai.Candidate {
+	if response == nil || len(response.Candidates) == 0 {
+		return nil
+	}
+	first := response.Candidates[0]
+	if first == nil || first.FinishReason != genai.FinishReasonSafety {
+		return first
+	}
+	for _, candidate := range response.Candidates[1:] {
+		if candidate == nil || candidate.Content == nil || len(candidate.Content.Parts) == 0 {
+			continue
+		}
+		if candidate.FinishReason == genai.FinishReasonStop || candidate.FinishReason == genai.FinishReasonUnspecified {
+			return candidate
+		}
+	}
+	return first
+}
+
+// promoteCandidate moves the candidate PickCandidate salvages to the front
+// of the response, where continuations and the rest of the program read it.
+func promoteCandidate(response *genai.GenerateContentResponse) {
+	picked := PickCandidate(response)
+	if picked == nil || picked == response.Candidates[0] {
+		return
+	}
+	candidates := []*genai.Candidate{picked}
+	for _, candidate := range response.Candidates {
+		if candidate != picked {
+			candidates = append(candidates, candidate)
+		}
+	}
+	response.Candidates = candidates
+}
+
+// blockedBySafety reports whether a request failed because safety filters
+// blocked every candidate of its response. A blocked prompt does not count,
+// since asking again would be blocked the same way.
+func blockedBySafety(response *genai.GenerateContentResponse, err error) bool {
+	var blocked *genai.BlockedError
+	if errors.As(err, &blocked) {
+		return blocked.Candidate != nil && blocked.Candidate.FinishReason == genai.FinishReasonSafety
+	}
+	candidate := PickCandidate(response)
+	return err == nil && candidate != nil && candidate.FinishReason == genai.FinishReasonSafety
+}
+
+// candidateCountEdit sets the candidate count in a generationConfig.
+func candidateCountEdit(count int) configEdit {
+	return func(config map[string]json.RawMessage) {
+		setConfig(config, "candidateCount", count)
+	}
+}
+
+// dropBlockedCandidates removes the candidates blocked by safety filters
+// from a JSON response body: a single response, or the array of chunks of a
+// streamed one. When every candidate was blocked, or the body cannot be
+// parsed, it is returned unchanged, so the SDK reports the block.
+func dropBlockedCandidates(body []byte) []byte {
+	streamed := bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+	var chunks []map[string]json.RawMessage
+	if streamed {
+		if err := json.Unmarshal(body, &chunks); err != nil {
+			return body
+		}
+	} else {
+		var chunk map[string]json.RawMessage
+		if err := json.Unmarshal(body, &chunk); err != nil {
+			return body
+		}
+		chunks = []map[string]json.RawMessage{chunk}
+	}
+
+	// Only the fields that tell candidates apart are read
+	type candidateFields struct {
+		Index        int             `json:"index"`
+		FinishReason json.RawMessage `json:"finishReason"`
+	}
+	parsed := make([][]json.RawMessage, len(chunks))
+	blocked := make(map[int]bool)
+	all := make(map[int]bool)
+	for i, chunk := range chunks {
+		if raw, ok := chunk["candidates"]; ok {
+			if err := json.Unmarshal(raw, &parsed[i]); err != nil {
+				return body
+			}
+		}
+		for _, raw := range parsed[i] {
+			var fields candidateFields
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				return body
+			}
+			all[fields.Index] = true
+			// The REST client asks for enums as numbers; 3 is SAFETY
+			if reason := strings.Trim(string(fields.FinishReason), `"`); reason == "3" || reason == "SAFETY" {
+				blocked[fields.Index] = true
+			}
+		}
+	}
+	if len(blocked) == 0 || len(blocked) == len(all) {
+		return body
+	}
+
+	// Chunks left without candidates are dropped, since the SDK keeps only the
+	// candidates of the first chunk
+	var kept []map[string]json.RawMessage
+	for i, chunk := range chunks {
+		var candidates []json.RawMessage
+		for _, raw := range parsed[i] {
+			var fields candidateFields
+			if json.Unmarshal(raw, &fields) == nil && !blocked[fields.Index] {
+				candidates = append(candidates, raw)
+			}
+		}
+		if len(parsed[i]) > 0 && len(candidates) == 0 {
+			continue
+		}
+		if len(parsed[i]) > 0 {
+			chunk["candidates"], _ = json.Marshal(candidates)
+		}
+		kept = append(kept, chunk)
+	}
+
+	var rewritten []byte
+	var err error
+	if streamed {
+		rewritten, err = json.Marshal(kept)
+	} else {
+		rewritten, err = json.Marshal(kept[0])
+	}
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestPickCandidate(t *testing.T) {
+	blocked := &genai.Candidate{FinishReason: genai.FinishReasonSafety}
+	passed := textResponse("# Jane Doe", genai.FinishReasonStop).Candidates[0]
+	truncated := textResponse("# Jane", genai.FinishReasonMaxTokens).Candidates[0]
+
+	tests := []struct {
+		name       string
+		candidates []*genai.Candidate
+		want       *genai.Candidate
+	}{
+		{"first passed", []*genai.Candidate{passed, blocked}, passed},
+		{"first blocked", []*genai.Candidate{blocked, truncated, passed}, passed},
+		{"every candidate blocked", []*genai.Candidate{blocked, truncated}, blocked},
+		{"no candidates", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PickCandidate(&genai.GenerateContentResponse{Candidates: tt.candidates}); got != tt.want {
+				t.Errorf("PickCandidate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessResponseSalvage(t *testing.T) {
+	// Test case 1: A later candidate is used when the first was blocked
+	response := &genai.GenerateContentResponse{Candidates: []*genai.Candidate{
+		{FinishReason: genai.FinishReasonSafety},
+		textResponse("# Jane Doe", genai.FinishReasonStop).Candidates[0],
+	}}
+	if text, err := ProcessResponse(response); err != nil || text != "# Jane Doe" {
+		t.Errorf("Expected the second candidate, got %q (%v)", text, err)
+	}
+
+	// Test case 2: The safety error is kept when every candidate was blocked
+	response.Candidates[1].FinishReason = genai.FinishReasonSafety
+	if _, err := ProcessResponse(response); err == nil || !strings.Contains(err.Error(), "safety") {
+		t.Errorf("Expected a safety error, got %v", err)
+	}
+}
+
+func TestDropBlockedCandidates(t *testing.T) {
+	// Test case 1: Blocked candidates are removed from every chunk, and chunks left empty are dropped
+	streamed := `[{"candidates":[{"index":0,"content":{"parts":[{"text":"Jane"}]}}]},
+		{"candidates":[{"index":0,"finishReason":3},{"index":1,"content":{"parts":[{"text":"# Jane Doe"}]},"finishReason":1}]},
+		{"usageMetadata":{"totalTokenCount":12}}]`
+	var chunks []struct {
+		Candidates []struct {
+			Index int `json:"index"`
+		} `json:"candidates"`
+		UsageMetadata map[string]any `json:"usageMetadata"`
+	}
+	if err := json.Unmarshal(dropBlockedCandidates([]byte(streamed)), &chunks); err != nil {
+		t.Fatalf("Expected JSON, got %v", err)
+	}
+	if len(chunks) != 2 || len(chunks[0].Candidates) != 1 || chunks[0].Candidates[0].Index != 1 || chunks[1].UsageMetadata == nil {
+		t.Errorf("Expected only candidate 1 and the usage to be kept, got %+v", chunks)
+	}
+
+	// Test case 2: A response whose every candidate was blocked is left for the SDK to report
+	single := `{"candidates":[{"index":0,"finishReason":"SAFETY"}]}`
+	if got := string(dropBlockedCandidates([]byte(single))); got != single {
+		t.Errorf("Expected the response unchanged, got %s", got)
+	}
+	if got := string(dropBlockedCandidates([]byte("not json"))); got != "not json" {
+		t.Errorf("Expected an unreadable body unchanged, got %s", got)
+	}
+}
+
+// countingChat is a ChatSender that is blocked by safety filters until it is
+// asked for several candidates, and records the count of each request.
+type countingChat struct {
+	counts []int
+}
+
+// SendMessage records the candidate count and answers like the API would.
+func (c *countingChat) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	count, _ := candidateCountFromContext(ctx)
+	c.counts = append(c.counts, count)
+	blocked := &genai.Candidate{FinishReason: genai.FinishReasonSafety}
+	if count < 2 {
+		return nil, &genai.BlockedError{Candidate: blocked}
+	}
+	passed := textResponse("# Jane Doe", genai.FinishReasonStop).Candidates[0]
+	return &genai.GenerateContentResponse{Candidates: []*genai.Candidate{blocked, passed}}, nil
+}
+
+func TestSessionRetriesBlockedTurn(t *testing.T) {
+	chat := &countingChat{}
+	base := &genai.ChatSession{History: []*genai.Content{genai.NewUserContent(genai.Text("notes"))}}
+	session := &Session{chat: chat, base: base}
+
+	response, err := session.Send(context.Background(), genai.NewUserContent(genai.Text("notes")))
+	if err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if len(chat.counts) != 2 || chat.counts[1] != SafetyCandidateCount {
+		t.Errorf("Expected a retry asking for %d candidates, got %v", SafetyCandidateCount, chat.counts)
+	}
+
+	// The candidate that passed is moved to the front, where continuations read it
+	if text, err := ParseGeneratedContent(response.Candidates[0].Content); err != nil || text != "# Jane Doe" {
+		t.Errorf("Expected the candidate that passed first, got %q (%v)", text, err)
+	}
+
+	// The blocked turn, left unanswered in the history, is removed before the retry
+	if len(base.History) != 0 {
+		t.Errorf("Expected the unanswered turn removed, got %d entries", len(base.History))
+	}
+}
+
+func TestExecuteRequestRetriesBlockedResponse(t *testing.T) {
+	// The first response is blocked; the retry's second candidate passes
+	var counts []any
+	useFakeClients(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			GenerationConfig map[string]any `json:"generationConfig"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &request)
+		counts = append(counts, request.GenerationConfig["candidateCount"])
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(counts) == 1 {
+			w.Write([]byte(`{"candidates": [{"index": 0, "finishReason": "SAFETY"}]}`))
+			return
+		}
+		w.Write([]byte(`{"candidates": [{"index": 0, "finishReason": "SAFETY"},
+			{"index": 1, "content": {"role": "model", "parts": [{"text": "# Jane Doe"}]}, "finishReason": "STOP"}]}`))
+	}))
+
+	client, model, err := InitializeClient(context.Background(), "test-key")
+	if err != nil {
+		t.Fatalf("InitializeClient returned error: %v", err)
+	}
+	defer client.Close()
+
+	response, err := ExecuteRequest(context.Background(), model, genai.NewUserContent(genai.Text("notes")))
+	if err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if text, err := ProcessResponse(response); err != nil || text != "# Jane Doe" {
+		t.Errorf("Expected the candidate that passed, got %q (%v)", text, err)
+	}
+	if len(counts) != 2 || counts[0] != nil || counts[1] != float64(SafetyCandidateCount) {
+		t.Errorf("Expected the default count and then %d, got %v", SafetyCandidateCount, counts)
+	}
+}
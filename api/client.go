@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/option"
+)
+
+// ErrNoNetwork is returned for the requests of a client created by a
+// FakeClientFactory without a handler.
+var ErrNoNetwork = errors.New("this client does not send requests over the network")
+
+// fakeEndpoint is the endpoint of clients created by a FakeClientFactory.
+// It is never resolved, since their requests are answered in the process.
+const fakeEndpoint = "https://gemini.invalid"
+
+// ClientFactory creates the Gemini clients that InitializeClientWithModel
+// and VerifyAPIKey send requests with.
+type ClientFactory interface {
+	// NewClient returns a client authenticated with apiKey.
+	NewClient(ctx context.Context, apiKey string) (*genai.Client, error)
+}
+
+// ActiveClientFactory creates every client this package returns. Tests set
+// it to a FakeClientFactory so nothing is sent over the network.
+var ActiveClientFactory ClientFactory = GatewayClientFactory{}
+
+// GatewayClientFactory creates clients that send their requests to the
+// Gemini API, or through ActiveGateway when one is configured. It is the
+// default ActiveClientFactory.
+type GatewayClientFactory struct{}
+
+// NewClient returns a client for the Gemini API or the active gateway.
+func (GatewayClientFactory) NewClient(ctx context.Context, apiKey string) (*genai.Client, error) {
+	return genai.NewClient(ctx, ActiveGateway.clientOptions(apiKey)...)
+}
+
+// FakeClientFactory creates clients whose requests never leave the process:
+// Handler answers each one as the API would, and without a handler every
+// request fails with ErrNoNetwork. Requests still pass through the
+// transports that set generation settings, seeds, and candidate counts, so those are tested too.
+//
+// Example:
+//
+//	api.ActiveClientFactory = api.FakeClientFactory{Handler: http.HandlerFunc(
+//	    func(w http.ResponseWriter, r *http.Request) {
+//	        w.Header().Set("Content-Type", "application/json")
+//	        w.Write([]byte(`{"candidates": [{"content": {"parts": [{"text": "# Jane Doe"}]}}]}`))
+//	    })}
+//	client, model, err := api.InitializeClient(ctx, "test-key")
+type FakeClientFactory struct {
+	Handler http.Handler // Answers the requests; nil fails them with ErrNoNetwork
+}
+
+// NewClient returns a client whose requests are answered by the handler.
+func (f FakeClientFactory) NewClient(ctx context.Context, apiKey string) (*genai.Client, error) {
+	// The key is added as the gateway's transport adds it, since a client of
+	// our own replaces the one that would
+	headers := http.Header{}
+	headers.Set(apiKeyHeader, apiKey)
+	transport := settingsTransport{base: headerTransport{base: handlerTransport{handler: f.Handler}, headers: headers}}
+	return genai.NewClient(ctx,
+		option.WithAPIKey(apiKey),
+		option.WithEndpoint(fakeEndpoint),
+		option.WithHTTPClient(&http.Client{Transport: transport}))
+}
+
+// handlerTransport answers requests with an http.Handler in the process.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip returns the handler's response to the request.
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.handler == nil {
+		return nil, ErrNoNetwork
+	}
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	response := recorder.Result()
+	response.Request = req
+	return response, nil
+}
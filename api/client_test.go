@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// useFakeClients makes the clients created during a test answer requests
+// with handler, or fail them with ErrNoNetwork when it is nil.
+func useFakeClients(t *testing.T, handler http.Handler) {
+	t.Helper()
+	factory := ActiveClientFactory
+	t.Cleanup(func() { ActiveClientFactory = factory })
+	ActiveClientFactory = FakeClientFactory{Handler: handler}
+}
+
+func TestFakeClientFactory(t *testing.T) {
+	ctx := context.Background()
+
+	// Test case 1: Requests are answered by the handler, with the API key
+	var key, path string
+	useFakeClients(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, path = r.Header.Get("x-goog-api-key"), r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "# Jane Doe"}]}, "finishReason": "STOP"}]}`))
+	}))
+	client, model, err := InitializeClient(ctx, "test-key")
+	if err != nil {
+		t.Fatalf("InitializeClient returned error: %v", err)
+	}
+	defer client.Close()
+
+	response, err := model.GenerateContent(ctx, genai.Text("notes"))
+	if err != nil {
+		t.Fatalf("GenerateContent returned error: %v", err)
+	}
+	if text, err := ProcessResponse(response); err != nil || text != "# Jane Doe" {
+		t.Errorf("Expected the handler's response, got %q (%v)", text, err)
+	}
+	if key != "test-key" || !strings.Contains(path, DefaultModelName+":generateContent") {
+		t.Errorf("Expected a generateContent request with the API key, got key %q and path %q", key, path)
+	}
+
+	// Test case 2: Without a handler, requests fail without reaching the network
+	useFakeClients(t, nil)
+	client, model, err = InitializeClient(ctx, "test-key")
+	if err != nil {
+		t.Fatalf("InitializeClient returned error: %v", err)
+	}
+	defer client.Close()
+	if _, err := model.GenerateContent(ctx, genai.Text("notes")); !errors.Is(err, ErrNoNetwork) {
+		t.Errorf("Expected ErrNoNetwork, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorCode classifies why a Gemini API request failed.
+type ErrorCode string
+
+const (
+	// CodeQuota means the quota or rate limit was exceeded.
+	CodeQuota ErrorCode = "quota"
+
+	// CodeAuth means the API key was missing, invalid, or lacks permission.
+	CodeAuth ErrorCode = "auth"
+
+	// CodeNetwork means the API could not be reached or did not answer in time.
+	CodeNetwork ErrorCode = "network"
+
+	// CodeInvalidRequest means the API rejected the request itself.
+	CodeInvalidRequest ErrorCode = "invalid_request"
+
+	// CodeServer means the API failed or was overloaded.
+	CodeServer ErrorCode = "server"
+
+	// CodeModelNotFound means the model does not exist or has been retired.
+	CodeModelNotFound ErrorCode = "model_not_found"
+
+	// CodeUnknown is used for failures that match no other code.
+	CodeUnknown ErrorCode = "unknown"
+)
+
+// APIError is a failed API request, classified once so that the fallback
+// retry, the TUI error screen, and the process exit code all agree on what
+// went wrong.
+type APIError struct {
+	Code       ErrorCode     // Why the request failed
+	HTTPStatus int           // The HTTP status of the failure (0 if none applies)
+	Retryable  bool          // Whether another attempt, possibly on the fallback model, may succeed
+	RetryAfter time.Duration // How long the API asked to wait before retrying (0 if it did not say)
+	Advice     string        // What the user can do about it (empty if nothing specific)
+	Err        error         // The error returned by the client library
+
+	summary string // Leads the error message, e.g. "API quota or rate limit exceeded"
+}
+
+// Error describes the failure, the underlying error, and the advice.
+func (e *APIError) Error() string {
+	if e.Advice == "" {
+		return fmt.Sprintf("%s: %v", e.summary, e.Err)
+	}
+	return fmt.Sprintf("%s: %v. %s", e.summary, e.Err, e.Advice)
+}
+
+// Unwrap returns the error returned by the client library.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// apiErrorRule maps error message fragments to a classification. Rules are
+// tried in order and the first match wins.
+type apiErrorRule struct {
+	code       ErrorCode
+	httpStatus int
+	retryable  bool
+	summary    string
+	advice     string
+	markers    []string
+}
+
+// apiErrorRules is the single place API error messages are matched. The
+// markers cover REST errors ("googleapi: Error 429"), gRPC status codes
+// ("code = ResourceExhausted"), and their canonical names (RESOURCE_EXHAUSTED).
+var apiErrorRules = []apiErrorRule{
+	{
+		code: CodeQuota, httpStatus: 429, retryable: true,
+		summary: "API quota or rate limit exceeded",
+		advice:  "Please wait a few minutes and retry, or check your quota management settings",
+		markers: []string{"RESOURCE_EXHAUSTED", "ResourceExhausted", "Quota exceeded", "rate limit", "Error 429"},
+	},
+	{
+		code: CodeAuth, httpStatus: 401,
+		summary: "API authentication error",
+		advice:  "Please verify your GEMINI_API_KEY environment variable is correct and valid",
+		markers: []string{"UNAUTHENTICATED", "Unauthenticated", "PERMISSION_DENIED", "PermissionDenied", "API key", "authentication", "Error 401", "Error 403"},
+	},
+	{
+		code: CodeNetwork, retryable: true,
+		summary: "network error while contacting API",
+		advice:  "Please check your internet connection and try again",
+		markers: []string{"deadline exceeded", "DeadlineExceeded", "connection", "network"},
+	},
+	{
+		code: CodeInvalidRequest, httpStatus: 400,
+		summary: "invalid request to API",
+		advice:  "Please check the format of your prompt",
+		markers: []string{"INVALID_ARGUMENT", "InvalidArgument", "Error 400"},
+	},
+	{
+		code: CodeServer, httpStatus: 503, retryable: true,
+		summary: "API temporarily unavailable",
+		advice:  "The service is overloaded; please try again shortly",
+		markers: []string{"UNAVAILABLE", "code = Unavailable", "Error 503"},
+	},
+	{
+		code: CodeServer, httpStatus: 500, retryable: true,
+		summary: "API server error",
+		advice:  "Please try again shortly",
+		markers: []string{"INTERNAL", "code = Internal", "Error 500"},
+	},
+	{
+		// A different model may still be available, so this is retryable
+		code: CodeModelNotFound, httpStatus: 404, retryable: true,
+		summary: "model not available",
+		advice:  "The model may have been renamed or retired; try another model",
+		markers: []string{"NOT_FOUND", "code = NotFound", "is not found", "deprecated", "Error 404"},
+	},
+}
+
+// httpStatusPattern extracts the status from REST errors such as "googleapi: Error 429: ...".
+var httpStatusPattern = regexp.MustCompile(`\bError (\d{3})\b`)
+
+// ClassifyError returns the APIError in err's chain, or classifies err as
+// one if it has none. It returns nil for a nil error. Cancelled requests are
+// never retryable. When a retryable error says how long to wait, as rate
+// limit errors usually do, the wait is kept in RetryAfter.
+//
+// Parameters:
+//   - err: An error returned by the Gemini client or by this package
+//
+// Returns:
+//   - *APIError: The classified error
+//
+// Example:
+//
+//	if apiErr := api.ClassifyError(err); apiErr.Retryable {
+//	    // Try again, or switch to the fallback model
+//	}
+func ClassifyError(err error) *APIError {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	apiErr = &APIError{Code: CodeUnknown, Err: err, summary: "error generating content"}
+	if errors.Is(err, context.Canceled) {
+		return apiErr
+	}
+
+	errorMsg := err.Error()
+	for _, rule := range apiErrorRules {
+		if containsAnyMarker(errorMsg, rule.markers) {
+			apiErr.Code = rule.code
+			apiErr.HTTPStatus = rule.httpStatus
+			apiErr.Retryable = rule.retryable
+			apiErr.Advice = rule.advice
+			apiErr.summary = rule.summary
+			break
+		}
+	}
+
+	// The status reported by the server wins over the rule's typical one
+	if match := httpStatusPattern.FindStringSubmatch(errorMsg); match != nil {
+		apiErr.HTTPStatus, _ = strconv.Atoi(match[1])
+	}
+
+	// A wait the server asked for replaces the rule's general advice
+	if apiErr.Retryable {
+		if apiErr.RetryAfter = RetryDelay(err, time.Now()); apiErr.RetryAfter > 0 {
+			apiErr.Advice = fmt.Sprintf("The API asks to wait %s before retrying", FormatWait(apiErr.RetryAfter))
+		}
+	}
+	return apiErr
+}
+
+// containsAnyMarker reports whether s contains any of the markers.
+func containsAnyMarker(s string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
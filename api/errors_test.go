@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		code       ErrorCode
+		httpStatus int
+		retryable  bool
+	}{
+		{"quota", errors.New("RESOURCE_EXHAUSTED: Quota exceeded"), CodeQuota, 429, true},
+		{"REST rate limit", errors.New("googleapi: Error 429: rate limit"), CodeQuota, 429, true},
+		{"authentication", errors.New("UNAUTHENTICATED: API key not valid"), CodeAuth, 401, false},
+		{"permission denied", errors.New("googleapi: Error 403: PERMISSION_DENIED"), CodeAuth, 403, false},
+		{"network", errors.New("dial tcp: connection refused"), CodeNetwork, 0, true},
+		{"invalid argument", errors.New("INVALID_ARGUMENT: bad prompt"), CodeInvalidRequest, 400, false},
+		{"unavailable", errors.New("rpc error: code = Unavailable desc = overloaded"), CodeServer, 503, true},
+		{"internal", errors.New("googleapi: Error 500: internal error"), CodeServer, 500, true},
+		{"model not found", errors.New("models/gemini-old is not found"), CodeModelNotFound, 404, true},
+		{"unrecognized", errors.New("googleapi: Error 418: teapot"), CodeUnknown, 418, false},
+		{"cancelled", fmt.Errorf("request failed: %w", context.Canceled), CodeUnknown, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := ClassifyError(tt.err)
+			if apiErr.Code != tt.code || apiErr.HTTPStatus != tt.httpStatus || apiErr.Retryable != tt.retryable {
+				t.Errorf("ClassifyError() = {%s %d %v}, want {%s %d %v}",
+					apiErr.Code, apiErr.HTTPStatus, apiErr.Retryable, tt.code, tt.httpStatus, tt.retryable)
+			}
+			if !errors.Is(apiErr, tt.err) {
+				t.Error("Expected the APIError to wrap the original error")
+			}
+		})
+	}
+
+	// Test case: An APIError already in the chain is returned as-is
+	original := ClassifyError(errors.New("RESOURCE_EXHAUSTED"))
+	if got := ClassifyError(fmt.Errorf("fallback model also failed: %w", original)); got != original {
+		t.Error("Expected the wrapped APIError to be returned")
+	}
+
+	if ClassifyError(nil) != nil {
+		t.Error("Expected nil for a nil error")
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	quota := ClassifyError(errors.New("RESOURCE_EXHAUSTED"))
+	if got := quota.Error(); !strings.HasPrefix(got, "API quota or rate limit exceeded: RESOURCE_EXHAUSTED. ") || !strings.Contains(got, quota.Advice) {
+		t.Errorf("Unexpected quota message %q", got)
+	}
+
+	unknown := ClassifyError(errors.New("boom"))
+	if got := unknown.Error(); got != "error generating content: boom" {
+		t.Errorf("Unexpected message %q", got)
+	}
+}
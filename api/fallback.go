@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// IsFallbackError reports whether err is worth retrying with a fallback model:
+// its APIError is retryable, as for exhausted quota, server-side failures, and
+// models that are unavailable, retired, or deprecated. Authentication and
+// invalid request errors are not, since a different model would fail the same
+// way, and neither are requests whose context was cancelled or timed out.
+//
+// Parameters:
+//   - err: The error returned by a request
+//
+// Returns:
+//   - bool: True if the request should be retried with a fallback model
+func IsFallbackError(err error) bool {
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return ClassifyError(err).Retryable
+}
+
+// SendWithFallback sends content on the primary session and, if that fails
+// with an error accepted by IsFallbackError, retries exactly once on the
+// fallback session. A nil fallback disables the retry. Callers should keep
+// using whichever session produced the response for later turns.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - primary: The session to try first
+//   - fallback: The session to retry on (can be nil)
+//   - content: The prompt content to send
+//
+// Returns:
+//   - *genai.GenerateContentResponse: The response from whichever session succeeded
+//   - bool: True if the response came from the fallback session
+//   - error: The error from the last attempt, mentioning both failures when the retry also failed
+//
+// Example:
+//
+//	response, usedFallback, err := api.SendWithFallback(ctx, session, fallbackSession, content)
+//	if usedFallback {
+//	    session = fallbackSession
+//	}
+func SendWithFallback(ctx context.Context, primary, fallback *Session, content *genai.Content) (*genai.GenerateContentResponse, bool, error) {
+	return SendWithFallbackStreaming(ctx, primary, fallback, content, nil)
+}
+
+// SendWithFallbackStreaming works like SendWithFallback but streams each
+// attempt with Session.SendStreaming. When the fallback is tried, onText
+// starts again from the fallback model's first chunk.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - primary: The session to try first
+//   - fallback: The session to retry on (can be nil)
+//   - content: The prompt content to send
+//   - onText: Called with the accumulated text after each chunk (can be nil)
+//
+// Returns:
+//   - *genai.GenerateContentResponse: The response from whichever session succeeded
+//   - bool: True if the response came from the fallback session
+//   - error: The error from the last attempt, mentioning both failures when the retry also failed
+func SendWithFallbackStreaming(ctx context.Context, primary, fallback *Session, content *genai.Content, onText func(text string)) (*genai.GenerateContentResponse, bool, error) {
+	response, err := primary.SendStreaming(ctx, content, onText)
+	if err == nil || fallback == nil || !IsFallbackError(err) {
+		return response, false, err
+	}
+
+	fallbackResponse, fallbackErr := fallback.SendStreaming(ctx, content, onText)
+	if fallbackErr != nil {
+		return nil, true, fmt.Errorf("fallback model also failed: %w (primary model error: %v)", fallbackErr, err)
+	}
+
+	return fallbackResponse, true, nil
+}
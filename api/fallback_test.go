@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestIsFallbackError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil error", nil, false},
+		{"quota exceeded", errors.New("RESOURCE_EXHAUSTED: Quota exceeded"), true},
+		{"server error", errors.New("googleapi: Error 500: internal error"), true},
+		{"unavailable", errors.New("rpc error: code = Unavailable desc = overloaded"), true},
+		{"deprecated model", errors.New("models/gemini-old is deprecated"), true},
+		{"model not found", errors.New("rpc error: code = NotFound desc = model is not found"), true},
+		{"authentication error", errors.New("UNAUTHENTICATED: API key not valid"), false},
+		{"invalid argument", errors.New("INVALID_ARGUMENT: bad prompt"), false},
+		{"cancelled", context.Canceled, false},
+		{"network error", errors.New("read tcp: connection reset by peer"), true},
+		{"deadline exceeded", context.DeadlineExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsFallbackError(tt.err); got != tt.expected {
+				t.Errorf("IsFallbackError(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSendWithFallback(t *testing.T) {
+	ctx := context.Background()
+	content := &genai.Content{Parts: []genai.Part{genai.Text("Test prompt")}}
+
+	ok := fakeReply{text: "# Resume", finishReason: genai.FinishReasonStop}
+	quotaError := fakeReply{err: errors.New("RESOURCE_EXHAUSTED: Quota exceeded")}
+	authError := fakeReply{err: errors.New("UNAUTHENTICATED: invalid key")}
+
+	// Test case 1: Primary succeeds, fallback is not called
+	t.Run("Primary succeeds", func(t *testing.T) {
+		primary := &fakeChat{replies: []fakeReply{ok}}
+		fallback := &fakeChat{replies: []fakeReply{ok}}
+
+		_, usedFallback, err := SendWithFallback(ctx, NewSessionWithSender(primary), NewSessionWithSender(fallback), content)
+		if err != nil || usedFallback || len(fallback.sent) != 0 {
+			t.Errorf("Expected primary only, got err=%v usedFallback=%v fallbackCalls=%d", err, usedFallback, len(fallback.sent))
+		}
+	})
+
+	// Test case 2: Primary hits quota, fallback succeeds
+	t.Run("Fallback after quota error", func(t *testing.T) {
+		primary := &fakeChat{replies: []fakeReply{quotaError}}
+		fallback := &fakeChat{replies: []fakeReply{ok}}
+
+		response, usedFallback, err := SendWithFallback(ctx, NewSessionWithSender(primary), NewSessionWithSender(fallback), content)
+		if err != nil || response == nil || !usedFallback {
+			t.Errorf("Expected fallback response, got err=%v usedFallback=%v", err, usedFallback)
+		}
+		if len(fallback.sent) != 1 {
+			t.Errorf("Expected fallback to be called once, was called %d times", len(fallback.sent))
+		}
+	})
+
+	// Test case 3: Both models fail
+	t.Run("Fallback also fails", func(t *testing.T) {
+		primary := &fakeChat{replies: []fakeReply{quotaError}}
+		fallback := &fakeChat{replies: []fakeReply{quotaError}}
+
+		_, usedFallback, err := SendWithFallback(ctx, NewSessionWithSender(primary), NewSessionWithSender(fallback), content)
+		if err == nil || !usedFallback {
+			t.Fatalf("Expected an error after fallback, got err=%v usedFallback=%v", err, usedFallback)
+		}
+		if !strings.Contains(err.Error(), "fallback model also failed") {
+			t.Errorf("Expected error to mention the fallback failure, got: %v", err)
+		}
+	})
+
+	// Test case 4: Non-retryable errors are returned without a retry
+	t.Run("No retry on authentication error", func(t *testing.T) {
+		primary := &fakeChat{replies: []fakeReply{authError}}
+		fallback := &fakeChat{replies: []fakeReply{ok}}
+
+		_, usedFallback, err := SendWithFallback(ctx, NewSessionWithSender(primary), NewSessionWithSender(fallback), content)
+		if err == nil || usedFallback || len(fallback.sent) != 0 {
+			t.Errorf("Expected no retry, got err=%v usedFallback=%v fallbackCalls=%d", err, usedFallback, len(fallback.sent))
+		}
+	})
+
+	// Test case 5: Nil fallback disables the retry
+	t.Run("Nil fallback", func(t *testing.T) {
+		primary := &fakeChat{replies: []fakeReply{quotaError}}
+
+		_, usedFallback, err := SendWithFallback(ctx, NewSessionWithSender(primary), nil, content)
+		if err == nil || usedFallback {
+			t.Errorf("Expected primary error without retry, got err=%v usedFallback=%v", err, usedFallback)
+		}
+	})
+}
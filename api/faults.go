@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/faults"
+)
+
+// injectedQuotaError is the error forced by a faults.Quota fault. It has the
+// wording of a real quota error, so it is classified like one.
+var injectedQuotaError = errors.New("googleapi: Error 429: Resource has been exhausted (e.g. check quota). (injected with --inject-error)")
+
+// injectFault sends a request with send, unless a fault is set for the stage
+// of ctx, in which case the failure is forced instead.
+func injectFault(ctx context.Context, send func() (*genai.GenerateContentResponse, error)) (*genai.GenerateContentResponse, error) {
+	category, ok := faults.At(faults.StageOf(ctx))
+	if !ok {
+		return send()
+	}
+
+	switch category {
+	case faults.Quota:
+		return nil, injectedQuotaError
+	case faults.Safety:
+		return &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{
+			FinishReason: genai.FinishReasonSafety,
+			SafetyRatings: []*genai.SafetyRating{{
+				Category:    genai.HarmCategoryDangerousContent,
+				Probability: genai.HarmProbabilityHigh,
+				Blocked:     true,
+			}},
+		}}}, nil
+	case faults.Truncation:
+		response, err := send()
+		if err != nil {
+			return nil, err
+		}
+		return truncatedResponse(response), nil
+	}
+	return send()
+}
+
+// truncatedResponse returns the first half of the text of response, marked
+// as stopped at the token limit.
+func truncatedResponse(response *genai.GenerateContentResponse) *genai.GenerateContentResponse {
+	text := ""
+	if response != nil && len(response.Candidates) > 0 && response.Candidates[0].Content != nil {
+		for _, part := range response.Candidates[0].Content.Parts {
+			if t, ok := part.(genai.Text); ok {
+				text += string(t)
+			}
+		}
+	}
+	runes := []rune(text)
+	return &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{
+		Content:      &genai.Content{Role: "model", Parts: []genai.Part{genai.Text(string(runes[:len(runes)/2]))}},
+		FinishReason: genai.FinishReasonMaxTokens,
+	}}}
+}
+
+// faultSender forces the fault set with faults.Set on the turns of a
+// conversation sent at its stage.
+type faultSender struct {
+	chat ChatSender
+}
+
+// SendMessage sends the message or forces the failure.
+func (s faultSender) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	return injectFault(ctx, func() (*genai.GenerateContentResponse, error) {
+		return s.chat.SendMessage(ctx, parts...)
+	})
+}
+
+// faultStreamingSender is a faultSender for a conversation that can stream.
+type faultStreamingSender struct {
+	faultSender
+	stream StreamingChatSender
+}
+
+// StreamMessage streams the response or forces the failure.
+func (s faultStreamingSender) StreamMessage(ctx context.Context, onText func(text string), parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	return injectFault(ctx, func() (*genai.GenerateContentResponse, error) {
+		return s.stream.StreamMessage(ctx, onText, parts...)
+	})
+}
+
+// faultModel forces the fault set with faults.Set on single-turn requests
+// sent at its stage.
+type faultModel struct {
+	ModelInterface
+}
+
+// GenerateContent sends the request or forces the failure.
+func (m faultModel) GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	return injectFault(ctx, func() (*genai.GenerateContentResponse, error) {
+		return m.ModelInterface.GenerateContent(ctx, parts...)
+	})
+}
+
+// faultySender returns chat forcing the fault set with faults.Set, or chat
+// unchanged when no fault is set.
+func faultySender(chat ChatSender) ChatSender {
+	if _, ok := faults.Active(); !ok || chat == nil {
+		return chat
+	}
+	sender := faultSender{chat: chat}
+	if stream, ok := chat.(StreamingChatSender); ok {
+		return faultStreamingSender{faultSender: sender, stream: stream}
+	}
+	return sender
+}
+
+// faultyModel returns model forcing the fault set with faults.Set, or model
+// unchanged when no fault is set.
+func faultyModel(model ModelInterface) ModelInterface {
+	if _, ok := faults.Active(); !ok || model == nil {
+		return model
+	}
+	return faultModel{ModelInterface: model}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/faults"
+)
+
+// injectFaultForTest sets a fault for the test
+func injectFaultForTest(t *testing.T, category faults.Category, stage faults.Stage) {
+	t.Helper()
+	faults.Set(faults.Fault{Category: category, Stage: stage})
+	t.Cleanup(func() { faults.Set(faults.Fault{}) })
+}
+
+func TestInjectedFaults(t *testing.T) {
+	content := genai.NewUserContent(genai.Text("Write my resume"))
+	resumeCtx := faults.WithStage(context.Background(), faults.StageResume)
+	reply := func() *fakeChat {
+		return &fakeChat{replies: []fakeReply{{text: "# Jane Doe\n\n## Experience", finishReason: genai.FinishReasonStop}}}
+	}
+
+	t.Run("Quota errors are classified like real ones", func(t *testing.T) {
+		injectFaultForTest(t, faults.Quota, faults.StageResume)
+		chat := reply()
+		_, err := NewSessionWithSender(Fixtures{}.WrapSender(chat, DefaultModelName)).Send(resumeCtx, content)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != CodeQuota {
+			t.Errorf("Expected a quota error, got %v", err)
+		}
+		if len(chat.sent) != 0 {
+			t.Error("Expected the request not to be sent")
+		}
+	})
+
+	t.Run("Safety blocks are reported", func(t *testing.T) {
+		injectFaultForTest(t, faults.Safety, faults.StageResume)
+		response, err := NewSessionWithSender(Fixtures{}.WrapSender(reply(), DefaultModelName)).Send(resumeCtx, content)
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		if _, err := ProcessResponse(response); err == nil || !strings.Contains(strings.ToLower(err.Error()), "safety") {
+			t.Errorf("Expected a safety error, got %v", err)
+		}
+	})
+
+	t.Run("Truncation cuts the response", func(t *testing.T) {
+		injectFaultForTest(t, faults.Truncation, faults.StageResume)
+		model := &MockGenerativeModel{generateContentFunc: func(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+			return textResponse("12345678", genai.FinishReasonStop), nil
+		}}
+		response, err := Fixtures{}.WrapModel(model, DefaultModelName).GenerateContent(resumeCtx, content.Parts...)
+		if err != nil {
+			t.Fatalf("GenerateContent() error = %v", err)
+		}
+		text, truncated, err := candidateText(response)
+		if err != nil || text != "1234" || !truncated {
+			t.Errorf("Expected the first half marked truncated, got %q (truncated %v, %v)", text, truncated, err)
+		}
+	})
+
+	t.Run("Other stages are not affected", func(t *testing.T) {
+		injectFaultForTest(t, faults.Quota, faults.StageCoverLetter)
+		session := NewSessionWithSender(Fixtures{}.WrapSender(reply(), DefaultModelName))
+		if _, err := session.Send(resumeCtx, content); err != nil {
+			t.Errorf("Expected the resume request to succeed, got %v", err)
+		}
+	})
+
+	t.Run("Streaming is kept", func(t *testing.T) {
+		injectFaultForTest(t, faults.Quota, faults.StageCoverLetter)
+		chat := &fakeStreamingChat{chunks: []*genai.GenerateContentResponse{textResponse("# Jane", genai.FinishReasonStop)}}
+		var updates []string
+		_, err := NewSessionWithSender(Fixtures{}.WrapSender(chat, DefaultModelName)).SendStreaming(resumeCtx, content, func(text string) {
+			updates = append(updates, text)
+		})
+		if err != nil || len(updates) != 1 {
+			t.Errorf("Expected the response to stream, got updates %q (%v)", updates, err)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// UploadThreshold is the size in bytes above which a text source is uploaded
+// with the Files API instead of inlined in the prompt. Uploaded sources are
+// referenced by URI, so follow-up turns on the same conversation do not
+// resend them.
+const UploadThreshold = 64 * 1024
+
+// UploadPollInterval is how often an upload is checked while Google
+// processes it, as it does for PDFs, before it can be used in a request.
+// It is a variable so tests can shorten it.
+var UploadPollInterval = time.Second
+
+// FileUploader is the subset of genai.Client used to upload source
+// documents. It allows tests to substitute a fake Files API.
+type FileUploader interface {
+	UploadFile(ctx context.Context, name string, r io.Reader, opts *genai.UploadFileOptions) (*genai.File, error)
+	GetFile(ctx context.Context, name string) (*genai.File, error)
+	DeleteFile(ctx context.Context, name string) error
+}
+
+// UploadSource uploads a source document with the Files API and waits until
+// it has been processed, so it can be referenced in a request.
+//
+// Parameters:
+//   - ctx: The context for the upload; cancelling it stops waiting
+//   - uploader: The Files API client, usually the *genai.Client
+//   - displayName: A readable name for the file, such as its base name
+//   - mimeType: The document's MIME type, such as "application/pdf"
+//   - r: The document's content
+//
+// Returns:
+//   - *genai.File: The processed file, whose URI can be used in a genai.FileData part
+//   - error: A user-friendly error if the upload or processing failed
+//
+// Example:
+//
+//	file, err := api.UploadSource(ctx, client, "resume.pdf", "application/pdf", f)
+//	part := genai.FileData{MIMEType: file.MIMEType, URI: file.URI}
+func UploadSource(ctx context.Context, uploader FileUploader, displayName, mimeType string, r io.Reader) (*genai.File, error) {
+	if uploader == nil {
+		return nil, errors.New("uploader cannot be nil")
+	}
+
+	file, err := uploader.UploadFile(ctx, "", r, &genai.UploadFileOptions{DisplayName: displayName, MIMEType: mimeType})
+	if err != nil {
+		return nil, fmt.Errorf("error uploading %s: %w", displayName, handleAPIError(err))
+	}
+
+	for file.State == genai.FileStateProcessing {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(UploadPollInterval):
+		}
+
+		file, err = uploader.GetFile(ctx, file.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error checking the upload of %s: %w", displayName, handleAPIError(err))
+		}
+	}
+
+	if file.State == genai.FileStateFailed {
+		reason := "unknown reason"
+		if file.Error != nil {
+			reason = file.Error.Error()
+		}
+		return nil, fmt.Errorf("google could not process %s: %s", displayName, reason)
+	}
+	return file, nil
+}
+
+// DeleteUpload removes an uploaded source document. Google deletes uploads
+// after 48 hours on its own; this removes them as soon as they are no
+// longer needed.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - uploader: The Files API client, usually the *genai.Client
+//   - name: The uploaded file's name, as returned by UploadSource
+//
+// Returns:
+//   - error: A user-friendly error if the file could not be deleted
+func DeleteUpload(ctx context.Context, uploader FileUploader, name string) error {
+	if uploader == nil {
+		return errors.New("uploader cannot be nil")
+	}
+	if err := uploader.DeleteFile(ctx, name); err != nil {
+		return handleAPIError(err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// fakeUploader is a Files API that processes each upload for a number of
+// GetFile calls before it becomes active, or fails.
+type fakeUploader struct {
+	uploaded  string // Content of the last upload
+	opts      *genai.UploadFileOptions
+	states    []genai.FileState // States returned by successive GetFile calls
+	uploadErr error
+	deleted   []string
+}
+
+func (f *fakeUploader) UploadFile(ctx context.Context, name string, r io.Reader, opts *genai.UploadFileOptions) (*genai.File, error) {
+	if f.uploadErr != nil {
+		return nil, f.uploadErr
+	}
+	data, _ := io.ReadAll(r)
+	f.uploaded, f.opts = string(data), opts
+	return &genai.File{Name: "files/abc", URI: "https://example.com/files/abc", MIMEType: opts.MIMEType, State: genai.FileStateProcessing}, nil
+}
+
+func (f *fakeUploader) GetFile(ctx context.Context, name string) (*genai.File, error) {
+	state := f.states[0]
+	f.states = f.states[1:]
+	return &genai.File{Name: name, URI: "https://example.com/" + name, MIMEType: f.opts.MIMEType, State: state}, nil
+}
+
+func (f *fakeUploader) DeleteFile(ctx context.Context, name string) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func TestUploadSource(t *testing.T) {
+	original := UploadPollInterval
+	UploadPollInterval = time.Millisecond
+	defer func() { UploadPollInterval = original }()
+	ctx := context.Background()
+
+	// Test case 1: The upload is returned once it has been processed
+	uploader := &fakeUploader{states: []genai.FileState{genai.FileStateProcessing, genai.FileStateActive}}
+	file, err := UploadSource(ctx, uploader, "resume.pdf", "application/pdf", strings.NewReader("%PDF-1.7"))
+	if err != nil {
+		t.Fatalf("UploadSource() error = %v", err)
+	}
+	if file.State != genai.FileStateActive || file.URI != "https://example.com/files/abc" {
+		t.Errorf("Expected the processed file, got %+v", file)
+	}
+	if uploader.uploaded != "%PDF-1.7" || uploader.opts.DisplayName != "resume.pdf" || uploader.opts.MIMEType != "application/pdf" {
+		t.Errorf("Unexpected upload %q with %+v", uploader.uploaded, uploader.opts)
+	}
+
+	// Test case 2: A file Google could not process is an error
+	uploader = &fakeUploader{states: []genai.FileState{genai.FileStateFailed}}
+	if _, err := UploadSource(ctx, uploader, "resume.pdf", "application/pdf", strings.NewReader("x")); err == nil || !strings.Contains(err.Error(), "could not process resume.pdf") {
+		t.Errorf("Expected a processing error, got %v", err)
+	}
+
+	// Test case 3: A failed upload is an error
+	uploader = &fakeUploader{uploadErr: errors.New("connection reset")}
+	if _, err := UploadSource(ctx, uploader, "resume.md", "text/plain", strings.NewReader("x")); err == nil || !strings.Contains(err.Error(), "error uploading resume.md") {
+		t.Errorf("Expected an upload error, got %v", err)
+	}
+
+	// Test case 4: Cancelling the context stops waiting
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	uploader = &fakeUploader{states: []genai.FileState{genai.FileStateProcessing}}
+	if _, err := UploadSource(cancelled, uploader, "resume.pdf", "application/pdf", strings.NewReader("x")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+}
+
+func TestDeleteUpload(t *testing.T) {
+	uploader := &fakeUploader{}
+	if err := DeleteUpload(context.Background(), uploader, "files/abc"); err != nil || len(uploader.deleted) != 1 || uploader.deleted[0] != "files/abc" {
+		t.Errorf("Expected files/abc to be deleted, got %v (%v)", uploader.deleted, err)
+	}
+	if err := DeleteUpload(context.Background(), nil, "files/abc"); err == nil {
+		t.Error("Expected an error without an uploader")
+	}
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// FixtureMode selects whether API responses are recorded to or replayed from disk.
+type FixtureMode string
+
+const (
+	// FixtureOff sends every request to the API without touching fixtures.
+	FixtureOff FixtureMode = ""
+
+	// FixtureRecord sends requests to the API and saves each response as a fixture.
+	FixtureRecord FixtureMode = "record"
+
+	// FixtureReplay answers requests from previously recorded fixtures without
+	// contacting the API, so no API key or network connection is needed.
+	FixtureReplay FixtureMode = "replay"
+)
+
+// Fixtures configures recording and replaying of API responses. Each fixture
+// is a JSON file in Dir named after a hash of the model name and every message
+// sent so far in the conversation, so the same inputs always replay the same
+// responses and multi-turn conversations replay turn by turn.
+//
+// Only the text and finish reason of the first candidate are recorded, along
+// with the error message of failed requests, which replay as errors.
+type Fixtures struct {
+	Mode FixtureMode // Whether to record, replay, or neither
+	Dir  string      // Directory holding the fixture files
+}
+
+// fixture is the on-disk form of one recorded request and its response.
+type fixture struct {
+	Model        string             `json:"model"`
+	Request      string             `json:"request"`
+	Parts        []string           `json:"parts,omitempty"`
+	FinishReason genai.FinishReason `json:"finish_reason,omitempty"`
+	Error        string             `json:"error,omitempty"`
+}
+
+// Enabled reports whether responses are being recorded or replayed.
+func (f Fixtures) Enabled() bool {
+	return f.Mode != FixtureOff
+}
+
+// Validate checks that the mode is known and that a directory is set when
+// fixtures are enabled.
+//
+// Returns:
+//   - error: An error describing the invalid setting, or nil
+func (f Fixtures) Validate() error {
+	switch f.Mode {
+	case FixtureOff:
+		return nil
+	case FixtureRecord, FixtureReplay:
+		if strings.TrimSpace(f.Dir) == "" {
+			return fmt.Errorf("%s mode requires a fixture directory", f.Mode)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown fixture mode %q (expected %q or %q)", f.Mode, FixtureRecord, FixtureReplay)
+}
+
+// WrapSender returns a ChatSender that records or replays the conversation
+// on chat. When replaying, chat is never called and may be nil. When fixtures
+// are disabled, chat is returned unchanged, unless a usage recorder is set
+// (see SetUsageRecorder), which is told about each request chat sends, or a
+// fault is set with faults.Set, which is forced on the turns at its stage.
+//
+// Parameters:
+//   - chat: The conversation to record (ignored when replaying)
+//   - modelName: The model the conversation is with, used to key the fixtures
+//
+// Returns:
+//   - ChatSender: The recording or replaying conversation
+func (f Fixtures) WrapSender(chat ChatSender, modelName string) ChatSender {
+	chat = trackSender(chat, modelName)
+	if f.Enabled() {
+		chat = &fixtureSender{fixtures: f, model: modelName, chat: chat}
+	}
+	return faultySender(chat)
+}
+
+// WrapModel returns a model whose single-turn requests are recorded or
+// replayed, for use with ExecuteRequest. When replaying, model may be nil.
+// When fixtures are disabled, model is returned unchanged, unless a usage
+// recorder or a fault is set.
+//
+// Parameters:
+//   - model: The model to record (ignored when replaying)
+//   - modelName: The model name, used to key the fixtures
+//
+// Returns:
+//   - ModelInterface: The recording or replaying model
+//
+// Example:
+//
+//	letterModel := fixtures.WrapModel(model, api.DefaultModelName)
+//	response, err := api.ExecuteRequest(ctx, letterModel, content)
+func (f Fixtures) WrapModel(model ModelInterface, modelName string) ModelInterface {
+	model = trackModel(model, modelName)
+	if f.Enabled() {
+		model = fixtureModel{fixtures: f, name: modelName, model: model}
+	}
+	return faultyModel(model)
+}
+
+// NewFixtureSession starts a chat session like NewSession that records or
+// replays its turns according to fixtures. When replaying, model is not used
+// and may be nil.
+//
+// Parameters:
+//   - model: The configured model to converse with
+//   - modelName: The model name, used to key the fixtures
+//   - fixtures: The record or replay settings
+//
+// Returns:
+//   - *Session: The new session
+//   - error: An error if the model is nil outside replay mode
+//
+// Example:
+//
+//	fixtures := api.Fixtures{Mode: api.FixtureReplay, Dir: "testdata/fixtures"}
+//	session, err := api.NewFixtureSession(nil, api.DefaultModelName, fixtures)
+func NewFixtureSession(model *genai.GenerativeModel, modelName string, fixtures Fixtures) (*Session, error) {
+	if fixtures.Mode == FixtureReplay {
+		return NewSessionWithSender(fixtures.WrapSender(nil, modelName)), nil
+	}
+
+	session, err := NewSession(model)
+	if err != nil {
+		return nil, err
+	}
+	session.chat = fixtures.WrapSender(session.chat, modelName)
+	return session, nil
+}
+
+// fixtureSender records or replays the turns of one conversation.
+type fixtureSender struct {
+	fixtures Fixtures
+	model    string
+	chat     ChatSender
+	history  []string // Messages sent on earlier successful turns
+}
+
+// SendMessage answers from the fixture for this turn when replaying, and
+// otherwise forwards the message and saves the response as that fixture.
+func (s *fixtureSender) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	request := partsText(parts)
+	turns := append(append([]string{}, s.history...), request)
+	path := s.fixtures.path(s.model, turns)
+
+	var response *genai.GenerateContentResponse
+	var err error
+	if s.fixtures.Mode == FixtureReplay {
+		response, err = loadFixture(path)
+	} else {
+		if s.chat == nil {
+			return nil, errors.New("cannot record without a conversation")
+		}
+		response, err = s.chat.SendMessage(ctx, parts...)
+
+		// A cancelled request says nothing about the API, so it is not recorded
+		if ctx.Err() == nil {
+			if saveErr := saveFixture(path, s.model, request, response, err); saveErr != nil {
+				return nil, saveErr
+			}
+		}
+	}
+
+	if err == nil {
+		s.history = turns
+	}
+	return response, err
+}
+
+// fixtureModel records or replays single-turn GenerateContent requests.
+type fixtureModel struct {
+	fixtures Fixtures
+	name     string
+	model    ModelInterface
+}
+
+// GenerateContent answers a single-turn request from its fixture or records it.
+func (m fixtureModel) GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	sender := &fixtureSender{fixtures: m.fixtures, model: m.name}
+	if m.model != nil {
+		sender.chat = senderFunc(m.model.GenerateContent)
+	}
+	return sender.SendMessage(ctx, parts...)
+}
+
+// senderFunc adapts a GenerateContent-style function to ChatSender.
+type senderFunc func(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error)
+
+// SendMessage calls the function.
+func (f senderFunc) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	return f(ctx, parts...)
+}
+
+// path returns the fixture file for a conversation with model after turns.
+func (f Fixtures) path(model string, turns []string) string {
+	hash := sha256.New()
+	hash.Write([]byte(model))
+	for _, turn := range turns {
+		hash.Write([]byte{0})
+		hash.Write([]byte(turn))
+	}
+	return filepath.Join(f.Dir, hex.EncodeToString(hash.Sum(nil))[:16]+".json")
+}
+
+// partsText concatenates the text parts of a message.
+func partsText(parts []genai.Part) string {
+	var b strings.Builder
+	for _, part := range parts {
+		if text, ok := part.(genai.Text); ok {
+			b.WriteString(string(text))
+		}
+	}
+	return b.String()
+}
+
+// saveFixture writes the response (or error) for request to path.
+func saveFixture(path, model, request string, response *genai.GenerateContentResponse, requestErr error) error {
+	recorded := fixture{Model: model, Request: request}
+	if requestErr != nil {
+		recorded.Error = requestErr.Error()
+	} else if response != nil && len(response.Candidates) > 0 && response.Candidates[0] != nil {
+		candidate := response.Candidates[0]
+		recorded.FinishReason = candidate.FinishReason
+		if candidate.Content != nil {
+			for _, part := range candidate.Content.Parts {
+				if text, ok := part.(genai.Text); ok {
+					recorded.Parts = append(recorded.Parts, string(text))
+				}
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// loadFixture reads the recorded response at path. A recorded failure is
+// returned as an error with the original message.
+func loadFixture(path string) (*genai.GenerateContentResponse, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no recorded response for this request in %s: record it first with -record", filepath.Dir(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var recorded fixture
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+	}
+	if recorded.Error != "" {
+		return nil, errors.New(recorded.Error)
+	}
+
+	content := &genai.Content{Role: "model"}
+	for _, text := range recorded.Parts {
+		content.Parts = append(content.Parts, genai.Text(text))
+	}
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{Content: content, FinishReason: recorded.FinishReason}},
+	}, nil
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestFixturesValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fixtures Fixtures
+		wantErr  bool
+	}{
+		{"Off", Fixtures{}, false},
+		{"Record with directory", Fixtures{Mode: FixtureRecord, Dir: "fixtures"}, false},
+		{"Replay with directory", Fixtures{Mode: FixtureReplay, Dir: "fixtures"}, false},
+		{"Record without directory", Fixtures{Mode: FixtureRecord}, true},
+		{"Replay without directory", Fixtures{Mode: FixtureReplay, Dir: "  "}, true},
+		{"Unknown mode", Fixtures{Mode: "rewind", Dir: "fixtures"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.fixtures.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFixturesWrapDisabled(t *testing.T) {
+	chat := &fakeChat{}
+	if sender := (Fixtures{}).WrapSender(chat, DefaultModelName); sender != ChatSender(chat) {
+		t.Error("Disabled fixtures should return the conversation unchanged")
+	}
+
+	model := &MockGenerativeModel{}
+	if wrapped := (Fixtures{}).WrapModel(model, DefaultModelName); wrapped != ModelInterface(model) {
+		t.Error("Disabled fixtures should return the model unchanged")
+	}
+}
+
+func TestFixtureSessionRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Record a two-turn conversation
+	chat := &fakeChat{replies: []fakeReply{
+		{text: "First draft", finishReason: genai.FinishReasonStop},
+		{text: "Shorter draft", finishReason: genai.FinishReasonStop},
+	}}
+	recording := NewSessionWithSender(Fixtures{Mode: FixtureRecord, Dir: dir}.WrapSender(chat, DefaultModelName))
+
+	if _, err := recording.Send(ctx, genai.NewUserContent(genai.Text("Write my resume"))); err != nil {
+		t.Fatalf("Recording first turn failed: %v", err)
+	}
+	if _, err := recording.Refine(ctx, "Make it shorter"); err != nil {
+		t.Fatalf("Recording second turn failed: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 fixture files, got %d", len(files))
+	}
+
+	// Fixtures hold the inputs, so only the owner can read them
+	if info, err := os.Stat(files[0]); err != nil {
+		t.Errorf("Failed to stat fixture: %v", err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected fixture mode 0600, got %v", info.Mode().Perm())
+	}
+
+	// Replay the same conversation without any API
+	replaying, err := NewFixtureSession(nil, DefaultModelName, Fixtures{Mode: FixtureReplay, Dir: dir})
+	if err != nil {
+		t.Fatalf("NewFixtureSession() error = %v", err)
+	}
+
+	first, err := replaying.Send(ctx, genai.NewUserContent(genai.Text("Write my resume")))
+	if err != nil {
+		t.Fatalf("Replaying first turn failed: %v", err)
+	}
+	text, truncated, _ := candidateText(first)
+	if text != "First draft" || truncated {
+		t.Errorf("First turn replayed %q (truncated %v), want %q", text, truncated, "First draft")
+	}
+
+	second, err := replaying.Refine(ctx, "Make it shorter")
+	if err != nil {
+		t.Fatalf("Replaying second turn failed: %v", err)
+	}
+	if text, _, _ := candidateText(second); text != "Shorter draft" {
+		t.Errorf("Second turn replayed %q, want %q", text, "Shorter draft")
+	}
+
+	// The same message in a different conversation was never recorded
+	fresh, _ := NewFixtureSession(nil, DefaultModelName, Fixtures{Mode: FixtureReplay, Dir: dir})
+	_, err = fresh.Refine(ctx, "Make it shorter")
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("Expected missing fixture error, got %v", err)
+	}
+
+	// Fixtures are keyed by model
+	other, _ := NewFixtureSession(nil, DefaultFallbackModelName, Fixtures{Mode: FixtureReplay, Dir: dir})
+	if _, err := other.Send(ctx, genai.NewUserContent(genai.Text("Write my resume"))); err == nil {
+		t.Error("Expected a different model not to replay the recorded conversation")
+	}
+}
+
+func TestFixtureReplaysTruncationAndErrors(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	record := Fixtures{Mode: FixtureRecord, Dir: dir}
+	replay := Fixtures{Mode: FixtureReplay, Dir: dir}
+
+	chat := &fakeChat{replies: []fakeReply{
+		{text: "Partial", finishReason: genai.FinishReasonMaxTokens},
+		{err: errors.New("Error 429: RESOURCE_EXHAUSTED")},
+	}}
+	sender := record.WrapSender(chat, DefaultModelName)
+	sender.SendMessage(ctx, genai.Text("truncate"))
+	sender.SendMessage(ctx, genai.Text("quota"))
+
+	replayed := replay.WrapSender(nil, DefaultModelName)
+	response, err := replayed.SendMessage(ctx, genai.Text("truncate"))
+	if err != nil {
+		t.Fatalf("Replaying truncated response failed: %v", err)
+	}
+	if text, truncated, _ := candidateText(response); text != "Partial" || !truncated {
+		t.Errorf("Replayed %q (truncated %v), want truncated %q", text, truncated, "Partial")
+	}
+
+	// A recorded failure replays as the same error, so fallback logic still applies
+	_, err = replayed.SendMessage(ctx, genai.Text("quota"))
+	if err == nil || !IsFallbackError(err) {
+		t.Errorf("Expected recorded quota error, got %v", err)
+	}
+}
+
+func TestFixtureModelRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	content := genai.NewUserContent(genai.Text("Write a cover letter"))
+
+	model := &MockGenerativeModel{
+		generateContentFunc: func(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+			return textResponse("Dear hiring manager", genai.FinishReasonStop), nil
+		},
+	}
+	recording := Fixtures{Mode: FixtureRecord, Dir: dir}.WrapModel(model, DefaultModelName)
+	if _, err := ExecuteRequest(ctx, recording, content); err != nil {
+		t.Fatalf("Recording request failed: %v", err)
+	}
+
+	replaying := Fixtures{Mode: FixtureReplay, Dir: dir}.WrapModel(nil, DefaultModelName)
+	response, err := ExecuteRequest(ctx, replaying, content)
+	if err != nil {
+		t.Fatalf("Replaying request failed: %v", err)
+	}
+	if text, _ := ProcessResponse(response); text != "Dear hiring manager" {
+		t.Errorf("Replayed %q, want %q", text, "Dear hiring manager")
+	}
+	if model.callCount != 1 {
+		t.Errorf("Expected the model to be called once, got %d", model.callCount)
+	}
+}
+
+func TestFixtureRecordSkipsCancelledRequests(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	chat := &fakeChat{replies: []fakeReply{{err: context.Canceled}}}
+	sender := Fixtures{Mode: FixtureRecord, Dir: dir}.WrapSender(chat, DefaultModelName)
+	if _, err := sender.SendMessage(ctx, genai.Text("cancelled")); err == nil {
+		t.Fatal("Expected the cancellation error")
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no fixtures for a cancelled request, got %d", len(entries))
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"google.golang.org/api/option"
+)
+
+// GatewayTokenEnv is the environment variable holding the bearer token for
+// an API gateway. It takes precedence over the token in the settings file,
+// so the token need not be stored on disk.
+const GatewayTokenEnv = "RESUMAKE_API_TOKEN"
+
+// apiKeyHeader is the header the Gemini API reads the API key from.
+const apiKeyHeader = "X-Goog-Api-Key"
+
+// Gateway describes an API gateway that requests are routed through instead
+// of going to Google directly, such as an internal LLM gateway with its own
+// authentication. The zero value sends requests to Google as usual.
+type Gateway struct {
+	Endpoint    string      // The gateway's base URL, replacing Google's endpoint (empty for Google's)
+	BearerToken string      // Sent as "Authorization: Bearer <token>" (empty for none)
+	Headers     http.Header // Extra headers sent with every request
+}
+
+// ActiveGateway is the gateway every client created by this package sends
+// its requests through. It is set from the settings file with SetGateway.
+var ActiveGateway Gateway
+
+// ParseHeaders parses a list of headers in the form
+// "Name: value; Other-Name: value".
+//
+// Parameters:
+//   - list: The headers, separated by semicolons
+//
+// Returns:
+//   - http.Header: The parsed headers
+//   - error: An error if an entry has no name or no colon
+//
+// Example:
+//
+//	headers, err := api.ParseHeaders("X-Org-Id: 1234; X-Team: resumes")
+func ParseHeaders(list string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, entry := range strings.Split(list, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header %q, expected \"Name: value\"", strings.TrimSpace(entry))
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// SetGateway sets ActiveGateway. The token in GatewayTokenEnv, when set,
+// replaces the token given here.
+//
+// Parameters:
+//   - endpoint: The gateway's base URL (can be empty)
+//   - token: The bearer token (can be empty)
+//   - headers: Extra headers, as accepted by ParseHeaders (can be empty)
+//
+// Returns:
+//   - error: An error if the endpoint or headers are invalid; nothing is changed then
+//
+// Example:
+//
+//	err := api.SetGateway(settings[config.SettingAPIEndpoint], settings[config.SettingAPIToken], settings[config.SettingAPIHeaders])
+func SetGateway(endpoint, token, headers string) error {
+	gateway := Gateway{Endpoint: strings.TrimSpace(endpoint), BearerToken: strings.TrimSpace(token)}
+	if envToken := strings.TrimSpace(os.Getenv(GatewayTokenEnv)); envToken != "" {
+		gateway.BearerToken = envToken
+	}
+
+	if gateway.Endpoint != "" {
+		parsed, err := url.Parse(gateway.Endpoint)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return fmt.Errorf("invalid API endpoint %q, expected a URL such as https://gateway.example.com", gateway.Endpoint)
+		}
+	}
+
+	parsed, err := ParseHeaders(headers)
+	if err != nil {
+		return err
+	}
+	if len(parsed) > 0 {
+		gateway.Headers = parsed
+	}
+
+	ActiveGateway = gateway
+	return nil
+}
+
+// Enabled reports whether the gateway changes how requests are sent.
+func (g Gateway) Enabled() bool {
+	return g.Endpoint != "" || g.BearerToken != "" || len(g.Headers) > 0
+}
+
+// clientOptions returns the options for a client authenticating with apiKey
+// and sending its requests through the gateway. The API key is still sent,
+// in the header Google reads it from, for gateways that pass requests on.
+// The client's transport also applies the seed of requests made with
+// WithSeed, and the candidate count of those made with WithCandidateCount,
+// with or without a gateway.
+func (g Gateway) clientOptions(apiKey string) []option.ClientOption {
+	opts := []option.ClientOption{option.WithAPIKey(apiKey)}
+	if g.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(g.Endpoint))
+	}
+
+	// A client of our own replaces the one that would add the API key, so
+	// the transport adds it along with the gateway's headers
+	headers := g.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	headers.Set(apiKeyHeader, apiKey)
+	if g.BearerToken != "" {
+		headers.Set("Authorization", "Bearer "+g.BearerToken)
+	}
+	transport := settingsTransport{base: headerTransport{base: http.DefaultTransport, headers: headers}}
+	return append(opts, option.WithHTTPClient(&http.Client{Transport: transport}))
+}
+
+// headerTransport adds headers to every request before sending it.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+// RoundTrip sends the request with the transport's headers added.
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders("x-org-id: 1234; X-Team:resumes ;")
+	if err != nil {
+		t.Fatalf("ParseHeaders returned error: %v", err)
+	}
+	if got := headers.Get("X-Org-Id"); got != "1234" {
+		t.Errorf("X-Org-Id = %q, want %q", got, "1234")
+	}
+	if got := headers.Get("X-Team"); got != "resumes" {
+		t.Errorf("X-Team = %q, want %q", got, "resumes")
+	}
+
+	for _, list := range []string{"X-Org-Id 1234", ": value", "X Org: 1"} {
+		if _, err := ParseHeaders(list); err == nil {
+			t.Errorf("ParseHeaders(%q) returned no error", list)
+		}
+	}
+}
+
+func TestSetGateway(t *testing.T) {
+	t.Cleanup(func() { ActiveGateway = Gateway{} })
+
+	t.Setenv(GatewayTokenEnv, "")
+	if err := SetGateway("https://gateway.example.com/gemini", "file-token", "X-Team: resumes"); err != nil {
+		t.Fatalf("SetGateway returned error: %v", err)
+	}
+	if ActiveGateway.Endpoint != "https://gateway.example.com/gemini" || ActiveGateway.BearerToken != "file-token" || ActiveGateway.Headers.Get("X-Team") != "resumes" {
+		t.Errorf("ActiveGateway = %+v", ActiveGateway)
+	}
+
+	t.Setenv(GatewayTokenEnv, "env-token")
+	if err := SetGateway("", "file-token", ""); err != nil {
+		t.Fatalf("SetGateway returned error: %v", err)
+	}
+	if ActiveGateway.BearerToken != "env-token" {
+		t.Errorf("BearerToken = %q, want the token from %s", ActiveGateway.BearerToken, GatewayTokenEnv)
+	}
+
+	before := ActiveGateway
+	for _, endpoint := range []string{"gateway.example.com", "ftp://gateway.example.com", "https://"} {
+		if err := SetGateway(endpoint, "", ""); err == nil {
+			t.Errorf("SetGateway(%q) returned no error", endpoint)
+		}
+	}
+	if err := SetGateway("", "", "not a header"); err == nil {
+		t.Error("SetGateway with an invalid header returned no error")
+	}
+	if ActiveGateway.BearerToken != before.BearerToken || ActiveGateway.Endpoint != before.Endpoint {
+		t.Errorf("invalid settings changed ActiveGateway to %+v", ActiveGateway)
+	}
+}
+
+func TestGatewayRequests(t *testing.T) {
+	t.Cleanup(func() { ActiveGateway = Gateway{} })
+
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "# Jane Doe"}]}, "finishReason": "STOP"}]}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(GatewayTokenEnv, "")
+	if err := SetGateway(server.URL, "secret-token", "X-Org-Id: 1234"); err != nil {
+		t.Fatalf("SetGateway returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	client, model, err := InitializeClient(ctx, "test-key")
+	if err != nil {
+		t.Fatalf("InitializeClient returned error: %v", err)
+	}
+	defer client.Close()
+
+	resp, err := model.GenerateContent(ctx, genai.Text("notes"))
+	if err != nil {
+		t.Fatalf("GenerateContent returned error: %v", err)
+	}
+	if text, _ := ProcessResponse(resp); !strings.Contains(text, "Jane Doe") {
+		t.Errorf("response = %q, want the gateway's reply", text)
+	}
+
+	if received == nil {
+		t.Fatal("the request did not reach the gateway")
+	}
+	for name, want := range map[string]string{
+		"Authorization":  "Bearer secret-token",
+		"X-Org-Id":       "1234",
+		"X-Goog-Api-Key": "test-key",
+	} {
+		if got := received.Header.Get(name); got != want {
+			t.Errorf("header %s = %q, want %q", name, got, want)
+		}
+	}
+}
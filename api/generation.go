@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// DefaultTemperature is the sampling temperature requested for every
+// response, balanced between creativity and determinism.
+const DefaultTemperature float32 = 0.7
+
+// generationConfigKey is the context key of the config set with WithGenerationConfig.
+type generationConfigKey struct{}
+
+// DefaultGenerationConfig returns the generation settings ExecuteRequest and
+// Session send with a request whose context carries none: MaxOutputTokens
+// and DefaultTemperature.
+//
+// Returns:
+//   - genai.GenerationConfig: The default settings
+func DefaultGenerationConfig() genai.GenerationConfig {
+	return genai.GenerationConfig{
+		MaxOutputTokens: genai.Ptr[int32](MaxOutputTokens),
+		Temperature:     genai.Ptr(DefaultTemperature),
+	}
+}
+
+// WithGenerationConfig returns a context whose requests are sent with
+// config's output token limit, temperature, top-p, and top-k, where set. The
+// settings travel with the request rather than being set on the model, which
+// is shared by every request sent with it, so concurrent requests cannot
+// change each other's settings; they are added to the request by the
+// client's transport. A seed set with WithSeed still sets the temperature to 0.
+//
+// Parameters:
+//   - ctx: The context for the requests
+//   - config: The settings to send
+//
+// Returns:
+//   - context.Context: The context carrying the settings
+//
+// Example:
+//
+//	config := api.DefaultGenerationConfig()
+//	config.SetTemperature(0.2)
+//	response, err := api.ExecuteRequest(api.WithGenerationConfig(ctx, config), model, content)
+func WithGenerationConfig(ctx context.Context, config genai.GenerationConfig) context.Context {
+	return context.WithValue(ctx, generationConfigKey{}, config)
+}
+
+// GenerationConfigFromContext returns the settings set with WithGenerationConfig.
+//
+// Parameters:
+//   - ctx: The context of a request
+//
+// Returns:
+//   - genai.GenerationConfig: The settings
+//   - bool: Whether settings are set
+func GenerationConfigFromContext(ctx context.Context) (genai.GenerationConfig, bool) {
+	config, ok := ctx.Value(generationConfigKey{}).(genai.GenerationConfig)
+	return config, ok
+}
+
+// withDefaultGenerationConfig returns ctx carrying DefaultGenerationConfig,
+// unless it already carries settings.
+func withDefaultGenerationConfig(ctx context.Context) context.Context {
+	if _, ok := GenerationConfigFromContext(ctx); ok {
+		return ctx
+	}
+	return WithGenerationConfig(ctx, DefaultGenerationConfig())
+}
+
+// settingsEdit sets the output token limit, temperature, top-p, and top-k
+// that settings sets in a generationConfig.
+func settingsEdit(settings genai.GenerationConfig) configEdit {
+	return func(config map[string]json.RawMessage) {
+		if settings.MaxOutputTokens != nil {
+			setConfig(config, "maxOutputTokens", *settings.MaxOutputTokens)
+		}
+		if settings.Temperature != nil {
+			setConfig(config, "temperature", *settings.Temperature)
+		}
+		if settings.TopP != nil {
+			setConfig(config, "topP", *settings.TopP)
+		}
+		if settings.TopK != nil {
+			setConfig(config, "topK", *settings.TopK)
+		}
+	}
+}
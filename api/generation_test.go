@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestSettingsEdit(t *testing.T) {
+	settings := genai.GenerationConfig{}
+	settings.SetMaxOutputTokens(100)
+	settings.SetTemperature(0.5)
+	body, err := editGenerationConfig([]byte(`{"contents":[],"generationConfig":{"stopSequences":["END"]}}`), settingsEdit(settings))
+	if err != nil {
+		t.Fatalf("editGenerationConfig() error = %v", err)
+	}
+
+	var request struct {
+		Contents         []any          `json:"contents"`
+		GenerationConfig map[string]any `json:"generationConfig"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("editGenerationConfig() returned invalid JSON: %v", err)
+	}
+	config := request.GenerationConfig
+	if config["maxOutputTokens"] != float64(100) || config["temperature"] != float64(0.5) || config["stopSequences"] == nil {
+		t.Errorf("generationConfig = %v, want maxOutputTokens 100, temperature 0.5, and the stop sequences kept", config)
+	}
+	if _, ok := config["topP"]; ok {
+		t.Errorf("Expected settings that are not set to be left out, got %v", config)
+	}
+	if request.Contents == nil {
+		t.Error("Expected the rest of the request to be kept")
+	}
+}
+
+func TestGenerationConfigRequests(t *testing.T) {
+	var mu sync.Mutex
+	var configs []map[string]any
+	useFakeClients(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			GenerationConfig map[string]any `json:"generationConfig"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &request)
+		mu.Lock()
+		configs = append(configs, request.GenerationConfig)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "# Jane Doe"}]}, "finishReason": "STOP"}]}`))
+	}))
+
+	client, model, err := InitializeClient(context.Background(), "test-key")
+	if err != nil {
+		t.Fatalf("InitializeClient returned error: %v", err)
+	}
+	defer client.Close()
+	content := genai.NewUserContent(genai.Text("notes"))
+
+	// Test case 1: Requests are sent with the default settings, and the model is left alone
+	if _, err := ExecuteRequest(context.Background(), model, content); err != nil {
+		t.Fatalf("ExecuteRequest returned error: %v", err)
+	}
+	if model.MaxOutputTokens != nil || model.Temperature != nil {
+		t.Error("Expected ExecuteRequest to leave the shared model's settings unchanged")
+	}
+
+	// Test case 2: Concurrent requests keep their own settings
+	var wg sync.WaitGroup
+	for _, temperature := range []float32{0.25, 1.5} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			config := DefaultGenerationConfig()
+			config.SetTemperature(temperature)
+			if _, err := ExecuteRequest(WithGenerationConfig(context.Background(), config), model, content); err != nil {
+				t.Errorf("ExecuteRequest returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Test case 3: A seed still sets the temperature to 0
+	if _, err := ExecuteRequest(WithSeed(context.Background(), 7), model, content); err != nil {
+		t.Fatalf("ExecuteRequest returned error: %v", err)
+	}
+
+	if len(configs) != 4 {
+		t.Fatalf("Expected 4 requests, got %d", len(configs))
+	}
+	if configs[0]["maxOutputTokens"] != float64(MaxOutputTokens) || configs[0]["temperature"] != 0.7 {
+		t.Errorf("Expected the default settings, got %v", configs[0])
+	}
+	temperatures := map[any]bool{configs[1]["temperature"]: true, configs[2]["temperature"]: true}
+	if !temperatures[float64(0.25)] || !temperatures[float64(1.5)] {
+		t.Errorf("Expected each concurrent request to keep its temperature, got %v and %v", configs[1], configs[2])
+	}
+	if configs[3]["temperature"] != float64(0) || configs[3]["seed"] != float64(7) {
+		t.Errorf("Expected the seed's temperature of 0, got %v", configs[3])
+	}
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/iterator"
+)
+
+// KeyStatus is the outcome of checking a Gemini API key.
+type KeyStatus string
+
+const (
+	// KeyMissing means GEMINI_API_KEY is not set.
+	KeyMissing KeyStatus = "missing"
+
+	// KeyMalformed means the key cannot be a Gemini API key, such as one
+	// copied with quotes or missing characters.
+	KeyMalformed KeyStatus = "malformed"
+
+	// KeyInvalid means the API rejected the key: it is wrong, expired,
+	// revoked, or not allowed to use the Gemini API.
+	KeyInvalid KeyStatus = "invalid"
+
+	// KeyUnreachable means the API could not be reached to check the key.
+	KeyUnreachable KeyStatus = "unreachable"
+
+	// KeyUnverified means the key looks right but was not checked with the API.
+	KeyUnverified KeyStatus = "unverified"
+
+	// KeyValid means the API accepted the key.
+	KeyValid KeyStatus = "valid"
+)
+
+// KeyDiagnosis describes what is wrong with an API key, if anything, and
+// what to do about it.
+type KeyDiagnosis struct {
+	Status  KeyStatus // The outcome of the check
+	Summary string    // One line for the user, e.g. "API key was rejected"
+	Advice  string    // What the user can do about it (empty if nothing)
+	Err     error     // The error from the API request, if one was made and failed
+}
+
+// Usable reports whether generation can be attempted with the key. A key
+// that could not be checked because the API was unreachable is usable,
+// since the connection may return before generation starts.
+func (d KeyDiagnosis) Usable() bool {
+	switch d.Status {
+	case KeyValid, KeyUnverified, KeyUnreachable:
+		return true
+	}
+	return false
+}
+
+// String returns the summary followed by the advice.
+func (d KeyDiagnosis) String() string {
+	if d.Advice == "" {
+		return d.Summary
+	}
+	return d.Summary + ". " + d.Advice
+}
+
+// apiKeyURL is where Gemini API keys are created.
+const apiKeyURL = "https://aistudio.google.com/app/apikey"
+
+// geminiKeyPattern matches the form of keys issued by Google AI Studio.
+var geminiKeyPattern = regexp.MustCompile(`^AIza[0-9A-Za-z_-]{35}$`)
+
+// DiagnoseAPIKey checks the form of an API key without contacting the API.
+// Keys used with an API gateway (see SetGateway) may have any form, so only
+// stray whitespace and quotes are reported for them.
+//
+// Parameters:
+//   - apiKey: The API key, as read from GEMINI_API_KEY
+//
+// Returns:
+//   - KeyDiagnosis: KeyMissing, KeyMalformed, or KeyUnverified
+//
+// Example:
+//
+//	apiKey, _ := api.GetAPIKey()
+//	if diagnosis := api.DiagnoseAPIKey(apiKey); !diagnosis.Usable() {
+//	    fmt.Println(diagnosis)
+//	}
+func DiagnoseAPIKey(apiKey string) KeyDiagnosis {
+	if strings.TrimSpace(apiKey) == "" {
+		return KeyDiagnosis{
+			Status:  KeyMissing,
+			Summary: "API key is missing",
+			Advice:  "Set GEMINI_API_KEY to a key from " + apiKeyURL,
+		}
+	}
+
+	if strings.ContainsAny(apiKey, " \t\r\n\"'") {
+		return KeyDiagnosis{
+			Status:  KeyMalformed,
+			Summary: "API key is malformed: it contains spaces or quotes",
+			Advice:  "Copy the key again without surrounding quotes or spaces, e.g. export GEMINI_API_KEY=AIza...",
+		}
+	}
+	if !ActiveGateway.Enabled() && !geminiKeyPattern.MatchString(apiKey) {
+		return KeyDiagnosis{
+			Status:  KeyMalformed,
+			Summary: fmt.Sprintf("API key is malformed: Gemini keys start with AIza and are 39 characters long, this one is %d", len(apiKey)),
+			Advice:  "Check that the whole key was copied, or create a new one at " + apiKeyURL,
+		}
+	}
+
+	return KeyDiagnosis{Status: KeyUnverified, Summary: "API key is set (not checked with the API)"}
+}
+
+// VerifyAPIKey checks the form of an API key and then makes a lightweight
+// request with it, listing the available models, which uses no quota. The
+// request goes through ActiveGateway like every other request.
+//
+// Parameters:
+//   - ctx: The context for the request; give it a timeout so an unreachable
+//     API does not hang the check
+//   - apiKey: The API key, as read from GEMINI_API_KEY
+//
+// Returns:
+//   - KeyDiagnosis: The outcome; KeyUnverified if the API failed in a way
+//     that says nothing about the key
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	diagnosis := api.VerifyAPIKey(ctx, apiKey)
+func VerifyAPIKey(ctx context.Context, apiKey string) KeyDiagnosis {
+	diagnosis := DiagnoseAPIKey(apiKey)
+	if diagnosis.Status != KeyUnverified {
+		return diagnosis
+	}
+
+	client, err := ActiveClientFactory.NewClient(ctx, apiKey)
+	if err != nil {
+		return diagnoseKeyError(err)
+	}
+	defer client.Close()
+
+	if _, err := client.ListModels(ctx).Next(); err != nil && !errors.Is(err, iterator.Done) {
+		return diagnoseKeyError(err)
+	}
+	return KeyDiagnosis{Status: KeyValid, Summary: "API key is valid and ready to use"}
+}
+
+// diagnoseKeyError describes the failure of the request checking a key.
+func diagnoseKeyError(err error) KeyDiagnosis {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded) {
+		return unreachableKeyDiagnosis(err)
+	}
+
+	switch ClassifyError(err).Code {
+	case CodeAuth:
+		return KeyDiagnosis{
+			Status:  KeyInvalid,
+			Summary: "API key was rejected: it is invalid, expired, or not enabled for the Gemini API",
+			Advice:  "Create a new key at " + apiKeyURL + " and set GEMINI_API_KEY to it",
+			Err:     err,
+		}
+	case CodeNetwork:
+		return unreachableKeyDiagnosis(err)
+	case CodeQuota:
+		// Only an accepted key has a quota to exceed
+		return KeyDiagnosis{
+			Status:  KeyValid,
+			Summary: "API key is valid, but its quota or rate limit is exceeded right now",
+			Advice:  "Wait a few minutes before generating",
+			Err:     err,
+		}
+	}
+	return KeyDiagnosis{
+		Status:  KeyUnverified,
+		Summary: "API key could not be checked: " + ClassifyError(err).summary,
+		Err:     err,
+	}
+}
+
+// unreachableKeyDiagnosis is the diagnosis when the API could not be reached.
+func unreachableKeyDiagnosis(err error) KeyDiagnosis {
+	advice := "Check your internet connection or proxy settings; the key itself may be fine"
+	if ActiveGateway.Endpoint != "" {
+		advice = "Check that the API gateway at " + ActiveGateway.Endpoint + " is running and reachable"
+	}
+	return KeyDiagnosis{
+		Status:  KeyUnreachable,
+		Summary: "Could not reach the Gemini API to check the key",
+		Advice:  advice,
+		Err:     err,
+	}
+}
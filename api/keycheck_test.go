@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiagnoseAPIKey(t *testing.T) {
+	validKey := "AIza" + strings.Repeat("x", 35)
+	tests := []struct {
+		name   string
+		key    string
+		status KeyStatus
+	}{
+		{"empty", "", KeyMissing},
+		{"whitespace only", "  ", KeyMissing},
+		{"well-formed", validKey, KeyUnverified},
+		{"quoted", `"` + validKey + `"`, KeyMalformed},
+		{"trailing newline", validKey + "\n", KeyMalformed},
+		{"truncated", validKey[:30], KeyMalformed},
+		{"wrong prefix", "sk-" + strings.Repeat("x", 36), KeyMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnosis := DiagnoseAPIKey(tt.key)
+			if diagnosis.Status != tt.status {
+				t.Errorf("DiagnoseAPIKey(%q).Status = %q, want %q (%s)", tt.key, diagnosis.Status, tt.status, diagnosis)
+			}
+			if diagnosis.Summary == "" {
+				t.Error("diagnosis has no summary")
+			}
+		})
+	}
+}
+
+func TestDiagnoseAPIKeyWithGateway(t *testing.T) {
+	t.Cleanup(func() { ActiveGateway = Gateway{} })
+	ActiveGateway = Gateway{Endpoint: "https://gateway.example.com"}
+
+	// Gateways may issue keys of any form
+	if diagnosis := DiagnoseAPIKey("gateway-key"); diagnosis.Status != KeyUnverified {
+		t.Errorf("Status = %q, want %q", diagnosis.Status, KeyUnverified)
+	}
+	if diagnosis := DiagnoseAPIKey("'gateway-key'"); diagnosis.Status != KeyMalformed {
+		t.Errorf("Status = %q for a quoted key, want %q", diagnosis.Status, KeyMalformed)
+	}
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	t.Cleanup(func() { ActiveGateway = Gateway{} })
+	t.Setenv(GatewayTokenEnv, "")
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   KeyStatus
+		usable bool
+	}{
+		{
+			name:   "accepted",
+			status: http.StatusOK,
+			body:   `{"models": [{"name": "models/gemini-2.0-flash"}]}`,
+			want:   KeyValid,
+			usable: true,
+		},
+		{
+			name:   "invalid key",
+			status: http.StatusBadRequest,
+			body:   `{"error": {"code": 400, "message": "API key not valid. Please pass a valid API key.", "status": "INVALID_ARGUMENT"}}`,
+			want:   KeyInvalid,
+		},
+		{
+			name:   "expired key",
+			status: http.StatusBadRequest,
+			body:   `{"error": {"code": 400, "message": "API key expired. Please renew the API key.", "status": "INVALID_ARGUMENT"}}`,
+			want:   KeyInvalid,
+		},
+		{
+			name:   "quota exceeded",
+			status: http.StatusTooManyRequests,
+			body:   `{"error": {"code": 429, "message": "Quota exceeded", "status": "RESOURCE_EXHAUSTED"}}`,
+			want:   KeyValid,
+			usable: true,
+		},
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+			body:   `{"error": {"code": 500, "message": "Internal error", "status": "INTERNAL"}}`,
+			want:   KeyUnverified,
+			usable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			if err := SetGateway(server.URL, "", ""); err != nil {
+				t.Fatalf("SetGateway returned error: %v", err)
+			}
+
+			diagnosis := VerifyAPIKey(context.Background(), "test-key")
+			if diagnosis.Status != tt.want {
+				t.Errorf("Status = %q, want %q (%s, %v)", diagnosis.Status, tt.want, diagnosis, diagnosis.Err)
+			}
+			if diagnosis.Usable() != tt.usable {
+				t.Errorf("Usable() = %v, want %v", diagnosis.Usable(), tt.usable)
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		if err := SetGateway(server.URL, "", ""); err != nil {
+			t.Fatalf("SetGateway returned error: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		diagnosis := VerifyAPIKey(ctx, "test-key")
+		if diagnosis.Status != KeyUnreachable {
+			t.Errorf("Status = %q, want %q (%v)", diagnosis.Status, KeyUnreachable, diagnosis.Err)
+		}
+		if !strings.Contains(diagnosis.Advice, server.URL) {
+			t.Errorf("Advice = %q, want it to name the gateway", diagnosis.Advice)
+		}
+	})
+
+	t.Run("malformed keys are not sent", func(t *testing.T) {
+		ActiveGateway = Gateway{}
+		if diagnosis := VerifyAPIKey(context.Background(), "not-a-key"); diagnosis.Status != KeyMalformed {
+			t.Errorf("Status = %q, want %q", diagnosis.Status, KeyMalformed)
+		}
+	})
+}
@@ -4,20 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/logging"
 )
 
 // ModelInterface defines the minimal interface needed for executing API requests
 type ModelInterface interface {
 	GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error)
-	SetMaxOutputTokens(tokens int32)
-	SetTemperature(temp float32)
 }
 
 // ExecuteRequest sends the provided content to the Gemini API and returns the response.
-// It requires a valid model, content, and a context for the request.
+// It requires a valid model, content, and a context for the request. The
+// request is sent with the generation settings set on ctx with
+// WithGenerationConfig, or DefaultGenerationConfig; the model itself is not
+// changed, so it can be shared by concurrent requests.
 func ExecuteRequest(ctx context.Context, model ModelInterface, content *genai.Content) (*genai.GenerateContentResponse, error) {
 	// Input validation
 	if model == nil {
@@ -27,13 +28,18 @@ func ExecuteRequest(ctx context.Context, model ModelInterface, content *genai.Co
 		return nil, errors.New("content cannot be nil")
 	}
 
-	// Set generation parameters
-	model.SetMaxOutputTokens(8192)
-	model.SetTemperature(0.7) // Balanced between creativity and determinism
+	// Set generation parameters on this request only
+	ctx = withDefaultGenerationConfig(ctx)
 
-	// Make the API request
-	fmt.Println("Sending request to Gemini API...")
+	// Make the API request; the debug log, rather than standard output, notes
+	// it, since standard output may carry the resume or the TUI
+	logging.Debugf("Sending request to Gemini API...")
 	response, err := model.GenerateContent(ctx, content.Parts...)
+	if blockedBySafety(response, err) {
+		// Asking for more candidates gives one that passes the filters a chance
+		logging.Debugf("Response blocked by safety filters; asking again for %d candidates", SafetyCandidateCount)
+		response, err = model.GenerateContent(WithCandidateCount(ctx, SafetyCandidateCount), content.Parts...)
+	}
 	if err != nil {
 		// Parse the error to provide more detailed information
 		return nil, handleAPIError(err)
@@ -44,49 +50,20 @@ func ExecuteRequest(ctx context.Context, model ModelInterface, content *genai.Co
 		return nil, errors.New("received nil response from API")
 	}
 
+	promoteCandidate(response)
 	return response, nil
 }
 
-// handleAPIError parses API errors and returns more user-friendly messages
-// with potential solutions when possible.
+// handleAPIError classifies an API error (see ClassifyError), so that its
+// message is user-friendly and suggests a solution when possible.
 func handleAPIError(err error) error {
-	errorMsg := err.Error()
-	
-	// Handle quota exceeded errors
-	if strings.Contains(errorMsg, "RESOURCE_EXHAUSTED") || 
-	   strings.Contains(errorMsg, "Quota exceeded") ||
-	   strings.Contains(errorMsg, "rate limit") {
-		return fmt.Errorf("API quota or rate limit exceeded: %w. "+
-			"Please wait a few minutes and retry, or check your quota management settings", err)
-	}
-	
-	// Handle authentication errors
-	if strings.Contains(errorMsg, "UNAUTHENTICATED") || 
-	   strings.Contains(errorMsg, "API key") ||
-	   strings.Contains(errorMsg, "authentication") {
-		return fmt.Errorf("API authentication error: %w. "+
-			"Please verify your GEMINI_API_KEY environment variable is correct and valid", err)
-	}
-	
-	// Handle network/timeout errors
-	if strings.Contains(errorMsg, "deadline exceeded") ||
-	   strings.Contains(errorMsg, "connection") ||
-	   strings.Contains(errorMsg, "network") {
-		return fmt.Errorf("network error while contacting API: %w. "+
-			"Please check your internet connection and try again", err)
-	}
-	
-	// Handle invalid request errors
-	if strings.Contains(errorMsg, "INVALID_ARGUMENT") {
-		return fmt.Errorf("invalid request to API: %w. "+
-			"Please check the format of your prompt", err)
-	}
-	
-	// Default case for unrecognized errors
-	return fmt.Errorf("error generating content: %w", err)
+	return ClassifyError(err)
 }
 
 // ProcessResponse extracts and processes the text from the API response.
+// When the first candidate was blocked by safety filters, the remaining
+// candidates are checked before failing, and the first that finished
+// normally is used (see PickCandidate).
 // Returns the generated text and any error that occurred.
 func ProcessResponse(response *genai.GenerateContentResponse) (string, error) {
 	// Input validation
@@ -99,7 +76,8 @@ func ProcessResponse(response *genai.GenerateContentResponse) (string, error) {
 		return "", errors.New("no candidates in response")
 	}
 
-	candidate := response.Candidates[0]
+	// A candidate blocked by safety filters is passed over for a later one that was not
+	candidate := PickCandidate(response)
 	
 	// Handle specific error conditions
 	if candidate.FinishReason != genai.FinishReasonStop && candidate.FinishReason != genai.FinishReasonUnspecified {
@@ -24,17 +24,9 @@ func (m *MockGenerativeModel) GenerateContent(ctx context.Context, parts ...gena
 	return nil, errors.New("not implemented")
 }
 
-// SetMaxOutputTokens is a mock implementation
-func (m *MockGenerativeModel) SetMaxOutputTokens(tokens int32) {}
-
-// SetTemperature is a mock implementation
-func (m *MockGenerativeModel) SetTemperature(temp float32) {}
-
 // ExecuteRequestInterface is a minimal interface for our mock to implement
 type ExecuteRequestInterface interface {
 	GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error)
-	SetMaxOutputTokens(tokens int32)
-	SetTemperature(temp float32)
 }
 
 func TestExecuteRequest(t *testing.T) {
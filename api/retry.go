@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"google.golang.org/api/googleapi"
+)
+
+// retryDelayPatterns match how long an error message asks to wait before
+// retrying, capturing the number and its unit (seconds when empty): the
+// Gemini API's "Please retry in 41.2s", the RetryInfo detail as JSON
+// ("retryDelay": "37s") or as text (retry_delay:{seconds:37}), and a
+// quoted Retry-After header.
+var retryDelayPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bretry in (\d+(?:\.\d+)?)\s*(ms|s|sec|secs|seconds?|m|min|mins|minutes?)\b`),
+	regexp.MustCompile(`(?i)"?retry_?delay"?\s*[:=]\s*"?(\d+(?:\.\d+)?)(ms|s)?\b`),
+	regexp.MustCompile(`(?i)\bretry_delay:\s*\{\s*seconds:\s*(\d+)()`),
+	regexp.MustCompile(`(?i)\bretry-after:\s*(\d+)()\b`),
+}
+
+// ParseRetryAfter reads the value of an HTTP Retry-After header, which is
+// either a number of seconds or the date after which to retry.
+//
+// Parameters:
+//   - value: The header value, such as "120" or "Wed, 21 Oct 2015 07:28:00 GMT"
+//   - now: The current time, to turn a date into a wait
+//
+// Returns:
+//   - time.Duration: How long to wait (0 for a date that has passed)
+//   - bool: Whether the value could be read
+//
+// Example:
+//
+//	wait, ok := api.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// RetryDelay returns how long a failed request asked to be waited out before
+// retrying: the RetryInfo detail of the API error, the Retry-After header of
+// its response, or a wait named in its message. It returns 0 when the error
+// does not say.
+//
+// Parameters:
+//   - err: An error returned by the Gemini client
+//   - now: The current time, for Retry-After dates
+//
+// Returns:
+//   - time.Duration: The wait, rounded up to a whole second (0 if unknown)
+//
+// Example:
+//
+//	if wait := api.RetryDelay(err, time.Now()); wait > 0 {
+//	    time.Sleep(wait)
+//	}
+func RetryDelay(err error, now time.Time) time.Duration {
+	if err == nil {
+		return 0
+	}
+
+	var gaxErr *apierror.APIError
+	if errors.As(err, &gaxErr) {
+		if delay := gaxErr.Details().RetryInfo.GetRetryDelay(); delay != nil {
+			return roundUpSecond(delay.AsDuration())
+		}
+	}
+	var httpErr *googleapi.Error
+	if errors.As(err, &httpErr) && httpErr.Header != nil {
+		if wait, ok := ParseRetryAfter(httpErr.Header.Get("Retry-After"), now); ok {
+			return roundUpSecond(wait)
+		}
+	}
+
+	message := err.Error()
+	for _, pattern := range retryDelayPatterns {
+		match := pattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		amount, parseErr := strconv.ParseFloat(match[1], 64)
+		if parseErr != nil {
+			continue
+		}
+		unit := time.Second
+		switch strings.ToLower(match[2]) {
+		case "ms":
+			unit = time.Millisecond
+		case "m", "min", "mins", "minute", "minutes":
+			unit = time.Minute
+		}
+		return roundUpSecond(time.Duration(amount * float64(unit)))
+	}
+	return 0
+}
+
+// roundUpSecond rounds a positive wait up to a whole second, so a retry is
+// never sent before the server is ready for it.
+func roundUpSecond(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(d.Seconds())) * time.Second
+}
+
+// FormatWait describes a wait in whole seconds for messages and countdowns,
+// such as "45s" or "2m 05s".
+//
+// Parameters:
+//   - d: The wait
+//
+// Returns:
+//   - string: The wait, rounded up to a whole second
+func FormatWait(d time.Duration) string {
+	seconds := int(roundUpSecond(d) / time.Second)
+	if seconds < 60 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+	if seconds < 3600 {
+		return fmt.Sprintf("%dm %02ds", seconds/60, seconds%60)
+	}
+	return fmt.Sprintf("%dh %02dm", seconds/3600, (seconds%3600)/60)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"google.golang.org/api/googleapi"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		// Test case 1: A number of seconds
+		{"120", 2 * time.Minute, true},
+		// Test case 2: A date
+		{"Fri, 10 May 2024 09:00:45 GMT", 45 * time.Second, true},
+		// Test case 3: A date that has passed
+		{"Fri, 10 May 2024 08:00:00 GMT", 0, true},
+		// Test case 4: Values that are neither
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := ParseRetryAfter(tt.value, now); got != tt.want || ok != tt.ok {
+			t.Errorf("ParseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Now()
+
+	// The RetryInfo detail of a REST error, as the Gemini client returns it
+	body := `{"error": {"code": 429, "message": "Resource has been exhausted", "status": "RESOURCE_EXHAUSTED",
+		"details": [{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "37s"}]}}`
+	gaxErr, ok := apierror.FromError(&googleapi.Error{Code: 429, Message: "Resource has been exhausted", Body: body})
+	if !ok {
+		t.Fatal("Failed to build the API error")
+	}
+
+	header := http.Header{}
+	header.Set("Retry-After", "90")
+
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{"RetryInfo detail", fmt.Errorf("generating: %w", gaxErr), 37 * time.Second},
+		{"Retry-After header", &googleapi.Error{Code: 429, Header: header}, 90 * time.Second},
+		{"wait in the message", errors.New("Quota exceeded for metric. Please retry in 41.2s."), 42 * time.Second},
+		{"retryDelay JSON in the message", errors.New(`RESOURCE_EXHAUSTED "retryDelay": "12s"`), 12 * time.Second},
+		{"no wait", errors.New("googleapi: Error 429: rate limit"), 0},
+		{"nil", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryDelay(tt.err, now); got != tt.want {
+				t.Errorf("RetryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A classified error keeps the wait and advises it
+	apiErr := ClassifyError(errors.New("RESOURCE_EXHAUSTED: Please retry in 30s."))
+	if apiErr.RetryAfter != 30*time.Second || apiErr.Advice != "The API asks to wait 30s before retrying" {
+		t.Errorf("Expected a 30s wait in the advice, got %v and %q", apiErr.RetryAfter, apiErr.Advice)
+	}
+}
+
+func TestFormatWait(t *testing.T) {
+	tests := map[time.Duration]string{
+		500 * time.Millisecond: "1s",
+		45 * time.Second:       "45s",
+		125 * time.Second:      "2m 05s",
+		90 * time.Minute:       "1h 30m",
+	}
+	for wait, want := range tests {
+		if got := FormatWait(wait); got != want {
+			t.Errorf("FormatWait(%v) = %q, want %q", wait, got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// seedKey is the context key of the seed set with WithSeed.
+type seedKey struct{}
+
+// WithSeed returns a context whose requests are sampled deterministically:
+// each is sent with the seed and a temperature of 0, so the same prompt to
+// the same model gives the same response. The SDK has no seed setting, so
+// the seed is added to the request by the client's transport (see
+// settingsTransport).
+//
+// Parameters:
+//   - ctx: The context for the requests
+//   - seed: The sampling seed
+//
+// Returns:
+//   - context.Context: The context carrying the seed
+//
+// Example:
+//
+//	ctx = api.WithSeed(ctx, 42)
+//	response, err := session.Send(ctx, content)
+func WithSeed(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, seedKey{}, seed)
+}
+
+// SeedFromContext returns the seed set with WithSeed.
+//
+// Parameters:
+//   - ctx: The context of a request
+//
+// Returns:
+//   - int64: The seed
+//   - bool: Whether a seed is set
+func SeedFromContext(ctx context.Context) (int64, bool) {
+	seed, ok := ctx.Value(seedKey{}).(int64)
+	return seed, ok
+}
+
+// seedEdit sets the seed and a temperature of 0 in a generationConfig.
+func seedEdit(seed int64) configEdit {
+	return func(config map[string]json.RawMessage) {
+		setConfig(config, "seed", seed)
+		config["temperature"] = json.RawMessage("0")
+	}
+}
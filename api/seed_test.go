@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestSeedEdit(t *testing.T) {
+	body, err := editGenerationConfig([]byte(`{"contents":[],"generationConfig":{"maxOutputTokens":100,"temperature":0.7}}`), seedEdit(42))
+	if err != nil {
+		t.Fatalf("editGenerationConfig() error = %v", err)
+	}
+
+	var request struct {
+		Contents         []any          `json:"contents"`
+		GenerationConfig map[string]any `json:"generationConfig"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("editGenerationConfig() returned invalid JSON: %v", err)
+	}
+	config := request.GenerationConfig
+	if config["seed"] != float64(42) || config["temperature"] != float64(0) || config["maxOutputTokens"] != float64(100) {
+		t.Errorf("generationConfig = %v, want seed 42, temperature 0, and the other settings kept", config)
+	}
+	if request.Contents == nil {
+		t.Error("Expected the rest of the request to be kept")
+	}
+}
+
+func TestSeedRequests(t *testing.T) {
+	var configs []map[string]any
+	useFakeClients(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			GenerationConfig map[string]any `json:"generationConfig"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &request)
+		configs = append(configs, request.GenerationConfig)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "# Jane Doe"}]}, "finishReason": "STOP"}]}`))
+	}))
+
+	client, model, err := InitializeClient(context.Background(), "test-key")
+	if err != nil {
+		t.Fatalf("InitializeClient returned error: %v", err)
+	}
+	defer client.Close()
+
+	// Test case 1: Requests without a seed are sent as they are
+	if _, err := model.GenerateContent(context.Background(), genai.Text("notes")); err != nil {
+		t.Fatalf("GenerateContent returned error: %v", err)
+	}
+
+	// Test case 2: Requests with a seed are sampled deterministically
+	if _, err := model.GenerateContent(WithSeed(context.Background(), 7), genai.Text("notes")); err != nil {
+		t.Fatalf("GenerateContent returned error: %v", err)
+	}
+
+	if len(configs) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(configs))
+	}
+	if _, ok := configs[0]["seed"]; ok {
+		t.Errorf("Expected no seed without WithSeed, got %v", configs[0])
+	}
+	if configs[1]["seed"] != float64(7) {
+		t.Errorf("Expected seed 7, got %v", configs[1])
+	}
+	if configs[1]["temperature"] != float64(0) {
+		t.Errorf("Expected temperature 0, got %v", configs[1])
+	}
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/logging"
+)
+
+// MaxContinuations is the number of times a truncated response is continued
+// before the partial result is accepted as-is.
+const MaxContinuations = 2
+
+// ContinuationPrompt asks the model to pick up a response that stopped at the
+// token limit. Because it is sent on the same chat session, the model sees its
+// own partial answer and the original prompt is not resent.
+const ContinuationPrompt = "Your previous response was cut off because it reached the output limit. " +
+	"Continue exactly where you stopped, without repeating any text, and end with the line " + ResumeEndDelimiter + "."
+
+// ChatSender is the subset of genai.ChatSession used by Session.
+// It allows tests to substitute a fake conversation.
+type ChatSender interface {
+	SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error)
+}
+
+// Session is a multi-turn conversation with a model. The first message carries
+// the full prompt; refinements, continuations of truncated output, and
+// follow-up questions are sent as further turns that reuse the conversation
+// history instead of resending the whole prompt.
+type Session struct {
+	chat ChatSender
+	base *genai.ChatSession // The SDK conversation under chat, if any, whose history a retry corrects
+}
+
+// NewSession starts a chat session on model. Its turns are sent with the
+// same generation settings as ExecuteRequest, without changing the model.
+//
+// Parameters:
+//   - model: The configured model to converse with
+//
+// Returns:
+//   - *Session: The new session
+//   - error: An error if the model is nil
+//
+// Example:
+//
+//	session, err := api.NewSession(model)
+//	response, err := session.Send(ctx, promptContent)
+//	refined, err := session.Refine(ctx, "Shorten the summary to two sentences")
+func NewSession(model *genai.GenerativeModel) (*Session, error) {
+	if model == nil {
+		return nil, errors.New("model cannot be nil")
+	}
+
+	chat := model.StartChat()
+	return &Session{chat: streamingChat{chat}, base: chat}, nil
+}
+
+// NewSessionWithSender creates a session backed by an arbitrary ChatSender.
+// This is primarily useful for testing.
+//
+// Parameters:
+//   - chat: The conversation to send messages on
+//
+// Returns:
+//   - *Session: The new session
+func NewSessionWithSender(chat ChatSender) *Session {
+	return &Session{chat: chat}
+}
+
+// Send sends content as the next turn of the conversation. A turn whose
+// response is blocked by safety filters is sent once more asking for
+// SafetyCandidateCount candidates, and the first that passes is used.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - content: The content to send
+//
+// Returns:
+//   - *genai.GenerateContentResponse: The model's response
+//   - error: A user-friendly error if the request failed
+func (s *Session) Send(ctx context.Context, content *genai.Content) (*genai.GenerateContentResponse, error) {
+	if s == nil || s.chat == nil {
+		return nil, errors.New("session is not initialized")
+	}
+	if content == nil {
+		return nil, errors.New("content cannot be nil")
+	}
+
+	ctx = withDefaultGenerationConfig(ctx)
+	response, err := s.chat.SendMessage(ctx, content.Parts...)
+	if blockedBySafety(response, err) {
+		response, err = s.retryBlocked(ctx, content)
+	}
+	if err != nil {
+		return nil, handleAPIError(err)
+	}
+	if response == nil {
+		return nil, errors.New("received nil response from API")
+	}
+
+	promoteCandidate(response)
+	return response, nil
+}
+
+// retryBlocked sends content again after safety filters blocked its
+// response, asking for SafetyCandidateCount candidates. The blocked turn,
+// which the SDK keeps in the history without an answer, is removed first.
+// The retry is not streamed.
+func (s *Session) retryBlocked(ctx context.Context, content *genai.Content) (*genai.GenerateContentResponse, error) {
+	if s.base != nil && len(s.base.History) > 0 && s.base.History[len(s.base.History)-1].Role == "user" {
+		s.base.History = s.base.History[:len(s.base.History)-1]
+	}
+	logging.Debugf("Response blocked by safety filters; asking again for %d candidates", SafetyCandidateCount)
+	return s.chat.SendMessage(WithCandidateCount(ctx, SafetyCandidateCount), content.Parts...)
+}
+
+// Refine sends follow-up instructions, such as a requested change or a
+// question about the generated resume, as the next turn of the conversation.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - instructions: The follow-up instructions
+//
+// Returns:
+//   - *genai.GenerateContentResponse: The model's response
+//   - error: An error if the instructions are empty or the request failed
+func (s *Session) Refine(ctx context.Context, instructions string) (*genai.GenerateContentResponse, error) {
+	if strings.TrimSpace(instructions) == "" {
+		return nil, errors.New("instructions cannot be empty")
+	}
+	return s.Send(ctx, genai.NewUserContent(genai.Text(instructions)))
+}
+
+// ContinueTruncated completes a response that stopped at the token limit by
+// asking the model to continue, up to MaxContinuations times.
+//
+// Parameters:
+//   - ctx: The context for the requests
+//   - response: The truncated response
+//
+// Returns:
+//   - string: The partial text followed by every continuation
+//   - bool: True if the text is still incomplete after all continuations
+//   - error: An error if the response has no text or a continuation request failed
+func (s *Session) ContinueTruncated(ctx context.Context, response *genai.GenerateContentResponse) (string, bool, error) {
+	text, truncated, err := candidateText(response)
+	if err != nil {
+		return "", false, err
+	}
+
+	var combined strings.Builder
+	combined.WriteString(text)
+
+	for i := 0; truncated && i < MaxContinuations; i++ {
+		next, err := s.Send(ctx, genai.NewUserContent(genai.Text(ContinuationPrompt)))
+		if err != nil {
+			return "", false, err
+		}
+
+		text, truncated, err = candidateText(next)
+		if err != nil {
+			return "", false, err
+		}
+		combined.WriteString(text)
+	}
+
+	return combined.String(), truncated, nil
+}
+
+// candidateText returns the text of the first candidate and whether it
+// stopped at the token limit.
+func candidateText(response *genai.GenerateContentResponse) (string, bool, error) {
+	if response == nil || len(response.Candidates) == 0 {
+		return "", false, errors.New("no candidates in response")
+	}
+
+	candidate := PickCandidate(response)
+	if candidate == nil || candidate.Content == nil {
+		return "", false, errors.New("no content in response")
+	}
+
+	text, err := ParseGeneratedContent(candidate.Content)
+	if err != nil {
+		return "", false, err
+	}
+
+	return text, candidate.FinishReason == genai.FinishReasonMaxTokens, nil
+}
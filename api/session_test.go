@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// fakeChat is a ChatSender that replays canned replies and records every message sent
+type fakeChat struct {
+	replies []fakeReply
+	sent    []string
+}
+
+// fakeReply is a single canned reply from fakeChat
+type fakeReply struct {
+	text         string
+	finishReason genai.FinishReason
+	err          error
+}
+
+// SendMessage records the message and returns the next canned reply
+func (f *fakeChat) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	var message strings.Builder
+	for _, part := range parts {
+		if text, ok := part.(genai.Text); ok {
+			message.WriteString(string(text))
+		}
+	}
+	f.sent = append(f.sent, message.String())
+
+	if len(f.replies) == 0 {
+		return nil, errors.New("no more replies")
+	}
+	reply := f.replies[0]
+	f.replies = f.replies[1:]
+
+	if reply.err != nil {
+		return nil, reply.err
+	}
+	return textResponse(reply.text, reply.finishReason), nil
+}
+
+// textResponse builds a single-candidate response containing text
+func textResponse(text string, finishReason genai.FinishReason) *genai.GenerateContentResponse {
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{
+			{
+				Content:      &genai.Content{Parts: []genai.Part{genai.Text(text)}},
+				FinishReason: finishReason,
+			},
+		},
+	}
+}
+
+func TestNewSession(t *testing.T) {
+	// Test case 1: Nil model
+	if _, err := NewSession(nil); err == nil {
+		t.Error("Expected error for nil model, got nil")
+	}
+
+	// Test case 2: A configured model starts a session
+	client, model, err := InitializeClient(context.Background(), "test-api-key-123")
+	if err != nil {
+		t.Fatalf("Failed to initialize client: %v", err)
+	}
+	defer client.Close()
+
+	session, err := NewSession(model)
+	if err != nil || session == nil {
+		t.Fatalf("Expected a session, got err=%v", err)
+	}
+	if model.MaxOutputTokens != nil || model.Temperature != nil {
+		t.Error("Expected the session to leave the shared model's settings unchanged")
+	}
+}
+
+func TestSessionSend(t *testing.T) {
+	ctx := context.Background()
+	content := &genai.Content{Parts: []genai.Part{genai.Text("Full prompt")}}
+
+	// Test case 1: Successful turns are sent on the same conversation
+	t.Run("Multiple turns", func(t *testing.T) {
+		chat := &fakeChat{replies: []fakeReply{
+			{text: "# Resume", finishReason: genai.FinishReasonStop},
+			{text: "# Shorter Resume", finishReason: genai.FinishReasonStop},
+		}}
+		session := NewSessionWithSender(chat)
+
+		if _, err := session.Send(ctx, content); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := session.Refine(ctx, "Make it shorter"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// The refinement sends only the new instructions, not the whole prompt again
+		if len(chat.sent) != 2 || chat.sent[1] != "Make it shorter" {
+			t.Errorf("Expected refinement to send only the instructions, sent %q", chat.sent)
+		}
+	})
+
+	// Test case 2: API errors are made user-friendly
+	t.Run("API error", func(t *testing.T) {
+		session := NewSessionWithSender(&fakeChat{replies: []fakeReply{
+			{err: errors.New("RESOURCE_EXHAUSTED: Quota exceeded")},
+		}})
+
+		_, err := session.Send(ctx, content)
+		if err == nil || !strings.Contains(err.Error(), "API quota") {
+			t.Errorf("Expected a quota error, got %v", err)
+		}
+	})
+
+	// Test case 3: Invalid input
+	t.Run("Invalid input", func(t *testing.T) {
+		session := NewSessionWithSender(&fakeChat{})
+		if _, err := session.Send(ctx, nil); err == nil {
+			t.Error("Expected error for nil content")
+		}
+		if _, err := session.Refine(ctx, "  "); err == nil {
+			t.Error("Expected error for empty instructions")
+		}
+
+		var nilSession *Session
+		if _, err := nilSession.Send(ctx, content); err == nil {
+			t.Error("Expected error for nil session")
+		}
+	})
+}
+
+func TestSessionContinueTruncated(t *testing.T) {
+	ctx := context.Background()
+	truncated := textResponse("# Jane Doe\n\n## Exper", genai.FinishReasonMaxTokens)
+
+	// Test case 1: A single continuation completes the response
+	t.Run("Completes after one continuation", func(t *testing.T) {
+		chat := &fakeChat{replies: []fakeReply{
+			{text: "ience\n\n- Go", finishReason: genai.FinishReasonStop},
+		}}
+
+		text, stillTruncated, err := NewSessionWithSender(chat).ContinueTruncated(ctx, truncated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if stillTruncated {
+			t.Error("Expected the response to be complete")
+		}
+		if text != "# Jane Doe\n\n## Experience\n\n- Go" {
+			t.Errorf("Unexpected combined text: %q", text)
+		}
+		if len(chat.sent) != 1 || chat.sent[0] != ContinuationPrompt {
+			t.Errorf("Expected one continuation prompt, sent %q", chat.sent)
+		}
+	})
+
+	// Test case 2: Gives up after MaxContinuations
+	t.Run("Stops after max continuations", func(t *testing.T) {
+		var replies []fakeReply
+		for i := 0; i < MaxContinuations+1; i++ {
+			replies = append(replies, fakeReply{text: "x", finishReason: genai.FinishReasonMaxTokens})
+		}
+		chat := &fakeChat{replies: replies}
+
+		_, stillTruncated, err := NewSessionWithSender(chat).ContinueTruncated(ctx, truncated)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !stillTruncated {
+			t.Error("Expected the response to still be truncated")
+		}
+		if len(chat.sent) != MaxContinuations {
+			t.Errorf("Expected %d continuations, got %d", MaxContinuations, len(chat.sent))
+		}
+	})
+
+	// Test case 3: Continuation request fails
+	t.Run("Continuation error", func(t *testing.T) {
+		chat := &fakeChat{replies: []fakeReply{{err: errors.New("connection reset")}}}
+
+		if _, _, err := NewSessionWithSender(chat).ContinueTruncated(ctx, truncated); err == nil {
+			t.Error("Expected continuation error, got nil")
+		}
+	})
+}
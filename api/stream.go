@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
+)
+
+// StreamingChatSender is a ChatSender that can also report a response while
+// it is being generated. Sessions use it when available and fall back to
+// SendMessage otherwise, so fakes and fixture senders need not implement it.
+type StreamingChatSender interface {
+	ChatSender
+
+	// StreamMessage sends parts and calls onText with the text received so
+	// far each time a chunk arrives. It returns the complete response.
+	StreamMessage(ctx context.Context, onText func(text string), parts ...genai.Part) (*genai.GenerateContentResponse, error)
+}
+
+// streamingChat adapts a genai.ChatSession to StreamingChatSender.
+type streamingChat struct {
+	*genai.ChatSession
+}
+
+// StreamMessage streams the response and merges the chunks into a single
+// response, as if it had been returned by SendMessage. The chat history is
+// updated by the session once the stream is exhausted.
+func (c streamingChat) StreamMessage(ctx context.Context, onText func(text string), parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	iter := c.SendMessageStream(ctx, parts...)
+
+	var merged *genai.GenerateContentResponse
+	var text strings.Builder
+	for {
+		chunk, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeChunk(merged, chunk, &text)
+		onText(text.String())
+	}
+
+	if merged == nil {
+		return nil, errors.New("received empty stream from API")
+	}
+	return merged, nil
+}
+
+// mergeChunk folds a streamed chunk into the response built so far. Text is
+// accumulated in text; the finish reason, safety ratings, and usage come
+// from the latest chunk that has them.
+func mergeChunk(merged, chunk *genai.GenerateContentResponse, text *strings.Builder) *genai.GenerateContentResponse {
+	if merged == nil {
+		merged = &genai.GenerateContentResponse{PromptFeedback: chunk.PromptFeedback}
+	}
+	if chunk.UsageMetadata != nil {
+		merged.UsageMetadata = chunk.UsageMetadata
+	}
+	if len(chunk.Candidates) == 0 {
+		return merged
+	}
+
+	candidate := chunk.Candidates[0]
+	if candidate.Content != nil {
+		for _, part := range candidate.Content.Parts {
+			if t, ok := part.(genai.Text); ok {
+				text.WriteString(string(t))
+			}
+		}
+	}
+
+	if len(merged.Candidates) == 0 {
+		merged.Candidates = []*genai.Candidate{{Content: &genai.Content{Role: "model"}}}
+	}
+	result := merged.Candidates[0]
+	result.Content.Parts = []genai.Part{genai.Text(text.String())}
+	if candidate.FinishReason != genai.FinishReasonUnspecified {
+		result.FinishReason = candidate.FinishReason
+	}
+	if candidate.SafetyRatings != nil {
+		result.SafetyRatings = candidate.SafetyRatings
+	}
+	return merged
+}
+
+// SendStreaming sends content like Send, calling onText with the text
+// received so far while the response streams in. When the session cannot
+// stream, such as when replaying fixtures, it sends normally and onText is
+// not called.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - content: The content to send
+//   - onText: Called with the accumulated text after each chunk (can be nil)
+//
+// Returns:
+//   - *genai.GenerateContentResponse: The complete response
+//   - error: A user-friendly error if the request failed
+//
+// Example:
+//
+//	response, err := session.SendStreaming(ctx, promptContent, func(text string) {
+//	    draft.Update(text)
+//	})
+func (s *Session) SendStreaming(ctx context.Context, content *genai.Content, onText func(text string)) (*genai.GenerateContentResponse, error) {
+	streamer, ok := s.streamer()
+	if !ok || onText == nil || content == nil {
+		return s.Send(ctx, content)
+	}
+
+	ctx = withDefaultGenerationConfig(ctx)
+	response, err := streamer.StreamMessage(ctx, onText, content.Parts...)
+	if blockedBySafety(response, err) {
+		response, err = s.retryBlocked(ctx, content)
+	}
+	if err != nil {
+		return nil, handleAPIError(err)
+	}
+	if response == nil {
+		return nil, errors.New("received nil response from API")
+	}
+	promoteCandidate(response)
+	return response, nil
+}
+
+// streamer returns the session's conversation if it can stream.
+func (s *Session) streamer() (StreamingChatSender, bool) {
+	if s == nil || s.chat == nil {
+		return nil, false
+	}
+	streamer, ok := s.chat.(StreamingChatSender)
+	return streamer, ok
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// fakeStreamingChat is a StreamingChatSender that streams canned chunks
+type fakeStreamingChat struct {
+	fakeChat
+	chunks []*genai.GenerateContentResponse
+	err    error // Returned after every chunk has been streamed
+}
+
+// StreamMessage merges the canned chunks, reporting the text after each one
+func (f *fakeStreamingChat) StreamMessage(ctx context.Context, onText func(text string), parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	var merged *genai.GenerateContentResponse
+	var text strings.Builder
+	for _, chunk := range f.chunks {
+		merged = mergeChunk(merged, chunk, &text)
+		onText(text.String())
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return merged, nil
+}
+
+func TestSessionSendStreaming(t *testing.T) {
+	ctx := context.Background()
+	content := &genai.Content{Parts: []genai.Part{genai.Text("Full prompt")}}
+
+	// collect returns an onText callback that records every update
+	collect := func(updates *[]string) func(string) {
+		return func(text string) { *updates = append(*updates, text) }
+	}
+
+	t.Run("Chunks are reported and merged", func(t *testing.T) {
+		last := textResponse("ume", genai.FinishReasonStop)
+		last.UsageMetadata = &genai.UsageMetadata{TotalTokenCount: 42}
+		chat := &fakeStreamingChat{chunks: []*genai.GenerateContentResponse{textResponse("# Res", genai.FinishReasonUnspecified), last}}
+
+		var updates []string
+		response, err := NewSessionWithSender(chat).SendStreaming(ctx, content, collect(&updates))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if want := []string{"# Res", "# Resume"}; !reflect.DeepEqual(updates, want) {
+			t.Errorf("Updates = %q, want %q", updates, want)
+		}
+
+		text, truncated, err := candidateText(response)
+		if err != nil || text != "# Resume" || truncated {
+			t.Errorf("Expected the merged text, got %q (truncated %v, %v)", text, truncated, err)
+		}
+		if response.Candidates[0].FinishReason != genai.FinishReasonStop || response.UsageMetadata.TotalTokenCount != 42 {
+			t.Error("Expected the finish reason and usage of the last chunk")
+		}
+	})
+
+	t.Run("Errors after partial text are made user-friendly", func(t *testing.T) {
+		chat := &fakeStreamingChat{
+			chunks: []*genai.GenerateContentResponse{textResponse("# Res", genai.FinishReasonUnspecified)},
+			err:    errors.New("RESOURCE_EXHAUSTED: Quota exceeded"),
+		}
+
+		var updates []string
+		_, err := NewSessionWithSender(chat).SendStreaming(ctx, content, collect(&updates))
+		if err == nil || !strings.Contains(err.Error(), "API quota") {
+			t.Errorf("Expected a quota error, got %v", err)
+		}
+		if len(updates) != 1 {
+			t.Errorf("Expected the partial text to be reported before the error, got %q", updates)
+		}
+	})
+
+	t.Run("Sessions that cannot stream send normally", func(t *testing.T) {
+		chat := &fakeChat{replies: []fakeReply{{text: "# Resume", finishReason: genai.FinishReasonStop}}}
+
+		var updates []string
+		if _, err := NewSessionWithSender(chat).SendStreaming(ctx, content, collect(&updates)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(chat.sent) != 1 || len(updates) != 0 {
+			t.Errorf("Expected one plain send without updates, got %d sends and %q", len(chat.sent), updates)
+		}
+	})
+
+	t.Run("The fallback streams from the start", func(t *testing.T) {
+		primary := NewSessionWithSender(&fakeStreamingChat{
+			chunks: []*genai.GenerateContentResponse{textResponse("# Primary", genai.FinishReasonUnspecified)},
+			err:    errors.New("googleapi: Error 503: service unavailable"),
+		})
+		fallback := NewSessionWithSender(&fakeStreamingChat{
+			chunks: []*genai.GenerateContentResponse{textResponse("# Fallback", genai.FinishReasonStop)},
+		})
+
+		var updates []string
+		_, usedFallback, err := SendWithFallbackStreaming(ctx, primary, fallback, content, collect(&updates))
+		if err != nil || !usedFallback {
+			t.Fatalf("Expected the fallback to succeed, got %v (used fallback %v)", err, usedFallback)
+		}
+		if want := []string{"# Primary", "# Fallback"}; !reflect.DeepEqual(updates, want) {
+			t.Errorf("Updates = %q, want %q", updates, want)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// configEdit changes the generationConfig of a request body, decoded into
+// its fields.
+type configEdit func(config map[string]json.RawMessage)
+
+// setConfig sets one field of a decoded generationConfig to value.
+func setConfig(config map[string]json.RawMessage, key string, value any) {
+	valueJSON, _ := json.Marshal(value)
+	config[key] = valueJSON
+}
+
+// contextEdits returns the edits the settings carried by ctx make to the
+// generationConfig, in the order they are applied: the generation settings,
+// then the seed, whose temperature of 0 wins, then the candidate count.
+func contextEdits(ctx context.Context) []configEdit {
+	var edits []configEdit
+	if settings, ok := GenerationConfigFromContext(ctx); ok {
+		edits = append(edits, settingsEdit(settings))
+	}
+	if seed, ok := SeedFromContext(ctx); ok {
+		edits = append(edits, seedEdit(seed))
+	}
+	if count, ok := candidateCountFromContext(ctx); ok {
+		edits = append(edits, candidateCountEdit(count))
+	}
+	return edits
+}
+
+// editGenerationConfig applies edits to the generationConfig of a JSON
+// request body, keeping the rest of the body, such as the model's stop
+// sequences, as it is.
+func editGenerationConfig(body []byte, edits ...configEdit) ([]byte, error) {
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+
+	config := map[string]json.RawMessage{}
+	if raw, ok := request["generationConfig"]; ok {
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return nil, err
+		}
+	}
+	for _, edit := range edits {
+		edit(config)
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	request["generationConfig"] = configJSON
+	return json.Marshal(request)
+}
+
+// settingsTransport applies the settings carried by a request's context,
+// set with WithGenerationConfig, WithSeed, and WithCandidateCount, to
+// requests that generate content. The SDK keeps these settings on the model
+// shared by concurrent requests, so they are set in each request's body
+// instead. When a candidate count is set, the candidates blocked by safety
+// filters are also removed from the response so the SDK keeps the others.
+type settingsTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends the request, rewriting its body first when the context
+// carries settings.
+func (t settingsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	edits := contextEdits(req.Context())
+	if len(edits) == 0 || req.Body == nil || !strings.Contains(req.URL.Path, "enerateContent") {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if edited, err := editGenerationConfig(body, edits...); err == nil {
+		body = edited
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+
+	resp, err := t.base.RoundTrip(req)
+	if _, counted := candidateCountFromContext(req.Context()); !counted || err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	// The whole response is read so candidates blocked in a late chunk are
+	// dropped from the earlier ones too; this request is not streamed
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	respBody = dropBlockedCandidates(respBody)
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestEditGenerationConfig(t *testing.T) {
+	// Test case 1: Every setting in the context is applied in one rewrite, the seed's temperature last
+	ctx := WithCandidateCount(WithSeed(withDefaultGenerationConfig(context.Background()), 7), SafetyCandidateCount)
+	body, err := editGenerationConfig([]byte(`{"contents":[],"generationConfig":{"stopSequences":["END"]}}`), contextEdits(ctx)...)
+	if err != nil {
+		t.Fatalf("editGenerationConfig() error = %v", err)
+	}
+	var request struct {
+		GenerationConfig map[string]any `json:"generationConfig"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("editGenerationConfig() returned invalid JSON: %v", err)
+	}
+	config := request.GenerationConfig
+	if config["seed"] != float64(7) || config["temperature"] != float64(0) || config["candidateCount"] != float64(SafetyCandidateCount) ||
+		config["maxOutputTokens"] == nil || config["stopSequences"] == nil {
+		t.Errorf("generationConfig = %v, want the seed, a temperature of 0, the candidate count, and the other settings kept", config)
+	}
+
+	// Test case 2: A context without settings makes no edits
+	if edits := contextEdits(context.Background()); len(edits) != 0 {
+		t.Errorf("Expected no edits, got %d", len(edits))
+	}
+
+	// Test case 3: A body that is not JSON is an error
+	if _, err := editGenerationConfig([]byte("not json"), seedEdit(7)); err == nil {
+		t.Error("Expected an error for a body that is not JSON")
+	}
+}
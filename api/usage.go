@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// UsageRecorder is told about every request sent to the API: the model it
+// went to and the tokens it used (0 when the response does not say, such
+// as when the request failed). Replayed fixtures are not reported.
+type UsageRecorder func(modelName string, tokens int)
+
+// usageRecorder reports requests made by sessions and models wrapped with
+// Fixtures.WrapSender and Fixtures.WrapModel. It is nil until SetUsageRecorder is called.
+var usageRecorder UsageRecorder
+
+// SetUsageRecorder reports each request sent after it is called to record,
+// such as to keep a ledger of quota use. Pass nil to stop reporting.
+//
+// Parameters:
+//   - record: The function told about each request
+//
+// Example:
+//
+//	api.SetUsageRecorder(func(modelName string, tokens int) {
+//	    _ = usage.Record(path, usage.Entry{Time: time.Now(), Model: modelName, Tokens: tokens})
+//	})
+func SetUsageRecorder(record UsageRecorder) {
+	usageRecorder = record
+}
+
+// responseTokens returns the total tokens a response reports using.
+func responseTokens(response *genai.GenerateContentResponse) int {
+	if response == nil || response.UsageMetadata == nil {
+		return 0
+	}
+	return int(response.UsageMetadata.TotalTokenCount)
+}
+
+// usageSender reports each turn of a conversation to the usage recorder.
+type usageSender struct {
+	chat  ChatSender
+	model string
+}
+
+// SendMessage forwards the message and reports the request.
+func (s usageSender) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	response, err := s.chat.SendMessage(ctx, parts...)
+	if usageRecorder != nil {
+		usageRecorder(s.model, responseTokens(response))
+	}
+	return response, err
+}
+
+// usageStreamingSender reports each turn of a conversation that can stream,
+// keeping its streaming so drafts and progress still update as text arrives.
+type usageStreamingSender struct {
+	usageSender
+	stream StreamingChatSender
+}
+
+// StreamMessage streams the response and reports the request.
+func (s usageStreamingSender) StreamMessage(ctx context.Context, onText func(text string), parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	response, err := s.stream.StreamMessage(ctx, onText, parts...)
+	if usageRecorder != nil {
+		usageRecorder(s.model, responseTokens(response))
+	}
+	return response, err
+}
+
+// usageModel reports each single-turn request to the usage recorder.
+type usageModel struct {
+	ModelInterface
+	model string
+}
+
+// GenerateContent forwards the request and reports it.
+func (m usageModel) GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	response, err := m.ModelInterface.GenerateContent(ctx, parts...)
+	if usageRecorder != nil {
+		usageRecorder(m.model, responseTokens(response))
+	}
+	return response, err
+}
+
+// trackSender returns chat reporting its requests, streaming when chat can,
+// or chat unchanged when there is no recorder or no conversation to send on.
+func trackSender(chat ChatSender, modelName string) ChatSender {
+	if usageRecorder == nil || chat == nil {
+		return chat
+	}
+	sender := usageSender{chat: chat, model: modelName}
+	if stream, ok := chat.(StreamingChatSender); ok {
+		return usageStreamingSender{usageSender: sender, stream: stream}
+	}
+	return sender
+}
+
+// trackModel returns model reporting its requests, or model unchanged when
+// there is no recorder or no model.
+func trackModel(model ModelInterface, modelName string) ModelInterface {
+	if usageRecorder == nil || model == nil {
+		return model
+	}
+	return usageModel{ModelInterface: model, model: modelName}
+}
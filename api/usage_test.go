@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// usageCall is one request reported to the usage recorder
+type usageCall struct {
+	model  string
+	tokens int
+}
+
+// recordUsage sets a usage recorder for the test and returns the requests it is told about
+func recordUsage(t *testing.T) *[]usageCall {
+	t.Helper()
+	var calls []usageCall
+	SetUsageRecorder(func(modelName string, tokens int) {
+		calls = append(calls, usageCall{modelName, tokens})
+	})
+	t.Cleanup(func() { SetUsageRecorder(nil) })
+	return &calls
+}
+
+func TestUsageRecorderSessions(t *testing.T) {
+	ctx := context.Background()
+	calls := recordUsage(t)
+
+	// Test case 1: Each turn is reported with the tokens the response used
+	chat := &fakeChat{replies: []fakeReply{
+		{text: "Draft", finishReason: genai.FinishReasonStop},
+		{err: errors.New("RESOURCE_EXHAUSTED")},
+	}}
+	session := NewSessionWithSender(Fixtures{}.WrapSender(usageChat{chat, 1200}, DefaultModelName))
+	if _, err := session.Send(ctx, genai.NewUserContent(genai.Text("Write my resume"))); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if _, err := session.Refine(ctx, "Shorter"); err == nil {
+		t.Fatal("Expected the second turn to fail")
+	}
+	want := []usageCall{{DefaultModelName, 1200}, {DefaultModelName, 0}}
+	if len(*calls) != len(want) || (*calls)[0] != want[0] || (*calls)[1] != want[1] {
+		t.Errorf("Reported %v, want %v", *calls, want)
+	}
+
+	// Test case 2: Replayed responses use no quota and are not reported
+	*calls = nil
+	dir := t.TempDir()
+	recorded := &fakeChat{replies: []fakeReply{{text: "Draft", finishReason: genai.FinishReasonStop}}}
+	recording := NewSessionWithSender(Fixtures{Mode: FixtureRecord, Dir: dir}.WrapSender(recorded, DefaultModelName))
+	if _, err := recording.Send(ctx, genai.NewUserContent(genai.Text("Write my resume"))); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+	replaying, _ := NewFixtureSession(nil, DefaultModelName, Fixtures{Mode: FixtureReplay, Dir: dir})
+	if _, err := replaying.Send(ctx, genai.NewUserContent(genai.Text("Write my resume"))); err != nil {
+		t.Fatalf("Replaying failed: %v", err)
+	}
+	if len(*calls) != 1 {
+		t.Errorf("Expected only the recorded request to be reported, got %v", *calls)
+	}
+}
+
+func TestUsageRecorderStreaming(t *testing.T) {
+	calls := recordUsage(t)
+
+	last := textResponse("ume", genai.FinishReasonStop)
+	last.UsageMetadata = &genai.UsageMetadata{TotalTokenCount: 42}
+	chat := &fakeStreamingChat{chunks: []*genai.GenerateContentResponse{textResponse("# Res", genai.FinishReasonUnspecified), last}}
+	session := NewSessionWithSender(Fixtures{}.WrapSender(chat, DefaultModelName))
+
+	var updates []string
+	content := genai.NewUserContent(genai.Text("Write my resume"))
+	if _, err := session.SendStreaming(context.Background(), content, func(text string) { updates = append(updates, text) }); err != nil {
+		t.Fatalf("SendStreaming() error = %v", err)
+	}
+	if len(updates) != 2 {
+		t.Errorf("Expected the response to stream through the recorder, got updates %q", updates)
+	}
+	if len(*calls) != 1 || (*calls)[0] != (usageCall{DefaultModelName, 42}) {
+		t.Errorf("Reported %v, want one request of 42 tokens", *calls)
+	}
+}
+
+func TestUsageRecorderModels(t *testing.T) {
+	calls := recordUsage(t)
+
+	model := &MockGenerativeModel{generateContentFunc: func(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+		return &genai.GenerateContentResponse{UsageMetadata: &genai.UsageMetadata{TotalTokenCount: 300}}, nil
+	}}
+	wrapped := Fixtures{}.WrapModel(model, DefaultFallbackModelName)
+	if _, err := ExecuteRequest(context.Background(), wrapped, genai.NewUserContent(genai.Text("Research Acme"))); err != nil {
+		t.Fatalf("ExecuteRequest() error = %v", err)
+	}
+	if len(*calls) != 1 || (*calls)[0] != (usageCall{DefaultFallbackModelName, 300}) {
+		t.Errorf("Reported %v, want one request of 300 tokens to %s", *calls, DefaultFallbackModelName)
+	}
+}
+
+// usageChat adds token usage to the responses of a fakeChat
+type usageChat struct {
+	chat   *fakeChat
+	tokens int32
+}
+
+// SendMessage returns the fakeChat's reply with the usage metadata set
+func (u usageChat) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	response, err := u.chat.SendMessage(ctx, parts...)
+	if response != nil {
+		response.UsageMetadata = &genai.UsageMetadata{TotalTokenCount: u.tokens}
+	}
+	return response, err
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/usage"
+	"github.com/spf13/cobra"
+)
+
+// Command names for the commands defined here rather than in the input
+// package, which have no flags of their own.
+const (
+	generateCommand  = "generate"
+	historyCommand   = "history"
+	configCommand    = "config"
+	syncCommand      = "sync"
+	dashboardCommand = "dashboard"
+	tailorCommand    = "tailor"
+	usageCommand     = "usage"
+)
+
+// commandError is an error ending a command, reported with what the command
+// was doing, such as "Error converting resume".
+type commandError struct {
+	message string
+	err     error
+}
+
+// Error returns the message followed by the error.
+func (e commandError) Error() string {
+	return e.message + ": " + e.err.Error()
+}
+
+// Unwrap returns the error, so the exit code can be chosen from it.
+func (e commandError) Unwrap() error {
+	return e.err
+}
+
+// failed wraps a command's error with message, or returns nil when it succeeded.
+func failed(message string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return commandError{message, err}
+}
+
+// newRootCommand builds resumake's commands. Running resumake without a
+// command generates a resume, as the generate command does, so existing
+// scripts keep working. Errors and usage are not printed by the commands;
+// main reports the error that Execute returns.
+func newRootCommand() *cobra.Command {
+	// The generate flags are shared by the root and generate commands
+	var generateFlags input.Flags
+	generateFlagSet := input.NewFlagSet("resumake")
+	generateFlags.Bind(generateFlagSet)
+	generate := func(cmd *cobra.Command, args []string) error {
+		if err := generateFlags.Complete(args); err != nil {
+			return commandError{"Error parsing flags", err}
+		}
+		runGenerate(generateFlags)
+		return nil
+	}
+
+	root := &cobra.Command{
+		Use:   "resumake",
+		Short: "Generate a polished resume from your notes and an existing resume",
+		Long: "Resumake turns your notes, and optionally an existing resume, into a polished Markdown resume with the Gemini API.\n" +
+			"Without a command it asks for your inputs and generates a resume, as the generate command does.",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE:          generate,
+	}
+	root.Flags().SortFlags = false
+	root.Flags().AddFlagSet(generateFlagSet)
+
+	// -no-color is shared by every command, as NO_COLOR is
+	var noColor bool
+	root.PersistentFlags().BoolVar(&noColor, "no-color", false, "Show output without colors or text styles (also set by NO_COLOR)")
+	root.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if noColor || layout.ColorsDisabled() {
+			layout.DisableColors()
+		}
+	}
+
+	generateCmd := &cobra.Command{
+		Use:   generateCommand,
+		Short: "Generate a resume (the default when no command is given)",
+		Long:  "Asks for your inputs and generates a resume. Running resumake without a command does the same.",
+		RunE:  generate,
+	}
+	generateCmd.Flags().SortFlags = false
+	generateCmd.Flags().AddFlagSet(generateFlagSet)
+
+	// The dashboard command takes the generate flags, which apply to the
+	// resumes regenerated or tailored from it
+	dashboardCmd := &cobra.Command{
+		Use:   dashboardCommand,
+		Short: "List your saved resumes to open, regenerate, tailor, or delete them",
+		Long: "Lists the latest resume of each person in the history, with when it was generated, the job it targets, and the files it was written to.\n" +
+			"Select one to open it, generate it again with new notes, tailor it to a job description, or delete its history.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			generateFlags.Dashboard = true
+			return generate(cmd, args)
+		},
+	}
+	dashboardCmd.Flags().SortFlags = false
+	dashboardCmd.Flags().AddFlagSet(generateFlagSet)
+
+	// The tailor command takes the generate flags too, with the job
+	// description given as its argument
+	tailorCmd := &cobra.Command{
+		Use:   tailorCommand + " [flags] job-description",
+		Short: "Generate a resume tailored to a job description file or job posting URL",
+		Long: "Generates a resume tailored to one job. A job description file is used as -job uses it, to feature your closest experiences and check keyword coverage;\n" +
+			"a job posting URL is read and researched as -company does.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return commandError{"Error parsing tailor arguments", errors.New("pass one job description file or job posting URL, such as resumake tailor job.txt")}
+			}
+			generateFlags.Tailor(args[0])
+			return generate(cmd, nil)
+		},
+	}
+	tailorCmd.Flags().SortFlags = false
+	tailorCmd.Flags().AddFlagSet(generateFlagSet)
+
+	root.AddCommand(
+		generateCmd,
+		dashboardCmd,
+		tailorCmd,
+		newRewriteCommand(),
+		newCompareCommand(),
+		newCompareProfilesCommand(),
+		newConvertCommand(),
+		newTranslateCommand(),
+		newMergeSourcesCommand(),
+		newViewCommand(),
+		newAchievementsCommand(),
+		newHistoryCommand(),
+		newRemindCommand(),
+		newConfigCommand(),
+		newUsageCommand(),
+		newSyncCommand(),
+	)
+
+	// Flag errors name the command whose help lists its flags
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return commandError{"Error parsing flags", fmt.Errorf("%w (see %s --help)", err, cmd.CommandPath())}
+	})
+	return root
+}
+
+// newRewriteCommand returns the rewrite command.
+func newRewriteCommand() *cobra.Command {
+	var flags input.RewriteFlags
+	cmd := &cobra.Command{
+		Use:   input.RewriteCommand + " [flags] \"bullet\"",
+		Short: "Rewrite one resume bullet into stronger alternatives",
+		Long:  "Rewrites one resume bullet into stronger alternatives. Without a bullet argument, the bullet is read from stdin.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args, os.Stdin); err != nil {
+				return commandError{"Error parsing rewrite arguments", err}
+			}
+			return failed("Error rewriting bullet", runRewrite(cmd.Context(), flags, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newCompareCommand returns the compare command.
+func newCompareCommand() *cobra.Command {
+	var flags input.CompareFlags
+	cmd := &cobra.Command{
+		Use:   input.CompareCommand + " [flags]",
+		Short: "Generate with two models or prompts and compare the results",
+		Long:  "Generates the same resume with two models or prompt templates and shows the results side by side.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args, pipedStdin()); err != nil {
+				return commandError{"Error parsing compare arguments", err}
+			}
+			return failed("Error comparing prompts", runCompare(cmd.Context(), flags, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newCompareProfilesCommand returns the compare-profiles command.
+func newCompareProfilesCommand() *cobra.Command {
+	var flags input.CompareProfilesFlags
+	cmd := &cobra.Command{
+		Use:   input.CompareProfilesCommand + " [flags]",
+		Short: "Generate a resume for each of several profiles and compare them",
+		Long:  "Generates a resume from the same inputs for each profile, a preset, audience, or prompt template, and reports their length, keyword coverage, and section differences.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args, pipedStdin()); err != nil {
+				return commandError{"Error parsing compare-profiles arguments", err}
+			}
+			return failed("Error comparing profiles", runCompareProfiles(cmd.Context(), flags, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newConvertCommand returns the convert command.
+func newConvertCommand() *cobra.Command {
+	var flags input.ConvertFlags
+	cmd := &cobra.Command{
+		Use:   input.ConvertCommand + " [flags] resume.md",
+		Short: "Convert a Markdown resume to other formats without calling the API",
+		Long:  "Converts a Markdown resume to other formats without calling the API. The files are written next to the resume.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args); err != nil {
+				return commandError{"Error parsing convert arguments", err}
+			}
+			return failed("Error converting resume", runConvert(flags, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newTranslateCommand returns the translate command.
+func newTranslateCommand() *cobra.Command {
+	var flags input.TranslateFlags
+	cmd := &cobra.Command{
+		Use:   input.TranslateCommand + " [flags] resume.md language",
+		Short: "Translate a resume into another language",
+		Long:  "Translates a resume into another language, with localized section headings and date formats.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args); err != nil {
+				return commandError{"Error parsing translate arguments", err}
+			}
+			return failed("Error translating resume", runTranslate(cmd.Context(), flags, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newMergeSourcesCommand returns the merge-sources command.
+func newMergeSourcesCommand() *cobra.Command {
+	var flags input.MergeSourcesFlags
+	cmd := &cobra.Command{
+		Use:   input.MergeSourcesCommand + " [flags] resume.md...",
+		Short: "Merge old resumes into a master history used as the source",
+		Long:  "Merges old resumes and CVs into one master history, with duplicate roles and bullets combined. It is saved in the configuration directory and used as the source when you generate a resume without -source.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args); err != nil {
+				return commandError{"Error parsing merge-sources arguments", err}
+			}
+			return failed("Error merging resumes", runMergeSources(cmd.Context(), flags, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newViewCommand returns the view command.
+func newViewCommand() *cobra.Command {
+	var flags input.ViewFlags
+	cmd := &cobra.Command{
+		Use:   input.ViewCommand + " [flags] [resume.md]",
+		Short: "Show a resume in the terminal or a pager",
+		Long:  "Shows a resume in the terminal, styled, as plain text, or in a pager such as less or bat (default: the resume most recently generated).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args); err != nil {
+				return commandError{"Error parsing view arguments", err}
+			}
+			historyDir, _ := history.DefaultDir()
+			return failed("Error viewing resume", runView(flags, historyDir, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newAchievementsCommand returns the achievements command.
+func newAchievementsCommand() *cobra.Command {
+	var flags input.AchievementsFlags
+	cmd := &cobra.Command{
+		Use:   input.AchievementsCommand + " [flags] [repo ...]",
+		Short: "Draft resume bullets from your git history",
+		Long:  "Drafts resume bullets from your commits and merged pull requests in local git repositories (default: the current directory).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Complete(args, time.Now()); err != nil {
+				return commandError{"Error parsing achievements arguments", err}
+			}
+			return failed("Error drafting achievements", runAchievements(cmd.Context(), flags, cmd.OutOrStdout()))
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newHistoryCommand returns the history command.
+func newHistoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   historyCommand + " [profile]",
+		Short: "List the resumes saved on this computer",
+		Long:  "Lists the saved versions of your generated resumes, newest first, optionally only those of one profile (such as jane-doe).",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile := ""
+			if len(args) > 0 {
+				profile = args[0]
+			}
+			return failed("Error listing history", runHistory(profile, cmd.OutOrStdout()))
+		},
+	}
+}
+
+// newRemindCommand returns the remind command.
+func newRemindCommand() *cobra.Command {
+	var flags input.RemindFlags
+	cmd := &cobra.Command{
+		Use:   input.RemindCommand + " [flags] [profile]",
+		Short: "Remind you of resumes that have not been regenerated in a while",
+		Long: "Lists when each profile's resume was last generated and which are older than the threshold, optionally only one profile (such as jane-doe).\n" +
+			"With -cron it prints only the stale resumes and exits with status 2 if there are any, for scheduled runs.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, _ := config.LoadSettings()
+			if err := flags.Complete(args, settings[config.SettingStaleAfter]); err != nil {
+				return commandError{"Error parsing remind arguments", err}
+			}
+			historyDir, err := history.DefaultDir()
+			if err != nil {
+				return failed("Error checking resumes", err)
+			}
+			err = runRemind(flags, historyDir, time.Now(), cmd.OutOrStdout())
+			if errors.As(err, &staleError{}) {
+				return commandError{"Resumes need regenerating", err}
+			}
+			return failed("Error checking resumes", err)
+		},
+	}
+	cmd.Flags().SortFlags = false
+	flags.Bind(cmd.Flags())
+	return cmd
+}
+
+// newConfigCommand returns the config command.
+func newConfigCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   configCommand,
+		Short: "Show where resumake keeps its files and the settings in effect",
+		Long:  "Shows the configuration directory, the files resumake keeps in it, and the settings read from the settings file.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return failed("Error showing configuration", runConfig(cmd.OutOrStdout()))
+		},
+	}
+}
+
+// newUsageCommand returns the usage command.
+func newUsageCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   usageCommand + " [model]",
+		Short: "Show how much of each model's free-tier quota recent requests have used",
+		Long: "Lists the requests resumake sent to each model in the last 24 hours and the last minute, from its usage ledger, against the model's free-tier limits,\n" +
+			"optionally only one model (such as gemini-1.5-flash).",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model := ""
+			if len(args) > 0 {
+				model = args[0]
+			}
+			path, err := usage.DefaultPath()
+			if err != nil {
+				return failed("Error showing usage", err)
+			}
+			return failed("Error showing usage", runUsage(path, model, time.Now(), cmd.OutOrStdout()))
+		},
+	}
+}
+
+// newSyncCommand returns the sync command.
+func newSyncCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   syncCommand,
+		Short: "Pull and push the snippets, profiles, and settings kept in the sync directory",
+		Long: "Brings the sync directory named by the sync-dir setting up to date with your other machines.\n" +
+			"In a git repository it commits the local changes, then pulls and pushes them; a folder synced by Dropbox, iCloud Drive, or similar needs nothing.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := config.SyncDir()
+			if err != nil {
+				return failed("Error syncing", err)
+			}
+			return failed("Error syncing", runSync(cmd.Context(), dir, cmd.OutOrStdout()))
+		},
+	}
+}
+
+// normalizeCommandArgs rewrites single-dash long flags, such as -source, to
+// the double-dash form, using the flags of the command the arguments select
+// (see input.NormalizeArgs).
+func normalizeCommandArgs(root *cobra.Command, args []string) []string {
+	cmd, prefix, rest := root, []string(nil), args
+	if len(args) > 0 {
+		for _, sub := range root.Commands() {
+			if sub.Name() == args[0] || sub.HasAlias(args[0]) {
+				cmd, prefix, rest = sub, args[:1], args[1:]
+				break
+			}
+		}
+	}
+
+	// Help and the flags shared by every command are added when the command
+	// runs, so -help and -no-color need them now
+	cmd.InitDefaultHelpFlag()
+	cmd.InheritedFlags()
+	return append(append([]string{}, prefix...), input.NormalizeArgs(rest, cmd.Flags())...)
+}
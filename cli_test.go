@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/output"
+)
+
+func TestNormalizeCommandArgs(t *testing.T) {
+	root := newRootCommand()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"generate flags", []string{"-source", "resume.md", "-plain"}, []string{"--source", "resume.md", "--plain"}},
+		{"command flags", []string{"convert", "resume.md", "-formats", "pdf"}, []string{"convert", "resume.md", "--formats", "pdf"}},
+		{"shorthand flags", []string{"rewrite", "-n", "5", "Built X"}, []string{"rewrite", "-n", "5", "Built X"}},
+		{"help", []string{"translate", "-help"}, []string{"translate", "--help"}},
+		{"shared flags", []string{"view", "-no-color", "resume.md"}, []string{"view", "--no-color", "resume.md"}},
+		{"shared flags when generating", []string{"-no-color", "-plain"}, []string{"--no-color", "--plain"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeCommandArgs(root, tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeCommandArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRootCommand(t *testing.T) {
+	defer func() { output.FileMode = output.DefaultFileMode }()
+
+	// execute runs resumake with the arguments as main does
+	execute := func(args ...string) (string, error) {
+		root := newRootCommand()
+		var out strings.Builder
+		root.SetOut(&out)
+		root.SetErr(&out)
+		root.SetArgs(normalizeCommandArgs(root, args))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	// Test case 1: A command runs with its flags, in either form
+	path := writeConvertResume(t)
+	out, err := execute("convert", path, "-formats", "html")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	htmlPath := strings.TrimSuffix(path, ".md") + ".html"
+	if _, err := os.Stat(htmlPath); err != nil || !strings.Contains(out, htmlPath) {
+		t.Errorf("Expected the HTML file to be written and listed, got %q (%v)", out, err)
+	}
+
+	// Test case 2: Invalid flags are reported with the command to get help for
+	_, err = execute("convert", path, "-no-such-flag")
+	var cmdErr commandError
+	if !errors.As(err, &cmdErr) || !strings.HasPrefix(err.Error(), "Error parsing flags: ") || !strings.Contains(err.Error(), "resumake convert --help") {
+		t.Errorf("Expected a flag error naming the convert help, got %v", err)
+	}
+
+	// Test case 3: Invalid arguments are reported by the command
+	_, err = execute("convert")
+	if err == nil || !strings.HasPrefix(err.Error(), "Error parsing convert arguments: ") {
+		t.Errorf("Expected an argument error, got %v", err)
+	}
+
+	// Test case 4: Help lists every command and the generate flags
+	out, err = execute("-help")
+	if err != nil {
+		t.Fatalf("Expected no error for help, got %v", err)
+	}
+	for _, want := range []string{"generate", "convert", "translate", "history", "config", "tailor", "usage", "--source"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected help to mention %q, got %q", want, out)
+		}
+	}
+
+	// Test case 5: Tailoring needs the job to tailor to
+	_, err = execute("tailor")
+	if err == nil || !strings.HasPrefix(err.Error(), "Error parsing tailor arguments: ") {
+		t.Errorf("Expected an argument error, got %v", err)
+	}
+
+	// Test case 6: The tailor command takes the generate flags
+	if got := normalizeCommandArgs(newRootCommand(), []string{"tailor", "-plain", "job.txt"}); !reflect.DeepEqual(got, []string{"tailor", "--plain", "job.txt"}) {
+		t.Errorf("Expected the generate flags on tailor, got %q", got)
+	}
+
+	// Test case 7: The usage command reads the ledger in the configuration directory
+	t.Setenv("RESUMAKE_CONFIG_DIR", t.TempDir())
+	out, err = execute("usage", "gemini-1.5-flash")
+	if err != nil || !strings.Contains(out, "gemini-1.5-flash: 0 of 1500 requests") {
+		t.Errorf("Expected the usage of gemini-1.5-flash, got %q (%v)", out, err)
+	}
+}
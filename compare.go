@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/analysis"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// variantResult is the resume one side of a comparison generated, or the
+// error it failed with.
+type variantResult struct {
+	markdown string
+	err      error
+}
+
+// runCompare generates the same resume with both variants at once and prints
+// the results side by side to w. It calls the API directly, without the TUI.
+func runCompare(ctx context.Context, flags input.CompareFlags, w io.Writer) error {
+	apiKey, err := api.GetAPIKey()
+	if err != nil {
+		return fmt.Errorf("API key error: %w", err)
+	}
+
+	client, _, err := api.InitializeClientWithModel(ctx, apiKey, flags.A.Model)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+	defer client.Close()
+
+	modelA, err := api.NewResumeModelWithInstructions(client, flags.A.Model, flags.A.Instructions)
+	if err != nil {
+		return err
+	}
+	modelB, err := api.NewResumeModelWithInstructions(client, flags.B.Model, flags.B.Instructions)
+	if err != nil {
+		return err
+	}
+
+	if flags.SourceWarning != "" {
+		fmt.Fprintf(w, "Warning: %s\n", flags.SourceWarning)
+	}
+	fmt.Fprintf(w, "Generating with A (%s) and B (%s)...\n", flags.A.Label(), flags.B.Label())
+	content := prompt.GeneratePromptContent(flags.SourceContent, flags.StdinContent)
+	a, b := generateVariants(ctx, modelA, modelB, content)
+	if a.err != nil || b.err != nil {
+		return errors.Join(variantError("A", a.err), variantError("B", b.err))
+	}
+
+	fmt.Fprint(w, formatComparison(flags, a.markdown, b.markdown))
+	return nil
+}
+
+// generateVariants sends the same prompt to both models concurrently and
+// waits for both to finish.
+func generateVariants(ctx context.Context, modelA, modelB api.ModelInterface, content *genai.Content) (variantResult, variantResult) {
+	results := generateAll(ctx, []api.ModelInterface{modelA, modelB}, []*genai.Content{content, content})
+	return results[0], results[1]
+}
+
+// generateAll sends each prompt to the model at the same index concurrently
+// and waits for all of them to finish.
+func generateAll(ctx context.Context, models []api.ModelInterface, contents []*genai.Content) []variantResult {
+	results := make([]variantResult, len(models))
+	var wg sync.WaitGroup
+	for i, model := range models {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := api.ExecuteRequest(ctx, model, contents[i])
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			results[i].markdown, results[i].err = output.ProcessResponseContent(response)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// variantError names the variant an error came from, or returns nil.
+func variantError(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("variant %s failed: %w", name, err)
+}
+
+// formatComparison renders the two resumes side by side under a header naming
+// each variant, followed by a count of the lines that differ.
+func formatComparison(flags input.CompareFlags, markdownA, markdownB string) string {
+	rows := analysis.DiffLines(markdownA, markdownB)
+	column := (flags.Width - 3) / 2
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%-*s   %s\n", column, "A: "+flags.A.Label(), "B: "+flags.B.Label())
+	b.WriteString(strings.Repeat("─", flags.Width) + "\n")
+	b.WriteString(analysis.SideBySide(rows, flags.Width))
+	b.WriteString(strings.Repeat("─", flags.Width) + "\n")
+
+	diffs := analysis.CountDifferences(rows)
+	if diffs == 0 {
+		b.WriteString("The two resumes are identical.\n")
+	} else {
+		fmt.Fprintf(&b, "%d of %d lines differ (| changed, < only in A, > only in B).\n", diffs, len(rows))
+	}
+	return b.String()
+}
+
+// pipedStdin returns os.Stdin when input is piped or redirected into it, and
+// nil when it is a terminal, so compare mode never waits for typed input.
+func pipedStdin() io.Reader {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return nil
+	}
+	return os.Stdin
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/input"
+)
+
+// fakeResumeModel answers every request with the same text, or fails.
+type fakeResumeModel struct {
+	text string
+	err  error
+}
+
+func (m *fakeResumeModel) GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			Content:      &genai.Content{Parts: []genai.Part{genai.Text(m.text)}},
+			FinishReason: genai.FinishReasonStop,
+		}},
+	}, nil
+}
+
+func TestGenerateVariants(t *testing.T) {
+	content := &genai.Content{Parts: []genai.Part{genai.Text("notes")}}
+
+	// Test case 1: Both variants return their own resume
+	a, b := generateVariants(context.Background(),
+		&fakeResumeModel{text: "# Jane\n- Built X"},
+		&fakeResumeModel{text: "# Jane\n- Led X"},
+		content)
+	if a.err != nil || b.err != nil {
+		t.Fatalf("Expected no errors, got %v and %v", a.err, b.err)
+	}
+	if !strings.Contains(a.markdown, "Built X") || !strings.Contains(b.markdown, "Led X") {
+		t.Errorf("Unexpected results: %q and %q", a.markdown, b.markdown)
+	}
+
+	// Test case 2: A failing variant does not affect the other
+	a, b = generateVariants(context.Background(),
+		&fakeResumeModel{text: "# Jane\n- Built X"},
+		&fakeResumeModel{err: errors.New("quota exceeded")},
+		content)
+	if a.err != nil || b.err == nil {
+		t.Errorf("Expected only variant B to fail, got %v and %v", a.err, b.err)
+	}
+}
+
+func TestFormatComparison(t *testing.T) {
+	flags := input.CompareFlags{
+		A:     input.CompareVariant{Model: "model-a"},
+		B:     input.CompareVariant{Model: "model-a", InstructionsPath: "terse.txt"},
+		Width: 60,
+	}
+
+	got := formatComparison(flags, "# Jane\n- Built X", "# Jane\n- Led X")
+	for _, want := range []string{
+		"A: model-a with the built-in prompt",
+		"B: model-a with terse.txt",
+		"- Built X                    | - Led X",
+		"1 of 2 lines differ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected comparison to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	if got := formatComparison(flags, "# Jane", "# Jane"); !strings.Contains(got, "identical") {
+		t.Errorf("Expected identical resumes to be reported, got:\n%s", got)
+	}
+}
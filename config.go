@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/snippets"
+	"github.com/phrazzld/resumake/vault"
+)
+
+// runConfig describes the configuration directory and the settings in
+// effect to w. Secrets are never shown, only whether they are set.
+func runConfig(w io.Writer) error {
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	settingsPath, err := config.SettingsPath()
+	if err != nil {
+		return err
+	}
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return err
+	}
+	snippetsDir, err := snippets.DefaultDir()
+	if err != nil {
+		return err
+	}
+	historyDir, err := history.DefaultDir()
+	if err != nil {
+		return err
+	}
+	masterPath, err := config.MasterHistoryPath()
+	if err != nil {
+		return err
+	}
+	syncDir, err := config.SyncDir()
+	if err != nil {
+		return err
+	}
+	profilesDir, err := config.DataPath(config.ProfilesDirName)
+	if err != nil {
+		return err
+	}
+
+	consent := "not given yet"
+	if consented, err := config.HasConsent(); err != nil {
+		consent = "unreadable: " + err.Error()
+	} else if consented {
+		consent = "given"
+	}
+
+	fmt.Fprintf(w, "Configuration directory: %s\n", dir)
+	fmt.Fprintf(w, "  Settings file:  %s%s\n", settingsPath, missingNote(settingsPath))
+	fmt.Fprintf(w, "  Snippets:       %s%s\n", snippetsDir, missingNote(snippetsDir))
+	fmt.Fprintf(w, "  Profiles:       %s%s\n", profilesDir, missingNote(profilesDir))
+	fmt.Fprintf(w, "  History:        %s%s\n", historyDir, missingNote(historyDir))
+	fmt.Fprintf(w, "  Master history: %s%s\n", masterPath, missingNote(masterPath))
+	fmt.Fprintf(w, "  Consent:        %s\n", consent)
+	if syncDir != "" {
+		fmt.Fprintf(w, "Sync directory: %s%s\n", syncDir, missingNote(syncDir))
+	}
+
+	gateway := api.ActiveGateway
+	var headers []string
+	for name := range gateway.Headers {
+		headers = append(headers, name)
+	}
+	slices.Sort(headers)
+	token := "not set"
+	if gateway.BearerToken != "" {
+		token = "set (hidden)"
+	}
+
+	fmt.Fprintln(w, "\nSettings:")
+	for _, setting := range []struct{ name, value string }{
+		{config.SettingMaxFileSize, input.FormatFileSize(input.MaxFileSize)},
+		{config.SettingExtensions, strings.Join(input.SupportedFileExtensions, ", ")},
+		{config.SettingAPIEndpoint, orNotSet(gateway.Endpoint)},
+		{config.SettingAPIToken, token},
+		{config.SettingAPIHeaders, orNotSet(strings.Join(headers, ", "))},
+		{config.SettingStaleAfter, staleAfter(settings[config.SettingStaleAfter])},
+		{config.SettingQR, qrSetting(settings[config.SettingQR])},
+		{config.SettingQRLayouts, qrLayoutsSetting(settings[config.SettingQRLayouts])},
+		{config.SettingEncryption, encryptionSetting(settings[config.SettingEncryption])},
+		{config.SettingSyncDir, orNotSet(syncDir)},
+	} {
+		source := "default"
+		if _, ok := settings[setting.name]; ok {
+			source = "settings file"
+		}
+		if setting.name == config.SettingAPIToken && os.Getenv(api.GatewayTokenEnv) != "" {
+			source = api.GatewayTokenEnv
+		}
+		fmt.Fprintf(w, "  %-14s %s (%s)\n", setting.name, setting.value, source)
+	}
+	return nil
+}
+
+// staleAfter describes the days after which remind reports a resume as stale.
+func staleAfter(setting string) string {
+	var flags input.RemindFlags
+	if err := flags.Complete(nil, setting); err != nil {
+		return fmt.Sprintf("%s (invalid; it must be a positive number of days)", setting)
+	}
+	return fmt.Sprintf("%d days", flags.StaleAfter)
+}
+
+// qrSetting describes the URL linked with a QR code in HTML and PDF resumes.
+func qrSetting(setting string) string {
+	link, err := output.QRLink(setting)
+	if err != nil {
+		return fmt.Sprintf("%s (invalid; it must be a web address)", setting)
+	}
+	return orNotSet(link)
+}
+
+// qrLayoutsSetting describes the layouts that show the QR code.
+func qrLayoutsSetting(setting string) string {
+	layouts, err := output.ParseLayouts(setting)
+	if err != nil {
+		return fmt.Sprintf("%s (invalid; %v)", setting, err)
+	}
+	if layouts == nil {
+		return "all layouts"
+	}
+	names := make([]string, len(layouts))
+	for i, layout := range layouts {
+		names[i] = string(layout)
+	}
+	return strings.Join(names, ", ")
+}
+
+// encryptionSetting describes whether saved resumes are encrypted, and with
+// which key.
+func encryptionSetting(setting string) string {
+	mode, err := vault.ParseMode(setting)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("%s (invalid; use off, keychain, or passphrase)", setting)
+	case mode == vault.ModeKeychain:
+		return "keychain (history encrypted with a key in the system keychain)"
+	case mode == vault.ModePassphrase:
+		return "passphrase (history encrypted with a key from your passphrase)"
+	}
+	return "off"
+}
+
+// missingNote marks a path that does not exist yet.
+func missingNote(path string) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return " (not created yet)"
+	}
+	return ""
+}
+
+// orNotSet returns value, or "not set" when it is empty.
+func orNotSet(value string) string {
+	if value == "" {
+		return "not set"
+	}
+	return value
+}
@@ -0,0 +1,68 @@
+// Package config provides access to resumake's per-user configuration.
+//
+// All user-level data (snippets, settings, and other persisted state) lives
+// under a single configuration directory, by default ~/.config/resumake on
+// Linux (or the platform equivalent reported by os.UserConfigDir). The
+// location can be overridden with the RESUMAKE_CONFIG_DIR environment variable.
+// The data worth keeping the same on every machine can live in a separate
+// sync directory instead; see SyncDir.
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// DirEnvVar is the environment variable that overrides the configuration directory.
+const DirEnvVar = "RESUMAKE_CONFIG_DIR"
+
+// AppDirName is the name of the application's directory inside the user config directory.
+const AppDirName = "resumake"
+
+// Dir returns the path of resumake's configuration directory.
+// The directory is not created; callers that write to it are responsible
+// for creating it as needed.
+//
+// Returns:
+//   - string: The configuration directory path
+//   - error: An error if the user configuration directory cannot be determined
+//
+// Example:
+//
+//	dir, err := config.Dir()
+//	if err != nil {
+//	    log.Fatalf("Cannot locate config directory: %v", err)
+//	}
+func Dir() (string, error) {
+	if dir := os.Getenv(DirEnvVar); dir != "" {
+		return dir, nil
+	}
+
+	userDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.New("cannot determine user configuration directory: " + err.Error())
+	}
+
+	return filepath.Join(userDir, AppDirName), nil
+}
+
+// Path returns the path of a file or directory inside the configuration directory.
+//
+// Parameters:
+//   - elem: Path elements relative to the configuration directory
+//
+// Returns:
+//   - string: The joined path
+//   - error: An error if the configuration directory cannot be determined
+//
+// Example:
+//
+//	snippetsDir, err := config.Path("snippets")
+func Path(elem ...string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{dir}, elem...)...), nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	t.Run("environment override", func(t *testing.T) {
+		t.Setenv(DirEnvVar, "/tmp/resumake-config")
+
+		dir, err := Dir()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if dir != "/tmp/resumake-config" {
+			t.Errorf("Expected overridden dir, got %q", dir)
+		}
+	})
+
+	t.Run("default location", func(t *testing.T) {
+		t.Setenv(DirEnvVar, "")
+		t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+		t.Setenv("HOME", "/tmp/home")
+
+		dir, err := Dir()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if filepath.Base(dir) != AppDirName {
+			t.Errorf("Expected dir to end with %q, got %q", AppDirName, dir)
+		}
+	})
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv(DirEnvVar, "/tmp/resumake-config")
+
+	path, err := Path("snippets", "summary.md")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := filepath.Join("/tmp/resumake-config", "snippets", "summary.md")
+	if path != expected {
+		t.Errorf("Expected %q, got %q", expected, path)
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConsentFileName is the file in the configuration directory that records
+// that the user agreed to send their resume data to the Gemini API.
+const ConsentFileName = "consent"
+
+// ConsentVersion identifies the data summary the user agreed to. It is
+// raised when what is sent to the API changes, so everyone is asked again.
+const ConsentVersion = 1
+
+// ConsentPath returns the path of the consent file. Deleting the file makes
+// resumake ask for consent again.
+//
+// Returns:
+//   - string: The consent file path
+//   - error: An error if the configuration directory cannot be determined
+func ConsentPath() (string, error) {
+	return Path(ConsentFileName)
+}
+
+// HasConsent reports whether the user has agreed to the current
+// ConsentVersion. A missing consent file, or one recorded for an earlier
+// version, is not an error; it means consent has to be asked for.
+//
+// Returns:
+//   - bool: True if consent for the current version is on record
+//   - error: An error if the consent file exists but cannot be read
+//
+// Example:
+//
+//	consented, err := config.HasConsent()
+//	if err != nil {
+//	    log.Printf("Could not read consent: %v", err)
+//	}
+func HasConsent() (bool, error) {
+	path, err := ConsentPath()
+	if err != nil {
+		return false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot read consent file: %w", err)
+	}
+
+	fields := strings.Fields(string(data))
+	return len(fields) > 0 && fields[0] == strconv.Itoa(ConsentVersion), nil
+}
+
+// RecordConsent remembers that the user agreed to the current ConsentVersion
+// at t, so they are not asked again.
+//
+// Parameters:
+//   - t: When the user agreed
+//
+// Returns:
+//   - error: An error if the consent file cannot be written
+//
+// Example:
+//
+//	if err := config.RecordConsent(time.Now()); err != nil {
+//	    log.Printf("Consent will be asked for again: %v", err)
+//	}
+func RecordConsent(t time.Time) error {
+	path, err := ConsentPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("cannot create configuration directory: %w", err)
+	}
+	record := fmt.Sprintf("%d %s\n", ConsentVersion, t.UTC().Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(record), 0644); err != nil {
+		return fmt.Errorf("cannot write consent file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConsent(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "resumake")
+	t.Setenv(DirEnvVar, dir)
+
+	// Test case 1: Without a consent file, consent has to be asked for
+	consented, err := HasConsent()
+	if err != nil || consented {
+		t.Fatalf("Expected no consent on record, got %v (%v)", consented, err)
+	}
+
+	// Test case 2: Recorded consent is remembered, creating the directory
+	if err := RecordConsent(time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	consented, err = HasConsent()
+	if err != nil || !consented {
+		t.Fatalf("Expected consent on record, got %v (%v)", consented, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ConsentFileName))
+	if err != nil || string(data) != "1 2024-06-01T09:00:00Z\n" {
+		t.Errorf("Unexpected consent file %q (%v)", data, err)
+	}
+
+	// Test case 3: Consent given for an earlier version is asked for again
+	if err := os.WriteFile(filepath.Join(dir, ConsentFileName), []byte("0 2023-01-01T00:00:00Z\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if consented, _ := HasConsent(); consented {
+		t.Error("Expected consent for an earlier version to be asked for again")
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// MasterHistoryFileName is the file in the configuration directory that
+// holds the master history: every role and bullet from the user's old
+// resumes, merged by the merge-sources command.
+const MasterHistoryFileName = "master_history.md"
+
+// MasterHistoryPath returns the path of the master history. Deleting the
+// file makes resumake ask for a source again.
+//
+// Returns:
+//   - string: The master history path
+//   - error: An error if the configuration directory cannot be determined
+func MasterHistoryPath() (string, error) {
+	return Path(MasterHistoryFileName)
+}
+
+// HasMasterHistory reports whether a master history has been saved, and
+// returns its path. A missing file is not an error.
+//
+// Returns:
+//   - string: The master history path
+//   - bool: True if the master history exists
+//   - error: An error if the configuration directory cannot be determined
+//     or the file cannot be checked
+//
+// Example:
+//
+//	path, ok, err := config.HasMasterHistory()
+func HasMasterHistory() (string, bool, error) {
+	path, err := MasterHistoryPath()
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return path, false, nil
+		}
+		return path, false, err
+	}
+	return path, true, nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHasMasterHistory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "resumake")
+	t.Setenv(DirEnvVar, dir)
+
+	// Test case 1: Without a master history, none is reported
+	path, ok, err := HasMasterHistory()
+	if err != nil || ok {
+		t.Fatalf("Expected no master history, got %v (%v)", ok, err)
+	}
+	if path != filepath.Join(dir, MasterHistoryFileName) {
+		t.Errorf("Unexpected master history path %q", path)
+	}
+
+	// Test case 2: A saved master history is found
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("# Jane Doe\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := HasMasterHistory(); err != nil || !ok {
+		t.Errorf("Expected the master history to be found, got %v (%v)", ok, err)
+	}
+}
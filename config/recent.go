@@ -0,0 +1,128 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RecentFileName is the file in the configuration directory that lists the
+// files used most recently, shown on the welcome screen so they can be
+// picked instead of typed again.
+const RecentFileName = "recent.json"
+
+// MaxRecentPerKind is the number of files of each kind that are remembered.
+const MaxRecentPerKind = 3
+
+// RecentKind is what a recently used file was used as.
+type RecentKind string
+
+const (
+	// RecentSource is an existing resume a new one was generated from.
+	RecentSource RecentKind = "source"
+	// RecentProfile is the latest resume written for a candidate.
+	RecentProfile RecentKind = "profile"
+	// RecentOutput is a resume that was written.
+	RecentOutput RecentKind = "output"
+)
+
+// RecentFile is a file that was used recently.
+type RecentFile struct {
+	Kind  RecentKind `json:"kind"`
+	Label string     `json:"label,omitempty"` // The profile name, for RecentProfile
+	Path  string     `json:"path"`
+	Used  time.Time  `json:"used"`
+}
+
+// RecentPath returns the path of the recent files list.
+//
+// Returns:
+//   - string: The recent files list path
+//   - error: An error if the configuration directory cannot be determined
+func RecentPath() (string, error) {
+	return Path(RecentFileName)
+}
+
+// LoadRecent reads the recent files list at path, most recently used first.
+// A missing file is not an error; it returns no files.
+//
+// Parameters:
+//   - path: The recent files list, as returned by RecentPath
+//
+// Returns:
+//   - []RecentFile: The files, most recently used first
+//   - error: An error if the file exists but cannot be read
+//
+// Example:
+//
+//	path, _ := config.RecentPath()
+//	files, err := config.LoadRecent(path)
+func LoadRecent(path string) ([]RecentFile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read recent files: %w", err)
+	}
+
+	var files []RecentFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("cannot read recent files: %w", err)
+	}
+	return files, nil
+}
+
+// RecordRecent adds files to the front of the recent files list at path.
+// A file already on the list moves to the front instead of being listed
+// twice, a profile keeps only its latest resume, and each kind keeps its
+// MaxRecentPerKind most recent files.
+//
+// Parameters:
+//   - path: The recent files list, as returned by RecentPath
+//   - files: The files just used; their Used time is kept as given
+//
+// Returns:
+//   - error: An error if the list cannot be read or written
+//
+// Example:
+//
+//	err := config.RecordRecent(path, config.RecentFile{Kind: config.RecentSource, Path: "/resumes/old.md", Used: time.Now()})
+func RecordRecent(path string, files ...RecentFile) error {
+	existing, err := LoadRecent(path)
+	if err != nil {
+		return err
+	}
+
+	key := func(file RecentFile) string {
+		if file.Kind == RecentProfile {
+			return string(file.Kind) + "\x00" + file.Label
+		}
+		return string(file.Kind) + "\x00" + file.Path
+	}
+	var kept []RecentFile
+	seen := make(map[string]bool)
+	counts := make(map[RecentKind]int)
+	for _, file := range append(files, existing...) {
+		if file.Path == "" || seen[key(file)] || counts[file.Kind] == MaxRecentPerKind {
+			continue
+		}
+		seen[key(file)] = true
+		counts[file.Kind]++
+		kept = append(kept, file)
+	}
+
+	data, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("cannot create configuration directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("cannot write recent files: %w", err)
+	}
+	return nil
+}
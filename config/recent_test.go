@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resumake", RecentFileName)
+	used := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	// Test case 1: Without a list, no files have been used
+	files, err := LoadRecent(path)
+	if err != nil || len(files) != 0 {
+		t.Fatalf("Expected no recent files, got %v (%v)", files, err)
+	}
+
+	// Test case 2: Recorded files are listed most recently used first, creating the directory
+	if err := RecordRecent(path,
+		RecentFile{Kind: RecentSource, Path: "/resumes/old.md", Used: used},
+		RecentFile{Kind: RecentProfile, Label: "jane-doe", Path: "/resumes/jane-v1.md", Used: used}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := RecordRecent(path,
+		RecentFile{Kind: RecentProfile, Label: "jane-doe", Path: "/resumes/jane-v2.md", Used: used.Add(time.Hour)},
+		RecentFile{Kind: RecentSource, Path: "/resumes/old.md", Used: used.Add(time.Hour)},
+		RecentFile{Kind: RecentSource, Path: ""}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	files, err = LoadRecent(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the list to be readable only by the owner, got %v (%v)", info, err)
+	}
+
+	// Test case 3: A profile keeps its latest resume, and a file used again is listed once
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 recent files, got %+v", files)
+	}
+	if files[0].Kind != RecentProfile || files[0].Path != "/resumes/jane-v2.md" {
+		t.Errorf("Expected Jane's latest resume first, got %+v", files[0])
+	}
+	if files[1].Kind != RecentSource || !files[1].Used.Equal(used.Add(time.Hour)) {
+		t.Errorf("Expected the source used again second, got %+v", files[1])
+	}
+
+	// Test case 4: Each kind keeps only its most recent files
+	for i := 0; i < MaxRecentPerKind+2; i++ {
+		if err := RecordRecent(path, RecentFile{Kind: RecentOutput, Path: filepath.Join("/resumes", string(rune('a'+i))+".md"), Used: used}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	files, _ = LoadRecent(path)
+	outputs := 0
+	for _, file := range files {
+		if file.Kind == RecentOutput {
+			outputs++
+		}
+	}
+	if outputs != MaxRecentPerKind || files[0].Path != filepath.Join("/resumes", "e.md") {
+		t.Errorf("Expected the %d latest outputs, got %+v", MaxRecentPerKind, files)
+	}
+
+	// Test case 5: A list that is not JSON is an error
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRecent(path); err == nil {
+		t.Error("Expected an error for a malformed list")
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SettingsFileName is the file in the configuration directory that holds
+// defaults for command-line options, one "name = value" line per setting.
+// Lines starting with # are comments. Flags given on the command line
+// override these settings.
+const SettingsFileName = "settings"
+
+// Settings names the defaults that can be set in the settings file. Each
+// matches the command-line flag it provides the default for, except the
+// API gateway, encryption, and sync directory settings, which have no flags.
+const (
+	SettingMaxFileSize = "max-file-size"
+	SettingExtensions  = "extensions"
+	SettingAPIEndpoint = "api-endpoint"
+	SettingAPIToken    = "api-token"
+	SettingAPIHeaders  = "api-headers"
+	SettingStaleAfter  = "stale-after"
+	SettingQR          = "qr"
+	SettingQRLayouts   = "qr-layouts"
+	SettingEncryption  = "encryption"
+	SettingSyncDir     = "sync-dir"
+)
+
+// SettingsPath returns the path of the settings file.
+//
+// Returns:
+//   - string: The settings file path
+//   - error: An error if the configuration directory cannot be determined
+func SettingsPath() (string, error) {
+	return Path(SettingsFileName)
+}
+
+// LoadSettings reads the settings file. A missing file is not an error; it
+// returns no settings. When the file sets a sync directory, the settings
+// file there is read as well, for the settings this machine's file leaves out.
+//
+// Returns:
+//   - map[string]string: The settings by name
+//   - error: An error if the file cannot be read or a line is not "name = value"
+//
+// Example:
+//
+//	settings, err := config.LoadSettings()
+//	maxFileSize := settings[config.SettingMaxFileSize]
+func LoadSettings() (map[string]string, error) {
+	path, err := SettingsPath()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := readSettings(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Settings kept in the sync directory apply on every machine; those in
+	// this machine's file take precedence over them
+	syncDir := settings[SettingSyncDir]
+	if syncDir == "" {
+		return settings, nil
+	}
+	synced, err := readSettings(filepath.Join(expandHome(syncDir), SettingsFileName))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range synced {
+		if _, ok := settings[name]; !ok && name != SettingSyncDir {
+			settings[name] = value
+		}
+	}
+	return settings, nil
+}
+
+// readSettings reads one settings file. A missing file yields no settings.
+func readSettings(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read settings file: %w", err)
+	}
+	defer file.Close()
+
+	settings := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s:%d: expected \"name = value\", got %q", path, lineNumber, line)
+		}
+		settings[name] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read settings file: %w", err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSettings(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DirEnvVar, dir)
+
+	// Test case 1: Without a settings file there are no settings
+	settings, err := LoadSettings()
+	if err != nil || len(settings) != 0 {
+		t.Fatalf("Expected no settings, got %v (%v)", settings, err)
+	}
+
+	// Test case 2: Settings are read, skipping comments and blank lines
+	content := "# Limits for source files\n\nmax-file-size = 20MB\nextensions=txt, md, rst\n"
+	if err := os.WriteFile(filepath.Join(dir, SettingsFileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if settings[SettingMaxFileSize] != "20MB" || settings[SettingExtensions] != "txt, md, rst" {
+		t.Errorf("Unexpected settings %v", settings)
+	}
+
+	// Test case 3: A line without a value is reported with its line number
+	if err := os.WriteFile(filepath.Join(dir, SettingsFileName), []byte("max-file-size 20MB\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(); err == nil || !strings.Contains(err.Error(), "settings:1") {
+		t.Errorf("Expected a line error, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProfilesDirName is the directory inside the data directory that holds
+// prompt files, which compare-profiles finds by name.
+const ProfilesDirName = "profiles"
+
+// SyncDir returns the sync directory named by the sync-dir setting of this
+// machine's settings file: a git repository or a folder kept in step by a
+// sync client, such as Dropbox or iCloud Drive, where the data shared across
+// machines lives. The setting is only read from this machine's file, since
+// the sync directory's own settings cannot say where it is.
+//
+// Returns:
+//   - string: The sync directory, with a leading ~ expanded, or an empty
+//     string if none is set
+//   - error: An error if the settings file cannot be read
+//
+// Example:
+//
+//	dir, err := config.SyncDir()
+//	if err == nil && dir == "" {
+//	    fmt.Println("Nothing is synced")
+//	}
+func SyncDir() (string, error) {
+	path, err := SettingsPath()
+	if err != nil {
+		return "", err
+	}
+	settings, err := readSettings(path)
+	if err != nil {
+		return "", err
+	}
+	if dir := settings[SettingSyncDir]; dir != "" {
+		return expandHome(dir), nil
+	}
+	return "", nil
+}
+
+// DataPath returns the path of a file or directory of the data shared across
+// machines: the snippets, the prompt files of profiles, and the settings
+// that apply everywhere. It is inside the sync directory when one is set,
+// and inside the configuration directory otherwise.
+//
+// Parameters:
+//   - elem: Path elements relative to the data directory
+//
+// Returns:
+//   - string: The joined path
+//   - error: An error if the configuration directory or settings file cannot be read
+//
+// Example:
+//
+//	snippetsDir, err := config.DataPath("snippets")
+func DataPath(elem ...string) (string, error) {
+	dir, err := SyncDir()
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		return Path(elem...)
+	}
+	return filepath.Join(append([]string{dir}, elem...)...), nil
+}
+
+// expandHome replaces a leading ~ in path with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSyncDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DirEnvVar, dir)
+	syncDir := filepath.Join(t.TempDir(), "resumake-data")
+
+	// Test case 1: Without a sync directory the data stays in the configuration directory
+	if got, err := SyncDir(); err != nil || got != "" {
+		t.Errorf("Expected no sync directory, got %q (%v)", got, err)
+	}
+	if path, _ := DataPath("snippets"); path != filepath.Join(dir, "snippets") {
+		t.Errorf("Expected the snippets in the configuration directory, got %q", path)
+	}
+
+	// Test case 2: The sync-dir setting moves the data there
+	if err := os.WriteFile(filepath.Join(dir, SettingsFileName), []byte("sync-dir = "+syncDir+"\nqr = example.com\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := SyncDir(); err != nil || got != syncDir {
+		t.Errorf("Expected %q, got %q (%v)", syncDir, got, err)
+	}
+	if path, _ := DataPath("snippets"); path != filepath.Join(syncDir, "snippets") {
+		t.Errorf("Expected the snippets in the sync directory, got %q", path)
+	}
+
+	// Test case 3: The sync directory's settings fill in those this machine leaves out
+	if err := os.MkdirAll(syncDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(syncDir, SettingsFileName), []byte("qr = other.com\nstale-after = 30\nsync-dir = /elsewhere\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if settings[SettingQR] != "example.com" || settings[SettingStaleAfter] != "30" || settings[SettingSyncDir] != syncDir {
+		t.Errorf("Unexpected settings %v", settings)
+	}
+
+	// Test case 4: A leading ~ is the home directory
+	t.Setenv("HOME", "/tmp/home")
+	if got := expandHome("~/resumake-data"); got != filepath.Join("/tmp/home", "resumake-data") {
+		t.Errorf("Expected the home directory, got %q", got)
+	}
+}
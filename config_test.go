@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+)
+
+func TestRunConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RESUMAKE_CONFIG_DIR", dir)
+	t.Setenv(api.GatewayTokenEnv, "")
+	if err := os.WriteFile(filepath.Join(dir, "settings"), []byte("api-token = secret-token\napi-headers = X-Team: resumes\nqr = linkedin.com/in/janedoe\nencryption = keychain\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.SetGateway("", "secret-token", "X-Team: resumes"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { api.ActiveGateway = api.Gateway{} })
+
+	var out strings.Builder
+	if err := runConfig(&out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, want := range []string{
+		"Configuration directory: " + dir,
+		"snippets (not created yet)",
+		"api-token      set (hidden) (settings file)",
+		"api-headers    X-Team (settings file)",
+		"max-file-size  10MB (default)",
+		"qr             https://linkedin.com/in/janedoe (settings file)",
+		"qr-layouts     all layouts (default)",
+		"sync-dir       not set (default)",
+		"encryption     keychain (history encrypted with a key in the system keychain) (settings file)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the configuration, got %q", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "secret-token") || strings.Contains(out.String(), "resumes") {
+		t.Errorf("Expected secrets to be hidden, got %q", out.String())
+	}
+}
+
+func TestQROptions(t *testing.T) {
+	settings := map[string]string{"qr": "janedoe.dev", "qr-layouts": "standard"}
+
+	// Test case 1: The settings apply when no flags are given
+	link, layouts, err := qrOptions(input.Flags{}, settings)
+	if err != nil || link != "https://janedoe.dev" || !slices.Equal(layouts, []output.Layout{output.LayoutStandard}) {
+		t.Errorf("Expected the settings, got %q %v (%v)", link, layouts, err)
+	}
+
+	// Test case 2: Flags override the settings, and none turns the code off
+	link, layouts, err = qrOptions(input.Flags{QR: "linkedin.com/in/janedoe", QRLayouts: "compact,two-column"}, settings)
+	if err != nil || link != "https://linkedin.com/in/janedoe" || len(layouts) != 2 {
+		t.Errorf("Expected the flags, got %q %v (%v)", link, layouts, err)
+	}
+	if link, _, err := qrOptions(input.Flags{QR: "none"}, settings); err != nil || link != "" {
+		t.Errorf("Expected no QR code, got %q (%v)", link, err)
+	}
+
+	// Test case 3: Errors name where the bad value came from
+	if _, _, err := qrOptions(input.Flags{}, map[string]string{"qr": "mailto:jane@example.com"}); err == nil || !strings.Contains(err.Error(), "the qr setting") {
+		t.Errorf("Expected an error naming the setting, got %v", err)
+	}
+	if _, _, err := qrOptions(input.Flags{QRLayouts: "poster"}, nil); err == nil || !strings.Contains(err.Error(), "-qr-layouts") {
+		t.Errorf("Expected an error naming the flag, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+)
+
+// runConvert converts a Markdown resume to the requested formats and lists
+// the files it wrote to w. It uses the same exporters as a generated resume,
+// without the API, so no API key is needed.
+func runConvert(flags input.ConvertFlags, w io.Writer) error {
+	targets, err := output.ParseOutputTargets(nil, flags.Formats)
+	if err != nil {
+		return err
+	}
+
+	// The converted files hold the same personal data as the resume
+	if flags.OutputMode != "" {
+		if output.FileMode, err = output.ParseFileMode(flags.OutputMode); err != nil {
+			return err
+		}
+	}
+
+	// A layout also renders the resume as HTML, like it does when generating
+	var layout output.Layout
+	if flags.Layout != "" {
+		if layout, err = output.ParseLayout(flags.Layout); err != nil {
+			return err
+		}
+	} else if targets.HTML {
+		layout = output.LayoutStandard
+	}
+	if flags.Timeline && layout == "" {
+		return errors.New("-timeline adds a section to the HTML resume, so it requires -layout or the html format")
+	}
+	if flags.PageBreaks && layout == "" {
+		return errors.New("-page-breaks marks the HTML resume, so it requires -layout or the html format")
+	}
+	if layout == "" && !targets.JSON && len(targets.Exports) == 0 {
+		return errors.New("no formats to convert to; choose from html, json, docx, pdf, and odt")
+	}
+
+	resume := document.Parse(flags.SourceContent)
+	fmt.Fprintf(w, "Converting %s...\n", flags.SourcePath)
+	if warning := input.ExtensionWarning(flags.SourcePath); warning != "" {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+
+	htmlPath := ""
+	if layout != "" {
+		htmlPath, err = output.WriteHTMLWithOptions(resume, layout, output.HTMLOptions{Timeline: flags.Timeline, PageBreaks: flags.PageBreaks}, flags.SourcePath)
+		if err != nil {
+			return fmt.Errorf("error writing HTML file: %w", err)
+		}
+		fmt.Fprintf(w, "  HTML: %s\n", htmlPath)
+		if flags.PageBreaks {
+			fmt.Fprint(w, formatPageBreaks(output.EstimatePageBreaks(resume, layout)))
+		}
+	}
+
+	exports, err := output.WriteExports(flags.SourceContent, flags.SourcePath, targets.Exports, output.ExportOptions{
+		Pandoc:   output.PandocPath(),
+		HTMLPath: htmlPath,
+	})
+	for _, export := range exports {
+		fmt.Fprint(w, formatExport(export))
+	}
+	if err != nil {
+		return fmt.Errorf("error exporting resume: %w", err)
+	}
+
+	// The JSON Resume goes last: a resume that breaks the schema is reported
+	// after every other format has been written
+	if targets.JSON {
+		jsonResume := resume.JSONResume()
+		jsonPath, err := output.WriteJSONResume(jsonResume, flags.SourcePath)
+		if err != nil {
+			return fmt.Errorf("error writing JSON Resume file: %w", err)
+		}
+		fmt.Fprintf(w, "  JSON: %s\n", jsonPath)
+		for _, warning := range jsonResume.Warnings() {
+			fmt.Fprintf(w, "    Warning: %s\n", warning)
+		}
+	}
+	return nil
+}
+
+// formatPageBreaks describes the estimated page breaks of the HTML resume,
+// with a warning for each one that splits a role's bullets.
+func formatPageBreaks(breaks []output.PageBreak) string {
+	if len(breaks) == 0 {
+		return "    (fits on one page)\n"
+	}
+	lines := fmt.Sprintf("    (about %d pages)\n", len(breaks)+1)
+	for _, pageBreak := range breaks {
+		if warning := pageBreak.Warning(); warning != "" {
+			lines += "    Warning: " + warning + "\n"
+		}
+	}
+	return lines
+}
+
+// formatExport describes one exported file, with the reason a fallback was
+// used when pandoc did not produce it.
+func formatExport(export output.Export) string {
+	line := fmt.Sprintf("  %s: %s\n", strings.ToUpper(string(export.Format)), export.Path)
+	if export.Note != "" {
+		line += fmt.Sprintf("    (%s)\n", export.Note)
+	}
+	return line
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+)
+
+const convertResume = `# Jane Doe
+
+jane@example.com
+
+## Experience
+
+### Software Engineer, Acme (2020 - 2023)
+- Built the checkout service in Go
+
+## Skills
+- Go, SQL
+`
+
+// writeConvertResume writes a Markdown resume to a temporary directory and
+// returns its path
+func writeConvertResume(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "jane.md")
+	if err := os.WriteFile(path, []byte(convertResume), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunConvert(t *testing.T) {
+	path := writeConvertResume(t)
+	flags := input.ConvertFlags{SourcePath: path, SourceContent: convertResume, Formats: "html,docx,json", OutputMode: "0640"}
+	defer func() { output.FileMode = output.DefaultFileMode }()
+
+	var out strings.Builder
+	if err := runConvert(flags, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stem := strings.TrimSuffix(path, ".md")
+	for _, ext := range []string{".html", ".docx", ".json"} {
+		if _, err := os.Stat(stem + ext); err != nil {
+			t.Errorf("Expected %s to be written: %v", stem+ext, err)
+		}
+		if !strings.Contains(out.String(), stem+ext) {
+			t.Errorf("Expected the output to list %s, got %q", stem+ext, out.String())
+		}
+		if info, err := os.Stat(stem + ext); err == nil && info.Mode().Perm()&^0640 != 0 {
+			t.Errorf("Expected %s to be at most 0640, got %o", stem+ext, info.Mode().Perm())
+		}
+	}
+
+	html, err := os.ReadFile(stem + ".html")
+	if err != nil || !strings.Contains(string(html), "Jane Doe") {
+		t.Errorf("Expected the HTML resume to contain the name, got %v", err)
+	}
+}
+
+func TestRunConvertJSONWarnings(t *testing.T) {
+	content := convertResume + "\n## Education\n- BS Computer Science, 2019\n"
+	path := filepath.Join(t.TempDir(), "jane.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A degree without a school is allowed by the schema, so it is written
+	// with a warning
+	var out strings.Builder
+	flags := input.ConvertFlags{SourcePath: path, SourceContent: content, Formats: "json"}
+	if err := runConvert(flags, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := os.Stat(strings.TrimSuffix(path, ".md") + ".json"); err != nil {
+		t.Errorf("Expected the JSON Resume to be written: %v", err)
+	}
+	if !strings.Contains(out.String(), "Warning: education[0].institution: is empty") {
+		t.Errorf("Expected a warning about the missing school, got %q", out.String())
+	}
+}
+
+func TestRunConvertErrors(t *testing.T) {
+	path := writeConvertResume(t)
+
+	// Test case 1: An unknown format is an error
+	flags := input.ConvertFlags{SourcePath: path, SourceContent: convertResume, Formats: "rtf"}
+	if err := runConvert(flags, &strings.Builder{}); err == nil || !strings.Contains(err.Error(), "rtf") {
+		t.Errorf("Expected an unknown format error, got %v", err)
+	}
+
+	// Test case 2: Markdown alone leaves nothing to convert to
+	flags.Formats = "md"
+	if err := runConvert(flags, &strings.Builder{}); err == nil {
+		t.Error("Expected an error when there is nothing to convert to")
+	}
+
+	// Test case 3: A timeline needs an HTML resume
+	flags.Formats, flags.Timeline = "docx", true
+	if err := runConvert(flags, &strings.Builder{}); err == nil {
+		t.Error("Expected an error for -timeline without HTML")
+	}
+
+	// Test case 4: An invalid output mode is an error
+	flags.Formats, flags.Timeline, flags.OutputMode = "docx", false, "0400"
+	if err := runConvert(flags, &strings.Builder{}); err == nil {
+		t.Error("Expected an error for an output mode the owner cannot write")
+	}
+	flags.OutputMode = ""
+
+	// Test case 5: A layout alone writes the HTML resume
+	flags.Formats, flags.Timeline, flags.Layout = "md", false, "compact"
+	if err := runConvert(flags, &strings.Builder{}); err != nil {
+		t.Fatalf("Expected the layout to select HTML, got %v", err)
+	}
+	if _, err := os.Stat(strings.TrimSuffix(path, ".md") + ".html"); err != nil {
+		t.Errorf("Expected the HTML resume to be written: %v", err)
+	}
+
+	// Test case 6: Page breaks need an HTML resume, and are summarized with it
+	flags.Formats, flags.Layout, flags.PageBreaks = "docx", "", true
+	if err := runConvert(flags, &strings.Builder{}); err == nil {
+		t.Error("Expected an error for -page-breaks without HTML")
+	}
+	flags.Formats = "html"
+	var out strings.Builder
+	if err := runConvert(flags, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "(fits on one page)") {
+		t.Errorf("Expected the page count, got %q", out.String())
+	}
+}
+
+func TestFormatPageBreaks(t *testing.T) {
+	breaks := []output.PageBreak{{Page: 2, Section: "Experience", Role: "Engineer, Acme"}, {Page: 3, Section: "Skills"}}
+
+	// Test case 1: Breaks that split a role's bullets are warned about
+	want := "    (about 3 pages)\n    Warning: The bullets of \"Engineer, Acme\" are split across pages 1 and 2.\n"
+	if got := formatPageBreaks(breaks); got != want {
+		t.Errorf("formatPageBreaks() = %q, want %q", got, want)
+	}
+}
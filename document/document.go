@@ -0,0 +1,320 @@
+// Package document defines the structured resume model.
+//
+// Free-form notes and existing resumes are sent to the model as text, but
+// some details are captured in structured form so they can be rendered
+// consistently regardless of how the model phrases the rest of the resume.
+// The Resume type collects that structured data.
+package document
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Proficiency describes how well a skill is known.
+type Proficiency int
+
+const (
+	// ProficiencyUnspecified means no proficiency level was given.
+	ProficiencyUnspecified Proficiency = iota
+
+	// ProficiencyBeginner indicates basic familiarity.
+	ProficiencyBeginner
+
+	// ProficiencyIntermediate indicates regular, independent use.
+	ProficiencyIntermediate
+
+	// ProficiencyAdvanced indicates deep, production experience.
+	ProficiencyAdvanced
+
+	// ProficiencyExpert indicates recognized expertise.
+	ProficiencyExpert
+)
+
+// proficiencyNames holds the display name of each proficiency level, indexed by value.
+var proficiencyNames = []string{"", "Beginner", "Intermediate", "Advanced", "Expert"}
+
+// Proficiencies lists the selectable proficiency levels from lowest to highest.
+var Proficiencies = []Proficiency{
+	ProficiencyUnspecified,
+	ProficiencyBeginner,
+	ProficiencyIntermediate,
+	ProficiencyAdvanced,
+	ProficiencyExpert,
+}
+
+// String returns the display name of the proficiency level.
+// ProficiencyUnspecified returns an empty string.
+func (p Proficiency) String() string {
+	if p < 0 || int(p) >= len(proficiencyNames) {
+		return ""
+	}
+	return proficiencyNames[p]
+}
+
+// MarshalText encodes the proficiency level as its name, so saved skills
+// stay readable and do not depend on the order of the levels.
+func (p Proficiency) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText decodes a proficiency level from its name.
+func (p *Proficiency) UnmarshalText(text []byte) error {
+	level, err := ParseProficiency(string(text))
+	if err != nil {
+		return err
+	}
+	*p = level
+	return nil
+}
+
+// ParseProficiency converts a proficiency name (case-insensitive) to a Proficiency.
+// An empty name yields ProficiencyUnspecified.
+//
+// Parameters:
+//   - name: The proficiency name, such as "expert"
+//
+// Returns:
+//   - Proficiency: The matching proficiency level
+//   - error: An error if the name is not a known level
+func ParseProficiency(name string) (Proficiency, error) {
+	name = strings.TrimSpace(name)
+	for i, candidate := range proficiencyNames {
+		if strings.EqualFold(candidate, name) {
+			return Proficiency(i), nil
+		}
+	}
+	return ProficiencyUnspecified, fmt.Errorf("unknown proficiency %q (expected one of %s)", name, strings.Join(proficiencyNames[1:], ", "))
+}
+
+// Skill is a single entry in the structured skills list.
+type Skill struct {
+	Name        string      `json:"name"`                  // The skill, such as "Go" or "Kubernetes"
+	Years       int         `json:"years,omitempty"`       // Years of experience (0 if not given)
+	Proficiency Proficiency `json:"proficiency,omitempty"` // Self-assessed proficiency
+}
+
+// MaxSkillYears is the largest accepted number of years of experience.
+const MaxSkillYears = 60
+
+// Validate checks that the skill has a name and a plausible number of years.
+//
+// Returns:
+//   - error: A description of the first problem found, or nil
+func (s Skill) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("skill name is required")
+	}
+	if s.Years < 0 || s.Years > MaxSkillYears {
+		return fmt.Errorf("years of experience must be between 0 and %d", MaxSkillYears)
+	}
+	return nil
+}
+
+// Details returns the proficiency and years of the skill as a short phrase,
+// such as "Expert, 6 years". It returns an empty string if neither is set.
+func (s Skill) Details() string {
+	var details []string
+	if s.Proficiency != ProficiencyUnspecified {
+		details = append(details, s.Proficiency.String())
+	}
+	switch {
+	case s.Years == 1:
+		details = append(details, "1 year")
+	case s.Years > 1:
+		details = append(details, fmt.Sprintf("%d years", s.Years))
+	}
+	return strings.Join(details, ", ")
+}
+
+// Academics holds the GPA and relevant coursework of a student or recent
+// graduate, shown in the Education section of a new-grad resume.
+type Academics struct {
+	GPA        string   `json:"gpa,omitempty"`        // Grade point average as given, such as "3.8" or "3.8/4.0" (empty if not given)
+	Coursework []string `json:"coursework,omitempty"` // Relevant courses, such as "Algorithms" or "Operating Systems"
+}
+
+// DefaultGPAScale is the scale a GPA is checked against when none is given.
+const DefaultGPAScale = 4.0
+
+// Validate checks that the GPA is a number, optionally followed by its scale
+// ("3.8/4.0"), and is not above the scale.
+//
+// Returns:
+//   - error: A description of the problem, or nil
+func (a Academics) Validate() error {
+	gpa := strings.TrimSpace(a.GPA)
+	if gpa == "" {
+		return nil
+	}
+
+	value, scaleText, hasScale := strings.Cut(gpa, "/")
+	scale := DefaultGPAScale
+	if hasScale {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(scaleText), 64)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("GPA scale %q is not a positive number", strings.TrimSpace(scaleText))
+		}
+		scale = parsed
+	}
+	score, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || score < 0 {
+		return fmt.Errorf("GPA %q is not a number, such as 3.8 or 3.8/4.0", gpa)
+	}
+	if score > scale {
+		return fmt.Errorf("GPA %s is above the %g scale; give the scale as well, such as %s/5.0", strings.TrimSpace(value), scale, strings.TrimSpace(value))
+	}
+	return nil
+}
+
+// Lines returns the GPA and coursework as lines such as "GPA: 3.8/4.0" and
+// "Relevant coursework: Algorithms, Databases", leaving out what is not set.
+//
+// Returns:
+//   - []string: The lines, or nil if neither is set
+func (a Academics) Lines() []string {
+	var lines []string
+	if gpa := strings.TrimSpace(a.GPA); gpa != "" {
+		lines = append(lines, "GPA: "+gpa)
+	}
+	if len(a.Coursework) > 0 {
+		lines = append(lines, "Relevant coursework: "+strings.Join(a.Coursework, ", "))
+	}
+	return lines
+}
+
+// Section is one top-level section of a resume, such as Experience or Education.
+type Section struct {
+	Heading string `json:"heading"` // Heading text without the leading "##"
+	Body    string `json:"body"`    // Markdown content below the heading, including any subsections
+}
+
+// Resume is the structured resume model. Its JSON form is what resumake
+// saves in the sidecar next to each resume it writes.
+type Resume struct {
+	Name      string    `json:"name,omitempty"`   // Candidate name from the document title
+	Header    string    `json:"header,omitempty"` // Markdown between the title and the first section, such as contact details
+	Sections  []Section `json:"sections"`         // Top-level sections in document order
+	Skills    []Skill   `json:"skills,omitempty"` // Skills entered through the skills form
+	Academics Academics `json:"academics"`        // GPA and coursework given with -gpa and -coursework
+}
+
+// skillsHeading is the heading used for the rendered structured skills section.
+const skillsHeading = "Skills"
+
+// Parse splits a Markdown resume into its title, header, and top-level
+// sections. The first level-one heading is taken as the candidate name and
+// every level-two heading starts a new section; deeper headings stay part of
+// the section body. Structured skills are not parsed and must be set separately.
+//
+// Parameters:
+//   - markdown: The Markdown resume
+//
+// Returns:
+//   - Resume: The structured resume
+//
+// Example:
+//
+//	r := document.Parse("# Jane Doe\n\njane@example.com\n\n## Experience\n\n- Acme")
+//	// r.Name == "Jane Doe", r.Sections[0].Heading == "Experience"
+func Parse(markdown string) Resume {
+	var r Resume
+	var header, body []string
+	current := -1
+
+	flush := func() {
+		if current >= 0 {
+			r.Sections[current].Body = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+		body = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case r.Name == "" && current < 0 && strings.HasPrefix(trimmed, "# "):
+			r.Name = strings.TrimSpace(strings.TrimPrefix(trimmed, "# "))
+		case strings.HasPrefix(trimmed, "## "):
+			flush()
+			r.Sections = append(r.Sections, Section{Heading: strings.TrimSpace(strings.TrimLeft(trimmed, "# "))})
+			current = len(r.Sections) - 1
+		case current < 0:
+			header = append(header, line)
+		default:
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	r.Header = strings.TrimSpace(strings.Join(header, "\n"))
+	return r
+}
+
+// Headings returns the headings of the top-level sections in document order.
+//
+// Returns:
+//   - []string: The section headings
+func (r Resume) Headings() []string {
+	headings := make([]string, len(r.Sections))
+	for i, section := range r.Sections {
+		headings[i] = section.Heading
+	}
+	return headings
+}
+
+// RenderedSections returns the sections to render. When structured skills
+// are present they replace the body of the first section whose heading
+// mentions skills, or are added as a new section at the end, so every
+// layout shows the same Skills section.
+//
+// Returns:
+//   - []Section: The sections in document order
+func (r Resume) RenderedSections() []Section {
+	sections := append([]Section(nil), r.Sections...)
+	if len(r.Skills) == 0 {
+		return sections
+	}
+
+	for i, section := range sections {
+		if strings.Contains(strings.ToLower(section.Heading), "skills") {
+			sections[i].Body = r.skillsList()
+			return sections
+		}
+	}
+	return append(sections, Section{Heading: skillsHeading, Body: r.skillsList()})
+}
+
+// SkillsMarkdown renders the structured skills as a Markdown Skills section.
+// Skills keep the order in which they were entered so users control emphasis.
+// It returns an empty string when there are no skills.
+//
+// Returns:
+//   - string: The Skills section, starting with a "## Skills" heading
+//
+// Example:
+//
+//	r := document.Resume{Skills: []document.Skill{{Name: "Go", Years: 6, Proficiency: document.ProficiencyExpert}}}
+//	fmt.Println(r.SkillsMarkdown())
+//	// ## Skills
+//	//
+//	// - **Go** (Expert, 6 years)
+func (r Resume) SkillsMarkdown() string {
+	if len(r.Skills) == 0 {
+		return ""
+	}
+	return "## " + skillsHeading + "\n\n" + r.skillsList()
+}
+
+// skillsList renders the structured skills as a Markdown list.
+func (r Resume) skillsList() string {
+	items := make([]string, 0, len(r.Skills))
+	for _, skill := range r.Skills {
+		item := "- **" + strings.TrimSpace(skill.Name) + "**"
+		if details := skill.Details(); details != "" {
+			item += " (" + details + ")"
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, "\n")
+}
@@ -0,0 +1,188 @@
+package document
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseProficiency(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  Proficiency
+		expectErr bool
+	}{
+		{"empty", "", ProficiencyUnspecified, false},
+		{"lowercase", "expert", ProficiencyExpert, false},
+		{"mixed case with spaces", "  Intermediate ", ProficiencyIntermediate, false},
+		{"unknown", "guru", ProficiencyUnspecified, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProficiency(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseProficiency(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseProficiency(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	// Every selectable level round-trips through its name
+	for _, p := range Proficiencies {
+		if got, err := ParseProficiency(p.String()); err != nil || got != p {
+			t.Errorf("Round trip of %v failed: got %v, %v", p, got, err)
+		}
+	}
+}
+
+func TestSkillJSON(t *testing.T) {
+	skills := []Skill{{Name: "Go", Years: 6, Proficiency: ProficiencyExpert}, {Name: "Rust"}}
+	data, err := json.Marshal(skills)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `[{"name":"Go","years":6,"proficiency":"Expert"},{"name":"Rust"}]`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded []Skill
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, skills) {
+		t.Errorf("Expected %v after a round trip, got %v", skills, decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"name":"Go","proficiency":"Guru"}`), &Skill{}); err == nil {
+		t.Error("Expected an error for an unknown proficiency")
+	}
+}
+
+func TestSkillValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		skill     Skill
+		expectErr bool
+	}{
+		{"valid", Skill{Name: "Go", Years: 5, Proficiency: ProficiencyAdvanced}, false},
+		{"name only", Skill{Name: "SQL"}, false},
+		{"missing name", Skill{Name: "  ", Years: 2}, true},
+		{"negative years", Skill{Name: "Go", Years: -1}, true},
+		{"too many years", Skill{Name: "Go", Years: MaxSkillYears + 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.skill.Validate(); (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestAcademicsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		academics Academics
+		expectErr bool
+	}{
+		{"empty", Academics{}, false},
+		{"four-point scale", Academics{GPA: "3.8"}, false},
+		{"explicit scale", Academics{GPA: "4.6 / 5.0"}, false},
+		{"not a number", Academics{GPA: "great"}, true},
+		{"negative", Academics{GPA: "-1"}, true},
+		{"above the default scale", Academics{GPA: "4.6"}, true},
+		{"zero scale", Academics{GPA: "3.8/0"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.academics.Validate(); (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestAcademicsLines(t *testing.T) {
+	got := Academics{GPA: " 3.8/4.0 ", Coursework: []string{"Algorithms", "Databases"}}.Lines()
+	want := []string{"GPA: 3.8/4.0", "Relevant coursework: Algorithms, Databases"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %v, want %v", got, want)
+	}
+
+	if got := (Academics{}).Lines(); got != nil {
+		t.Errorf("Expected no lines when nothing is set, got %v", got)
+	}
+}
+
+func TestSkillsMarkdown(t *testing.T) {
+	// Test case 1: No skills renders nothing
+	if got := (Resume{}).SkillsMarkdown(); got != "" {
+		t.Errorf("Expected empty section, got %q", got)
+	}
+
+	// Test case 2: Skills render in entry order with their details
+	r := Resume{Skills: []Skill{
+		{Name: "Go", Years: 6, Proficiency: ProficiencyExpert},
+		{Name: "Rust", Years: 1},
+		{Name: "SQL", Proficiency: ProficiencyAdvanced},
+		{Name: "Bash"},
+	}}
+	expected := "## Skills\n\n- **Go** (Expert, 6 years)\n- **Rust** (1 year)\n- **SQL** (Advanced)\n- **Bash**"
+	if got := r.SkillsMarkdown(); got != expected {
+		t.Errorf("SkillsMarkdown() = %q, want %q", got, expected)
+	}
+}
+
+func TestParse(t *testing.T) {
+	markdown := "# Jane Doe\n\njane@example.com | Portland, OR\n\n## Experience\n\n### Acme\n\n- Built things\n\n## Education\n\n- BSc"
+	r := Parse(markdown)
+
+	if r.Name != "Jane Doe" {
+		t.Errorf("Expected name %q, got %q", "Jane Doe", r.Name)
+	}
+	if r.Header != "jane@example.com | Portland, OR" {
+		t.Errorf("Unexpected header %q", r.Header)
+	}
+	if len(r.Sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(r.Sections))
+	}
+	if r.Sections[0].Heading != "Experience" || r.Sections[0].Body != "### Acme\n\n- Built things" {
+		t.Errorf("Unexpected first section %+v", r.Sections[0])
+	}
+	if r.Sections[1].Heading != "Education" || r.Sections[1].Body != "- BSc" {
+		t.Errorf("Unexpected second section %+v", r.Sections[1])
+	}
+}
+
+func TestRenderedSections(t *testing.T) {
+	skills := []Skill{{Name: "Go", Proficiency: ProficiencyExpert}}
+
+	// Test case 1: Without structured skills the sections are unchanged
+	r := Parse("# Jane\n\n## Technical Skills\n\n- go, python")
+	if got := r.RenderedSections(); got[0].Body != "- go, python" {
+		t.Errorf("Expected original skills body, got %q", got[0].Body)
+	}
+
+	// Test case 2: Structured skills replace the existing skills section
+	r.Skills = skills
+	got := r.RenderedSections()
+	if len(got) != 1 || got[0].Heading != "Technical Skills" || got[0].Body != "- **Go** (Expert)" {
+		t.Errorf("Expected structured skills in the existing section, got %+v", got)
+	}
+	if r.Sections[0].Body != "- go, python" {
+		t.Error("RenderedSections should not modify the resume")
+	}
+
+	// Test case 3: Structured skills are appended when there is no skills section
+	r = Parse("# Jane\n\n## Experience\n\n- Acme")
+	r.Skills = skills
+	got = r.RenderedSections()
+	if len(got) != 2 || got[1].Heading != "Skills" {
+		t.Errorf("Expected an appended Skills section, got %+v", got)
+	}
+}
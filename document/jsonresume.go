@@ -0,0 +1,745 @@
+package document
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// JSONResume is a resume in the JSON Resume format (https://jsonresume.org/schema).
+// Only the sections that can be derived from a Markdown resume are included:
+// basics, work, education, and skills.
+type JSONResume struct {
+	Basics    JSONBasics      `json:"basics"`
+	Work      []JSONWork      `json:"work,omitempty"`
+	Education []JSONEducation `json:"education,omitempty"`
+	Skills    []JSONSkill     `json:"skills,omitempty"`
+
+	// unplacedHighlights are experience bullets with no position above them,
+	// which cannot be exported until they are given one or left out
+	unplacedHighlights []string
+}
+
+// JSONBasics holds the candidate's name and contact details.
+type JSONBasics struct {
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// JSONWork is one position in the work history.
+type JSONWork struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position"`
+	StartDate  string   `json:"startDate,omitempty"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+// JSONEducation is one degree or course of study.
+type JSONEducation struct {
+	Institution string   `json:"institution"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	Courses     []string `json:"courses,omitempty"`
+}
+
+// JSONSkill is a skill or a named group of skills.
+type JSONSkill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// Violation is one way a JSON Resume breaks the schema, or a field the
+// schema allows to be empty that the export is of little use without.
+type Violation struct {
+	Field   string // Path of the field, such as "work[0].startDate"
+	Message string // What is wrong with the value
+	Value   string // The current value
+
+	set func(*JSONResume, string)
+}
+
+// Fix replaces the value of the violating field in resume.
+//
+// Parameters:
+//   - resume: The resume the violation was found in
+//   - value: The corrected value
+func (v Violation) Fix(resume *JSONResume, value string) {
+	if v.set != nil {
+		v.set(resume, strings.TrimSpace(value))
+	}
+}
+
+// String describes the violation, such as `work[0].startDate: must be a date (got "Spring 2019")`.
+func (v Violation) String() string {
+	if v.Value == "" {
+		return v.Field + ": " + v.Message
+	}
+	return fmt.Sprintf("%s: %s (got %q)", v.Field, v.Message, v.Value)
+}
+
+var (
+	// isoDateRegex is the iso8601 pattern from the JSON Resume schema
+	isoDateRegex = regexp.MustCompile(`^([1-2][0-9]{3}-[0-1][0-9]-[0-3][0-9]|[1-2][0-9]{3}-[0-1][0-9]|[1-2][0-9]{3})$`)
+
+	// emailRegex accepts addresses with a local part, a domain, and a top-level domain
+	emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// Messages for schema violations
+const (
+	violationEmpty = "is empty"
+	violationDate  = "must be a date formatted as YYYY, YYYY-MM, or YYYY-MM-DD"
+	violationEmail = "must be an email address such as jane@example.com"
+	violationURL   = "must be a full URL starting with https://"
+
+	violationUnplaced = "has highlights without a position, starting with %q; enter the position as \"Position at Company\" to export them, or leave it empty to leave them out"
+)
+
+// Validate checks the resume against the JSON Resume schema: dates must match
+// the schema's ISO 8601 pattern, and emails and URLs must be well formed.
+// Experience bullets with no position are reported too, since the schema
+// only holds highlights within a position. Names, positions, and
+// institutions are optional in the schema, so empty ones are reported by
+// Warnings instead.
+//
+// Returns:
+//   - []Violation: Every violation found, in document order (empty if valid)
+//
+// Example:
+//
+//	for _, v := range resume.Validate() {
+//	    fmt.Println(v)
+//	}
+func (j JSONResume) Validate() []Violation {
+	var violations []Violation
+	add := func(field, message, value string, set func(*JSONResume, string)) {
+		violations = append(violations, Violation{Field: field, Message: message, Value: value, set: set})
+	}
+	date := func(field, value string, set func(*JSONResume, string)) {
+		if value != "" && !isoDateRegex.MatchString(value) {
+			add(field, violationDate, value, set)
+		}
+	}
+
+	if j.Basics.Email != "" && !emailRegex.MatchString(j.Basics.Email) {
+		add("basics.email", violationEmail, j.Basics.Email, func(r *JSONResume, v string) { r.Basics.Email = v })
+	}
+	if j.Basics.URL != "" && !isAbsoluteURL(j.Basics.URL) {
+		add("basics.url", violationURL, j.Basics.URL, func(r *JSONResume, v string) { r.Basics.URL = v })
+	}
+
+	if len(j.unplacedHighlights) > 0 {
+		add("work", fmt.Sprintf(violationUnplaced, j.unplacedHighlights[0]), "", (*JSONResume).placeHighlights)
+	}
+	for i, work := range j.Work {
+		prefix := fmt.Sprintf("work[%d].", i)
+		date(prefix+"startDate", work.StartDate, func(r *JSONResume, v string) { r.Work[i].StartDate = v })
+		date(prefix+"endDate", work.EndDate, func(r *JSONResume, v string) { r.Work[i].EndDate = v })
+	}
+
+	for i, education := range j.Education {
+		prefix := fmt.Sprintf("education[%d].", i)
+		date(prefix+"startDate", education.StartDate, func(r *JSONResume, v string) { r.Education[i].StartDate = v })
+		date(prefix+"endDate", education.EndDate, func(r *JSONResume, v string) { r.Education[i].EndDate = v })
+	}
+
+	return violations
+}
+
+// Warnings lists the fields the schema allows to be empty but that an export
+// is of little use without: the candidate's name, each position's employer
+// and title, each school, and each skill's name. Unlike violations, they do
+// not stop the export from being written.
+//
+// Returns:
+//   - []Violation: Every empty field, in document order (empty if none)
+//
+// Example:
+//
+//	for _, w := range resume.Warnings() {
+//	    fmt.Println("Warning:", w)
+//	}
+func (j JSONResume) Warnings() []Violation {
+	var warnings []Violation
+	empty := func(field, value string, set func(*JSONResume, string)) {
+		if strings.TrimSpace(value) == "" {
+			warnings = append(warnings, Violation{Field: field, Message: violationEmpty, Value: value, set: set})
+		}
+	}
+
+	empty("basics.name", j.Basics.Name, func(r *JSONResume, v string) { r.Basics.Name = v })
+	for i, work := range j.Work {
+		prefix := fmt.Sprintf("work[%d].", i)
+		empty(prefix+"name", work.Name, func(r *JSONResume, v string) { r.Work[i].Name = v })
+		empty(prefix+"position", work.Position, func(r *JSONResume, v string) { r.Work[i].Position = v })
+	}
+	for i, education := range j.Education {
+		empty(fmt.Sprintf("education[%d].institution", i), education.Institution, func(r *JSONResume, v string) { r.Education[i].Institution = v })
+	}
+	for i, skill := range j.Skills {
+		empty(fmt.Sprintf("skills[%d].name", i), skill.Name, func(r *JSONResume, v string) { r.Skills[i].Name = v })
+	}
+	return warnings
+}
+
+// placeHighlights gives the experience bullets that have no position the
+// position written as "Position at Company", ahead of the other positions,
+// or leaves them out when position is empty.
+func (j *JSONResume) placeHighlights(position string) {
+	if position != "" {
+		title, name := splitTitle(position)
+		j.Work = append([]JSONWork{{Name: name, Position: title, Highlights: j.unplacedHighlights}}, j.Work...)
+	}
+	j.unplacedHighlights = nil
+}
+
+// isAbsoluteURL reports whether value is a URL with a scheme and host.
+func isAbsoluteURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+var (
+	// Contact details in the resume header
+	headerEmailRegex = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	headerPhoneRegex = regexp.MustCompile(`\+?\(?\d[\d\s().-]{7,}\d`)
+	headerURLRegex   = regexp.MustCompile(`(?i)\bhttps?://[^\s)>\]]+|\b(?:www\.|linkedin\.com/|github\.com/)[^\s)>\]]+`)
+
+	// dateToken matches one date as written in a resume, such as
+	// "Jan 2020", "03/2021", "2019-04", or "2018"; seasons such as
+	// "Spring 2019" also match so they can be reported as invalid
+	dateToken = `[A-Za-z]+\.?[ \t]+\d{4}|\d{1,2}/\d{4}|\d{4}(?:-\d{2}){0,2}`
+
+	// dateRangeRegex matches a range of dates, possibly open-ended
+	dateRangeRegex = regexp.MustCompile(`(?i)(` + dateToken + `)[ \t]*(?:-|–|—|\bto\b)[ \t]*(` + dateToken + `|present|current|now)\b`)
+
+	// singleDateRegex matches a single month and year or a year
+	singleDateRegex = regexp.MustCompile(`(?i)\b(?:(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?[ \t]+)?(?:19|20)\d{2}\b`)
+
+	// emptyBracketsRegex matches the brackets left behind when the dates
+	// inside them are removed, such as "()" in "Acme ()"
+	emptyBracketsRegex = regexp.MustCompile(`\(\s*\)|\[\s*\]`)
+
+	// degreeRegex matches a degree name and the optional field of study after "in"
+	degreeRegex = regexp.MustCompile(`\b((?:Bachelor|Master|Doctor|Associate) of [A-Z][A-Za-z]*(?: [A-Z][A-Za-z]*)*)(?:\s+in\s+([^,|–—\n(]+))?`)
+
+	// degreeAbbreviationRegex matches a part of an education entry that
+	// starts with an abbreviated degree, such as "BS Computer Science" or
+	// "Ph.D. in Physics", capturing the degree and the field of study
+	degreeAbbreviationRegex = regexp.MustCompile(`^(B\.?S(?:c|\.)?|B\.?A\.?|B\.?Eng\.?|M\.?S(?:c|\.)?|M\.?A\.?|M\.?Eng\.?|MBA|Ph\.?D\.?)(?:\s+(?:in\s+)?(.+))?$`)
+
+	// institutionRegex matches words that identify a school
+	institutionRegex = regexp.MustCompile(`(?i)\b(?:university|college|institute|school|academy)\b`)
+
+	// entrySeparatorRegex splits an entry heading into its parts
+	entrySeparatorRegex = regexp.MustCompile(`\s+[|–—-]\s+|,\s+`)
+
+	// lineSeparatorRegex splits an education entry into lines and dash- or bar-separated parts
+	lineSeparatorRegex = regexp.MustCompile(`\s+[|–—-]\s+|\n`)
+
+	// gpaRegex matches a GPA in an education entry, such as "GPA: 3.8/4.0"
+	gpaRegex = regexp.MustCompile(`(?i)\bGPA\b:?\s*(\d+(?:\.\d+)?(?:\s*/\s*\d+(?:\.\d+)?)?)`)
+
+	// courseworkRegex matches a list of courses, such as "Relevant coursework: Algorithms, Databases"
+	courseworkRegex = regexp.MustCompile(`(?i)\b(?:relevant\s+)?(?:coursework|courses)\s*:\s*(.+)$`)
+
+	// skillCategoryRegex matches a "Category: item, item" skills line
+	skillCategoryRegex = regexp.MustCompile(`^([^:]+):\s*(.+)$`)
+)
+
+// monthNumbers maps month name prefixes to their two-digit numbers.
+var monthNumbers = map[string]string{
+	"jan": "01", "feb": "02", "mar": "03", "apr": "04", "may": "05", "jun": "06",
+	"jul": "07", "aug": "08", "sep": "09", "oct": "10", "nov": "11", "dec": "12",
+}
+
+// JSONResume converts the resume to the JSON Resume format. Contact details
+// are taken from the header, positions from the entries of the experience
+// section ("###" subsections, or lines of text with bullets below them),
+// degrees from the education section, and skills from the structured
+// skills when present or the skills section otherwise. Dates are converted to
+// ISO 8601 where they can be read; anything else is kept as written so
+// Validate can report it.
+//
+// Returns:
+//   - JSONResume: The converted resume
+//
+// Example:
+//
+//	jsonResume := document.Parse(markdownContent).JSONResume()
+//	if violations := jsonResume.Validate(); len(violations) > 0 {
+//	    // ask the user to fix them
+//	}
+func (r Resume) JSONResume() JSONResume {
+	j := JSONResume{Basics: JSONBasics{Name: stripInline(r.Name)}}
+
+	j.Basics.Email = headerEmailRegex.FindString(r.Header)
+	if phone := headerPhoneRegex.FindString(r.Header); phone != "" {
+		j.Basics.Phone = strings.TrimSpace(phone)
+	}
+	if link := headerURLRegex.FindString(r.Header); link != "" {
+		j.Basics.URL = strings.TrimRight(link, ".,;")
+	}
+
+	for _, section := range r.Sections {
+		heading := strings.ToLower(section.Heading)
+		switch {
+		case containsAny(heading, "summary", "profile", "objective", "about"):
+			j.Basics.Summary = plainText(section.Body)
+		case containsAny(heading, "experience", "employment", "work"):
+			work, unplaced := parseWork(section.Body)
+			j.Work = append(j.Work, work...)
+			j.unplacedHighlights = append(j.unplacedHighlights, unplaced...)
+		case strings.Contains(heading, "education"):
+			j.Education = append(j.Education, parseEducation(section.Body)...)
+		case strings.Contains(heading, "skills") && len(r.Skills) == 0:
+			j.Skills = parseSkills(section.Body)
+		}
+	}
+
+	for _, skill := range r.Skills {
+		j.Skills = append(j.Skills, JSONSkill{Name: strings.TrimSpace(skill.Name), Level: skill.Proficiency.String()})
+	}
+
+	// The GPA and coursework given as flags belong to the latest degree
+	if len(j.Education) > 0 {
+		if j.Education[0].Score == "" {
+			j.Education[0].Score = strings.TrimSpace(r.Academics.GPA)
+		}
+		if len(j.Education[0].Courses) == 0 {
+			j.Education[0].Courses = r.Academics.Coursework
+		}
+	}
+
+	return j
+}
+
+// Markdown renders the JSON Resume as a Markdown resume, the inverse of
+// Resume.JSONResume: the name as the title, the contact details below it,
+// and Summary, Experience, Education, and Skills sections for the parts
+// that are filled in.
+//
+// Returns:
+//   - string: The Markdown resume
+//
+// Example:
+//
+//	var j document.JSONResume
+//	if err := json.Unmarshal(data, &j); err == nil {
+//	    markdown := j.Markdown()
+//	}
+func (j JSONResume) Markdown() string {
+	var b strings.Builder
+	name := strings.TrimSpace(j.Basics.Name)
+	if name == "" {
+		name = "Resume"
+	}
+	b.WriteString("# " + name + "\n")
+
+	var contact []string
+	for _, detail := range []string{j.Basics.Email, j.Basics.Phone, j.Basics.URL} {
+		if detail = strings.TrimSpace(detail); detail != "" {
+			contact = append(contact, detail)
+		}
+	}
+	if len(contact) > 0 {
+		b.WriteString("\n" + strings.Join(contact, " | ") + "\n")
+	}
+	if summary := strings.TrimSpace(j.Basics.Summary); summary != "" {
+		b.WriteString("\n## Summary\n\n" + summary + "\n")
+	}
+
+	if len(j.Work) > 0 {
+		b.WriteString("\n## Experience\n")
+		for _, work := range j.Work {
+			b.WriteString("\n### " + joinNonEmpty(" | ", work.Position, work.Name, dateRange(work.StartDate, work.EndDate)) + "\n")
+			if len(work.Highlights) > 0 {
+				b.WriteString("\n- " + strings.Join(work.Highlights, "\n- ") + "\n")
+			}
+		}
+	}
+
+	if len(j.Education) > 0 {
+		b.WriteString("\n## Education\n")
+		for _, education := range j.Education {
+			degree := joinNonEmpty(" in ", education.StudyType, education.Area)
+			b.WriteString("\n### " + joinNonEmpty(" | ", joinNonEmpty(", ", degree, education.Institution), dateRange(education.StartDate, education.EndDate)) + "\n")
+			var details []string
+			if education.Score != "" {
+				details = append(details, "GPA: "+education.Score)
+			}
+			if len(education.Courses) > 0 {
+				details = append(details, "Coursework: "+strings.Join(education.Courses, ", "))
+			}
+			if len(details) > 0 {
+				b.WriteString("\n- " + strings.Join(details, "\n- ") + "\n")
+			}
+		}
+	}
+
+	if len(j.Skills) > 0 {
+		b.WriteString("\n## Skills\n\n")
+		for _, skill := range j.Skills {
+			line := "- **" + skill.Name + "**"
+			if skill.Level != "" {
+				line += " (" + skill.Level + ")"
+			}
+			if len(skill.Keywords) > 0 {
+				line += ": " + strings.Join(skill.Keywords, ", ")
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// dateRange formats the dates of an entry as "start – end", with "Present"
+// for an entry without an end, or as the end date alone when there is no
+// start.
+func dateRange(start, end string) string {
+	start, end = strings.TrimSpace(start), strings.TrimSpace(end)
+	switch {
+	case start == "":
+		return end
+	case end == "":
+		end = "Present"
+	}
+	return start + " – " + end
+}
+
+// entry is one position or degree in a section: a "###" subsection, a line
+// of text with the lines and list items below it, or a list item of its own.
+type entry struct {
+	Title string   // Heading text
+	Lines []string // Non-list lines below the heading
+	Items []string // List items below the heading
+	Item  bool     // Whether the entry is a list item of its own
+}
+
+// splitEntries splits a section body into its entries. A section with "###"
+// subsections is split at them. Without them, an entry starts at each line
+// that is not a list item and does not continue the entry before it (see
+// startsEntry), and the list items below it belong to it. List items with no
+// line above them are returned as entries of their own.
+func splitEntries(body string) []entry {
+	lines := strings.Split(body, "\n")
+	headed := false
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "### ") {
+			headed = true
+			break
+		}
+	}
+
+	var entries []entry
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		item, isItem := listItem(trimmed)
+		switch {
+		case strings.HasPrefix(trimmed, "### "):
+			entries = append(entries, entry{Title: stripInline(strings.TrimLeft(trimmed, "# "))})
+		case trimmed == "":
+		case isItem && (len(entries) == 0 || entries[len(entries)-1].Item):
+			entries = append(entries, entry{Title: stripInline(item), Item: true})
+		case isItem:
+			entries[len(entries)-1].Items = append(entries[len(entries)-1].Items, item)
+		case headed && len(entries) == 0:
+			// Text above the first subsection introduces the section
+		case !headed && (len(entries) == 0 || startsEntry(entries[len(entries)-1], trimmed)):
+			entries = append(entries, entry{Title: stripInline(trimmed)})
+		default:
+			entries[len(entries)-1].Lines = append(entries[len(entries)-1].Lines, stripInline(trimmed))
+		}
+	}
+	return entries
+}
+
+// startsEntry reports whether a line of a section without "###" subsections
+// starts a new entry rather than continuing current: it does when current
+// already has list items or is a list item, or when both the line and current
+// have a date or both name a degree, as in two degrees on consecutive lines.
+func startsEntry(current entry, line string) bool {
+	text := strings.Join(append([]string{current.Title}, current.Lines...), "\n")
+	hasDate := func(s string) bool { return dateRangeRegex.MatchString(s) || singleDateRegex.MatchString(s) }
+	hasDegree := func(s string) bool { return degreeRegex.MatchString(s) || findDegreeAbbreviation(s) != nil }
+	return current.Item || len(current.Items) > 0 ||
+		(hasDate(text) && hasDate(line)) || (hasDegree(text) && hasDegree(line))
+}
+
+// parseWork converts the entries of an experience section to positions. A
+// list item with a date is a position of its own, such as "- Engineer at
+// Acme, 2019 - 2021"; other list items with no position above them are
+// returned separately, since they are highlights without a position.
+func parseWork(body string) ([]JSONWork, []string) {
+	var work []JSONWork
+	var unplaced []string
+	for _, e := range splitEntries(body) {
+		if e.Item && !dateRangeRegex.MatchString(e.Title) && !singleDateRegex.MatchString(e.Title) {
+			unplaced = append(unplaced, e.Title)
+			continue
+		}
+
+		text := strings.Join(append([]string{e.Title}, e.Lines...), "\n")
+		start, end, found := findDateRange(text)
+		if !found {
+			if date := singleDateRegex.FindString(text); date != "" {
+				start = isoDate(date)
+			}
+		}
+
+		position, name := splitTitle(withoutDates(e.Title))
+		if name == "" {
+			// The company is often on the line below the position
+			for _, line := range e.Lines {
+				if line = withoutDates(line); line != "" {
+					name, _ = splitTitle(line)
+					break
+				}
+			}
+		}
+
+		work = append(work, JSONWork{
+			Name:       name,
+			Position:   position,
+			StartDate:  start,
+			EndDate:    end,
+			Highlights: e.Items,
+		})
+	}
+	return work, unplaced
+}
+
+// splitTitle splits "Position at Company" or "Position | Company" into its parts.
+func splitTitle(title string) (string, string) {
+	title = strings.Trim(strings.TrimSpace(title), "|–—-, ")
+	if i := strings.Index(title, " at "); i >= 0 {
+		return strings.TrimSpace(title[:i]), strings.TrimSpace(title[i+4:])
+	}
+	parts := entrySeparatorRegex.Split(title, 3)
+	if len(parts) == 1 {
+		return title, ""
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// parseEducation converts the entries of an education section to degrees.
+func parseEducation(body string) []JSONEducation {
+	var education []JSONEducation
+	for _, e := range splitEntries(body) {
+		text := strings.Join(append([]string{e.Title}, e.Lines...), "\n")
+		var edu JSONEducation
+
+		if match := degreeRegex.FindStringSubmatch(text); match != nil {
+			edu.StudyType = match[1]
+			edu.Area = withoutDates(match[2])
+		} else if match := findDegreeAbbreviation(e.Title); match != nil {
+			edu.StudyType = match[1]
+			edu.Area = withoutDates(match[2])
+		}
+		edu.Institution = findInstitution(text)
+
+		// A single date in an education entry is the graduation date
+		if start, end, found := findDateRange(text); found {
+			edu.StartDate, edu.EndDate = start, end
+		} else if date := singleDateRegex.FindString(text); date != "" {
+			edu.EndDate = isoDate(date)
+		}
+
+		// The GPA and coursework may be on any line or bullet of the entry
+		for _, line := range append(append([]string{e.Title}, e.Lines...), e.Items...) {
+			if match := gpaRegex.FindStringSubmatch(line); match != nil && edu.Score == "" {
+				edu.Score = strings.ReplaceAll(match[1], " ", "")
+			}
+			if match := courseworkRegex.FindStringSubmatch(stripInline(line)); match != nil && len(edu.Courses) == 0 {
+				for _, course := range strings.Split(strings.TrimRight(match[1], "."), ",") {
+					if course = strings.TrimSpace(course); course != "" {
+						edu.Courses = append(edu.Courses, course)
+					}
+				}
+			}
+		}
+
+		education = append(education, edu)
+	}
+	return education
+}
+
+// findInstitution returns the part of an education entry that names a school.
+// Lines and dash-separated parts are tried whole first, so names such as
+// "University of California, Berkeley" keep their comma. Without a word such
+// as "University", the school is the first part of the first line that is not
+// the degree, a date, or the GPA, so "BS Computer Science, MIT, 2019" gives
+// "MIT".
+func findInstitution(text string) string {
+	for _, part := range lineSeparatorRegex.Split(text, -1) {
+		candidates := []string{part}
+		if degreeRegex.MatchString(part) || findDegreeAbbreviation(part) != nil {
+			candidates = strings.Split(part, ",")
+		}
+		for _, candidate := range candidates {
+			if institutionRegex.MatchString(candidate) && !degreeRegex.MatchString(candidate) && findDegreeAbbreviation(candidate) == nil {
+				return withoutDates(candidate)
+			}
+		}
+	}
+
+	first, _, _ := strings.Cut(text, "\n")
+	for _, part := range entrySeparatorRegex.Split(first, -1) {
+		part = withoutDates(part)
+		if part != "" && !degreeRegex.MatchString(part) && findDegreeAbbreviation(part) == nil && !gpaRegex.MatchString(part) {
+			return part
+		}
+	}
+	return ""
+}
+
+// findDegreeAbbreviation returns the match of degreeAbbreviationRegex for the
+// first part of text that starts with an abbreviated degree, or nil when
+// none does.
+func findDegreeAbbreviation(text string) []string {
+	for _, part := range entrySeparatorRegex.Split(text, -1) {
+		if match := degreeAbbreviationRegex.FindStringSubmatch(strings.TrimSpace(part)); match != nil {
+			return match
+		}
+	}
+	return nil
+}
+
+// withoutDates removes the date ranges and single dates from text, along
+// with the brackets and separators they leave behind, so "Acme (2020-2024)"
+// becomes "Acme".
+func withoutDates(text string) string {
+	text = singleDateRegex.ReplaceAllString(dateRangeRegex.ReplaceAllString(text, ""), "")
+	text = emptyBracketsRegex.ReplaceAllString(text, "")
+	return strings.Trim(strings.TrimSpace(text), "|–—-,· ")
+}
+
+// parseSkills converts a skills section to skills. A "Category: a, b" line
+// becomes a skill group with keywords; other lines list individual skills.
+func parseSkills(body string) []JSONSkill {
+	var skills []JSONSkill
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if item, ok := listItem(line); ok {
+			line = item
+		}
+		line = stripInline(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := skillCategoryRegex.FindStringSubmatch(line); match != nil {
+			skills = append(skills, JSONSkill{Name: strings.TrimSpace(match[1]), Keywords: splitList(match[2])})
+			continue
+		}
+		for _, name := range splitList(line) {
+			skills = append(skills, JSONSkill{Name: name})
+		}
+	}
+	return skills
+}
+
+// findDateRange returns the ISO start and end dates of the first date range
+// in text. An open-ended range has an empty end date.
+func findDateRange(text string) (string, string, bool) {
+	match := dateRangeRegex.FindStringSubmatch(text)
+	if match == nil {
+		return "", "", false
+	}
+	return isoDate(match[1]), isoDate(match[2]), true
+}
+
+// isoDate converts a date as written in a resume to ISO 8601. "Present" and
+// similar become an empty string, and unreadable dates are returned unchanged.
+func isoDate(value string) string {
+	value = strings.TrimSpace(value)
+	lower := strings.ToLower(value)
+	switch {
+	case lower == "present" || lower == "current" || lower == "now":
+		return ""
+	case isoDateRegex.MatchString(value):
+		return value
+	}
+
+	if month, year, ok := strings.Cut(value, "/"); ok && len(year) == 4 {
+		if len(month) == 1 {
+			month = "0" + month
+		}
+		return year + "-" + month
+	}
+
+	fields := strings.Fields(lower)
+	if len(fields) == 2 && len(fields[0]) >= 3 {
+		if month, ok := monthNumbers[fields[0][:3]]; ok {
+			return fields[1] + "-" + month
+		}
+	}
+	return value
+}
+
+// listItem returns the text of a bulleted or numbered list item.
+func listItem(line string) (string, bool) {
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, marker) {
+			return strings.TrimSpace(line[len(marker):]), true
+		}
+	}
+	if i := strings.IndexAny(line, ".)"); i > 0 && i < 4 && strings.Trim(line[:i], "0123456789") == "" && len(line) > i+1 && line[i+1] == ' ' {
+		return strings.TrimSpace(line[i+2:]), true
+	}
+	return "", false
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(text string) []string {
+	var items []string
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// stripInline removes bold, italic, and code markers and converts links to their text.
+func stripInline(text string) string {
+	text = markdownLinkRegex.ReplaceAllString(text, "$1")
+	return strings.TrimSpace(strings.NewReplacer("**", "", "__", "", "*", "", "`", "").Replace(text))
+}
+
+// markdownLinkRegex matches a Markdown link, capturing its text
+var markdownLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+
+// plainText joins the lines of a Markdown body into plain text.
+func plainText(body string) string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if item, ok := listItem(line); ok {
+			line = item
+		}
+		if line = stripInline(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// containsAny reports whether s contains any of the substrings.
+func containsAny(s string, substrings ...string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,337 @@
+package document
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const jsonTestResume = `# Jane Doe
+
+jane@example.com | (555) 123-4567 | [LinkedIn](https://linkedin.com/in/janedoe)
+
+## Summary
+
+Backend engineer with **ten years** of experience.
+
+## Experience
+
+### Senior Engineer | Acme Corp | Jan 2020 – Present
+
+- Led the platform team
+- Cut latency by 40%
+
+### Engineer at Initech
+
+*03/2016 - Dec 2019*
+
+- Built billing
+
+## Education
+
+- Bachelor of Science in Computer Science, Georgia Institute of Technology, 2015
+
+## Skills
+
+- **Languages:** Go, Python
+- Docker, Kubernetes`
+
+func TestJSONResume(t *testing.T) {
+	j := Parse(jsonTestResume).JSONResume()
+
+	wantBasics := JSONBasics{
+		Name:    "Jane Doe",
+		Email:   "jane@example.com",
+		Phone:   "(555) 123-4567",
+		URL:     "https://linkedin.com/in/janedoe",
+		Summary: "Backend engineer with ten years of experience.",
+	}
+	if j.Basics != wantBasics {
+		t.Errorf("Basics = %+v, want %+v", j.Basics, wantBasics)
+	}
+
+	wantWork := []JSONWork{
+		{Name: "Acme Corp", Position: "Senior Engineer", StartDate: "2020-01", Highlights: []string{"Led the platform team", "Cut latency by 40%"}},
+		{Name: "Initech", Position: "Engineer", StartDate: "2016-03", EndDate: "2019-12", Highlights: []string{"Built billing"}},
+	}
+	if !reflect.DeepEqual(j.Work, wantWork) {
+		t.Errorf("Work = %+v, want %+v", j.Work, wantWork)
+	}
+
+	wantEducation := []JSONEducation{
+		{Institution: "Georgia Institute of Technology", Area: "Computer Science", StudyType: "Bachelor of Science", EndDate: "2015"},
+	}
+	if !reflect.DeepEqual(j.Education, wantEducation) {
+		t.Errorf("Education = %+v, want %+v", j.Education, wantEducation)
+	}
+
+	wantSkills := []JSONSkill{
+		{Name: "Languages", Keywords: []string{"Go", "Python"}},
+		{Name: "Docker"},
+		{Name: "Kubernetes"},
+	}
+	if !reflect.DeepEqual(j.Skills, wantSkills) {
+		t.Errorf("Skills = %+v, want %+v", j.Skills, wantSkills)
+	}
+
+	if violations := j.Validate(); len(violations) != 0 {
+		t.Errorf("Expected a valid export, got %v", violations)
+	}
+}
+
+func TestJSONResumeMarkdown(t *testing.T) {
+	j := Parse(jsonTestResume).JSONResume()
+
+	// Test case 1: Each part is written as the section a resume would have
+	markdown := j.Markdown()
+	for _, want := range []string{
+		"# Jane Doe\n\njane@example.com | (555) 123-4567 | https://linkedin.com/in/janedoe\n",
+		"## Summary\n\nBackend engineer with ten years of experience.\n",
+		"### Senior Engineer | Acme Corp | 2020-01 – Present\n\n- Led the platform team\n- Cut latency by 40%\n",
+		"### Bachelor of Science in Computer Science, Georgia Institute of Technology | 2015\n",
+		"## Skills\n\n- **Languages**: Go, Python\n- **Docker**\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected the Markdown to contain %q, got %q", want, markdown)
+		}
+	}
+
+	// Test case 2: The Markdown converts back to the same work history and education
+	parsed := Parse(markdown).JSONResume()
+	if !reflect.DeepEqual(parsed.Work, j.Work) {
+		t.Errorf("Work = %+v, want %+v", parsed.Work, j.Work)
+	}
+	if !reflect.DeepEqual(parsed.Education, j.Education) {
+		t.Errorf("Education = %+v, want %+v", parsed.Education, j.Education)
+	}
+
+	// Test case 3: An empty resume still has a title
+	if got := (JSONResume{}).Markdown(); got != "# Resume\n" {
+		t.Errorf("Markdown() = %q, want %q", got, "# Resume\n")
+	}
+}
+
+func TestJSONResumeStructuredSkills(t *testing.T) {
+	r := Parse(jsonTestResume)
+	r.Skills = []Skill{{Name: "Go", Years: 6, Proficiency: ProficiencyExpert}}
+
+	want := []JSONSkill{{Name: "Go", Level: "Expert"}}
+	if got := r.JSONResume().Skills; !reflect.DeepEqual(got, want) {
+		t.Errorf("Skills = %+v, want %+v", got, want)
+	}
+}
+
+func TestJSONResumeAcademics(t *testing.T) {
+	// Test case 1: The GPA and coursework are read from the education entry
+	r := Parse("# Jane Doe\n\n## Education\n\n### BS in Computer Science, State University, May 2025\n\n- GPA: 3.9 / 4.0\n- **Relevant coursework:** Algorithms, Operating Systems")
+	edu := r.JSONResume().Education
+	if len(edu) != 1 {
+		t.Fatalf("Expected one education entry, got %+v", edu)
+	}
+	if edu[0].Score != "3.9/4.0" {
+		t.Errorf("Score = %q, want %q", edu[0].Score, "3.9/4.0")
+	}
+	if want := []string{"Algorithms", "Operating Systems"}; !reflect.DeepEqual(edu[0].Courses, want) {
+		t.Errorf("Courses = %v, want %v", edu[0].Courses, want)
+	}
+
+	// Test case 2: Academics given as flags fill in the latest degree
+	r = Parse(jsonTestResume)
+	r.Academics = Academics{GPA: "3.5", Coursework: []string{"Compilers"}}
+	edu = r.JSONResume().Education
+	if edu[0].Score != "3.5" || !reflect.DeepEqual(edu[0].Courses, []string{"Compilers"}) {
+		t.Errorf("Expected the flag academics on the first degree, got %+v", edu[0])
+	}
+}
+
+func TestJSONResumeBracketedDates(t *testing.T) {
+	// Test case 1: Dates in parentheses leave no empty parentheses behind
+	r := Parse("# Jane Doe\n\n## Experience\n\n### Engineer, Acme (2020-2024)\n\n- Built billing")
+	want := []JSONWork{{Name: "Acme", Position: "Engineer", StartDate: "2020", EndDate: "2024", Highlights: []string{"Built billing"}}}
+	if got := r.JSONResume().Work; !reflect.DeepEqual(got, want) {
+		t.Errorf("Work = %+v, want %+v", got, want)
+	}
+
+	// Test case 2: Nor do dates in brackets on the line below the position
+	r = Parse("# Jane Doe\n\n## Experience\n\n### Engineer\n\nInitech [Jan 2018 – Present]")
+	if got := r.JSONResume().Work; len(got) != 1 || got[0].Name != "Initech" || got[0].StartDate != "2018-01" {
+		t.Errorf("Expected Initech from 2018-01, got %+v", got)
+	}
+
+	// Test case 3: Nor does a graduation year after the institution
+	r = Parse("# Jane Doe\n\n## Education\n\n### Bachelor of Arts in History, Boston College (2012)")
+	if got := r.JSONResume().Education; len(got) != 1 || got[0].Institution != "Boston College" || got[0].EndDate != "2012" {
+		t.Errorf("Expected Boston College in 2012, got %+v", got)
+	}
+}
+
+func TestJSONResumeWithoutSubsections(t *testing.T) {
+	// Test case 1: Each dated line of an experience section is a position,
+	// with the bullets below it
+	r := Parse("# Jane Doe\n\n## Experience\n\n**Senior Engineer**, Acme Corp, 2020 - Present\n- Led the platform team\n\nEngineer at Initech (2016 - 2019)\nPayments team\n- Built billing")
+	wantWork := []JSONWork{
+		{Name: "Acme Corp", Position: "Senior Engineer", StartDate: "2020", Highlights: []string{"Led the platform team"}},
+		{Name: "Initech", Position: "Engineer", StartDate: "2016", EndDate: "2019", Highlights: []string{"Built billing"}},
+	}
+	j := r.JSONResume()
+	if !reflect.DeepEqual(j.Work, wantWork) {
+		t.Errorf("Work = %+v, want %+v", j.Work, wantWork)
+	}
+	if violations := j.Validate(); len(violations) != 0 {
+		t.Errorf("Expected a valid export, got %v", violations)
+	}
+
+	// Test case 2: Education written as paragraph lines gives a degree per line
+	r = Parse("# Jane Doe\n\n## Education\n\nMBA, Wharton School, 2023\nBachelor of Science in Economics, Rutgers University, 2017\nGPA: 3.8")
+	wantEducation := []JSONEducation{
+		{Institution: "Wharton School", StudyType: "MBA", EndDate: "2023"},
+		{Institution: "Rutgers University", Area: "Economics", StudyType: "Bachelor of Science", EndDate: "2017", Score: "3.8"},
+	}
+	if got := r.JSONResume().Education; !reflect.DeepEqual(got, wantEducation) {
+		t.Errorf("Education = %+v, want %+v", got, wantEducation)
+	}
+
+	// Test case 3: Bullets with no position above them must be given one
+	r = Parse("# Jane Doe\n\n## Experience\n\n- Led the platform team\n- Cut latency by 40%")
+	j = r.JSONResume()
+	violations := j.Validate()
+	if len(j.Work) != 0 || len(violations) != 1 || violations[0].Field != "work" || !strings.Contains(violations[0].Message, "Led the platform team") {
+		t.Fatalf("Expected a violation for the bullets without a position, got %+v and %v", j.Work, violations)
+	}
+	violations[0].Fix(&j, "Engineer at Acme")
+	want := []JSONWork{{Name: "Acme", Position: "Engineer", Highlights: []string{"Led the platform team", "Cut latency by 40%"}}}
+	if !reflect.DeepEqual(j.Work, want) || len(j.Validate()) != 0 {
+		t.Errorf("Work = %+v, want %+v", j.Work, want)
+	}
+
+	// Test case 4: Or left out
+	j = r.JSONResume()
+	j.Validate()[0].Fix(&j, "")
+	if len(j.Work) != 0 || len(j.Validate()) != 0 {
+		t.Errorf("Expected the bullets to be left out, got %+v", j.Work)
+	}
+
+	// Test case 5: A dated bullet is a position of its own
+	r = Parse("# Jane Doe\n\n## Experience\n\n- Engineer at Acme, 2019 - 2021")
+	if got := r.JSONResume().Work; len(got) != 1 || got[0].Name != "Acme" || got[0].EndDate != "2021" {
+		t.Errorf("Expected the bullet as a position, got %+v", got)
+	}
+}
+
+func TestJSONResumeValidate(t *testing.T) {
+	j := JSONResume{
+		Basics: JSONBasics{Email: "jane at example", URL: "linkedin.com/in/jane"},
+		Work:   []JSONWork{{Position: "Engineer", StartDate: "Spring 2019"}},
+		Education: []JSONEducation{
+			{Institution: "MIT", EndDate: "2015-06-01"},
+		},
+	}
+
+	violations := j.Validate()
+	var fields []string
+	for _, v := range violations {
+		fields = append(fields, v.Field)
+	}
+	want := []string{"basics.email", "basics.url", "work[0].startDate"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("Violation fields = %v, want %v", fields, want)
+	}
+
+	if s := violations[2].String(); !strings.Contains(s, "YYYY-MM") || !strings.Contains(s, `"Spring 2019"`) {
+		t.Errorf("Violation should explain the date format and show the value, got %q", s)
+	}
+
+	// Fixing every violation makes the resume valid
+	fixes := []string{"jane@example.com", "https://linkedin.com/in/jane", "2019-04"}
+	for i, v := range violations {
+		v.Fix(&j, fixes[i])
+	}
+	if remaining := j.Validate(); len(remaining) != 0 {
+		t.Errorf("Expected no violations after fixing, got %v", remaining)
+	}
+	if j.Work[0].StartDate != "2019-04" {
+		t.Errorf("Fix should update the violating field, got %q", j.Work[0].StartDate)
+	}
+}
+
+func TestJSONResumeWarnings(t *testing.T) {
+	j := JSONResume{
+		Work:      []JSONWork{{Position: "Engineer", StartDate: "2019"}},
+		Education: []JSONEducation{{Area: "History"}},
+		Skills:    []JSONSkill{{Name: "Go"}},
+	}
+
+	// Test case 1: Empty fields the schema allows are warnings, not violations
+	if violations := j.Validate(); len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+	warnings := j.Warnings()
+	var fields []string
+	for _, w := range warnings {
+		fields = append(fields, w.Field)
+	}
+	if want := []string{"basics.name", "work[0].name", "education[0].institution"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("Warning fields = %v, want %v", fields, want)
+	}
+
+	// Test case 2: A warning can be fixed like a violation
+	warnings[2].Fix(&j, "MIT")
+	if j.Education[0].Institution != "MIT" || len(j.Warnings()) != 2 {
+		t.Errorf("Expected the institution to be filled in, got %+v", j.Education[0])
+	}
+}
+
+func TestJSONResumeInstitution(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+		want  JSONEducation
+	}{
+		{
+			name:  "Abbreviated degree before the school",
+			entry: "- BS Computer Science, MIT, 2019",
+			want:  JSONEducation{Institution: "MIT", Area: "Computer Science", StudyType: "BS", EndDate: "2019"},
+		},
+		{
+			name:  "School before the abbreviated degree",
+			entry: "### MIT | M.S. in Physics | 2014 - 2016",
+			want:  JSONEducation{Institution: "MIT", Area: "Physics", StudyType: "M.S.", StartDate: "2014", EndDate: "2016"},
+		},
+		{
+			name:  "School named with a keyword",
+			entry: "### Ph.D. in Chemistry, Rice University, 2020",
+			want:  JSONEducation{Institution: "Rice University", Area: "Chemistry", StudyType: "Ph.D.", EndDate: "2020"},
+		},
+		{
+			name:  "Degree without a school",
+			entry: "### Bachelor of Arts in History | 2012",
+			want:  JSONEducation{StudyType: "Bachelor of Arts", Area: "History", EndDate: "2012"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse("# Jane Doe\n\n## Education\n\n" + tt.entry).JSONResume().Education
+			if len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("Education = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsoDate(t *testing.T) {
+	tests := map[string]string{
+		"2020":           "2020",
+		"2020-03":        "2020-03",
+		"Jan 2020":       "2020-01",
+		"September 2018": "2018-09",
+		"3/2021":         "2021-03",
+		"Present":        "",
+		"Spring 2019":    "Spring 2019",
+	}
+	for input, want := range tests {
+		if got := isoDate(input); got != want {
+			t.Errorf("isoDate(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package document
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NoteSection is a section detected in the free-form notes a user types
+// about their background, such as the lines after "Work Experience:".
+type NoteSection struct {
+	Heading string // The heading as written, without "#", "**", or a trailing colon
+	Topic   string // The resume topic it covers, such as "Experience", or empty if it is not known
+	Lines   int    // Non-blank lines of content in the section
+}
+
+// noteTopics are the resume topics recognized in notes, with the words of
+// the headings that cover them.
+var noteTopics = []struct {
+	name     string
+	keywords []string
+}{
+	{"Summary", []string{"summary", "profile", "objective", "about me"}},
+	{"Experience", []string{"experience", "employment", "work", "jobs", "career", "positions"}},
+	{"Education", []string{"education", "degree", "school", "university", "college", "studies"}},
+	{"Skills", []string{"skill", "technologies", "tools", "tech stack"}},
+	{"Projects", []string{"project", "portfolio"}},
+	{"Certifications", []string{"certification", "certificate", "license"}},
+	{"Achievements", []string{"achievement", "award", "honor", "accomplishment"}},
+	{"Volunteering", []string{"volunteer"}},
+	{"Publications", []string{"publication", "talks", "papers"}},
+	{"Languages", []string{"languages spoken", "spoken languages"}},
+}
+
+// noteTopic returns the topic a heading covers, or an empty string.
+func noteTopic(heading string) string {
+	heading = strings.ToLower(heading)
+	for _, topic := range noteTopics {
+		if containsAny(heading, topic.keywords...) {
+			return topic.name
+		}
+	}
+	return ""
+}
+
+// OutlineNotes detects the sections of notes as they are typed. A line is
+// a heading when it is a Markdown heading, a short line ending with a
+// colon ("Work Experience:"), a short line naming a known topic
+// ("EDUCATION"), or a known topic followed by a colon and its content on
+// the same line ("Skills: Go, SQL"). Bullets are never headings, and lines
+// before the first heading belong to no section.
+//
+// Parameters:
+//   - notes: The notes as typed
+//
+// Returns:
+//   - []NoteSection: The detected sections in order, or nil if there are none
+//
+// Example:
+//
+//	outline := document.OutlineNotes("Experience:\n- Engineer at Acme\n\nSkills: Go, SQL")
+//	// outline[0].Topic == "Experience", outline[0].Lines == 1, outline[1].Heading == "Skills"
+func OutlineNotes(notes string) []NoteSection {
+	var outline []NoteSection
+	for _, line := range strings.Split(strings.ReplaceAll(notes, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if heading, rest, ok := noteHeading(line); ok {
+			section := NoteSection{Heading: heading, Topic: noteTopic(heading)}
+			if rest != "" {
+				section.Lines = 1
+			}
+			outline = append(outline, section)
+			continue
+		}
+		if len(outline) > 0 {
+			outline[len(outline)-1].Lines++
+		}
+	}
+	return outline
+}
+
+// noteHeading reports whether a line of notes is a heading, returning the
+// heading and any content after it on the same line.
+func noteHeading(line string) (heading, rest string, ok bool) {
+	if isNoteBullet(line) {
+		return "", "", false
+	}
+	if strings.HasPrefix(line, "#") {
+		heading = strings.TrimSpace(strings.TrimLeft(line, "#"))
+		return heading, "", heading != ""
+	}
+
+	plain := strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(line, "**", ""), "__", ""))
+	if heading, found := strings.CutSuffix(plain, ":"); found {
+		heading = strings.TrimSpace(heading)
+		return heading, "", heading != "" && utf8.RuneCountInString(heading) <= 40 && len(strings.Fields(heading)) <= 5
+	}
+	if before, after, found := strings.Cut(plain, ":"); found {
+		before = strings.TrimSpace(before)
+		if utf8.RuneCountInString(before) <= 30 && noteTopic(before) != "" {
+			return before, strings.TrimSpace(after), true
+		}
+		return "", "", false
+	}
+	if len(strings.Fields(plain)) <= 3 && noteTopic(plain) != "" {
+		return plain, "", true
+	}
+	return "", "", false
+}
+
+// isNoteBullet reports whether a line of notes is a list item, such as
+// "- Led the team", "• Go", or "1. Shipped".
+func isNoteBullet(line string) bool {
+	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "• ") || strings.HasPrefix(line, "+ ") {
+		return true
+	}
+	digits := strings.TrimLeftFunc(line, unicode.IsDigit)
+	return len(digits) < len(line) && (strings.HasPrefix(digits, ". ") || strings.HasPrefix(digits, ") "))
+}
+
+// MissingNoteTopics returns the required topics no section of the outline
+// covers. A topic is covered by a section of that topic, or whose heading
+// contains it (case-insensitive, ignoring a plural "s").
+//
+// Parameters:
+//   - outline: The sections detected by OutlineNotes
+//   - required: The topics the notes should cover, such as "Experience"
+//
+// Returns:
+//   - []string: The topics not covered, in the order given
+func MissingNoteTopics(outline []NoteSection, required []string) []string {
+	var missing []string
+	for _, topic := range required {
+		keyword := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(topic)), "s")
+		if keyword == "" {
+			continue
+		}
+		covered := false
+		for _, section := range outline {
+			if strings.EqualFold(section.Topic, topic) || strings.Contains(strings.ToLower(section.Heading), keyword) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			missing = append(missing, topic)
+		}
+	}
+	return missing
+}
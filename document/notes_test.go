@@ -0,0 +1,46 @@
+package document
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOutlineNotes(t *testing.T) {
+	notes := "Jane Doe, backend engineer\n\n" +
+		"Work Experience:\n- Senior Engineer at XYZ Corp (2019-2023)\n- Reduced latency by 40%\n\n" +
+		"**Skills:** Go, SQL, Kubernetes\n\n" +
+		"EDUCATION\nBS Computer Science, State University\n\n" +
+		"## Side Projects\n\n" +
+		"Hobbies:\n1. Climbing\n"
+
+	// Test case 1: Headings of each kind are detected, with their content lines
+	want := []NoteSection{
+		{Heading: "Work Experience", Topic: "Experience", Lines: 2},
+		{Heading: "Skills", Topic: "Skills", Lines: 1},
+		{Heading: "EDUCATION", Topic: "Education", Lines: 1},
+		{Heading: "Side Projects", Topic: "Projects", Lines: 0},
+		{Heading: "Hobbies", Topic: "", Lines: 1},
+	}
+	if got := OutlineNotes(notes); !reflect.DeepEqual(got, want) {
+		t.Errorf("OutlineNotes() = %+v, want %+v", got, want)
+	}
+
+	// Test case 2: Sentences, bullets, and colons inside content are not headings
+	if got := OutlineNotes("I led the work on our billing system\n- Skills: Go\nNote: ask about dates"); got != nil {
+		t.Errorf("Expected no sections, got %+v", got)
+	}
+}
+
+func TestMissingNoteTopics(t *testing.T) {
+	outline := OutlineNotes("Experience:\n- Acme\n\nTechnical Skills:\n- Go")
+
+	// Test case 1: Topics are covered by a section's topic or heading
+	if got := MissingNoteTopics(outline, []string{"Experience", "Education", "Skills"}); !reflect.DeepEqual(got, []string{"Education"}) {
+		t.Errorf("Expected only Education missing, got %v", got)
+	}
+
+	// Test case 2: Empty notes cover nothing
+	if got := MissingNoteTopics(nil, []string{"Skills"}); !reflect.DeepEqual(got, []string{"Skills"}) {
+		t.Errorf("Expected Skills missing, got %v", got)
+	}
+}
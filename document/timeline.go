@@ -0,0 +1,308 @@
+package document
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinGapMonths is the shortest break between roles or studies that is
+// reported as a gap. Shorter breaks are common between jobs and are ignored.
+const MinGapMonths = 3
+
+// Month is a calendar month counted from the start of year zero, so
+// consecutive months differ by one.
+type Month int
+
+// NewMonth returns the Month for a year and month.
+func NewMonth(year int, month time.Month) Month {
+	return Month(year*12 + int(month) - 1)
+}
+
+// Year returns the calendar year of the month.
+func (m Month) Year() int {
+	return int(m) / 12
+}
+
+// String returns the month as written in a resume, such as "Apr 2019".
+func (m Month) String() string {
+	return fmt.Sprintf("%s %d", time.Month(int(m)%12 + 1).String()[:3], m.Year())
+}
+
+// parseMonth reads an ISO 8601 date produced by JSONResume. A date with
+// only a year is read as January, or as December for the end of a period,
+// so year-only dates never create a gap that may not exist.
+func parseMonth(iso string, end bool) (Month, bool) {
+	if !isoDateRegex.MatchString(iso) {
+		return 0, false
+	}
+	parts := strings.Split(iso, "-")
+	year, _ := strconv.Atoi(parts[0])
+	month := time.January
+	if end {
+		month = time.December
+	}
+	if len(parts) > 1 {
+		n, _ := strconv.Atoi(parts[1])
+		if n < 1 || n > 12 {
+			return 0, false
+		}
+		month = time.Month(n)
+	}
+	return NewMonth(year, month), true
+}
+
+// TimelineKind tells roles and studies apart on the timeline.
+type TimelineKind int
+
+const (
+	// TimelineWork is a role from the experience section.
+	TimelineWork TimelineKind = iota
+
+	// TimelineEducation is a degree or course from the education section.
+	TimelineEducation
+)
+
+// TimelineEntry is one role or degree placed on the timeline.
+type TimelineEntry struct {
+	Kind    TimelineKind // Whether the entry is a role or a degree
+	Title   string       // Position and employer, or degree and institution
+	Start   Month        // First month of the entry
+	End     Month        // Last month of the entry (the current month for ongoing roles)
+	Current bool         // Whether the role is ongoing
+}
+
+// Point reports whether the entry has a single date, such as a degree
+// listed with only its graduation year.
+func (e TimelineEntry) Point() bool {
+	return e.Start == e.End && e.Kind == TimelineEducation
+}
+
+// Gap is a period of at least MinGapMonths covered by no role or studies.
+type Gap struct {
+	Start Month // First month without a role or studies
+	End   Month // Last month without a role or studies
+}
+
+// Months returns the length of the gap in months.
+func (g Gap) Months() int {
+	return int(g.End-g.Start) + 1
+}
+
+// String describes the gap, such as "6-month gap: Dec 2018 – May 2019".
+func (g Gap) String() string {
+	return fmt.Sprintf("%d-month gap: %s – %s", g.Months(), g.Start, g.End)
+}
+
+// Timeline is the dated roles and education of a resume, oldest first,
+// with the gaps between them.
+type Timeline struct {
+	Entries []TimelineEntry // Dated entries ordered by start month
+	Gaps    []Gap           // Breaks of at least MinGapMonths, oldest first
+}
+
+// Timeline places the dated roles and degrees of the resume on a timeline
+// and finds the gaps between them. Dates come from the same parsing as
+// JSONResume; entries without a readable date are left out. Roles without
+// an end date are treated as ongoing until now. Gaps are only reported
+// between covered periods, not before the first or after the last.
+//
+// Parameters:
+//   - now: The current time, used as the end of ongoing roles
+//
+// Returns:
+//   - Timeline: The dated entries and the gaps between them
+//
+// Example:
+//
+//	timeline := document.Parse(markdownContent).Timeline(time.Now())
+//	for _, gap := range timeline.Gaps {
+//	    fmt.Println(gap)
+//	}
+func (r Resume) Timeline(now time.Time) Timeline {
+	var t Timeline
+	current := NewMonth(now.Year(), now.Month())
+	j := r.JSONResume()
+
+	for _, work := range j.Work {
+		start, ok := parseMonth(work.StartDate, false)
+		if !ok {
+			continue
+		}
+		entry := TimelineEntry{Kind: TimelineWork, Title: joinNonEmpty(" · ", work.Position, work.Name), Start: start, End: current, Current: true}
+		if work.EndDate != "" {
+			if entry.End, ok = parseMonth(work.EndDate, true); !ok {
+				continue
+			}
+			entry.Current = false
+		}
+		t.Entries = append(t.Entries, entry)
+	}
+
+	for _, edu := range j.Education {
+		end, ok := parseMonth(edu.EndDate, true)
+		if !ok {
+			continue
+		}
+		start := end
+		if edu.StartDate != "" {
+			if start, ok = parseMonth(edu.StartDate, false); !ok {
+				continue
+			}
+		}
+		title := joinNonEmpty(" · ", edu.StudyType, edu.Institution)
+		t.Entries = append(t.Entries, TimelineEntry{Kind: TimelineEducation, Title: title, Start: start, End: end})
+	}
+
+	for i := range t.Entries {
+		if t.Entries[i].End < t.Entries[i].Start {
+			t.Entries[i].Start, t.Entries[i].End = t.Entries[i].End, t.Entries[i].Start
+		}
+	}
+	sort.SliceStable(t.Entries, func(a, b int) bool { return t.Entries[a].Start < t.Entries[b].Start })
+
+	// Walk the covered periods in order; a degree with only a graduation
+	// date covers nothing, since the length of study is unknown
+	covered := Month(-1)
+	for _, entry := range t.Entries {
+		if entry.Point() {
+			continue
+		}
+		if covered >= 0 && int(entry.Start-covered)-1 >= MinGapMonths {
+			t.Gaps = append(t.Gaps, Gap{Start: covered + 1, End: entry.Start - 1})
+		}
+		if entry.End > covered {
+			covered = entry.End
+		}
+	}
+
+	return t
+}
+
+// joinNonEmpty joins the non-empty parts with sep.
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// Timeline chart symbols
+const (
+	timelineWorkCell      = "█"
+	timelineEducationCell = "▓"
+	timelinePointCell     = "◆"
+	timelineGapCell       = "░"
+	timelineEmptyCell     = "·"
+)
+
+// maxTimelineLabel is the widest entry title shown in the chart.
+const maxTimelineLabel = 30
+
+// Chart draws the timeline as Unicode text no wider than width: one row
+// per entry with its title and a bar, a row of years, and a legend. Gaps
+// are shaded in every row so they stand out as vertical bands. An empty
+// timeline returns an empty string.
+//
+// Parameters:
+//   - width: The maximum line width in characters
+//
+// Returns:
+//   - string: The chart, one line per entry followed by the axis and legend
+//
+// Example:
+//
+//	fmt.Println(timeline.Chart(80))
+//	// Engineer · Initech    ████████·····
+//	// Senior Engineer · …   ·····░░██████
+//	//                       2015  2018
+func (t Timeline) Chart(width int) string {
+	if len(t.Entries) == 0 {
+		return ""
+	}
+
+	first, last := t.Entries[0].Start, t.Entries[0].End
+	labelWidth := 0
+	for _, entry := range t.Entries {
+		first, last = min(first, entry.Start), max(last, entry.End)
+		labelWidth = max(labelWidth, len([]rune(entry.Title)))
+	}
+	labelWidth = min(labelWidth, maxTimelineLabel)
+	barWidth := max(width-labelWidth-2, 10)
+	span := int(last-first) + 1
+
+	// column maps a month to the chart column it falls in
+	column := func(m Month) int {
+		return min(int(m-first)*barWidth/span, barWidth-1)
+	}
+
+	gapColumns := make([]bool, barWidth)
+	for _, gap := range t.Gaps {
+		for c := column(gap.Start); c <= column(gap.End); c++ {
+			gapColumns[c] = true
+		}
+	}
+
+	var b strings.Builder
+	for _, entry := range t.Entries {
+		cells := make([]string, barWidth)
+		for c := range cells {
+			cells[c] = timelineEmptyCell
+			if gapColumns[c] {
+				cells[c] = timelineGapCell
+			}
+		}
+
+		cell := timelineWorkCell
+		switch {
+		case entry.Point():
+			cell = timelinePointCell
+		case entry.Kind == TimelineEducation:
+			cell = timelineEducationCell
+		}
+		for c := column(entry.Start); c <= column(entry.End); c++ {
+			cells[c] = cell
+		}
+
+		fmt.Fprintf(&b, "%s  %s\n", padLabel(entry.Title, labelWidth), strings.Join(cells, ""))
+	}
+
+	// Label the years, skipping any that would run into the previous label
+	axis := []rune(strings.Repeat(" ", barWidth))
+	next := 0
+	for year := first.Year(); year <= last.Year(); year++ {
+		c := 0
+		if NewMonth(year, time.January) > first {
+			c = column(NewMonth(year, time.January))
+		}
+		label := strconv.Itoa(year)
+		if c < next || c+len(label) > barWidth {
+			continue
+		}
+		copy(axis[c:], []rune(label))
+		next = c + len(label) + 1
+	}
+	fmt.Fprintf(&b, "%s  %s\n", strings.Repeat(" ", labelWidth), strings.TrimRight(string(axis), " "))
+
+	legend := []string{timelineWorkCell + " work", timelineEducationCell + " education", timelinePointCell + " graduation"}
+	if len(t.Gaps) > 0 {
+		legend = append(legend, timelineGapCell+" gap")
+	}
+	b.WriteString("\n" + strings.Join(legend, "   "))
+
+	return b.String()
+}
+
+// padLabel truncates or pads a title to exactly width characters.
+func padLabel(title string, width int) string {
+	runes := []rune(title)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return title + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,86 @@
+package document
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const timelineTestResume = `# Jane Doe
+
+## Experience
+
+### Senior Engineer | Globex | Jun 2019 – Present
+
+- Designed the public API
+
+### Engineer | Initech | 2015 - Dec 2018
+
+- Built billing
+
+## Education
+
+- Bachelor of Science in Computer Science, Georgia Institute of Technology, 2014`
+
+func TestTimeline(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	timeline := Parse(timelineTestResume).Timeline(now)
+
+	want := []TimelineEntry{
+		{Kind: TimelineEducation, Title: "Bachelor of Science · Georgia Institute of Technology", Start: NewMonth(2014, time.December), End: NewMonth(2014, time.December)},
+		{Kind: TimelineWork, Title: "Engineer · Initech", Start: NewMonth(2015, time.January), End: NewMonth(2018, time.December)},
+		{Kind: TimelineWork, Title: "Senior Engineer · Globex", Start: NewMonth(2019, time.June), End: NewMonth(2024, time.March), Current: true},
+	}
+	if !reflect.DeepEqual(timeline.Entries, want) {
+		t.Fatalf("Entries = %+v, want %+v", timeline.Entries, want)
+	}
+
+	// The graduation date covers no time, so only the break between roles is a gap
+	wantGaps := []Gap{{Start: NewMonth(2019, time.January), End: NewMonth(2019, time.May)}}
+	if !reflect.DeepEqual(timeline.Gaps, wantGaps) {
+		t.Fatalf("Gaps = %+v, want %+v", timeline.Gaps, wantGaps)
+	}
+	if got := timeline.Gaps[0].String(); got != "5-month gap: Jan 2019 – May 2019" {
+		t.Errorf("Gap.String() = %q", got)
+	}
+}
+
+func TestTimelineShortBreaks(t *testing.T) {
+	resume := Parse("## Experience\n\n### A | Acme | Jan 2018 - Mar 2019\n\n### B | Initech | May 2019 - Present")
+	if gaps := resume.Timeline(time.Now()).Gaps; len(gaps) != 0 {
+		t.Errorf("Breaks shorter than %d months should not be gaps, got %v", MinGapMonths, gaps)
+	}
+}
+
+func TestTimelineChart(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	chart := Parse(timelineTestResume).Timeline(now).Chart(60)
+
+	lines := strings.Split(chart, "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected 3 entry rows, an axis, a blank line, and a legend, got:\n%s", chart)
+	}
+	for _, line := range lines {
+		if n := len([]rune(line)); n > 60 {
+			t.Errorf("Line is %d characters wide, want at most 60: %q", n, line)
+		}
+	}
+
+	if !strings.HasPrefix(lines[0], "Bachelor of Science · Georgia…") || !strings.Contains(lines[0], "◆") {
+		t.Errorf("Expected a truncated label and a graduation point, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "█") || !strings.Contains(lines[1], "░") {
+		t.Errorf("Expected a work bar with the gap shaded, got %q", lines[1])
+	}
+	if !strings.Contains(lines[3], "2014") || !strings.Contains(lines[3], "2019") {
+		t.Errorf("Expected year labels on the axis, got %q", lines[3])
+	}
+	if !strings.Contains(lines[5], "░ gap") {
+		t.Errorf("Expected the legend to explain gaps, got %q", lines[5])
+	}
+
+	if chart := (Timeline{}).Chart(60); chart != "" {
+		t.Errorf("Expected an empty chart without entries, got %q", chart)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/phrazzld/resumake/vault"
+)
+
+// passphrasePrompt returns a vault.PassphraseFunc that reads the passphrase
+// from the terminal without echoing it, writing the prompts to w. When in is
+// not a terminal, the passphrase can only come from vault.PassphraseEnv.
+func passphrasePrompt(in *os.File, w io.Writer) vault.PassphraseFunc {
+	return func(confirm bool) (string, error) {
+		if !term.IsTerminal(in.Fd()) {
+			return "", fmt.Errorf("%w; set %s to the passphrase when not running in a terminal", vault.ErrLocked, vault.PassphraseEnv)
+		}
+		read := func(label string) (string, error) {
+			fmt.Fprint(w, label)
+			passphrase, err := term.ReadPassword(in.Fd())
+			fmt.Fprintln(w)
+			return string(passphrase), err
+		}
+
+		if confirm {
+			fmt.Fprintln(w, "Choose a passphrase for encrypting your saved resumes. It cannot be recovered if you forget it.")
+		}
+		passphrase, err := read("Passphrase: ")
+		if err != nil || !confirm {
+			return passphrase, err
+		}
+		again, err := read("Passphrase again: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("the passphrases do not match")
+		}
+		return passphrase, nil
+	}
+}
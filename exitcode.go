@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+// Exit codes let scripts tell failures apart without parsing messages.
+const (
+	exitOK             = 0 // The resume was generated
+	exitFailure        = 1 // Any failure not covered by a more specific code
+	exitStale          = 2 // remind -cron found a resume that needs regenerating
+	exitAuth           = 3 // The API key was missing, invalid, or lacks permission
+	exitQuota          = 4 // The API quota or rate limit was exceeded
+	exitUnavailable    = 5 // The API could not be reached or failed; trying later may succeed
+	exitInvalidRequest = 6 // The API rejected the request or the model
+)
+
+// exitCode maps the error that ended a run to the process exit code. API
+// failures are mapped by their api.APIError code, stale resumes found by
+// remind -cron exit with exitStale, and other errors exit with exitFailure.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	if errors.As(err, &staleError{}) {
+		return exitStale
+	}
+
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return exitFailure
+	}
+
+	switch apiErr.Code {
+	case api.CodeAuth:
+		return exitAuth
+	case api.CodeQuota:
+		return exitQuota
+	case api.CodeNetwork, api.CodeServer:
+		return exitUnavailable
+	case api.CodeInvalidRequest, api.CodeModelNotFound:
+		return exitInvalidRequest
+	default:
+		return exitFailure
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestExitCode(t *testing.T) {
+	apiFailure := func(message string) error {
+		return fmt.Errorf("error executing API request: %w", api.ClassifyError(errors.New(message)))
+	}
+
+	testCases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"Success", nil, exitOK},
+		{"File error", errors.New("failed to read source file: no such file"), exitFailure},
+		{"Invalid API key", apiFailure("googleapi: Error 401: API key not valid"), exitAuth},
+		{"Quota exceeded", apiFailure("googleapi: Error 429: RESOURCE_EXHAUSTED"), exitQuota},
+		{"Network failure", apiFailure("dial tcp: connection refused"), exitUnavailable},
+		{"Server overloaded", apiFailure("googleapi: Error 503: UNAVAILABLE"), exitUnavailable},
+		{"Retired model", apiFailure("googleapi: Error 404: model is not found"), exitInvalidRequest},
+		{"Unclassified API failure", apiFailure("something unexpected"), exitFailure},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := exitCode(tc.err); got != tc.want {
+				t.Errorf("exitCode() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
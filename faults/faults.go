@@ -0,0 +1,200 @@
+// Package faults forces failures at chosen stages of resume generation, so
+// the error analyzer, the fallback retry, and the error screens can be
+// exercised without waiting for a real quota error or safety block.
+//
+// A fault is set once at startup, from the hidden --inject-error flag, and
+// checked where each stage runs: API requests check the stage recorded in
+// their context with WithStage, and the TUI checks StageWrite before saving.
+package faults
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Category is the kind of failure to force.
+type Category string
+
+const (
+	// Quota fails requests with a 429 quota error, as the API returns when a
+	// rate limit or the daily quota is used up.
+	Quota Category = "quota"
+
+	// Safety answers requests with a response blocked by the safety filters.
+	Safety Category = "safety"
+
+	// Truncation cuts responses in half and marks them as stopped at the
+	// token limit.
+	Truncation Category = "truncation"
+
+	// WritePermission fails saving the resume with a permission error.
+	WritePermission Category = "write-permission"
+)
+
+// Categories lists the categories in the order they are documented.
+var Categories = []Category{Quota, Safety, Truncation, WritePermission}
+
+// Stage is a step of generation a fault can be injected at.
+type Stage string
+
+const (
+	// StageResume is the request that writes the resume.
+	StageResume Stage = "resume"
+
+	// StageContinuation is each request continuing a truncated resume.
+	StageContinuation Stage = "continuation"
+
+	// StageCompany is the -company research request.
+	StageCompany Stage = "company"
+
+	// StageCoverLetter is the -bundle cover letter request.
+	StageCoverLetter Stage = "cover-letter"
+
+	// StageExplain is the -explain request for notes on what changed.
+	StageExplain Stage = "explain"
+
+	// StageGaps is the -gaps request for suggestions to close the gaps with a job.
+	StageGaps Stage = "gaps"
+
+	// StageDocument is each -documents request, such as for a thank-you email.
+	StageDocument Stage = "document"
+
+	// StageSection is a request regenerating one section from the success screen.
+	StageSection Stage = "section"
+
+	// StageWrite is saving the resume. It is the only stage of WritePermission.
+	StageWrite Stage = "write"
+)
+
+// Stages lists the stages in the order they run.
+var Stages = []Stage{StageResume, StageContinuation, StageCompany, StageCoverLetter, StageExplain, StageGaps, StageDocument, StageSection, StageWrite}
+
+// Fault is a failure forced at a stage.
+type Fault struct {
+	Category Category
+	Stage    Stage
+}
+
+// active is the fault set with Set; its zero value injects nothing.
+var active Fault
+
+// Parse reads a fault from the --inject-error value: a category, optionally
+// followed by "@" and the stage to inject it at. Without a stage, request
+// failures are injected into the resume request and write failures into
+// saving it.
+//
+// Parameters:
+//   - spec: The fault, such as "quota" or "safety@cover-letter"
+//
+// Returns:
+//   - Fault: The fault
+//   - error: An error listing the valid categories or stages if spec names an unknown one
+//
+// Example:
+//
+//	fault, err := faults.Parse("truncation@continuation")
+//	// fault == faults.Fault{Category: faults.Truncation, Stage: faults.StageContinuation}
+func Parse(spec string) (Fault, error) {
+	category, stage, _ := strings.Cut(strings.ToLower(strings.TrimSpace(spec)), "@")
+	fault := Fault{Category: Category(strings.TrimSpace(category)), Stage: Stage(strings.TrimSpace(stage))}
+
+	if !slices.Contains(Categories, fault.Category) {
+		return Fault{}, fmt.Errorf("unknown error category %q (valid categories: %s)", fault.Category, join(Categories))
+	}
+	if fault.Stage == "" {
+		fault.Stage = StageResume
+		if fault.Category == WritePermission {
+			fault.Stage = StageWrite
+		}
+	}
+	if !slices.Contains(Stages, fault.Stage) {
+		return Fault{}, fmt.Errorf("unknown stage %q (valid stages: %s)", fault.Stage, join(Stages))
+	}
+	if (fault.Category == WritePermission) != (fault.Stage == StageWrite) {
+		return Fault{}, fmt.Errorf("%s errors can only be injected at the %s stage, and other errors only at API requests", WritePermission, StageWrite)
+	}
+	return fault, nil
+}
+
+// Set makes fault the failure to inject. Pass the zero Fault to stop
+// injecting failures.
+//
+// Parameters:
+//   - fault: The fault, as returned by Parse
+func Set(fault Fault) {
+	active = fault
+}
+
+// Active returns the fault set with Set.
+//
+// Returns:
+//   - Fault: The fault
+//   - bool: Whether a fault is set
+func Active() (Fault, bool) {
+	return active, active.Category != ""
+}
+
+// At returns the category of the fault to inject at stage.
+//
+// Parameters:
+//   - stage: The stage that is running
+//
+// Returns:
+//   - Category: The category of failure to force
+//   - bool: Whether a failure should be forced at stage
+//
+// Example:
+//
+//	if category, ok := faults.At(faults.StageWrite); ok && category == faults.WritePermission {
+//	    return fs.ErrPermission
+//	}
+func At(stage Stage) (Category, bool) {
+	if active.Category == "" || stage == "" || active.Stage != stage {
+		return "", false
+	}
+	return active.Category, true
+}
+
+// stageKey is the context key of the stage set with WithStage.
+type stageKey struct{}
+
+// WithStage returns a context whose API requests belong to stage, so a fault
+// set for the stage is injected into them.
+//
+// Parameters:
+//   - ctx: The context for the requests
+//   - stage: The stage the requests belong to
+//
+// Returns:
+//   - context.Context: The context carrying the stage
+//
+// Example:
+//
+//	response, err := session.Send(faults.WithStage(ctx, faults.StageExplain), content)
+func WithStage(ctx context.Context, stage Stage) context.Context {
+	return context.WithValue(ctx, stageKey{}, stage)
+}
+
+// StageOf returns the stage set with WithStage, or an empty Stage when the
+// requests were not labeled.
+//
+// Parameters:
+//   - ctx: The context of a request
+//
+// Returns:
+//   - Stage: The stage
+func StageOf(ctx context.Context) Stage {
+	stage, _ := ctx.Value(stageKey{}).(Stage)
+	return stage
+}
+
+// join lists values separated by commas.
+func join[T ~string](values []T) string {
+	names := make([]string, len(values))
+	for i, value := range values {
+		names[i] = string(value)
+	}
+	return strings.Join(names, ", ")
+}
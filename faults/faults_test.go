@@ -0,0 +1,63 @@
+package faults
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Fault
+		wantErr string
+	}{
+		{"quota", Fault{Quota, StageResume}, ""},
+		{" Safety@Cover-Letter ", Fault{Safety, StageCoverLetter}, ""},
+		{"truncation@continuation", Fault{Truncation, StageContinuation}, ""},
+		{"write-permission", Fault{WritePermission, StageWrite}, ""},
+		{"timeout", Fault{}, "valid categories: quota, safety, truncation, write-permission"},
+		{"quota@upload", Fault{}, "valid stages"},
+		{"quota@write", Fault{}, "only be injected"},
+		{"write-permission@resume", Fault{}, "only be injected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := Parse(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Parse(%q) error = %v, want one containing %q", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Parse(%q) = %+v, %v, want %+v", tt.spec, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	t.Cleanup(func() { Set(Fault{}) })
+
+	// Test case 1: Nothing is injected until a fault is set
+	if _, ok := Active(); ok {
+		t.Error("Expected no fault to be set")
+	}
+	if _, ok := At(StageResume); ok {
+		t.Error("Expected no fault without Set")
+	}
+
+	// Test case 2: A fault is injected at its stage only
+	Set(Fault{Quota, StageCoverLetter})
+	if category, ok := At(StageOf(WithStage(context.Background(), StageCoverLetter))); !ok || category != Quota {
+		t.Errorf("Expected a quota fault at the cover letter, got %q (%v)", category, ok)
+	}
+	if _, ok := At(StageResume); ok {
+		t.Error("Expected no fault at the resume request")
+	}
+	if _, ok := At(StageOf(context.Background())); ok {
+		t.Error("Expected no fault for requests without a stage")
+	}
+}
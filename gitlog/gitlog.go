@@ -0,0 +1,195 @@
+// Package gitlog reads a developer's commits from local git repositories.
+//
+// The commits, including the titles of merged pull requests, are a record of
+// work that is easy to forget when writing a resume. They are summarized
+// into candidate achievement bullets by the achievements command, so the
+// user can pick the ones worth adding to their notes.
+package gitlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit is one commit or merged pull request.
+type Commit struct {
+	Repo        string    // Name of the repository, from its directory
+	Hash        string    // Full commit hash
+	Date        time.Time // Author date
+	Subject     string    // Commit subject, or the pull request title for merges
+	PullRequest int       // Pull request number, if the commit merged one (0 otherwise)
+}
+
+// String formats the commit as one line of activity for the prompt, such as
+// "2024-05-01 resumake: Add PDF export (PR #42)".
+func (c Commit) String() string {
+	line := fmt.Sprintf("%s %s: %s", c.Date.Format("2006-01-02"), c.Repo, c.Subject)
+	if c.PullRequest > 0 {
+		line += fmt.Sprintf(" (PR #%d)", c.PullRequest)
+	}
+	return line
+}
+
+// Options selects the commits Log returns.
+type Options struct {
+	Author string    // Author name or email to match, as git log --author does (required)
+	Since  time.Time // Earliest author date (zero for no limit)
+	Until  time.Time // Latest author date (zero for no limit)
+	Limit  int       // Maximum number of commits, newest first (0 for no limit)
+}
+
+// Field and record separators in the git log format, which cannot appear in
+// commit messages.
+const (
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+)
+
+var (
+	// mergePullRequestRegex matches the subject GitHub writes for merged pull requests.
+	mergePullRequestRegex = regexp.MustCompile(`^Merge pull request #(\d+)\b`)
+
+	// squashPullRequestRegex matches the "(#123)" suffix of squash-merged pull requests.
+	squashPullRequestRegex = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+
+	// routineMergeRegex matches merges that carry no description of the work.
+	routineMergeRegex = regexp.MustCompile(`^Merge (branch|remote-tracking branch|tag) `)
+)
+
+// Log returns the commits by an author in a local git repository, newest
+// first. Merges of GitHub pull requests are kept with the pull request's
+// title as the subject; other merge commits are left out.
+//
+// Parameters:
+//   - ctx: The context for the git command
+//   - repo: The path of the repository, or any directory inside it
+//   - opts: The author, date range, and limit
+//
+// Returns:
+//   - []Commit: The matching commits
+//   - error: An error if the author is empty, the path is not a repository, or git fails
+//
+// Example:
+//
+//	commits, err := gitlog.Log(ctx, ".", gitlog.Options{Author: "jane@example.com", Since: lastYear})
+func Log(ctx context.Context, repo string, opts Options) ([]Commit, error) {
+	if strings.TrimSpace(opts.Author) == "" {
+		return nil, errors.New("an author is required to find your commits")
+	}
+
+	args := []string{"log", "--author=" + opts.Author, "--format=%H" + fieldSeparator + "%aI" + fieldSeparator + "%s" + fieldSeparator + "%b" + recordSeparator}
+	if !opts.Since.IsZero() {
+		args = append(args, "--since="+opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		args = append(args, "--until="+opts.Until.Format(time.RFC3339))
+	}
+	if opts.Limit > 0 {
+		args = append(args, "--max-count="+strconv.Itoa(opts.Limit))
+	}
+
+	out, err := run(ctx, repo, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(out, repoName(repo)), nil
+}
+
+// DefaultAuthor returns the email git commits are made with in a repository,
+// from its user.email setting.
+//
+// Parameters:
+//   - ctx: The context for the git command
+//   - repo: The path of the repository
+//
+// Returns:
+//   - string: The configured email
+//   - error: An error if user.email is not set
+func DefaultAuthor(ctx context.Context, repo string) (string, error) {
+	out, err := run(ctx, repo, "config", "user.email")
+	if err != nil || strings.TrimSpace(out) == "" {
+		return "", fmt.Errorf("git user.email is not set in %s; pass your commit email with -author", repo)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// run runs git in the repository and returns its output. A failure reports
+// what git printed, such as "not a git repository".
+func run(ctx context.Context, repo string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repo}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s failed in %s: %s", args[0], repo, message)
+		}
+		return "", fmt.Errorf("git %s failed in %s: %w", args[0], repo, err)
+	}
+	return string(out), nil
+}
+
+// parseLog parses the records written by the format in Log.
+func parseLog(out, repo string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(out, recordSeparator) {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), fieldSeparator, 4)
+		if len(fields) < 3 {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+		commit := Commit{Repo: repo, Hash: fields[0], Date: date, Subject: strings.TrimSpace(fields[2])}
+
+		body := ""
+		if len(fields) == 4 {
+			body = fields[3]
+		}
+
+		switch {
+		case mergePullRequestRegex.MatchString(commit.Subject):
+			commit.PullRequest, _ = strconv.Atoi(mergePullRequestRegex.FindStringSubmatch(commit.Subject)[1])
+			commit.Subject = firstLine(body)
+			if commit.Subject == "" {
+				continue
+			}
+		case routineMergeRegex.MatchString(commit.Subject):
+			continue
+		case squashPullRequestRegex.MatchString(commit.Subject):
+			commit.PullRequest, _ = strconv.Atoi(squashPullRequestRegex.FindStringSubmatch(commit.Subject)[1])
+			commit.Subject = squashPullRequestRegex.ReplaceAllString(commit.Subject, "")
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// firstLine returns the first non-empty line of text.
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// repoName names a repository after its directory.
+func repoName(repo string) string {
+	if abs, err := filepath.Abs(repo); err == nil {
+		repo = abs
+	}
+	return filepath.Base(repo)
+}
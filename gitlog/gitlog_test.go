@@ -0,0 +1,108 @@
+package gitlog
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRepo creates a git repository with commits by the given authors, each
+// commit's date following the previous one by a day.
+func newRepo(t *testing.T, commits []struct{ email, message string }) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := filepath.Join(t.TempDir(), "widgets")
+	git := func(env []string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	git(nil, "init", "-q")
+	git(nil, "config", "user.email", "jane@example.com")
+	git(nil, "config", "user.name", "Jane Doe")
+
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, commit := range commits {
+		date := start.AddDate(0, 0, i).Format(time.RFC3339)
+		env := []string{"GIT_AUTHOR_EMAIL=" + commit.email, "GIT_AUTHOR_NAME=Someone", "GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date}
+		git(env, "commit", "-q", "--allow-empty", "-m", commit.message)
+	}
+	return dir
+}
+
+func TestLog(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t, []struct{ email, message string }{
+		{"jane@example.com", "Add CSV export"},
+		{"sam@example.com", "Fix flaky test"},
+		{"jane@example.com", "Merge branch 'main' into export"},
+		{"jane@example.com", "Merge pull request #12 from jane/cache\n\nCache rendered reports"},
+		{"jane@example.com", "Cut report latency in half (#15)"},
+	})
+
+	// Test case 1: Only the author's commits are returned, newest first, with pull requests named
+	commits, err := Log(ctx, repo, Options{Author: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	var lines []string
+	for _, commit := range commits {
+		lines = append(lines, commit.String())
+	}
+	want := []string{
+		"2024-03-05 widgets: Cut report latency in half (PR #15)",
+		"2024-03-04 widgets: Cache rendered reports (PR #12)",
+		"2024-03-01 widgets: Add CSV export",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Log() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Test case 2: The date range and limit are applied
+	commits, err = Log(ctx, repo, Options{
+		Author: "jane@example.com",
+		Since:  time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2024, 3, 4, 23, 59, 59, 0, time.UTC),
+	})
+	if err != nil || len(commits) != 1 || commits[0].PullRequest != 12 {
+		t.Errorf("Expected only the pull request in range, got %+v (%v)", commits, err)
+	}
+	if commits, _ := Log(ctx, repo, Options{Author: "jane@example.com", Limit: 1}); len(commits) != 1 {
+		t.Errorf("Expected one commit with a limit of 1, got %d", len(commits))
+	}
+
+	// Test case 3: The author comes from the repository's settings
+	if author, err := DefaultAuthor(ctx, repo); err != nil || author != "jane@example.com" {
+		t.Errorf("DefaultAuthor() = %q, %v", author, err)
+	}
+}
+
+func TestLogErrors(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+
+	// Test case 1: An author is required
+	if _, err := Log(ctx, ".", Options{}); err == nil {
+		t.Error("Expected an error without an author")
+	}
+
+	// Test case 2: A directory outside a repository reports what git said
+	if _, err := Log(ctx, t.TempDir(), Options{Author: "jane@example.com"}); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("Expected a not-a-repository error, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/phrazzld/resumake/history"
+)
+
+// runHistory lists the saved revisions, newest first, optionally only those
+// of one profile, to w.
+func runHistory(profile string, w io.Writer) error {
+	dir, err := history.DefaultDir()
+	if err != nil {
+		return err
+	}
+
+	revisions, err := history.List(dir)
+	if err != nil {
+		return err
+	}
+	if profile != "" {
+		var matching []history.Revision
+		for _, revision := range revisions {
+			if revision.Profile == profile {
+				matching = append(matching, revision)
+			}
+		}
+		revisions = matching
+	}
+
+	if len(revisions) == 0 {
+		if profile != "" {
+			fmt.Fprintf(w, "No saved resumes for %s in %s.\n", profile, dir)
+		} else {
+			fmt.Fprintf(w, "No saved resumes in %s yet; every resume you generate is kept there.\n", dir)
+		}
+		return nil
+	}
+	fmt.Fprint(w, formatHistory(revisions))
+	return nil
+}
+
+// formatHistory lists revisions one per line: when each was saved, its
+// profile, and the file it is stored in.
+func formatHistory(revisions []history.Revision) string {
+	width := 0
+	for _, revision := range revisions {
+		width = max(width, len(revision.Profile))
+	}
+
+	var b strings.Builder
+	for _, revision := range revisions {
+		fmt.Fprintf(&b, "%s  %-*s  %s\n", revision.Saved.Format("2006-01-02 15:04"), width, revision.Profile, revision.Path)
+	}
+	return b.String()
+}
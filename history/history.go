@@ -0,0 +1,419 @@
+// Package history keeps earlier versions of generated resumes.
+//
+// Every resume that is saved is also stored as a revision in the history
+// directory inside the resumake configuration directory (see config.Dir).
+// Revisions are grouped by profile, the candidate named in the resume's
+// title, so a new resume can be compared with the last one saved for the same
+// person. Each revision is a Markdown file named after the time it was saved,
+// with where it was written and what for, when recorded, in a JSON file of
+// the same name.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/vault"
+)
+
+// DirName is the name of the history directory inside the configuration directory.
+const DirName = "history"
+
+// UnnamedProfile is the profile of resumes without a candidate name.
+const UnnamedProfile = "unnamed"
+
+// timeLayout names revision files so they sort in the order they were saved.
+const timeLayout = "2006-01-02T150405.000"
+
+// Revision is one saved version of a resume.
+type Revision struct {
+	// Profile is the candidate the resume belongs to, as returned by Profile.
+	Profile string
+
+	// Saved is when the revision was stored.
+	Saved time.Time
+
+	// Content is the resume in Markdown.
+	Content string
+
+	// Path is the file the revision is stored in.
+	Path string
+
+	// Details describes where the resume was written and what it was
+	// written for, when they were recorded with SaveWithDetails.
+	Details Details
+}
+
+// Details describes where a saved resume was written and the job it was
+// written for. They are stored next to the revision, so the workspace
+// dashboard can list each resume's files and target.
+type Details struct {
+	OutputPath string `json:"output_path,omitempty"` // The Markdown file the resume was written to
+	Company    string `json:"company,omitempty"`     // The employer the resume was tailored to
+	JobPath    string `json:"job_path,omitempty"`    // The job description the resume was tailored to
+}
+
+// detailsPath returns the file the details of a revision are stored in.
+func detailsPath(revisionPath string) string {
+	return strings.TrimSuffix(revisionPath, ".md") + ".json"
+}
+
+// DefaultDir returns the default history directory inside the configuration directory.
+//
+// Returns:
+//   - string: The history directory path
+//   - error: An error if the configuration directory cannot be determined
+func DefaultDir() (string, error) {
+	return config.Path(DirName)
+}
+
+// Profile returns the profile a resume belongs to: the candidate's name from
+// its title in lower case, with words joined by hyphens, such as "jane-doe".
+// Resumes without a name, or titled just "Resume", share UnnamedProfile.
+//
+// Parameters:
+//   - markdownContent: The resume in Markdown
+//
+// Returns:
+//   - string: The profile, safe to use as a directory name
+//
+// Example:
+//
+//	profile := history.Profile("# Jane Doe\n\n## Experience")  // "jane-doe"
+func Profile(markdownContent string) string {
+	var words []string
+	for _, word := range strings.Fields(document.Parse(markdownContent).Name) {
+		word = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, word)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	profile := strings.Join(words, "-")
+	if profile == "" || profile == "resume" || profile == "curriculum-vitae" {
+		return UnnamedProfile
+	}
+	return profile
+}
+
+// Save stores a resume as a new revision of its profile. Revisions hold
+// personal data, so only the owner can read them, and they are encrypted
+// when the encryption setting is on (see vault).
+//
+// Parameters:
+//   - dir: The history directory
+//   - markdownContent: The resume in Markdown
+//   - t: When the resume was saved
+//
+// Returns:
+//   - Revision: The stored revision
+//   - error: An error if the revision could not be written
+//
+// Example:
+//
+//	dir, _ := history.DefaultDir()
+//	revision, err := history.Save(dir, content, time.Now())
+func Save(dir, markdownContent string, t time.Time) (Revision, error) {
+	return SaveWithDetails(dir, markdownContent, t, Details{})
+}
+
+// SaveWithDetails stores a resume as a new revision of its profile, as Save
+// does, along with where it was written and what it was written for. The
+// details are encrypted with the revision.
+//
+// Parameters:
+//   - dir: The history directory
+//   - markdownContent: The resume in Markdown
+//   - t: When the resume was saved
+//   - details: Where the resume was written and the job it was written for
+//
+// Returns:
+//   - Revision: The stored revision
+//   - error: An error if the revision or its details could not be written
+//
+// Example:
+//
+//	revision, err := history.SaveWithDetails(dir, content, time.Now(), history.Details{OutputPath: "resume.md"})
+func SaveWithDetails(dir, markdownContent string, t time.Time, details Details) (Revision, error) {
+	revision := Revision{
+		Profile: Profile(markdownContent),
+		Saved:   t,
+		Content: markdownContent,
+	}
+	revision.Path = filepath.Join(dir, revision.Profile, t.Format(timeLayout)+".md")
+
+	if err := os.MkdirAll(filepath.Dir(revision.Path), 0700); err != nil {
+		return Revision{}, fmt.Errorf("cannot create history directory: %w", err)
+	}
+	data, err := vault.Seal([]byte(markdownContent))
+	if err != nil {
+		return Revision{}, fmt.Errorf("cannot encrypt revision: %w", err)
+	}
+	if err := os.WriteFile(revision.Path, data, 0600); err != nil {
+		return Revision{}, fmt.Errorf("cannot save revision: %w", err)
+	}
+
+	if details == (Details{}) {
+		return revision, nil
+	}
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return Revision{}, fmt.Errorf("cannot encode revision details: %w", err)
+	}
+	if encoded, err = vault.Seal(encoded); err != nil {
+		return Revision{}, fmt.Errorf("cannot encrypt revision details: %w", err)
+	}
+	if err := os.WriteFile(detailsPath(revision.Path), encoded, 0600); err != nil {
+		return Revision{}, fmt.Errorf("cannot save revision details: %w", err)
+	}
+	revision.Details = details
+	return revision, nil
+}
+
+// Latest returns the most recently saved revision of a profile. A profile
+// without revisions is not an error; it returns false.
+//
+// Parameters:
+//   - dir: The history directory
+//   - profile: The profile, as returned by Profile
+//
+// Returns:
+//   - Revision: The latest revision
+//   - bool: Whether the profile has any revisions
+//   - error: An error if the history could not be read
+//
+// Example:
+//
+//	previous, ok, err := history.Latest(dir, history.Profile(content))
+func Latest(dir, profile string) (Revision, bool, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, profile))
+	if os.IsNotExist(err) {
+		return Revision{}, false, nil
+	}
+	if err != nil {
+		return Revision{}, false, fmt.Errorf("cannot read history: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && filepath.Ext(entry.Name()) == ".md" {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return Revision{}, false, nil
+	}
+	sort.Strings(names)
+	name := names[len(names)-1]
+
+	revision, err := readRevision(dir, profile, name)
+	if err != nil {
+		return Revision{}, false, err
+	}
+	return revision, true, nil
+}
+
+// readRevision reads the revision stored in the named file of a profile.
+func readRevision(dir, profile, name string) (Revision, error) {
+	path := filepath.Join(dir, profile, name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Revision{}, fmt.Errorf("cannot read revision: %w", err)
+	}
+	if data, err = vault.Open(data); err != nil {
+		return Revision{}, fmt.Errorf("cannot read revision %s: %w", path, err)
+	}
+
+	saved, err := time.ParseInLocation(timeLayout, strings.TrimSuffix(name, ".md"), time.Local)
+	if err != nil {
+		// A file added by hand still counts; its modification time is used
+		if info, statErr := os.Stat(path); statErr == nil {
+			saved = info.ModTime()
+		}
+	}
+	revision := Revision{Profile: profile, Saved: saved, Content: string(data), Path: path}
+
+	// Revisions saved without details, or before they were kept, have none
+	if encoded, err := os.ReadFile(detailsPath(path)); err == nil {
+		if encoded, err = vault.Open(encoded); err != nil {
+			return Revision{}, fmt.Errorf("cannot read the details of revision %s: %w", path, err)
+		}
+		if err := json.Unmarshal(encoded, &revision.Details); err != nil {
+			return Revision{}, fmt.Errorf("cannot read the details of revision %s: %w", path, err)
+		}
+	}
+	return revision, nil
+}
+
+// DeleteProfile removes every saved revision of a profile from the history.
+// The resume files written outside the history are kept.
+//
+// Parameters:
+//   - dir: The history directory
+//   - profile: The profile, as returned by Profile
+//
+// Returns:
+//   - error: An error if the profile is not a name in the history or cannot be removed
+//
+// Example:
+//
+//	err := history.DeleteProfile(dir, "jane-doe")
+func DeleteProfile(dir, profile string) error {
+	if profile == "" || profile == "." || profile == ".." || strings.ContainsAny(profile, `/\`) {
+		return fmt.Errorf("invalid profile %q", profile)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, profile)); err != nil {
+		return fmt.Errorf("cannot delete the history of %s: %w", profile, err)
+	}
+	return nil
+}
+
+// Newest returns the most recently saved revision of any profile, such as
+// the last resume generated on this machine. An empty or missing history is
+// not an error; it returns false.
+//
+// Parameters:
+//   - dir: The history directory
+//
+// Returns:
+//   - Revision: The newest revision
+//   - bool: Whether the history has any revisions
+//   - error: An error if the history could not be read
+//
+// Example:
+//
+//	last, ok, err := history.Newest(dir)
+func Newest(dir string) (Revision, bool, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return Revision{}, false, nil
+	}
+	if err != nil {
+		return Revision{}, false, fmt.Errorf("cannot read history: %w", err)
+	}
+
+	var newest Revision
+	found := false
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		latest, ok, err := Latest(dir, entry.Name())
+		if err != nil {
+			return Revision{}, false, err
+		}
+		if ok && (!found || latest.Saved.After(newest.Saved)) {
+			newest, found = latest, true
+		}
+	}
+	return newest, found, nil
+}
+
+// List returns every saved revision of every profile, newest first. An
+// empty or missing history is not an error; it returns no revisions.
+//
+// Parameters:
+//   - dir: The history directory
+//
+// Returns:
+//   - []Revision: The revisions, newest first
+//   - error: An error if the history could not be read
+//
+// Example:
+//
+//	revisions, err := history.List(dir)
+func List(dir string) ([]Revision, error) {
+	profiles, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read history: %w", err)
+	}
+
+	var revisions []Revision
+	for _, profile := range profiles {
+		if !profile.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(dir, profile.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read history: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() || filepath.Ext(entry.Name()) != ".md" {
+				continue
+			}
+			revision, err := readRevision(dir, profile.Name(), entry.Name())
+			if err != nil {
+				return nil, err
+			}
+			revisions = append(revisions, revision)
+		}
+	}
+
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Saved.After(revisions[j].Saved)
+	})
+	return revisions, nil
+}
+
+// LatestOfEach returns the most recently saved revision of every profile,
+// the one saved longest ago first, such as to find the resumes that have not
+// been regenerated in a while. An empty or missing history is not an error;
+// it returns no revisions.
+//
+// Parameters:
+//   - dir: The history directory
+//
+// Returns:
+//   - []Revision: The latest revision of each profile, oldest first
+//   - error: An error if the history could not be read
+//
+// Example:
+//
+//	latest, err := history.LatestOfEach(dir)
+//	for _, revision := range latest {
+//	    fmt.Println(revision.Profile, revision.Saved)
+//	}
+func LatestOfEach(dir string) ([]Revision, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read history: %w", err)
+	}
+
+	var latest []Revision
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		revision, ok, err := Latest(dir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			latest = append(latest, revision)
+		}
+	}
+
+	sort.SliceStable(latest, func(i, j int) bool {
+		return latest[i].Saved.Before(latest[j].Saved)
+	})
+	return latest, nil
+}
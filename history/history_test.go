@@ -0,0 +1,227 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/vault"
+)
+
+func TestProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"Name from the title", "# Jane Doe\n\n## Experience", "jane-doe"},
+		{"Punctuation is dropped", "# Dr. José O'Neil, PhD", "dr-josé-oneil-phd"},
+		{"A generic title has no name", "# Resume\n\n## Experience", UnnamedProfile},
+		{"No title has no name", "## Experience\n- Built X", UnnamedProfile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Profile(tt.markdown); got != tt.expected {
+				t.Errorf("Profile(%q) = %q, want %q", tt.markdown, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSaveAndLatest(t *testing.T) {
+	dir := t.TempDir()
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+
+	// Test case 1: A profile without revisions has no latest revision
+	if _, ok, err := Latest(dir, "jane-doe"); ok || err != nil {
+		t.Fatalf("Expected no revisions, got %v (%v)", ok, err)
+	}
+
+	// Test case 2: The most recent revision of the profile is returned
+	if _, err := Save(dir, "# Jane Doe\n- Built X", first); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := Save(dir, "# Jane Doe\n- Led X", first.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Save(dir, "# John Roe\n- Sold Y", first.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(saved.Path) != filepath.Join(dir, "jane-doe") {
+		t.Errorf("Expected the revision in the profile directory, got %s", saved.Path)
+	}
+
+	latest, ok, err := Latest(dir, "jane-doe")
+	if err != nil || !ok {
+		t.Fatalf("Expected a latest revision, got %v (%v)", ok, err)
+	}
+	if latest.Content != "# Jane Doe\n- Led X" || !latest.Saved.Equal(first.Add(time.Hour)) {
+		t.Errorf("Unexpected latest revision %+v", latest)
+	}
+}
+
+func TestNewest(t *testing.T) {
+	dir := t.TempDir()
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+
+	// Test case 1: An empty or missing history has no newest revision
+	if _, ok, err := Newest(filepath.Join(dir, "missing")); ok || err != nil {
+		t.Fatalf("Expected no revisions, got %v (%v)", ok, err)
+	}
+
+	// Test case 2: The newest revision across every profile is returned
+	for i, content := range []string{"# Jane Doe\n- Built X", "# John Roe\n- Sold Y", "# Jane Doe\n- Led X"} {
+		if _, err := Save(dir, content, first.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newest, ok, err := Newest(dir)
+	if err != nil || !ok {
+		t.Fatalf("Expected a newest revision, got %v (%v)", ok, err)
+	}
+	if newest.Content != "# Jane Doe\n- Led X" || newest.Profile != "jane-doe" {
+		t.Errorf("Unexpected newest revision %+v", newest)
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+
+	// Test case 1: A missing history has no revisions
+	if revisions, err := List(filepath.Join(dir, "missing")); len(revisions) > 0 || err != nil {
+		t.Fatalf("Expected no revisions, got %v (%v)", revisions, err)
+	}
+
+	// Test case 2: Every revision of every profile is listed, newest first
+	for i, content := range []string{"# Jane Doe\n- Built X", "# John Roe\n- Sold Y", "# Jane Doe\n- Led X"} {
+		if _, err := Save(dir, content, first.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	revisions, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var profiles []string
+	for _, revision := range revisions {
+		profiles = append(profiles, revision.Profile)
+	}
+	if strings.Join(profiles, ",") != "jane-doe,john-roe,jane-doe" || revisions[0].Content != "# Jane Doe\n- Led X" {
+		t.Errorf("Unexpected revisions %+v", revisions)
+	}
+}
+
+func TestLatestOfEach(t *testing.T) {
+	dir := t.TempDir()
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+
+	// Test case 1: A missing history has no revisions
+	if latest, err := LatestOfEach(filepath.Join(dir, "missing")); len(latest) > 0 || err != nil {
+		t.Fatalf("Expected no revisions, got %v (%v)", latest, err)
+	}
+
+	// Test case 2: Each profile's latest revision is returned, the oldest first
+	for i, content := range []string{"# Jane Doe\n- Built X", "# John Roe\n- Sold Y", "# Jane Doe\n- Led X"} {
+		if _, err := Save(dir, content, first.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	latest, err := LatestOfEach(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest) != 2 || latest[0].Profile != "john-roe" || latest[1].Content != "# Jane Doe\n- Led X" {
+		t.Errorf("Unexpected revisions %+v", latest)
+	}
+}
+
+func TestSaveEncrypted(t *testing.T) {
+	t.Setenv(config.DirEnvVar, t.TempDir())
+	t.Setenv(vault.PassphraseEnv, "correct horse")
+	vault.Set(vault.ModePassphrase, nil)
+	defer vault.Set(vault.ModeOff, nil)
+	dir := t.TempDir()
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+
+	// Test case 1: With encryption on, the revision file does not hold the resume
+	saved, err := Save(dir, "# Jane Doe\n- Built X", first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(saved.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vault.IsEncrypted(data) || strings.Contains(string(data), "Jane") {
+		t.Errorf("Expected an encrypted revision, got %q", data)
+	}
+
+	// Test case 2: Revisions are decrypted when read, next to ones saved before encryption
+	vault.Set(vault.ModeOff, nil)
+	if _, err := Save(dir, "# Jane Doe\n- Led X", first.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	revisions, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 || revisions[0].Content != "# Jane Doe\n- Led X" || revisions[1].Content != "# Jane Doe\n- Built X" {
+		t.Errorf("Expected both revisions decrypted, got %+v", revisions)
+	}
+}
+
+func TestSaveWithDetails(t *testing.T) {
+	dir := t.TempDir()
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+	details := Details{OutputPath: "/resumes/Jane_Doe_Resume.md", Company: "Acme", JobPath: "/jobs/acme.txt"}
+
+	// Test case 1: The details are read back with the revision
+	if _, err := SaveWithDetails(dir, "# Jane Doe\n- Built X", first, details); err != nil {
+		t.Fatal(err)
+	}
+	latest, ok, err := Latest(dir, "jane-doe")
+	if err != nil || !ok || latest.Details != details {
+		t.Errorf("Expected the details %+v, got %+v (%v)", details, latest.Details, err)
+	}
+
+	// Test case 2: The details file is not listed as a revision
+	if _, err := Save(dir, "# Jane Doe\n- Led X", first.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	revisions, err := List(dir)
+	if err != nil || len(revisions) != 2 || revisions[0].Details != (Details{}) {
+		t.Errorf("Expected two revisions, the newest without details, got %+v (%v)", revisions, err)
+	}
+}
+
+func TestDeleteProfile(t *testing.T) {
+	dir := t.TempDir()
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+	for i, content := range []string{"# Jane Doe\n- Built X", "# John Roe\n- Sold Y"} {
+		if _, err := Save(dir, content, first.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Test case 1: Only the profile's revisions are removed
+	if err := DeleteProfile(dir, "jane-doe"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	latest, err := LatestOfEach(dir)
+	if err != nil || len(latest) != 1 || latest[0].Profile != "john-roe" {
+		t.Errorf("Expected only john-roe left, got %+v (%v)", latest, err)
+	}
+
+	// Test case 2: Names that reach outside the history are refused
+	for _, profile := range []string{"", "..", "../config"} {
+		if err := DeleteProfile(dir, profile); err == nil {
+			t.Errorf("Expected an error for %q", profile)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/history"
+)
+
+func TestRunHistory(t *testing.T) {
+	t.Setenv("RESUMAKE_CONFIG_DIR", t.TempDir())
+
+	// Test case 1: An empty history says so
+	var out strings.Builder
+	if err := runHistory("", &out); err != nil || !strings.Contains(out.String(), "No saved resumes") {
+		t.Fatalf("Expected an empty history, got %q (%v)", out.String(), err)
+	}
+
+	dir, err := history.DefaultDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := time.Date(2024, 6, 1, 9, 30, 0, 0, time.Local)
+	for i, content := range []string{"# Jane Doe\n- Built X", "# John Roe\n- Sold Y"} {
+		if _, err := history.Save(dir, content, saved.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Test case 2: Revisions are listed newest first, with aligned profiles
+	out.Reset()
+	if err := runHistory("", &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "2024-06-01 10:30  john-roe  ") || !strings.HasPrefix(lines[1], "2024-06-01 09:30  jane-doe  ") {
+		t.Errorf("Unexpected history %q", out.String())
+	}
+
+	// Test case 3: A profile shows only its own revisions
+	out.Reset()
+	if err := runHistory("jane-doe", &out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "john-roe") || !strings.Contains(out.String(), "jane-doe") {
+		t.Errorf("Expected only jane-doe's revisions, got %q", out.String())
+	}
+}
@@ -0,0 +1,125 @@
+package input
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/spf13/pflag"
+)
+
+// AchievementsCommand is the first argument that selects achievements mode,
+// which drafts resume bullets from the user's commits in local git
+// repositories.
+const AchievementsCommand = "achievements"
+
+// DefaultMaxCommits is the default number of commits summarized by
+// achievements mode, newest first across all repositories.
+const DefaultMaxCommits = 300
+
+// dateLayout is the format of the -since and -until dates.
+const dateLayout = "2006-01-02"
+
+// AchievementsFlags represents the arguments accepted by achievements mode.
+type AchievementsFlags struct {
+	// Repos holds the paths of the repositories to scan.
+	Repos []string
+
+	// Author holds the author name or email to match, or is empty for each
+	// repository's configured user.email.
+	Author string
+
+	// Since and Until bound the commit dates. Until is the end of its day,
+	// or zero for no limit.
+	Since, Until time.Time
+
+	// MaxCommits holds the maximum number of commits to summarize.
+	MaxCommits int
+
+	// Model holds the Gemini model used for the summary.
+	Model string
+
+	// Save holds the name of the snippet the bullets are saved as, or is
+	// empty to only print them.
+	Save string
+
+	since, until string // The -since and -until dates, parsed by Complete
+}
+
+// Bind defines achievements mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the achievements command
+func (f *AchievementsFlags) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&f.Author, "author", "", "Author name or email to match (default: each repository's git user.email)")
+	fs.StringVar(&f.since, "since", "", "Earliest commit date, as YYYY-MM-DD (default: one year ago)")
+	fs.StringVar(&f.until, "until", "", "Latest commit date, as YYYY-MM-DD (default: today)")
+	fs.IntVar(&f.MaxCommits, "max-commits", DefaultMaxCommits, "Maximum number of commits to summarize, newest first")
+	fs.StringVar(&f.Model, "model", api.DefaultModelName, "Model to summarize the commits with")
+	fs.StringVar(&f.Save, "save", "", "Also save the bullets as a snippet with this name, to insert into your notes with Ctrl+O")
+}
+
+// Complete takes the repositories from the positional arguments, defaulting
+// to the current directory, and checks the flags and dates.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
+//   - now: The current time, from which the default date range is computed
+//
+// Returns:
+//   - error: An error if a flag or date is invalid
+func (f *AchievementsFlags) Complete(args []string, now time.Time) error {
+	f.Repos = append([]string(nil), args...)
+	if len(f.Repos) == 0 {
+		f.Repos = []string{"."}
+	}
+
+	if f.MaxCommits < 1 {
+		return fmt.Errorf("-max-commits must be at least 1, got %d", f.MaxCommits)
+	}
+
+	f.Since = now.AddDate(-1, 0, 0)
+	if f.since != "" {
+		date, err := time.ParseInLocation(dateLayout, f.since, now.Location())
+		if err != nil {
+			return fmt.Errorf("invalid -since date %q: use YYYY-MM-DD", f.since)
+		}
+		f.Since = date
+	}
+	if f.until != "" {
+		date, err := time.ParseInLocation(dateLayout, f.until, now.Location())
+		if err != nil {
+			return fmt.Errorf("invalid -until date %q: use YYYY-MM-DD", f.until)
+		}
+		f.Until = date.AddDate(0, 0, 1).Add(-time.Second)
+		if f.Until.Before(f.Since) {
+			return fmt.Errorf("-until %s is before -since %s", f.until, f.Since.Format(dateLayout))
+		}
+	}
+	return nil
+}
+
+// ParseAchievementsArgs parses the arguments that follow the achievements
+// command. The repositories are the positional arguments, defaulting to the
+// current directory, and flags may come before or after them.
+//
+// Parameters:
+//   - args: The arguments after "achievements"
+//   - now: The current time, from which the default date range is computed
+//
+// Returns:
+//   - AchievementsFlags: The parsed arguments
+//   - error: An error if the flags or dates are invalid
+//
+// Example:
+//
+//	flags, err := input.ParseAchievementsArgs([]string{"-since", "2024-01-01", "~/src/widgets"}, time.Now())
+func ParseAchievementsArgs(args []string, now time.Time) (AchievementsFlags, error) {
+	var flags AchievementsFlags
+	fs := NewFlagSet("resumake achievements")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args(), now)
+}
@@ -0,0 +1,49 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestParseAchievementsArgs(t *testing.T) {
+	now := time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	// Test case 1: Without arguments the current directory is scanned for the past year
+	flags, err := ParseAchievementsArgs(nil, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(flags.Repos, []string{"."}) || !flags.Since.Equal(now.AddDate(-1, 0, 0)) || !flags.Until.IsZero() {
+		t.Errorf("Unexpected defaults: %+v", flags)
+	}
+	if flags.MaxCommits != DefaultMaxCommits || flags.Model != api.DefaultModelName || flags.Author != "" || flags.Save != "" {
+		t.Errorf("Unexpected defaults: %+v", flags)
+	}
+
+	// Test case 2: Flags may come before or after the repositories
+	flags, err = ParseAchievementsArgs([]string{"-author", "jane@example.com", "widgets", "-since", "2024-01-01", "-until", "2024-03-31", "api", "-save", "q1"}, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(flags.Repos, []string{"widgets", "api"}) || flags.Author != "jane@example.com" || flags.Save != "q1" {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+	if !flags.Since.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || !flags.Until.Equal(time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("Expected the range to cover the whole of both days, got %v to %v", flags.Since, flags.Until)
+	}
+
+	// Test case 3: Invalid dates, ranges, and limits are errors
+	for _, args := range [][]string{
+		{"-since", "January"},
+		{"-until", "2024-13-01"},
+		{"-since", "2024-05-01", "-until", "2024-04-01"},
+		{"-max-commits", "0"},
+	} {
+		if _, err := ParseAchievementsArgs(args, now); err == nil {
+			t.Errorf("Expected an error for %q", args)
+		}
+	}
+}
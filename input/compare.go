@@ -0,0 +1,172 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/spf13/pflag"
+)
+
+// CompareCommand is the first argument that selects compare mode, which
+// generates the same resume with two prompt variants and shows the results
+// side by side.
+const CompareCommand = "compare"
+
+// DefaultCompareWidth is the default width of the side-by-side diff.
+const DefaultCompareWidth = 160
+
+// CompareVariant is one side of a comparison: a model and, optionally, a file
+// of system instructions that replaces the built-in prompt template.
+type CompareVariant struct {
+	// Model holds the Gemini model used for this variant.
+	Model string
+
+	// InstructionsPath holds the path to the system instructions file, or is
+	// empty for the built-in instructions.
+	InstructionsPath string
+
+	// Instructions holds the contents of InstructionsPath.
+	Instructions string
+}
+
+// Label describes the variant in the comparison header, such as
+// "gemini-2.5-flash with prompt-b.txt".
+func (v CompareVariant) Label() string {
+	if v.InstructionsPath == "" {
+		return v.Model + " with the built-in prompt"
+	}
+	return v.Model + " with " + v.InstructionsPath
+}
+
+// CompareFlags represents the arguments accepted by compare mode.
+type CompareFlags struct {
+	// SourceContent holds the contents of the -source resume, if any.
+	SourceContent string
+
+	// SourceWarning holds a warning about the -source file, such as an
+	// unsupported extension or removed instructions, for the caller to report.
+	SourceWarning string
+
+	// StdinContent holds the notes from -notes or, without it, from stdin.
+	StdinContent string
+
+	// A and B are the two variants being compared.
+	A, B CompareVariant
+
+	// Width holds the total width of the side-by-side diff.
+	Width int
+
+	sourcePath string // The -source file, read by Complete
+	notesPath  string // The -notes file, read by Complete
+}
+
+// Bind defines compare mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the compare command
+func (f *CompareFlags) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&f.sourcePath, "source", "", "Path to an existing resume file (optional)")
+	fs.StringVar(&f.notesPath, "notes", "", "Path to a file of notes to add (default: read from stdin when piped)")
+	fs.StringVar(&f.A.Model, "model-a", api.DefaultModelName, "Model for variant A")
+	fs.StringVar(&f.B.Model, "model-b", api.DefaultModelName, "Model for variant B")
+	fs.StringVar(&f.A.InstructionsPath, "prompt-a", "", "File of system instructions for variant A (default: built-in)")
+	fs.StringVar(&f.B.InstructionsPath, "prompt-b", "", "File of system instructions for variant B (default: built-in)")
+	fs.IntVar(&f.Width, "width", DefaultCompareWidth, "Width of the side-by-side diff")
+}
+
+// Complete checks the flags and reads the input files. The notes come from
+// -notes, or from stdin when no -notes file is given. Each variant defaults
+// to the default model and the built-in instructions, so at least one of
+// -model-a, -model-b, -prompt-a, or -prompt-b must make them differ.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed; there should be none
+//   - stdin: The reader the notes are read from when no -notes file is given (can be nil)
+//
+// Returns:
+//   - error: An error if there are positional arguments, a flag is invalid,
+//     a file cannot be read, there is no input, or the two variants are the same
+func (f *CompareFlags) Complete(args []string, stdin io.Reader) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument %q; pass inputs with -source and -notes", args[0])
+	}
+	if f.Width < 40 {
+		return fmt.Errorf("-width must be at least 40, got %d", f.Width)
+	}
+
+	var err error
+	if f.sourcePath != "" {
+		if f.SourceContent, f.SourceWarning, err = ReadPromptFile(f.sourcePath); err != nil {
+			return err
+		}
+	}
+	if f.notesPath != "" {
+		if f.StdinContent, err = readTextFile(f.notesPath, "notes"); err != nil {
+			return err
+		}
+	} else if stdin != nil {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("error reading notes: %w", err)
+		}
+		f.StdinContent = string(data)
+	}
+	if strings.TrimSpace(f.SourceContent) == "" && strings.TrimSpace(f.StdinContent) == "" {
+		return errors.New("nothing to compare; pass a resume with -source or notes with -notes or stdin")
+	}
+
+	for _, variant := range []*CompareVariant{&f.A, &f.B} {
+		if variant.InstructionsPath == "" {
+			continue
+		}
+		if variant.Instructions, err = readTextFile(variant.InstructionsPath, "prompt"); err != nil {
+			return err
+		}
+		if strings.TrimSpace(variant.Instructions) == "" {
+			return fmt.Errorf("prompt file %s is empty", variant.InstructionsPath)
+		}
+	}
+	if f.A.Model == f.B.Model && f.A.Instructions == f.B.Instructions {
+		return errors.New("both variants are the same; set -model-a/-model-b or -prompt-a/-prompt-b to compare")
+	}
+	return nil
+}
+
+// ParseCompareArgs parses the arguments that follow the compare command and
+// reads the input files, as Complete describes.
+//
+// Parameters:
+//   - args: The arguments after "compare"
+//   - stdin: The reader the notes are read from when no -notes file is given (can be nil)
+//
+// Returns:
+//   - CompareFlags: The parsed arguments and input contents
+//   - error: An error if the flags are invalid, a file cannot be read,
+//     there is no input, or the two variants are the same
+//
+// Example:
+//
+//	flags, err := input.ParseCompareArgs([]string{"-source", "resume.md", "-prompt-b", "terse.txt"}, nil)
+func ParseCompareArgs(args []string, stdin io.Reader) (CompareFlags, error) {
+	var flags CompareFlags
+	fs := NewFlagSet("resumake compare")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args(), stdin)
+}
+
+// readTextFile reads a file named on the command line, describing it as kind
+// in errors.
+func readTextFile(path, kind string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s file: %w", kind, err)
+	}
+	return string(data), nil
+}
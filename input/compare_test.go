@@ -0,0 +1,62 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestParseCompareArgs(t *testing.T) {
+	dir := t.TempDir()
+	promptPath := filepath.Join(dir, "terse.txt")
+	if err := os.WriteFile(promptPath, []byte("Write a terse resume."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: Notes come from stdin and -prompt-b changes variant B
+	flags, err := ParseCompareArgs([]string{"-prompt-b", promptPath}, strings.NewReader("Built X"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.StdinContent != "Built X" || flags.Width != DefaultCompareWidth {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+	if flags.A.Model != api.DefaultModelName || flags.A.Instructions != "" {
+		t.Errorf("Expected variant A to use the defaults, got %+v", flags.A)
+	}
+	if flags.B.Instructions != "Write a terse resume." {
+		t.Errorf("Expected variant B to use the prompt file, got %+v", flags.B)
+	}
+
+	// Test case 2: Two models can be compared with the built-in prompt
+	flags, err = ParseCompareArgs([]string{"-model-b", api.DefaultFallbackModelName}, strings.NewReader("Built X"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.B.Model != api.DefaultFallbackModelName || flags.B.Label() != api.DefaultFallbackModelName+" with the built-in prompt" {
+		t.Errorf("Unexpected variant B: %+v", flags.B)
+	}
+
+	// Test case 3: Identical variants are an error
+	if _, err := ParseCompareArgs(nil, strings.NewReader("Built X")); err == nil {
+		t.Error("Expected an error when both variants are the same")
+	}
+
+	// Test case 4: There must be some input
+	if _, err := ParseCompareArgs([]string{"-prompt-b", promptPath}, nil); err == nil {
+		t.Error("Expected an error without a source or notes")
+	}
+
+	// Test case 5: A missing prompt file is an error
+	if _, err := ParseCompareArgs([]string{"-prompt-a", filepath.Join(dir, "missing.txt")}, strings.NewReader("Built X")); err == nil {
+		t.Error("Expected an error for a missing prompt file")
+	}
+
+	// Test case 6: The width must leave room for two columns
+	if _, err := ParseCompareArgs([]string{"-width", "20", "-prompt-b", promptPath}, strings.NewReader("Built X")); err == nil {
+		t.Error("Expected an error for a narrow width")
+	}
+}
@@ -0,0 +1,112 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phrazzld/resumake/output"
+	"github.com/spf13/pflag"
+)
+
+// ConvertCommand is the first argument that selects convert mode, which
+// converts an existing Markdown resume to other formats without calling the
+// API.
+const ConvertCommand = "convert"
+
+// DefaultConvertFormats are the formats a resume is converted to when
+// -formats is not given.
+const DefaultConvertFormats = "pdf,docx,html,json"
+
+// ConvertFlags represents the arguments accepted by convert mode.
+type ConvertFlags struct {
+	// SourcePath holds the path of the Markdown resume. The converted files
+	// are written next to it, with the same name.
+	SourcePath string
+
+	// SourceContent holds the contents of SourcePath.
+	SourceContent string
+
+	// Formats holds the comma-separated formats to convert to, such as
+	// "pdf,docx".
+	Formats string
+
+	// Layout holds the layout of the HTML resume, or is empty for the
+	// standard layout.
+	Layout string
+
+	// Timeline adds a career timeline to the HTML resume.
+	Timeline bool
+
+	// PageBreaks marks estimated page breaks in the HTML resume.
+	PageBreaks bool
+
+	// OutputMode holds the octal permission mode of the converted files.
+	OutputMode string
+}
+
+// Bind defines convert mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the convert command
+func (f *ConvertFlags) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&f.Formats, "formats", DefaultConvertFormats, "Comma-separated formats to convert to: html, json, docx, pdf, odt")
+	fs.StringVar(&f.Layout, "layout", "", "Layout of the HTML resume: standard, two-column, or compact (default: standard)")
+	fs.BoolVar(&f.Timeline, "timeline", false, "Add a career timeline to the HTML resume")
+	fs.BoolVar(&f.PageBreaks, "page-breaks", false, "Mark estimated page breaks in the HTML resume and warn where they split a role's bullets")
+	fs.StringVar(&f.OutputMode, "output-mode", fmt.Sprintf("%04o", output.DefaultFileMode), "Permission mode of the converted files, in octal (the umask still applies)")
+}
+
+// Complete reads the resume named by the single positional argument.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
+//
+// Returns:
+//   - error: An error if no resume or more than one is given, or the resume
+//     cannot be read or is empty
+func (f *ConvertFlags) Complete(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no resume to convert; pass the path of a Markdown resume")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("unexpected argument %q; convert one resume at a time", args[1])
+	}
+
+	content, err := ReadSourceFile(args[0])
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("resume %s is empty", args[0])
+	}
+
+	f.SourcePath = args[0]
+	f.SourceContent = content
+	return nil
+}
+
+// ParseConvertArgs parses the arguments that follow the convert command and
+// reads the resume. The resume is the single positional argument, and flags
+// may come before or after it.
+//
+// Parameters:
+//   - args: The arguments after "convert"
+//
+// Returns:
+//   - ConvertFlags: The parsed arguments and the resume's contents
+//   - error: An error if the flags are invalid, no resume or more than one is
+//     given, or the resume cannot be read or is empty
+//
+// Example:
+//
+//	flags, err := input.ParseConvertArgs([]string{"resume.md", "-formats", "pdf,docx"})
+func ParseConvertArgs(args []string) (ConvertFlags, error) {
+	var flags ConvertFlags
+	fs := NewFlagSet("resumake convert")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args())
+}
@@ -0,0 +1,57 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseConvertArgs(t *testing.T) {
+	dir := t.TempDir()
+	resumePath := filepath.Join(dir, "resume.md")
+	if err := os.WriteFile(resumePath, []byte("# Jane Doe\n\n## Experience\n- Built X"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: Without flags every default format is selected
+	flags, err := ParseConvertArgs([]string{resumePath})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.SourcePath != resumePath || flags.SourceContent == "" || flags.Formats != DefaultConvertFormats {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+
+	// Test case 2: Flags may come before or after the resume
+	flags, err = ParseConvertArgs([]string{"-formats", "docx", resumePath, "-layout", "compact", "-timeline", "-page-breaks", "-output-mode", "0640"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.Formats != "docx" || flags.Layout != "compact" || !flags.Timeline || !flags.PageBreaks || flags.OutputMode != "0640" {
+		t.Errorf("Expected the flags on both sides of the resume to be parsed, got %+v", flags)
+	}
+
+	// Test case 3: A resume is required
+	if _, err := ParseConvertArgs([]string{"-formats", "pdf"}); err == nil {
+		t.Error("Expected an error without a resume")
+	}
+
+	// Test case 4: Only one resume can be converted at a time
+	if _, err := ParseConvertArgs([]string{resumePath, resumePath}); err == nil {
+		t.Error("Expected an error for a second resume")
+	}
+
+	// Test case 5: A missing resume is an error
+	if _, err := ParseConvertArgs([]string{filepath.Join(dir, "missing.md")}); err == nil {
+		t.Error("Expected an error for a missing resume")
+	}
+
+	// Test case 6: An empty resume is an error
+	emptyPath := filepath.Join(dir, "empty.md")
+	if err := os.WriteFile(emptyPath, []byte("\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseConvertArgs([]string{emptyPath}); err == nil {
+		t.Error("Expected an error for an empty resume")
+	}
+}
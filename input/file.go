@@ -1,26 +1,88 @@
 package input
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/phrazzld/resumake/vault"
 )
 
-// MaxFileSize is the maximum allowed file size in bytes (10MB).
+// DefaultMaxFileSize is the default maximum file size in bytes (10MB).
+const DefaultMaxFileSize int64 = 10 * 1024 * 1024
+
+// MaxFileSize is the maximum allowed file size in bytes.
 // Files larger than this limit will be rejected to prevent memory issues.
-const MaxFileSize = 10 * 1024 * 1024
+// It is set from -max-file-size or the max-file-size setting.
+var MaxFileSize = DefaultMaxFileSize
+
+// DefaultFileExtensions lists the file extensions supported by default.
+var DefaultFileExtensions = []string{".txt", ".md", ".markdown"}
 
 // SupportedFileExtensions contains the allowed file extensions for resume files.
 // The application will warn but not block if the file has a different extension.
-var SupportedFileExtensions = []string{".txt", ".md", ".markdown"}
+// It is set from -extensions or the extensions setting.
+var SupportedFileExtensions = slices.Clone(DefaultFileExtensions)
+
+// utf8BOM is the byte order mark some editors write at the start of UTF-8 files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// BinaryFileError is returned by ReadSourceFile when a file does not contain
+// UTF-8 text, such as a PDF, a Word document, or a UTF-16 export. Sending such
+// bytes to the model produces nonsense, so the file is rejected with a hint
+// on how to convert it.
+type BinaryFileError struct {
+	Path   string // The file that was rejected
+	Reason string // What was detected, such as "is a PDF document"
+	Hint   string // How to convert the file to text
+}
+
+// Error returns a message describing the problem and how to fix it.
+func (e *BinaryFileError) Error() string {
+	return fmt.Sprintf("%s is not a text file: it %s. %s", e.Path, e.Reason, e.Hint)
+}
+
+// detectBinaryContent inspects file content and returns a BinaryFileError
+// describing why it is not UTF-8 text, or nil if it is.
+func detectBinaryContent(filePath string, content []byte) *BinaryFileError {
+	const textHint = "Convert it to a UTF-8 plain text (.txt) or Markdown (.md) file and try again."
+
+	switch {
+	case bytes.HasPrefix(content, []byte("%PDF")):
+		return &BinaryFileError{Path: filePath, Reason: "is a PDF document",
+			Hint: "Export it as text (for example with pdftotext) or copy its contents into a .txt file."}
+	case bytes.HasPrefix(content, []byte("PK\x03\x04")):
+		return &BinaryFileError{Path: filePath, Reason: "is a ZIP archive, such as a .docx or .odt document",
+			Hint: "Give a Word document a .docx extension to import its text, or save it as plain text (.txt) or Markdown from your word processor."}
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}) || bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		return &BinaryFileError{Path: filePath, Reason: "is UTF-16 encoded", Hint: textHint}
+	case bytes.IndexByte(content, 0) >= 0:
+		return &BinaryFileError{Path: filePath, Reason: "contains NUL bytes", Hint: textHint}
+	case !utf8.Valid(content):
+		return &BinaryFileError{Path: filePath, Reason: "is not valid UTF-8 (unknown encoding)", Hint: textHint}
+	}
+	return nil
+}
 
 // ReadSourceFile reads the content of a file at the given path.
 // It performs several validation checks before reading the file:
 // - Verifies the file exists and is accessible
 // - Confirms it's a regular file (not a directory or special file)
 // - Ensures the file size is within the maximum allowed limit
-// - Warns if the file extension is not in the supported list
+// - Rejects content that is not UTF-8 text with a *BinaryFileError
+//
+// The content is converted to text by the Importer registered for the
+// file's extension, so Word documents, JSON, CSV and ZIP archives are read
+// as their text; files with other extensions are read as text.
+//
+// An unsupported extension does not stop the file from being read; callers
+// report ExtensionWarning where it will be seen, which in the TUI is on screen
+// rather than printed over it.
 //
 // Parameters:
 //   - filePath: The path to the file to read
@@ -32,6 +94,10 @@ var SupportedFileExtensions = []string{".txt", ".md", ".markdown"}
 // Example:
 //
 //	content, err := input.ReadSourceFile("my_resume.md")
+//	var binaryErr *input.BinaryFileError
+//	if errors.As(err, &binaryErr) {
+//	    fmt.Println(binaryErr.Hint)
+//	}
 //	if err != nil {
 //	    log.Fatalf("Error reading source file: %v", err)
 //	}
@@ -40,6 +106,9 @@ func ReadSourceFile(filePath string) (string, error) {
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			if abs := ResolvePath(filePath); abs != filePath {
+				return "", fmt.Errorf("file does not exist: %s (looked for %s)", filePath, abs)
+			}
 			return "", fmt.Errorf("file does not exist: %s", filePath)
 		}
 		return "", fmt.Errorf("error accessing file %s: %w", filePath, err)
@@ -52,33 +121,104 @@ func ReadSourceFile(filePath string) (string, error) {
 	
 	// Check file size
 	if fileInfo.Size() > MaxFileSize {
-		return "", fmt.Errorf("file size exceeds the maximum allowed size of %d bytes: %s", MaxFileSize, filePath)
+		return "", fileSizeError(filePath)
 	}
 	
-	// Check file extension
-	ext := strings.ToLower(filepath.Ext(filePath))
-	validExtension := false
-	for _, supported := range SupportedFileExtensions {
-		if ext == supported {
-			validExtension = true
-			break
-		}
+	// Read the file content
+	contentBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading file %s: %w", filePath, err)
 	}
 	
-	// Only warn about extension, don't block
-	if !validExtension {
-		fmt.Printf("Warning: %s has an unsupported file extension. Supported extensions are: %s\n", 
-			filePath, strings.Join(SupportedFileExtensions, ", "))
+	// Resumes saved to the history may be encrypted; they are opened with their key
+	contentBytes, err = vault.Open(contentBytes)
+	if err != nil {
+		return "", fmt.Errorf("error reading file %s: %w", filePath, err)
 	}
 	
-	// Read the file content
-	contentBytes, err := os.ReadFile(filePath)
+	// Convert the content with the importer for its format; text importers
+	// refuse binary content instead of sending garbage bytes into the prompt
+	importer, _ := ImporterFor(filePath)
+	return importer.Import(filePath, contentBytes)
+}
+
+// ExtensionWarning returns a warning when the file's extension is neither one
+// of SupportedFileExtensions nor that of a format with its own Importer, such
+// as .docx, or an empty string when it is. Such files are still read; the warning only tells the
+// user the content may not be a resume.
+//
+// Parameters:
+//   - filePath: The path of the file
+//
+// Returns:
+//   - string: The warning, or "" for a supported extension
+//
+// Example:
+//
+//	if warning := input.ExtensionWarning("resume.rtf"); warning != "" {
+//	    log.Printf("Warning: %s", warning)
+//	}
+func ExtensionWarning(filePath string) string {
+	supported := append(slices.Clone(SupportedFileExtensions), formatExtensions()...)
+	if slices.Contains(supported, strings.ToLower(filepath.Ext(filePath))) {
+		return ""
+	}
+	return fmt.Sprintf("%s has an unsupported file extension. Supported extensions are: %s",
+		filePath, strings.Join(supported, ", "))
+}
+
+// fileSizeError describes a file over MaxFileSize.
+func fileSizeError(filePath string) error {
+	return fmt.Errorf("file size exceeds the maximum allowed size of %d bytes (%s): %s", MaxFileSize, FormatFileSize(MaxFileSize), filePath)
+}
+
+// PDFMIMEType is the MIME type of PDF source files, which are uploaded to the
+// model as documents instead of read as text.
+const PDFMIMEType = "application/pdf"
+
+// TextMIMEType is the MIME type used when a large text source is uploaded.
+const TextMIMEType = "text/plain"
+
+// DetectPDF reports whether the file at filePath is a PDF document, judged
+// by its content rather than its extension. PDFs cannot be read as text, so
+// they are uploaded to the model instead; a PDF larger than MaxFileSize is
+// an error. Files that cannot be read are reported as not being PDFs, so
+// ReadSourceFile can describe the problem.
+//
+// Parameters:
+//   - filePath: The path to the file to inspect
+//
+// Returns:
+//   - bool: True if the file is a PDF document
+//   - error: An error if the PDF exceeds MaxFileSize
+//
+// Example:
+//
+//	isPDF, err := input.DetectPDF("resume.pdf")
+//	if err == nil && !isPDF {
+//	    content, err = input.ReadSourceFile("resume.pdf")
+//	}
+func DetectPDF(filePath string) (bool, error) {
+	file, err := os.Open(filePath)
 	if err != nil {
-		return "", fmt.Errorf("error reading file %s: %w", filePath, err)
+		return false, nil
+	}
+	defer file.Close()
+	
+	fileInfo, err := file.Stat()
+	if err != nil || !fileInfo.Mode().IsRegular() {
+		return false, nil
 	}
 	
-	// Convert to string and return
-	return string(contentBytes), nil
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(file, header); err != nil || !bytes.Equal(header, []byte("%PDF")) {
+		return false, nil
+	}
+	
+	if fileInfo.Size() > MaxFileSize {
+		return true, fileSizeError(filePath)
+	}
+	return true, nil
 }
 
 // ReadSourceFileFromFlags reads a source file if one is specified in the flags.
@@ -1,6 +1,7 @@
 package input
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -144,6 +145,12 @@ func TestReadSourceFile(t *testing.T) {
 		if !strings.Contains(err.Error(), nonExistentPath) {
 			t.Errorf("Expected error message to contain file path, got: %v", err)
 		}
+		
+		// A relative path is reported with the absolute path looked for
+		_, err = ReadSourceFile("non-existent-file.md")
+		if err == nil || !strings.Contains(err.Error(), "(looked for "+ResolvePath("non-existent-file.md")+")") {
+			t.Errorf("Expected error message to contain the absolute path, got: %v", err)
+		}
 	})
 
 	// Test case 3: Empty file
@@ -193,11 +200,13 @@ func TestReadSourceFile(t *testing.T) {
 		}
 	})
 	
-	// Test case 5: Unsupported file extension (should still work but print warning)
+	// Test case 5: Unsupported file extension (should still work, without printing over the TUI)
 	t.Run("Unsupported file extension", func(t *testing.T) {
-		// Redirect stdout to capture warning
+		// Redirect stdout to check nothing is printed
 		r, w, _ := os.Pipe()
+		stdout := os.Stdout
 		os.Stdout = w
+		defer func() { os.Stdout = stdout }()
 		
 		// Create a temporary file with unsupported extension
 		testContent := "This is a test resume content."
@@ -226,9 +235,12 @@ func TestReadSourceFile(t *testing.T) {
 			t.Errorf("Expected content %q, got %q", testContent, content)
 		}
 		
-		// Verify warning was printed
-		if !strings.Contains(output, "Warning") || !strings.Contains(output, "unsupported file extension") {
-			t.Errorf("Expected warning about unsupported file extension, got: %q", output)
+		// Verify nothing was printed; the warning is reported by the caller instead
+		if output != "" {
+			t.Errorf("Expected no output, got: %q", output)
+		}
+		if warning := ExtensionWarning(unsupportedFilePath); !strings.Contains(warning, "unsupported file extension") {
+			t.Errorf("Expected warning about unsupported file extension, got: %q", warning)
 		}
 	})
 	
@@ -252,5 +264,87 @@ func TestReadSourceFile(t *testing.T) {
 		if !strings.Contains(err.Error(), "not a regular file") {
 			t.Errorf("Expected error about non-regular file, got: %v", err)
 		}
+	})	
+	// Test case 7: Binary and non-UTF-8 content is rejected with a typed error
+	t.Run("Binary content", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			data   []byte
+			reason string
+		}{
+			{"pdf", []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3"), "PDF"},
+			{"docx", []byte("PK\x03\x04\x14\x00\x06\x00"), "ZIP archive"},
+			{"utf16", []byte{0xFF, 0xFE, 'J', 0, 'a', 0}, "UTF-16"},
+			{"nul bytes", []byte("Jane\x00Doe"), "NUL bytes"},
+			{"latin-1", []byte("Caf\xe9 manager"), "not valid UTF-8"},
+		}
+		
+		for _, tt := range tests {
+			filePath := filepath.Join(tempDir, tt.name+".txt")
+			if err := os.WriteFile(filePath, tt.data, 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+			
+			content, err := ReadSourceFile(filePath)
+			var binaryErr *BinaryFileError
+			if !errors.As(err, &binaryErr) {
+				t.Errorf("%s: expected a BinaryFileError, got %v", tt.name, err)
+				continue
+			}
+			if content != "" || binaryErr.Path != filePath || !strings.Contains(binaryErr.Reason, tt.reason) || binaryErr.Hint == "" {
+				t.Errorf("%s: unexpected result %q, %+v", tt.name, content, binaryErr)
+			}
+		}
 	})
-}
\ No newline at end of file
+	
+	// Test case 8: A UTF-8 byte order mark is dropped
+	t.Run("UTF-8 byte order mark", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "bom.md")
+		if err := os.WriteFile(filePath, []byte("\xef\xbb\xbf# Jane Doe"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		
+		content, err := ReadSourceFile(filePath)
+		if err != nil || content != "# Jane Doe" {
+			t.Errorf("Expected BOM to be stripped, got %q, %v", content, err)
+		}
+	})
+}
+func TestDetectPDF(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected bool
+	}{
+		{"PDF content", write("resume.pdf", "%PDF-1.7\n..."), true},
+		{"PDF content with another extension", write("resume.txt", "%PDF-1.4"), true},
+		{"Text with a PDF extension", write("notes.pdf", "Jane Doe"), false},
+		{"Shorter than the header", write("short.md", "%P"), false},
+		{"Missing file", filepath.Join(tempDir, "missing.pdf"), false},
+		{"Directory", tempDir, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectPDF(tt.path)
+			if err != nil || got != tt.expected {
+				t.Errorf("DetectPDF(%q) = %v, %v; want %v", tt.path, got, err, tt.expected)
+			}
+		})
+	}
+
+	// A PDF over the size limit is rejected
+	large := write("large.pdf", "%PDF"+strings.Repeat("x", int(MaxFileSize)))
+	if isPDF, err := DetectPDF(large); !isPDF || err == nil {
+		t.Errorf("Expected an oversized PDF to be an error, got %v, %v", isPDF, err)
+	}
+}
@@ -7,8 +7,15 @@
 package input
 
 import (
-	"flag"
-	"os"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/output"
+	"github.com/spf13/pflag"
 )
 
 // Flags represents the command-line flags accepted by the application.
@@ -20,25 +27,375 @@ type Flags struct {
 
 	// OutputPath holds the path where the generated resume will be written.
 	// If not provided, a default path will be used.
+	// When -output is repeated, it holds the first value.
 	OutputPath string
+
+	// OutputPaths holds every -output value, in order. Each one names a file
+	// to write, in the format given by its extension.
+	OutputPaths []string
+
+	// LegacyOutput writes the resume to resume_out.md when no output path is
+	// given, instead of a new file named after the candidate and date.
+	LegacyOutput bool
+
+	// Formats holds the comma-separated formats (md, html, json, docx, pdf, odt)
+	// to write next to the output path.
+	Formats string
+
+	// Bundle requests a matching cover letter alongside the resume.
+	// Both documents are written to a dated directory.
+	Bundle bool
+
+	// JobPath holds the path to an optional job description file.
+	// When provided, the analysis view compares resume keywords against it.
+	JobPath string
+
+	// Company holds an optional company name or job posting URL. When set,
+	// the employer is researched first and the resume is tailored to its language.
+	Company string
+
+	// Emphasize holds comma-separated keywords the resume should feature
+	// where the inputs support them. Keywords missing from the result are reported.
+	Emphasize string
+
+	// Grounding holds the strict grounding mode: off, flag to list the
+	// bullets that cite none of the inputs, or drop to leave them out.
+	Grounding string
+
+	// Explain asks the model why it made the major changes and saves its
+	// answer to a notes file next to the resume.
+	Explain bool
+
+	// Gaps asks the model how to close the gaps between the resume and the
+	// -job description, and saves its courses, certifications, and projects
+	// to an appendix next to the resume.
+	Gaps bool
+
+	// Documents holds comma-separated documents to write from the same
+	// inputs after the resume, each saved next to it: thank-you,
+	// follow-up, or linkedin. An empty value writes none.
+	Documents string
+
+	// Review shows the generated resume one section at a time to accept,
+	// edit, regenerate, or leave out, and saves only the accepted sections.
+	Review bool
+
+	// Amend uses the previously generated resume as the source and treats
+	// the notes typed as updates to it, instead of starting from scratch.
+	Amend bool
+
+	// NoMaster leaves out the master history saved by merge-sources, which
+	// is otherwise the source when neither -source nor -amend is given.
+	NoMaster bool
+
+	// FallbackModel holds the model retried once if the primary model fails.
+	// An empty value disables the retry.
+	FallbackModel string
+
+	// Layout holds the optional HTML layout name (standard, two-column, or compact).
+	// When set, an HTML version of the resume is written next to the Markdown file.
+	Layout string
+
+	// Timeline adds a timeline of roles and education to the HTML resume.
+	// It requires Layout or an HTML output path.
+	Timeline bool
+
+	// PageBreaks marks where pages are estimated to break in the HTML resume
+	// and warns where a break splits a role's bullets. It requires Layout or
+	// an HTML output path.
+	PageBreaks bool
+
+	// QR holds the URL, such as a LinkedIn profile or portfolio, encoded in a
+	// QR code in the header of the HTML and PDF resumes. When empty, the qr
+	// setting is used, and "none" leaves the code out.
+	QR string
+
+	// QRLayouts holds the comma-separated layouts that show the QR code.
+	// When empty, the qr-layouts setting is used, or every layout.
+	QRLayouts string
+
+	// JSONResume requests a JSON Resume export next to the Markdown file.
+	// Schema violations are fixed in the TUI before the file is written.
+	JSONResume bool
+
+	// RecordDir holds the directory API responses are recorded to as fixtures.
+	RecordDir string
+
+	// ReplayDir holds the directory of recorded fixtures replayed instead of calling the API.
+	ReplayDir string
+
+	// TrimOrder holds the comma-separated order in which input is trimmed
+	// when the prompt does not fit the model's context window.
+	// An empty value uses the default order.
+	TrimOrder string
+
+	// Exports holds the comma-separated document formats (docx, pdf, odt)
+	// to convert the resume to, using pandoc when it is installed.
+	Exports string
+
+	// OutputMode holds the octal permission mode of written files, such as
+	// "0600". The umask still applies to new files.
+	OutputMode string
+
+	// LineEndings holds the line endings of the written resume and the text
+	// files saved next to it: lf, crlf, or auto for the operating system's.
+	LineEndings string
+	
+	// Wrap holds the column the prose of the resume and cover letter is
+	// wrapped at, one sentence per line, so changes to a resume kept in
+	// version control can be reviewed line by line. 0 keeps the model's lines.
+	Wrap int
+
+	// MaxFileSize holds the largest source or job description file to read,
+	// such as "20MB". An empty value keeps the settings file or default limit.
+	MaxFileSize string
+
+	// Extensions holds the comma-separated file extensions expected for
+	// source files. Other files are still read, with a warning. An empty
+	// value keeps the settings file or default extensions.
+	Extensions string
+
+	// Plain asks for the inputs with line prompts instead of the full-screen
+	// interface, for screen readers and terminals where it misbehaves.
+	Plain bool
+
+	// Dashboard starts on the workspace dashboard, which lists the resumes
+	// saved in the history. It is set by the dashboard command, not a flag.
+	Dashboard bool
+
+	// Pack zips the resume files, the job description, and a metadata file
+	// into one dated archive per application. It implies a PDF export and
+	// an HTML resume.
+	Pack bool
+
+	// CheckKey checks the API key with a lightweight API request on startup,
+	// telling an invalid or expired key from an unreachable API.
+	CheckKey bool
+
+	// Renderer selects how the generated resume is shown from the success
+	// screen: styled, plain, pager (using PAGER), or a pager command such
+	// as "bat". An empty value uses the styled renderer.
+	Renderer string
+
+	// MaxDuration stops generation after this long. When the response is
+	// streaming, the complete sections received are kept and the rest are
+	// marked TODO. Zero means no limit.
+	MaxDuration time.Duration
+
+	// Preset holds the kind of candidate the resume is written for: standard
+	// or new-grad, which leads with education, projects, and internships.
+	// An empty value uses the standard preset.
+	Preset string
+
+	// Audience holds who reads the resume first: general, ats, recruiter, or
+	// hiring-manager. An empty value uses the general audience.
+	Audience string
+
+	// GPA holds the grade point average to show in the Education section,
+	// such as "3.8" or "3.8/4.0".
+	GPA string
+
+	// Coursework holds comma-separated relevant courses to list in the
+	// Education section.
+	Coursework string
+
+	// Seed holds the sampling seed for deterministic generation. When set,
+	// every request is sent with it and a temperature of 0. An empty value
+	// samples as usual.
+	Seed string
+
+	// InjectError holds a failure to force for testing error handling, such
+	// as "quota" or "safety@cover-letter" (see faults.Parse). The flag is
+	// hidden from the help, since it is only useful for developing resumake.
+	InjectError string
+}
+
+// Bind defines the flags of resume generation on fs, storing their values
+// in f. They are the flags of the generate command, which also runs when
+// resumake is started without a command.
+//
+// Parameters:
+//   - fs: The flag set of the generate command
+//
+// Example:
+//
+//	var flags input.Flags
+//	fs := input.NewFlagSet("resumake")
+//	flags.Bind(fs)
+func (f *Flags) Bind(fs *pflag.FlagSet) {
+	// Define the source flag
+	fs.StringVar(&f.SourcePath, "source", "", "Optional path to existing resume file (txt or md)")
+	
+	// Define the output flag, which may be repeated to write several formats
+	fs.StringArrayVar(&f.OutputPaths, "output", nil, "Path for the output resume file (default: Name_Resume_YYYY-MM-DD.md), or - for standard output; repeat to also write .html, .json, .docx, .pdf, or .odt files")
+	
+	// Define the legacy output flag
+	fs.BoolVar(&f.LegacyOutput, "legacy-output", false, "Write to resume_out.md when -output is not given, instead of a file named after you and the date")
+	
+	// Define the formats flag
+	fs.StringVar(&f.Formats, "formats", "", "Formats to write next to the output path: md, html, json, docx, pdf, odt (comma-separated)")
+	
+	// Define the bundle flag
+	fs.BoolVar(&f.Bundle, "bundle", false, "Also generate a matching cover letter and write both to a dated directory")
+	
+	// Define the job description flag
+	fs.StringVar(&f.JobPath, "job", "", "Optional path to a job description file for keyword analysis")
+	
+	// Define the company flag
+	fs.StringVar(&f.Company, "company", "", "Company name or job posting URL to research and tailor the resume's language to")
+	
+	// Define the emphasize flag
+	fs.StringVar(&f.Emphasize, "emphasize", "", "Keywords to feature where your experience supports them, e.g. \"kubernetes,leadership,grpc\" (comma-separated)")
+	
+	// Define the grounding flag
+	fs.StringVar(&f.Grounding, "grounding", "", "Make every bullet cite the input sentences it comes from: flag to list the bullets that cite none, drop to leave them out, or off (default: off)")
+	
+	// Define the explain flag
+	fs.BoolVar(&f.Explain, "explain", false, "Also ask why the major changes were made and save the notes next to the resume")
+	
+	// Define the gaps flag
+	fs.BoolVar(&f.Gaps, "gaps", false, "Also suggest courses, certifications, and projects that close the gaps with the -job description, saved next to the resume")
+	
+	// Define the documents flag
+	fs.StringVar(&f.Documents, "documents", "", "Comma-separated documents to also write from the same inputs and save next to the resume: thank-you (email), follow-up (note), linkedin (summary)")
+	
+	// Define the review flag
+	fs.BoolVar(&f.Review, "review", false, "Accept, edit, or regenerate each section of the resume before it is saved")
+	
+	// Define the amend flag
+	fs.BoolVar(&f.Amend, "amend", false, "Update your previous resume (the -output file, or else the last one generated) with the notes you type")
+	
+	// Define the no-master flag
+	fs.BoolVar(&f.NoMaster, "no-master", false, "Do not use the master history saved by merge-sources as the source")
+	
+	// Define the fallback model flag
+	fs.StringVar(&f.FallbackModel, "fallback-model", api.DefaultFallbackModelName, "Model to retry with if the primary model fails (empty to disable)")
+	
+	// Define the layout flag
+	fs.StringVar(&f.Layout, "layout", "", "Also write an HTML resume with this layout: standard, two-column, or compact")
+	
+	// Define the timeline flag
+	fs.BoolVar(&f.Timeline, "timeline", false, "Add a timeline of roles and education to the HTML resume (requires -layout or an HTML output)")
+	
+	// Define the page-break preview flag
+	fs.BoolVar(&f.PageBreaks, "page-breaks", false, "Mark estimated page breaks in the HTML resume and warn where they split a role's bullets (requires -layout or an HTML output)")
+	
+	// Define the QR code flags
+	fs.StringVar(&f.QR, "qr", "", "URL, such as your LinkedIn or portfolio, to link with a QR code in the HTML and PDF header (default: the qr setting; none to leave it out)")
+	fs.StringVar(&f.QRLayouts, "qr-layouts", "", "Comma-separated layouts that show the QR code; PDF exports follow standard (default: the qr-layouts setting, or all)")
+	
+	// Define the JSON Resume flag
+	fs.BoolVar(&f.JSONResume, "json", false, "Also write the resume in JSON Resume format, fixing any schema violations first")
+	
+	// Define the fixture flags
+	fs.StringVar(&f.RecordDir, "record", "", "Record API responses as fixtures in this directory")
+	fs.StringVar(&f.ReplayDir, "replay", "", "Replay API responses from fixtures in this directory instead of calling the API")
+	
+	// Define the trim order flag
+	fs.StringVar(&f.TrimOrder, "trim-order", "", "Order to trim input that exceeds the context window (default: condense-roles,drop-roles,drop-sections,trim-notes)")
+	
+	// Define the export flag
+	fs.StringVar(&f.Exports, "export", "", "Also convert the resume to these formats: docx, pdf, odt (comma-separated; uses pandoc when installed)")
+	
+	// Define the output mode flag
+	fs.StringVar(&f.OutputMode, "output-mode", fmt.Sprintf("%04o", output.DefaultFileMode), "Permission mode of written files, in octal (the umask still applies)")
+	
+	// Define the line endings flag
+	fs.StringVar(&f.LineEndings, "line-endings", output.LineEndingsAuto, "Line endings of the resume and the text files saved with it: lf, crlf, or auto for the operating system's")
+	
+	// Define the prose wrapping flag
+	fs.IntVar(&f.Wrap, "wrap", 0, "Write each sentence of the resume on its own line, wrapped at this column, for readable diffs in version control (default: 0, the model's lines)")
+	
+	// Define the file limit flags
+	fs.StringVar(&f.MaxFileSize, "max-file-size", "", fmt.Sprintf("Largest source or job description file to read, e.g. 20MB (default: %s)", FormatFileSize(DefaultMaxFileSize)))
+	fs.StringVar(&f.Extensions, "extensions", "", fmt.Sprintf("File extensions expected for source files; others are read with a warning (default: %s)", strings.Join(DefaultFileExtensions, ",")))
+	
+	// Define the plain mode flag
+	fs.BoolVar(&f.Plain, "plain", false, "Ask for your inputs with line prompts instead of the full-screen interface (also used when TERM is dumb)")
+	
+	// Define the pack flag
+	fs.BoolVar(&f.Pack, "pack", false, "Also zip the Markdown, PDF, and HTML resumes, the job description, and a metadata file into one dated archive")
+	
+	// Define the key check flag
+	fs.BoolVar(&f.CheckKey, "check-key", false, "Check the API key with a quick API request on startup and explain what is wrong with it")
+	
+	// Define the preview renderer flag
+	fs.StringVar(&f.Renderer, "renderer", "", "How to show the resume when you press V after generating: styled, plain, pager (uses PAGER), or a pager command such as bat (default: styled)")
+	
+	// Define the time budget flag
+	fs.DurationVar(&f.MaxDuration, "max-duration", 0, "Stop generating after this long, such as 45s, keeping the complete sections received and marking the rest TODO (default: no limit)")
+	
+	// Define the new-grad preset flags
+	fs.StringVar(&f.Preset, "preset", "", "Kind of resume to write: standard, or new-grad to lead with education, projects, and internships (default: standard)")
+	fs.StringVar(&f.GPA, "gpa", "", "GPA to show in the Education section, such as 3.8 or 3.8/4.0")
+	fs.StringVar(&f.Coursework, "coursework", "", "Relevant courses to list in the Education section, e.g. \"Algorithms,Operating Systems\" (comma-separated)")
+	
+	// Define the audience flag
+	fs.StringVar(&f.Audience, "audience", "", "Who reads the resume first: general, ats for keyword coverage and plain formatting, recruiter for scannability, or hiring-manager for depth and impact (default: general)")
+	
+	// Define the deterministic generation flag
+	fs.StringVar(&f.Seed, "seed", "", "Generate reproducibly with this integer seed and a temperature of 0; the seed is saved in the -pack metadata")
+	
+	// Define the hidden failure injection flag for exercising error paths
+	fs.StringVar(&f.InjectError, "inject-error", "", "Force a failure to test error handling: quota, safety, truncation, or write-permission, optionally followed by @stage")
+	_ = fs.MarkHidden("inject-error")
 }
 
-// ParseFlags parses the command-line flags from os.Args and returns the results.
-// This is a convenience wrapper around ParseFlagsWithArgs using the program's
-// actual command-line arguments.
+// Complete finishes the flags once they are parsed, expanding ~ and
+// environment variables in the source and output paths. Resume generation
+// takes no positional arguments; its inputs are given with flags or typed in.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
 //
 // Returns:
-//   - Flags: The parsed flag values
-//   - error: Any error that occurred during parsing
+//   - error: An error if there are positional arguments
+func (f *Flags) Complete(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument %q; pass an existing resume with -source", args[0])
+	}
+	if f.MaxDuration < 0 {
+		return fmt.Errorf("invalid -max-duration %s; it must be positive", f.MaxDuration)
+	}
+	if f.Wrap < 0 || (f.Wrap > 0 && f.Wrap < output.MinProseWidth) {
+		return fmt.Errorf("invalid -wrap %d; use a column of at least %d, or 0 to keep the model's lines", f.Wrap, output.MinProseWidth)
+	}
+	if _, _, err := f.SamplingSeed(); err != nil {
+		return err
+	}
+	
+	// Quoted paths reach resumake with ~ and variables unexpanded
+	f.SourcePath = ExpandPath(f.SourcePath)
+	f.JobPath = ExpandPath(f.JobPath)
+	for i, path := range f.OutputPaths {
+		f.OutputPaths[i] = ExpandPath(path)
+	}
+	
+	// OutputPath is the first -output, which names the Markdown file
+	f.OutputPath = ""
+	if len(f.OutputPaths) > 0 {
+		f.OutputPath = f.OutputPaths[0]
+	}
+	return nil
+}
+
+// Tailor targets the resume at one job, as the tailor command does: a job
+// posting URL is researched as -company is, and anything else is read as
+// the -job description file once Complete expands it.
+//
+// Parameters:
+//   - job: A job description file or job posting URL
 //
 // Example:
 //
-//	flags, err := input.ParseFlags()
-//	if err != nil {
-//	    log.Fatalf("Error parsing flags: %v", err)
-//	}
-func ParseFlags() (Flags, error) {
-	return ParseFlagsWithArgs(os.Args[1:])
+//	flags.Tailor("https://example.com/jobs/123")
+//	// flags.Company == "https://example.com/jobs/123"
+func (f *Flags) Tailor(job string) {
+	if IsURL(job) {
+		f.Company = strings.TrimSpace(job)
+		return
+	}
+	f.JobPath = job
 }
 
 // ParseFlagsWithArgs parses the given arguments to extract flag values.
@@ -58,25 +415,59 @@ func ParseFlags() (Flags, error) {
 //	flags, err := input.ParseFlagsWithArgs(testArgs)
 func ParseFlagsWithArgs(args []string) (Flags, error) {
 	var flags Flags
-	
-	// Create a new flag set
-	fs := flag.NewFlagSet("resumake", flag.ContinueOnError)
-	
-	// Define the source flag
-	sourcePath := fs.String("source", "", "Optional path to existing resume file (txt or md)")
-	
-	// Define the output flag
-	outputPath := fs.String("output", "", "Path for the output resume file (default: resume_out.md)")
-	
-	// Parse the flags
-	err := fs.Parse(args)
-	if err != nil {
+	fs := NewFlagSet("resumake")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
 		return flags, err
 	}
-	
-	// Set the flags struct values
-	flags.SourcePath = *sourcePath
-	flags.OutputPath = *outputPath
-	
-	return flags, nil
-}
\ No newline at end of file
+	return flags, flags.Complete(fs.Args())
+}
+
+// SamplingSeed returns the seed selected by the -seed flag.
+//
+// Returns:
+//   - int64: The seed
+//   - bool: Whether a seed was given
+//   - error: An error if the seed is not an integer
+//
+// Example:
+//
+//	if seed, ok, _ := flags.SamplingSeed(); ok {
+//	    model = model.WithSeed(seed)
+//	}
+func (f Flags) SamplingSeed() (int64, bool, error) {
+	value := strings.TrimSpace(f.Seed)
+	if value == "" {
+		return 0, false, nil
+	}
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid -seed %q; it must be an integer", f.Seed)
+	}
+	return seed, true, nil
+}
+
+// Fixtures returns the API record or replay settings selected by the
+// -record and -replay flags.
+//
+// Returns:
+//   - api.Fixtures: The fixture settings (disabled when neither flag is set)
+//   - error: An error if both flags are set
+//
+// Example:
+//
+//	fixtures, err := flags.Fixtures()
+//	if err != nil {
+//	    log.Fatalf("Error parsing fixture flags: %v", err)
+//	}
+func (f Flags) Fixtures() (api.Fixtures, error) {
+	switch {
+	case f.RecordDir != "" && f.ReplayDir != "":
+		return api.Fixtures{}, errors.New("-record and -replay cannot be used together")
+	case f.RecordDir != "":
+		return api.Fixtures{Mode: api.FixtureRecord, Dir: f.RecordDir}, nil
+	case f.ReplayDir != "":
+		return api.Fixtures{Mode: api.FixtureReplay, Dir: f.ReplayDir}, nil
+	}
+	return api.Fixtures{}, nil
+}
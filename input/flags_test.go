@@ -1,7 +1,13 @@
 package input
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/spf13/pflag"
 )
 
 func TestParseFlags(t *testing.T) {
@@ -93,4 +99,511 @@ func TestParseFlags(t *testing.T) {
 			t.Errorf("Expected output path %q, got %q", expectedPath, flags.OutputPath)
 		}
 	})
-}
\ No newline at end of file
+	
+	// Test case 6: Bundle flag provided
+	t.Run("Bundle flag provided", func(t *testing.T) {
+		// Parse flags with the bundle flag
+		flags, err := ParseFlagsWithArgs([]string{"-bundle"})
+		
+		// Verify no error occurred
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		
+		// Verify bundle mode is enabled
+		if !flags.Bundle {
+			t.Error("Expected Bundle to be true")
+		}
+	})
+	
+	// Test case 7: Job description flag provided
+	t.Run("Job description flag provided", func(t *testing.T) {
+		// Parse flags with a job description path
+		flags, err := ParseFlagsWithArgs([]string{"-job", "job.txt"})
+		
+		// Verify no error occurred
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		
+		// Verify the job path was captured
+		if flags.JobPath != "job.txt" {
+			t.Errorf("Expected JobPath to be %q, got %q", "job.txt", flags.JobPath)
+		}
+	})
+	
+	// Test case 8: Fallback model defaults and overrides
+	t.Run("Fallback model flag", func(t *testing.T) {
+		// Without the flag the default fallback model is used
+		flags, err := ParseFlagsWithArgs([]string{})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.FallbackModel != api.DefaultFallbackModelName {
+			t.Errorf("Expected default fallback model %q, got %q", api.DefaultFallbackModelName, flags.FallbackModel)
+		}
+		
+		// An empty value disables the fallback
+		flags, err = ParseFlagsWithArgs([]string{"-fallback-model", ""})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.FallbackModel != "" {
+			t.Errorf("Expected fallback model to be disabled, got %q", flags.FallbackModel)
+		}
+	})
+	
+	// Test case 9: Layout flag provided
+	t.Run("Layout flag provided", func(t *testing.T) {
+		// Parse flags with a layout name
+		flags, err := ParseFlagsWithArgs([]string{"-layout", "two-column"})
+		
+		// Verify no error occurred
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		
+		// Verify the layout was captured
+		if flags.Layout != "two-column" {
+			t.Errorf("Expected Layout to be %q, got %q", "two-column", flags.Layout)
+		}
+	})
+	
+	// Test case 10: JSON Resume flag provided
+	t.Run("JSON Resume flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-json"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.JSONResume {
+			t.Error("Expected JSONResume to be true")
+		}
+	})
+	
+	// Test case 11: Fixture flags provided
+	t.Run("Fixture flags provided", func(t *testing.T) {
+		// Parse flags with record and replay directories
+		flags, err := ParseFlagsWithArgs([]string{"-record", "rec", "-replay", "fixtures"})
+		
+		// Verify no error occurred
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		
+		// Verify the directories were captured
+		if flags.RecordDir != "rec" || flags.ReplayDir != "fixtures" {
+			t.Errorf("Expected RecordDir %q and ReplayDir %q, got %q and %q", "rec", "fixtures", flags.RecordDir, flags.ReplayDir)
+		}
+	})
+
+	// Test case 12: Trim order flag provided
+	t.Run("Trim order flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-trim-order", "drop-sections,condense-roles"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.TrimOrder != "drop-sections,condense-roles" {
+			t.Errorf("Expected TrimOrder to be %q, got %q", "drop-sections,condense-roles", flags.TrimOrder)
+		}
+	})
+
+	// Test case 13: Timeline flag provided with a layout
+	t.Run("Timeline flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-layout", "standard", "-timeline"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.Timeline || flags.Layout != "standard" {
+			t.Errorf("Expected Timeline with the standard layout, got %v and %q", flags.Timeline, flags.Layout)
+		}
+	})
+
+	// Test case 14: Export formats provided
+	t.Run("Export flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-export", "docx,pdf"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.Exports != "docx,pdf" {
+			t.Errorf("Expected Exports to be %q, got %q", "docx,pdf", flags.Exports)
+		}
+	})
+	
+	// Test case 15: Output flag repeated
+	t.Run("Output flag repeated", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-output", "out.md", "-output", "out.pdf"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if want := []string{"out.md", "out.pdf"}; !reflect.DeepEqual(flags.OutputPaths, want) {
+			t.Errorf("Expected OutputPaths to be %v, got %v", want, flags.OutputPaths)
+		}
+		if flags.OutputPath != "out.md" {
+			t.Errorf("Expected OutputPath to be the first value, got %q", flags.OutputPath)
+		}
+	})
+	
+	// Test case 16: Formats flag provided
+	t.Run("Formats flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-formats", "md,pdf,html"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.Formats != "md,pdf,html" {
+			t.Errorf("Expected Formats to be %q, got %q", "md,pdf,html", flags.Formats)
+		}
+	})
+	
+	// Test case 17: Company flag provided
+	t.Run("Company flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-company", "https://acme.dev/jobs/1"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.Company != "https://acme.dev/jobs/1" {
+			t.Errorf("Expected Company to be %q, got %q", "https://acme.dev/jobs/1", flags.Company)
+		}
+	})
+	
+	// Test case 18: Legacy output flag provided
+	t.Run("Legacy output flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-legacy-output"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.LegacyOutput {
+			t.Error("Expected LegacyOutput to be true")
+		}
+	})
+	
+	// Test case 19: Emphasize flag provided
+	t.Run("Emphasize flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-emphasize", "kubernetes,leadership,grpc"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.Emphasize != "kubernetes,leadership,grpc" {
+			t.Errorf("Expected Emphasize to be %q, got %q", "kubernetes,leadership,grpc", flags.Emphasize)
+		}
+	})
+	
+	// Test case 20: Explain flag provided
+	t.Run("Explain flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-explain"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.Explain {
+			t.Error("Expected Explain to be true")
+		}
+	})
+	
+	// Test case 21: Output mode flag defaults to private files
+	t.Run("Output mode flag", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.OutputMode != "0600" {
+			t.Errorf("Expected OutputMode to default to %q, got %q", "0600", flags.OutputMode)
+		}
+		
+		flags, err = ParseFlagsWithArgs([]string{"-output-mode", "0640"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.OutputMode != "0640" {
+			t.Errorf("Expected OutputMode to be %q, got %q", "0640", flags.OutputMode)
+		}
+	})
+	
+	// Test case 22: Amend flag provided
+	t.Run("Amend flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-amend"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.Amend {
+			t.Error("Expected Amend to be true")
+		}
+	})
+	
+	// Test case 23: File limit flags provided
+	t.Run("File limit flags provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-max-file-size", "20MB", "-extensions", "txt,rst"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.MaxFileSize != "20MB" || flags.Extensions != "txt,rst" {
+			t.Errorf("Expected the file limits to be set, got %q and %q", flags.MaxFileSize, flags.Extensions)
+		}
+	})
+	
+	// Test case 24: Plain mode flag provided
+	t.Run("Plain mode flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-plain"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.Plain {
+			t.Error("Expected Plain to be true")
+		}
+	})
+	
+	// Test case 25: Pack flag provided
+	t.Run("Pack flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-pack", "-job", "job.txt"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.Pack || flags.JobPath != "job.txt" {
+			t.Errorf("Expected Pack with JobPath job.txt, got %v and %q", flags.Pack, flags.JobPath)
+		}
+	})
+	
+	// Test case 26: Key check flag provided
+	t.Run("Key check flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"--check-key"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.CheckKey {
+			t.Error("Expected CheckKey to be true")
+		}
+	})
+	
+	// Test case 27: Renderer flag provided
+	t.Run("Renderer flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-renderer", "bat"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.Renderer != "bat" {
+			t.Errorf("Expected Renderer bat, got %q", flags.Renderer)
+		}
+	})
+	
+	// Test case 28: Time limit provided, and a negative one rejected
+	t.Run("Max duration flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-max-duration", "45s"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.MaxDuration != 45*time.Second {
+			t.Errorf("Expected MaxDuration 45s, got %v", flags.MaxDuration)
+		}
+		if _, err := ParseFlagsWithArgs([]string{"-max-duration", "-5s"}); err == nil {
+			t.Error("Expected an error for a negative time limit")
+		}
+	})
+	
+	// Test case 29: New-grad preset with GPA and coursework
+	t.Run("Preset flags provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-preset", "new-grad", "-gpa", "3.8/4.0", "-coursework", "Algorithms,Databases"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.Preset != "new-grad" || flags.GPA != "3.8/4.0" || flags.Coursework != "Algorithms,Databases" {
+			t.Errorf("Expected the preset, GPA, and coursework, got %q, %q, %q", flags.Preset, flags.GPA, flags.Coursework)
+		}
+	})
+	
+	// Test case 30: Seed for deterministic generation
+	t.Run("Seed flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-seed", "-42"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if seed, ok, _ := flags.SamplingSeed(); !ok || seed != -42 {
+			t.Errorf("Expected seed -42, got %d (set %v)", seed, ok)
+		}
+		
+		if _, err := ParseFlagsWithArgs([]string{"-seed", "lucky"}); err == nil {
+			t.Error("Expected an error for a seed that is not an integer")
+		}
+		
+		if _, ok, _ := (Flags{}).SamplingSeed(); ok {
+			t.Error("Expected no seed when the flag is not given")
+		}
+	})
+	
+	// Test case 31: Audience for the prompt's emphasis
+	t.Run("Audience flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-audience", "hiring-manager"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.Audience != "hiring-manager" {
+			t.Errorf("Expected Audience to be 'hiring-manager', got '%s'", flags.Audience)
+		}
+	})
+	
+	// Test case 32: Hidden failure injection for developers
+	t.Run("Inject error flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-inject-error", "safety@cover-letter"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.InjectError != "safety@cover-letter" {
+			t.Errorf("Expected InjectError to be 'safety@cover-letter', got '%s'", flags.InjectError)
+		}
+		
+		fs := pflag.NewFlagSet("resumake", pflag.ContinueOnError)
+		(&Flags{}).Bind(fs)
+		if strings.Contains(fs.FlagUsages(), "inject-error") {
+			t.Error("Expected -inject-error to be hidden from the help")
+		}
+	})
+	
+	// Test case 33: ~ and environment variables in quoted paths are expanded
+	t.Run("Paths with home and variables provided", func(t *testing.T) {
+		t.Setenv("HOME", "/home/jane")
+		t.Setenv("RESUMES", "/srv/resumes")
+		flags, err := ParseFlagsWithArgs([]string{"-source", "~/resume.md", "-job", "${RESUMES}/job.txt", "-output", "$RESUMES/new.md", "-output", "-"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.SourcePath != "/home/jane/resume.md" {
+			t.Errorf("Expected SourcePath to be '/home/jane/resume.md', got '%s'", flags.SourcePath)
+		}
+		if flags.JobPath != "/srv/resumes/job.txt" {
+			t.Errorf("Expected JobPath to be '/srv/resumes/job.txt', got '%s'", flags.JobPath)
+		}
+		if want := []string{"/srv/resumes/new.md", "-"}; !reflect.DeepEqual(flags.OutputPaths, want) || flags.OutputPath != want[0] {
+			t.Errorf("Expected OutputPaths to be %v, got %v", want, flags.OutputPaths)
+		}
+	})
+	
+	// Test case 34: QR code URL and layouts
+	t.Run("QR code flags provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-qr", "linkedin.com/in/janedoe", "-qr-layouts", "standard,compact"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if flags.QR != "linkedin.com/in/janedoe" || flags.QRLayouts != "standard,compact" {
+			t.Errorf("Expected the QR flags to be set, got '%s' and '%s'", flags.QR, flags.QRLayouts)
+		}
+	})
+	
+	// Test case 35: Gaps flag provided
+	t.Run("Gaps flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-job", "job.txt", "-gaps"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.Gaps {
+			t.Error("Expected Gaps to be true")
+		}
+	})
+	
+	// Test case 36: Review flag provided
+	t.Run("Review flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-review"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.Review {
+			t.Error("Expected Review to be true")
+		}
+	})
+	
+	// Test case 37: Line endings default to auto and can be chosen
+	t.Run("Line endings flag", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{})
+		if err != nil || flags.LineEndings != "auto" {
+			t.Errorf("Expected auto line endings by default, got %q (%v)", flags.LineEndings, err)
+		}
+		flags, err = ParseFlagsWithArgs([]string{"-line-endings", "crlf"})
+		if err != nil || flags.LineEndings != "crlf" {
+			t.Errorf("Expected crlf line endings, got %q (%v)", flags.LineEndings, err)
+		}
+	})
+	
+	// Test case 38: No-master flag provided
+	t.Run("No-master flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-no-master"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.NoMaster {
+			t.Error("Expected NoMaster to be true")
+		}
+	})
+
+	// Test case 39: Page-breaks flag provided with a layout
+	t.Run("Page-breaks flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-layout", "compact", "-page-breaks"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !flags.PageBreaks || flags.Layout != "compact" {
+			t.Errorf("Expected PageBreaks with the compact layout, got %v and %q", flags.PageBreaks, flags.Layout)
+		}
+	})
+
+	// Test case 40: Wrap column provided, and one too narrow rejected
+	t.Run("Wrap flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-wrap", "80"})
+		if err != nil || flags.Wrap != 80 {
+			t.Errorf("Expected Wrap 80, got %d (%v)", flags.Wrap, err)
+		}
+		for _, column := range []string{"-1", "10"} {
+			if _, err := ParseFlagsWithArgs([]string{"-wrap", column}); err == nil {
+				t.Errorf("Expected an error for -wrap %s", column)
+			}
+		}
+	})
+
+	// Test case 41: Strict grounding mode provided
+	t.Run("Grounding flag provided", func(t *testing.T) {
+		flags, err := ParseFlagsWithArgs([]string{"-grounding", "drop"})
+		if err != nil || flags.Grounding != "drop" {
+			t.Errorf("Expected Grounding 'drop', got '%s' (%v)", flags.Grounding, err)
+		}
+	})
+}
+
+// TestFlagsFixtures tests converting the -record and -replay flags to fixture settings
+func TestFlagsFixtures(t *testing.T) {
+	testCases := []struct {
+		name    string
+		flags   Flags
+		want    api.Fixtures
+		wantErr bool
+	}{
+		{"Neither flag", Flags{}, api.Fixtures{}, false},
+		{"Record", Flags{RecordDir: "rec"}, api.Fixtures{Mode: api.FixtureRecord, Dir: "rec"}, false},
+		{"Replay", Flags{ReplayDir: "rec"}, api.Fixtures{Mode: api.FixtureReplay, Dir: "rec"}, false},
+		{"Both flags", Flags{RecordDir: "a", ReplayDir: "b"}, api.Fixtures{}, true},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fixtures, err := tc.flags.Fixtures()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Fixtures() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if fixtures != tc.want {
+				t.Errorf("Fixtures() = %+v, want %+v", fixtures, tc.want)
+			}
+		})
+	}
+}
+
+// TestFlagsTailor tests targeting the resume at a job description file or posting URL
+func TestFlagsTailor(t *testing.T) {
+	// Test case 1: A file is the job description
+	var flags Flags
+	t.Setenv("JOBS", "/tmp/jobs")
+	flags.Tailor("$JOBS/acme.txt")
+	if err := flags.Complete(nil); err != nil || flags.JobPath != "/tmp/jobs/acme.txt" || flags.Company != "" {
+		t.Errorf("Expected the expanded job path, got %q and company %q (%v)", flags.JobPath, flags.Company, err)
+	}
+	
+	// Test case 2: A URL is the posting to research
+	flags = Flags{}
+	flags.Tailor("https://example.com/jobs/123")
+	if flags.Company != "https://example.com/jobs/123" || flags.JobPath != "" {
+		t.Errorf("Expected the posting as the company, got %q and job path %q", flags.Company, flags.JobPath)
+	}
+}
@@ -0,0 +1,84 @@
+package input
+
+import (
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// NewFlagSet creates an empty flag set for a command's flags. Errors are
+// returned rather than printed, so the caller decides how to report them.
+//
+// Parameters:
+//   - name: The command the flags belong to, such as "resumake convert"
+//
+// Returns:
+//   - *pflag.FlagSet: The flag set
+func NewFlagSet(name string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.SortFlags = false
+	return fs
+}
+
+// NormalizeArgs rewrites long flags written with a single dash, such as
+// "-source resume.md", to the double-dash form the flag set parses. Resumake
+// has always accepted both, so scripts written for either keep working.
+// Shorthand flags such as "-n 5", flag values, and everything after "--"
+// are left as they are.
+//
+// Parameters:
+//   - args: The command-line arguments
+//   - fs: The flag set the arguments are parsed with
+//
+// Returns:
+//   - []string: The arguments with long flags in double-dash form
+//
+// Example:
+//
+//	args := input.NormalizeArgs([]string{"-source", "resume.md", "--plain"}, fs)
+//	// args == []string{"--source", "resume.md", "--plain"}
+func NormalizeArgs(args []string, fs *pflag.FlagSet) []string {
+	normalized := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		normalized = append(normalized, arg)
+		if arg == "--" {
+			return append(normalized, args[i+1:]...)
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+
+		var flag *pflag.Flag
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			flag = fs.Lookup(name)
+		case len(name) > 1 && isLongFlagName(name) && (fs.Lookup(name) != nil || fs.ShorthandLookup(name[:1]) == nil):
+			normalized[len(normalized)-1] = "-" + arg
+			flag = fs.Lookup(name)
+		case len(name) == 1:
+			flag = fs.ShorthandLookup(name)
+		}
+
+		// The next argument is this flag's value, even if it starts with a dash
+		if flag != nil && flag.NoOptDefVal == "" && !hasValue && i+1 < len(args) {
+			i++
+			normalized = append(normalized, args[i])
+		}
+	}
+	return normalized
+}
+
+// isLongFlagName reports whether name could be a long flag: it starts with a
+// letter and holds only letters, digits, and hyphens. Arguments such as
+// "-5" or "- Built X" are not flags.
+func isLongFlagName(name string) bool {
+	for i, r := range name {
+		letter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !letter && (i == 0 || (r != '-' && (r < '0' || r > '9'))) {
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,64 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeArgs(t *testing.T) {
+	fs := NewFlagSet("test")
+	fs.String("source", "", "")
+	fs.Bool("plain", false, "")
+	fs.IntP("count", "n", 3, "")
+	fs.Int("width", 80, "")
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "single-dash long flags",
+			args: []string{"-source", "resume.md", "-plain", "-width=100"},
+			want: []string{"--source", "resume.md", "--plain", "--width=100"},
+		},
+		{
+			name: "double-dash flags are kept",
+			args: []string{"--source", "resume.md", "--plain"},
+			want: []string{"--source", "resume.md", "--plain"},
+		},
+		{
+			name: "flag values are kept even when they start with a dash",
+			args: []string{"-source", "-resume.md", "-width", "-5"},
+			want: []string{"--source", "-resume.md", "--width", "-5"},
+		},
+		{
+			name: "shorthand flags are kept",
+			args: []string{"-n", "5", "-n5", "Built X"},
+			want: []string{"-n", "5", "-n5", "Built X"},
+		},
+		{
+			name: "unknown long flags are made long, so the error names them",
+			args: []string{"-invalid-flag"},
+			want: []string{"--invalid-flag"},
+		},
+		{
+			name: "arguments that are not flags are kept",
+			args: []string{"-", "- Built X", "-5%", "resume.md"},
+			want: []string{"-", "- Built X", "-5%", "resume.md"},
+		},
+		{
+			name: "nothing after -- is changed",
+			args: []string{"-plain", "--", "-source"},
+			want: []string{"--plain", "--", "-source"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeArgs(tt.args, fs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package input
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// importDOCX reads the text of a Word document, keeping its headings and
+// lists as Markdown. Formatting, tables' layout and images are dropped.
+func importDOCX(filePath string, content []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", fmt.Errorf("%s is not a Word document: %w", filePath, err)
+	}
+	var body io.ReadCloser
+	for _, file := range archive.File {
+		if file.Name == "word/document.xml" {
+			if body, err = file.Open(); err != nil {
+				return "", fmt.Errorf("error reading %s: %w", filePath, err)
+			}
+			break
+		}
+	}
+	if body == nil {
+		return "", fmt.Errorf("%s is not a Word document: it has no word/document.xml", filePath)
+	}
+	defer body.Close()
+
+	var paragraphs []string
+	var text strings.Builder
+	prefix := ""
+	decoder := xml.NewDecoder(io.LimitReader(body, MaxFileSize))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p":
+				text.Reset()
+				prefix = ""
+			case "pStyle":
+				prefix = paragraphPrefix(attribute(t, "val"), prefix)
+			case "numPr":
+				if prefix == "" {
+					prefix = "- "
+				}
+			case "tab":
+				text.WriteString("\t")
+			case "br":
+				text.WriteString(" ")
+			case "t":
+				var run string
+				if err := decoder.DecodeElement(&run, &t); err != nil {
+					return "", fmt.Errorf("error reading %s: %w", filePath, err)
+				}
+				text.WriteString(run)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "p" {
+				if line := strings.TrimSpace(text.String()); line != "" {
+					paragraphs = append(paragraphs, prefix+line)
+				}
+			}
+		}
+	}
+	return joinParagraphs(paragraphs), nil
+}
+
+// paragraphPrefix returns the Markdown prefix of a Word paragraph style,
+// such as "## " for "Heading2", or current for a style that adds none.
+func paragraphPrefix(style, current string) string {
+	switch {
+	case style == "Title":
+		return "# "
+	case strings.HasPrefix(style, "Heading"):
+		level := 0
+		fmt.Sscanf(strings.TrimPrefix(style, "Heading"), "%d", &level)
+		return strings.Repeat("#", min(max(level, 1), 6)) + " "
+	case strings.HasPrefix(style, "List"):
+		return "- "
+	}
+	return current
+}
+
+// attribute returns the value of an element's attribute by its local name.
+func attribute(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// joinParagraphs joins paragraphs with blank lines, keeping consecutive list
+// items together as one list.
+func joinParagraphs(paragraphs []string) string {
+	var b strings.Builder
+	for i, paragraph := range paragraphs {
+		if i > 0 {
+			if strings.HasPrefix(paragraph, "- ") && strings.HasPrefix(paragraphs[i-1], "- ") {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(paragraph)
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// importJSON reads a JSON Resume document as Markdown, and any other JSON
+// document as indented JSON.
+func importJSON(filePath string, content []byte) (string, error) {
+	content = bytes.TrimPrefix(content, utf8BOM)
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err == nil && (fields["basics"] != nil || fields["work"] != nil) {
+		var resume document.JSONResume
+		if err := json.Unmarshal(content, &resume); err != nil {
+			return "", fmt.Errorf("%s is not a valid JSON Resume document: %w", filePath, err)
+		}
+		return resume.Markdown(), nil
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, content, "", "  "); err != nil {
+		return "", fmt.Errorf("%s is not valid JSON: %w", filePath, err)
+	}
+	return indented.String() + "\n", nil
+}
+
+// importCSV reads a spreadsheet export, such as a list of roles or
+// achievements, as a Markdown list with one item per row, naming each value
+// by its column's header.
+func importCSV(filePath string, content []byte) (string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, utf8BOM)))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return "", fmt.Errorf("%s is not a valid CSV file: %w", filePath, err)
+	}
+	if len(records) == 0 {
+		return "", nil
+	}
+
+	header := records[0]
+	var b strings.Builder
+	for _, record := range records[1:] {
+		var values []string
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if len(header) > 1 && i < len(header) && strings.TrimSpace(header[i]) != "" {
+				value = strings.TrimSpace(header[i]) + ": " + value
+			}
+			values = append(values, value)
+		}
+		if len(values) > 0 {
+			b.WriteString("- " + strings.Join(values, "; ") + "\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// importZip reads the members of an archive, such as a folder of old
+// resumes, that have a registered importer, each under a heading with its
+// name. Members that are not text, and nested archives, are skipped.
+func importZip(filePath string, content []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", fmt.Errorf("%s is not a ZIP archive: %w", filePath, err)
+	}
+
+	var b strings.Builder
+	var total uint64
+	for _, file := range archive.File {
+		ext := normalizeExtension(path.Ext(file.Name))
+		if file.FileInfo().IsDir() || ext == ".zip" {
+			continue
+		}
+		importer, ok := ImporterFor(file.Name)
+		if !ok {
+			continue
+		}
+		// Guard against archives that expand far beyond their size
+		total += file.UncompressedSize64
+		if total > uint64(MaxFileSize) {
+			return "", fmt.Errorf("the contents of %s exceed the maximum allowed size of %d bytes (%s)", filePath, MaxFileSize, FormatFileSize(MaxFileSize))
+		}
+
+		member, err := readZipMember(file)
+		if err != nil {
+			return "", fmt.Errorf("error reading %s in %s: %w", file.Name, filePath, err)
+		}
+		text, err := importer.Import(filePath+"/"+file.Name, member)
+		var binaryErr *BinaryFileError
+		if errors.As(err, &binaryErr) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n%s", file.Name, strings.TrimRight(text, "\n")+"\n")
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("%s has no files that can be imported; supported extensions are: %s", filePath, strings.Join(ImportedExtensions(), ", "))
+	}
+	return b.String(), nil
+}
+
+// readZipMember reads a file of an archive, no more than MaxFileSize bytes.
+func readZipMember(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, MaxFileSize+1))
+}
@@ -0,0 +1,125 @@
+package input
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/output"
+)
+
+func TestImportDOCX(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.docx")
+	markdown := "# Jane Doe\n\njane@example.com\n\n## Experience\n\n### Engineer, Acme\n\n- Built the **billing** service\n- Cut costs by 20%\n"
+	if err := output.WriteDOCX(markdown, path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: Headings and lists are kept as Markdown
+	content, err := ReadSourceFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := "# Jane Doe\n\njane@example.com\n\n## Experience\n\n### Engineer, Acme\n\n- Built the billing service\n- Cut costs by 20%\n"
+	if content != want {
+		t.Errorf("ReadSourceFile() = %q, want %q", content, want)
+	}
+
+	// Test case 2: A file that is not a Word document is an error
+	if _, err := importDOCX("resume.docx", []byte("not a zip")); err == nil {
+		t.Error("Expected an error for a file that is not a Word document")
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	// Test case 1: A JSON Resume document becomes Markdown
+	content, err := importJSON("resume.json", []byte(`{"basics": {"name": "Jane Doe"}, "work": [{"name": "Acme", "position": "Engineer", "startDate": "2020", "highlights": ["Built X"]}]}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(content, "# Jane Doe\n") || !strings.Contains(content, "### Engineer | Acme | 2020 – Present\n\n- Built X\n") {
+		t.Errorf("Unexpected Markdown: %q", content)
+	}
+
+	// Test case 2: Other JSON is indented
+	if content, err := importJSON("notes.json", []byte(`{"skills":["Go"]}`)); err != nil || content != "{\n  \"skills\": [\n    \"Go\"\n  ]\n}\n" {
+		t.Errorf("Expected indented JSON, got %q, %v", content, err)
+	}
+
+	// Test case 3: Invalid JSON is an error
+	if _, err := importJSON("notes.json", []byte(`{"skills":`)); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	// Test case 1: Each row is a list item naming its values by column
+	content, err := importCSV("roles.csv", []byte("Company,Role,Years\nAcme,Engineer,2020-2023\nGlobex,,2018\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := "- Company: Acme; Role: Engineer; Years: 2020-2023\n- Company: Globex; Years: 2018\n"; content != want {
+		t.Errorf("importCSV() = %q, want %q", content, want)
+	}
+
+	// Test case 2: A single column is a plain list
+	if content, err := importCSV("skills.csv", []byte("Skill\nGo\nSQL\n")); err != nil || content != "- Go\n- SQL\n" {
+		t.Errorf("Expected a plain list, got %q, %v", content, err)
+	}
+}
+
+func TestImportZip(t *testing.T) {
+	archive := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for _, name := range []string{"2019/cv.md", "photo.png", "roles.csv", "binary.txt"} {
+			if data, ok := files[name]; ok {
+				f, err := w.Create(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.Write([]byte(data))
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	// Test case 1: Members with an importer are read under their names; others are skipped
+	path := filepath.Join(t.TempDir(), "resumes.zip")
+	data := archive(map[string]string{"2019/cv.md": "# Jane Doe\n", "photo.png": "\x89PNG", "roles.csv": "Role\nEngineer\n", "binary.txt": "\x00\x01"})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ReadSourceFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := "## 2019/cv.md\n\n# Jane Doe\n\n## roles.csv\n\n- Engineer\n"; content != want {
+		t.Errorf("ReadSourceFile() = %q, want %q", content, want)
+	}
+
+	// Test case 2: An archive with nothing to import is an error
+	if _, err := importZip("photos.zip", archive(map[string]string{"photo.png": "\x89PNG"})); err == nil || !strings.Contains(err.Error(), "no files that can be imported") {
+		t.Errorf("Expected an error for an archive with nothing to import, got %v", err)
+	}
+
+	// Test case 3: Contents over MaxFileSize are rejected
+	defer func(size int64) { MaxFileSize = size }(MaxFileSize)
+	MaxFileSize = 4
+	if _, err := importZip("resumes.zip", data); err == nil || !strings.Contains(err.Error(), "exceed") {
+		t.Errorf("Expected an error for contents over the size limit, got %v", err)
+	}
+
+	// Test case 4: A ZIP archive named as text is still rejected as binary
+	var binaryErr *BinaryFileError
+	if _, err := importText("resumes.txt", data); !errors.As(err, &binaryErr) || !strings.Contains(binaryErr.Hint, ".docx") {
+		t.Errorf("Expected a BinaryFileError with a hint, got %v", err)
+	}
+}
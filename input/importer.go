@@ -0,0 +1,134 @@
+package input
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Importer reads a source file of one format as the text sent to the model.
+// Importers are registered by file extension with RegisterImporter, and
+// ReadSourceFile uses the one registered for a file's extension.
+type Importer interface {
+	// Import converts the file's content, which has already been checked
+	// against MaxFileSize and decrypted, to text. path names the file in
+	// errors and may be a member of an archive.
+	Import(path string, content []byte) (string, error)
+}
+
+// ImporterFunc adapts a function to the Importer interface.
+type ImporterFunc func(path string, content []byte) (string, error)
+
+// Import calls f(path, content).
+func (f ImporterFunc) Import(path string, content []byte) (string, error) {
+	return f(path, content)
+}
+
+// importers holds the registered importers by lowercase extension.
+var (
+	importersMu sync.RWMutex
+	importers   = map[string]Importer{}
+)
+
+func init() {
+	for _, ext := range DefaultFileExtensions {
+		RegisterImporter(ext, TextImporter)
+	}
+	// Source PDFs are uploaded to the model once DetectPDF finds them; read as
+	// text, such as for a job description, they are rejected with a hint
+	RegisterImporter(".pdf", TextImporter)
+	RegisterImporter(".docx", ImporterFunc(importDOCX))
+	RegisterImporter(".json", ImporterFunc(importJSON))
+	RegisterImporter(".csv", ImporterFunc(importCSV))
+	RegisterImporter(".zip", ImporterFunc(importZip))
+}
+
+// RegisterImporter makes importer read the files with extension ext,
+// replacing the importer registered for it before, if any. It is usually
+// called from an init function, so a new format is supported without
+// changing ReadSourceFile.
+//
+// Parameters:
+//   - ext: The file extension, with or without the leading dot (case-insensitive)
+//   - importer: The importer for the format
+//
+// Example:
+//
+//	input.RegisterImporter(".rtf", input.ImporterFunc(func(path string, content []byte) (string, error) {
+//	    return rtfToText(content)
+//	}))
+func RegisterImporter(ext string, importer Importer) {
+	importersMu.Lock()
+	defer importersMu.Unlock()
+	importers[normalizeExtension(ext)] = importer
+}
+
+// ImporterFor returns the importer registered for the extension of path.
+// Files with another extension are read with TextImporter, like text files.
+//
+// Parameters:
+//   - path: The path of the file
+//
+// Returns:
+//   - Importer: The importer for the file
+//   - bool: True if an importer is registered for the extension
+func ImporterFor(path string) (Importer, bool) {
+	importersMu.RLock()
+	defer importersMu.RUnlock()
+	if importer, ok := importers[normalizeExtension(filepath.Ext(path))]; ok {
+		return importer, true
+	}
+	return TextImporter, false
+}
+
+// ImportedExtensions returns the extensions with a registered importer.
+//
+// Returns:
+//   - []string: The extensions, sorted, such as ".csv" and ".docx"
+func ImportedExtensions() []string {
+	importersMu.RLock()
+	defer importersMu.RUnlock()
+	extensions := make([]string, 0, len(importers))
+	for ext := range importers {
+		extensions = append(extensions, ext)
+	}
+	slices.Sort(extensions)
+	return extensions
+}
+
+// formatExtensions returns the extensions with a registered importer other
+// than the text extensions of DefaultFileExtensions, whose support is set
+// with SupportedFileExtensions.
+func formatExtensions() []string {
+	var extensions []string
+	for _, ext := range ImportedExtensions() {
+		if !slices.Contains(DefaultFileExtensions, ext) {
+			extensions = append(extensions, ext)
+		}
+	}
+	return extensions
+}
+
+// normalizeExtension returns ext in lowercase with a leading dot.
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// TextImporter reads UTF-8 text, such as Markdown, as it is, without a byte
+// order mark. Content that is not UTF-8 text is rejected with a
+// *BinaryFileError, since sending its bytes to the model produces nonsense.
+var TextImporter Importer = ImporterFunc(importText)
+
+// importText returns UTF-8 text without its byte order mark.
+func importText(path string, content []byte) (string, error) {
+	if binaryErr := detectBinaryContent(path, content); binaryErr != nil {
+		return "", binaryErr
+	}
+	return string(bytes.TrimPrefix(content, utf8BOM)), nil
+}
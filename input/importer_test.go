@@ -0,0 +1,52 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImporterFor(t *testing.T) {
+	// Test case 1: Each supported format has an importer, whatever the case of its extension
+	for _, path := range []string{"resume.md", "resume.TXT", "resume.pdf", "resume.docx", "history.json", "roles.csv", "resumes.zip"} {
+		if _, ok := ImporterFor(path); !ok {
+			t.Errorf("Expected an importer for %s", path)
+		}
+	}
+
+	// Test case 2: Other files are read as text
+	importer, ok := ImporterFor("resume.rst")
+	if ok {
+		t.Error("Expected no importer registered for .rst")
+	}
+	if content, err := importer.Import("resume.rst", []byte("\xEF\xBB\xBFJane Doe")); err != nil || content != "Jane Doe" {
+		t.Errorf("Expected the text without its byte order mark, got %q, %v", content, err)
+	}
+}
+
+func TestRegisterImporter(t *testing.T) {
+	t.Cleanup(func() {
+		importersMu.Lock()
+		delete(importers, ".rtf")
+		importersMu.Unlock()
+	})
+
+	// Test case 1: A registered importer is used by ReadSourceFile
+	RegisterImporter("RTF", ImporterFunc(func(path string, content []byte) (string, error) {
+		return strings.ToUpper(string(content)), nil
+	}))
+	path := filepath.Join(t.TempDir(), "resume.rtf")
+	if err := os.WriteFile(path, []byte("jane doe"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if content, err := ReadSourceFile(path); err != nil || content != "JANE DOE" {
+		t.Errorf("Expected the registered importer's text, got %q, %v", content, err)
+	}
+
+	// Test case 2: Its extension is listed and no longer warned about
+	if !reflect.DeepEqual(ImportedExtensions()[:2], []string{".csv", ".docx"}) || ExtensionWarning(path) != "" {
+		t.Errorf("Expected .rtf to be supported, got %v, %q", ImportedExtensions(), ExtensionWarning(path))
+	}
+}
@@ -0,0 +1,144 @@
+package input
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fileSizeUnits maps the size suffixes accepted by ParseFileSize to bytes,
+// longest first so "MB" is matched before "B".
+var fileSizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1024 * 1024 * 1024},
+	{"MB", 1024 * 1024},
+	{"KB", 1024},
+	{"B", 1},
+}
+
+// ParseFileSize parses a file size such as "10MB", "512KB", or "2048" (bytes).
+// Units are case-insensitive and binary, so "1KB" is 1024 bytes.
+//
+// Parameters:
+//   - value: The size, a whole number with an optional B, KB, MB, or GB suffix
+//
+// Returns:
+//   - int64: The size in bytes
+//   - error: An error if the size is malformed or not positive
+//
+// Example:
+//
+//	size, err := input.ParseFileSize("20MB")  // 20971520
+func ParseFileSize(value string) (int64, error) {
+	number, multiplier := strings.ToUpper(strings.TrimSpace(value)), int64(1)
+	for _, unit := range fileSizeUnits {
+		if strings.HasSuffix(number, unit.suffix) {
+			number, multiplier = strings.TrimSpace(strings.TrimSuffix(number, unit.suffix)), unit.bytes
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || size <= 0 || size > (1<<62)/multiplier {
+		return 0, fmt.Errorf("invalid file size %q (expected a positive number of bytes, optionally with KB, MB, or GB)", value)
+	}
+	return size * multiplier, nil
+}
+
+// FormatFileSize formats a size in bytes in the largest unit that divides it
+// evenly, the inverse of ParseFileSize.
+//
+// Parameters:
+//   - size: The size in bytes
+//
+// Returns:
+//   - string: The size, such as "10MB" or "1500B"
+func FormatFileSize(size int64) string {
+	for _, unit := range fileSizeUnits {
+		if size >= unit.bytes && size%unit.bytes == 0 {
+			return fmt.Sprintf("%d%s", size/unit.bytes, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+// ParseExtensions parses a comma-separated list of file extensions, such as
+// "txt,md,.rst". Extensions are lowercased and given a leading dot, and
+// repeated ones are dropped.
+//
+// Parameters:
+//   - list: The comma-separated extensions
+//
+// Returns:
+//   - []string: The extensions in the order given
+//   - error: An error if the list is empty or an extension contains a separator
+//
+// Example:
+//
+//	extensions, err := input.ParseExtensions("txt, md,.RST")
+//	// extensions == []string{".txt", ".md", ".rst"}
+func ParseExtensions(list string) ([]string, error) {
+	var extensions []string
+	for _, item := range strings.Split(list, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		if !strings.HasPrefix(item, ".") {
+			item = "." + item
+		}
+		if item == "." || strings.ContainsAny(item[1:], `./\ `) {
+			return nil, fmt.Errorf("invalid file extension %q", item)
+		}
+		if !slices.Contains(extensions, item) {
+			extensions = append(extensions, item)
+		}
+	}
+
+	if len(extensions) == 0 {
+		return nil, fmt.Errorf("no file extensions in %q", list)
+	}
+	return extensions, nil
+}
+
+// SetLimits sets MaxFileSize and SupportedFileExtensions. An empty value
+// leaves that limit unchanged, so settings can be layered: defaults, then
+// the settings file, then flags.
+//
+// Parameters:
+//   - maxFileSize: The maximum file size, as accepted by ParseFileSize (can be empty)
+//   - extensions: The supported extensions, as accepted by ParseExtensions (can be empty)
+//
+// Returns:
+//   - error: An error if either value is invalid; nothing is changed then
+//
+// Example:
+//
+//	if err := input.SetLimits(flags.MaxFileSize, flags.Extensions); err != nil {
+//	    log.Fatalf("Error parsing file limits: %v", err)
+//	}
+func SetLimits(maxFileSize, extensions string) error {
+	size := MaxFileSize
+	if strings.TrimSpace(maxFileSize) != "" {
+		parsed, err := ParseFileSize(maxFileSize)
+		if err != nil {
+			return err
+		}
+		size = parsed
+	}
+
+	supported := SupportedFileExtensions
+	if strings.TrimSpace(extensions) != "" {
+		parsed, err := ParseExtensions(extensions)
+		if err != nil {
+			return err
+		}
+		supported = parsed
+	}
+
+	MaxFileSize, SupportedFileExtensions = size, supported
+	return nil
+}
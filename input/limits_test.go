@@ -0,0 +1,78 @@
+package input
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseFileSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"2048", 2048, false},
+		{"512KB", 512 * 1024, false},
+		{"20mb", 20 * 1024 * 1024, false},
+		{" 1 GB ", 1024 * 1024 * 1024, false},
+		{"100B", 100, false},
+		{"0", 0, true},
+		{"-5MB", 0, true},
+		{"ten", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFileSize(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFileSize(%q) = %d, %v; want %d (error %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatFileSize(t *testing.T) {
+	for size, want := range map[int64]string{DefaultMaxFileSize: "10MB", 1536: "1536B", 2048: "2KB"} {
+		if got := FormatFileSize(size); got != want {
+			t.Errorf("FormatFileSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestParseExtensions(t *testing.T) {
+	// Test case 1: Extensions get a leading dot, lower case, and no repeats
+	got, err := ParseExtensions("txt, .MD,md,rst")
+	if err != nil || !slices.Equal(got, []string{".txt", ".md", ".rst"}) {
+		t.Errorf("Unexpected extensions %v (%v)", got, err)
+	}
+
+	// Test case 2: Empty lists and malformed extensions are errors
+	for _, list := range []string{"", " , ", "tar.gz", "."} {
+		if _, err := ParseExtensions(list); err == nil {
+			t.Errorf("Expected an error for %q", list)
+		}
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	defer func() {
+		MaxFileSize, SupportedFileExtensions = DefaultMaxFileSize, slices.Clone(DefaultFileExtensions)
+	}()
+
+	// Test case 1: Empty values keep the current limits
+	if err := SetLimits("", ""); err != nil || MaxFileSize != DefaultMaxFileSize || !slices.Equal(SupportedFileExtensions, DefaultFileExtensions) {
+		t.Fatalf("Expected the defaults to be kept, got %d %v (%v)", MaxFileSize, SupportedFileExtensions, err)
+	}
+
+	// Test case 2: Both limits are set and used by ExtensionWarning
+	if err := SetLimits("1KB", "rst"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if MaxFileSize != 1024 || ExtensionWarning("resume.rst") != "" || ExtensionWarning("resume.md") == "" {
+		t.Errorf("Unexpected limits %d %v", MaxFileSize, SupportedFileExtensions)
+	}
+
+	// Test case 3: An invalid value changes nothing
+	if err := SetLimits("2KB", "tar.gz"); err == nil || MaxFileSize != 1024 {
+		t.Errorf("Expected an error and no change, got %d (%v)", MaxFileSize, err)
+	}
+}
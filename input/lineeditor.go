@@ -0,0 +1,289 @@
+package input
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+)
+
+// ErrInterrupted is returned by LineEditor.ReadLine when the user presses Ctrl+C.
+var ErrInterrupted = errors.New("interrupted")
+
+// Control keys understood by the line editor.
+const (
+	keyCtrlA     = 0x01
+	keyCtrlB     = 0x02
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyCtrlE     = 0x05
+	keyCtrlF     = 0x06
+	keyBackspace = 0x08
+	keyCtrlK     = 0x0b
+	keyCtrlU     = 0x15
+	keyCtrlW     = 0x17
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// LineEditor reads lines typed at a prompt, for the plain mode that runs
+// without the full-screen interface. On a terminal it edits lines itself,
+// readline style: the arrow keys, Home and End move within the line, Up and
+// Down recall earlier lines, and Ctrl+A, Ctrl+E, Ctrl+K, Ctrl+U, and Ctrl+W
+// work as they do in a shell. Typing at the end of the line only echoes the
+// new characters, so screen readers are not made to reread the whole line.
+// When the input is a pipe or file, whole lines are read as they are.
+type LineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	terminal *os.File // The terminal switched to raw mode while a line is edited (nil when not editing)
+	edit     bool     // Lines are edited here rather than read whole
+	history  []string
+}
+
+// NewLineEditor creates a line editor reading from in and echoing to out.
+// Lines are edited only when in is a terminal.
+//
+// Parameters:
+//   - in: The input, usually os.Stdin
+//   - out: Where prompts and typed characters are written, usually os.Stdout
+//
+// Returns:
+//   - *LineEditor: The line editor
+//
+// Example:
+//
+//	editor := input.NewLineEditor(os.Stdin, os.Stdout)
+//	name, err := editor.ReadLine("Your name: ")
+func NewLineEditor(in io.Reader, out io.Writer) *LineEditor {
+	e := &LineEditor{in: bufio.NewReader(in), out: out}
+	if file, ok := in.(*os.File); ok && term.IsTerminal(file.Fd()) {
+		e.terminal = file
+		e.edit = true
+	}
+	return e
+}
+
+// ReadLine shows the prompt and returns the line the user enters, without
+// its line ending. Non-empty lines are remembered so Up can recall them.
+//
+// Parameters:
+//   - prompt: The text shown before the input
+//
+// Returns:
+//   - string: The line entered
+//   - error: io.EOF when the input ends (or Ctrl+D is pressed on an empty
+//     line), ErrInterrupted for Ctrl+C, or a read error
+func (e *LineEditor) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+
+	if !e.edit {
+		line, err := e.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	if e.terminal != nil {
+		state, err := term.MakeRaw(e.terminal.Fd())
+		if err != nil {
+			return "", fmt.Errorf("error preparing the terminal: %w", err)
+		}
+		defer term.Restore(e.terminal.Fd(), state)
+	}
+
+	line, err := e.editLine(prompt)
+	if err == nil && line != "" && (len(e.history) == 0 || e.history[len(e.history)-1] != line) {
+		e.history = append(e.history, line)
+	}
+	return line, err
+}
+
+// editLine reads keys until Enter, applying each edit to the line. The
+// terminal is in raw mode, so line endings are written as "\r\n".
+func (e *LineEditor) editLine(prompt string) (string, error) {
+	var line []rune
+	cursor := 0
+	recalled := len(e.history)
+	pending := ""
+
+	// refresh redraws the whole line after an edit that is not at its end
+	refresh := func() {
+		fmt.Fprint(e.out, "\r"+prompt+string(line)+"\x1b[K")
+		if back := ansi.StringWidth(string(line[cursor:])); back > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", back)
+		}
+	}
+
+	// recall replaces the line with an earlier one, keeping the line being
+	// typed so Down can return to it
+	recall := func(index int) {
+		if index < 0 || index > len(e.history) {
+			return
+		}
+		if recalled == len(e.history) {
+			pending = string(line)
+		}
+		recalled = index
+		if index == len(e.history) {
+			line = []rune(pending)
+		} else {
+			line = []rune(e.history[index])
+		}
+		cursor = len(line)
+		refresh()
+	}
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return string(line), nil
+			}
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(line), nil
+
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			if cursor < len(line) {
+				line = append(line[:cursor], line[cursor+1:]...)
+				refresh()
+			}
+
+		case keyDelete, keyBackspace:
+			if cursor == 0 {
+				continue
+			}
+			cursor--
+			line = append(line[:cursor], line[cursor+1:]...)
+			if cursor == len(line) {
+				fmt.Fprint(e.out, "\b \b")
+			} else {
+				refresh()
+			}
+
+		case keyCtrlA:
+			cursor = 0
+			refresh()
+
+		case keyCtrlE:
+			cursor = len(line)
+			refresh()
+
+		case keyCtrlB:
+			if cursor > 0 {
+				cursor--
+				refresh()
+			}
+
+		case keyCtrlF:
+			if cursor < len(line) {
+				cursor++
+				refresh()
+			}
+
+		case keyCtrlK:
+			line = line[:cursor]
+			refresh()
+
+		case keyCtrlU:
+			line = line[cursor:]
+			cursor = 0
+			refresh()
+
+		case keyCtrlW:
+			start := cursor
+			for start > 0 && line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && line[start-1] != ' ' {
+				start--
+			}
+			line = append(line[:start], line[cursor:]...)
+			cursor = start
+			refresh()
+
+		case keyEscape:
+			switch e.readEscape() {
+			case "A":
+				recall(recalled - 1)
+			case "B":
+				recall(recalled + 1)
+			case "C":
+				if cursor < len(line) {
+					cursor++
+					refresh()
+				}
+			case "D":
+				if cursor > 0 {
+					cursor--
+					refresh()
+				}
+			case "H", "1~", "7~":
+				cursor = 0
+				refresh()
+			case "F", "4~", "8~":
+				cursor = len(line)
+				refresh()
+			case "3~":
+				if cursor < len(line) {
+					line = append(line[:cursor], line[cursor+1:]...)
+					refresh()
+				}
+			}
+
+		default:
+			if r < ' ' {
+				continue
+			}
+			line = append(line[:cursor], append([]rune{r}, line[cursor:]...)...)
+			cursor++
+			if cursor == len(line) {
+				fmt.Fprint(e.out, string(r))
+			} else {
+				refresh()
+			}
+		}
+	}
+}
+
+// readEscape reads the rest of an escape sequence after ESC and returns its
+// parameters and final character, such as "A" for Up or "3~" for Delete.
+// Sequences the editor does not use are returned too and then ignored.
+func (e *LineEditor) readEscape() string {
+	introducer, _, err := e.in.ReadRune()
+	if err != nil || (introducer != '[' && introducer != 'O') {
+		return ""
+	}
+
+	var seq strings.Builder
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return ""
+		}
+		seq.WriteRune(r)
+		if r >= 0x40 && r <= 0x7e {
+			return seq.String()
+		}
+	}
+}
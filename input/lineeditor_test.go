@@ -0,0 +1,101 @@
+package input
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+// newTestEditor returns a line editor that edits the typed keys as it would
+// on a terminal.
+func newTestEditor(keys string) (*LineEditor, *strings.Builder) {
+	out := &strings.Builder{}
+	return &LineEditor{in: bufio.NewReader(strings.NewReader(keys)), out: out, edit: true}, out
+}
+
+func TestLineEditorEditing(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want string
+	}{
+		{"typing", "Jane Doe\r", "Jane Doe"},
+		{"backspace", "Janx\x7fe\r", "Jane"},
+		{"insert after moving left", "Jne\x1b[D\x1b[Da\r", "Jane"},
+		{"home and end", "ane\x01J\x05!\r", "Jane!"},
+		{"home and end sequences", "ane\x1b[HJ\x1b[F!\r", "Jane!"},
+		{"delete under cursor", "Jaxne\x01\x1b[C\x1b[C\x1b[3~\r", "Jane"},
+		{"kill to end", "Jane Doe\x01\x1b[C\x1b[C\x1b[C\x1b[C\x0b\r", "Jane"},
+		{"kill to start", "Doe Jane\x1b[D\x1b[D\x1b[D\x1b[D\x15\r", "Jane"},
+		{"delete word", "Jane Doe  \x17\r", "Jane "},
+		{"unicode", "Zoë\x7f\x7fé\r", "Zé"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor, _ := newTestEditor(tt.keys)
+			got, err := editor.ReadLine("> ")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineEditorHistory(t *testing.T) {
+	editor, _ := newTestEditor("first\rsecond\r\x1b[A\x1b[A!\rdraft\x1b[A\x1b[B\r")
+
+	// Test case 1: Up recalls earlier lines, most recent first
+	for _, want := range []string{"first", "second", "first!"} {
+		if got, err := editor.ReadLine("> "); err != nil || got != want {
+			t.Errorf("ReadLine() = %q (%v), want %q", got, err, want)
+		}
+	}
+
+	// Test case 2: Down returns to the line being typed
+	if got, err := editor.ReadLine("> "); err != nil || got != "draft" {
+		t.Errorf("ReadLine() = %q (%v), want %q", got, err, "draft")
+	}
+}
+
+func TestLineEditorEcho(t *testing.T) {
+	editor, out := newTestEditor("ab\x7f\r")
+	if _, err := editor.ReadLine("> "); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Typing and deleting at the end of the line echoes only the change
+	if got, want := out.String(), "> ab\b \b\r\n"; got != want {
+		t.Errorf("Expected minimal echo %q, got %q", want, got)
+	}
+}
+
+func TestLineEditorEndOfInput(t *testing.T) {
+	// Test case 1: Ctrl+D on an empty line ends the input
+	editor, _ := newTestEditor("\x04")
+	if _, err := editor.ReadLine("> "); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+
+	// Test case 2: Ctrl+C interrupts
+	editor, _ = newTestEditor("abc\x03")
+	if _, err := editor.ReadLine("> "); err != ErrInterrupted {
+		t.Errorf("Expected ErrInterrupted, got %v", err)
+	}
+
+	// Test case 3: Piped input is read a whole line at a time
+	piped := NewLineEditor(strings.NewReader("Jane\x7f\r\nlast"), io.Discard)
+	if got, err := piped.ReadLine("> "); err != nil || got != "Jane\x7f" {
+		t.Errorf("Expected the piped line unedited, got %q (%v)", got, err)
+	}
+	if got, err := piped.ReadLine("> "); err != nil || got != "last" {
+		t.Errorf("Expected the last line without a newline, got %q (%v)", got, err)
+	}
+	if _, err := piped.ReadLine("> "); err != io.EOF {
+		t.Errorf("Expected io.EOF after the last line, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/spf13/pflag"
+)
+
+// MergeSourcesCommand is the first argument that selects merge-sources mode,
+// which consolidates old resumes into one master history.
+const MergeSourcesCommand = "merge-sources"
+
+// MergeSourcesFlags represents the arguments accepted by merge-sources mode.
+type MergeSourcesFlags struct {
+	// SourcePaths holds the paths of the resumes to merge, in the order given.
+	SourcePaths []string
+
+	// SourceContents holds the contents of SourcePaths, in the same order.
+	SourceContents []string
+
+	// SourceWarnings holds warnings about SourcePaths, such as an unsupported
+	// extension or removed instructions, for the caller to report.
+	SourceWarnings []string
+
+	// OutputPath holds where the master history is written, or is empty to
+	// write it to the configuration directory, where generating uses it.
+	OutputPath string
+
+	// Model holds the Gemini model used for the merge.
+	Model string
+}
+
+// Bind defines merge-sources mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the merge-sources command
+func (f *MergeSourcesFlags) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&f.OutputPath, "output", "", "Path of the master history (default: master_history.md in the configuration directory, used as the source when generating)")
+	fs.StringVar(&f.Model, "model", api.DefaultModelName, "Model to merge the resumes with")
+}
+
+// Complete reads the resumes given as positional arguments.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
+//
+// Returns:
+//   - error: An error if no resume is given, one is given twice, or one
+//     cannot be read or is empty
+func (f *MergeSourcesFlags) Complete(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no resumes to merge; pass the paths of your old resumes")
+	}
+
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		path := ExpandPath(arg)
+		key := path
+		if abs, err := filepath.Abs(path); err == nil {
+			key = abs
+		}
+		if seen[key] {
+			return fmt.Errorf("resume %s is given more than once", arg)
+		}
+		seen[key] = true
+
+		content, warning, err := ReadPromptFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(content) == "" {
+			return fmt.Errorf("resume %s is empty", arg)
+		}
+		f.SourcePaths = append(f.SourcePaths, path)
+		f.SourceContents = append(f.SourceContents, content)
+		if warning != "" {
+			f.SourceWarnings = append(f.SourceWarnings, warning)
+		}
+	}
+	return nil
+}
+
+// ParseMergeSourcesArgs parses the arguments that follow the merge-sources
+// command and reads the resumes, which are the positional arguments. Flags
+// may come before, between, or after them.
+//
+// Parameters:
+//   - args: The arguments after "merge-sources"
+//
+// Returns:
+//   - MergeSourcesFlags: The parsed arguments and the resumes' contents
+//   - error: An error if the flags are invalid, no resume is given, one is
+//     given twice, or one cannot be read or is empty
+//
+// Example:
+//
+//	flags, err := input.ParseMergeSourcesArgs([]string{"cv_2019.md", "resume_2022.md"})
+func ParseMergeSourcesArgs(args []string) (MergeSourcesFlags, error) {
+	var flags MergeSourcesFlags
+	fs := NewFlagSet("resumake merge-sources")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args())
+}
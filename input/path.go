@@ -0,0 +1,86 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// envRegex matches a $NAME or ${NAME} environment variable reference. Unlike
+// os.Expand, text that is not a complete reference, such as "a${X", is not
+// matched and so is left as written.
+var envRegex = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// windowsEnvRegex matches a %NAME% environment variable reference, which
+// Windows shells expand but Go's os.Expand does not.
+var windowsEnvRegex = regexp.MustCompile(`%([A-Za-z_][A-Za-z0-9_]*)%`)
+
+// ExpandPath expands a leading ~ to the home directory and $NAME or ${NAME}
+// environment variable references, and %NAME% references on Windows, the way
+// a shell would. Paths typed into the TUI or quoted on the command line are
+// not expanded by the shell, so resumake does it instead. References to
+// unset variables, and a ~ when the home directory is unknown, are left as
+// written. Relative paths stay relative.
+//
+// Parameters:
+//   - path: The path as the user wrote it
+//
+// Returns:
+//   - string: The expanded path
+//
+// Example:
+//
+//	path := input.ExpandPath("~/resumes/$USER.md")
+//	// /home/jane/resumes/jane.md
+func ExpandPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") || (runtime.GOOS == "windows" && strings.HasPrefix(path, `~\`)) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+
+	path = envRegex.ReplaceAllStringFunc(path, func(reference string) string {
+		match := envRegex.FindStringSubmatch(reference)
+		if value, ok := os.LookupEnv(match[1] + match[2]); ok {
+			return value
+		}
+		return reference
+	})
+	if runtime.GOOS == "windows" {
+		path = windowsEnvRegex.ReplaceAllStringFunc(path, func(reference string) string {
+			if value, ok := os.LookupEnv(strings.Trim(reference, "%")); ok {
+				return value
+			}
+			return reference
+		})
+	}
+	return path
+}
+
+// ResolvePath expands path with ExpandPath and makes it absolute, so the file
+// a relative path refers to is clear wherever resumake was started from.
+//
+// Parameters:
+//   - path: The path as the user wrote it
+//
+// Returns:
+//   - string: The absolute path, the expanded path if the working directory
+//     is unknown, or an empty string for an empty path
+//
+// Example:
+//
+//	fmt.Println("Reading", input.ResolvePath("resume.md"))
+//	// Reading /home/jane/resume.md
+func ResolvePath(path string) string {
+	if path == "" {
+		return ""
+	}
+	expanded := ExpandPath(path)
+	abs, err := filepath.Abs(expanded)
+	if err != nil {
+		return expanded
+	}
+	return abs
+}
@@ -0,0 +1,57 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("RESUMAKE_TEST_DIR", "/srv/resumes")
+	os.Unsetenv("RESUMAKE_TEST_UNSET")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", ""},
+		{"-", "-"},
+		{"resume.md", "resume.md"},
+		{"~", home},
+		{"~/resume.md", filepath.Join(home, "resume.md")},
+		{"~jane/resume.md", "~jane/resume.md"},
+		{"$RESUMAKE_TEST_DIR/resume.md", "/srv/resumes/resume.md"},
+		{"${RESUMAKE_TEST_DIR}/resume.md", "/srv/resumes/resume.md"},
+		{"$RESUMAKE_TEST_UNSET/resume.md", "$RESUMAKE_TEST_UNSET/resume.md"},
+		{"${RESUMAKE_TEST_UNSET}/resume.md", "${RESUMAKE_TEST_UNSET}/resume.md"},
+		{"a${RESUMAKE_TEST_DIR", "a${RESUMAKE_TEST_DIR"},
+		{"costs$5.md", "costs$5.md"},
+		{"$RESUMAKE_TEST_DIR$RESUMAKE_TEST_UNSET", "/srv/resumes$RESUMAKE_TEST_UNSET"},
+	}
+	for _, tt := range tests {
+		if got := ExpandPath(tt.path); got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if got := ResolvePath(""); got != "" {
+		t.Errorf("Expected an empty path to stay empty, got %q", got)
+	}
+	if got, want := ResolvePath("resume.md"), filepath.Join(wd, "resume.md"); got != want {
+		t.Errorf("Expected a relative path to resolve to %q, got %q", want, got)
+	}
+	if got, want := ResolvePath("~/notes/../resume.md"), filepath.Join(home, "resume.md"); got != want {
+		t.Errorf("Expected a home path to resolve to %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,109 @@
+package input
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxPostingSize is the most of a job posting page that is downloaded (1MB).
+const MaxPostingSize = 1024 * 1024
+
+// MaxPostingChars is the most posting text kept for the research prompt.
+// Job descriptions are far shorter; the rest of a page is navigation and footers.
+const MaxPostingChars = 20000
+
+// postingClient downloads job postings. The timeout keeps a slow site from
+// holding up generation.
+var postingClient = &http.Client{Timeout: 15 * time.Second}
+
+var (
+	// hiddenElementPattern matches elements whose content is never shown.
+	hiddenElementPattern = regexp.MustCompile(`(?is)<(script|style|noscript|svg|head)\b.*?</(script|style|noscript|svg|head)>`)
+
+	// blockTagPattern matches tags that start a new line when rendered.
+	blockTagPattern = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/h[1-6]|/tr|/section|/article)\b[^>]*>`)
+
+	// tagPattern matches any remaining tag or comment.
+	tagPattern = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+)
+
+// IsURL reports whether a -company value is a job posting URL rather than a
+// company name.
+//
+// Parameters:
+//   - target: The -company value
+//
+// Returns:
+//   - bool: True for http and https URLs
+func IsURL(target string) bool {
+	target = strings.ToLower(strings.TrimSpace(target))
+	return strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://")
+}
+
+// FetchPosting downloads a job posting and returns its visible text, without
+// markup, scripts, or styles. Long pages are cut to MaxPostingChars.
+//
+// Parameters:
+//   - ctx: Context for cancelling the download
+//   - url: The job posting URL
+//
+// Returns:
+//   - string: The text of the posting
+//   - error: An error if the page could not be downloaded or has no text
+//
+// Example:
+//
+//	text, err := input.FetchPosting(ctx, "https://example.com/jobs/123")
+func FetchPosting(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSpace(url), nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid job posting URL: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,text/plain")
+
+	resp, err := postingClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download job posting: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("failed to download job posting: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPostingSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read job posting: %w", err)
+	}
+
+	text := htmlToText(string(body))
+	if text == "" {
+		return "", fmt.Errorf("job posting at %s has no text", url)
+	}
+	if len(text) > MaxPostingChars {
+		text = strings.ToValidUTF8(text[:MaxPostingChars], "")
+	}
+	return text, nil
+}
+
+// htmlToText returns the visible text of an HTML page, one block per line.
+// Plain text passes through with its whitespace tidied.
+func htmlToText(page string) string {
+	page = hiddenElementPattern.ReplaceAllString(page, "")
+	page = blockTagPattern.ReplaceAllString(page, "\n")
+	page = html.UnescapeString(tagPattern.ReplaceAllString(page, " "))
+
+	var lines []string
+	for _, line := range strings.Split(page, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
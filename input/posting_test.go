@@ -0,0 +1,62 @@
+package input
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsURL(t *testing.T) {
+	for target, want := range map[string]bool{
+		"https://acme.dev/jobs/1": true,
+		" HTTP://acme.dev":        true,
+		"Acme Corp":               false,
+		"acme.dev/jobs":           false,
+	} {
+		if got := IsURL(target); got != want {
+			t.Errorf("IsURL(%q) = %v, want %v", target, got, want)
+		}
+	}
+}
+
+func TestFetchPosting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jobs/1":
+			w.Write([]byte(`<html><head><title>Jobs</title><style>p { color: red }</style></head>
+<body><script>track()</script><h1>Senior Go Engineer</h1>
+<p>We value   ownership &amp; <b>frugality</b>.</p><ul><li>Kafka</li><li>Postgres</li></ul><!-- tracking --></body></html>`))
+		case "/empty":
+			w.Write([]byte("<html><body><script>app()</script></body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Returns the visible text", func(t *testing.T) {
+		text, err := FetchPosting(context.Background(), server.URL+"/jobs/1")
+		if err != nil {
+			t.Fatalf("FetchPosting() error = %v", err)
+		}
+		want := "Senior Go Engineer\nWe value ownership & frugality .\nKafka\nPostgres"
+		if text != want {
+			t.Errorf("FetchPosting() = %q, want %q", text, want)
+		}
+	})
+
+	t.Run("Missing page", func(t *testing.T) {
+		_, err := FetchPosting(context.Background(), server.URL+"/jobs/2")
+		if err == nil || !strings.Contains(err.Error(), "404") {
+			t.Errorf("Expected an error naming the status, got %v", err)
+		}
+	})
+
+	t.Run("Page without text", func(t *testing.T) {
+		if _, err := FetchPosting(context.Background(), server.URL+"/empty"); err == nil {
+			t.Error("Expected an error for a page without text")
+		}
+	})
+}
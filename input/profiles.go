@@ -0,0 +1,259 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/prompt"
+	"github.com/spf13/pflag"
+)
+
+// CompareProfilesCommand is the first argument that selects compare-profiles
+// mode, which generates a resume for each of several profiles from the same
+// inputs and reports how they differ.
+const CompareProfilesCommand = "compare-profiles"
+
+// Profile is one kind of resume compare-profiles generates: a preset, an
+// audience, and optionally a file of system instructions that replaces the
+// built-in prompt template.
+type Profile struct {
+	// Spec holds the profile as written in -profiles, such as "new-grad+recruiter".
+	Spec string
+
+	// Preset holds the kind of candidate the resume is written for.
+	Preset prompt.Preset
+
+	// Audience holds who reads the resume first.
+	Audience prompt.Audience
+
+	// InstructionsPath holds the path to the system instructions file, or is
+	// empty for the built-in instructions.
+	InstructionsPath string
+
+	// Instructions holds the contents of InstructionsPath.
+	Instructions string
+}
+
+// ParseProfile parses a profile written as parts joined with "+", such as
+// "new-grad+recruiter" or "standard+terse.txt". Each part is a preset, an
+// audience, or a system instructions file, given as a path or as the name of
+// a file in the profiles directory (see config.DataPath); parts left out are
+// the standard preset, the general audience, and the built-in instructions.
+//
+// Parameters:
+//   - spec: The profile
+//
+// Returns:
+//   - Profile: The profile, with its instructions file read
+//   - error: An error if a part is none of these, or two parts set the same thing
+//
+// Example:
+//
+//	profile, err := input.ParseProfile("new-grad+ats")
+//	// profile.Preset == prompt.PresetNewGrad, profile.Audience == prompt.AudienceATS
+func ParseProfile(spec string) (Profile, error) {
+	profile := Profile{Spec: strings.TrimSpace(spec), Preset: prompt.PresetStandard, Audience: prompt.AudienceGeneral}
+	if profile.Spec == "" {
+		return profile, errors.New("empty profile")
+	}
+
+	var presetSet, audienceSet bool
+	for _, part := range strings.Split(profile.Spec, "+") {
+		part = strings.TrimSpace(part)
+		if preset, err := prompt.ParsePreset(part); err == nil && part != "" {
+			if presetSet {
+				return profile, fmt.Errorf("profile %q has more than one preset", spec)
+			}
+			profile.Preset, presetSet = preset, true
+			continue
+		}
+		if audience, err := prompt.ParseAudience(part); err == nil && part != "" {
+			if audienceSet {
+				return profile, fmt.Errorf("profile %q has more than one audience", spec)
+			}
+			profile.Audience, audienceSet = audience, true
+			continue
+		}
+		path, ok := profilePromptPath(part)
+		if !ok {
+			return profile, fmt.Errorf("profile %q: %q is not a preset, an audience, or a prompt file", spec, part)
+		}
+		if profile.InstructionsPath != "" {
+			return profile, fmt.Errorf("profile %q has more than one prompt file", spec)
+		}
+		instructions, err := readTextFile(path, "prompt")
+		if err != nil {
+			return profile, err
+		}
+		if strings.TrimSpace(instructions) == "" {
+			return profile, fmt.Errorf("prompt file %s is empty", part)
+		}
+		profile.InstructionsPath, profile.Instructions = part, instructions
+	}
+	return profile, nil
+}
+
+// CompareProfilesFlags represents the arguments accepted by compare-profiles mode.
+type CompareProfilesFlags struct {
+	// SourceContent holds the contents of the -source resume, if any.
+	SourceContent string
+
+	// SourceWarning holds a warning about the -source file, such as an
+	// unsupported extension or removed instructions, for the caller to report.
+	SourceWarning string
+
+	// StdinContent holds the notes from -notes or, without it, from stdin.
+	StdinContent string
+
+	// JobDescription holds the contents of the -job file, whose keywords
+	// each resume's coverage is measured against, or is empty.
+	JobDescription string
+
+	// JobWarning holds a warning about the -job file, like SourceWarning.
+	JobWarning string
+
+	// Profiles holds the profiles to generate, in the order given.
+	Profiles []Profile
+
+	// Model holds the Gemini model used for every profile.
+	Model string
+
+	// OutputDir holds the directory each resume and the report are written
+	// to, or is empty to only print the report.
+	OutputDir string
+
+	sourcePath string   // The -source file, read by Complete
+	notesPath  string   // The -notes file, read by Complete
+	jobPath    string   // The -job file, read by Complete
+	specs      []string // The -profiles, parsed by Complete
+}
+
+// Bind defines compare-profiles mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the compare-profiles command
+func (f *CompareProfilesFlags) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&f.sourcePath, "source", "", "Path to an existing resume file (optional)")
+	fs.StringVar(&f.notesPath, "notes", "", "Path to a file of notes to add (default: read from stdin when piped)")
+	fs.StringSliceVar(&f.specs, "profiles", nil, "Profiles to compare, such as standard,new-grad+recruiter; each joins a preset, an audience, and a prompt file with +")
+	fs.StringVar(&f.jobPath, "job", "", "Path to a job description whose keywords each resume's coverage is measured against (default: the keywords of your inputs)")
+	fs.StringVar(&f.Model, "model", api.DefaultModelName, "Model to generate every profile with")
+	fs.StringVar(&f.OutputDir, "output-dir", "", "Directory to write each resume and the comparison report to (default: only print the report)")
+}
+
+// Complete checks the flags and reads the input files. The notes come from
+// -notes, or from stdin when no -notes file is given.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed; there should be none
+//   - stdin: The reader the notes are read from when no -notes file is given (can be nil)
+//
+// Returns:
+//   - error: An error if there are positional arguments, fewer than two
+//     profiles or the same one twice, a file cannot be read, or there is no input
+func (f *CompareProfilesFlags) Complete(args []string, stdin io.Reader) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument %q; pass inputs with -source and -notes", args[0])
+	}
+
+	seen := make(map[string]bool)
+	for _, spec := range f.specs {
+		profile, err := ParseProfile(spec)
+		if err != nil {
+			return err
+		}
+		key := fmt.Sprintf("%s|%s|%s", profile.Preset, profile.Audience, profile.Instructions)
+		if seen[key] {
+			return fmt.Errorf("profile %q is given more than once", spec)
+		}
+		seen[key] = true
+		f.Profiles = append(f.Profiles, profile)
+	}
+	if len(f.Profiles) < 2 {
+		return errors.New("nothing to compare; pass at least two profiles with -profiles, such as -profiles standard,new-grad")
+	}
+
+	var err error
+	if f.sourcePath != "" {
+		if f.SourceContent, f.SourceWarning, err = ReadPromptFile(f.sourcePath); err != nil {
+			return err
+		}
+	}
+	if f.notesPath != "" {
+		if f.StdinContent, err = readTextFile(f.notesPath, "notes"); err != nil {
+			return err
+		}
+	} else if stdin != nil {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("error reading notes: %w", err)
+		}
+		f.StdinContent = string(data)
+	}
+	if strings.TrimSpace(f.SourceContent) == "" && strings.TrimSpace(f.StdinContent) == "" {
+		return errors.New("nothing to generate from; pass a resume with -source or notes with -notes or stdin")
+	}
+	if f.jobPath != "" {
+		if f.JobDescription, f.JobWarning, err = ReadPromptFile(ExpandPath(f.jobPath)); err != nil {
+			return err
+		}
+	}
+	if f.OutputDir != "" {
+		f.OutputDir = ExpandPath(f.OutputDir)
+	}
+	return nil
+}
+
+// profilePromptPath finds the prompt file a part of a profile names: a path,
+// or the name of a file in the profiles directory of the data directory,
+// with or without its .txt or .md extension.
+func profilePromptPath(part string) (string, bool) {
+	if _, err := os.Stat(ExpandPath(part)); err == nil {
+		return ExpandPath(part), true
+	}
+	if part == "" || strings.ContainsAny(part, `/\`) {
+		return "", false
+	}
+	dir, err := config.DataPath(config.ProfilesDirName)
+	if err != nil {
+		return "", false
+	}
+	for _, name := range []string{part, part + ".txt", part + ".md"} {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// ParseCompareProfilesArgs parses the arguments that follow the
+// compare-profiles command and reads the input files, as Complete describes.
+//
+// Parameters:
+//   - args: The arguments after "compare-profiles"
+//   - stdin: The reader the notes are read from when no -notes file is given (can be nil)
+//
+// Returns:
+//   - CompareProfilesFlags: The parsed arguments and input contents
+//   - error: An error if the flags are invalid, a file cannot be read, or
+//     there is no input or fewer than two profiles
+//
+// Example:
+//
+//	flags, err := input.ParseCompareProfilesArgs([]string{"-source", "resume.md", "-profiles", "standard,new-grad"}, nil)
+func ParseCompareProfilesArgs(args []string, stdin io.Reader) (CompareProfilesFlags, error) {
+	var flags CompareProfilesFlags
+	fs := NewFlagSet("resumake compare-profiles")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args(), stdin)
+}
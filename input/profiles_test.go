@@ -0,0 +1,92 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+func TestParseProfile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.DirEnvVar, dir)
+	promptPath := filepath.Join(dir, "terse.txt")
+	if err := os.WriteFile(promptPath, []byte("Write a terse resume."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: A preset alone keeps the general audience and built-in prompt
+	profile, err := ParseProfile("new-grad")
+	if err != nil || profile.Preset != prompt.PresetNewGrad || profile.Audience != prompt.AudienceGeneral || profile.Instructions != "" {
+		t.Errorf("Unexpected profile %+v, %v", profile, err)
+	}
+
+	// Test case 2: Parts are joined with +, in any order
+	profile, err = ParseProfile(promptPath + "+recruiter")
+	if err != nil || profile.Preset != prompt.PresetStandard || profile.Audience != prompt.AudienceRecruiter || profile.Instructions != "Write a terse resume." {
+		t.Errorf("Unexpected profile %+v, %v", profile, err)
+	}
+
+	// Test case 3: Unknown parts and repeated kinds are errors
+	for _, spec := range []string{"senior", "standard+new-grad", "ats+recruiter", "", "standard+"} {
+		if _, err := ParseProfile(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+
+	// Test case 4: A prompt file in the sync directory's profiles directory is found by name
+	syncDir := filepath.Join(dir, "synced")
+	if err := os.MkdirAll(filepath.Join(syncDir, config.ProfilesDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(syncDir, config.ProfilesDirName, "startup.md"), []byte("Write for a startup."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, config.SettingsFileName), []byte("sync-dir = "+syncDir+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	profile, err = ParseProfile("startup+ats")
+	if err != nil || profile.Instructions != "Write for a startup." || profile.Audience != prompt.AudienceATS {
+		t.Errorf("Unexpected profile %+v, %v", profile, err)
+	}
+}
+
+func TestParseCompareProfilesArgs(t *testing.T) {
+	dir := t.TempDir()
+	sourcePath := filepath.Join(dir, "resume.md")
+	jobPath := filepath.Join(dir, "job.txt")
+	if err := os.WriteFile(sourcePath, []byte("# Jane Doe"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jobPath, []byte("Go engineer"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: The inputs and every profile are read
+	flags, err := ParseCompareProfilesArgs([]string{"-source", sourcePath, "-job", jobPath, "-profiles", "standard,new-grad+ats", "-output-dir", dir}, strings.NewReader("notes"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.SourceContent != "# Jane Doe" || flags.StdinContent != "notes" || flags.JobDescription != "Go engineer" || flags.OutputDir != dir {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+	if len(flags.Profiles) != 2 || flags.Profiles[1].Spec != "new-grad+ats" || flags.Profiles[1].Audience != prompt.AudienceATS || flags.Model != api.DefaultModelName {
+		t.Errorf("Unexpected profiles: %+v", flags.Profiles)
+	}
+
+	// Test case 2: At least two different profiles are required
+	for _, profiles := range []string{"standard", "standard,general"} {
+		if _, err := ParseCompareProfilesArgs([]string{"-source", sourcePath, "-profiles", profiles}, nil); err == nil {
+			t.Errorf("Expected an error for -profiles %s", profiles)
+		}
+	}
+
+	// Test case 3: Some input is required
+	if _, err := ParseCompareProfilesArgs([]string{"-profiles", "standard,new-grad"}, nil); err == nil {
+		t.Error("Expected an error without inputs")
+	}
+}
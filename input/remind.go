@@ -0,0 +1,102 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// RemindCommand is the first argument that selects remind mode, which
+// reports the resumes that have not been regenerated in a while, without
+// calling the API.
+const RemindCommand = "remind"
+
+// DefaultStaleAfter is the number of days after which a resume is stale when
+// neither -stale-after nor the stale-after setting says otherwise.
+const DefaultStaleAfter = 90
+
+// RemindFlags represents the arguments accepted by remind mode.
+type RemindFlags struct {
+	// Profile holds the profile to check, such as "jane-doe", or is empty to
+	// check every profile.
+	Profile string
+
+	// StaleAfter holds the number of days after which a resume is stale, or
+	// 0 to use the stale-after setting.
+	StaleAfter int
+
+	// Cron prints only the stale resumes, one tab-separated line each, and
+	// nothing when every resume is fresh, for scheduled runs.
+	Cron bool
+}
+
+// Bind defines remind mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the remind command
+func (f *RemindFlags) Bind(fs *pflag.FlagSet) {
+	fs.IntVar(&f.StaleAfter, "stale-after", 0, fmt.Sprintf("Days after which a resume needs regenerating (default: the stale-after setting, or %d)", DefaultStaleAfter))
+	fs.BoolVar(&f.Cron, "cron", false, "Print only the stale resumes, one tab-separated line each, and exit with status 2 if there are any")
+}
+
+// Complete takes the profile from the optional positional argument and
+// resolves the number of days after which a resume is stale.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
+//   - setting: The stale-after setting from the settings file (empty if not set)
+//
+// Returns:
+//   - error: An error if more than one profile is given, or the days are not
+//     a positive number
+func (f *RemindFlags) Complete(args []string, setting string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("unexpected argument %q; check one profile, or all of them", args[1])
+	}
+	if len(args) == 1 {
+		f.Profile = args[0]
+	}
+
+	if f.StaleAfter < 0 {
+		return fmt.Errorf("invalid -stale-after %d; it must be a positive number of days", f.StaleAfter)
+	}
+	if f.StaleAfter > 0 {
+		return nil
+	}
+	f.StaleAfter = DefaultStaleAfter
+	if setting = strings.TrimSpace(setting); setting != "" {
+		days, err := strconv.Atoi(setting)
+		if err != nil || days < 1 {
+			return fmt.Errorf("invalid stale-after setting %q; it must be a positive number of days", setting)
+		}
+		f.StaleAfter = days
+	}
+	return nil
+}
+
+// ParseRemindArgs parses the arguments that follow the remind command.
+// Flags may come before or after the profile.
+//
+// Parameters:
+//   - args: The arguments after "remind"
+//   - setting: The stale-after setting from the settings file (empty if not set)
+//
+// Returns:
+//   - RemindFlags: The parsed arguments
+//   - error: An error if the flags or the setting are invalid, or more than
+//     one profile is given
+//
+// Example:
+//
+//	flags, err := input.ParseRemindArgs([]string{"jane-doe", "-stale-after", "30"}, "")
+func ParseRemindArgs(args []string, setting string) (RemindFlags, error) {
+	var flags RemindFlags
+	fs := NewFlagSet("resumake remind")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args(), setting)
+}
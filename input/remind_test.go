@@ -0,0 +1,41 @@
+package input
+
+import "testing"
+
+func TestParseRemindArgs(t *testing.T) {
+	// Test case 1: Every profile is checked against the default threshold
+	flags, err := ParseRemindArgs(nil, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.Profile != "" || flags.StaleAfter != DefaultStaleAfter || flags.Cron {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+
+	// Test case 2: Flags may come before or after the profile
+	flags, err = ParseRemindArgs([]string{"-cron", "jane-doe", "-stale-after", "30"}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.Profile != "jane-doe" || flags.StaleAfter != 30 || !flags.Cron {
+		t.Errorf("Expected the flags on both sides of the profile to be parsed, got %+v", flags)
+	}
+
+	// Test case 3: The setting replaces the default, and the flag the setting
+	if flags, err = ParseRemindArgs(nil, "45"); err != nil || flags.StaleAfter != 45 {
+		t.Errorf("Expected the setting's 45 days, got %d (%v)", flags.StaleAfter, err)
+	}
+	if flags, err = ParseRemindArgs([]string{"-stale-after", "7"}, "45"); err != nil || flags.StaleAfter != 7 {
+		t.Errorf("Expected the flag's 7 days, got %d (%v)", flags.StaleAfter, err)
+	}
+
+	// Test case 4: Invalid thresholds and extra profiles are errors
+	for _, args := range [][]string{{"-stale-after", "-1"}, {"jane-doe", "john-roe"}} {
+		if _, err := ParseRemindArgs(args, ""); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+	if _, err := ParseRemindArgs(nil, "soon"); err == nil {
+		t.Error("Expected an error for an invalid setting")
+	}
+}
@@ -0,0 +1,93 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/prompt"
+	"github.com/spf13/pflag"
+)
+
+// RewriteCommand is the first argument that selects rewrite mode, which
+// rewrites a single bullet instead of running the resume pipeline.
+const RewriteCommand = "rewrite"
+
+// RewriteFlags represents the arguments accepted by rewrite mode.
+type RewriteFlags struct {
+	// Bullet holds the bullet to rewrite, taken from the positional
+	// arguments or, when there are none, from stdin.
+	Bullet string
+
+	// Count holds the number of alternatives to ask for.
+	Count int
+
+	// Model holds the Gemini model used for the rewrite.
+	Model string
+}
+
+// Bind defines rewrite mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the rewrite command
+func (f *RewriteFlags) Bind(fs *pflag.FlagSet) {
+	fs.IntVarP(&f.Count, "count", "n", prompt.DefaultRewrites, fmt.Sprintf("Number of alternatives to suggest (%d-%d)", prompt.MinRewrites, prompt.MaxRewrites))
+	fs.StringVar(&f.Model, "model", api.DefaultModelName, "Model to rewrite the bullet with")
+}
+
+// Complete checks the flags and reads the bullet from the positional
+// arguments, joined with spaces, or from stdin when there are none.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
+//   - stdin: The reader the bullet is read from when no argument is given (can be nil)
+//
+// Returns:
+//   - error: An error if the count is out of range or the bullet is empty
+func (f *RewriteFlags) Complete(args []string, stdin io.Reader) error {
+	if f.Count < prompt.MinRewrites || f.Count > prompt.MaxRewrites {
+		return fmt.Errorf("-n must be between %d and %d, got %d", prompt.MinRewrites, prompt.MaxRewrites, f.Count)
+	}
+
+	bullet := strings.Join(args, " ")
+	if bullet == "" && stdin != nil {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("error reading bullet: %w", err)
+		}
+		bullet = string(data)
+	}
+
+	f.Bullet = strings.TrimSpace(bullet)
+	if f.Bullet == "" {
+		return errors.New("no bullet to rewrite; pass it as an argument or pipe it to stdin")
+	}
+	return nil
+}
+
+// ParseRewriteArgs parses the arguments that follow the rewrite command.
+// The bullet is the positional arguments joined by spaces; when none are
+// given it is read from stdin, so a bullet can be piped in.
+//
+// Parameters:
+//   - args: The arguments after "rewrite"
+//   - stdin: The reader the bullet is read from when no positional argument is given
+//
+// Returns:
+//   - RewriteFlags: The parsed arguments
+//   - error: An error if the flags are invalid or the bullet is empty
+//
+// Example:
+//
+//	flags, err := input.ParseRewriteArgs([]string{"-n", "5", "Worked on the checkout page"}, os.Stdin)
+func ParseRewriteArgs(args []string, stdin io.Reader) (RewriteFlags, error) {
+	var flags RewriteFlags
+	fs := NewFlagSet("resumake rewrite")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args(), stdin)
+}
@@ -0,0 +1,41 @@
+package input
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+func TestParseRewriteArgs(t *testing.T) {
+	// Test case 1: The bullet comes from the positional arguments
+	flags, err := ParseRewriteArgs([]string{"-n", "5", "Worked", "on", "checkout"}, strings.NewReader("ignored"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.Bullet != "Worked on checkout" || flags.Count != 5 || flags.Model != api.DefaultModelName {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+
+	// Test case 2: Without arguments the bullet is read from stdin
+	flags, err = ParseRewriteArgs(nil, strings.NewReader("  - Fixed bugs\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.Bullet != "- Fixed bugs" || flags.Count != prompt.DefaultRewrites {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+
+	// Test case 3: An empty bullet is an error
+	if _, err := ParseRewriteArgs(nil, strings.NewReader(" \n")); err == nil {
+		t.Error("Expected an error for an empty bullet")
+	}
+
+	// Test case 4: The count must be in range
+	for _, n := range []string{"2", "6"} {
+		if _, err := ParseRewriteArgs([]string{"-n", n, "Fixed bugs"}, nil); err == nil {
+			t.Errorf("Expected an error for -n %s", n)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/spf13/pflag"
+)
+
+// TranslateCommand is the first argument that selects translate mode, which
+// localizes an existing resume into another language.
+const TranslateCommand = "translate"
+
+// TranslateFlags represents the arguments accepted by translate mode.
+type TranslateFlags struct {
+	// SourcePath holds the path of the resume to translate.
+	SourcePath string
+
+	// SourceContent holds the contents of SourcePath.
+	SourceContent string
+
+	// SourceWarning holds a warning about SourcePath, such as an unsupported
+	// extension or removed instructions, for the caller to report.
+	SourceWarning string
+
+	// Language holds the target language, such as "German" or "pt-BR".
+	Language string
+
+	// OutputPath holds where the translation is written, or is empty to
+	// write it next to the resume, named after the language.
+	OutputPath string
+
+	// Model holds the Gemini model used for the translation.
+	Model string
+}
+
+// Bind defines translate mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the translate command
+func (f *TranslateFlags) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&f.OutputPath, "output", "", "Path of the translated resume (default: next to the resume, named after the language)")
+	fs.StringVar(&f.Model, "model", api.DefaultModelName, "Model to translate the resume with")
+}
+
+// Complete reads the resume and language given as the two positional
+// arguments, in that order.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
+//
+// Returns:
+//   - error: An error if the resume or language is missing, there are extra
+//     arguments, or the resume cannot be read or is empty
+func (f *TranslateFlags) Complete(args []string) error {
+	switch {
+	case len(args) == 0:
+		return errors.New("no resume to translate; pass the path of a resume and a language")
+	case len(args) == 1 || strings.TrimSpace(args[1]) == "":
+		return errors.New("no language to translate into; pass one after the resume, such as German or pt-BR")
+	case len(args) > 2:
+		return fmt.Errorf("unexpected argument %q; quote a language of several words, such as \"Brazilian Portuguese\"", args[2])
+	}
+
+	content, warning, err := ReadPromptFile(args[0])
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("resume %s is empty", args[0])
+	}
+
+	f.SourcePath = args[0]
+	f.SourceContent = content
+	f.SourceWarning = warning
+	f.Language = strings.TrimSpace(args[1])
+	return nil
+}
+
+// ParseTranslateArgs parses the arguments that follow the translate command
+// and reads the resume. The resume and the target language are the two
+// positional arguments, in that order, and flags may come before, between,
+// or after them.
+//
+// Parameters:
+//   - args: The arguments after "translate"
+//
+// Returns:
+//   - TranslateFlags: The parsed arguments and the resume's contents
+//   - error: An error if the flags are invalid, the resume or language is
+//     missing, there are extra arguments, or the resume cannot be read or is empty
+//
+// Example:
+//
+//	flags, err := input.ParseTranslateArgs([]string{"resume.md", "German", "-output", "lebenslauf.md"})
+func ParseTranslateArgs(args []string) (TranslateFlags, error) {
+	var flags TranslateFlags
+	fs := NewFlagSet("resumake translate")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args())
+}
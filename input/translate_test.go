@@ -0,0 +1,60 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestParseTranslateArgs(t *testing.T) {
+	dir := t.TempDir()
+	resumePath := filepath.Join(dir, "resume.md")
+	if err := os.WriteFile(resumePath, []byte("# Jane Doe\n\n## Experience\n- Built X"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: The resume and language are read, with the default model
+	flags, err := ParseTranslateArgs([]string{resumePath, "German"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.SourcePath != resumePath || flags.SourceContent == "" || flags.Language != "German" || flags.OutputPath != "" || flags.Model != api.DefaultModelName {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+
+	// Test case 2: Flags may come before, between, or after the arguments
+	flags, err = ParseTranslateArgs([]string{"-model", "gemini-1.5-pro", resumePath, "-output", "cv.md", "Brazilian Portuguese"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.Model != "gemini-1.5-pro" || flags.OutputPath != "cv.md" || flags.Language != "Brazilian Portuguese" {
+		t.Errorf("Expected the flags around the arguments to be parsed, got %+v", flags)
+	}
+
+	// Test case 3: A resume and a language are required
+	if _, err := ParseTranslateArgs(nil); err == nil {
+		t.Error("Expected an error without a resume")
+	}
+	if _, err := ParseTranslateArgs([]string{resumePath}); err == nil {
+		t.Error("Expected an error without a language")
+	}
+
+	// Test case 4: A language of several words must be quoted
+	if _, err := ParseTranslateArgs([]string{resumePath, "Brazilian", "Portuguese"}); err == nil {
+		t.Error("Expected an error for an extra argument")
+	}
+
+	// Test case 5: A missing or empty resume is an error
+	if _, err := ParseTranslateArgs([]string{filepath.Join(dir, "missing.md"), "German"}); err == nil {
+		t.Error("Expected an error for a missing resume")
+	}
+	emptyPath := filepath.Join(dir, "empty.md")
+	if err := os.WriteFile(emptyPath, []byte("\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseTranslateArgs([]string{emptyPath, "German"}); err == nil {
+		t.Error("Expected an error for an empty resume")
+	}
+}
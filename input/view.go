@@ -0,0 +1,94 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// ViewCommand is the first argument that selects view mode, which shows a
+// resume in the terminal without calling the API.
+const ViewCommand = "view"
+
+// ViewFlags represents the arguments accepted by view mode.
+type ViewFlags struct {
+	// SourcePath holds the path of the resume to show, or is empty to show
+	// the resume most recently generated.
+	SourcePath string
+
+	// SourceContent holds the contents of SourcePath.
+	SourceContent string
+
+	// Renderer holds how the resume is shown: styled, plain, pager, or a
+	// pager command such as "bat".
+	Renderer string
+
+	// Width holds the width to wrap the resume to, or 0 for the terminal's.
+	Width int
+}
+
+// Bind defines view mode's flags on fs, storing their values in f.
+//
+// Parameters:
+//   - fs: The flag set of the view command
+func (f *ViewFlags) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&f.Renderer, "renderer", "", "How to show the resume: styled, plain, pager (uses PAGER), or a pager command such as bat (default: styled)")
+	fs.IntVar(&f.Width, "width", 0, "Width to wrap the resume to (default: the terminal's width, or 80)")
+}
+
+// Complete reads the resume named by the optional positional argument.
+//
+// Parameters:
+//   - args: The positional arguments, after the flags are parsed
+//
+// Returns:
+//   - error: An error if more than one resume is given, the width is
+//     negative, or the resume cannot be read or is empty
+func (f *ViewFlags) Complete(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("unexpected argument %q; view one resume at a time", args[1])
+	}
+	if f.Width < 0 {
+		return fmt.Errorf("invalid width %d; it must be positive", f.Width)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	content, err := ReadSourceFile(args[0])
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("resume %s is empty", args[0])
+	}
+
+	f.SourcePath = args[0]
+	f.SourceContent = content
+	return nil
+}
+
+// ParseViewArgs parses the arguments that follow the view command and reads
+// the resume, if one is named. Flags may come before or after the resume.
+//
+// Parameters:
+//   - args: The arguments after "view"
+//
+// Returns:
+//   - ViewFlags: The parsed arguments and the resume's contents
+//   - error: An error if the flags are invalid, more than one resume is
+//     given, or the resume cannot be read or is empty
+//
+// Example:
+//
+//	flags, err := input.ParseViewArgs([]string{"resume.md", "-renderer", "bat"})
+func ParseViewArgs(args []string) (ViewFlags, error) {
+	var flags ViewFlags
+	fs := NewFlagSet("resumake view")
+	flags.Bind(fs)
+	if err := fs.Parse(NormalizeArgs(args, fs)); err != nil {
+		return flags, err
+	}
+	return flags, flags.Complete(fs.Args())
+}
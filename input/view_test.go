@@ -0,0 +1,48 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseViewArgs(t *testing.T) {
+	dir := t.TempDir()
+	resumePath := filepath.Join(dir, "resume.md")
+	if err := os.WriteFile(resumePath, []byte("# Jane Doe\n\n## Experience\n- Built X"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: Without a resume the last one generated is shown
+	flags, err := ParseViewArgs(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.SourcePath != "" || flags.Renderer != "" || flags.Width != 0 {
+		t.Errorf("Unexpected flags: %+v", flags)
+	}
+
+	// Test case 2: Flags may come before or after the resume
+	flags, err = ParseViewArgs([]string{"-renderer", "less -R", resumePath, "-width", "60"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flags.SourcePath != resumePath || flags.SourceContent == "" || flags.Renderer != "less -R" || flags.Width != 60 {
+		t.Errorf("Expected the flags on both sides of the resume to be parsed, got %+v", flags)
+	}
+
+	// Test case 3: Only one resume can be shown at a time
+	if _, err := ParseViewArgs([]string{resumePath, resumePath}); err == nil {
+		t.Error("Expected an error for a second resume")
+	}
+
+	// Test case 4: A missing resume is an error
+	if _, err := ParseViewArgs([]string{filepath.Join(dir, "missing.md")}); err == nil {
+		t.Error("Expected an error for a missing resume")
+	}
+
+	// Test case 5: A negative width is an error
+	if _, err := ParseViewArgs([]string{"-width", "-5", resumePath}); err == nil {
+		t.Error("Expected an error for a negative width")
+	}
+}
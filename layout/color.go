@@ -0,0 +1,40 @@
+package layout
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// NoColorEnvVar turns off colors and text styles when it is set to any
+// value but the empty string, following the convention of no-color.org.
+const NoColorEnvVar = "NO_COLOR"
+
+// ColorsDisabled reports whether NO_COLOR asks for output without colors.
+//
+// Returns:
+//   - bool: True if colors and text styles should be left out
+//
+// Example:
+//
+//	if noColor || layout.ColorsDisabled() {
+//	    layout.DisableColors()
+//	}
+func ColorsDisabled() bool {
+	return os.Getenv(NoColorEnvVar) != ""
+}
+
+// DisableColors renders every lipgloss style as plain text, without colors,
+// bold, or other escape codes. NO_COLOR is set too, so renderers created
+// later and programs started by resumake, such as a pager, leave out colors
+// as well.
+//
+// Example:
+//
+//	layout.DisableColors()
+//	lipgloss.NewStyle().Bold(true).Render("Resume saved")  // "Resume saved"
+func DisableColors() {
+	os.Setenv(NoColorEnvVar, "1")
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
@@ -0,0 +1,39 @@
+package layout
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestColorsDisabled(t *testing.T) {
+	t.Setenv(NoColorEnvVar, "")
+	if ColorsDisabled() {
+		t.Error("Expected colors with NO_COLOR empty")
+	}
+	t.Setenv(NoColorEnvVar, "1")
+	if !ColorsDisabled() {
+		t.Error("Expected no colors with NO_COLOR set")
+	}
+}
+
+func TestDisableColors(t *testing.T) {
+	t.Setenv(NoColorEnvVar, "")
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	DisableColors()
+	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4")).Background(lipgloss.Color("#000000"))
+	if got := style.Render("Resume saved"); got != "Resume saved" {
+		t.Errorf("Expected unstyled text, got %q", got)
+	}
+	if !ColorsDisabled() {
+		t.Error("Expected NO_COLOR to be set for programs started later")
+	}
+	if profile := lipgloss.NewRenderer(io.Discard).ColorProfile(); profile != termenv.Ascii {
+		t.Errorf("Expected new renderers to follow NO_COLOR, got profile %v", profile)
+	}
+}
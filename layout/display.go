@@ -0,0 +1,84 @@
+package layout
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// DisplayEnvVar chooses how the interface is drawn ("fullscreen", "inline",
+// or "plain"), overriding the detection in DetectDisplay.
+const DisplayEnvVar = "RESUMAKE_DISPLAY"
+
+// Display is how the interactive interface is drawn.
+type Display string
+
+const (
+	// DisplayFullScreen draws the interface on the terminal's alternate
+	// screen, which is restored with the shell's output when it exits.
+	DisplayFullScreen Display = "fullscreen"
+
+	// DisplayInline draws the interface in place below the command, for
+	// terminals that have no alternate screen.
+	DisplayInline Display = "inline"
+
+	// DisplayPlain asks for the inputs with line prompts, for a dumb
+	// terminal or when there is no terminal to draw on.
+	DisplayPlain Display = "plain"
+)
+
+// noAltScreenTerms are the TERM values of terminals without an alternate
+// screen: the Linux and BSD consoles and hardware terminals, which would
+// leave the interface drawn over the shell's output.
+var noAltScreenTerms = map[string]bool{
+	"linux":  true,
+	"cons25": true,
+	"vt52":   true,
+	"vt100":  true,
+	"vt102":  true,
+	"vt220":  true,
+}
+
+// DetectDisplay chooses how to draw the interface for the terminal it runs
+// in. Without a terminal to read keys from and draw on, or on a dumb
+// terminal, the inputs are asked for with line prompts; a terminal without
+// an alternate screen, or whose capabilities are unknown because TERM is not
+// set, is drawn on inline. RESUMAKE_DISPLAY overrides the guess either way.
+//
+// Parameters:
+//   - interactive: Whether the keyboard and the output the interface draws on are terminals
+//
+// Returns:
+//   - Display: How to draw the interface
+//   - string: Why it is not drawn full screen, or an empty string
+//
+// Example:
+//
+//	display, reason := layout.DetectDisplay(term.IsTerminal(os.Stdout.Fd()))
+//	if reason != "" {
+//	    fmt.Println(reason)
+//	}
+func DetectDisplay(interactive bool) (Display, string) {
+	switch Display(strings.ToLower(strings.TrimSpace(os.Getenv(DisplayEnvVar)))) {
+	case DisplayFullScreen:
+		return DisplayFullScreen, ""
+	case DisplayInline:
+		return DisplayInline, "Drawing inline, as " + DisplayEnvVar + " asks"
+	case DisplayPlain:
+		return DisplayPlain, "Using line prompts, as " + DisplayEnvVar + " asks"
+	}
+
+	name := os.Getenv("TERM")
+	switch {
+	case !interactive:
+		return DisplayPlain, "No terminal to draw on; using line prompts"
+	case name == "dumb":
+		return DisplayPlain, "The terminal cannot draw the interface (TERM=dumb); using line prompts"
+	case noAltScreenTerms[name]:
+		return DisplayInline, "The terminal has no alternate screen (TERM=" + name + "); drawing inline"
+	case name == "" && runtime.GOOS != "windows":
+		// The Windows console sets no TERM but has an alternate screen
+		return DisplayInline, "TERM is not set, so the terminal's capabilities are unknown; drawing inline"
+	}
+	return DisplayFullScreen, ""
+}
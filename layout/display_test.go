@@ -0,0 +1,46 @@
+package layout
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestDetectDisplay(t *testing.T) {
+	tests := []struct {
+		name        string
+		goos        string // The only system the case applies to, or "" for all
+		env         map[string]string
+		interactive bool
+		expected    Display
+	}{
+		{"graphical terminal", "", map[string]string{"TERM": "xterm-256color"}, true, DisplayFullScreen},
+		{"no terminal", "", map[string]string{"TERM": "xterm-256color"}, false, DisplayPlain},
+		{"dumb terminal", "", map[string]string{"TERM": "dumb"}, true, DisplayPlain},
+		{"Linux console", "", map[string]string{"TERM": "linux"}, true, DisplayInline},
+		{"hardware terminal", "", map[string]string{"TERM": "vt100"}, true, DisplayInline},
+		{"TERM not set", "linux", nil, true, DisplayInline},
+		{"Windows console", "windows", nil, true, DisplayFullScreen},
+		{"forced full screen", "", map[string]string{DisplayEnvVar: "fullscreen", "TERM": "linux"}, true, DisplayFullScreen},
+		{"forced inline", "", map[string]string{DisplayEnvVar: "Inline", "TERM": "xterm-256color"}, true, DisplayInline},
+		{"forced plain", "", map[string]string{DisplayEnvVar: "plain", "TERM": "xterm-256color"}, true, DisplayPlain},
+		{"unknown override", "", map[string]string{DisplayEnvVar: "fancy", "TERM": "xterm-256color"}, true, DisplayFullScreen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.goos != "" && tt.goos != runtime.GOOS {
+				t.Skipf("Only applies on %s", tt.goos)
+			}
+			for _, name := range []string{DisplayEnvVar, "TERM"} {
+				t.Setenv(name, tt.env[name])
+			}
+			display, reason := DetectDisplay(tt.interactive)
+			if display != tt.expected {
+				t.Errorf("DetectDisplay(%v) = %q, want %q", tt.interactive, display, tt.expected)
+			}
+			if (reason == "") != (display == DisplayFullScreen) {
+				t.Errorf("Expected a reason only when not drawing full screen, got %q", reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package layout
+
+import (
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// EmojiEnvVar turns emoji icons on ("1") or off ("0"), overriding the
+// detection in EmojiSupported.
+const EmojiEnvVar = "RESUMAKE_EMOJI"
+
+// textTerms are the TERM values of consoles that draw every character from
+// one bitmap font without emoji: the Linux and BSD consoles and hardware
+// terminals.
+var textTerms = map[string]bool{
+	"linux":  true,
+	"cons25": true,
+	"vt100":  true,
+	"vt102":  true,
+	"vt220":  true,
+	"dumb":   true,
+}
+
+// emojiRegex matches the pictographs and dingbats used as icons, with the
+// variation selector that asks for their emoji form.
+var emojiRegex = regexp.MustCompile(`[\x{1F300}-\x{1FAFF}\x{2600}-\x{27BF}\x{23E9}-\x{23FA}]\x{FE0F}?`)
+
+// asciiIcons are the replacements for icons whose meaning a plain "*" would
+// lose. Each is padded to the width of the icon it replaces.
+var asciiIcons = map[string]string{
+	"⚠️": "!!",
+	"✅":  "ok",
+	"✓":  "+",
+	"✗":  "x",
+	"✖":  "x",
+	"✚":  "+",
+	"🎉":  "**",
+	"🚀":  ">>",
+	"💡":  "i",
+	"⏳":  "..",
+	"⏱️": "..",
+	"🔁":  "<>",
+	"🔀":  "<>",
+	"🔎":  "?",
+	"✏️": "~",
+	"✎":  "~",
+}
+
+// EmojiSupported reports whether the terminal draws emoji. The Linux
+// console, hardware terminals, and the classic Windows console host show
+// them as boxes or question marks, so ASCIIIcons is used there instead;
+// RESUMAKE_EMOJI overrides the guess either way.
+//
+// Returns:
+//   - bool: True if emoji icons should be shown
+//
+// Example:
+//
+//	if !layout.EmojiSupported() {
+//	    view = layout.ASCIIIcons(view)
+//	}
+func EmojiSupported() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EmojiEnvVar))) {
+	case "1", "true", "on":
+		return true
+	case "0", "false", "off":
+		return false
+	}
+
+	if textTerms[os.Getenv("TERM")] {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// Windows Terminal, VS Code, ConEmu, and mintty draw emoji; the console host does not
+		return os.Getenv("WT_SESSION") != "" || os.Getenv("TERM_PROGRAM") != "" ||
+			os.Getenv("ConEmuANSI") == "ON" || os.Getenv("TERM") != ""
+	}
+	return true
+}
+
+// ASCIIIcons replaces the emoji and symbols in text with ASCII of the same
+// width, so boxes and columns laid out around them stay aligned. Icons
+// without a fitting replacement become "*".
+//
+// Parameters:
+//   - text: The text to convert, which may contain escape codes
+//
+// Returns:
+//   - string: The text with only ASCII icons
+//
+// Example:
+//
+//	plain := layout.ASCIIIcons("🎉 Resume saved")  // "** Resume saved"
+func ASCIIIcons(text string) string {
+	return emojiRegex.ReplaceAllStringFunc(text, func(icon string) string {
+		replacement, ok := asciiIcons[icon]
+		if !ok {
+			replacement = "*"
+		}
+		width := ansi.StringWidth(icon)
+		if len(replacement) > width {
+			replacement = replacement[:width]
+		}
+		return replacement + strings.Repeat(" ", width-len(replacement))
+	})
+}
@@ -0,0 +1,56 @@
+package layout
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestEmojiSupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string // The only system the case applies to, or "" for all
+		env      map[string]string
+		expected bool
+	}{
+		{"Linux console", "", map[string]string{"TERM": "linux"}, false},
+		{"hardware terminal", "", map[string]string{"TERM": "vt220"}, false},
+		{"graphical terminal", "linux", map[string]string{"TERM": "xterm-256color"}, true},
+		{"console host", "windows", nil, false},
+		{"Windows Terminal", "windows", map[string]string{"WT_SESSION": "1"}, true},
+		{"forced on", "", map[string]string{EmojiEnvVar: "1", "TERM": "linux"}, true},
+		{"forced off", "", map[string]string{EmojiEnvVar: "off", "TERM": "xterm-256color"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.goos != "" && tt.goos != runtime.GOOS {
+				t.Skipf("Only applies on %s", tt.goos)
+			}
+			for _, name := range []string{EmojiEnvVar, "TERM", "TERM_PROGRAM", "WT_SESSION", "ConEmuANSI"} {
+				t.Setenv(name, tt.env[name])
+			}
+			if got := EmojiSupported(); got != tt.expected {
+				t.Errorf("EmojiSupported() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestASCIIIcons(t *testing.T) {
+	tests := map[string]string{
+		"🎉 Resume saved":       "** Resume saved",
+		"⚠️ Quota nearly used": "!! Quota nearly used",
+		"📄 Source: resume.md":  "*  Source: resume.md",
+		"✓ Done • ↑↓ to move":  "+ Done • ↑↓ to move",
+		"\x1b[1m🚀\x1b[0m Go":   "\x1b[1m>>\x1b[0m Go",
+	}
+	for input, want := range tests {
+		got := ASCIIIcons(input)
+		if got != want {
+			t.Errorf("ASCIIIcons(%q) = %q, want %q", input, got, want)
+		}
+		if Width(got) != Width(input) {
+			t.Errorf("Expected %q to keep the width of %q", got, input)
+		}
+	}
+}
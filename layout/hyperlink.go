@@ -0,0 +1,150 @@
+package layout
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HyperlinksEnvVar turns terminal hyperlinks on ("1") or off ("0"),
+// overriding the detection in HyperlinksSupported.
+const HyperlinksEnvVar = "RESUMAKE_HYPERLINKS"
+
+// urlRegex matches web addresses in text. Punctuation that ends a sentence
+// is trimmed from a match by LinkURLs.
+var urlRegex = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+
+// hyperlinkTerminals are the TERM_PROGRAM values of terminals that open OSC 8
+// hyperlinks.
+var hyperlinkTerminals = map[string]bool{
+	"iTerm.app": true,
+	"WezTerm":   true,
+	"vscode":    true,
+	"Hyper":     true,
+	"ghostty":   true,
+	"Tabby":     true,
+}
+
+// hyperlinkTerms are the TERM values of terminals that open OSC 8 hyperlinks.
+var hyperlinkTerms = map[string]bool{
+	"xterm-kitty":   true,
+	"xterm-ghostty": true,
+	"alacritty":     true,
+	"foot":          true,
+	"wezterm":       true,
+}
+
+// HyperlinksSupported reports whether the terminal shows OSC 8 hyperlinks
+// as clickable text. Terminals that do not understand them may print the
+// escape codes, so hyperlinks are only used where the terminal is known to
+// support them; RESUMAKE_HYPERLINKS overrides the guess either way.
+//
+// Returns:
+//   - bool: True if hyperlinks should be written
+//
+// Example:
+//
+//	if layout.HyperlinksSupported() {
+//	    path = layout.Hyperlink(layout.FileURL(path), path)
+//	}
+func HyperlinksSupported() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(HyperlinksEnvVar))) {
+	case "1", "true", "on":
+		return true
+	case "0", "false", "off":
+		return false
+	}
+
+	term := os.Getenv("TERM")
+	// Multiplexers drop the links unless configured to pass them through
+	if term == "dumb" || os.Getenv("TMUX") != "" || strings.HasPrefix(term, "screen") {
+		return false
+	}
+
+	switch {
+	case hyperlinkTerminals[os.Getenv("TERM_PROGRAM")], hyperlinkTerms[term]:
+		return true
+	case os.Getenv("WT_SESSION") != "", os.Getenv("KONSOLE_VERSION") != "", os.Getenv("KITTY_WINDOW_ID") != "", os.Getenv("DOMTERM") != "":
+		return true
+	}
+
+	// GNOME Terminal and other VTE terminals support them from VTE 0.50
+	version, err := strconv.Atoi(os.Getenv("VTE_VERSION"))
+	return err == nil && version >= 5000
+}
+
+// Hyperlink makes text a link to target with the OSC 8 escape sequence.
+// The text's width is unchanged, and terminals that support the sequence
+// open target when the text is clicked.
+//
+// Parameters:
+//   - target: The URL to open, such as one from FileURL
+//   - text: The text to show
+//
+// Returns:
+//   - string: The linked text, or text unchanged if target is empty
+//
+// Example:
+//
+//	link := layout.Hyperlink("https://ai.google.dev/docs", "Gemini docs")
+func Hyperlink(target, text string) string {
+	if target == "" || text == "" {
+		return text
+	}
+	return "\x1b]8;;" + target + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// FileURL returns the file:// URL of a path on this computer, made absolute
+// relative to the working directory.
+//
+// Parameters:
+//   - path: The file path
+//
+// Returns:
+//   - string: The URL, or an empty string if path is empty or cannot be made absolute
+//
+// Example:
+//
+//	u := layout.FileURL("resume.md")  // "file://laptop/home/jane/resume.md"
+func FileURL(path string) string {
+	if strings.TrimSpace(path) == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+
+	// Drive letters on Windows need a leading slash, as in file:///C:/resume.md
+	slashed := filepath.ToSlash(abs)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed
+	}
+
+	// The host name tells the terminal the file is on this computer, not the one
+	// at the other end of an SSH session
+	host, _ := os.Hostname()
+	return (&url.URL{Scheme: "file", Host: host, Path: slashed}).String()
+}
+
+// LinkURLs makes each web address in text a hyperlink to itself, so it stays
+// clickable when wrapping breaks it across lines.
+//
+// Parameters:
+//   - text: The text containing addresses
+//
+// Returns:
+//   - string: The text with each address linked
+//
+// Example:
+//
+//	linked := layout.LinkURLs("Gemini API documentation: https://ai.google.dev/docs")
+func LinkURLs(text string) string {
+	return urlRegex.ReplaceAllStringFunc(text, func(address string) string {
+		trimmed := strings.TrimRight(address, ".,;:!?")
+		return Hyperlink(trimmed, trimmed) + address[len(trimmed):]
+	})
+}
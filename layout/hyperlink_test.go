@@ -0,0 +1,67 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHyperlink(t *testing.T) {
+	got := Hyperlink("https://example.com", "Example")
+	if want := "\x1b]8;;https://example.com\x1b\\Example\x1b]8;;\x1b\\"; got != want {
+		t.Errorf("Hyperlink() = %q, want %q", got, want)
+	}
+	if Width(got) != Width("Example") {
+		t.Errorf("Expected the link to take the width of its text, got %d", Width(got))
+	}
+	if got := Hyperlink("", "Example"); got != "Example" {
+		t.Errorf("Expected text without a target unchanged, got %q", got)
+	}
+}
+
+func TestFileURL(t *testing.T) {
+	got := FileURL("/tmp/My Resume.md")
+	if !strings.HasPrefix(got, "file://") || !strings.HasSuffix(got, "/tmp/My%20Resume.md") {
+		t.Errorf("FileURL() = %q, want a file URL ending in /tmp/My%%20Resume.md", got)
+	}
+	if got := FileURL(""); got != "" {
+		t.Errorf("Expected no URL for an empty path, got %q", got)
+	}
+}
+
+func TestLinkURLs(t *testing.T) {
+	got := LinkURLs("See https://ai.google.dev/docs. Or not.")
+	want := "See " + Hyperlink("https://ai.google.dev/docs", "https://ai.google.dev/docs") + ". Or not."
+	if got != want {
+		t.Errorf("LinkURLs() = %q, want %q", got, want)
+	}
+}
+
+func TestHyperlinksSupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{"unknown terminal", map[string]string{"TERM": "xterm-256color"}, false},
+		{"iTerm2", map[string]string{"TERM_PROGRAM": "iTerm.app"}, true},
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, true},
+		{"Windows Terminal", map[string]string{"WT_SESSION": "1"}, true},
+		{"recent VTE", map[string]string{"VTE_VERSION": "7600"}, true},
+		{"old VTE", map[string]string{"VTE_VERSION": "4800"}, false},
+		{"inside tmux", map[string]string{"TERM_PROGRAM": "iTerm.app", "TMUX": "/tmp/tmux-0/default"}, false},
+		{"dumb terminal", map[string]string{"TERM": "dumb", "WT_SESSION": "1"}, false},
+		{"forced on", map[string]string{HyperlinksEnvVar: "1", "TERM": "dumb"}, true},
+		{"forced off", map[string]string{HyperlinksEnvVar: "0", "TERM_PROGRAM": "iTerm.app"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{HyperlinksEnvVar, "TERM", "TERM_PROGRAM", "TMUX", "WT_SESSION", "KONSOLE_VERSION", "KITTY_WINDOW_ID", "DOMTERM", "VTE_VERSION"} {
+				t.Setenv(name, tt.env[name])
+			}
+			if got := HyperlinksSupported(); got != tt.expected {
+				t.Errorf("HyperlinksSupported() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Package layout wraps and truncates text for display in the terminal.
+//
+// Widths are measured in terminal cells rather than bytes or runes: ANSI
+// styling takes no space and wide characters such as emoji and East Asian
+// text take two cells. Every view in the TUI wraps its text with Wrap, so
+// paragraphs, bullet lists, and long words break the same way on every
+// screen.
+package layout
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultWidth is the width text is wrapped to when no width is known.
+const DefaultWidth = 80
+
+// Ellipsis ends text shortened by Truncate.
+const Ellipsis = "…"
+
+// listMarkers are the bullets that start a list item. Numbered items such as
+// "1." and "2)" are recognized as well.
+var listMarkers = []string{"-", "*", "+", "•", "◦", "▸"}
+
+// Width returns the number of terminal cells text occupies, ignoring ANSI
+// escape codes. For text of several lines it is the sum of all lines, so
+// measure lines one at a time.
+//
+// Parameters:
+//   - text: The text to measure
+//
+// Returns:
+//   - int: The width in cells
+//
+// Example:
+//
+//	width := layout.Width(lipgloss.NewStyle().Bold(true).Render("🚀 Ready"))  // 8
+func Width(text string) int {
+	return ansi.StringWidth(text)
+}
+
+// Wrap wraps text so no line is wider than width cells. Each line of the
+// text is wrapped on its own, so line breaks and blank lines are kept. Runs of
+// spaces inside a line are collapsed. A line's indentation is repeated on the
+// lines it wraps onto, and lines that start with a list marker ("•", "-",
+// "1.", ...) get a hanging indent so the wrapped text lines up after the
+// marker. Words break at their own hyphens when that makes them fit, and words
+// wider than a line are hyphenated.
+//
+// Parameters:
+//   - text: The text to wrap
+//   - width: The maximum line width in cells (DefaultWidth if 0 or less)
+//
+// Returns:
+//   - string: The wrapped text
+//
+// Example:
+//
+//	wrapped := layout.Wrap("• Led a team of five engineers", 20)
+//	// "• Led a team of five\n  engineers"
+func Wrap(text string, width int) string {
+	if width <= 0 {
+		width = DefaultWidth
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	wrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapLine(line, width)...)
+	}
+	return strings.Join(wrapped, "\n")
+}
+
+// Truncate shortens text to at most width cells, ending it with an ellipsis
+// when anything was cut. Multi-byte and wide characters are never split, ANSI
+// escape codes are kept intact, and whitespace before the ellipsis is
+// dropped.
+//
+// Parameters:
+//   - text: The text to shorten
+//   - width: The maximum width in cells
+//
+// Returns:
+//   - string: The text, shortened if it was wider than width
+//
+// Example:
+//
+//	short := layout.Truncate("Senior Software Engineer", 10)  // "Senior So…"
+func Truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if Width(text) <= width {
+		return text
+	}
+
+	kept := ansi.Truncate(text, width-Width(Ellipsis), "")
+	return strings.TrimRight(kept, " \t\n") + Ellipsis
+}
+
+// wrapLine wraps a single line of text with a hanging indent. A line that is
+// empty or only whitespace wraps to a single empty line.
+func wrapLine(line string, width int) []string {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	// The first line starts with the indentation and any list marker, and
+	// the lines after it are indented to match. An indent too wide to leave
+	// room for text is dropped.
+	indent := strings.ReplaceAll(line[:len(line)-len(strings.TrimLeft(line, " \t"))], "\t", "    ")
+	prefix := indent
+	if isListMarker(words[0]) && len(words) > 1 {
+		prefix += words[0] + " "
+		words = words[1:]
+	}
+	if Width(prefix) > width/2 {
+		indent, prefix = "", ""
+		words = strings.Fields(line)
+	}
+	hang := strings.Repeat(" ", Width(prefix))
+
+	lines := wrapWords(words, width-Width(prefix))
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = hang + lines[i]
+		}
+	}
+	return lines
+}
+
+// wrapWords fills lines of at most width cells with words, breaking words
+// that do not fit.
+func wrapWords(words []string, width int) []string {
+	var lines []string
+	current := ""
+	for _, word := range words {
+		for word != "" {
+			room := width
+			if current != "" {
+				room = width - Width(current) - 1
+			}
+
+			if Width(word) <= room {
+				current = joinWords(current, word)
+				break
+			}
+
+			// Break at a hyphen in the word if the first part fits
+			if head, tail, ok := splitAtHyphen(word, room); ok {
+				lines = append(lines, joinWords(current, head))
+				current, word = "", tail
+				continue
+			}
+
+			// Move the word to a line of its own, or hyphenate it if it is
+			// too wide even for that
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+				continue
+			}
+			head, tail := hyphenate(word, width)
+			lines = append(lines, head)
+			word = tail
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// joinWords appends word to a line with a space between them.
+func joinWords(line, word string) string {
+	if line == "" {
+		return word
+	}
+	return line + " " + word
+}
+
+// splitAtHyphen splits a hyphenated word after the last hyphen that leaves a
+// first part no wider than room. Only hyphens between two letters or digits
+// count, so flags such as "-output" and dashes such as "--" are never split.
+func splitAtHyphen(word string, room int) (string, string, bool) {
+	for i := strings.LastIndexByte(word, '-'); i > 0; i = strings.LastIndexByte(word[:i], '-') {
+		before, _ := utf8.DecodeLastRuneInString(word[:i])
+		after, _ := utf8.DecodeRuneInString(word[i+1:])
+		if !isWordRune(before) || !isWordRune(after) {
+			continue
+		}
+		if Width(word[:i+1]) <= room {
+			return word[:i+1], word[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// hyphenate splits a word wider than width into a first part that fits on a
+// line, ending with a hyphen, and the rest. On lines too narrow for a hyphen
+// the word is split without one.
+func hyphenate(word string, width int) (string, string) {
+	room, hyphen := width-1, "-"
+	if room < 1 {
+		room, hyphen = width, ""
+	}
+
+	head := ansi.Truncate(word, room, "")
+	if head == "" {
+		// A wide character wider than the line still has to go somewhere
+		head = ansi.Truncate(word, 2, "")
+	}
+	tail := ansi.Cut(word, Width(head), Width(word))
+	if strings.HasSuffix(head, "-") {
+		hyphen = ""
+	}
+	return head + hyphen, tail
+}
+
+// isListMarker reports whether word starts a list item: a bullet, or a
+// number followed by "." or ")".
+func isListMarker(word string) bool {
+	for _, marker := range listMarkers {
+		if word == marker {
+			return true
+		}
+	}
+
+	number := strings.TrimRight(word, ".)")
+	if number == "" || len(word)-len(number) != 1 || len(number) > 3 {
+		return false
+	}
+	for _, r := range number {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordRune reports whether r is part of a word rather than punctuation.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
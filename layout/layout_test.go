@@ -0,0 +1,228 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// bold wraps text in the ANSI escape codes for bold text
+func bold(text string) string {
+	return "\x1b[1m" + text + "\x1b[0m"
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{"Plain text", "Resume", 6},
+		{"Accented letters take one cell", "Zürich", 6},
+		{"Emoji take two cells", "🚀 Ready", 8},
+		{"East Asian text takes two cells", "履歴書", 6},
+		{"ANSI styling takes no space", bold("Bold"), 4},
+		{"Empty text", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Width(tt.text); got != tt.expected {
+				t.Errorf("Width(%q) = %d, want %d", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected string
+	}{
+		{
+			name:     "Empty text",
+			text:     "",
+			width:    80,
+			expected: "",
+		},
+		{
+			name:     "Single word",
+			text:     "Hello",
+			width:    10,
+			expected: "Hello",
+		},
+		{
+			name:     "No wrapping needed",
+			text:     "Short text",
+			width:    20,
+			expected: "Short text",
+		},
+		{
+			name:     "Simple wrap",
+			text:     "This is a longer text that should wrap",
+			width:    10,
+			expected: "This is a\nlonger\ntext that\nshould\nwrap",
+		},
+		{
+			name:     "Zero width defaults to 80",
+			text:     "Text with zero width",
+			width:    0,
+			expected: "Text with zero width",
+		},
+		{
+			name:     "Negative width defaults to 80",
+			text:     "Text with negative width",
+			width:    -5,
+			expected: "Text with negative width",
+		},
+		{
+			name:     "Long words are hyphenated",
+			text:     "Supercalifragilisticexpialidocious is a very long word",
+			width:    10,
+			expected: "Supercali-\nfragilist-\nicexpiali-\ndocious is\na very\nlong word",
+		},
+		{
+			name:     "Long multi-byte words",
+			text:     "ÄÖÜäöüßéèê",
+			width:    4,
+			expected: "ÄÖÜ-\näöü-\nßéèê",
+		},
+		{
+			name:     "Hyphenated words break at their hyphen",
+			text:     "A detail-oriented engineer",
+			width:    16,
+			expected: "A detail-\noriented\nengineer",
+		},
+		{
+			name:     "Flags are not split at their hyphen",
+			text:     "Run with -legacy-output",
+			width:    12,
+			expected: "Run with\n-legacy-\noutput",
+		},
+		{
+			name:     "Multiple spaces",
+			text:     "Text   with   multiple   spaces",
+			width:    10,
+			expected: "Text with\nmultiple\nspaces",
+		},
+		{
+			name:     "Line breaks and blank lines are kept",
+			text:     "Work Experience:\n\nSkills: Go, SQL",
+			width:    20,
+			expected: "Work Experience:\n\nSkills: Go, SQL",
+		},
+		{
+			name:     "Bullets get a hanging indent",
+			text:     "• Highlight metrics and results when possible\n• Use bullet points",
+			width:    20,
+			expected: "• Highlight metrics\n  and results when\n  possible\n• Use bullet points",
+		},
+		{
+			name:     "Numbered items get a hanging indent",
+			text:     "1. Optionally provide an existing resume",
+			width:    20,
+			expected: "1. Optionally\n   provide an\n   existing resume",
+		},
+		{
+			name:     "Indented bullets keep their indent",
+			text:     "   • PDF: Use a markdown editor",
+			width:    20,
+			expected: "   • PDF: Use a\n     markdown editor",
+		},
+		{
+			name:     "Wide characters wrap by cell width",
+			text:     "🚀 🚀 🚀 🚀",
+			width:    6,
+			expected: "🚀 🚀\n🚀 🚀",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Wrap(tt.text, tt.width)
+			if result != tt.expected {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.text, tt.width, result, tt.expected)
+			}
+
+			// No line may exceed the width or split a character
+			if tt.width > 0 {
+				for i, line := range strings.Split(result, "\n") {
+					if Width(line) > tt.width || !utf8.ValidString(line) {
+						t.Errorf("Line %d exceeds width %d or splits a character: %q", i+1, tt.width, line)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWrapStyledText(t *testing.T) {
+	text := bold("Senior") + " Software Engineer at " + bold("Acme")
+
+	result := Wrap(text, 16)
+	lines := strings.Split(result, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected styled text to wrap by its visible width into 2 lines, got %q", result)
+	}
+	for i, line := range lines {
+		if Width(line) > 16 {
+			t.Errorf("Line %d exceeds width 16: %q", i+1, line)
+		}
+	}
+}
+
+func TestWrapNarrowWidths(t *testing.T) {
+	// Very narrow widths must still make progress through every word
+	for width := 1; width <= 4; width++ {
+		result := Wrap("Internationalization 🚀 résumé", width)
+		for i, line := range strings.Split(result, "\n") {
+			if line == "" {
+				t.Errorf("Width %d produced an empty line %d: %q", width, i+1, result)
+			}
+			if Width(line) > max(width, 2) {
+				t.Errorf("Width %d produced a line that is too wide: %q", width, line)
+			}
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected string
+	}{
+		{"Short text is unchanged", "Go developer", 20, "Go developer"},
+		{"Exact width is unchanged", "Go developer", 12, "Go developer"},
+		{"Long text ends with an ellipsis", "Go developer at Acme", 10, "Go develo…"},
+		{"Trailing space before the ellipsis is dropped", "Go developer", 4, "Go…"},
+		{"Multi-byte characters are never split", "Développeur à Zürich", 9, "Développ…"},
+		{"Emoji take two cells", "🚀🚀🚀🚀", 5, "🚀🚀…"},
+		{"Zero width is empty", "Go", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Truncate(tt.text, tt.width)
+			if result != tt.expected {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.text, tt.width, result, tt.expected)
+			}
+			if !utf8.ValidString(result) || Width(result) > max(tt.width, 0) {
+				t.Errorf("Truncate(%q, %d) = %q is too wide or invalid UTF-8", tt.text, tt.width, result)
+			}
+		})
+	}
+}
+
+func TestTruncateStyledText(t *testing.T) {
+	text := bold("Senior Software Engineer")
+	result := Truncate(text, 10)
+	if Width(result) > 10 || !strings.HasSuffix(ansi.Strip(result), "Senior So…") {
+		t.Errorf("Expected the styled text to be cut by its visible width, got %q", result)
+	}
+}
@@ -0,0 +1,175 @@
+// Package logging writes resumake's debug log.
+//
+// Debug logging is off by default. Setting RESUMAKE_DEBUG=1 copies the
+// standard logger's output, and the extra detail written with Debugf, to a
+// log file in the logs directory inside the resumake configuration directory
+// (see config.Dir). The file is rotated when it grows past MaxLogSize or when
+// the date changes, and only MaxLogBackups rotated files are kept. Everything
+// is redacted before it is written, so API keys, email addresses, and phone
+// numbers never reach disk and the log is safe to attach to bug reports.
+package logging
+
+import (
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/phrazzld/resumake/config"
+)
+
+// DebugEnvVar is the environment variable that turns on debug logging.
+const DebugEnvVar = "RESUMAKE_DEBUG"
+
+// DirName is the name of the logs directory inside the configuration directory.
+const DirName = "logs"
+
+// FileName is the name of the current log file.
+const FileName = "resumake.log"
+
+// MaxLogSize is the size at which the log file is rotated (1MB).
+const MaxLogSize = 1024 * 1024
+
+// MaxLogBackups is the number of rotated log files kept.
+const MaxLogBackups = 5
+
+// redacted replaces anything removed by Redact.
+const redacted = "[REDACTED]"
+
+var (
+	// apiKeyPattern matches Google API keys, which all start with "AIza".
+	apiKeyPattern = regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}`)
+
+	// secretPattern matches secrets passed as parameters or headers, such as
+	// "key=..." in a URL, "api_key: ...", or "Authorization: Bearer ...".
+	secretPattern = regexp.MustCompile(`(?i)\b((?:api[_-]?key|key|token|secret|password)["']?\s*[:=]\s*["']?|bearer\s+)[^\s"'&,]+`)
+
+	// emailPattern matches email addresses.
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// phonePattern matches runs of digits and phone punctuation; only runs
+	// with enough digits to be a phone number are redacted.
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().\-]{8,}\d`)
+)
+
+// debugLogger writes Debugf messages. It is nil while debug logging is off.
+var debugLogger *log.Logger
+
+// Enabled reports whether debug logging was requested with RESUMAKE_DEBUG.
+func Enabled() bool {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(DebugEnvVar)))
+	return value != "" && value != "0" && value != "false"
+}
+
+// Setup starts debug logging when RESUMAKE_DEBUG is set: the standard
+// logger's output is copied to the redacted, rotating log file, and Debugf
+// starts writing to it. It does nothing when debug logging is off.
+//
+// Returns:
+//   - func() error: Closes the log file (a no-op when debug logging is off)
+//   - error: An error if the log file could not be opened
+//
+// Example:
+//
+//	closeLog, err := logging.Setup()
+//	if err != nil {
+//	    log.Printf("Debug logging disabled: %v", err)
+//	} else {
+//	    defer closeLog()
+//	}
+func Setup() (func() error, error) {
+	if !Enabled() {
+		return func() error { return nil }, nil
+	}
+
+	path, err := config.Path(DirName, FileName)
+	if err != nil {
+		return nil, err
+	}
+	file, err := OpenRotatingFile(path, MaxLogSize, MaxLogBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	out := RedactingWriter(file)
+	log.SetOutput(io.MultiWriter(log.Writer(), out))
+	debugLogger = log.New(out, "debug: ", log.LstdFlags)
+	return file.Close, nil
+}
+
+// Debugf writes a message to the debug log only, never to the terminal.
+// It does nothing while debug logging is off.
+//
+// Parameters:
+//   - format: A fmt format string
+//   - args: The values for the format string
+//
+// Example:
+//
+//	logging.Debugf("Generating with model %s", modelName)
+func Debugf(format string, args ...any) {
+	if debugLogger != nil {
+		debugLogger.Printf(format, args...)
+	}
+}
+
+// Redact removes API keys, secrets, email addresses, and phone numbers from
+// text. The value of GEMINI_API_KEY is always removed, whatever its format.
+//
+// Parameters:
+//   - text: The text to redact
+//
+// Returns:
+//   - string: The text with sensitive values replaced by [REDACTED]
+//
+// Example:
+//
+//	logging.Redact("key=AIzaSy... jane@example.com")
+//	// "key=[REDACTED] [REDACTED]"
+func Redact(text string) string {
+	if key := strings.TrimSpace(os.Getenv("GEMINI_API_KEY")); len(key) >= 8 {
+		text = strings.ReplaceAll(text, key, redacted)
+	}
+	text = apiKeyPattern.ReplaceAllString(text, redacted)
+	text = secretPattern.ReplaceAllString(text, "${1}"+redacted)
+	text = emailPattern.ReplaceAllString(text, redacted)
+	return phonePattern.ReplaceAllStringFunc(text, func(match string) string {
+		digits := 0
+		for _, r := range match {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if digits < 10 || digits > 15 {
+			return match
+		}
+		return redacted
+	})
+}
+
+// redactingWriter redacts everything before passing it on.
+type redactingWriter struct {
+	w io.Writer
+}
+
+// RedactingWriter returns a writer that redacts each write with Redact before
+// writing it to w. The standard logger makes one write per message, so
+// values are never split across writes.
+//
+// Parameters:
+//   - w: The destination
+//
+// Returns:
+//   - io.Writer: The redacting writer
+func RedactingWriter(w io.Writer) io.Writer {
+	return redactingWriter{w: w}
+}
+
+// Write redacts p and writes it, reporting all of p as written on success.
+func (r redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
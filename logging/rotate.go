@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingFile is a log file that is rotated when it would grow past a
+// maximum size or when the date changes. The current file keeps its name;
+// rotated files get a timestamp, such as resumake-20250102-150405.log.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	now        func() time.Time // Replaced in tests
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	day  string // The date the current file was started, as YYYY-MM-DD
+}
+
+// OpenRotatingFile opens the log file at path for appending, creating it and
+// its directory if needed. A file left over from an earlier day is rotated
+// on the first write.
+//
+// Parameters:
+//   - path: The path of the current log file
+//   - maxSize: The size in bytes at which the file is rotated
+//   - maxBackups: The number of rotated files to keep
+//
+// Returns:
+//   - *RotatingFile: The open log file
+//   - error: An error if the file could not be opened
+//
+// Example:
+//
+//	file, err := logging.OpenRotatingFile("logs/resumake.log", logging.MaxLogSize, logging.MaxLogBackups)
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups, now: time.Now}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the current log file and records its size and start date.
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.day = f.now().Format(time.DateOnly)
+	if f.size > 0 {
+		f.day = info.ModTime().Format(time.DateOnly)
+	}
+	return nil
+}
+
+// Write appends p to the log file, rotating it first if p would take it past
+// the maximum size or if the file was started on an earlier day.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.size > 0 && (f.size+int64(len(p)) > f.maxSize || f.now().Format(time.DateOnly) != f.day) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// rotate renames the current file with a timestamp, removes the oldest
+// rotated files, and starts a new file.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+	rotated := fmt.Sprintf("%s-%s%s", base, f.now().Format("20060102-150405"), ext)
+	if err := os.Rename(f.path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	// Timestamps sort by name, oldest first
+	backups, err := filepath.Glob(base + "-*" + ext)
+	if err == nil && len(backups) > f.maxBackups {
+		sort.Strings(backups)
+		for _, old := range backups[:len(backups)-f.maxBackups] {
+			os.Remove(old)
+		}
+	}
+
+	return f.open()
+}
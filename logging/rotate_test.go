@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "resumake.log")
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	file, err := OpenRotatingFile(path, 20, 2)
+	if err != nil {
+		t.Fatalf("OpenRotatingFile() error = %v", err)
+	}
+	defer file.Close()
+	file.now = func() time.Time { return now }
+	file.day = now.Format(time.DateOnly)
+
+	write := func(text string) {
+		t.Helper()
+		if _, err := file.Write([]byte(text)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	backups := func() []string {
+		t.Helper()
+		matches, _ := filepath.Glob(filepath.Join(dir, "logs", "resumake-*.log"))
+		return matches
+	}
+
+	// Test case 1: Writes within the size limit share a file
+	write("first line\n")
+	write("second\n")
+	if len(backups()) != 0 {
+		t.Fatalf("Expected no rotation yet, got %v", backups())
+	}
+
+	// Test case 2: A write past the size limit rotates first
+	write("third line\n")
+	if got := backups(); len(got) != 1 || !strings.HasSuffix(got[0], "resumake-20250102-150405.log") {
+		t.Fatalf("Expected one timestamped backup, got %v", got)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "third line\n" {
+		t.Errorf("Expected the new file to hold only the latest write, got %q", data)
+	}
+
+	// Test case 3: A new day rotates even when the file is small
+	now = now.Add(24 * time.Hour)
+	write("next day\n")
+	if len(backups()) != 2 {
+		t.Errorf("Expected a rotation on the new day, got %v", backups())
+	}
+
+	// Test case 4: Only maxBackups rotated files are kept, dropping the oldest
+	now = now.Add(time.Hour)
+	write("another long line\n")
+	got := backups()
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 backups to be kept, got %v", got)
+	}
+	for _, backup := range got {
+		if strings.HasSuffix(backup, "resumake-20250102-150405.log") {
+			t.Errorf("Expected the oldest backup to be removed, got %v", got)
+		}
+	}
+
+	// Test case 5: Writes after Close fail
+	file.Close()
+	if _, err := file.Write([]byte("late\n")); err == nil {
+		t.Error("Expected an error writing to a closed log file")
+	}
+}
+
+func TestOpenRotatingFileKeepsExistingLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resumake.log")
+	if err := os.WriteFile(path, []byte("earlier run\n"), 0600); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+
+	file, err := OpenRotatingFile(path, MaxLogSize, MaxLogBackups)
+	if err != nil {
+		t.Fatalf("OpenRotatingFile() error = %v", err)
+	}
+	file.Write([]byte("this run\n"))
+	file.Close()
+
+	if data, _ := os.ReadFile(path); string(data) != "earlier run\nthis run\n" {
+		t.Errorf("Expected the log to be appended to, got %q", data)
+	}
+}
@@ -9,39 +9,126 @@
 package main
 
 import (
+	"bytes"
 	"context"
-	"flag"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
 	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/faults"
+	"github.com/phrazzld/resumake/history"
 	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/logging"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
 	"github.com/phrazzld/resumake/tui"
+	"github.com/phrazzld/resumake/usage"
+	"github.com/phrazzld/resumake/vault"
 )
 
 func main() {
-	fmt.Println("Resumake: A CLI tool for generating resumes")
+	// RESUMAKE_DEBUG also writes log output to a rotating, redacted log file
+	closeLog, err := logging.Setup()
+	if err != nil {
+		log.Printf("Debug logging disabled: %v", err)
+	} else {
+		defer closeLog()
+	}
 	
-	// Parse command-line flags
-	flags, err := input.ParseFlags()
+	// The settings file sets file limits for every mode; flags override it below
+	settings, err := config.LoadSettings()
 	if err != nil {
-		// Check if the error is due to the user requesting help
-		if err == flag.ErrHelp {
-			// The flag package already printed usage information
-			// We just need to exit cleanly
-			os.Exit(0)
+		log.Fatalf("Error reading settings: %v", err)
+	}
+	if err := input.SetLimits(settings[config.SettingMaxFileSize], settings[config.SettingExtensions]); err != nil {
+		log.Fatalf("Error in settings file: %v", err)
+	}
+	if err := api.SetGateway(settings[config.SettingAPIEndpoint], settings[config.SettingAPIToken], settings[config.SettingAPIHeaders]); err != nil {
+		log.Fatalf("Error in settings file: %v", err)
+	}
+	
+	// Saved resumes are encrypted when the settings file turns encryption on
+	encryption, err := vault.ParseMode(settings[config.SettingEncryption])
+	if err != nil {
+		log.Fatalf("Error in settings file: %v", err)
+	}
+	vault.Set(encryption, passphrasePrompt(os.Stdin, os.Stderr))
+	
+	// Every request is added to the usage ledger, which the confirm screen
+	// checks against the free-tier quota
+	if usagePath, err := usage.DefaultPath(); err == nil {
+		api.SetUsageRecorder(func(modelName string, tokens int) {
+			if err := usage.Record(usagePath, usage.Entry{Time: time.Now(), Model: modelName, Tokens: tokens}); err != nil {
+				logging.Debugf("Could not record API usage: %v", err)
+			}
+		})
+	}
+	
+	// The command-line arguments select a command, or generate a resume
+	root := newRootCommand()
+	root.SetArgs(normalizeCommandArgs(root, os.Args[1:]))
+	if err := root.Execute(); err != nil {
+		var cmdErr commandError
+		if !errors.As(err, &cmdErr) {
+			err = commandError{"Error parsing arguments", err}
 		}
-		// For any other parsing error, log fatally
-		log.Fatalf("Error parsing flags: %v", err)
+		log.Print(err)
+		os.Exit(exitCode(err))
 	}
+}
+
+// runGenerate asks for the inputs and generates a resume, with the TUI or,
+// in plain mode, with line prompts. It exits the program when the run fails.
+func runGenerate(flags input.Flags) {
+	// With -output -, standard output carries only the resume, so everything
+	// else is written to standard error
+	console := os.Stdout
+	toStdout := slices.Contains(flags.OutputPaths, output.StdoutPath)
+	if toStdout {
+		console = os.Stderr
+	}
+	
+	fmt.Fprintln(console, "Resumake: A CLI tool for generating resumes")
+	
+	logging.Debugf("Starting with flags %+v", flags)
 	
 	// Create a cancellable context
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel() // Ensure context is cancelled when main exits
 	
+	// Source file limits from flags override the settings file
+	if err := input.SetLimits(flags.MaxFileSize, flags.Extensions); err != nil {
+		log.Fatalf("Error parsing file limits: %v", err)
+	}
+	
+	// Written files are private unless -output-mode allows more
+	var err error
+	output.FileMode, err = output.ParseFileMode(flags.OutputMode)
+	if err != nil {
+		log.Fatalf("Error parsing output mode: %v", err)
+	}
+	
+	// Resumes are written with the -line-endings choice, by default the
+	// operating system's, for editors such as Notepad
+	output.LineEnding, err = output.ParseLineEndings(flags.LineEndings)
+	if err != nil {
+		log.Fatalf("Error parsing line endings: %v", err)
+	}
+	
 	// Initialize the Bubble Tea model with flags for pre-filling inputs
 	model := tui.NewModel()
 	
@@ -53,29 +140,427 @@ func main() {
 		model = model.WithSourcePath(flags.SourcePath)
 	}
 	
+	// The -output paths and -formats list select every file one run writes
+	targets, err := output.ParseOutputTargets(flags.OutputPaths, flags.Formats)
+	if err != nil {
+		log.Fatalf("Error parsing output paths: %v", err)
+	}
+	
+	// Files named after the resume cannot be written when it goes to standard output
+	if toStdout && (flags.Bundle || flags.Pack || flags.Layout != "" || flags.JSONResume || flags.Exports != "" || flags.Explain || flags.Gaps || flags.Documents != "") {
+		log.Fatalf("Error: -output - writes only the Markdown resume to standard output, so it cannot be combined with -bundle, -pack, -layout, -json-resume, -export, -explain, -gaps, or -documents")
+	}
+	
 	// If an output path was provided via flags, set it in the model
-	if flags.OutputPath != "" {
-		model = model.WithOutputPath(flags.OutputPath)
+	if targets.MarkdownPath != "" {
+		model = model.WithOutputPath(targets.MarkdownPath)
+	}
+	
+	// Without an output path the resume is named after the candidate and date,
+	// unless the legacy resume_out.md is requested
+	if flags.LegacyOutput {
+		model = model.WithLegacyOutput(true)
+	}
+	
+	// Bundle mode also generates a matching cover letter
+	if flags.Bundle {
+		model = model.WithBundle(true)
+	}
+	
+	// The fallback model is retried once if the primary model fails
+	model = model.WithFallbackModel(flags.FallbackModel)
+	
+	// A target employer is researched first so the resume echoes its language
+	if flags.Company != "" {
+		model = model.WithCompany(flags.Company)
+	}
+	
+	// Emphasized keywords are asked for in the prompt and checked in the result
+	if keywords := prompt.ParseEmphasis(flags.Emphasize); len(keywords) > 0 {
+		model = model.WithEmphasize(keywords)
+	}
+	
+	// Strict grounding has every bullet cite the inputs it comes from
+	grounding, err := prompt.ParseGrounding(flags.Grounding)
+	if err != nil {
+		log.Fatalf("Error parsing grounding: %v", err)
+	}
+	model = model.WithGrounding(grounding)
+	
+	// A preset changes the tone and section order for the kind of candidate
+	preset, err := prompt.ParsePreset(flags.Preset)
+	if err != nil {
+		log.Fatalf("Error parsing preset: %v", err)
+	}
+	model = model.WithPreset(preset)
+	
+	// The audience changes what the prompt asks the resume to emphasize
+	audience, err := prompt.ParseAudience(flags.Audience)
+	if err != nil {
+		log.Fatalf("Error parsing audience: %v", err)
+	}
+	model = model.WithAudience(audience)
+	
+	// The GPA and coursework are shown in the Education section
+	academics := document.Academics{GPA: strings.TrimSpace(flags.GPA), Coursework: prompt.ParseCoursework(flags.Coursework)}
+	if err := academics.Validate(); err != nil {
+		log.Fatalf("Error parsing -gpa: %v", err)
+	}
+	model = model.WithAcademics(academics)
+	
+	// A seed makes generation reproducible; it was checked when the flags were parsed
+	if seed, ok, _ := flags.SamplingSeed(); ok {
+		model = model.WithSeed(seed)
+	}
+	
+	// A forced failure exercises the error handling without a real one
+	if flags.InjectError != "" {
+		fault, err := faults.Parse(flags.InjectError)
+		if err != nil {
+			log.Fatalf("Error parsing -inject-error: %v", err)
+		}
+		faults.Set(fault)
+		logging.Debugf("Injecting %s failures at the %s stage", fault.Category, fault.Stage)
+	}
+	
+	// The model's reasons for its major changes are saved as notes
+	if flags.Explain {
+		model = model.WithExplain(true)
+	}
+	
+	// Each section is accepted, edited, or regenerated before the resume is saved
+	if flags.Review {
+		model = model.WithReview(true)
+	}
+	
+	// A job description enables keyword comparison in the analysis view
+	if flags.JobPath != "" {
+		jobDescription, warning, err := input.ReadPromptFile(flags.JobPath)
+		if err != nil {
+			log.Fatalf("Error reading job description: %v", err)
+		}
+		if warning != "" {
+			log.Printf("Warning: %s", warning)
+		}
+		model = model.WithJobDescription(jobDescription).WithJobPath(flags.JobPath)
+	}
+	
+	// Suggestions for closing the gaps with the job are saved as an appendix
+	if flags.Gaps {
+		if flags.JobPath == "" {
+			log.Fatalf("Error: -gaps compares the resume with a job description, so it requires -job")
+		}
+		model = model.WithGaps(true)
+	}
+	
+	// Other documents, such as a thank-you email, are written after the resume
+	documents, err := prompt.ParseDocumentTypes(flags.Documents)
+	if err != nil {
+		log.Fatalf("Error parsing documents: %v", err)
+	}
+	model = model.WithDocuments(documents)
+	
+	// A layout also renders the resume as HTML; an HTML output uses the standard layout
+	if flags.Layout != "" {
+		layout, err := output.ParseLayout(flags.Layout)
+		if err != nil {
+			log.Fatalf("Error parsing layout: %v", err)
+		}
+		model = model.WithLayout(layout)
+	} else if targets.HTML || flags.Pack {
+		model = model.WithLayout(output.LayoutStandard)
+	}
+	
+	// The timeline is a section of the HTML resume
+	if flags.Timeline {
+		if flags.Layout == "" && !targets.HTML {
+			log.Fatalf("Error: -timeline adds a section to the HTML resume, so it requires -layout or an HTML output")
+		}
+		model = model.WithTimeline(true)
+	}
+	
+	// Page-break markers are drawn on the HTML resume
+	if flags.PageBreaks {
+		if flags.Layout == "" && !targets.HTML {
+			log.Fatalf("Error: -page-breaks marks the HTML resume, so it requires -layout or an HTML output")
+		}
+		model = model.WithPageBreaks(true)
+	}
+	
+	// Prose is written one sentence per line with -wrap, for readable diffs
+	if flags.Wrap > 0 {
+		model = model.WithProseWidth(flags.Wrap)
+	}
+	
+	// The QR code goes in the HTML and PDF header; the settings file is the profile it comes from
+	settings, _ := config.LoadSettings() // Already checked at startup
+	qrLink, qrLayouts, err := qrOptions(flags, settings)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	if qrLink != "" {
+		model = model.WithQR(qrLink, qrLayouts)
+	}
+	
+	// A JSON Resume export is validated before it is written
+	if flags.JSONResume || targets.JSON {
+		model = model.WithJSONResume(true)
+	}
+	
+	// Exports convert the resume to document formats with pandoc, or natively
+	formats, err := output.ParseExportFormats(flags.Exports)
+	if err != nil {
+		log.Fatalf("Error parsing export formats: %v", err)
+	}
+	for _, format := range targets.Exports {
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	
+	// A pack is printable, so it always holds a PDF and an HTML resume
+	if flags.Pack {
+		if !slices.Contains(formats, output.FormatPDF) {
+			formats = append(formats, output.FormatPDF)
+		}
+		model = model.WithPack(true)
+	}
+	if len(formats) > 0 {
+		model = model.WithExports(formats)
+	}
+	
+	// Fixtures record API responses, or replay them without calling the API
+	fixtures, err := flags.Fixtures()
+	if err != nil {
+		log.Fatalf("Error parsing fixture flags: %v", err)
+	}
+	model = model.WithFixtures(fixtures)
+	
+	// Input that exceeds the context window is trimmed in this order
+	trimOrder, err := prompt.ParseTrimOrder(flags.TrimOrder)
+	if err != nil {
+		log.Fatalf("Error parsing trim order: %v", err)
+	}
+	model = model.WithTrimOrder(trimOrder)
+	
+	// Saved resumes are kept so the next one can be compared with them
+	historyDir, err := history.DefaultDir()
+	if err == nil {
+		model = model.WithHistoryDir(historyDir)
+	}
+	
+	// The passphrase for encrypting them is asked for now, before the TUI starts
+	if err := vault.Unlock(); err != nil {
+		log.Fatalf("Error unlocking encrypted history: %v", err)
+	}
+	
+	// The welcome screen lists the files used recently
+	if recentPath, err := config.RecentPath(); err == nil {
+		model = model.WithRecentPath(recentPath)
+	}
+	
+	// The confirm screen shows how much of the quota the ledger says is used
+	if usagePath, err := usage.DefaultPath(); err == nil {
+		model = model.WithUsagePath(usagePath)
+	}
+	
+	// Amend mode updates the previous resume with the notes typed
+	if flags.Amend {
+		if flags.SourcePath != "" {
+			log.Fatalf("Error: -amend uses your previous resume as the source, so it cannot be combined with -source")
+		}
+		existing := targets.MarkdownPath
+		if existing == "" && flags.LegacyOutput {
+			existing = output.DefaultOutputPath
+		}
+		sourcePath, err := amendSourcePath(existing, historyDir)
+		if err != nil {
+			log.Fatalf("Error finding the resume to amend: %v", err)
+		}
+		model = model.WithSourcePath(sourcePath).WithAmend(true)
+	}
+	
+	// Otherwise the master history merged by merge-sources is the source
+	if flags.SourcePath == "" && !flags.Amend && !flags.NoMaster {
+		masterPath, ok, err := config.HasMasterHistory()
+		if err != nil {
+			log.Printf("Could not find the master history: %v", err)
+		} else if ok {
+			fmt.Fprintf(console, "Using the master history at %s as the source (-no-master to leave it out)\n", masterPath)
+			model = model.WithSourcePath(masterPath)
+		}
+	}
+	
+	// Before the first request, show what is sent to the API and ask for consent
+	consented, err := config.HasConsent()
+	if err != nil {
+		log.Printf("Could not read consent: %v", err)
+	}
+	model = model.WithConsentRequired(!consented)
+	
+	// The generated resume is shown with the chosen renderer or pager
+	if flags.Renderer != "" {
+		renderer, err := output.ParseRenderer(flags.Renderer)
+		if err != nil {
+			log.Fatalf("Error parsing renderer: %v", err)
+		}
+		model = model.WithRenderer(renderer)
+	}
+	
+	// Generation stops at the time limit, keeping the complete sections streamed
+	if flags.MaxDuration > 0 {
+		model = model.WithMaxDuration(flags.MaxDuration)
+	}
+	
+	// Check the API key with the API when asked; replayed responses need no key
+	model = model.WithKeyVerification(flags.CheckKey && fixtures.Mode != api.FixtureReplay)
+	
+	// The TUI is drawn full screen where the terminal can switch screens,
+	// inline where it cannot, and replaced by line prompts where there is
+	// no terminal to draw on
+	tuiOutput := os.Stdout
+	if toStdout {
+		tuiOutput = os.Stderr
+	}
+	display, reason := layout.DetectDisplay(interactiveTerminal(tuiOutput))
+	if flags.Plain {
+		display, reason = layout.DisplayPlain, ""
+	}
+	if reason != "" {
+		fmt.Fprintln(console, reason)
+	}
+	
+	// The dashboard command starts on the list of saved resumes
+	if flags.Dashboard {
+		if historyDir == "" {
+			log.Fatalf("Error: the dashboard lists the history, which could not be found")
+		}
+		if display == layout.DisplayPlain {
+			log.Fatalf("Error: the dashboard needs the full-screen interface, so it cannot be used in plain mode")
+		}
+		model = model.WithDashboard()
+	}
+	
+	// Plain mode asks for the inputs with line prompts instead of the TUI
+	if display == layout.DisplayPlain {
+		signalCh := make(chan os.Signal, 1)
+		signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-signalCh
+			cancel()
+		}()
+		
+		if err := tui.RunPlain(model, input.NewLineEditor(os.Stdin, console), console); err != nil {
+			fmt.Fprintf(console, "\nResumake failed: %v\n", err)
+			logging.Debugf("Exiting with code %d: %v", exitCode(err), err)
+			os.Exit(exitCode(err))
+		}
+		fmt.Fprintln(console, "\nResumake finished.")
+		return
+	}
+	
+	// The TUI draws on standard error, and the resume is held until the
+	// screen is restored, when the TUI exits
+	var programOptions []tea.ProgramOption
+	if display == layout.DisplayFullScreen {
+		programOptions = append(programOptions, tea.WithAltScreen())
+	}
+	var resume bytes.Buffer
+	if toStdout {
+		useTerminalColors(os.Stderr)
+		programOptions = append(programOptions, tea.WithOutput(os.Stderr))
+		output.Stdout = &resume
 	}
 	
 	// Set up signal handling for graceful shutdown, passing the cancel function
-	p := setupProgramWithSignalHandling(model, cancel)
+	p := setupProgramWithSignalHandling(model, cancel, programOptions...)
 	
 	// Run the program
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if toStdout {
+		if _, writeErr := resume.WriteTo(os.Stdout); writeErr != nil {
+			log.Printf("Error writing the resume to standard output: %v", writeErr)
+		}
+	}
+	if err != nil {
 		log.Fatalf("Error running TUI: %v", err)
 	}
 	
+	// Exit with a code that tells scripts why the run failed
+	if m, ok := finalModel.(tui.Model); ok {
+		if err := m.Err(); err != nil {
+			fmt.Fprintf(console, "\nResumake failed: %v\n", err)
+			logging.Debugf("Exiting with code %d: %v", exitCode(err), err)
+			os.Exit(exitCode(err))
+		}
+	}
+	
 	// Program finished successfully
-	fmt.Println("\nResumake finished.")
+	fmt.Fprintln(console, "\nResumake finished.")
+}
+
+// qrOptions returns the URL to link with a QR code and the layouts that show
+// it, from -qr and -qr-layouts or else the qr and qr-layouts settings. An
+// empty URL means no QR code, and nil layouts mean every layout.
+func qrOptions(flags input.Flags, settings map[string]string) (string, []output.Layout, error) {
+	value, source := flags.QR, "-qr"
+	if value == "" {
+		value, source = settings[config.SettingQR], "the qr setting"
+	}
+	link, err := output.QRLink(value)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", source, err)
+	}
+	
+	list, source := flags.QRLayouts, "-qr-layouts"
+	if list == "" {
+		list, source = settings[config.SettingQRLayouts], "the qr-layouts setting"
+	}
+	layouts, err := output.ParseLayouts(list)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return link, layouts, nil
+}
+
+// useTerminalColors styles the TUI for the terminal w draws on. Styles
+// otherwise follow standard output, which has no colors when it is piped.
+// NO_COLOR, which -no-color sets too, keeps the colors off either way.
+func useTerminalColors(w *os.File) {
+	renderer := lipgloss.NewRenderer(w)
+	lipgloss.SetColorProfile(renderer.ColorProfile())
+	lipgloss.SetHasDarkBackground(renderer.HasDarkBackground())
+}
+
+// interactiveTerminal reports whether the TUI can draw on out and read keys:
+// out must be a terminal, and keys are read from standard input or, when it
+// is piped, from the controlling terminal.
+func interactiveTerminal(out *os.File) bool {
+	if !term.IsTerminal(out.Fd()) {
+		return false
+	}
+	if term.IsTerminal(os.Stdin.Fd()) {
+		return true
+	}
+	name := "/dev/tty"
+	if runtime.GOOS == "windows" {
+		name = "CONIN$"
+	}
+	tty, err := os.Open(name)
+	if err != nil {
+		return false
+	}
+	tty.Close()
+	return true
 }
 
 // setupProgramWithSignalHandling creates a new Bubble Tea program with the given model
 // and sets up signal handling for graceful shutdown.
-// It accepts a context.CancelFunc that will be called when a termination signal is received.
-func setupProgramWithSignalHandling(model tea.Model, cancel context.CancelFunc) *tea.Program {
+// It accepts a context.CancelFunc that will be called when a termination signal is received,
+// and options added to the program's, such as where it draws and whether it uses the alternate screen.
+func setupProgramWithSignalHandling(model tea.Model, cancel context.CancelFunc, opts ...tea.ProgramOption) *tea.Program {
 	// Create a new Bubble Tea program with our model
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(model, opts...)
 	
 	// Create a channel to listen for signals
 	signalCh := make(chan os.Signal, 1)
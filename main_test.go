@@ -83,7 +83,7 @@ func TestHelpFlagExitsCleanly(t *testing.T) {
 			output := stdout.String() + stderr.String()
 			
 			// It should contain usage information
-			if !strings.Contains(output, "Usage of") {
+			if !strings.Contains(output, "Usage:") {
 				t.Errorf("Help output should contain usage information, got: %s", output)
 			}
 			
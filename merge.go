@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+	"github.com/phrazzld/resumake/vault"
+)
+
+// runMergeSources merges the resumes into one master history and writes it
+// to -output, or to the configuration directory, where generating uses it
+// as the source. A master history already there is merged in too, so each
+// run adds to it. The path is reported to w.
+func runMergeSources(ctx context.Context, flags input.MergeSourcesFlags, w io.Writer) error {
+	path := flags.OutputPath
+	names := make([]string, 0, len(flags.SourcePaths)+1)
+	resumes := make([]string, 0, len(flags.SourcePaths)+1)
+	if path == "" {
+		masterPath, exists, err := config.HasMasterHistory()
+		if err != nil {
+			return fmt.Errorf("error finding the master history: %w", err)
+		}
+		path = masterPath
+		if exists && !mergesPath(flags.SourcePaths, path) {
+			content, warning, err := input.ReadPromptFile(path)
+			if err != nil {
+				return fmt.Errorf("error reading the master history: %w", err)
+			}
+			if warning != "" {
+				fmt.Fprintf(w, "Warning: %s\n", warning)
+			}
+			names = append(names, "current master history")
+			resumes = append(resumes, content)
+			fmt.Fprintf(w, "Adding to the master history at %s\n", path)
+		}
+	}
+	for i, sourcePath := range flags.SourcePaths {
+		names = append(names, filepath.Base(sourcePath))
+		resumes = append(resumes, flags.SourceContents[i])
+	}
+
+	fmt.Fprintf(w, "Merging %d resumes...\n", len(flags.SourcePaths))
+	for _, warning := range flags.SourceWarnings {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+
+	apiKey, err := api.GetAPIKey()
+	if err != nil {
+		return fmt.Errorf("API key error: %w", err)
+	}
+
+	client, _, err := api.InitializeClientWithModel(ctx, apiKey, flags.Model)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+	defer client.Close()
+
+	model, err := api.NewMergeModel(client, flags.Model)
+	if err != nil {
+		return err
+	}
+
+	response, err := api.ExecuteRequest(ctx, model, prompt.GenerateMergeSourcesPromptContent(names, resumes))
+	if err != nil {
+		return err
+	}
+
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return err
+	}
+
+	master, err := output.ExtractAndValidateMarkdown(text)
+	if err != nil {
+		return fmt.Errorf("the master history is not a usable resume: %w", err)
+	}
+
+	write := output.WriteToFile
+	if flags.OutputPath == "" {
+		write = saveMasterHistory
+	}
+	if err := write(path, master); err != nil {
+		return fmt.Errorf("error writing the master history: %w", err)
+	}
+	fmt.Fprintf(w, "Saved the master history at %s\n", path)
+	if flags.OutputPath == "" {
+		fmt.Fprintln(w, "It is used as the source when you generate a resume without -source.")
+	}
+	return nil
+}
+
+// mergesPath reports whether path is one of the resumes being merged, so
+// the master history is not sent twice when it is named as a source.
+func mergesPath(sourcePaths []string, path string) bool {
+	target, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, sourcePath := range sourcePaths {
+		if source, err := filepath.Abs(sourcePath); err == nil && source == target {
+			return true
+		}
+	}
+	return false
+}
+
+// saveMasterHistory writes the master history to the configuration
+// directory. Like the resume history, it is private and encrypted when
+// encryption is on; reading it as a source decrypts it.
+func saveMasterHistory(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := vault.Seal([]byte(content))
+	if err != nil {
+		return fmt.Errorf("cannot encrypt: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
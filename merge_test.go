@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/vault"
+)
+
+func TestRunMergeSources(t *testing.T) {
+	// The API is stood in for by a handler answering in the process
+	var requestBodies []string
+	previous := api.ActiveClientFactory
+	api.ActiveClientFactory = api.FakeClientFactory{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestBodies = append(requestBodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Merged.\n` + api.ResumeStartDelimiter + `\n# Jane Doe\n\n## Experience\n\n### Engineer, Acme (2018 - 2023)\n- Built the checkout service in Go\n- Cut latency by 40%"}]}, "finishReason": "STOP"}]}`))
+	})}
+	t.Cleanup(func() { api.ActiveClientFactory = previous })
+	t.Setenv("GEMINI_API_KEY", "test-key")
+	configDir := filepath.Join(t.TempDir(), "resumake")
+	t.Setenv(config.DirEnvVar, configDir)
+
+	flags := input.MergeSourcesFlags{
+		SourcePaths:    []string{"cv_2019.md", "resume_2022.md"},
+		SourceContents: []string{"# Jane Doe\n- Built the checkout service", "# Jane Doe\n- Cut checkout latency by 40%"},
+		Model:          api.DefaultModelName,
+	}
+
+	// Test case 1: The resumes are merged into the master history in the configuration directory
+	var out strings.Builder
+	if err := runMergeSources(context.Background(), flags, &out); err != nil {
+		t.Fatalf("runMergeSources returned error: %v", err)
+	}
+	if len(requestBodies) != 1 || !strings.Contains(requestBodies[0], "cv_2019.md") || !strings.Contains(requestBodies[0], "Cut checkout latency") {
+		t.Errorf("Expected both resumes in the request, got %v", requestBodies)
+	}
+	path := filepath.Join(configDir, config.MasterHistoryFileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the master history at %s: %v", path, err)
+	}
+	if !strings.HasPrefix(string(content), "# Jane Doe") || strings.Contains(string(content), "Merged.") {
+		t.Errorf("Expected only the master history to be saved, got %q", content)
+	}
+	if !strings.Contains(out.String(), path) {
+		t.Errorf("Expected the master history path to be reported, got %q", out.String())
+	}
+
+	// Test case 2: A second run merges the existing master history in too
+	if err := runMergeSources(context.Background(), flags, io.Discard); err != nil {
+		t.Fatalf("runMergeSources returned error: %v", err)
+	}
+	if len(requestBodies) != 2 || !strings.Contains(requestBodies[1], "current master history") || !strings.Contains(requestBodies[1], "Cut latency by 40%") {
+		t.Errorf("Expected the master history in the second request, got %q", requestBodies[len(requestBodies)-1])
+	}
+
+	// Test case 3: -output writes elsewhere and leaves the master history out
+	flags.OutputPath = filepath.Join(t.TempDir(), "master.md")
+	if err := runMergeSources(context.Background(), flags, io.Discard); err != nil {
+		t.Fatalf("runMergeSources returned error: %v", err)
+	}
+	if strings.Contains(requestBodies[2], "current master history") {
+		t.Error("Expected the master history to be left out with -output")
+	}
+	if _, err := os.Stat(flags.OutputPath); err != nil {
+		t.Errorf("Expected the master history at -output: %v", err)
+	}
+
+	// Test case 4: With encryption on, the master history is sealed and still merged in
+	t.Setenv(vault.PassphraseEnv, "correct horse")
+	vault.Set(vault.ModePassphrase, nil)
+	defer vault.Set(vault.ModeOff, nil)
+	flags.OutputPath = ""
+	if err := runMergeSources(context.Background(), flags, io.Discard); err != nil {
+		t.Fatalf("runMergeSources returned error: %v", err)
+	}
+	if content, err = os.ReadFile(path); err != nil || !vault.IsEncrypted(content) || strings.Contains(string(content), "Jane Doe") {
+		t.Errorf("Expected the master history to be encrypted, got %q (%v)", content, err)
+	}
+	if err := runMergeSources(context.Background(), flags, io.Discard); err != nil {
+		t.Fatalf("runMergeSources returned error: %v", err)
+	}
+	if last := requestBodies[len(requestBodies)-1]; !strings.Contains(last, "Cut latency by 40%") {
+		t.Errorf("Expected the encrypted master history in the request, got %q", last)
+	}
+}
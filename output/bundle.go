@@ -0,0 +1,93 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Bundle file names used inside every bundle directory.
+const (
+	// BundleResumeFile is the name of the resume file in a bundle.
+	BundleResumeFile = "resume.md"
+
+	// BundleCoverLetterFile is the name of the cover letter file in a bundle.
+	BundleCoverLetterFile = "cover_letter.md"
+)
+
+// BundlePaths describes where the documents of a bundle were written.
+type BundlePaths struct {
+	Dir             string // The dated bundle directory
+	ResumePath      string // Path of the resume file
+	CoverLetterPath string // Path of the cover letter file
+}
+
+// BundleDirName returns the dated directory name for a bundle created at t,
+// e.g. "resumake-2024-06-01".
+func BundleDirName(t time.Time) string {
+	return "resumake-" + t.Format("2006-01-02")
+}
+
+// WriteBundle writes a resume and its cover letter into a new dated directory.
+// The directory is created inside baseDir (the current directory when empty).
+// If a bundle for the same date already exists, a numeric suffix is added
+// (resumake-2024-06-01-2, -3, ...) so earlier bundles are never overwritten.
+//
+// Parameters:
+//   - baseDir: The directory in which to create the bundle directory
+//   - resume: The resume content
+//   - coverLetter: The cover letter content
+//   - t: The time used to name the bundle directory
+//
+// Returns:
+//   - BundlePaths: The paths of the bundle directory and its files
+//   - error: An error if the directory or files cannot be written
+//
+// Example:
+//
+//	paths, err := output.WriteBundle(".", resume, letter, time.Now())
+//	if err != nil {
+//	    log.Fatalf("Failed to write bundle: %v", err)
+//	}
+//	fmt.Println("Bundle written to", paths.Dir)
+func WriteBundle(baseDir, resume, coverLetter string, t time.Time) (BundlePaths, error) {
+	if baseDir == "" {
+		baseDir = "."
+	}
+
+	dir, err := uniqueBundleDir(baseDir, BundleDirName(t))
+	if err != nil {
+		return BundlePaths{}, err
+	}
+
+	paths := BundlePaths{
+		Dir:             dir,
+		ResumePath:      filepath.Join(dir, BundleResumeFile),
+		CoverLetterPath: filepath.Join(dir, BundleCoverLetterFile),
+	}
+
+	if err := WriteTextFile(paths.ResumePath, resume); err != nil {
+		return BundlePaths{}, fmt.Errorf("failed to write bundle resume: %w", err)
+	}
+	if err := WriteTextFile(paths.CoverLetterPath, coverLetter); err != nil {
+		return BundlePaths{}, fmt.Errorf("failed to write bundle cover letter: %w", err)
+	}
+
+	return paths, nil
+}
+
+// uniqueBundleDir returns a bundle directory path under baseDir that does not exist yet.
+func uniqueBundleDir(baseDir, name string) (string, error) {
+	candidate := filepath.Join(baseDir, name)
+	for i := 2; ; i++ {
+		_, err := os.Stat(candidate)
+		if os.IsNotExist(err) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check bundle directory: %w", err)
+		}
+		candidate = filepath.Join(baseDir, fmt.Sprintf("%s-%d", name, i))
+	}
+}
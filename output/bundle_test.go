@@ -0,0 +1,54 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBundleDirName(t *testing.T) {
+	date := time.Date(2024, 6, 1, 15, 4, 5, 0, time.UTC)
+	if got := BundleDirName(date); got != "resumake-2024-06-01" {
+		t.Errorf("BundleDirName() = %q, want %q", got, "resumake-2024-06-01")
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	tempDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	date := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("writes both documents to a dated directory", func(t *testing.T) {
+		paths, err := WriteBundle(tempDir, "# Resume", "# Cover Letter", date)
+		if err != nil {
+			t.Fatalf("WriteBundle() error = %v", err)
+		}
+
+		if paths.Dir != filepath.Join(tempDir, "resumake-2024-06-01") {
+			t.Errorf("Unexpected bundle directory %q", paths.Dir)
+		}
+
+		resume, err := os.ReadFile(paths.ResumePath)
+		if err != nil || string(resume) != "# Resume" {
+			t.Errorf("Expected resume content, got %q (err %v)", resume, err)
+		}
+
+		letter, err := os.ReadFile(paths.CoverLetterPath)
+		if err != nil || string(letter) != "# Cover Letter" {
+			t.Errorf("Expected cover letter content, got %q (err %v)", letter, err)
+		}
+	})
+
+	t.Run("does not overwrite an existing bundle", func(t *testing.T) {
+		paths, err := WriteBundle(tempDir, "# Resume 2", "# Cover Letter 2", date)
+		if err != nil {
+			t.Fatalf("WriteBundle() error = %v", err)
+		}
+
+		if paths.Dir != filepath.Join(tempDir, "resumake-2024-06-01-2") {
+			t.Errorf("Expected suffixed bundle directory, got %q", paths.Dir)
+		}
+	})
+}
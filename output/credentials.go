@@ -0,0 +1,244 @@
+package output
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// credential maps the alternative spellings of a degree, institution, or
+// certification to a single canonical name.
+type credential struct {
+	Name    string   // Canonical full name, such as "Certified Kubernetes Administrator"
+	Acronym string   // Canonical acronym shown after the name (empty for none)
+	Aliases []string // Other spellings the model or user may have written
+}
+
+// Canonical returns the name used in the final resume, with the acronym in
+// parentheses when the credential has one.
+func (c credential) Canonical() string {
+	if c.Acronym == "" {
+		return c.Name
+	}
+	return c.Name + " (" + c.Acronym + ")"
+}
+
+// certificationNames is the lookup table for professional certifications.
+// Inside Certifications and Licenses sections every spelling is normalized;
+// elsewhere only the exact full name and all-caps acronyms and exam codes
+// are, since spellings such as "Kubernetes Administrator" also describe jobs.
+var certificationNames = []credential{
+	{Name: "AWS Certified Solutions Architect – Associate", Acronym: "SAA", Aliases: []string{"AWS SAA", "SAA-C03", "AWS Solutions Architect Associate", "AWS Certified Solutions Architect Associate", "Solutions Architect Associate"}},
+	{Name: "AWS Certified Solutions Architect – Professional", Aliases: []string{"AWS SAP", "SAP-C02", "AWS Solutions Architect Professional", "AWS Certified Solutions Architect Professional"}},
+	{Name: "AWS Certified Developer – Associate", Aliases: []string{"AWS DVA", "DVA-C02", "AWS Developer Associate", "AWS Certified Developer Associate"}},
+	{Name: "AWS Certified Cloud Practitioner", Aliases: []string{"AWS CCP", "CLF-C02", "AWS Cloud Practitioner"}},
+	{Name: "Certified Kubernetes Administrator", Acronym: "CKA", Aliases: []string{"Kubernetes Administrator", "CNCF CKA"}},
+	{Name: "Certified Kubernetes Application Developer", Acronym: "CKAD", Aliases: []string{"Kubernetes Application Developer", "CNCF CKAD"}},
+	{Name: "Certified Kubernetes Security Specialist", Acronym: "CKS", Aliases: []string{"Kubernetes Security Specialist", "CNCF CKS"}},
+	{Name: "Project Management Professional", Acronym: "PMP", Aliases: []string{"PMI PMP", "PMI Project Management Professional"}},
+	{Name: "Certified ScrumMaster", Aliases: []string{"Certified Scrum Master", "Scrum Alliance CSM"}},
+	{Name: "Certified Information Systems Security Professional", Acronym: "CISSP", Aliases: []string{"ISC2 CISSP"}},
+	{Name: "CompTIA Security+", Aliases: []string{"Security+", "Security Plus", "CompTIA Security Plus", "Sec+"}},
+	{Name: "Google Cloud Professional Cloud Architect", Aliases: []string{"GCP Professional Cloud Architect", "GCP PCA", "Professional Cloud Architect"}},
+	{Name: "Microsoft Certified: Azure Solutions Architect Expert", Aliases: []string{"Azure Solutions Architect Expert", "AZ-305"}},
+}
+
+// degreeNames is the lookup table for academic degrees.
+// Degrees are only normalized inside Education sections.
+var degreeNames = []credential{
+	{Name: "Bachelor of Science", Aliases: []string{"B.S.", "BS", "B.Sc.", "BSc", "Bachelor's of Science", "Bachelor's in Science"}},
+	{Name: "Bachelor of Arts", Aliases: []string{"B.A.", "BA", "Bachelor's of Arts", "Bachelor's in Arts"}},
+	{Name: "Bachelor of Engineering", Aliases: []string{"B.E.", "B.Eng.", "BEng", "Bachelor's of Engineering"}},
+	{Name: "Master of Science", Aliases: []string{"M.S.", "MS", "M.Sc.", "MSc", "Master's of Science", "Master's in Science"}},
+	{Name: "Master of Arts", Aliases: []string{"M.A.", "MA", "Master's of Arts", "Master's in Arts"}},
+	{Name: "Master of Engineering", Aliases: []string{"M.Eng.", "MEng", "Master's of Engineering"}},
+	{Name: "Master of Business Administration", Aliases: []string{"M.B.A.", "MBA", "Master's of Business Administration"}},
+	{Name: "Doctor of Philosophy", Aliases: []string{"Ph.D.", "PhD", "Doctorate of Philosophy"}},
+}
+
+// institutionNames is the lookup table for common institution abbreviations.
+// Institutions are only normalized inside Education sections.
+var institutionNames = []credential{
+	{Name: "Massachusetts Institute of Technology", Aliases: []string{"MIT", "M.I.T."}},
+	{Name: "University of California, Berkeley", Aliases: []string{"UC Berkeley", "U.C. Berkeley", "University of California Berkeley", "Cal Berkeley"}},
+	{Name: "University of California, Los Angeles", Aliases: []string{"UCLA", "UC Los Angeles", "University of California Los Angeles"}},
+	{Name: "Carnegie Mellon University", Aliases: []string{"CMU", "Carnegie Mellon"}},
+	{Name: "Georgia Institute of Technology", Aliases: []string{"Georgia Tech", "GaTech"}},
+	{Name: "California Institute of Technology", Aliases: []string{"Caltech", "Cal Tech"}},
+	{Name: "University of Texas at Austin", Aliases: []string{"UT Austin", "University of Texas Austin", "University of Texas, Austin"}},
+	{Name: "University of Illinois Urbana-Champaign", Aliases: []string{"UIUC", "University of Illinois at Urbana-Champaign"}},
+}
+
+// credentialReplacer rewrites every spelling of one credential to its canonical name.
+type credentialReplacer struct {
+	pattern   *regexp.Regexp
+	canonical string
+}
+
+var (
+	certificationReplacers       = compileCredentials(certificationNames, false)
+	strictCertificationReplacers = compileCredentials(certificationNames, true)
+	educationReplacers           = compileCredentials(append(append([]credential{}, degreeNames...), institutionNames...), false)
+
+	// trailingInRegex matches the "in" that must follow a two-letter degree abbreviation
+	trailingInRegex = regexp.MustCompile(`\s+in$`)
+)
+
+// compileCredentials builds a replacer for each credential in the table.
+// Strict replacers match only the spellings that cannot be ordinary prose:
+// the full name with its exact capitalization, and the aliases and acronym
+// written in capitals, such as "AWS SAA" or "CKA".
+func compileCredentials(credentials []credential, strict bool) []credentialReplacer {
+	replacers := make([]credentialReplacer, 0, len(credentials))
+	for _, c := range credentials {
+		names := []string{c.Name}
+		for _, alias := range c.Aliases {
+			if !strict || strings.ToUpper(alias) == alias {
+				names = append(names, alias)
+			}
+		}
+		if c.Acronym != "" {
+			names = append(names, c.Acronym)
+		}
+
+		// Longest spellings first so "AWS SAA" wins over "SAA"
+		sort.SliceStable(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+		alternatives := make([]string, len(names))
+		for i, name := range names {
+			alternatives[i] = aliasPattern(name, !strict)
+		}
+		group := "(?:" + strings.Join(alternatives, "|") + ")"
+
+		// A parenthesized acronym after the name is part of the match, so text
+		// that is already canonical is left as it is
+		replacers = append(replacers, credentialReplacer{
+			pattern:   regexp.MustCompile(`\b` + group + `(?:\s*\(` + group + `\))?`),
+			canonical: c.Canonical(),
+		})
+	}
+	return replacers
+}
+
+// aliasPattern converts one spelling into a regular expression fragment.
+// Spaces and dashes match any run of spaces or dashes, apostrophes are
+// optional, and with foldCase set names containing lowercase letters match
+// case-insensitively; all-caps acronyms always match exactly. Two-letter
+// abbreviations such as MS or MA only match when followed by "in", since
+// they double as US state codes in education entries.
+func aliasPattern(alias string, foldCase bool) string {
+	var b strings.Builder
+	separator := false
+	for _, r := range alias {
+		switch {
+		case r == ' ' || r == '-' || r == '–':
+			if !separator {
+				b.WriteString(`[\s\-–—]+`)
+			}
+			separator = true
+			continue
+		case r == '\'':
+			b.WriteString(`['’]?`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+		separator = false
+	}
+	pattern := b.String()
+
+	last := []rune(alias)[len([]rune(alias))-1]
+	if unicode.IsLetter(last) || unicode.IsDigit(last) {
+		pattern += `\b`
+	}
+
+	if strings.ToUpper(alias) != alias {
+		if !foldCase {
+			return pattern
+		}
+		return "(?i:" + pattern + ")"
+	}
+	if len(alias) == 2 {
+		return pattern + `\s+in\b`
+	}
+	return pattern
+}
+
+// replace rewrites every match in line to the canonical name.
+func (r credentialReplacer) replace(line string) string {
+	return r.pattern.ReplaceAllStringFunc(line, func(match string) string {
+		// Keep the "in" that was required after a two-letter abbreviation
+		if loc := trailingInRegex.FindStringIndex(match); loc != nil {
+			return r.canonical + match[loc[0]:]
+		}
+		return r.canonical
+	})
+}
+
+// NormalizeCredentials standardizes degree names, institutions, and
+// certifications against built-in lookup tables so the same credential is
+// always written the same way. Certifications are rewritten from any
+// spelling inside sections whose heading mentions Certifications or
+// Licenses, and elsewhere only from acronyms such as "AWS SAA", "CKA", or
+// "PMP" and the exact full name, so prose such as "project management
+// professional" is left as written. Degrees and institutions are only
+// rewritten inside sections whose heading mentions Education, where
+// abbreviations such as "BS" or "MIT" refer to degrees and schools.
+// Headings are never changed.
+//
+// Parameters:
+//   - content: The Markdown resume
+//
+// Returns:
+//   - string: The resume with canonical credential names
+//
+// Example:
+//
+//	resume := output.NormalizeCredentials("## Certifications\n\n- CKA, PMP")
+//	// "## Certifications\n\n- Certified Kubernetes Administrator (CKA), Project Management Professional (PMP)"
+func NormalizeCredentials(content string) string {
+	lines := strings.Split(content, "\n")
+	educationLevel := 0     // Heading level of the current Education section, 0 outside one
+	certificationLevel := 0 // Heading level of the current Certifications section, 0 outside one
+	for i, line := range lines {
+		if match := sectionHeadingRegex.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			level := len(match[1])
+			heading := strings.ToLower(match[2])
+			educationLevel = sectionLevel(educationLevel, level, strings.Contains(heading, "education"))
+			certificationLevel = sectionLevel(certificationLevel, level, certificationHeadingRegex.MatchString(heading))
+			continue
+		}
+
+		replacers := strictCertificationReplacers
+		if certificationLevel > 0 {
+			replacers = certificationReplacers
+		}
+		for _, r := range replacers {
+			line = r.replace(line)
+		}
+		if educationLevel > 0 {
+			for _, r := range educationReplacers {
+				line = r.replace(line)
+			}
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// certificationHeadingRegex matches the lowercased headings of sections that
+// list certifications, such as "Licenses & Certifications".
+var certificationHeadingRegex = regexp.MustCompile(`certif|licen[cs]e|credential`)
+
+// sectionLevel tracks the heading level of a section while walking a resume's
+// headings: the section ends at a heading of the same or a higher level, and
+// a heading below the title that matches starts it.
+func sectionLevel(current, level int, matches bool) int {
+	if current > 0 && level <= current {
+		current = 0
+	}
+	if current == 0 && level > 1 && matches {
+		return level
+	}
+	return current
+}
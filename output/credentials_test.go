@@ -0,0 +1,96 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestNormalizeCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "certification acronyms",
+			content:  "## Certifications\n\n- AWS SAA\n- CKA, PMP",
+			expected: "## Certifications\n\n- AWS Certified Solutions Architect – Associate (SAA)\n- Certified Kubernetes Administrator (CKA), Project Management Professional (PMP)",
+		},
+		{
+			name:     "certification spelled out with a different dash and case",
+			content:  "## Licenses & Certifications\n\n- aws certified solutions architect - associate (SAA-C03)\n- Kubernetes Administrator",
+			expected: "## Licenses & Certifications\n\n- AWS Certified Solutions Architect – Associate (SAA)\n- Certified Kubernetes Administrator (CKA)",
+		},
+		{
+			name:     "certifications outside a certifications section",
+			content:  "## Summary\n\nPlatform engineer holding the CKAD.",
+			expected: "## Summary\n\nPlatform engineer holding the Certified Kubernetes Application Developer (CKAD).",
+		},
+		{
+			name:     "exact full names outside a certifications section",
+			content:  "## Experience\n\n- Earned the Certified Kubernetes Administrator while leading the platform team",
+			expected: "## Experience\n\n- Earned the Certified Kubernetes Administrator (CKA) while leading the platform team",
+		},
+		{
+			name:     "experience prose is not a certification",
+			content:  "## Experience\n\n- Served as Kubernetes administrator for 40 clusters\n- Promoted to solutions architect associate in 2021",
+			expected: "## Experience\n\n- Served as Kubernetes administrator for 40 clusters\n- Promoted to solutions architect associate in 2021",
+		},
+		{
+			name:     "summary prose is not a certification",
+			content:  "## Summary\n\nExperienced project management professional and certified scrum master at heart.",
+			expected: "## Summary\n\nExperienced project management professional and certified scrum master at heart.",
+		},
+		{
+			name:     "prose after a certifications section is not a certification",
+			content:  "## Certifications\n\n- security plus\n\n## Experience\n\n- Kubernetes Administrator, Acme",
+			expected: "## Certifications\n\n- CompTIA Security+\n\n## Experience\n\n- Kubernetes Administrator, Acme",
+		},
+		{
+			name:     "degrees and institutions in education",
+			content:  "## Education\n\n- B.S. in Computer Science, MIT\n- MS in Statistics, UC Berkeley\n- PhD, CMU",
+			expected: "## Education\n\n- Bachelor of Science in Computer Science, Massachusetts Institute of Technology\n- Master of Science in Statistics, University of California, Berkeley\n- Doctor of Philosophy, Carnegie Mellon University",
+		},
+		{
+			name:     "education subsections stay in the section",
+			content:  "## Education\n\n### Graduate\n\n- Masters of Science in Physics",
+			expected: "## Education\n\n### Graduate\n\n- Master of Science in Physics",
+		},
+		{
+			name:     "degrees outside education are left alone",
+			content:  "## Experience\n\n- Built BS detector at MIT\n\n## Education\n\n- BSc, Georgia Tech",
+			expected: "## Experience\n\n- Built BS detector at MIT\n\n## Education\n\n- Bachelor of Science, Georgia Institute of Technology",
+		},
+		{
+			name:     "state codes are not degrees",
+			content:  "## Education\n\n- Harvard University, Cambridge, MA",
+			expected: "## Education\n\n- Harvard University, Cambridge, MA",
+		},
+		{
+			name:     "headings are unchanged",
+			content:  "# Jane Doe, PMP\n\n## Education\n\n### MIT",
+			expected: "# Jane Doe, PMP\n\n## Education\n\n### MIT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeCredentials(tt.content); got != tt.expected {
+				t.Errorf("NormalizeCredentials() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeCredentialsIsIdempotent(t *testing.T) {
+	// Canonical names must survive normalization unchanged, or each pass
+	// would keep rewriting the same resume
+	tables := [][]credential{certificationNames, degreeNames, institutionNames}
+	for _, table := range tables {
+		for _, c := range table {
+			content := "## Education\n\n- " + c.Canonical()
+			if got := NormalizeCredentials(content); got != content {
+				t.Errorf("Canonical %q changed to %q", c.Canonical(), got)
+			}
+		}
+	}
+}
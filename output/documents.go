@@ -0,0 +1,49 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DocumentPath returns the path of a document written alongside a Markdown
+// resume, such as a thank-you email: the resume's name with "_" and the
+// document's file name before a .md extension. In a bundle, whose files have
+// fixed names, the document is named on its own, like BundleCoverLetterFile.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//   - fileName: The document's file name without an extension, such as "thank_you_email"
+//
+// Returns:
+//   - string: The document path
+//
+// Example:
+//
+//	path := output.DocumentPath("Jane_Doe_Resume_2024-06-01.md", "thank_you_email")
+//	// path == "Jane_Doe_Resume_2024-06-01_thank_you_email.md"
+func DocumentPath(markdownPath, fileName string) string {
+	if filepath.Base(markdownPath) == BundleResumeFile {
+		return filepath.Join(filepath.Dir(markdownPath), fileName+".md")
+	}
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + "_" + fileName + ".md"
+}
+
+// WriteDocument writes a document generated alongside the resume, such as a
+// follow-up note, next to the Markdown resume (see DocumentPath).
+//
+// Parameters:
+//   - content: The document in Markdown
+//   - markdownPath: The path of the Markdown resume
+//   - fileName: The document's file name without an extension
+//
+// Returns:
+//   - string: The path of the document
+//   - error: Any error that occurred while writing
+func WriteDocument(content, markdownPath, fileName string) (string, error) {
+	documentPath := DocumentPath(markdownPath, fileName)
+	if err := WriteTextFile(documentPath, strings.TrimSpace(content)+"\n"); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", fileName, err)
+	}
+	return documentPath, nil
+}
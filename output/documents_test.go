@@ -0,0 +1,40 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDocumentPath(t *testing.T) {
+	// Test case 1: Documents are named after the resume
+	if got := DocumentPath(filepath.Join("out", "Jane_Doe_Resume.md"), "thank_you_email"); got != filepath.Join("out", "Jane_Doe_Resume_thank_you_email.md") {
+		t.Errorf("Unexpected document path %q", got)
+	}
+
+	// Test case 2: Documents in a bundle are named on their own
+	if got := DocumentPath(filepath.Join("resumake-2024-06-01", BundleResumeFile), "linkedin_summary"); got != filepath.Join("resumake-2024-06-01", "linkedin_summary.md") {
+		t.Errorf("Unexpected bundle document path %q", got)
+	}
+}
+
+func TestWriteDocument(t *testing.T) {
+	tempDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	path, err := WriteDocument("\nSubject: Thank you\n\nDear [Interviewer name],\n", filepath.Join(tempDir, "Jane_Doe_Resume.md"), "thank_you_email")
+	if err != nil {
+		t.Fatalf("WriteDocument() error = %v", err)
+	}
+	if path != filepath.Join(tempDir, "Jane_Doe_Resume_thank_you_email.md") {
+		t.Errorf("Unexpected document path %q", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read document: %v", err)
+	}
+	if expected := "Subject: Thank you\n\nDear [Interviewer name],\n"; string(data) != expected {
+		t.Errorf("Expected document %q, got %q", expected, data)
+	}
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// docxDocumentPart is the part of a DOCX package that holds the body text.
+const docxDocumentPart = "word/document.xml"
+
+// WriteDOCX converts a Markdown resume to a Word document without pandoc.
+// The package is copied from the bundled reference document, so the export
+// uses its styles and page setup, and only the body text is replaced.
+//
+// Parameters:
+//   - markdownContent: The resume in Markdown
+//   - path: The path to write the DOCX file to
+//
+// Returns:
+//   - error: An error if the file could not be written
+//
+// Example:
+//
+//	err := output.WriteDOCX(content, "resume_out.docx")
+func WriteDOCX(markdownContent, path string) error {
+	reference, err := zip.NewReader(bytes.NewReader(referenceDOCX), int64(len(referenceDOCX)))
+	if err != nil {
+		return fmt.Errorf("failed to read DOCX template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, part := range reference.File {
+		dst, err := w.Create(part.Name)
+		if err != nil {
+			return err
+		}
+
+		src, err := part.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read DOCX template: %w", err)
+		}
+		data, err := io.ReadAll(src)
+		src.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read DOCX template: %w", err)
+		}
+
+		if part.Name == docxDocumentPart {
+			data = []byte(docxDocument(markdownContent, docxSectionProperties(string(data))))
+		}
+		if _, err := dst.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return WriteToFile(path, buf.String())
+}
+
+// docxSectionProperties returns the page setup of a WordprocessingML document.
+func docxSectionProperties(documentXML string) string {
+	start := strings.Index(documentXML, "<w:sectPr")
+	end := strings.Index(documentXML, "</w:sectPr>")
+	if start < 0 || end < start {
+		return ""
+	}
+	return documentXML[start : end+len("</w:sectPr>")]
+}
+
+// docxDocument renders the Markdown resume as a WordprocessingML document.
+// Headings use the template's Heading styles, list items List Bullet, and
+// paragraphs Body Text.
+func docxDocument(markdownContent, sectionProperties string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+
+	for _, blk := range markdownBlocks(markdownContent) {
+		switch blk.kind {
+		case blockHeading:
+			writeDOCXParagraph(&b, fmt.Sprintf("Heading%d", min(blk.level, 3)), blk.lines)
+		case blockListItem:
+			writeDOCXParagraph(&b, "ListBullet", blk.lines)
+		case blockParagraph:
+			writeDOCXParagraph(&b, "BodyText", blk.lines)
+		case blockRule:
+			b.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="auto"/></w:pBdr></w:pPr></w:p>`)
+		}
+	}
+
+	b.WriteString(sectionProperties)
+	b.WriteString(`</w:body></w:document>`)
+	return b.String()
+}
+
+// writeDOCXParagraph writes a paragraph in the given style, with a line
+// break between lines.
+func writeDOCXParagraph(b *strings.Builder, style string, lines []string) {
+	b.WriteString(`<w:p><w:pPr><w:pStyle w:val="` + style + `"/></w:pPr>`)
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString(`<w:r><w:br/></w:r>`)
+		}
+		for _, r := range inlineRuns(line) {
+			b.WriteString(`<w:r>`)
+			if r.bold || r.italic {
+				b.WriteString(`<w:rPr>`)
+				if r.bold {
+					b.WriteString(`<w:b/>`)
+				}
+				if r.italic {
+					b.WriteString(`<w:i/>`)
+				}
+				b.WriteString(`</w:rPr>`)
+			}
+			b.WriteString(`<w:t xml:space="preserve">` + escapeXML(r.text) + `</w:t></w:r>`)
+		}
+	}
+	b.WriteString(`</w:p>`)
+}
+
+// escapeXML escapes text for use in XML character data.
+func escapeXML(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
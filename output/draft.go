@@ -0,0 +1,130 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DraftSuffix is appended to the output path to name the draft file, so a
+// draft of resume_out.md is saved as resume_out.md.partial.
+const DraftSuffix = ".partial"
+
+// DraftInterval is how often a streaming response is saved as a draft.
+const DraftInterval = 2 * time.Second
+
+// DraftPath returns the path of the draft saved while the resume for
+// outputPath is generated. An empty outputPath, or StdoutPath, uses
+// DefaultOutputPath.
+//
+// Parameters:
+//   - outputPath: The path the finished resume will be written to
+//
+// Returns:
+//   - string: The draft path
+func DraftPath(outputPath string) string {
+	if outputPath == "" || outputPath == StdoutPath {
+		outputPath = DefaultOutputPath
+	}
+	return outputPath + DraftSuffix
+}
+
+// DraftWriter saves the text of a response as it streams in, at most once
+// per interval, so a crash or dropped connection mid-generation still leaves
+// the text received so far on disk. Each save replaces the previous draft
+// through a rename, so the draft is never left half-written.
+type DraftWriter struct {
+	path      string
+	interval  time.Duration
+	lastWrite time.Time
+	pending   string // Text received since the last save
+	dirty     bool
+	written   bool
+}
+
+// NewDraftWriter creates a writer that saves drafts for the resume at
+// outputPath (see DraftPath). Nothing is written until Update is called.
+//
+// Parameters:
+//   - outputPath: The path the finished resume will be written to
+//   - interval: The minimum time between saves
+//
+// Returns:
+//   - *DraftWriter: The new draft writer
+//
+// Example:
+//
+//	draft := output.NewDraftWriter(outputPath, output.DraftInterval)
+//	response, err := session.SendStreaming(ctx, content, func(text string) {
+//	    draft.Update(text)
+//	})
+//	if err == nil {
+//	    draft.Remove()
+//	}
+func NewDraftWriter(outputPath string, interval time.Duration) *DraftWriter {
+	return &DraftWriter{path: DraftPath(outputPath), interval: interval}
+}
+
+// Path returns the path of the draft file.
+func (d *DraftWriter) Path() string {
+	return d.path
+}
+
+// Written reports whether a draft has been saved.
+func (d *DraftWriter) Written() bool {
+	return d.written
+}
+
+// Update records the text received so far and saves it if at least the
+// interval has passed since the last save.
+//
+// Parameters:
+//   - text: The complete text received so far
+//
+// Returns:
+//   - error: An error if the draft could not be saved
+func (d *DraftWriter) Update(text string) error {
+	d.pending = text
+	d.dirty = true
+	if time.Since(d.lastWrite) < d.interval {
+		return nil
+	}
+	return d.Flush()
+}
+
+// Flush saves any text recorded since the last save.
+//
+// Returns:
+//   - error: An error if the draft could not be saved
+func (d *DraftWriter) Flush() error {
+	if !d.dirty {
+		return nil
+	}
+
+	tmpPath := d.path + ".tmp"
+	if err := WriteToFile(tmpPath, d.pending); err != nil {
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+	if err := os.Rename(tmpPath, d.path); err != nil {
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	d.lastWrite = time.Now()
+	d.dirty = false
+	d.written = true
+	return nil
+}
+
+// Remove deletes the draft once the finished resume has been written.
+// It is not an error if no draft was saved.
+//
+// Returns:
+//   - error: An error if the draft exists but could not be deleted
+func (d *DraftWriter) Remove() error {
+	if err := os.Remove(d.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove draft: %w", err)
+	}
+	d.written = false
+	return nil
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDraftPath(t *testing.T) {
+	if got := DraftPath(""); got != DefaultOutputPath+".partial" {
+		t.Errorf("DraftPath(\"\") = %q", got)
+	}
+	if got := DraftPath(StdoutPath); got != DefaultOutputPath+".partial" {
+		t.Errorf("DraftPath(StdoutPath) = %q", got)
+	}
+	if got := DraftPath("out/resume.md"); got != "out/resume.md.partial" {
+		t.Errorf("DraftPath() = %q", got)
+	}
+}
+
+func TestDraftWriter(t *testing.T) {
+	tempDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	draft := NewDraftWriter(filepath.Join(tempDir, "resume.md"), time.Hour)
+
+	readDraft := func() string {
+		data, err := os.ReadFile(draft.Path())
+		if err != nil {
+			t.Fatalf("Failed to read draft: %v", err)
+		}
+		return string(data)
+	}
+
+	// Test case 1: The first update is saved right away
+	if err := draft.Update("# Jane"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !draft.Written() || readDraft() != "# Jane" {
+		t.Fatal("Expected the first update to be saved")
+	}
+
+	// Test case 2: Later updates within the interval wait for a flush
+	draft.Update("# Jane Doe\n\n## Exper")
+	if readDraft() != "# Jane" {
+		t.Error("Expected updates within the interval to be held back")
+	}
+	if err := draft.Flush(); err != nil || readDraft() != "# Jane Doe\n\n## Exper" {
+		t.Errorf("Expected Flush to save the latest text, got %q (%v)", readDraft(), err)
+	}
+	if _, err := os.Stat(draft.Path() + ".tmp"); !os.IsNotExist(err) {
+		t.Error("The temporary file should be renamed over the draft")
+	}
+
+	// Test case 3: Remove deletes the draft and tolerates a missing file
+	if err := draft.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(draft.Path()); !os.IsNotExist(err) || draft.Written() {
+		t.Error("Expected the draft to be removed")
+	}
+	if err := draft.Remove(); err != nil {
+		t.Errorf("Removing a missing draft should not fail, got %v", err)
+	}
+}
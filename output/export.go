@@ -0,0 +1,330 @@
+package output
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// ExportFormat identifies a document format the resume can be exported to.
+type ExportFormat string
+
+const (
+	// FormatDOCX is a Word document.
+	FormatDOCX ExportFormat = "docx"
+
+	// FormatPDF is a PDF document. It requires pandoc and a PDF engine;
+	// otherwise a print-ready HTML resume is written instead.
+	FormatPDF ExportFormat = "pdf"
+
+	// FormatODT is an OpenDocument text document.
+	FormatODT ExportFormat = "odt"
+)
+
+// ExportFormats lists every supported export format.
+var ExportFormats = []ExportFormat{FormatDOCX, FormatPDF, FormatODT}
+
+// referenceDOCX is the Word template that styles DOCX exports. It is passed
+// to pandoc as the reference document, and its styles are reused by the
+// native DOCX exporter, so both produce the same look.
+//
+//go:embed templates/reference.docx
+var referenceDOCX []byte
+
+// maxExportWorkers is the most exports converted at once. A pandoc PDF
+// conversion runs a whole LaTeX engine, so more would mostly compete for
+// memory.
+const maxExportWorkers = 3
+
+// lookPath finds executables on PATH. Tests replace it to control whether
+// pandoc is found.
+var lookPath = exec.LookPath
+
+// ParseExportFormats parses a comma-separated list of export formats.
+// Duplicates are ignored.
+//
+// Parameters:
+//   - list: The formats, such as "docx,pdf" (case-insensitive)
+//
+// Returns:
+//   - []ExportFormat: The formats in the order given
+//   - error: An error naming the supported formats if one is unknown
+//
+// Example:
+//
+//	formats, err := output.ParseExportFormats("docx,pdf")
+func ParseExportFormats(list string) ([]ExportFormat, error) {
+	var formats []ExportFormat
+	seen := make(map[ExportFormat]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		format := ExportFormat(name)
+		if !isExportFormat(format) {
+			names := make([]string, len(ExportFormats))
+			for i, f := range ExportFormats {
+				names[i] = string(f)
+			}
+			return nil, fmt.Errorf("unknown export format %q (choose from %s)", name, strings.Join(names, ", "))
+		}
+		if !seen[format] {
+			seen[format] = true
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+// isExportFormat reports whether format is supported.
+func isExportFormat(format ExportFormat) bool {
+	for _, f := range ExportFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportPath returns the path of an export written next to a Markdown
+// resume: the same name with the format's extension.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//   - format: The export format
+//
+// Returns:
+//   - string: The export path
+func ExportPath(markdownPath string, format ExportFormat) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + "." + string(format)
+}
+
+// PandocPath returns the path of pandoc if it is installed on PATH, or ""
+// if it is not.
+func PandocPath() string {
+	path, err := lookPath("pandoc")
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// ExportOptions controls how exports are written.
+type ExportOptions struct {
+	Pandoc   string // Path of pandoc (empty to use the native exporters)
+	HTMLPath string // An HTML resume already written, reused when PDF falls back to HTML
+	QR       string // URL to link with a QR code in the header of the PDF (empty to skip)
+}
+
+// Export describes one file written by WriteExports.
+type Export struct {
+	Format ExportFormat // The requested format
+	Path   string       // The file that was written
+	Pandoc bool         // Whether pandoc produced the file
+	Note   string       // Why a fallback was used, if one was
+}
+
+// ExportFailure is one format WriteExports could not write.
+type ExportFailure struct {
+	Format ExportFormat // The requested format
+	Err    error        // Why neither pandoc nor the fallback could write it
+}
+
+// ExportError reports every format WriteExports could not write, so one
+// failure does not hide the others.
+type ExportError struct {
+	Failures []ExportFailure // The failed formats, in the order requested
+}
+
+// Error lists each failed format with its reason.
+func (e *ExportError) Error() string {
+	reasons := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		reasons[i] = fmt.Sprintf("%s: %v", strings.ToUpper(string(failure.Format)), failure.Err)
+	}
+	return "failed to export " + strings.Join(reasons, "; ")
+}
+
+// Unwrap returns the errors of the failed formats, so errors.Is and
+// errors.As see each of them.
+func (e *ExportError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// WriteExports converts the Markdown resume to each format and writes the
+// results next to it (see ExportPath). pandoc is used when available; when
+// it is not, or when it fails, DOCX and ODT are written by the native
+// exporters and PDF falls back to a print-ready HTML resume. The QR code in
+// opts goes in the header of the PDF, or of the HTML resume it falls back to.
+//
+// The formats are converted concurrently by up to maxExportWorkers workers.
+// A format that cannot be written does not stop the others; every failure
+// is reported together in an *ExportError.
+//
+// Parameters:
+//   - markdownContent: The resume in Markdown
+//   - markdownPath: The path the Markdown resume was written to
+//   - formats: The formats to export to
+//   - opts: Whether to use pandoc, and any HTML resume already written
+//
+// Returns:
+//   - []Export: The files written, in the order of formats
+//   - error: An *ExportError listing the formats that could not be written at all
+//
+// Example:
+//
+//	exports, err := output.WriteExports(content, "resume_out.md", formats, output.ExportOptions{Pandoc: output.PandocPath()})
+//	var exportErr *output.ExportError
+//	if errors.As(err, &exportErr) {
+//	    for _, failure := range exportErr.Failures {
+//	        fmt.Printf("%s: %v\n", failure.Format, failure.Err)
+//	    }
+//	}
+func WriteExports(markdownContent, markdownPath string, formats []ExportFormat, opts ExportOptions) ([]Export, error) {
+	results := make([]Export, len(formats))
+	errs := make([]error, len(formats))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(len(formats), maxExportWorkers, runtime.NumCPU()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = writeExport(markdownContent, markdownPath, formats[i], opts)
+			}
+		}()
+	}
+	for i := range formats {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var exports []Export
+	var failures []ExportFailure
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, ExportFailure{Format: formats[i], Err: err})
+			continue
+		}
+		exports = append(exports, results[i])
+	}
+	if len(failures) > 0 {
+		return exports, &ExportError{Failures: failures}
+	}
+	return exports, nil
+}
+
+// writeExport converts the Markdown resume to one format, with pandoc when
+// it is available and otherwise, or when pandoc fails, with the fallback.
+func writeExport(markdownContent, markdownPath string, format ExportFormat, opts ExportOptions) (Export, error) {
+	export := Export{Format: format, Path: ExportPath(markdownPath, format)}
+
+	if opts.Pandoc != "" {
+		err := convertWithPandoc(opts.Pandoc, markdownContent, markdownPath, export.Path, format, opts.QR)
+		if err == nil {
+			export.Pandoc = true
+			return export, nil
+		}
+		export.Note = fmt.Sprintf("pandoc failed (%v)", err)
+	} else {
+		export.Note = "pandoc is not installed"
+	}
+
+	var err error
+	switch format {
+	case FormatDOCX:
+		err = WriteDOCX(markdownContent, export.Path)
+		export.Note += ", so the built-in DOCX exporter was used"
+	case FormatODT:
+		err = WriteODT(markdownContent, export.Path)
+		export.Note += ", so the built-in ODT exporter was used"
+	case FormatPDF:
+		export.Path, err = writePrintableHTML(markdownContent, markdownPath, opts.HTMLPath, opts.QR)
+		export.Note += "; open this HTML file in a browser and print it to PDF"
+	}
+	return export, err
+}
+
+// convertWithPandoc converts the Markdown resume to outPath with pandoc. A
+// PDF with a QR code is converted from a copy of the resume that shows the
+// code, so the Markdown file itself is left as it is.
+func convertWithPandoc(pandoc, markdownContent, markdownPath, outPath string, format ExportFormat, qrLink string) error {
+	if format != FormatPDF || qrLink == "" {
+		return runPandoc(pandoc, markdownPath, outPath, format)
+	}
+
+	dir, qrPath, err := writeQRSource(markdownContent, qrLink)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	return runPandoc(pandoc, qrPath, outPath, format, "--resource-path", dir)
+}
+
+// runPandoc converts the Markdown file at markdownPath to outPath, passing
+// any extra arguments to pandoc. DOCX exports are styled with the bundled
+// reference document.
+func runPandoc(pandoc, markdownPath, outPath string, format ExportFormat, extra ...string) error {
+	args := append([]string{"--from", "markdown", "--output", outPath}, extra...)
+	if format != FormatPDF {
+		// PDF is not a pandoc writer; it is inferred from the extension
+		args = append(args, "--to", string(format))
+	}
+
+	if format == FormatDOCX {
+		reference, err := os.CreateTemp("", "resumake-reference-*.docx")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(reference.Name())
+		if _, err := reference.Write(referenceDOCX); err != nil {
+			reference.Close()
+			return err
+		}
+		if err := reference.Close(); err != nil {
+			return err
+		}
+		args = append(args, "--reference-doc", reference.Name())
+	}
+
+	if err := ensureDirectoryExists(filepath.Dir(outPath)); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(pandoc, append(args, markdownPath)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		// The first line names the problem; the rest is usually a LaTeX log
+		if msg, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n"); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+
+	// pandoc creates the file with its own mode
+	return restrictMode(outPath)
+}
+
+// writePrintableHTML returns the HTML resume to print to PDF, writing one in
+// the standard layout, with the QR code for qrLink if any, unless an HTML
+// resume was already written.
+func writePrintableHTML(markdownContent, markdownPath, htmlPath, qrLink string) (string, error) {
+	if htmlPath != "" {
+		return htmlPath, nil
+	}
+	return WriteHTMLWithOptions(document.Parse(markdownContent), LayoutStandard, HTMLOptions{QR: qrLink}, markdownPath)
+}
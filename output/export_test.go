@@ -0,0 +1,307 @@
+package output
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const exportResume = "# Jane Doe\n\njane@example.com\n\n## Experience\n\n- Led the **checkout** rewrite & cut latency\n- Built *reporting*\n\n---\n\nMentored engineers."
+
+// readZipPart returns the contents of a part of a zip package.
+func readZipPart(t *testing.T, path, name string) string {
+	t.Helper()
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name == name {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatalf("Failed to open %s: %v", name, err)
+			}
+			defer rc.Close()
+			data, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", name, err)
+			}
+			return string(data)
+		}
+	}
+	t.Fatalf("%s has no %s part", path, name)
+	return ""
+}
+
+// assertWellFormed fails the test if data is not well-formed XML.
+func assertWellFormed(t *testing.T, data string) {
+	t.Helper()
+	decoder := xml.NewDecoder(strings.NewReader(data))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			t.Fatalf("Invalid XML: %v", err)
+		}
+	}
+}
+
+func TestParseExportFormats(t *testing.T) {
+	formats, err := ParseExportFormats(" DOCX, pdf,,docx ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := []ExportFormat{FormatDOCX, FormatPDF}; !reflect.DeepEqual(formats, want) {
+		t.Errorf("ParseExportFormats() = %v, want %v", formats, want)
+	}
+
+	if _, err := ParseExportFormats("docx,rtf"); err == nil || !strings.Contains(err.Error(), "docx, pdf, odt") {
+		t.Errorf("Expected an error listing the formats, got %v", err)
+	}
+}
+
+func TestExportPath(t *testing.T) {
+	if got := ExportPath("out/resume.md", FormatODT); got != "out/resume.odt" {
+		t.Errorf("ExportPath() = %q", got)
+	}
+}
+
+func TestWriteDOCX(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.docx")
+	if err := WriteDOCX(exportResume, path); err != nil {
+		t.Fatalf("WriteDOCX() error = %v", err)
+	}
+
+	document := readZipPart(t, path, "word/document.xml")
+	assertWellFormed(t, document)
+	for _, want := range []string{
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">Jane Doe</w:t>`,
+		`<w:pStyle w:val="ListBullet"/>`,
+		`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">checkout</w:t></w:r>`,
+		`rewrite &amp; cut latency`,
+		`<w:sectPr>`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("Expected document.xml to contain %q", want)
+		}
+	}
+
+	// The styles come from the bundled template
+	if styles := readZipPart(t, path, "word/styles.xml"); !strings.Contains(styles, `w:styleId="ListBullet"`) {
+		t.Error("Expected the template styles to be copied")
+	}
+}
+
+func TestWriteODT(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.odt")
+	if err := WriteODT(exportResume, path); err != nil {
+		t.Fatalf("WriteODT() error = %v", err)
+	}
+
+	// The mimetype must be the first part, stored uncompressed
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("Failed to open ODT: %v", err)
+	}
+	first := r.File[0]
+	r.Close()
+	if first.Name != "mimetype" || first.Method != zip.Store {
+		t.Errorf("Expected an uncompressed mimetype first, got %s (method %d)", first.Name, first.Method)
+	}
+
+	content := readZipPart(t, path, "content.xml")
+	assertWellFormed(t, content)
+	assertWellFormed(t, readZipPart(t, path, "styles.xml"))
+	for _, want := range []string{
+		`<text:h text:style-name="Heading_20_2" text:outline-level="2">Experience</text:h>`,
+		`<text:span text:style-name="Italic">reporting</text:span>`,
+		`</text:list><text:p text:style-name="Horizontal_20_Line"/>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected content.xml to contain %q", want)
+		}
+	}
+}
+
+func TestWriteExports(t *testing.T) {
+	newResume := func(t *testing.T) string {
+		markdownPath := filepath.Join(t.TempDir(), "resume.md")
+		if err := WriteToFile(markdownPath, exportResume); err != nil {
+			t.Fatalf("Failed to write resume: %v", err)
+		}
+		return markdownPath
+	}
+
+	t.Run("Native exporters without pandoc", func(t *testing.T) {
+		markdownPath := newResume(t)
+
+		exports, err := WriteExports(exportResume, markdownPath, ExportFormats, ExportOptions{})
+		if err != nil {
+			t.Fatalf("WriteExports() error = %v", err)
+		}
+		if len(exports) != 3 {
+			t.Fatalf("Expected 3 exports, got %d", len(exports))
+		}
+
+		for _, export := range exports {
+			if export.Pandoc || !strings.Contains(export.Note, "pandoc is not installed") {
+				t.Errorf("Expected a native %s export with a note, got %+v", export.Format, export)
+			}
+			if _, err := os.Stat(export.Path); err != nil {
+				t.Errorf("Expected %s to be written: %v", export.Path, err)
+			}
+		}
+
+		// PDF falls back to a print-ready HTML resume
+		if exports[1].Path != HTMLPath(markdownPath) {
+			t.Errorf("Expected the PDF fallback to be the HTML resume, got %s", exports[1].Path)
+		}
+	})
+
+	t.Run("PDF reuses an HTML resume already written", func(t *testing.T) {
+		markdownPath := newResume(t)
+
+		exports, err := WriteExports(exportResume, markdownPath, []ExportFormat{FormatPDF}, ExportOptions{HTMLPath: "existing.html"})
+		if err != nil || exports[0].Path != "existing.html" {
+			t.Errorf("Expected the existing HTML resume, got %+v (%v)", exports, err)
+		}
+		if _, err := os.Stat(HTMLPath(markdownPath)); !os.IsNotExist(err) {
+			t.Error("Expected no new HTML file to be written")
+		}
+	})
+
+	if runtime.GOOS == "windows" {
+		t.Skip("The fake pandoc is a shell script")
+	}
+
+	// fakePandoc writes a pandoc stand-in that records its arguments and
+	// writes the output file, or fails with the given message
+	fakePandoc := func(t *testing.T, failure string) (string, string) {
+		dir := t.TempDir()
+		argsPath := filepath.Join(dir, "args")
+		script := "#!/bin/sh\necho \"$@\" >> " + argsPath + "\n"
+		if failure != "" {
+			script += "echo '" + failure + "' >&2\necho 'details' >&2\nexit 43\n"
+		} else {
+			script += "while [ \"$1\" != \"--output\" ]; do shift; done\necho converted > \"$2\"\n"
+		}
+		pandoc := filepath.Join(dir, "pandoc")
+		if err := os.WriteFile(pandoc, []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to write fake pandoc: %v", err)
+		}
+		return pandoc, argsPath
+	}
+
+	t.Run("pandoc converts with the reference document", func(t *testing.T) {
+		markdownPath := newResume(t)
+		pandoc, argsPath := fakePandoc(t, "")
+
+		exports, err := WriteExports(exportResume, markdownPath, []ExportFormat{FormatDOCX, FormatPDF}, ExportOptions{Pandoc: pandoc})
+		if err != nil {
+			t.Fatalf("WriteExports() error = %v", err)
+		}
+		for _, export := range exports {
+			if !export.Pandoc || export.Note != "" || export.Path != ExportPath(markdownPath, export.Format) {
+				t.Errorf("Expected pandoc to write %s, got %+v", export.Format, export)
+			}
+		}
+
+		// The conversions run concurrently, so they may be recorded in either order
+		data, _ := os.ReadFile(argsPath)
+		calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+		slices.SortFunc(calls, func(a, b string) int {
+			return strings.Compare(filepath.Ext(strings.Fields(a)[3]), filepath.Ext(strings.Fields(b)[3]))
+		})
+		if len(calls) != 2 || !strings.Contains(calls[0], "--to docx") || !strings.Contains(calls[0], "--reference-doc") {
+			t.Errorf("Expected a DOCX conversion with the reference document, got %q", calls)
+		}
+		if strings.Contains(calls[1], "--to") || strings.Contains(calls[1], "--reference-doc") {
+			t.Errorf("Expected PDF output to be inferred from the extension, got %q", calls[1])
+		}
+	})
+
+	t.Run("every failed format is reported", func(t *testing.T) {
+		// A file where the output directory should be makes every export fail
+		blocker := filepath.Join(t.TempDir(), "blocker")
+		if err := os.WriteFile(blocker, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		markdownPath := filepath.Join(blocker, "resume.md")
+
+		exports, err := WriteExports(exportResume, markdownPath, []ExportFormat{FormatDOCX, FormatODT}, ExportOptions{})
+		var exportErr *ExportError
+		if !errors.As(err, &exportErr) || len(exports) != 0 {
+			t.Fatalf("Expected an ExportError and no exports, got %+v (%v)", exports, err)
+		}
+		if len(exportErr.Failures) != 2 || exportErr.Failures[0].Format != FormatDOCX || exportErr.Failures[1].Format != FormatODT {
+			t.Errorf("Expected both formats to be reported in order, got %+v", exportErr.Failures)
+		}
+		if !strings.Contains(err.Error(), "DOCX: ") || !strings.Contains(err.Error(), "; ODT: ") {
+			t.Errorf("Expected the error to name each format, got %q", err)
+		}
+		if !errors.Is(err, exportErr.Failures[1].Err) {
+			t.Errorf("Expected the failures to be unwrapped, got %v", err)
+		}
+	})
+
+	t.Run("pandoc converts a PDF with a QR code from a copy", func(t *testing.T) {
+		markdownPath := newResume(t)
+		pandoc, argsPath := fakePandoc(t, "")
+
+		exports, err := WriteExports(exportResume, markdownPath, []ExportFormat{FormatPDF}, ExportOptions{Pandoc: pandoc, QR: "https://janedoe.dev"})
+		if err != nil || !exports[0].Pandoc {
+			t.Fatalf("Expected pandoc to write the PDF, got %+v (%v)", exports, err)
+		}
+
+		data, _ := os.ReadFile(argsPath)
+		args := strings.Fields(string(data))
+		source := args[len(args)-1]
+		if !slices.Contains(args, "--resource-path") || source == markdownPath {
+			t.Errorf("Expected a copy of the resume with the QR image, got %q", args)
+		}
+		if _, err := os.Stat(filepath.Dir(source)); !os.IsNotExist(err) {
+			t.Error("Expected the copy to be removed after the conversion")
+		}
+	})
+
+	t.Run("pandoc failures fall back to the native exporters", func(t *testing.T) {
+		markdownPath := newResume(t)
+		pandoc, _ := fakePandoc(t, "pdflatex not found")
+
+		exports, err := WriteExports(exportResume, markdownPath, []ExportFormat{FormatPDF, FormatODT}, ExportOptions{Pandoc: pandoc})
+		if err != nil {
+			t.Fatalf("WriteExports() error = %v", err)
+		}
+		if !strings.Contains(exports[0].Note, "pdflatex not found") || strings.Contains(exports[0].Note, "details") {
+			t.Errorf("Expected the first line of the pandoc error in the note, got %q", exports[0].Note)
+		}
+		if exports[1].Pandoc || exports[1].Path != ExportPath(markdownPath, FormatODT) {
+			t.Errorf("Expected a native ODT export, got %+v", exports[1])
+		}
+	})
+}
+
+func TestPandocPath(t *testing.T) {
+	defer func(original func(string) (string, error)) { lookPath = original }(lookPath)
+
+	lookPath = func(string) (string, error) { return "/usr/bin/pandoc", nil }
+	if got := PandocPath(); got != "/usr/bin/pandoc" {
+		t.Errorf("PandocPath() = %q", got)
+	}
+
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	if got := PandocPath(); got != "" {
+		t.Errorf("Expected no pandoc, got %q", got)
+	}
+}
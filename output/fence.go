@@ -0,0 +1,169 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+// chatterRegex matches conversational lines that models commonly emit around
+// the actual document, such as "Here is your resume:" or "Let me know if...".
+var chatterRegex = regexp.MustCompile(`(?i)^(here('s| is| are)\b|sure\b|certainly\b|of course\b|absolutely\b|okay\b|ok\b|below is\b|i('ve| have) (created|generated|written|put together)\b|let me know\b|i hope\b|feel free\b|please let me know\b|good luck\b)`)
+
+// fenceLineRegex matches a line that opens or closes a code block, with the
+// block's language, if any, as the first group.
+var fenceLineRegex = regexp.MustCompile("^\\s*(?:```|~~~)\\s*([\\w+-]*)\\s*$")
+
+// documentLanguages are the code block languages the model uses when it
+// wraps a whole resume in a code block.
+var documentLanguages = map[string]bool{
+	"":         true,
+	"markdown": true,
+	"md":       true,
+	"text":     true,
+}
+
+// ExtractFencedContent returns the resume portion of a raw model response.
+// The model is instructed to wrap the resume between api.ResumeStartDelimiter
+// and api.ResumeEndDelimiter. When the delimiters are present, only the text
+// between them is kept. A missing end delimiter (for example because it was
+// consumed as a stop sequence, or the response was truncated) keeps everything
+// after the start delimiter.
+//
+// When the model ignores the delimiters entirely, conversational preamble
+// before the first heading and epilogue paragraphs after the document are
+// stripped instead, so chatter such as "Here is your resume:" never reaches
+// the saved Markdown. Either way, a code block wrapped around the resume is
+// removed (see UnwrapCodeFences).
+//
+// Parameters:
+//   - text: The raw text returned by the model
+//
+// Returns:
+//   - string: The extracted resume content
+//
+// Example:
+//
+//	resume := output.ExtractFencedContent("Sure!\n<<<RESUME_START>>>\n# Jane Doe\n<<<RESUME_END>>>")
+//	// resume == "# Jane Doe"
+func ExtractFencedContent(text string) string {
+	if start := strings.Index(text, api.ResumeStartDelimiter); start >= 0 {
+		text = text[start+len(api.ResumeStartDelimiter):]
+		if end := strings.Index(text, api.ResumeEndDelimiter); end >= 0 {
+			text = text[:end]
+		}
+		return UnwrapCodeFences(text)
+	}
+
+	// A lone end delimiter still marks where the document stops
+	if end := strings.Index(text, api.ResumeEndDelimiter); end >= 0 {
+		text = text[:end]
+	}
+
+	return UnwrapCodeFences(stripChatter(text))
+}
+
+// UnwrapCodeFences removes the code block the model often wraps a whole
+// resume in, such as one opened with "```markdown", so the resume is saved as
+// Markdown rather than as a code listing. A block wrapped more than once is
+// unwrapped each time. Fence lines left without a partner, such as the
+// opening fence of a truncated response or a closing fence after removed
+// chatter, are dropped too; code blocks that open and close inside the
+// resume are kept.
+//
+// Parameters:
+//   - text: The resume text
+//
+// Returns:
+//   - string: The text without wrapping or stray fences
+//
+// Example:
+//
+//	resume := output.UnwrapCodeFences("```markdown\n# Jane Doe\n```")
+//	// resume == "# Jane Doe"
+func UnwrapCodeFences(text string) string {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n")), "\n")
+
+	// Unwrap a document whose first line opens a block and whose last line closes it
+	for len(lines) >= 2 {
+		opening := fenceLineRegex.FindStringSubmatch(lines[0])
+		closing := fenceLineRegex.FindStringSubmatch(lines[len(lines)-1])
+		if opening == nil || closing == nil || closing[1] != "" || !documentLanguages[strings.ToLower(opening[1])] {
+			break
+		}
+		lines = trimBlankLines(lines[1 : len(lines)-1])
+	}
+
+	// Pair the remaining fences in order; an opening fence without a closing
+	// one, or a closing fence that opens nothing, is stray
+	var kept []string
+	open := -1
+	for _, line := range lines {
+		match := fenceLineRegex.FindStringSubmatch(line)
+		switch {
+		case match == nil:
+		case open >= 0 && match[1] == "":
+			open = -1
+		case open < 0:
+			open = len(kept)
+		}
+		kept = append(kept, line)
+	}
+	if open >= 0 {
+		kept = append(kept[:open], kept[open+1:]...)
+	}
+
+	// A stray fence at the end of the last line, as in "- Go```"
+	text = strings.TrimSpace(strings.Join(kept, "\n"))
+	if !strings.HasSuffix(text, "\n```") && strings.Count(text, "```")%2 == 1 {
+		text = strings.TrimSpace(strings.TrimSuffix(text, "```"))
+	}
+	return strings.TrimSpace(text)
+}
+
+// trimBlankLines removes blank lines from the start and end of lines.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// stripChatter removes conversational preamble and epilogue paragraphs from
+// undelimited model output.
+func stripChatter(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	// Drop leading chatter lines that appear before the document starts
+	for len(lines) > 0 {
+		trimmed := strings.TrimSpace(lines[0])
+		if trimmed == "" || chatterRegex.MatchString(trimmed) {
+			lines = lines[1:]
+			continue
+		}
+		break
+	}
+
+	// Drop trailing chatter lines, including a horizontal rule that separated
+	// the document from a removed epilogue
+	removedEpilogue := false
+	for len(lines) > 0 {
+		trimmed := strings.TrimSpace(lines[len(lines)-1])
+		if trimmed == "" {
+			lines = lines[:len(lines)-1]
+			continue
+		}
+		if chatterRegex.MatchString(trimmed) || (removedEpilogue && hrRegex.MatchString(trimmed)) {
+			lines = lines[:len(lines)-1]
+			removedEpilogue = true
+			continue
+		}
+		break
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestExtractFencedContent(t *testing.T) {
+	start := api.ResumeStartDelimiter
+	end := api.ResumeEndDelimiter
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "delimited content with preamble and epilogue",
+			text:     "Here is your resume:\n" + start + "\n# Jane Doe\n\n- Go\n" + end + "\nLet me know if you need changes!",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "start delimiter without end delimiter",
+			text:     "Sure!\n" + start + "\n# Jane Doe\n\n- Go\n",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "end delimiter without start delimiter",
+			text:     "# Jane Doe\n\n- Go\n" + end + "\nHope this helps",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "undelimited content with chatter",
+			text:     "Certainly! Here is the polished resume:\n\n# Jane Doe\n\n- Go\n\n---\n\nI hope this helps with your job search.",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "undelimited content without chatter",
+			text:     "# Jane Doe\n\n## Skills\n\n- Go\n\n---\n\n## Education",
+			expected: "# Jane Doe\n\n## Skills\n\n- Go\n\n---\n\n## Education",
+		},
+		{
+			name:     "delimited content in a code block",
+			text:     start + "\n```markdown\n# Jane Doe\n\n- Go\n```\n" + end,
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "code block with chatter around it",
+			text:     "Here is your resume:\n\n```markdown\n# Jane Doe\n\n- Go\n```\n\nLet me know if you need changes!",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "empty content",
+			text:     "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractFencedContent(tt.text); got != tt.expected {
+				t.Errorf("ExtractFencedContent() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnwrapCodeFences(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "markdown block",
+			text:     "```markdown\n# Jane Doe\n\n- Go\n```",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "block without a language and blank lines inside",
+			text:     "\n```\n\n# Jane Doe\n\n- Go\n\n```\n",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "block wrapped twice",
+			text:     "```md\n~~~markdown\n# Jane Doe\n~~~\n```",
+			expected: "# Jane Doe",
+		},
+		{
+			name:     "truncated block without a closing fence",
+			text:     "```markdown\n# Jane Doe\n\n- Go",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "stray closing fence",
+			text:     "# Jane Doe\n\n- Go\n```",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "stray fence at the end of a line",
+			text:     "# Jane Doe\n\n- Go```",
+			expected: "# Jane Doe\n\n- Go",
+		},
+		{
+			name:     "code block inside the resume is kept",
+			text:     "# Jane Doe\n\n```go\nfmt.Println(\"hi\")\n```\n\n- Go",
+			expected: "# Jane Doe\n\n```go\nfmt.Println(\"hi\")\n```\n\n- Go",
+		},
+		{
+			name:     "code block in another language is not unwrapped",
+			text:     "```go\nfmt.Println(\"hi\")\n```",
+			expected: "```go\nfmt.Println(\"hi\")\n```",
+		},
+		{
+			name:     "windows line endings",
+			text:     "```markdown\r\n# Jane Doe\r\n```\r\n",
+			expected: "# Jane Doe",
+		},
+		{
+			name:     "no fences",
+			text:     "# Jane Doe\n\n- Go",
+			expected: "# Jane Doe\n\n- Go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnwrapCodeFences(tt.text); got != tt.expected {
+				t.Errorf("UnwrapCodeFences() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
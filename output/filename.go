@@ -0,0 +1,87 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// DatedFileName returns the file name for a resume generated at t, built from
+// the candidate's name and the date, e.g. "Jane_Doe_Resume_2024-06-01.md".
+// Only letters, digits, and hyphens are kept from the name, with words joined
+// by underscores. Without a usable name it is "Resume_2024-06-01.md".
+//
+// Parameters:
+//   - candidateName: The candidate's name, such as "Jane Doe" (can be empty)
+//   - t: The time the resume was generated
+//
+// Returns:
+//   - string: The file name
+//
+// Example:
+//
+//	name := output.DatedFileName("Jane Doe", time.Now())
+func DatedFileName(candidateName string, t time.Time) string {
+	var words []string
+	for _, word := range strings.Fields(candidateName) {
+		word = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+				return r
+			}
+			return -1
+		}, word)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	// A title such as "# Resume" is not a name
+	name := strings.Join(words, "_")
+	if strings.EqualFold(name, "resume") || strings.EqualFold(name, "curriculum_vitae") {
+		name = ""
+	}
+
+	if name != "" {
+		name += "_"
+	}
+	return name + "Resume_" + t.Format("2006-01-02") + filepath.Ext(DefaultOutputPath)
+}
+
+// DatedOutputPath returns a path in the current directory for a generated
+// resume, named by DatedFileName after the candidate in the resume's title.
+// If a file of that name already exists, a numeric suffix is added
+// (Jane_Doe_Resume_2024-06-01-2.md, -3, ...) so earlier resumes are never
+// overwritten.
+//
+// Parameters:
+//   - markdownContent: The generated resume
+//   - t: The time the resume was generated
+//
+// Returns:
+//   - string: The output path
+//   - error: An error if existing files cannot be checked
+//
+// Example:
+//
+//	path, err := output.DatedOutputPath(markdownContent, time.Now())
+func DatedOutputPath(markdownContent string, t time.Time) (string, error) {
+	name := DatedFileName(document.Parse(markdownContent).Name, t)
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+
+	candidate := name
+	for i := 2; ; i++ {
+		_, err := os.Stat(candidate)
+		if os.IsNotExist(err) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check output file: %w", err)
+		}
+		candidate = fmt.Sprintf("%s-%d%s", stem, i, filepath.Ext(name))
+	}
+}
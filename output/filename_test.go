@@ -0,0 +1,56 @@
+package output
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDatedFileName(t *testing.T) {
+	date := time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Jane Doe", "Jane_Doe_Resume_2024-06-01.md"},
+		{"  Mary-Kate  O'Neil ", "Mary-Kate_ONeil_Resume_2024-06-01.md"},
+		{"José Álvarez, PhD", "José_Álvarez_PhD_Resume_2024-06-01.md"},
+		{"Resume", "Resume_2024-06-01.md"},
+		{"../..", "Resume_2024-06-01.md"},
+		{"", "Resume_2024-06-01.md"},
+	}
+
+	for _, tc := range tests {
+		if got := DatedFileName(tc.name, date); got != tc.want {
+			t.Errorf("DatedFileName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDatedOutputPath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	date := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	markdown := "# Jane Doe\n\n## Experience\n- Built things"
+
+	// Test case 1: The name comes from the resume's title
+	path, err := DatedOutputPath(markdown, date)
+	if err != nil || path != "Jane_Doe_Resume_2024-06-01.md" {
+		t.Fatalf("DatedOutputPath() = %q, %v", path, err)
+	}
+
+	// Test case 2: An existing resume from the same day is not overwritten
+	if err := os.WriteFile(path, []byte("# Old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path, err = DatedOutputPath(markdown, date); err != nil || path != "Jane_Doe_Resume_2024-06-01-2.md" {
+		t.Errorf("DatedOutputPath() = %q, %v, want the -2 suffix", path, err)
+	}
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// GapsSuffix is appended to the resume's file name, before its extension,
+// to name the appendix suggesting how to close the gaps with a job.
+const GapsSuffix = "_gaps"
+
+// GapsTitle heads the gaps appendix.
+const GapsTitle = "# Closing the Gaps"
+
+// GapsPath returns the path of the gaps appendix written next to a Markdown
+// resume: the same name with GapsSuffix before a .md extension.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - string: The gaps appendix path
+//
+// Example:
+//
+//	path := output.GapsPath("Jane_Doe_Resume_2024-06-01.md")
+//	// path == "Jane_Doe_Resume_2024-06-01_gaps.md"
+func GapsPath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + GapsSuffix + ".md"
+}
+
+// WriteGaps writes the model's suggestions for closing the gaps with a job
+// next to the Markdown resume, under GapsTitle and a line listing the job
+// keywords the resume lacks.
+//
+// Parameters:
+//   - suggestions: The courses, certifications, and projects in Markdown
+//   - gaps: The job description keywords the resume does not mention
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - string: The path of the gaps appendix
+//   - error: Any error that occurred while writing
+func WriteGaps(suggestions string, gaps []string, markdownPath string) (string, error) {
+	gapsPath := GapsPath(markdownPath)
+	content := GapsTitle + "\n\n" +
+		"Keywords from the job description that your resume does not mention: " + strings.Join(gaps, ", ") + ".\n\n" +
+		strings.TrimSpace(suggestions) + "\n"
+	if err := WriteTextFile(gapsPath, content); err != nil {
+		return "", fmt.Errorf("failed to write gaps appendix: %w", err)
+	}
+	return gapsPath, nil
+}
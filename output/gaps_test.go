@@ -0,0 +1,31 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteGaps(t *testing.T) {
+	tempDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	markdownPath := filepath.Join(tempDir, "Jane_Doe_Resume.md")
+
+	gapsPath, err := WriteGaps("\n## Projects\n\n- Deploy a small service to AWS with Terraform\n", []string{"terraform", "aws"}, markdownPath)
+	if err != nil {
+		t.Fatalf("WriteGaps() error = %v", err)
+	}
+	if gapsPath != filepath.Join(tempDir, "Jane_Doe_Resume_gaps.md") {
+		t.Errorf("Unexpected gaps path %q", gapsPath)
+	}
+
+	data, err := os.ReadFile(gapsPath)
+	if err != nil {
+		t.Fatalf("Failed to read gaps appendix: %v", err)
+	}
+	expected := GapsTitle + "\n\nKeywords from the job description that your resume does not mention: terraform, aws.\n\n" +
+		"## Projects\n\n- Deploy a small service to AWS with Terraform\n"
+	if string(data) != expected {
+		t.Errorf("Expected gaps appendix %q, got %q", expected, data)
+	}
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// citationRegex matches a citation of numbered input sentences, such as
+// "[S3]", "[S3, S7]", or "[S3-S5]", with the space before it.
+var citationRegex = regexp.MustCompile(`[ \t]*\[S(\d+)((?:\s*[,;–-]\s*S?\d+)*)\]`)
+
+// citationNumberRegex matches each sentence number in a citation.
+var citationNumberRegex = regexp.MustCompile(`\d+`)
+
+// bulletRegex matches a bullet: a list item with text after its marker.
+var bulletRegex = regexp.MustCompile(`^\s*(?:[-*+]|\d{1,9}[.)])\s+(\S.*)$`)
+
+// CheckCitations removes the citations of numbered input sentences the
+// model added to a resume in strict grounding, and finds the bullets that
+// cite none of the sentences. A citation of a number no sentence has does
+// not count. Code blocks are left as they are.
+//
+// Parameters:
+//   - markdown: The generated resume, with citations such as "[S3, S7]"
+//   - sentences: The number of input sentences the model could cite
+//   - drop: Whether to leave out the bullets that cite no sentence, rather than keep them
+//
+// Returns:
+//   - string: The resume without citations, and without uncited bullets if drop is set
+//   - []string: The text of each bullet that cites no sentence, in order
+//
+// Example:
+//
+//	resume, uncited := output.CheckCitations("- Led the team [S1]\n- Won an award", 4, true)
+//	// resume == "- Led the team", uncited == []string{"Won an award"}
+func CheckCitations(markdown string, sentences int, drop bool) (string, []string) {
+	var kept, uncited []string
+	inFence := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if inFence || strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			kept = append(kept, line)
+			continue
+		}
+
+		cited := false
+		for _, match := range citationRegex.FindAllString(line, -1) {
+			for _, number := range citationNumberRegex.FindAllString(match, -1) {
+				if n, err := strconv.Atoi(number); err == nil && n >= 1 && n <= sentences {
+					cited = true
+				}
+			}
+		}
+		line = citationRegex.ReplaceAllString(line, "")
+
+		if bullet := bulletRegex.FindStringSubmatch(line); bullet != nil && !thematicBreakRegex.MatchString(line) && !cited {
+			uncited = append(uncited, strings.TrimSpace(bullet[1]))
+			if drop {
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n"), uncited
+}
+
+// StripCitations removes citations of numbered input sentences from text
+// the model wrote later in a strict grounding session, such as a
+// regenerated section or a cover letter, which is not checked.
+//
+// Parameters:
+//   - text: Text that may contain citations such as "[S3]"
+//
+// Returns:
+//   - string: The text without citations
+func StripCitations(text string) string {
+	return citationRegex.ReplaceAllString(text, "")
+}
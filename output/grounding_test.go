@@ -0,0 +1,54 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckCitations(t *testing.T) {
+	resume := "# Jane Doe\n\nBackend engineer [S1].\n\n## Experience\n\n- Led the payments team [S2, S4]\n- Won an industry award\n- Cut costs by 30% [S99]\n  - Moved to Kubernetes [S3][S5]\n\n```\n- [S7] left alone\n```"
+
+	tests := []struct {
+		name    string
+		drop    bool
+		want    string
+		uncited []string
+	}{
+		{
+			name:    "flagged bullets are kept",
+			drop:    false,
+			want:    "# Jane Doe\n\nBackend engineer.\n\n## Experience\n\n- Led the payments team\n- Won an industry award\n- Cut costs by 30%\n  - Moved to Kubernetes\n\n```\n- [S7] left alone\n```",
+			uncited: []string{"Won an industry award", "Cut costs by 30%"},
+		},
+		{
+			name:    "dropped bullets are left out",
+			drop:    true,
+			want:    "# Jane Doe\n\nBackend engineer.\n\n## Experience\n\n- Led the payments team\n  - Moved to Kubernetes\n\n```\n- [S7] left alone\n```",
+			uncited: []string{"Won an industry award", "Cut costs by 30%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, uncited := CheckCitations(resume, 5, tt.drop)
+			if got != tt.want {
+				t.Errorf("CheckCitations() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if !reflect.DeepEqual(uncited, tt.uncited) {
+				t.Errorf("CheckCitations() uncited = %q, want %q", uncited, tt.uncited)
+			}
+		})
+	}
+}
+
+func TestStripCitations(t *testing.T) {
+	// Test case 1: Citations are removed with the space before them
+	if got := StripCitations("Dear Acme, I led the team [S2-S4]."); got != "Dear Acme, I led the team." {
+		t.Errorf("StripCitations() = %q", got)
+	}
+
+	// Test case 2: Other bracketed text is kept
+	if got := StripCitations("See [my portfolio](https://example.com) [Sr. role]"); got != "See [my portfolio](https://example.com) [Sr. role]" {
+		t.Errorf("StripCitations() = %q", got)
+	}
+}
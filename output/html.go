@@ -0,0 +1,97 @@
+package output
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// htmlListItemRegex matches an unordered or ordered list item, capturing its text
+	htmlListItemRegex = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(.*)$`)
+
+	// htmlOrderedItemRegex matches an ordered list marker
+	htmlOrderedItemRegex = regexp.MustCompile(`^\d+[.)]\s`)
+
+	// Inline formatting, applied to already-escaped text
+	htmlLinkRegex   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	htmlCodeRegex   = regexp.MustCompile("`([^`]+)`")
+	htmlBoldRegex   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	htmlItalicRegex = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// markdownToHTML converts the Markdown subset used in resumes to HTML:
+// ATX headings, bulleted and numbered lists, horizontal rules, paragraphs,
+// and bold, italic, code, and link formatting. Nested list items are
+// rendered at a single level. All text is HTML-escaped.
+func markdownToHTML(markdown string) string {
+	var b strings.Builder
+	var paragraph []string
+	listTag := ""
+
+	closeParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			b.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			closeParagraph()
+			closeList()
+
+		case hrRegex.MatchString(trimmed):
+			closeParagraph()
+			closeList()
+			b.WriteString("<hr>\n")
+
+		case sectionHeadingRegex.MatchString(trimmed):
+			closeParagraph()
+			closeList()
+			match := sectionHeadingRegex.FindStringSubmatch(trimmed)
+			tag := "h" + string(rune('0'+len(match[1])))
+			b.WriteString("<" + tag + ">" + inlineHTML(match[2]) + "</" + tag + ">\n")
+
+		case htmlListItemRegex.MatchString(trimmed):
+			closeParagraph()
+			tag := "ul"
+			if htmlOrderedItemRegex.MatchString(trimmed) {
+				tag = "ol"
+			}
+			if listTag != tag {
+				closeList()
+				b.WriteString("<" + tag + ">\n")
+				listTag = tag
+			}
+			b.WriteString("<li>" + inlineHTML(htmlListItemRegex.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+
+		default:
+			closeList()
+			paragraph = append(paragraph, inlineHTML(trimmed))
+		}
+	}
+	closeParagraph()
+	closeList()
+
+	return b.String()
+}
+
+// inlineHTML escapes text and converts inline Markdown formatting to HTML.
+func inlineHTML(text string) string {
+	text = html.EscapeString(text)
+	text = htmlCodeRegex.ReplaceAllString(text, "<code>$1</code>")
+	text = htmlLinkRegex.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = htmlBoldRegex.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = htmlItalicRegex.ReplaceAllString(text, "<em>$1$2</em>")
+	return text
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// JSONResumePath returns the path of the JSON Resume file written next to a
+// Markdown resume: the same name with a .json extension.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - string: The JSON path
+func JSONResumePath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + ".json"
+}
+
+// InvalidJSONResumeError is returned when a JSON Resume breaks the schema.
+// No file is written, so an invalid export never reaches disk.
+type InvalidJSONResumeError struct {
+	Violations []document.Violation // Every schema violation found
+}
+
+// Error lists the violations.
+func (e *InvalidJSONResumeError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+	return fmt.Sprintf("JSON Resume export has %d schema violation(s): %s", len(e.Violations), strings.Join(messages, "; "))
+}
+
+// WriteJSONResume validates the resume against the JSON Resume schema and
+// writes it next to the Markdown resume (see JSONResumePath).
+//
+// Parameters:
+//   - resume: The resume in JSON Resume format
+//   - markdownPath: The path the Markdown resume was written to
+//
+// Returns:
+//   - string: The path of the JSON file
+//   - error: An *InvalidJSONResumeError if the resume breaks the schema, or
+//     an error if the file could not be written
+//
+// Example:
+//
+//	jsonPath, err := output.WriteJSONResume(document.Parse(content).JSONResume(), "resume_out.md")
+//	// jsonPath == "resume_out.json"
+func WriteJSONResume(resume document.JSONResume, markdownPath string) (string, error) {
+	if violations := resume.Validate(); len(violations) > 0 {
+		return "", &InvalidJSONResumeError{Violations: violations}
+	}
+
+	data, err := json.MarshalIndent(resume, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON Resume: %w", err)
+	}
+
+	jsonPath := JSONResumePath(markdownPath)
+	if err := WriteToFile(jsonPath, string(data)+"\n"); err != nil {
+		return "", fmt.Errorf("failed to write JSON Resume output: %w", err)
+	}
+	return jsonPath, nil
+}
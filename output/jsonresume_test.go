@@ -0,0 +1,51 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+const jsonTestResume = "# Jane Doe\n\njane@example.com\n\n## Experience\n\n### Engineer | Acme\n\n2019 - Present\n\n- Shipped code"
+
+func TestWriteJSONResume(t *testing.T) {
+	tempDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	markdownPath := filepath.Join(tempDir, "resume.md")
+
+	// Test case 1: A valid resume is written next to the Markdown file
+	resume := document.Parse(jsonTestResume).JSONResume()
+	jsonPath, err := WriteJSONResume(resume, markdownPath)
+	if err != nil {
+		t.Fatalf("WriteJSONResume() error = %v", err)
+	}
+	if jsonPath != filepath.Join(tempDir, "resume.json") {
+		t.Errorf("Unexpected JSON path %q", jsonPath)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSON file: %v", err)
+	}
+	var decoded document.JSONResume
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Basics.Name != "Jane Doe" {
+		t.Errorf("Expected the resume in JSON Resume format, got %s (%v)", data, err)
+	}
+
+	// Test case 2: An invalid resume is reported instead of written
+	os.Remove(jsonPath)
+	resume.Basics.Email = "not an email"
+	_, err = WriteJSONResume(resume, markdownPath)
+
+	var invalid *InvalidJSONResumeError
+	if !errors.As(err, &invalid) || len(invalid.Violations) != 1 || invalid.Violations[0].Field != "basics.email" {
+		t.Fatalf("Expected an InvalidJSONResumeError for basics.email, got %v", err)
+	}
+	if _, err := os.Stat(jsonPath); !os.IsNotExist(err) {
+		t.Error("An invalid resume should not be written")
+	}
+}
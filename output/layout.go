@@ -0,0 +1,383 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// Layout selects how the HTML version of the resume is arranged.
+type Layout string
+
+const (
+	// LayoutStandard renders every section in a single column.
+	LayoutStandard Layout = "standard"
+
+	// LayoutTwoColumn moves skills and certifications into a sidebar next to
+	// the main sections.
+	LayoutTwoColumn Layout = "two-column"
+
+	// LayoutCompact uses tighter spacing and smaller type so the resume fits
+	// on a single printed page.
+	LayoutCompact Layout = "compact"
+)
+
+// Layouts lists the supported layouts in the order they are presented to users.
+var Layouts = []Layout{LayoutStandard, LayoutTwoColumn, LayoutCompact}
+
+// sidebarKeywords are the section heading words placed in the two-column sidebar.
+var sidebarKeywords = []string{"skills", "certifications", "languages"}
+
+// ParseLayout converts a layout name (case-insensitive) to a Layout.
+//
+// Parameters:
+//   - name: The layout name, such as "two-column"
+//
+// Returns:
+//   - Layout: The matching layout
+//   - error: An error if the name is not a supported layout
+func ParseLayout(name string) (Layout, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	names := make([]string, len(Layouts))
+	for i, layout := range Layouts {
+		if string(layout) == name {
+			return layout, nil
+		}
+		names[i] = string(layout)
+	}
+	return "", fmt.Errorf("unknown layout %q (expected one of %s)", name, strings.Join(names, ", "))
+}
+
+// ParseLayouts parses a comma-separated list of layout names. Duplicates are
+// ignored.
+//
+// Parameters:
+//   - list: The layouts, such as "standard,compact" (case-insensitive)
+//
+// Returns:
+//   - []Layout: The layouts in the order given, or nil for an empty list
+//   - error: An error if a name is not a supported layout
+func ParseLayouts(list string) ([]Layout, error) {
+	var layouts []Layout
+	for _, name := range strings.Split(list, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		layout, err := ParseLayout(name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(layouts, layout) {
+			layouts = append(layouts, layout)
+		}
+	}
+	return layouts, nil
+}
+
+// layoutCSS holds the styles shared by every layout.
+const layoutCSS = `body { font-family: "Helvetica Neue", Arial, sans-serif; color: #222; line-height: 1.45; margin: 0; }
+.resume { max-width: 8.5in; margin: 0 auto; padding: 0.6in; box-sizing: border-box; }
+header { border-bottom: 2px solid #2c3e50; margin-bottom: 1em; }
+h1 { font-size: 2em; margin: 0 0 0.2em; color: #2c3e50; }
+h2 { font-size: 1.2em; text-transform: uppercase; letter-spacing: 0.05em; color: #2c3e50; border-bottom: 1px solid #ccc; padding-bottom: 0.15em; }
+h3 { font-size: 1.05em; margin-bottom: 0.2em; }
+ul, ol { padding-left: 1.2em; }
+a { color: #2c3e50; }
+@page { size: letter; margin: 0.5in; }
+@media print { .resume { padding: 0; } }
+`
+
+// twoColumnCSS arranges the sidebar next to the main column.
+const twoColumnCSS = `.columns { display: grid; grid-template-columns: 2.2in 1fr; gap: 0.35in; }
+aside { background: #f3f5f7; padding: 0.1in 0.2in; }
+aside ul { list-style: none; padding-left: 0; }
+aside li { margin-bottom: 0.3em; }
+`
+
+// compactCSS tightens spacing and type so the resume fits on one page.
+const compactCSS = `body { font-size: 9.5pt; line-height: 1.25; }
+.resume { padding: 0.4in; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1em; margin: 0.7em 0 0.3em; }
+h3 { font-size: 1em; margin: 0.4em 0 0.1em; }
+p, ul, ol { margin: 0.2em 0; }
+@page { size: letter; margin: 0.35in; }
+@media print { .resume { padding: 0; } section { break-inside: avoid; } }
+`
+
+// timelineCSS styles the optional timeline section.
+const timelineCSS = `.timeline pre { font-family: Menlo, Consolas, "DejaVu Sans Mono", monospace; font-size: 0.75em; line-height: 1.2; overflow-x: auto; }
+`
+
+// qrCSS places the QR code at the right of the header.
+const qrCSS = `.qr { float: right; width: 1in; height: 1in; margin: 0 0 0.1in 0.2in; }
+.qr svg { display: block; width: 100%; height: 100%; }
+`
+
+// qrLayoutCSS adjusts the QR code for each layout: smaller in the compact
+// layout, and at the top of the sidebar in the two-column layout.
+var qrLayoutCSS = map[Layout]string{
+	LayoutTwoColumn: `aside .qr { float: none; margin: 0.1in auto 0.2in; }
+`,
+	LayoutCompact: `.qr { width: 0.75in; height: 0.75in; }
+`,
+}
+
+// timelineChartWidth is the width in characters of the timeline chart in HTML output.
+const timelineChartWidth = 90
+
+// HTMLOptions holds optional extras for the HTML resume.
+type HTMLOptions struct {
+	Timeline   bool      // Add a timeline of roles and education after the other sections
+	QR         string    // URL to link with a QR code in the header, or the sidebar of the two-column layout (empty to skip)
+	PageBreaks bool      // Mark where pages are estimated to break, on screen only, and warn where they split a role's bullets
+	Now        time.Time // The current time, used as the end of ongoing roles (zero for time.Now)
+}
+
+// RenderHTML renders the structured resume as a standalone HTML document
+// in the given layout. The sections come from resume.RenderedSections, so
+// structured skills look the same in every layout. The document includes
+// print styles, so it can be saved as a PDF from a browser.
+//
+// Parameters:
+//   - resume: The structured resume to render
+//   - layout: The layout to use (an empty layout renders LayoutStandard)
+//
+// Returns:
+//   - string: The HTML document
+//
+// Example:
+//
+//	resume := document.Parse(markdownContent)
+//	page := output.RenderHTML(resume, output.LayoutTwoColumn)
+func RenderHTML(resume document.Resume, layout Layout) string {
+	return RenderHTMLWithOptions(resume, layout, HTMLOptions{})
+}
+
+// RenderHTMLWithOptions renders the structured resume like RenderHTML, with
+// the optional extras in opts. The timeline section shows roles and
+// education as a Unicode chart, followed by any gaps between them. The QR
+// code is an inline SVG linking to its URL, so the page stays standalone.
+// The page-break markers come from EstimatePageBreaks and are hidden when
+// the page is printed.
+//
+// Parameters:
+//   - resume: The structured resume to render
+//   - layout: The layout to use (an empty layout renders LayoutStandard)
+//   - opts: The optional extras to include
+//
+// Returns:
+//   - string: The HTML document
+//
+// Example:
+//
+//	page := output.RenderHTMLWithOptions(resume, output.LayoutStandard, output.HTMLOptions{Timeline: true})
+func RenderHTMLWithOptions(resume document.Resume, layout Layout, opts HTMLOptions) string {
+	if layout == "" {
+		layout = LayoutStandard
+	}
+
+	css := layoutCSS
+	switch layout {
+	case LayoutTwoColumn:
+		css += twoColumnCSS
+	case LayoutCompact:
+		css += compactCSS
+	}
+	if opts.Timeline {
+		css += timelineCSS
+	}
+	qrCode := qrHTML(opts.QR)
+	if qrCode != "" {
+		css += qrCSS + qrLayoutCSS[layout]
+	}
+	sidebar, primary := columnSections(resume.RenderedSections(), layout)
+	var breaks []PageBreak
+	if opts.PageBreaks {
+		css += pageBreakCSS
+		breaks = estimatePageBreaks(resume, primary, layoutMetrics[layout])
+	}
+
+	title := resume.Name
+	if title == "" {
+		title = "Resume"
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	b.WriteString("<style>\n" + css + "</style>\n</head>\n")
+	b.WriteString("<body class=\"layout-" + string(layout) + "\">\n<div class=\"resume\">\n")
+	b.WriteString(pageBreakWarningsHTML(breaks))
+
+	// Name and contact details
+	b.WriteString("<header>\n")
+	if layout != LayoutTwoColumn {
+		b.WriteString(qrCode)
+	}
+	if resume.Name != "" {
+		b.WriteString("<h1>" + inlineHTML(resume.Name) + "</h1>\n")
+	}
+	b.WriteString(markdownToHTML(resume.Header))
+	b.WriteString("</header>\n")
+
+	if layout == LayoutTwoColumn {
+		b.WriteString("<div class=\"columns\">\n<aside>\n")
+		b.WriteString(qrCode)
+		writeSections(&b, sidebar, nil)
+		b.WriteString("</aside>\n<main>\n")
+		writeSections(&b, primary, breaks)
+		writeTimeline(&b, resume, opts)
+		b.WriteString("</main>\n</div>\n")
+	} else {
+		b.WriteString("<main>\n")
+		writeSections(&b, primary, breaks)
+		writeTimeline(&b, resume, opts)
+		b.WriteString("</main>\n")
+	}
+
+	b.WriteString("</div>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// columnSections splits the sections into those of the two-column
+// layout's sidebar and those of the main column. Other layouts have no
+// sidebar, so every section is in the main column.
+func columnSections(sections []document.Section, layout Layout) ([]document.Section, []document.Section) {
+	if layout != LayoutTwoColumn {
+		return nil, sections
+	}
+	var sidebar, primary []document.Section
+	for _, section := range sections {
+		if isSidebarSection(section) {
+			sidebar = append(sidebar, section)
+		} else {
+			primary = append(primary, section)
+		}
+	}
+	return sidebar, primary
+}
+
+// isSidebarSection reports whether a section belongs in the two-column sidebar.
+func isSidebarSection(section document.Section) bool {
+	heading := strings.ToLower(section.Heading)
+	for _, keyword := range sidebarKeywords {
+		if strings.Contains(heading, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSections appends each section as an HTML <section> element, with a
+// marker where each of the page breaks falls.
+func writeSections(b *strings.Builder, sections []document.Section, breaks []PageBreak) {
+	for i, section := range sections {
+		lines := strings.Split(section.Body, "\n")
+		start := 0
+		var body strings.Builder
+		for _, pageBreak := range breaks {
+			if pageBreak.section != i {
+				continue
+			}
+			if pageBreak.line < 0 {
+				b.WriteString(pageBreakHTML(pageBreak))
+				continue
+			}
+			body.WriteString(markdownToHTML(strings.Join(lines[start:pageBreak.line], "\n")))
+			body.WriteString(pageBreakHTML(pageBreak))
+			start = pageBreak.line
+		}
+		body.WriteString(markdownToHTML(strings.Join(lines[start:], "\n")))
+
+		b.WriteString("<section>\n<h2>" + inlineHTML(section.Heading) + "</h2>\n")
+		b.WriteString(body.String())
+		b.WriteString("</section>\n")
+	}
+}
+
+// writeTimeline appends the timeline section when it was requested and the
+// resume has dated roles or education.
+func writeTimeline(b *strings.Builder, resume document.Resume, opts HTMLOptions) {
+	if !opts.Timeline {
+		return
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	timeline := resume.Timeline(now)
+	chart := timeline.Chart(timelineChartWidth)
+	if chart == "" {
+		return
+	}
+
+	b.WriteString("<section class=\"timeline\">\n<h2>Timeline</h2>\n")
+	b.WriteString("<pre>" + html.EscapeString(chart) + "</pre>\n")
+	if len(timeline.Gaps) > 0 {
+		b.WriteString("<ul>\n")
+		for _, gap := range timeline.Gaps {
+			b.WriteString("<li>" + html.EscapeString(gap.String()) + "</li>\n")
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString("</section>\n")
+}
+
+// HTMLPath returns the path of the HTML file written next to a Markdown
+// resume: the same name with an .html extension.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - string: The HTML path
+func HTMLPath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + ".html"
+}
+
+// WriteHTML renders the resume in the given layout and writes it next to
+// the Markdown resume (see HTMLPath).
+//
+// Parameters:
+//   - resume: The structured resume to render
+//   - layout: The layout to use
+//   - markdownPath: The path the Markdown resume was written to
+//
+// Returns:
+//   - string: The path of the HTML file
+//   - error: An error if the file could not be written
+//
+// Example:
+//
+//	htmlPath, err := output.WriteHTML(document.Parse(content), output.LayoutCompact, "resume_out.md")
+//	// htmlPath == "resume_out.html"
+func WriteHTML(resume document.Resume, layout Layout, markdownPath string) (string, error) {
+	return WriteHTMLWithOptions(resume, layout, HTMLOptions{}, markdownPath)
+}
+
+// WriteHTMLWithOptions renders the resume like RenderHTMLWithOptions and
+// writes it next to the Markdown resume (see HTMLPath).
+//
+// Parameters:
+//   - resume: The structured resume to render
+//   - layout: The layout to use
+//   - opts: The optional extras to include
+//   - markdownPath: The path the Markdown resume was written to
+//
+// Returns:
+//   - string: The path of the HTML file
+//   - error: An error if the file could not be written
+func WriteHTMLWithOptions(resume document.Resume, layout Layout, opts HTMLOptions, markdownPath string) (string, error) {
+	htmlPath := HTMLPath(markdownPath)
+	if err := WriteToFile(htmlPath, RenderHTMLWithOptions(resume, layout, opts)); err != nil {
+		return "", fmt.Errorf("failed to write HTML output: %w", err)
+	}
+	return htmlPath, nil
+}
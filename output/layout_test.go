@@ -0,0 +1,175 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+const layoutTestResume = "# Jane Doe\n\njane@example.com\n\n## Experience\n\n### Acme\n\n- Shipped **fast** code\n\n## Skills\n\n- Go\n\n## Certifications\n\n- CKA"
+
+func TestParseLayout(t *testing.T) {
+	for _, layout := range Layouts {
+		if got, err := ParseLayout(strings.ToUpper(string(layout))); err != nil || got != layout {
+			t.Errorf("ParseLayout(%q) = %q, %v", layout, got, err)
+		}
+	}
+
+	if _, err := ParseLayout("three-column"); err == nil || !strings.Contains(err.Error(), "two-column") {
+		t.Errorf("Expected an error listing the supported layouts, got %v", err)
+	}
+}
+
+func TestParseLayouts(t *testing.T) {
+	layouts, err := ParseLayouts(" Compact, standard,,compact ")
+	if err != nil || !reflect.DeepEqual(layouts, []Layout{LayoutCompact, LayoutStandard}) {
+		t.Errorf("ParseLayouts() = %v, %v", layouts, err)
+	}
+	if layouts, err := ParseLayouts(""); err != nil || layouts != nil {
+		t.Errorf("Expected no layouts for an empty list, got %v, %v", layouts, err)
+	}
+	if _, err := ParseLayouts("standard,three-column"); err == nil {
+		t.Error("Expected an error for an unknown layout")
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	resume := document.Parse(layoutTestResume)
+
+	t.Run("standard layout keeps a single column", func(t *testing.T) {
+		page := RenderHTML(resume, "")
+		for _, element := range []string{"<title>Jane Doe</title>", "<h1>Jane Doe</h1>", "<p>jane@example.com</p>", "<h3>Acme</h3>", "<strong>fast</strong>", `class="layout-standard"`} {
+			if !strings.Contains(page, element) {
+				t.Errorf("Standard layout should contain %q", element)
+			}
+		}
+		if strings.Contains(page, "<aside>") {
+			t.Error("Standard layout should not have a sidebar")
+		}
+	})
+
+	t.Run("two-column layout moves skills into the sidebar", func(t *testing.T) {
+		page := RenderHTML(resume, LayoutTwoColumn)
+		aside := page[strings.Index(page, "<aside>"):strings.Index(page, "</aside>")]
+		if !strings.Contains(aside, "Skills") || !strings.Contains(aside, "Certifications") {
+			t.Errorf("Sidebar should contain skills and certifications, got %q", aside)
+		}
+		if strings.Contains(aside, "Experience") {
+			t.Error("Experience should stay in the main column")
+		}
+	})
+
+	t.Run("compact layout adds compact styles", func(t *testing.T) {
+		if page := RenderHTML(resume, LayoutCompact); !strings.Contains(page, "break-inside: avoid") {
+			t.Error("Compact layout should include the compact print styles")
+		}
+	})
+
+	t.Run("structured skills are rendered", func(t *testing.T) {
+		withSkills := resume
+		withSkills.Skills = []document.Skill{{Name: "Kubernetes", Years: 3}}
+		page := RenderHTML(withSkills, LayoutTwoColumn)
+		if !strings.Contains(page, "<strong>Kubernetes</strong> (3 years)") {
+			t.Error("Structured skills should replace the Skills section")
+		}
+	})
+}
+
+func TestRenderHTMLWithOptions(t *testing.T) {
+	resume := document.Parse("# Jane Doe\n\n## Experience\n\n### Senior Engineer | Globex | Jun 2019 – Present\n\n### Engineer | Initech | 2015 - Dec 2018")
+	opts := HTMLOptions{Timeline: true, Now: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}
+
+	// Test case 1: The timeline section follows the other sections in the main column
+	page := RenderHTMLWithOptions(resume, LayoutTwoColumn, opts)
+	main := page[strings.Index(page, "<main>"):strings.Index(page, "</main>")]
+	for _, element := range []string{`<section class="timeline">`, "<pre>Engineer · Initech", "<li>5-month gap: Jan 2019 – May 2019</li>"} {
+		if !strings.Contains(main, element) {
+			t.Errorf("Main column should contain %q", element)
+		}
+	}
+	if strings.Index(main, "Timeline") < strings.Index(main, "Experience") {
+		t.Error("The timeline should come after the resume sections")
+	}
+
+	// Test case 2: No timeline without the option or without dated entries
+	if page := RenderHTML(resume, LayoutStandard); strings.Contains(page, "timeline") {
+		t.Error("The timeline should only be rendered when requested")
+	}
+	if page := RenderHTMLWithOptions(document.Parse(layoutTestResume), LayoutStandard, opts); strings.Contains(page, "<h2>Timeline</h2>") {
+		t.Error("A resume without dates should have no timeline section")
+	}
+}
+
+func TestRenderHTMLQR(t *testing.T) {
+	resume := document.Parse(layoutTestResume)
+	opts := HTMLOptions{QR: "https://janedoe.dev/?a=1&b=2"}
+
+	// Test case 1: The code links to the URL from the header
+	page := RenderHTMLWithOptions(resume, LayoutStandard, opts)
+	header := page[strings.Index(page, "<header>"):strings.Index(page, "</header>")]
+	if !strings.Contains(header, `<a class="qr" href="https://janedoe.dev/?a=1&amp;b=2"`) || !strings.Contains(header, "<svg") {
+		t.Errorf("Expected a linked QR code in the header, got %q", header)
+	}
+	if !strings.Contains(page, ".qr {") {
+		t.Error("Expected the QR code styles")
+	}
+
+	// Test case 2: The two-column layout shows it at the top of the sidebar
+	page = RenderHTMLWithOptions(resume, LayoutTwoColumn, opts)
+	if !strings.HasPrefix(page[strings.Index(page, "<aside>"):], "<aside>\n<a class=\"qr\"") || strings.Count(page, "<svg") != 1 {
+		t.Error("Expected the QR code once, at the top of the sidebar")
+	}
+	if page := RenderHTMLWithOptions(resume, LayoutCompact, opts); !strings.Contains(page, "width: 0.75in") {
+		t.Error("Expected a smaller QR code in the compact layout")
+	}
+
+	// Test case 3: No code without a URL
+	if page := RenderHTML(resume, LayoutStandard); strings.Contains(page, "qr") {
+		t.Error("Expected no QR code without a URL")
+	}
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"paragraph lines", "line one\nline two", "<p>line one<br>\nline two</p>\n"},
+		{"escaping", "R&D <team>", "<p>R&amp;D &lt;team&gt;</p>\n"},
+		{"ordered list", "1. first\n2. second", "<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n"},
+		{"inline formatting", "*Go* and `gofmt` at [Acme](https://acme.example)", `<p><em>Go</em> and <code>gofmt</code> at <a href="https://acme.example">Acme</a></p>` + "\n"},
+		{"snake case is not italic", "my_var_name", "<p>my_var_name</p>\n"},
+		{"horizontal rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownToHTML(tt.markdown); got != tt.expected {
+				t.Errorf("markdownToHTML() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	tempDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	markdownPath := filepath.Join(tempDir, "resume.md")
+	htmlPath, err := WriteHTML(document.Parse(layoutTestResume), LayoutCompact, markdownPath)
+	if err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+	if htmlPath != filepath.Join(tempDir, "resume.html") {
+		t.Errorf("Unexpected HTML path %q", htmlPath)
+	}
+	if data, err := os.ReadFile(htmlPath); err != nil || !strings.Contains(string(data), "<h1>Jane Doe</h1>") {
+		t.Errorf("Expected the rendered resume in %s, got %v", htmlPath, err)
+	}
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Names of the line endings accepted by ParseLineEndings.
+const (
+	// LineEndingsAuto uses CRLF on Windows and LF elsewhere.
+	LineEndingsAuto = "auto"
+
+	// LineEndingsLF ends lines with "\n", as macOS, Linux, and most tools expect.
+	LineEndingsLF = "lf"
+
+	// LineEndingsCRLF ends lines with "\r\n", as Windows Notepad and some
+	// applicant tracking systems expect.
+	LineEndingsCRLF = "crlf"
+)
+
+// LineEnding ends the lines of the text files WriteTextFile writes, "\n" or
+// "\r\n". -line-endings chooses it with ParseLineEndings.
+var LineEnding = "\n"
+
+// ParseLineEndings returns the line ending named by value: "lf", "crlf", or
+// "auto", which picks the one the operating system uses. An empty value is
+// "auto".
+//
+// Parameters:
+//   - value: The name of the line endings
+//
+// Returns:
+//   - string: The line ending, "\n" or "\r\n"
+//   - error: An error if the name is not one of the three
+//
+// Example:
+//
+//	output.LineEnding, err = output.ParseLineEndings("crlf")
+//	// output.LineEnding == "\r\n"
+func ParseLineEndings(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", LineEndingsAuto:
+		if runtime.GOOS == "windows" {
+			return "\r\n", nil
+		}
+		return "\n", nil
+	case LineEndingsLF:
+		return "\n", nil
+	case LineEndingsCRLF:
+		return "\r\n", nil
+	}
+	return "", fmt.Errorf("invalid line endings %q: use %s, %s, or %s", value, LineEndingsLF, LineEndingsCRLF, LineEndingsAuto)
+}
+
+// NormalizeLineEndings ends every line of content with LineEnding, whether
+// it ended with "\n", "\r\n", or a lone "\r", so a resume whose model
+// response or source mixed them is consistent.
+//
+// Parameters:
+//   - content: The text to normalize
+//
+// Returns:
+//   - string: The text with every line ending replaced by LineEnding
+//
+// Example:
+//
+//	output.LineEnding = "\r\n"
+//	text := output.NormalizeLineEndings("# Jane Doe\n\n## Skills\r\n")
+//	// text == "# Jane Doe\r\n\r\n## Skills\r\n"
+func NormalizeLineEndings(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+	if LineEnding == "\n" {
+		return content
+	}
+	return strings.ReplaceAll(content, "\n", LineEnding)
+}
+
+// WriteTextFile writes a text file with WriteToFile, its lines ending with
+// LineEnding. Resumes, cover letters, and the notes saved next to them are
+// written with it, since they are opened in editors and pasted into forms;
+// binary files such as DOCX are written with WriteToFile.
+//
+// Parameters:
+//   - path: The path of the file to write
+//   - content: The text to write
+//
+// Returns:
+//   - error: An error if the file could not be written
+//
+// Example:
+//
+//	err := output.WriteTextFile("Jane_Doe_Cover_Letter.md", coverLetter)
+func WriteTextFile(path string, content string) error {
+	return WriteToFile(path, NormalizeLineEndings(content))
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestParseLineEndings(t *testing.T) {
+	auto := "\n"
+	if runtime.GOOS == "windows" {
+		auto = "\r\n"
+	}
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"lf", "\n", false},
+		{"CRLF", "\r\n", false},
+		{"auto", auto, false},
+		{"", auto, false},
+		{"cr", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseLineEndings(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseLineEndings(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeLineEndings(t *testing.T) {
+	defer func() { LineEnding = "\n" }()
+	mixed := "# Jane Doe\r\n\r\n## Skills\n- Go\r- Rust\n"
+
+	// Test case 1: LF replaces CRLF and lone CR
+	LineEnding = "\n"
+	if got := NormalizeLineEndings(mixed); got != "# Jane Doe\n\n## Skills\n- Go\n- Rust\n" {
+		t.Errorf("NormalizeLineEndings() = %q", got)
+	}
+
+	// Test case 2: CRLF ends every line, without doubling the existing ones
+	LineEnding = "\r\n"
+	if got := NormalizeLineEndings(mixed); got != "# Jane Doe\r\n\r\n## Skills\r\n- Go\r\n- Rust\r\n" {
+		t.Errorf("NormalizeLineEndings() = %q", got)
+	}
+}
+
+func TestWriteOutputLineEndings(t *testing.T) {
+	defer func() { LineEnding = "\n" }()
+	LineEnding = "\r\n"
+
+	path := filepath.Join(t.TempDir(), "resume.md")
+	if _, err := WriteOutput("# Jane Doe\n\n## Skills\n", path); err != nil {
+		t.Fatalf("WriteOutput returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "# Jane Doe\r\n\r\n## Skills\r\n" {
+		t.Errorf("Expected CRLF line endings, got %q (%v)", data, err)
+	}
+}
@@ -103,10 +103,14 @@ func ValidateMarkdown(content string) error {
 //
 // Cleaning operations include:
 // - Normalizing line endings to Unix-style (\n)
-// - Trimming leading and trailing whitespace
-// - Ensuring proper spacing around headers and list items
-// - Removing excessive blank lines
-// - Ensuring consistent spacing patterns
+// - Trimming leading and trailing whitespace, and trailing whitespace on lines
+// - Separating headers from the text around them with a blank line
+// - Separating lists from the paragraphs before and after them with a blank line
+// - Separating fenced code blocks from the text around them with a blank line
+// - Collapsing runs of blank lines into one
+//
+// The lines inside fenced code blocks are kept as they are, and the items of a
+// list are not separated from each other.
 //
 // Parameters:
 //   - content: The raw Markdown content to clean
@@ -116,65 +120,144 @@ func ValidateMarkdown(content string) error {
 //
 // Example:
 //
-//	cleanContent := output.CleanMarkdown(rawMarkdown)
+//	cleanContent := output.CleanMarkdown("# Resume\n## Skills\n- Go\n- Python")
+//	// cleanContent == "# Resume\n\n## Skills\n\n- Go\n- Python"
 func CleanMarkdown(content string) string {
 	// Normalize line endings
 	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
 	
 	// Trim leading and trailing whitespace
 	content = strings.TrimSpace(content)
 	
-	// Handle specific test cases to ensure they pass
-	content = adjustSpecificTestCases(content)
+	// Space the blocks of the document consistently
+	return normalizeMarkdown(content)
+}
+
+// markdownLine is the kind of block a line of Markdown belongs to, which
+// decides the spacing around it.
+type markdownLine int
+
+const (
+	lineBlank        markdownLine = iota // An empty line
+	lineText                             // A line of a paragraph, table, or anything else
+	lineHeader                           // An ATX header, such as "## Skills"
+	lineListItem                         // The first line of a list item
+	lineContinuation                     // An indented line continuing a list item
+	lineRule                             // A horizontal rule
+	lineFence                            // The line opening or closing a fenced code block
+)
+
+// Regular expressions for the kinds of Markdown line
+var (
+	// Match an ATX header, with up to three spaces of indentation
+	headerLineRegex = regexp.MustCompile(`^ {0,3}#{1,6}(?:[ \t]|$)`)
+	
+	// Match the first line of a bulleted or numbered list item
+	listItemLineStartRegex = regexp.MustCompile(`^[ \t]*(?:[-*+]|\d{1,9}[.)])[ \t]+\S`)
 	
-	// General cleaning for non-test cases
-	content = formatMarkdown(content)
+	// Match a horizontal rule of three or more -, *, or _ characters
+	ruleLineRegex = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
 	
-	return content
-}
+	// Match the line opening a fenced code block, capturing its fence
+	codeFenceRegex = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+)
 
-// adjustSpecificTestCases handles known test cases to ensure they match expected output
-func adjustSpecificTestCases(content string) string {
-	// Special case for "inconsistent newlines" test
-	if strings.Contains(content, "# Resume") && 
-	   strings.Contains(content, "## Skills") && 
-	   strings.Contains(content, "- Go") && 
-	   strings.Contains(content, "- Python") &&
-	   !strings.Contains(content, "\n\n") {
-		return "# Resume\n\n## Skills\n\n- Go\n- Python"
-	}
+// normalizeMarkdown spaces the blocks of a Markdown document line by line:
+// runs of blank lines become one, and a blank line is added where two
+// blocks touch that should be apart (see needsBlankLine). Trailing
+// whitespace is removed outside fenced code blocks, whose lines are kept
+// as they are.
+func normalizeMarkdown(content string) string {
+	var lines []string
+	previous := lineBlank
+	fence := ""
 	
-	// Special case for "extra whitespace" test
-	if strings.Contains(content, "# Resume\n\n\n\n## Skills") {
-		return "# Resume\n\n## Skills\n\n- Go\n- Python"
+	for _, line := range strings.Split(content, "\n") {
+		// Code is kept verbatim up to the fence that closes it
+		if fence != "" {
+			lines = append(lines, line)
+			if closesFence(line, fence) {
+				fence, previous = "", lineFence
+			}
+			continue
+		}
+		
+		line = strings.TrimRight(line, " \t")
+		kind := classifyLine(line, previous)
+		if kind == lineBlank {
+			if previous != lineBlank {
+				lines = append(lines, "")
+			}
+			previous = lineBlank
+			continue
+		}
+		
+		if needsBlankLine(previous, kind) {
+			lines = append(lines, "")
+		}
+		switch kind {
+		case lineHeader:
+			line = strings.TrimLeft(line, " ")
+		case lineFence:
+			fence = codeFenceRegex.FindStringSubmatch(line)[1]
+		}
+		lines = append(lines, line)
+		previous = kind
 	}
 	
-	// Special case for "leading and trailing whitespace" test
-	if strings.HasPrefix(content, "  \n  # Resume") || strings.HasSuffix(content, "\n  ") {
-		return "# Resume\n\n## Skills\n\n- Go\n- Python"
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// classifyLine returns the kind of a line outside a code block. An indented
+// line right after a list item, or after another such line, continues the
+// item rather than starting a paragraph.
+func classifyLine(line string, previous markdownLine) markdownLine {
+	switch {
+	case strings.TrimSpace(line) == "":
+		return lineBlank
+	case codeFenceRegex.MatchString(line):
+		return lineFence
+	case headerLineRegex.MatchString(line):
+		return lineHeader
+	case ruleLineRegex.MatchString(line):
+		return lineRule
+	case listItemLineStartRegex.MatchString(line):
+		return lineListItem
+	case (previous == lineListItem || previous == lineContinuation) && (line[0] == ' ' || line[0] == '\t'):
+		return lineContinuation
 	}
-	
-	return content
+	return lineText
 }
 
-// formatMarkdown applies general markdown formatting rules
-func formatMarkdown(content string) string {
-	// Replace multiple consecutive blank lines with a single blank line
-	content = regexp.MustCompile(`\n{3,}`).ReplaceAllString(content, "\n\n")
-	
-	// Ensure headers have a blank line before them (except at the start of the document)
-	content = regexp.MustCompile(`(?m)([^\n])\n(#{1,6}\s+)`).ReplaceAllString(content, "$1\n\n$2")
-	
-	// Ensure headers have a blank line after them
-	content = regexp.MustCompile(`(?m)(^#{1,6}\s+.+)\n([^\n#])`).ReplaceAllString(content, "$1\n\n$2")
-	
-	// Ensure list items have proper spacing
-	content = regexp.MustCompile(`(?m)(^[-*+]\s+.+)\n([^-*+\n])`).ReplaceAllString(content, "$1\n\n$2")
-	
-	// Remove trailing whitespace on lines
-	content = regexp.MustCompile(`(?m)[ \t]+$`).ReplaceAllString(content, "")
-	
-	return content
+// needsBlankLine reports whether a blank line belongs between a line of the
+// previous kind and the next: around headers and fenced code blocks, and
+// between a list and a paragraph on either side of it. The items of a list,
+// the lines of a paragraph, and a rule after text, which makes a setext
+// header, are left together.
+func needsBlankLine(previous, next markdownLine) bool {
+	switch {
+	case previous == lineBlank:
+		return false
+	case previous == lineHeader || next == lineHeader:
+		return true
+	case previous == lineFence || next == lineFence:
+		return true
+	case previous == lineText && next == lineListItem:
+		return true
+	case (previous == lineListItem || previous == lineContinuation) && next == lineText:
+		return true
+	}
+	return false
+}
+
+// closesFence reports whether line closes a code block opened with fence:
+// the same character, at least as many times, and nothing else.
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return len(line)-len(strings.TrimLeft(line, " ")) <= 3 &&
+		strings.HasPrefix(trimmed, fence) &&
+		strings.Trim(trimmed, fence[:1]) == ""
 }
 
 // PrepareForOutput validates and cleans Markdown content for output.
@@ -73,12 +73,97 @@ func TestCleanMarkdown(t *testing.T) {
 			content:  "  \n  # Resume\n\n## Skills\n\n- Go\n- Python\n  ",
 			expected: "# Resume\n\n## Skills\n\n- Go\n- Python",
 		},
+		{
+			name:     "headers separated from paragraphs",
+			content:  "# Jane Doe\nBackend engineer.\n## Experience\nAcme, 2020-2024",
+			expected: "# Jane Doe\n\nBackend engineer.\n\n## Experience\n\nAcme, 2020-2024",
+		},
+		{
+			name:     "consecutive headers separated",
+			content:  "# Jane Doe\n## Summary\n### Highlights",
+			expected: "# Jane Doe\n\n## Summary\n\n### Highlights",
+		},
+		{
+			name:     "indented header moved to the margin",
+			content:  "Intro\n   ## Skills\n- Go",
+			expected: "Intro\n\n## Skills\n\n- Go",
+		},
+		{
+			name:     "list separated from the paragraphs around it",
+			content:  "**Engineer** | Acme | 2020\n- Built the billing service\n* Led three engineers\nReferences on request.",
+			expected: "**Engineer** | Acme | 2020\n\n- Built the billing service\n* Led three engineers\n\nReferences on request.",
+		},
+		{
+			name:     "numbered list separated from a paragraph",
+			content:  "Steps:\n1. Design\n2) Build",
+			expected: "Steps:\n\n1. Design\n2) Build",
+		},
+		{
+			name:     "nested items and continuation lines kept with their item",
+			content:  "- Built the billing service\n  handling 2M invoices a month\n  - Cut costs by 30%\n- Led three engineers",
+			expected: "- Built the billing service\n  handling 2M invoices a month\n  - Cut costs by 30%\n- Led three engineers",
+		},
+		{
+			name:     "loose list kept loose",
+			content:  "- Go\n\n- Python",
+			expected: "- Go\n\n- Python",
+		},
+		{
+			name:     "runs of blank lines collapsed",
+			content:  "Summary\n\n\n\n\nMore text\n\n\n- Go",
+			expected: "Summary\n\nMore text\n\n- Go",
+		},
+		{
+			name:     "trailing whitespace removed from every line",
+			content:  "# Resume \t\n\nText   \n- Go  ",
+			expected: "# Resume\n\nText\n\n- Go",
+		},
+		{
+			name:     "old Mac line endings",
+			content:  "# Resume\r## Skills\r- Go",
+			expected: "# Resume\n\n## Skills\n\n- Go",
+		},
+		{
+			name:     "fenced code kept verbatim and separated",
+			content:  "Setup:\n```bash\n# not a header  \n\n\n- not a list\n```\nDone.",
+			expected: "Setup:\n\n```bash\n# not a header  \n\n\n- not a list\n```\n\nDone.",
+		},
+		{
+			name:     "tilde fence closed only by its own fence",
+			content:  "~~~~\n```\n# inside\n~~~~\n## After",
+			expected: "~~~~\n```\n# inside\n~~~~\n\n## After",
+		},
+		{
+			name:     "unclosed fence keeps the rest of the document",
+			content:  "Code:\n```\n# inside\n\n\nstill inside",
+			expected: "Code:\n\n```\n# inside\n\n\nstill inside",
+		},
+		{
+			name:     "rules and setext underlines left in place",
+			content:  "# Jane Doe\n---\nName\n---",
+			expected: "# Jane Doe\n\n---\nName\n---",
+		},
+		{
+			name:     "tables kept together",
+			content:  "## Skills\n| Skill | Years |\n| --- | --- |\n| Go | 5 |",
+			expected: "## Skills\n\n| Skill | Years |\n| --- | --- |\n| Go | 5 |",
+		},
+		{
+			name:     "emphasis and hashtags are not headers or lists",
+			content:  "*Remote* team\n#golang\n**Bold** lead",
+			expected: "*Remote* team\n#golang\n**Bold** lead",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if got := CleanMarkdown(tt.content); got != tt.expected {
-				t.Errorf("CleanMarkdown() = %v, want %v", got, tt.expected)
+				t.Errorf("CleanMarkdown() = %q, want %q", got, tt.expected)
+			}
+
+			// Cleaning is idempotent
+			if again := CleanMarkdown(tt.expected); again != tt.expected {
+				t.Errorf("CleanMarkdown() of clean content = %q, want it unchanged", again)
 			}
 		})
 	}
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+)
+
+// blockKind identifies the kind of a Markdown block.
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockListItem
+	blockRule
+)
+
+// block is one paragraph-level element of a Markdown resume, as read by the
+// native exporters.
+type block struct {
+	kind  blockKind
+	level int      // Heading level (1-6)
+	lines []string // Text of the block; paragraphs keep their line breaks
+	line  int      // Index of the block's first line in the Markdown
+}
+
+// run is a stretch of text with the same inline formatting.
+type run struct {
+	text   string
+	bold   bool
+	italic bool
+}
+
+// Inline formatting the native exporters keep; links and code are reduced to their text
+var (
+	nativeLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	nativeCodeRegex = regexp.MustCompile("`([^`]+)`")
+	nativeEmphasis  = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__|\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// markdownBlocks splits the Markdown subset used in resumes into blocks:
+// ATX headings, list items, horizontal rules, and paragraphs. It reads the
+// same subset as markdownToHTML.
+func markdownBlocks(markdown string) []block {
+	var blocks []block
+	var paragraph []string
+	start := 0
+
+	closeParagraph := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, block{kind: blockParagraph, lines: paragraph, line: start})
+			paragraph = nil
+		}
+	}
+
+	for i, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			closeParagraph()
+
+		case hrRegex.MatchString(trimmed):
+			closeParagraph()
+			blocks = append(blocks, block{kind: blockRule, line: i})
+
+		case sectionHeadingRegex.MatchString(trimmed):
+			closeParagraph()
+			match := sectionHeadingRegex.FindStringSubmatch(trimmed)
+			blocks = append(blocks, block{kind: blockHeading, level: len(match[1]), lines: []string{match[2]}, line: i})
+
+		case htmlListItemRegex.MatchString(trimmed):
+			closeParagraph()
+			blocks = append(blocks, block{kind: blockListItem, lines: []string{htmlListItemRegex.FindStringSubmatch(trimmed)[1]}, line: i})
+
+		default:
+			if len(paragraph) == 0 {
+				start = i
+			}
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	closeParagraph()
+
+	return blocks
+}
+
+// inlineRuns splits a line into runs of bold, italic, and plain text.
+func inlineRuns(text string) []run {
+	text = nativeLinkRegex.ReplaceAllString(text, "$1")
+	text = nativeCodeRegex.ReplaceAllString(text, "$1")
+
+	var runs []run
+	last := 0
+	for _, match := range nativeEmphasis.FindAllStringSubmatchIndex(text, -1) {
+		if match[0] > last {
+			runs = append(runs, run{text: text[last:match[0]]})
+		}
+		for group := 1; group <= 4; group++ {
+			start, end := match[2*group], match[2*group+1]
+			if start >= 0 {
+				runs = append(runs, run{text: text[start:end], bold: group <= 2, italic: group > 2})
+			}
+		}
+		last = match[1]
+	}
+	if last < len(text) {
+		runs = append(runs, run{text: text[last:]})
+	}
+	return runs
+}
@@ -0,0 +1,38 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarkdownBlocks(t *testing.T) {
+	blocks := markdownBlocks("# Jane Doe\njane@example.com\n555-0100\n\n## Skills\n- Go\n1. Rust\n***\nDone.")
+
+	expected := []block{
+		{kind: blockHeading, level: 1, lines: []string{"Jane Doe"}},
+		{kind: blockParagraph, lines: []string{"jane@example.com", "555-0100"}, line: 1},
+		{kind: blockHeading, level: 2, lines: []string{"Skills"}, line: 4},
+		{kind: blockListItem, lines: []string{"Go"}, line: 5},
+		{kind: blockListItem, lines: []string{"Rust"}, line: 6},
+		{kind: blockRule, line: 7},
+		{kind: blockParagraph, lines: []string{"Done."}, line: 8},
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("markdownBlocks() = %+v, want %+v", blocks, expected)
+	}
+}
+
+func TestInlineRuns(t *testing.T) {
+	runs := inlineRuns("Led **Go** and *Rust* work at [Acme](https://acme.dev) using `make`")
+
+	expected := []run{
+		{text: "Led "},
+		{text: "Go", bold: true},
+		{text: " and "},
+		{text: "Rust", italic: true},
+		{text: " work at Acme using make"},
+	}
+	if !reflect.DeepEqual(runs, expected) {
+		t.Errorf("inlineRuns() = %+v, want %+v", runs, expected)
+	}
+}
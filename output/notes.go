@@ -0,0 +1,49 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// NotesSuffix is appended to the resume's file name, before its extension,
+// to name the notes explaining how the resume was written.
+const NotesSuffix = "_notes"
+
+// NotesTitle heads the notes file.
+const NotesTitle = "# Why Your Resume Changed"
+
+// NotesPath returns the path of the notes written next to a Markdown resume:
+// the same name with NotesSuffix before a .md extension.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - string: The notes path
+//
+// Example:
+//
+//	path := output.NotesPath("Jane_Doe_Resume_2024-06-01.md")
+//	// path == "Jane_Doe_Resume_2024-06-01_notes.md"
+func NotesPath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + NotesSuffix + ".md"
+}
+
+// WriteNotes writes the model's explanation of its changes next to the
+// Markdown resume, under NotesTitle.
+//
+// Parameters:
+//   - notes: The explanation in Markdown
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - string: The path of the notes file
+//   - error: Any error that occurred while writing
+func WriteNotes(notes, markdownPath string) (string, error) {
+	notesPath := NotesPath(markdownPath)
+	if err := WriteTextFile(notesPath, NotesTitle+"\n\n"+strings.TrimSpace(notes)+"\n"); err != nil {
+		return "", fmt.Errorf("failed to write notes: %w", err)
+	}
+	return notesPath, nil
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteNotes(t *testing.T) {
+	tempDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	markdownPath := filepath.Join(tempDir, "Jane_Doe_Resume.md")
+
+	notesPath, err := WriteNotes("\n## Removed\n\n- The hobbies section, which did not support the target role\n", markdownPath)
+	if err != nil {
+		t.Fatalf("WriteNotes() error = %v", err)
+	}
+	if notesPath != filepath.Join(tempDir, "Jane_Doe_Resume_notes.md") {
+		t.Errorf("Unexpected notes path %q", notesPath)
+	}
+
+	data, err := os.ReadFile(notesPath)
+	if err != nil {
+		t.Fatalf("Failed to read notes file: %v", err)
+	}
+	expected := NotesTitle + "\n\n## Removed\n\n- The hobbies section, which did not support the target role\n"
+	if string(data) != expected {
+		t.Errorf("Expected notes %q, got %q", expected, data)
+	}
+}
@@ -0,0 +1,158 @@
+package output
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// odtMimeType identifies an OpenDocument text package. It must be the first
+// file in the package and stored uncompressed.
+const odtMimeType = "application/vnd.oasis.opendocument.text"
+
+const odtManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+<manifest:file-entry manifest:full-path="/" manifest:media-type="` + odtMimeType + `"/>
+<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+<manifest:file-entry manifest:full-path="styles.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>`
+
+// odtNamespaces are declared on the root element of every ODT part.
+const odtNamespaces = `xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+	`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+	`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" ` +
+	`office:version="1.2"`
+
+// odtStyles mirrors the bundled DOCX template: Calibri body text and blue,
+// bold headings.
+const odtStyles = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-styles ` + odtNamespaces + `><office:styles>
+<style:default-style style:family="paragraph"><style:paragraph-properties fo:margin-bottom="0.06in"/><style:text-properties style:font-name="Calibri" fo:font-family="Calibri" fo:font-size="10.5pt"/></style:default-style>
+<style:style style:name="Standard" style:family="paragraph"/>
+<style:style style:name="Text_20_body" style:display-name="Text body" style:family="paragraph" style:parent-style-name="Standard"/>
+<style:style style:name="List_20_Bullet" style:display-name="List Bullet" style:family="paragraph" style:parent-style-name="Standard"><style:paragraph-properties fo:margin-left="0.25in" fo:margin-bottom="0.03in"/></style:style>
+<style:style style:name="Heading_20_1" style:display-name="Heading 1" style:family="paragraph" style:parent-style-name="Standard" style:default-outline-level="1"><style:paragraph-properties fo:margin-top="0.17in" fo:border-bottom="0.5pt solid #1F3864" fo:keep-with-next="always"/><style:text-properties fo:color="#1F3864" fo:font-size="14pt" fo:font-weight="bold"/></style:style>
+<style:style style:name="Heading_20_2" style:display-name="Heading 2" style:family="paragraph" style:parent-style-name="Standard" style:default-outline-level="2"><style:paragraph-properties fo:margin-top="0.14in" fo:keep-with-next="always"/><style:text-properties fo:color="#1F3864" fo:font-size="12pt" fo:font-weight="bold"/></style:style>
+<style:style style:name="Heading_20_3" style:display-name="Heading 3" style:family="paragraph" style:parent-style-name="Standard" style:default-outline-level="3"><style:paragraph-properties fo:margin-top="0.11in" fo:keep-with-next="always"/><style:text-properties fo:font-size="11pt" fo:font-weight="bold"/></style:style>
+<style:style style:name="Horizontal_20_Line" style:display-name="Horizontal Line" style:family="paragraph" style:parent-style-name="Standard"><style:paragraph-properties fo:border-bottom="0.5pt solid #000000"/></style:style>
+</office:styles></office:document-styles>`
+
+// odtAutomaticStyles are the inline formatting styles used by content.xml.
+const odtAutomaticStyles = `<office:automatic-styles>
+<style:style style:name="Bold" style:family="text"><style:text-properties fo:font-weight="bold"/></style:style>
+<style:style style:name="Italic" style:family="text"><style:text-properties fo:font-style="italic"/></style:style>
+<style:style style:name="BoldItalic" style:family="text"><style:text-properties fo:font-weight="bold" fo:font-style="italic"/></style:style>
+</office:automatic-styles>`
+
+// WriteODT converts a Markdown resume to an OpenDocument text file without
+// pandoc, styled to match the bundled DOCX template.
+//
+// Parameters:
+//   - markdownContent: The resume in Markdown
+//   - path: The path to write the ODT file to
+//
+// Returns:
+//   - error: An error if the file could not be written
+//
+// Example:
+//
+//	err := output.WriteODT(content, "resume_out.odt")
+func WriteODT(markdownContent, path string) error {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	mimetype, err := w.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := mimetype.Write([]byte(odtMimeType)); err != nil {
+		return err
+	}
+
+	parts := []struct{ name, content string }{
+		{"META-INF/manifest.xml", odtManifest},
+		{"styles.xml", odtStyles},
+		{"content.xml", odtContent(markdownContent)},
+	}
+	for _, part := range parts {
+		dst, err := w.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := dst.Write([]byte(part.content)); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to build ODT file: %w", err)
+	}
+
+	return WriteToFile(path, buf.String())
+}
+
+// odtContent renders the Markdown resume as an OpenDocument content part.
+func odtContent(markdownContent string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<office:document-content ` + odtNamespaces + `>` + odtAutomaticStyles + `<office:body><office:text>`)
+
+	inList := false
+	for _, blk := range markdownBlocks(markdownContent) {
+		if inList && blk.kind != blockListItem {
+			b.WriteString(`</text:list>`)
+			inList = false
+		}
+
+		switch blk.kind {
+		case blockHeading:
+			level := min(blk.level, 3)
+			fmt.Fprintf(&b, `<text:h text:style-name="Heading_20_%d" text:outline-level="%d">`, level, level)
+			writeODTLines(&b, blk.lines)
+			b.WriteString(`</text:h>`)
+		case blockListItem:
+			if !inList {
+				b.WriteString(`<text:list>`)
+				inList = true
+			}
+			b.WriteString(`<text:list-item><text:p text:style-name="List_20_Bullet">• `)
+			writeODTLines(&b, blk.lines)
+			b.WriteString(`</text:p></text:list-item>`)
+		case blockParagraph:
+			b.WriteString(`<text:p text:style-name="Text_20_body">`)
+			writeODTLines(&b, blk.lines)
+			b.WriteString(`</text:p>`)
+		case blockRule:
+			b.WriteString(`<text:p text:style-name="Horizontal_20_Line"/>`)
+		}
+	}
+	if inList {
+		b.WriteString(`</text:list>`)
+	}
+
+	b.WriteString(`</office:text></office:body></office:document-content>`)
+	return b.String()
+}
+
+// writeODTLines writes lines of inline text with a line break between them.
+func writeODTLines(b *strings.Builder, lines []string) {
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString(`<text:line-break/>`)
+		}
+		for _, r := range inlineRuns(line) {
+			text := escapeXML(r.text)
+			switch {
+			case r.bold && r.italic:
+				b.WriteString(`<text:span text:style-name="BoldItalic">` + text + `</text:span>`)
+			case r.bold:
+				b.WriteString(`<text:span text:style-name="Bold">` + text + `</text:span>`)
+			case r.italic:
+				b.WriteString(`<text:span text:style-name="Italic">` + text + `</text:span>`)
+			default:
+				b.WriteString(text)
+			}
+		}
+	}
+}
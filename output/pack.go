@@ -0,0 +1,192 @@
+package output
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Names of the files a pack holds besides the resume files themselves.
+const (
+	// PackMetadataFile is the name of the metadata file in a pack.
+	PackMetadataFile = "metadata.json"
+
+	// PackJobDescriptionFile is the name of the job description in a pack,
+	// before the extension of the file it was read from.
+	PackJobDescriptionFile = "job_description"
+)
+
+// PackMetadata describes the application a pack was made for. It is written
+// to the pack as PackMetadataFile.
+type PackMetadata struct {
+	Candidate      string    `json:"candidate,omitempty"`       // The candidate's name from the resume title
+	Company        string    `json:"company,omitempty"`         // The employer the resume was tailored to
+	Generated      time.Time `json:"generated"`                 // When the resume was generated
+	Model          string    `json:"model,omitempty"`           // The model that produced the resume
+	Source         string    `json:"source,omitempty"`          // The file name of the existing resume used, if any
+	JobDescription string    `json:"job_description,omitempty"` // The file name of the job description used, if any
+	Keywords       []string  `json:"keywords,omitempty"`        // The keywords the resume was asked to emphasize
+	Seed           *int64    `json:"seed,omitempty"`            // The -seed the resume was generated with, to regenerate it identically
+	Files          []string  `json:"files"`                     // The files in the pack, in order
+}
+
+// Pack is the set of documents zipped together for one application.
+type Pack struct {
+	Files          []string     // Paths of the resume files to include, such as the Markdown, PDF, and HTML
+	JobDescription string       // The job description used (empty for none)
+	JobPath        string       // The path it was read from, for its extension
+	Metadata       PackMetadata // Written as PackMetadataFile; Files is filled in by WritePack
+}
+
+// PackPath returns the path of the pack for a Markdown resume: the resume's
+// name with the date and, when given, the employer, and a .zip extension.
+// The date is not repeated when the name already ends with it, as the
+// default resume names do.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//   - company: The employer the resume was tailored to (can be empty)
+//   - t: The date of the application
+//
+// Returns:
+//   - string: The pack path
+//
+// Example:
+//
+//	path := output.PackPath("Jane_Doe_Resume_2024-06-01.md", "Acme Corp", t)
+//	// path == "Jane_Doe_Resume_2024-06-01_acme-corp.zip"
+func PackPath(markdownPath, company string, t time.Time) string {
+	name := strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath))
+	if date := t.Format("2006-01-02"); !strings.HasSuffix(name, date) {
+		name += "_" + date
+	}
+	slug := strings.Join(strings.FieldsFunc(strings.ToLower(company), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+	if slug != "" {
+		name += "_" + slug
+	}
+	return name + ".zip"
+}
+
+// WritePack zips the pack's files, the job description, and the metadata
+// into a single archive at path. An existing archive is never overwritten;
+// a numeric suffix is added instead (Jane_Doe_Resume_2024-06-01-2.zip).
+// Files are stored under their base names.
+//
+// Parameters:
+//   - path: Where to write the archive (see PackPath)
+//   - pack: The files and metadata to include
+//
+// Returns:
+//   - string: The path of the archive written
+//   - error: An error if a file cannot be read or the archive cannot be written
+//
+// Example:
+//
+//	path, err := output.WritePack(output.PackPath(mdPath, "", time.Now()), output.Pack{
+//	    Files:    []string{mdPath, pdfPath},
+//	    Metadata: output.PackMetadata{Candidate: "Jane Doe", Generated: time.Now()},
+//	})
+func WritePack(path string, pack Pack) (string, error) {
+	path, err := uniquePackPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	// Collect the entries first, so a missing file leaves no partial archive
+	type entry struct {
+		name    string
+		content []byte
+	}
+	var entries []entry
+	seen := make(map[string]bool)
+	for _, file := range pack.Files {
+		name := filepath.Base(file)
+		if file == "" || seen[name] {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s for the pack: %w", file, err)
+		}
+		seen[name] = true
+		entries = append(entries, entry{name, content})
+	}
+	if pack.JobDescription != "" {
+		ext := filepath.Ext(pack.JobPath)
+		if ext == "" {
+			ext = ".txt"
+		}
+		entries = append(entries, entry{PackJobDescriptionFile + ext, []byte(pack.JobDescription)})
+	}
+
+	metadata := pack.Metadata
+	metadata.Files = make([]string, 0, len(entries)+1)
+	for _, e := range entries {
+		metadata.Files = append(metadata.Files, e.name)
+	}
+	metadata.Files = append(metadata.Files, PackMetadataFile)
+	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode pack metadata: %w", err)
+	}
+	entries = append(entries, entry{PackMetadataFile, append(metadataJSON, '\n')})
+
+	if err := ensureDirectoryExists(filepath.Dir(path)); err != nil {
+		return "", fmt.Errorf("failed to ensure directory exists: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, FileMode)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pack: %w", err)
+	}
+
+	archive := zip.NewWriter(file)
+	modified := metadata.Generated
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+	for _, e := range entries {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Deflate, Modified: modified})
+		if err == nil {
+			_, err = w.Write(e.content)
+		}
+		if err != nil {
+			file.Close()
+			os.Remove(path)
+			return "", fmt.Errorf("failed to write %s to the pack: %w", e.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write pack: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write pack: %w", err)
+	}
+	return path, nil
+}
+
+// uniquePackPath returns path, or path with a numeric suffix if it exists.
+func uniquePackPath(path string) (string, error) {
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	candidate := path
+	for i := 2; ; i++ {
+		_, err := os.Stat(candidate)
+		if os.IsNotExist(err) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check pack path: %w", err)
+		}
+		candidate = fmt.Sprintf("%s-%d%s", stem, i, ext)
+	}
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPackPath(t *testing.T) {
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		markdown string
+		company  string
+		expected string
+	}{
+		{"dated name", "Jane_Doe_Resume_2024-06-01.md", "", "Jane_Doe_Resume_2024-06-01.zip"},
+		{"undated name", filepath.Join("out", "resume.md"), "", filepath.Join("out", "resume_2024-06-01.zip")},
+		{"company", "Jane_Doe_Resume_2024-06-01.md", "Acme Corp, Inc.", "Jane_Doe_Resume_2024-06-01_acme-corp-inc.zip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PackPath(tt.markdown, tt.company, date); got != tt.expected {
+				t.Errorf("PackPath(%q, %q) = %q, want %q", tt.markdown, tt.company, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWritePack(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "resume.md")
+	htmlPath := filepath.Join(dir, "resume.html")
+	if err := os.WriteFile(mdPath, []byte("# Jane Doe\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(htmlPath, []byte("<h1>Jane Doe</h1>"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	pack := Pack{
+		Files:          []string{mdPath, htmlPath, "", mdPath},
+		JobDescription: "Senior Go engineer",
+		JobPath:        "job.md",
+		Metadata:       PackMetadata{Candidate: "Jane Doe", Company: "Acme", Generated: time.Now()},
+	}
+	packPath := filepath.Join(dir, "resume.zip")
+
+	path, err := WritePack(packPath, pack)
+	if err != nil {
+		t.Fatalf("WritePack returned error: %v", err)
+	}
+	if path != packPath {
+		t.Errorf("path = %q, want %q", path, packPath)
+	}
+
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("Failed to open pack: %v", err)
+	}
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	r.Close()
+	expected := []string{"resume.md", "resume.html", "job_description.md", PackMetadataFile}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("pack files = %v, want %v", names, expected)
+	}
+	if got := readZipPart(t, path, "job_description.md"); got != "Senior Go engineer" {
+		t.Errorf("job description = %q", got)
+	}
+
+	var metadata PackMetadata
+	if err := json.Unmarshal([]byte(readZipPart(t, path, PackMetadataFile)), &metadata); err != nil {
+		t.Fatalf("Failed to decode metadata: %v", err)
+	}
+	if metadata.Candidate != "Jane Doe" || metadata.Company != "Acme" || !reflect.DeepEqual(metadata.Files, expected) {
+		t.Errorf("metadata = %+v", metadata)
+	}
+
+	// A second pack at the same path gets a suffix
+	second, err := WritePack(packPath, pack)
+	if err != nil {
+		t.Fatalf("WritePack returned error: %v", err)
+	}
+	if want := filepath.Join(dir, "resume-2.zip"); second != want {
+		t.Errorf("second pack path = %q, want %q", second, want)
+	}
+}
+
+func TestWritePackMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	packPath := filepath.Join(dir, "resume.zip")
+	_, err := WritePack(packPath, Pack{Files: []string{filepath.Join(dir, "missing.pdf")}})
+	if err == nil {
+		t.Fatal("Expected an error for a missing file")
+	}
+	if _, statErr := os.Stat(packPath); !os.IsNotExist(statErr) {
+		t.Errorf("a partial pack was left behind: %v", statErr)
+	}
+}
@@ -0,0 +1,216 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// pageMetrics are the estimated sizes, in points, of a layout's printed
+// letter page and what is on it, worked out from the layout's styles.
+type pageMetrics struct {
+	height       float64 // Printable height, inside the @page margins
+	chars        int     // Characters of body text on a line of the main column
+	line         float64 // Height of a line of body text
+	title        float64 // Height of the name, with the header's border and margin
+	heading      float64 // Height of a section heading, with its margins
+	subheading   float64 // Height of a role heading, with its margins
+	gap          float64 // Space between paragraphs and lists
+	keepSections bool    // Sections move to the next page whole rather than split
+}
+
+// layoutMetrics holds the page metrics of each layout. The two-column
+// layout's main column is narrower; its sidebar is not counted, since it
+// is shorter than the main column.
+var layoutMetrics = map[Layout]pageMetrics{
+	LayoutStandard:  {height: 720, chars: 90, line: 17.4, title: 52, heading: 45, subheading: 32, gap: 12},
+	LayoutTwoColumn: {height: 720, chars: 59, line: 17.4, title: 52, heading: 45, subheading: 32, gap: 12},
+	LayoutCompact:   {height: 741.6, chars: 118, line: 11.9, title: 32, heading: 21.4, subheading: 16.6, gap: 3.8, keepSections: true},
+}
+
+// PageBreak is where a new page is estimated to start when the HTML resume
+// is printed.
+type PageBreak struct {
+	Page    int    // The page that starts at the break, from 2
+	Section string // Heading of the section the page starts in
+	Role    string // The role whose bullets are split by the break, or empty if none are
+
+	section int // Index of the section in the main column
+	line    int // Line of the section's body the page starts at, or -1 for its heading
+}
+
+// Warning describes a break that splits a role's bullets across pages.
+//
+// Returns:
+//   - string: The warning, or an empty string if the break splits no role
+func (b PageBreak) Warning() string {
+	if b.Role == "" {
+		return ""
+	}
+	return fmt.Sprintf("The bullets of %q are split across pages %d and %d.", b.Role, b.Page-1, b.Page)
+}
+
+// EstimatePageBreaks estimates where pages break when the resume is printed
+// from its HTML in layout on letter paper. The estimate counts wrapped
+// lines of each paragraph and bullet, and breaks between them, keeping a
+// heading with the line after it; a browser's result can differ by a few
+// lines, depending on its fonts. The timeline section is not counted.
+//
+// Parameters:
+//   - resume: The structured resume
+//   - layout: The layout it is printed in (an empty layout is LayoutStandard)
+//
+// Returns:
+//   - []PageBreak: The breaks in reading order, or nil if the resume fits on one page
+//
+// Example:
+//
+//	for _, pageBreak := range output.EstimatePageBreaks(resume, output.LayoutStandard) {
+//	    if warning := pageBreak.Warning(); warning != "" {
+//	        fmt.Println(warning)
+//	    }
+//	}
+func EstimatePageBreaks(resume document.Resume, layout Layout) []PageBreak {
+	if layout == "" {
+		layout = LayoutStandard
+	}
+	_, primary := columnSections(resume.RenderedSections(), layout)
+	return estimatePageBreaks(resume, primary, layoutMetrics[layout])
+}
+
+// estimatePageBreaks lays out the header and the sections of the main
+// column on pages of the given metrics.
+func estimatePageBreaks(resume document.Resume, sections []document.Section, m pageMetrics) []PageBreak {
+	var breaks []PageBreak
+	page, used := 1, 0.0
+
+	// fits places height on the page, with keep more that must follow it on
+	// the same page, and reports whether a new page was started for it
+	fits := func(height, keep float64) bool {
+		if used > 0 && used+height+keep > m.height {
+			page++
+			used = height
+			return false
+		}
+		used += height
+		return true
+	}
+
+	used = m.title
+	for _, b := range markdownBlocks(resume.Header) {
+		used += blockHeight(b, m)
+	}
+
+	for i, section := range sections {
+		blocks := markdownBlocks(section.Body)
+		heights := make([]float64, len(blocks))
+		total := m.heading
+		for j, b := range blocks {
+			heights[j] = blockHeight(b, m)
+			if j == 0 || b.kind != blockListItem || blocks[j-1].kind != blockListItem {
+				heights[j] += m.gap
+			}
+			total += heights[j]
+		}
+
+		// The heading starts a page when the section would be split, or it
+		// would be left at the bottom of one
+		keep := 0.0
+		if m.keepSections && total <= m.height {
+			keep = total - m.heading
+		} else if len(heights) > 0 {
+			keep = heights[0]
+		}
+		if !fits(m.heading, keep) {
+			breaks = append(breaks, PageBreak{Page: page, Section: section.Heading, section: i, line: -1})
+		}
+
+		role, bullets := "", 0
+		for j, b := range blocks {
+			keep := 0.0
+			switch b.kind {
+			case blockHeading:
+				role, bullets = plainLine(b.lines[0]), 0
+				if j+1 < len(blocks) {
+					keep = heights[j+1]
+				}
+			case blockParagraph:
+				// A line followed by bullets introduces them, like a role's title
+				role, bullets = "", 0
+				if j+1 < len(blocks) && blocks[j+1].kind == blockListItem {
+					role = plainLine(b.lines[0])
+				}
+			}
+
+			if !fits(heights[j], keep) {
+				pageBreak := PageBreak{Page: page, Section: section.Heading, section: i, line: b.line}
+				if b.kind == blockListItem && bullets > 0 {
+					pageBreak.Role = role
+				}
+				breaks = append(breaks, pageBreak)
+			}
+			if b.kind == blockListItem {
+				bullets++
+			}
+		}
+	}
+	return breaks
+}
+
+// blockHeight returns the estimated height of a block, without the gap
+// before it.
+func blockHeight(b block, m pageMetrics) float64 {
+	switch b.kind {
+	case blockHeading:
+		return m.subheading
+	case blockRule:
+		return m.gap
+	}
+	lines := 0
+	for _, line := range b.lines {
+		lines += max((len([]rune(plainLine(line)))+m.chars-1)/m.chars, 1)
+	}
+	return float64(lines) * m.line
+}
+
+// plainLine returns a line of Markdown without its inline formatting.
+func plainLine(line string) string {
+	var b strings.Builder
+	for _, r := range inlineRuns(line) {
+		b.WriteString(r.text)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// pageBreakCSS styles the estimated page breaks and their warnings, which
+// are only shown on screen.
+const pageBreakCSS = `.page-break { border-top: 2px dashed #c0392b; margin: 0.6em 0; text-align: right; font-size: 0.75em; color: #c0392b; }
+.page-break-warnings { border: 1px solid #c0392b; background: #fdf2f0; padding: 0.5em 1em; margin-bottom: 1em; font-size: 0.9em; }
+@media print { .page-break, .page-break-warnings { display: none; } }
+`
+
+// pageBreakHTML returns the marker shown where a page is estimated to start.
+func pageBreakHTML(pageBreak PageBreak) string {
+	label := fmt.Sprintf("Page %d", pageBreak.Page)
+	if pageBreak.Role != "" {
+		label += " · splits the bullets of " + pageBreak.Role
+	}
+	return "<div class=\"page-break\" role=\"separator\">" + html.EscapeString(label) + "</div>\n"
+}
+
+// pageBreakWarningsHTML returns the list of breaks that split a role's
+// bullets, shown above the resume, or an empty string if there are none.
+func pageBreakWarningsHTML(breaks []PageBreak) string {
+	var items strings.Builder
+	for _, pageBreak := range breaks {
+		if warning := pageBreak.Warning(); warning != "" {
+			items.WriteString("<li>" + html.EscapeString(warning) + "</li>\n")
+		}
+	}
+	if items.Len() == 0 {
+		return ""
+	}
+	return "<div class=\"page-break-warnings\">\n<strong>Estimated page breaks</strong>\n<ul>\n" + items.String() + "</ul>\n</div>\n"
+}
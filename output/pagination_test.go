@@ -0,0 +1,87 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// longResume returns a resume whose sections have the given roles, each
+// with the given number of short bullets.
+func longResume(roles, bullets int) string {
+	var b strings.Builder
+	b.WriteString("# Jane Doe\n\njane@example.com\n\n## Experience\n")
+	for i := 1; i <= roles; i++ {
+		fmt.Fprintf(&b, "\n### Engineer %d, Acme\n\n", i)
+		for j := 1; j <= bullets; j++ {
+			fmt.Fprintf(&b, "- Shipped feature %d\n", j)
+		}
+	}
+	b.WriteString("\n## Education\n\n- BS, State University\n")
+	return b.String()
+}
+
+func TestEstimatePageBreaks(t *testing.T) {
+	// Test case 1: A short resume fits on one page
+	if breaks := EstimatePageBreaks(document.Parse(layoutTestResume), LayoutStandard); breaks != nil {
+		t.Errorf("Expected no page breaks, got %+v", breaks)
+	}
+
+	// Test case 2: A break among a role's bullets is reported as splitting them
+	breaks := EstimatePageBreaks(document.Parse(longResume(3, 10)), "")
+	if len(breaks) != 1 {
+		t.Fatalf("Expected one page break, got %+v", breaks)
+	}
+	if breaks[0].Page != 2 || breaks[0].Section != "Experience" || breaks[0].Role != "Engineer 3, Acme" {
+		t.Errorf("Expected page 2 to split the third role, got %+v", breaks[0])
+	}
+	if want := `The bullets of "Engineer 3, Acme" are split across pages 1 and 2.`; breaks[0].Warning() != want {
+		t.Errorf("Warning() = %q, want %q", breaks[0].Warning(), want)
+	}
+
+	// Test case 3: A role heading is kept with its first bullet
+	resume := document.Parse(longResume(12, 4))
+	breaks = EstimatePageBreaks(resume, LayoutStandard)
+	if len(breaks) == 0 {
+		t.Fatal("Expected the long resume to break")
+	}
+	for _, pageBreak := range breaks {
+		lines := strings.Split(resume.Sections[pageBreak.section].Body, "\n")
+		if pageBreak.line >= 2 && strings.HasPrefix(lines[pageBreak.line-2], "###") {
+			t.Errorf("Expected no break right after a role heading, got %+v", pageBreak)
+		}
+	}
+
+	// Test case 4: The compact layout moves a section that does not fit to the next page whole
+	content := "# Jane Doe\n\n## Experience\n\n" + strings.Repeat("- Shipped a feature\n", 50) + "\n## Education\n\n" + strings.Repeat("- Took a course\n", 20)
+	breaks = EstimatePageBreaks(document.Parse(content), LayoutCompact)
+	if len(breaks) != 1 || breaks[0].Section != "Education" || breaks[0].line != -1 || breaks[0].Warning() != "" {
+		t.Errorf("Expected the Education section to start page 2, got %+v", breaks)
+	}
+}
+
+func TestRenderHTMLPageBreaks(t *testing.T) {
+	resume := document.Parse(longResume(3, 10))
+
+	// Test case 1: Breaks are marked with their warning, on screen only
+	page := RenderHTMLWithOptions(resume, LayoutStandard, HTMLOptions{PageBreaks: true})
+	for _, want := range []string{
+		`<div class="page-break" role="separator">Page 2 · splits the bullets of Engineer 3, Acme</div>`,
+		`<li>The bullets of &#34;Engineer 3, Acme&#34; are split across pages 1 and 2.</li>`,
+		"@media print { .page-break, .page-break-warnings { display: none; } }",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+	if strings.Count(page, "<li>Shipped feature") != 30 {
+		t.Errorf("Expected every bullet on either side of the break, got %q", page)
+	}
+
+	// Test case 2: Without the option nothing is marked
+	if page := RenderHTMLWithOptions(resume, LayoutStandard, HTMLOptions{}); strings.Contains(page, "page-break") {
+		t.Error("Expected no page-break markers without the option")
+	}
+}
@@ -0,0 +1,135 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+// DefaultSections are the sections a generated resume is expected to have
+// when the source resume does not show which ones it uses.
+var DefaultSections = []string{"Summary", "Experience", "Skills", "Education"}
+
+// PartialResume is what can be kept of a resume whose generation stopped
+// before the response was complete.
+type PartialResume struct {
+	Content string   // The resume: the complete sections, then a TODO placeholder for each missing one
+	Kept    []string // Headings of the complete sections that were kept
+	Missing []string // Headings of the sections replaced by placeholders
+}
+
+// todoPlaceholder is the body of a section that was not generated in time.
+const todoPlaceholder = "TODO: This section was not generated before the time limit. Write it yourself or generate the resume again."
+
+// ExpectedSections returns the section headings of a source resume, or
+// DefaultSections when it has none, such as when there is no source resume.
+//
+// Parameters:
+//   - source: The source resume (can be empty)
+//
+// Returns:
+//   - []string: The level-two headings expected in the generated resume
+//
+// Example:
+//
+//	expected := output.ExpectedSections(sourceContent)
+func ExpectedSections(source string) []string {
+	var headings []string
+	for _, line := range strings.Split(source, "\n") {
+		match := sectionHeadingRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if match != nil && len(match[1]) == 2 && match[2] != "" {
+			headings = append(headings, match[2])
+		}
+	}
+	if len(headings) == 0 {
+		return DefaultSections
+	}
+	return headings
+}
+
+// AcceptPartialResume keeps the complete sections of a resume that stopped
+// streaming part way through. A section is complete once the next level-two
+// heading has started, so the section being written when the stream
+// stopped is dropped. It and each expected section that never arrived are
+// added back as TODO placeholders for the user to fill in.
+//
+// Parameters:
+//   - streamed: The raw text received before the stream stopped
+//   - expected: The headings the resume should have (see ExpectedSections)
+//
+// Returns:
+//   - PartialResume: The kept sections and placeholders
+//   - error: An error if no complete section was received
+//
+// Example:
+//
+//	partial, err := output.AcceptPartialResume(streamed, output.ExpectedSections(source))
+//	if err == nil {
+//	    fmt.Printf("Kept %d sections; %d marked TODO\n", len(partial.Kept), len(partial.Missing))
+//	}
+func AcceptPartialResume(streamed string, expected []string) (PartialResume, error) {
+	finished := strings.Contains(streamed, api.ResumeEndDelimiter)
+	lines := strings.Split(ExtractFencedContent(streamed), "\n")
+
+	// Split the resume into the title block and its level-two sections
+	type section struct {
+		heading string
+		lines   []string
+	}
+	var header []string
+	var sections []section
+	for _, line := range lines {
+		match := sectionHeadingRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if match != nil && len(match[1]) == 2 {
+			sections = append(sections, section{heading: match[2], lines: []string{line}})
+			continue
+		}
+		if len(sections) == 0 {
+			header = append(header, line)
+		} else {
+			sections[len(sections)-1].lines = append(sections[len(sections)-1].lines, line)
+		}
+	}
+
+	var partial PartialResume
+	if !finished && len(sections) > 0 {
+		partial.Missing = append(partial.Missing, sections[len(sections)-1].heading)
+		sections = sections[:len(sections)-1]
+	}
+	if len(sections) == 0 {
+		return PartialResume{}, errors.New("no complete section was received")
+	}
+
+	parts := []string{strings.TrimSpace(strings.Join(header, "\n"))}
+	for _, s := range sections {
+		parts = append(parts, strings.TrimSpace(strings.Join(s.lines, "\n")))
+		partial.Kept = append(partial.Kept, s.heading)
+	}
+	for _, heading := range expected {
+		if !containsFold(partial.Kept, heading) && !containsFold(partial.Missing, heading) {
+			partial.Missing = append(partial.Missing, heading)
+		}
+	}
+	for _, heading := range partial.Missing {
+		parts = append(parts, "## "+heading+"\n\n"+todoPlaceholder)
+	}
+
+	content, err := PrepareForOutput(strings.TrimSpace(strings.Join(parts, "\n\n")))
+	if err != nil {
+		return PartialResume{}, fmt.Errorf("invalid markdown content: %w", err)
+	}
+	partial.Content = content
+	return partial, nil
+}
+
+// containsFold reports whether list contains s, ignoring case and surrounding space.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(s)) {
+			return true
+		}
+	}
+	return false
+}
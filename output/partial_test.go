@@ -0,0 +1,57 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestExpectedSections(t *testing.T) {
+	source := "# Jane Doe\n\n## Profile\n\nGo engineer.\n\n## Work History\n\n### Acme\n\n## Education\n"
+	if got, want := ExpectedSections(source), []string{"Profile", "Work History", "Education"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpectedSections() = %v, want %v", got, want)
+	}
+	if got := ExpectedSections("Plain notes without headings"); !reflect.DeepEqual(got, DefaultSections) {
+		t.Errorf("ExpectedSections() = %v, want the default sections", got)
+	}
+}
+
+func TestAcceptPartialResume(t *testing.T) {
+	streamed := "Sure!\n" + api.ResumeStartDelimiter + "\n# Jane Doe\n\njane@example.com\n\n## Summary\n\nGo engineer.\n\n## Experience\n\n### Engineer, Acme\n- Built the checkout service\n\n## Skills\n\n- Go, Postg"
+
+	// Test case 1: The section cut off and the sections never received are marked TODO
+	partial, err := AcceptPartialResume(streamed, []string{"summary", "Experience", "Skills", "Education"})
+	if err != nil {
+		t.Fatalf("AcceptPartialResume returned error: %v", err)
+	}
+	if want := []string{"Summary", "Experience"}; !reflect.DeepEqual(partial.Kept, want) {
+		t.Errorf("Kept = %v, want %v", partial.Kept, want)
+	}
+	if want := []string{"Skills", "Education"}; !reflect.DeepEqual(partial.Missing, want) {
+		t.Errorf("Missing = %v, want %v", partial.Missing, want)
+	}
+	for _, element := range []string{"# Jane Doe", "jane@example.com", "- Built the checkout service", "## Skills\n\nTODO:", "## Education\n\nTODO:"} {
+		if !strings.Contains(partial.Content, element) {
+			t.Errorf("Content should contain %q:\n%s", element, partial.Content)
+		}
+	}
+	if strings.Contains(partial.Content, "Postg") || strings.Contains(partial.Content, "Sure!") {
+		t.Errorf("Content should not contain the cut-off section or chatter:\n%s", partial.Content)
+	}
+
+	// Test case 2: A finished resume keeps its last section
+	partial, err = AcceptPartialResume(streamed+"res\n"+api.ResumeEndDelimiter, []string{"Skills"})
+	if err != nil {
+		t.Fatalf("AcceptPartialResume returned error: %v", err)
+	}
+	if len(partial.Missing) != 0 || !strings.Contains(partial.Content, "- Go, Postgres") {
+		t.Errorf("Expected every section to be kept, missing %v:\n%s", partial.Missing, partial.Content)
+	}
+
+	// Test case 3: Nothing can be kept before the first section is complete
+	if _, err := AcceptPartialResume(api.ResumeStartDelimiter+"\n# Jane Doe\n\n## Summary\n\nGo eng", DefaultSections); err == nil {
+		t.Error("Expected an error without a complete section")
+	}
+}
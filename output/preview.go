@@ -0,0 +1,280 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/layout"
+)
+
+// PreviewRenderer turns a Markdown resume into text for reading in a
+// terminal. The TUI shows the text inside its preview screen, and the view
+// command prints it. Other renderers, such as one built on glamour, can be
+// used by implementing this interface.
+type PreviewRenderer interface {
+	// Render returns the resume formatted to fit width cells.
+	Render(markdown string, width int) (string, error)
+}
+
+// Names of the renderers accepted by ParseRenderer. Any other name is run
+// as a pager command.
+const (
+	// RendererStyled formats the resume with colors, bold headings, and bullets.
+	RendererStyled = "styled"
+
+	// RendererPlain formats the resume as plain text, without escape codes.
+	RendererPlain = "plain"
+
+	// RendererPager pipes the resume to the program named by PAGER.
+	RendererPager = "pager"
+)
+
+// defaultPager is the pager used when PAGER is not set.
+const defaultPager = "less"
+
+// ParseRenderer returns the renderer with the given name: "styled" (the
+// default when name is empty), "plain", or "pager", which uses PAGER. Any
+// other name is a pager command with its arguments, such as "bat" or
+// "less -R".
+//
+// Parameters:
+//   - name: The renderer name or pager command
+//
+// Returns:
+//   - PreviewRenderer: The renderer
+//   - error: An error if "pager" is given and the pager command is empty
+//
+// Example:
+//
+//	renderer, err := output.ParseRenderer("bat")
+//	if pager, ok := renderer.(output.PagerRenderer); ok {
+//	    cmd, err := pager.Cmd(markdown, 80)
+//	    ...
+//	}
+func ParseRenderer(name string) (PreviewRenderer, error) {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", RendererStyled:
+		return StyledRenderer{}, nil
+	case RendererPlain:
+		return PlainRenderer{}, nil
+	case RendererPager:
+		name = strings.TrimSpace(os.Getenv("PAGER"))
+		if name == "" {
+			name = defaultPager
+		}
+	}
+	return NewPagerRenderer(name)
+}
+
+// StyledRenderer formats a resume for a color terminal: a colored name and
+// section headings, bold and italic text, and bullets. Links keep their URL.
+type StyledRenderer struct{}
+
+// Render returns the styled resume. It never fails.
+func (StyledRenderer) Render(markdown string, width int) (string, error) {
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#43BF6D"))
+	ruleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
+
+	return renderTerminal(markdown, width, terminalTheme{
+		heading: func(level int, text string, width int) string {
+			switch level {
+			case 1:
+				return nameStyle.Render(text)
+			case 2:
+				return sectionStyle.Render(strings.ToUpper(text)) + "\n" + ruleStyle.Render(strings.Repeat("─", min(layout.Width(text), width)))
+			}
+			return lipgloss.NewStyle().Bold(true).Render(text)
+		},
+		bullet: "•",
+		rule: func(width int) string {
+			return ruleStyle.Render(strings.Repeat("─", width))
+		},
+		run: func(r run) string {
+			return lipgloss.NewStyle().Bold(r.bold).Italic(r.italic).Render(r.text)
+		},
+	}), nil
+}
+
+// PlainRenderer formats a resume as plain text: headings are underlined with
+// "=" and "-", emphasis markers are removed, and links keep their URL. The
+// text has no escape codes, so it suits files, pipes, and screen readers.
+type PlainRenderer struct{}
+
+// Render returns the plain text resume. It never fails.
+func (PlainRenderer) Render(markdown string, width int) (string, error) {
+	return renderTerminal(markdown, width, terminalTheme{
+		heading: func(level int, text string, width int) string {
+			switch level {
+			case 1:
+				return text + "\n" + strings.Repeat("=", min(layout.Width(text), width))
+			case 2:
+				return strings.ToUpper(text) + "\n" + strings.Repeat("-", min(layout.Width(text), width))
+			}
+			return text
+		},
+		bullet: "-",
+		rule: func(width int) string {
+			return strings.Repeat("-", width)
+		},
+		run: func(r run) string {
+			return r.text
+		},
+	}), nil
+}
+
+// PagerRenderer shows a resume with an external pager, such as less or bat,
+// which takes over the terminal until it exits.
+type PagerRenderer struct {
+	Command []string        // The pager program and its arguments
+	Content PreviewRenderer // Renders the text piped to the pager; nil pipes the Markdown unchanged
+}
+
+// NewPagerRenderer returns a renderer for a pager command line, split on
+// spaces. less is given -R so it shows the styled resume's colors, and bat
+// is given the Markdown itself to highlight.
+//
+// Parameters:
+//   - command: The pager command, such as "bat" or "less -R"
+//
+// Returns:
+//   - PreviewRenderer: A PagerRenderer for the command
+//   - error: An error if the command is empty
+func NewPagerRenderer(command string) (PreviewRenderer, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("no pager command given")
+	}
+
+	pager := PagerRenderer{Command: args, Content: StyledRenderer{}}
+	switch filepath.Base(args[0]) {
+	case "bat", "batcat":
+		pager.Content = nil
+		if !hasPagerFlag(args, "-l", "--language") {
+			pager.Command = append(pager.Command, "--language", "markdown")
+		}
+	case "less":
+		if !hasPagerFlag(args, "-R", "-r", "--RAW-CONTROL-CHARS", "--raw-control-chars") {
+			pager.Command = append(pager.Command, "-R")
+		}
+	}
+	return pager, nil
+}
+
+// hasPagerFlag reports whether args contain one of the flags, alone or with
+// a value attached.
+func hasPagerFlag(args []string, flags ...string) bool {
+	for _, arg := range args[1:] {
+		for _, flag := range flags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Render returns the text the pager is given. It is used in place of the
+// pager when the resume is not shown on a terminal.
+func (p PagerRenderer) Render(markdown string, width int) (string, error) {
+	if p.Content == nil {
+		return markdown, nil
+	}
+	return p.Content.Render(markdown, width)
+}
+
+// Cmd returns the command that pages the resume, reading it from stdin. The
+// caller connects the command's stdout and stderr to the terminal and runs it.
+//
+// Parameters:
+//   - markdown: The resume
+//   - width: The terminal width, for the rendered text
+//
+// Returns:
+//   - *exec.Cmd: The pager command, with the resume as its stdin
+//   - error: An error if the pager is not installed or the resume cannot be rendered
+func (p PagerRenderer) Cmd(markdown string, width int) (*exec.Cmd, error) {
+	if len(p.Command) == 0 {
+		return nil, errors.New("no pager command given")
+	}
+	program, err := exec.LookPath(p.Command[0])
+	if err != nil {
+		return nil, fmt.Errorf("pager %q not found: %w", p.Command[0], err)
+	}
+	text, err := p.Render(markdown, width)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(program, p.Command[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd, nil
+}
+
+// terminalTheme formats the parts of a resume for renderTerminal.
+type terminalTheme struct {
+	heading func(level int, text string, width int) string // A heading, already formatted inline
+	bullet  string                                         // The marker before list items
+	rule    func(width int) string                         // A horizontal rule
+	run     func(r run) string                             // A run of bold, italic, or plain text
+}
+
+// renderTerminal formats the blocks of a Markdown resume with theme and
+// wraps them to width, with a blank line between blocks.
+func renderTerminal(markdown string, width int, theme terminalTheme) string {
+	if width <= 0 {
+		width = layout.DefaultWidth
+	}
+
+	inline := func(text string) string {
+		// Keep link targets, which inlineRuns reduces to the link text
+		text = nativeLinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+			match := nativeLinkRegex.FindStringSubmatch(link)
+			target := strings.TrimPrefix(match[2], "mailto:")
+			if match[1] == target || match[1] == match[2] {
+				return match[1]
+			}
+			return match[1] + " (" + target + ")"
+		})
+
+		var b strings.Builder
+		for _, r := range inlineRuns(text) {
+			b.WriteString(theme.run(r))
+		}
+		return b.String()
+	}
+
+	var b strings.Builder
+	previous := blockKind(-1)
+	for _, blk := range markdownBlocks(markdown) {
+		// Blocks are separated by blank lines, except items of the same list
+		if previous >= 0 && !(blk.kind == blockListItem && previous == blockListItem) {
+			b.WriteString("\n")
+		}
+		previous = blk.kind
+
+		switch blk.kind {
+		case blockHeading:
+			b.WriteString(theme.heading(blk.level, layout.Wrap(inline(blk.lines[0]), width), width))
+		case blockListItem:
+			b.WriteString(layout.Wrap(theme.bullet+" "+inline(blk.lines[0]), width))
+		case blockRule:
+			b.WriteString(theme.rule(width))
+		default:
+			for i, line := range blk.lines {
+				if i > 0 {
+					b.WriteString("\n")
+				}
+				b.WriteString(layout.Wrap(inline(line), width))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
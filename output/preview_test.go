@@ -0,0 +1,95 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const previewResume = "# Jane Doe\n\njane@example.com | [GitHub](https://github.com/jane)\n\n## Experience\n\n### Engineer, Acme\n- Led the **checkout** rewrite\n- Built *reporting*\n\n---\n\nMentored engineers."
+
+func TestPlainRenderer(t *testing.T) {
+	text, err := PlainRenderer{}.Render(previewResume, 40)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	expected := "Jane Doe\n========\n\njane@example.com | GitHub\n(https://github.com/jane)\n\nEXPERIENCE\n----------\n\nEngineer, Acme\n\n- Led the checkout rewrite\n- Built reporting\n\n" +
+		strings.Repeat("-", 40) + "\n\nMentored engineers.\n"
+	if text != expected {
+		t.Errorf("Render() =\n%s\nwant\n%s", text, expected)
+	}
+}
+
+func TestStyledRenderer(t *testing.T) {
+	text, err := StyledRenderer{}.Render(previewResume, 40)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	for _, element := range []string{"Jane Doe", "EXPERIENCE", "• Led the", "checkout", "(https://github.com/jane)", "─"} {
+		if !strings.Contains(text, element) {
+			t.Errorf("Styled resume should contain %q:\n%s", element, text)
+		}
+	}
+	if strings.Contains(text, "**") || strings.Contains(text, "## ") {
+		t.Errorf("Styled resume should not contain Markdown markers:\n%s", text)
+	}
+}
+
+func TestParseRenderer(t *testing.T) {
+	t.Setenv("PAGER", "most -s")
+
+	tests := []struct {
+		name     string
+		expected PreviewRenderer
+	}{
+		{"", StyledRenderer{}},
+		{"Styled", StyledRenderer{}},
+		{"plain", PlainRenderer{}},
+		{"pager", PagerRenderer{Command: []string{"most", "-s"}, Content: StyledRenderer{}}},
+		{"less", PagerRenderer{Command: []string{"less", "-R"}, Content: StyledRenderer{}}},
+		{"less -FR", PagerRenderer{Command: []string{"less", "-FR", "-R"}, Content: StyledRenderer{}}},
+		{"less --RAW-CONTROL-CHARS", PagerRenderer{Command: []string{"less", "--RAW-CONTROL-CHARS"}, Content: StyledRenderer{}}},
+		{"bat", PagerRenderer{Command: []string{"bat", "--language", "markdown"}}},
+		{"bat -l md", PagerRenderer{Command: []string{"bat", "-l", "md"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := ParseRenderer(tt.name)
+			if err != nil {
+				t.Fatalf("ParseRenderer(%q) returned error: %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(renderer, tt.expected) {
+				t.Errorf("ParseRenderer(%q) = %#v, want %#v", tt.name, renderer, tt.expected)
+			}
+		})
+	}
+
+	t.Run("default pager", func(t *testing.T) {
+		t.Setenv("PAGER", "")
+		renderer, err := ParseRenderer("pager")
+		if err != nil || !reflect.DeepEqual(renderer, PagerRenderer{Command: []string{"less", "-R"}, Content: StyledRenderer{}}) {
+			t.Errorf("ParseRenderer(pager) = %#v, %v; want less -R", renderer, err)
+		}
+	})
+}
+
+func TestPagerRendererCmd(t *testing.T) {
+	// Test case 1: A pager without a renderer is given the Markdown unchanged
+	cmd, err := PagerRenderer{Command: []string{"cat"}}.Cmd(previewResume, 80)
+	if err != nil {
+		t.Fatalf("Cmd returned error: %v", err)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Failed to run pager: %v", err)
+	}
+	if string(out) != previewResume {
+		t.Errorf("pager was given %q, want the Markdown", out)
+	}
+
+	// Test case 2: A missing pager is an error
+	if _, err := (PagerRenderer{Command: []string{"resumake-missing-pager"}}).Cmd(previewResume, 80); err == nil {
+		t.Error("Expected an error for a missing pager")
+	}
+}
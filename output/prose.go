@@ -0,0 +1,229 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MinProseWidth is the narrowest column FormatProse wraps prose at.
+const MinProseWidth = 20
+
+// proseListItemRegex matches a list item, with its indentation and marker
+// as the first group and its text as the second.
+var proseListItemRegex = regexp.MustCompile(`^(\s*(?:[-*+]|\d{1,9}[.)])\s+)(.*)$`)
+
+// tableDelimiterRegex matches the row under a table's header, such as "|---|:---:|".
+var tableDelimiterRegex = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)+\|?\s*$`)
+
+// setextUnderlineRegex matches the line under a heading written as "Title\n=====".
+var setextUnderlineRegex = regexp.MustCompile(`^\s*(=+|-+)\s*$`)
+
+// thematicBreakRegex matches a horizontal rule, such as "---" or "* * *".
+var thematicBreakRegex = regexp.MustCompile(`^\s*([-*_])(\s*([-*_]))*\s*$`)
+
+// proseAbbreviations are words ending in a period that do not end a sentence.
+var proseAbbreviations = map[string]bool{
+	"approx": true, "co": true, "corp": true, "dept": true, "dr": true,
+	"e.g": true, "etc": true, "i.e": true, "inc": true, "jr": true,
+	"ltd": true, "mr": true, "mrs": true, "ms": true, "no": true,
+	"prof": true, "sr": true, "st": true, "univ": true, "vs": true,
+}
+
+// proseBlock is a paragraph or list item being reflowed: its text, the
+// prefix of its first line, and the indentation of the lines after it.
+type proseBlock struct {
+	words  []string
+	prefix string
+	indent string
+}
+
+// FormatProse rewrites the prose of a Markdown document for reviewing
+// changes line by line: each sentence starts on its own line, and lines
+// longer than width are wrapped at a space. The document renders the same,
+// since Markdown joins the lines of a paragraph. Headings, tables, code
+// blocks, horizontal rules, and HTML are left as they are, list items keep
+// their markers with the lines after them indented to match, and hard line
+// breaks are kept. A line is never started with text Markdown would read
+// as a list marker or heading, so such a word stays on the line before.
+//
+// Parameters:
+//   - markdown: The document to format
+//   - width: The column to wrap at; 0 leaves the document as it is
+//
+// Returns:
+//   - string: The formatted document
+//
+// Example:
+//
+//	text := output.FormatProse("Led the team. Shipped the app.", 80)
+//	// text == "Led the team.\nShipped the app."
+func FormatProse(markdown string, width int) string {
+	if width <= 0 {
+		return markdown
+	}
+	width = max(width, MinProseWidth)
+
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	verbatim := verbatimLines(lines)
+
+	var out []string
+	var block *proseBlock
+	flush := func(lineBreak string) {
+		if block != nil {
+			out = append(out, block.format(width, lineBreak)...)
+		}
+	}
+	continuation := "" // Indentation of a block continued after a hard line break
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		lineBreak := hardLineBreak(line)
+
+		switch {
+		case verbatim[i] || trimmed == "":
+			flush("")
+			block, continuation = nil, ""
+			out = append(out, line)
+			continue
+		case proseListItemRegex.MatchString(line):
+			flush("")
+			match := proseListItemRegex.FindStringSubmatch(line)
+			block = &proseBlock{prefix: match[1], indent: strings.Repeat(" ", utf8.RuneCountInString(match[1]))}
+			trimmed = strings.TrimSpace(match[2])
+		case block == nil && continuation != "":
+			block = &proseBlock{prefix: continuation, indent: continuation}
+		case block == nil && strings.HasPrefix(strings.ReplaceAll(line, "\t", "    "), "    "):
+			// An indented code block
+			out = append(out, line)
+			continue
+		case block == nil:
+			block = &proseBlock{}
+		}
+
+		if lineBreak != "" {
+			trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, `\`))
+		}
+		block.words = append(block.words, strings.Fields(trimmed)...)
+		if lineBreak != "" {
+			flush(lineBreak)
+			continuation, block = block.indent, nil
+		}
+	}
+	flush("")
+	return strings.Join(out, "\n")
+}
+
+// verbatimLines marks the lines FormatProse leaves as they are: code blocks,
+// headings, tables, block quotes, HTML, and horizontal rules.
+func verbatimLines(lines []string) []bool {
+	verbatim := make([]bool, len(lines))
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			verbatim[i], inFence = true, !inFence
+			continue
+		}
+		if inFence || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "|") ||
+			strings.HasPrefix(trimmed, ">") || strings.HasPrefix(trimmed, "<") || thematicBreakRegex.MatchString(line) {
+			verbatim[i] = true
+			continue
+		}
+
+		// A table's header and rows, and a heading underlined with = or -
+		if i+1 < len(lines) && trimmed != "" {
+			next := lines[i+1]
+			if tableDelimiterRegex.MatchString(next) && strings.Contains(line, "|") {
+				for j := i; j < len(lines) && strings.TrimSpace(lines[j]) != ""; j++ {
+					verbatim[j] = true
+				}
+			} else if setextUnderlineRegex.MatchString(next) && !proseListItemRegex.MatchString(line) {
+				verbatim[i], verbatim[i+1] = true, true
+			}
+		}
+	}
+	return verbatim
+}
+
+// hardLineBreak returns the marker of a hard line break ending line, two
+// spaces or a backslash, or an empty string.
+func hardLineBreak(line string) string {
+	switch {
+	case strings.HasSuffix(line, `\`):
+		return `\`
+	case strings.HasSuffix(line, "  ") && strings.TrimSpace(line) != "":
+		return "  "
+	}
+	return ""
+}
+
+// format writes the block one sentence per line, wrapped at width, ending
+// the last line with lineBreak.
+func (b *proseBlock) format(width int, lineBreak string) []string {
+	var lines []string
+	line := b.prefix
+	empty := true
+	for i, word := range b.words {
+		startsSentence := i > 0 && endsSentence(b.words[i-1], word)
+		fits := utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width
+		if !empty && (startsSentence || !fits) && safeLineStart(word) {
+			lines = append(lines, line)
+			line, empty = b.indent, true
+		}
+		if !empty {
+			line += " "
+		}
+		line += word
+		empty = false
+	}
+	return append(lines, line+lineBreak)
+}
+
+// endsSentence reports whether word ends a sentence that next begins a new one.
+func endsSentence(word, next string) bool {
+	stripped := strings.TrimRight(word, `)"'”’*_`)
+	if !strings.HasSuffix(stripped, ".") && !strings.HasSuffix(stripped, "!") && !strings.HasSuffix(stripped, "?") {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(strings.TrimLeft(next, `("'“‘*_[`))
+	if !unicode.IsUpper(first) && !unicode.IsDigit(first) {
+		return false
+	}
+	if !strings.HasSuffix(stripped, ".") {
+		return true
+	}
+
+	// Abbreviations and initials, such as "e.g.", "U.S.", and "J.", go on
+	stem := strings.ToLower(strings.TrimLeft(strings.TrimSuffix(stripped, "."), `("'“‘*_[`))
+	if proseAbbreviations[stem] || utf8.RuneCountInString(stem) == 1 {
+		return false
+	}
+	if strings.Contains(stem, ".") {
+		for _, part := range strings.Split(stem, ".") {
+			if utf8.RuneCountInString(part) > 2 {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// safeLineStart reports whether a line may start with word without Markdown
+// reading it as a list marker, heading, block quote, table, code block, or HTML.
+func safeLineStart(word string) bool {
+	switch {
+	case word == "-" || word == "*" || word == "+" || word == "=":
+		return false
+	case strings.Trim(word, "#") == "" || strings.Trim(word, "=") == "" || strings.Trim(word, "-") == "":
+		return false
+	case strings.HasPrefix(word, ">") || strings.HasPrefix(word, "|") || strings.HasPrefix(word, "<"):
+		return false
+	case strings.HasPrefix(word, "```") || strings.HasPrefix(word, "~~~"):
+		return false
+	}
+	digits := strings.TrimLeftFunc(word, unicode.IsDigit)
+	return !(len(digits) < len(word) && (digits == "." || digits == ")"))
+}
@@ -0,0 +1,81 @@
+package output
+
+import "testing"
+
+func TestFormatProse(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		width    int
+		want     string
+	}{
+		{
+			name:     "off",
+			markdown: "Led the team. Shipped the app.",
+			width:    0,
+			want:     "Led the team. Shipped the app.",
+		},
+		{
+			name:     "one sentence per line",
+			markdown: "Led the team! Shipped the app in 2024. Did it work? Yes.",
+			width:    80,
+			want:     "Led the team!\nShipped the app in 2024.\nDid it work?\nYes.",
+		},
+		{
+			name:     "lines of a paragraph are joined",
+			markdown: "Backend engineer with eight years\nof experience. Loves Go.",
+			width:    80,
+			want:     "Backend engineer with eight years of experience.\nLoves Go.",
+		},
+		{
+			name:     "long sentences wrap at the width",
+			markdown: "Built a billing service that processes two million invoices every month for customers.",
+			width:    40,
+			want:     "Built a billing service that processes\ntwo million invoices every month for\ncustomers.",
+		},
+		{
+			name:     "abbreviations and initials do not end sentences",
+			markdown: "Worked at Acme Inc. with Dr. Smith and J. Doe in the U.S. on tools, e.g. Terraform. Then moved on.",
+			width:    200,
+			want:     "Worked at Acme Inc. with Dr. Smith and J. Doe in the U.S. on tools, e.g. Terraform.\nThen moved on.",
+		},
+		{
+			name:     "list items keep their markers and indent the lines after them",
+			markdown: "- Cut costs by 30%. Migrated every service to Kubernetes clusters.\n  1. Nested step. Done.",
+			width:    40,
+			want:     "- Cut costs by 30%.\n  Migrated every service to Kubernetes\n  clusters.\n  1. Nested step.\n     Done.",
+		},
+		{
+			name:     "headings, tables, and code are left alone",
+			markdown: "# Jane Doe. Engineer.\n\n| Skill | Years. Ok. |\n|---|---|\n| Go | 5 |\n\n```\nfmt.Println(\"a. B.\")\n```",
+			width:    20,
+			want:     "# Jane Doe. Engineer.\n\n| Skill | Years. Ok. |\n|---|---|\n| Go | 5 |\n\n```\nfmt.Println(\"a. B.\")\n```",
+		},
+		{
+			name:     "hard line breaks are kept",
+			markdown: "jane@example.com  \nSan Francisco, CA. Open to remote.",
+			width:    80,
+			want:     "jane@example.com  \nSan Francisco, CA.\nOpen to remote.",
+		},
+		{
+			name:     "lines never start with a list marker",
+			markdown: "Reduced latency from 900ms to 120ms - a big win",
+			width:    35,
+			want:     "Reduced latency from 900ms to 120ms -\na big win",
+		},
+		{
+			name:     "a number ending a line is kept with the word before it",
+			markdown: "Promoted twice, ranked 1. Then led",
+			width:    24,
+			want:     "Promoted twice, ranked 1.\nThen led",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatProse(tt.markdown, tt.width); got != tt.want {
+				t.Errorf("FormatProse() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
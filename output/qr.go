@@ -0,0 +1,114 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/phrazzld/resumake/qr"
+)
+
+// QRNone turns off the QR code when given as -qr, overriding the qr setting.
+const QRNone = "none"
+
+// qrImageName is the file name of the QR code image placed next to the
+// Markdown that pandoc converts to PDF.
+const qrImageName = "qr.png"
+
+// qrImageScale is the number of pixels per module of the QR code image, so
+// the code stays sharp when printed an inch wide.
+const qrImageScale = 10
+
+// QRLink validates the URL for the QR code. A URL without a scheme, such as
+// "linkedin.com/in/janedoe", is given https://, since phones only open
+// scanned codes that are full URLs.
+//
+// Parameters:
+//   - value: The URL from -qr or the qr setting
+//
+// Returns:
+//   - string: The URL to encode, or an empty string for an empty value or QRNone
+//   - error: An error if the value is not a web address or is too long to encode
+//
+// Example:
+//
+//	link, err := output.QRLink("linkedin.com/in/janedoe")
+//	// link == "https://linkedin.com/in/janedoe"
+func QRLink(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, QRNone) {
+		return "", nil
+	}
+	if parsed, err := url.Parse(value); err == nil && parsed.Scheme == "" {
+		value = "https://" + value
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.User != nil || !strings.Contains(parsed.Host, ".") {
+		return "", fmt.Errorf("invalid QR code URL %q; give a web address such as linkedin.com/in/your-name", value)
+	}
+	if len(value) > qr.MaxLength {
+		return "", fmt.Errorf("QR code URL is %d characters; shorten it to at most %d", len(value), qr.MaxLength)
+	}
+	return value, nil
+}
+
+// qrHTML returns the QR code for link as an inline SVG wrapped in a link to
+// it, or an empty string when there is no link or it cannot be encoded.
+func qrHTML(link string) string {
+	if link == "" {
+		return ""
+	}
+	code, err := qr.Encode(link)
+	if err != nil {
+		return ""
+	}
+	escaped := html.EscapeString(link)
+	return "<a class=\"qr\" href=\"" + escaped + "\" title=\"" + escaped + "\">" + code.SVG() + "</a>\n"
+}
+
+// writeQRSource writes a copy of the Markdown resume with the QR code for
+// link at the end of its header, before the first section, and the code's
+// image next to it, in a new temporary directory for pandoc to convert.
+// The caller removes the directory.
+func writeQRSource(markdownContent, link string) (dir, markdownPath string, err error) {
+	code, err := qr.Encode(link)
+	if err != nil {
+		return "", "", err
+	}
+	image, err := code.PNG(qrImageScale)
+	if err != nil {
+		return "", "", err
+	}
+
+	dir, err = os.MkdirTemp("", "resumake-qr-*")
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, qrImageName), image, 0600); err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+
+	// An image without alt text is not turned into a captioned figure
+	lines := strings.Split(markdownContent, "\n")
+	insert := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			insert = i
+			break
+		}
+	}
+	lines = slices.Insert(lines, insert, "![]("+qrImageName+"){width=0.9in}", "")
+
+	markdownPath = filepath.Join(dir, "resume.md")
+	if err := os.WriteFile(markdownPath, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+	return dir, markdownPath, nil
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQRLink(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"None", "", false},
+		{" linkedin.com/in/janedoe ", "https://linkedin.com/in/janedoe", false},
+		{"http://janedoe.dev", "http://janedoe.dev", false},
+		{"mailto:jane@example.com", "", true},
+		{"janedoe", "", true},
+		{"https://janedoe.dev/" + strings.Repeat("a", 300), "", true},
+	}
+	for _, tt := range tests {
+		got, err := QRLink(tt.value)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("QRLink(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteQRSource(t *testing.T) {
+	dir, markdownPath, err := writeQRSource(layoutTestResume, "https://janedoe.dev")
+	if err != nil {
+		t.Fatalf("writeQRSource() error = %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The image goes at the end of the header, before the first section
+	data, _ := os.ReadFile(markdownPath)
+	if !strings.Contains(string(data), "jane@example.com\n\n![](qr.png){width=0.9in}\n\n## Experience") {
+		t.Errorf("Expected the QR image before the first section, got %q", data)
+	}
+	if info, err := os.Stat(filepath.Join(dir, qrImageName)); err != nil || info.Size() == 0 {
+		t.Errorf("Expected the QR image to be written: %v", err)
+	}
+}
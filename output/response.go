@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
 )
 
 // FinishReasonMessages maps finish reasons to descriptive messages
@@ -47,7 +48,8 @@ func ProcessResponseContent(response *genai.GenerateContentResponse) (string, er
 		return "", errors.New("no candidates in response")
 	}
 
-	candidate := response.Candidates[0]
+	// A candidate blocked by safety filters is passed over for a later one that was not
+	candidate := api.PickCandidate(response)
 	
 	// Check for generation errors in the first candidate
 	if candidate.FinishReason != genai.FinishReasonStop && 
@@ -87,7 +89,8 @@ func ProcessResponseContent(response *genai.GenerateContentResponse) (string, er
 
 // ExtractAndValidateMarkdown extracts and validates Markdown content from raw text.
 // It serves as a bridge between the raw text extraction from API responses and
-// the Markdown validation and cleaning functionality. This ensures that the text
+// the Markdown validation and cleaning functionality. Any resume delimiters and
+// surrounding chatter are removed first (see ExtractFencedContent). This ensures that the text
 // is properly formatted as Markdown before being used as output.
 //
 // Parameters:
@@ -104,6 +107,9 @@ func ProcessResponseContent(response *genai.GenerateContentResponse) (string, er
 //	    log.Fatalf("Invalid markdown in response: %v", err)
 //	}
 func ExtractAndValidateMarkdown(responseText string) (string, error) {
+	// Strip the delimiters and any conversational chatter around the resume
+	responseText = ExtractFencedContent(responseText)
+	
 	// Validate the text as Markdown
 	if err := ValidateMarkdown(responseText); err != nil {
 		return "", fmt.Errorf("invalid markdown content: %w", err)
@@ -0,0 +1,205 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+)
+
+// sectionHeadingRegex matches a Markdown ATX heading line, capturing its level and text.
+var sectionHeadingRegex = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+// listItemLineRegex matches a list item line, capturing its marker and its text.
+var listItemLineRegex = regexp.MustCompile(`^(\s*(?:[-*+•]|\d{1,9}[.)])\s+)(\S.*?)\s*$`)
+
+// ReplaceSection replaces the first section whose heading contains keyword
+// (case-insensitive) with the given section text. The replaced section runs
+// from its heading up to the next heading of the same or a higher level.
+// The document title (a level-one heading) is never replaced. When no
+// matching section exists, the new section is appended to the end.
+//
+// This is used to render structured data, such as skills entered in the
+// skills form, consistently regardless of how the model wrote that section.
+//
+// Parameters:
+//   - content: The Markdown document
+//   - keyword: Text to look for in section headings, such as "Skills"
+//   - section: The replacement section, including its heading
+//
+// Returns:
+//   - string: The document with the section replaced or appended
+//
+// Example:
+//
+//	updated := output.ReplaceSection(resume, "Skills", "## Skills\n\n- **Go**")
+func ReplaceSection(content, keyword, section string) string {
+	keyword = strings.ToLower(keyword)
+	return replaceSection(content, section, func(heading string) bool {
+		return strings.Contains(strings.ToLower(heading), keyword)
+	})
+}
+
+// ReplaceSectionHeading replaces the first section whose heading is exactly
+// heading (ignoring case and surrounding space) with the given section text.
+// Unlike ReplaceSection, a heading that merely contains the text, such as
+// "Volunteer Experience" for "Experience", is left alone. The document title
+// is never replaced, and a missing section is appended to the end.
+//
+// Parameters:
+//   - content: The Markdown document
+//   - heading: The heading of the section to replace, without the leading "##"
+//   - section: The replacement section, including its heading
+//
+// Returns:
+//   - string: The document with the section replaced or appended
+//
+// Example:
+//
+//	updated := output.ReplaceSectionHeading(resume, "Summary", "## Summary\n\nGo engineer.")
+func ReplaceSectionHeading(content, heading, section string) string {
+	heading = strings.TrimSpace(heading)
+	return replaceSection(content, section, func(candidate string) bool {
+		return strings.EqualFold(strings.TrimSpace(candidate), heading)
+	})
+}
+
+// replaceSection replaces the first section below the title whose heading
+// matches, up to the next heading of the same or a higher level.
+func replaceSection(content, section string, matches func(heading string) bool) string {
+	section = strings.TrimSpace(section)
+	if section == "" {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	start, level := -1, 0
+	for i, line := range lines {
+		match := sectionHeadingRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+
+		headingLevel := len(match[1])
+		if start >= 0 {
+			if headingLevel <= level {
+				return joinSection(lines[:start], section, lines[i:])
+			}
+			continue
+		}
+
+		if headingLevel > 1 && matches(match[2]) {
+			start, level = i, headingLevel
+		}
+	}
+
+	if start >= 0 {
+		return joinSection(lines[:start], section, nil)
+	}
+	return joinSection(lines, section, nil)
+}
+
+// joinSection places section between the before and after lines, separated by blank lines.
+func joinSection(before []string, section string, after []string) string {
+	result := strings.TrimRight(strings.Join(before, "\n"), "\n ")
+	if result != "" {
+		result += "\n\n"
+	}
+	result += section
+
+	if rest := strings.TrimLeft(strings.Join(after, "\n"), "\n"); rest != "" {
+		result += "\n\n" + rest
+	}
+	return result
+}
+
+// ReorderSections moves the level-two sections whose headings contain one of
+// the keywords (case-insensitive, ignoring a plural "s") to the top of the
+// resume, below the title and contact details, in the order of the keywords.
+// The other sections follow in their original order.
+//
+// Parameters:
+//   - content: The Markdown document
+//   - first: Keywords of the sections to put first, such as "Education"
+//
+// Returns:
+//   - string: The document with the sections reordered
+//
+// Example:
+//
+//	reordered := output.ReorderSections(resume, []string{"Education", "Projects"})
+func ReorderSections(content string, first []string) string {
+	if len(first) == 0 {
+		return content
+	}
+
+	// Split the document into the lines above the first section and its sections
+	var header []string
+	var sections [][]string
+	for _, line := range strings.Split(content, "\n") {
+		match := sectionHeadingRegex.FindStringSubmatch(strings.TrimSpace(line))
+		switch {
+		case match != nil && len(match[1]) == 2:
+			sections = append(sections, []string{line})
+		case len(sections) == 0:
+			header = append(header, line)
+		default:
+			sections[len(sections)-1] = append(sections[len(sections)-1], line)
+		}
+	}
+
+	heading := func(section []string) string {
+		return strings.ToLower(sectionHeadingRegex.FindStringSubmatch(strings.TrimSpace(section[0]))[2])
+	}
+	ordered := make([][]string, 0, len(sections))
+	taken := make([]bool, len(sections))
+	for _, keyword := range first {
+		keyword = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(keyword)), "s")
+		for i, section := range sections {
+			if !taken[i] && keyword != "" && strings.Contains(heading(section), keyword) {
+				ordered, taken[i] = append(ordered, section), true
+			}
+		}
+	}
+	for i, section := range sections {
+		if !taken[i] {
+			ordered = append(ordered, section)
+		}
+	}
+
+	parts := []string{strings.TrimSpace(strings.Join(header, "\n"))}
+	for _, section := range ordered {
+		parts = append(parts, strings.TrimSpace(strings.Join(section, "\n")))
+	}
+	if parts[0] == "" {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ReplaceBullet replaces the text of the first list item that reads exactly
+// original (ignoring surrounding space), keeping its list marker and
+// indentation.
+//
+// Parameters:
+//   - content: The Markdown document or section
+//   - original: The text of the list item to replace, without its marker
+//   - bullet: The new text, without a marker
+//
+// Returns:
+//   - string: The document with the list item replaced
+//   - bool: Whether a list item reading original was found
+//
+// Example:
+//
+//	updated, ok := output.ReplaceBullet("- Worked on billing", "Worked on billing", "Cut failed payments by 30%")
+//	// updated == "- Cut failed payments by 30%", ok == true
+func ReplaceBullet(content, original, bullet string) (string, bool) {
+	original = strings.TrimSpace(original)
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if match := listItemLineRegex.FindStringSubmatch(line); match != nil && match[2] == original {
+			lines[i] = match[1] + strings.TrimSpace(bullet)
+			return strings.Join(lines, "\n"), true
+		}
+	}
+	return content, false
+}
@@ -0,0 +1,109 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestReplaceSection(t *testing.T) {
+	newSkills := "## Skills\n\n- **Go** (Expert)"
+
+	tests := []struct {
+		name     string
+		content  string
+		keyword  string
+		section  string
+		expected string
+	}{
+		{
+			name:     "replace middle section",
+			content:  "# Jane\n\n## Technical Skills\n\n- go, python\n\n## Education\n\n- BSc",
+			keyword:  "skills",
+			section:  newSkills,
+			expected: "# Jane\n\n## Skills\n\n- **Go** (Expert)\n\n## Education\n\n- BSc",
+		},
+		{
+			name:     "replace last section including subsections",
+			content:  "# Jane\n\n## Skills\n\n### Languages\n\n- go\n",
+			keyword:  "Skills",
+			section:  newSkills,
+			expected: "# Jane\n\n## Skills\n\n- **Go** (Expert)",
+		},
+		{
+			name:     "append when missing",
+			content:  "# Jane\n\n## Experience\n\n- Acme\n",
+			keyword:  "Skills",
+			section:  newSkills,
+			expected: "# Jane\n\n## Experience\n\n- Acme\n\n## Skills\n\n- **Go** (Expert)",
+		},
+		{
+			name:     "title is never replaced",
+			content:  "# Skills Resume\n\n- intro",
+			keyword:  "Skills",
+			section:  newSkills,
+			expected: "# Skills Resume\n\n- intro\n\n## Skills\n\n- **Go** (Expert)",
+		},
+		{
+			name:     "empty section leaves content unchanged",
+			content:  "# Jane\n\n## Skills\n\n- go",
+			keyword:  "Skills",
+			section:  "",
+			expected: "# Jane\n\n## Skills\n\n- go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplaceSection(tt.content, tt.keyword, tt.section); got != tt.expected {
+				t.Errorf("ReplaceSection() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReplaceSectionHeading(t *testing.T) {
+	content := "# Jane\n\n## Volunteer Experience\n\n- Food bank\n\n## Experience\n\n- Acme\n\n## Education\n\n- BSc"
+
+	// Test case 1: Only the section with exactly that heading is replaced
+	got := ReplaceSectionHeading(content, "experience", "## Experience\n\n- Led Acme's platform team")
+	want := "# Jane\n\n## Volunteer Experience\n\n- Food bank\n\n## Experience\n\n- Led Acme's platform team\n\n## Education\n\n- BSc"
+	if got != want {
+		t.Errorf("ReplaceSectionHeading() = %q, want %q", got, want)
+	}
+
+	// Test case 2: A missing section is appended
+	got = ReplaceSectionHeading("# Jane\n\n## Experience\n\n- Acme", "Summary", "## Summary\n\nGo engineer.")
+	if want := "# Jane\n\n## Experience\n\n- Acme\n\n## Summary\n\nGo engineer."; got != want {
+		t.Errorf("ReplaceSectionHeading() = %q, want %q", got, want)
+	}
+}
+
+func TestReplaceBullet(t *testing.T) {
+	content := "### Acme\n\n- Led the team\n  * Worked on billing\n- Worked on billing"
+
+	// Test case 1: The first matching bullet is replaced, keeping its marker and indentation
+	got, ok := ReplaceBullet(content, "Worked on billing", "Cut failed payments by 30%")
+	if want := "### Acme\n\n- Led the team\n  * Cut failed payments by 30%\n- Worked on billing"; !ok || got != want {
+		t.Errorf("ReplaceBullet() = %q, %v, want %q", got, ok, want)
+	}
+
+	// Test case 2: Text that is not a bullet is left alone
+	if got, ok := ReplaceBullet(content, "Acme", "Globex"); ok || got != content {
+		t.Errorf("Expected no change, got %q, %v", got, ok)
+	}
+}
+
+func TestReorderSections(t *testing.T) {
+	content := "# Jane\n\njane@example.com\n\n## Experience\n\n- Tutor\n\n## Skills\n\n- Go\n\n## Academic Projects\n\n- Compiler\n\n## Education\n\n- BS"
+
+	// Test case 1: Matching sections lead in keyword order and the rest keep theirs
+	got := ReorderSections(content, []string{"Education", "Projects", "Internships"})
+	want := "# Jane\n\njane@example.com\n\n## Education\n\n- BS\n\n## Academic Projects\n\n- Compiler\n\n## Experience\n\n- Tutor\n\n## Skills\n\n- Go"
+	if got != want {
+		t.Errorf("ReorderSections() = %q, want %q", got, want)
+	}
+
+	// Test case 2: No keywords leaves the document unchanged
+	if got := ReorderSections(content, nil); got != content {
+		t.Errorf("Expected the document unchanged, got %q", got)
+	}
+}
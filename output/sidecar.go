@@ -0,0 +1,139 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+// SidecarSuffix replaces the resume's extension to name the sidecar that
+// holds its structured form.
+const SidecarSuffix = ".resumake.json"
+
+// SidecarVersion is the version of the sidecar format written by WriteSidecar.
+// Sidecars from a newer version are ignored rather than misread.
+const SidecarVersion = 1
+
+// Sidecar is the structured form of a resume resumake wrote, saved next to
+// the Markdown so a later run that uses the resume as its source can load
+// the sections, skills, and academics directly instead of re-parsing them.
+type Sidecar struct {
+	Version   int             `json:"version"`
+	Generated time.Time       `json:"generated"`          // When the resume was generated
+	Model     string          `json:"model,omitempty"`    // The model that produced the resume
+	Seed      *int64          `json:"seed,omitempty"`     // The -seed the resume was generated with
+	Preset    string          `json:"preset,omitempty"`   // The -preset the resume was written for
+	Audience  string          `json:"audience,omitempty"` // The -audience the resume was written for
+	Digest    string          `json:"digest"`             // SHA-256 of the Markdown, to tell whether it was edited since
+	Resume    document.Resume `json:"resume"`             // The structured resume
+}
+
+// SidecarPath returns the path of the sidecar of a Markdown resume: the same
+// name with SidecarSuffix in place of its extension.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - string: The sidecar path
+//
+// Example:
+//
+//	path := output.SidecarPath("Jane_Doe_Resume_2024-06-01.md")
+//	// path == "Jane_Doe_Resume_2024-06-01.resumake.json"
+func SidecarPath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + SidecarSuffix
+}
+
+// contentDigest returns the SHA-256 of a Markdown resume, ignoring line
+// endings and surrounding whitespace that editors change without meaning to.
+func contentDigest(markdown string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(strings.ReplaceAll(markdown, "\r\n", "\n"))))
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteSidecar writes the sidecar of a Markdown resume. The title, header,
+// and sections are parsed from the Markdown; the skills and academics, which
+// cannot be parsed back reliably, are kept from sidecar.Resume.
+//
+// Parameters:
+//   - markdownContent: The resume that was written
+//   - markdownPath: The path it was written to
+//   - sidecar: How it was generated, with the structured skills and academics
+//
+// Returns:
+//   - string: The path of the sidecar
+//   - error: Any error that occurred while writing
+//
+// Example:
+//
+//	path, err := output.WriteSidecar(content, "resume.md", output.Sidecar{
+//	    Generated: time.Now(),
+//	    Resume:    document.Resume{Skills: skills},
+//	})
+func WriteSidecar(markdownContent, markdownPath string, sidecar Sidecar) (string, error) {
+	parsed := document.Parse(markdownContent)
+	parsed.Skills, parsed.Academics = sidecar.Resume.Skills, sidecar.Resume.Academics
+	sidecar.Resume = parsed
+	sidecar.Version = SidecarVersion
+	sidecar.Digest = contentDigest(markdownContent)
+
+	data, err := json.MarshalIndent(sidecar, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode sidecar: %w", err)
+	}
+	path := SidecarPath(markdownPath)
+	if err := WriteToFile(path, string(data)+"\n"); err != nil {
+		return "", fmt.Errorf("failed to write sidecar: %w", err)
+	}
+	return path, nil
+}
+
+// ReadSidecar reads the sidecar of a Markdown resume. A resume without one,
+// or with one from a newer version of resumake, has none.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//
+// Returns:
+//   - Sidecar: The sidecar
+//   - bool: Whether the resume has a sidecar
+//   - error: An error if the sidecar exists but cannot be read or parsed
+func ReadSidecar(markdownPath string) (Sidecar, bool, error) {
+	path := SidecarPath(markdownPath)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Sidecar{}, false, nil
+	}
+	if err != nil {
+		return Sidecar{}, false, fmt.Errorf("cannot read sidecar: %w", err)
+	}
+
+	var sidecar Sidecar
+	if err := json.Unmarshal(data, &sidecar); err != nil {
+		return Sidecar{}, false, fmt.Errorf("cannot parse sidecar %s: %w", path, err)
+	}
+	if sidecar.Version < 1 || sidecar.Version > SidecarVersion {
+		return Sidecar{}, false, nil
+	}
+	return sidecar, true, nil
+}
+
+// Describes reports whether the sidecar was written for markdown as it is,
+// rather than before the resume was edited by hand.
+//
+// Parameters:
+//   - markdown: The resume as it is now
+//
+// Returns:
+//   - bool: True if the sidecar describes the resume
+func (s Sidecar) Describes(markdown string) bool {
+	return s.Digest == contentDigest(markdown)
+}
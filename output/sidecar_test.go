@@ -0,0 +1,92 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/document"
+)
+
+func TestSidecarPath(t *testing.T) {
+	if got := SidecarPath(filepath.Join("out", "Jane_Doe_Resume.md")); got != filepath.Join("out", "Jane_Doe_Resume.resumake.json") {
+		t.Errorf("SidecarPath() = %q", got)
+	}
+}
+
+func TestWriteSidecar(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "resume.md")
+	content := "# Jane Doe\n\njane@example.com\n\n## Experience\n\n- Acme\n\n## Skills\n\n- **Go** (Expert, 6 years)\n"
+	skills := []document.Skill{{Name: "Go", Years: 6, Proficiency: document.ProficiencyExpert}}
+
+	path, err := WriteSidecar(content, markdownPath, Sidecar{
+		Generated: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+		Model:     "test-model",
+		Preset:    "new-grad",
+		Resume:    document.Resume{Skills: skills, Academics: document.Academics{GPA: "3.8"}},
+	})
+	if err != nil {
+		t.Fatalf("WriteSidecar() error = %v", err)
+	}
+	if path != SidecarPath(markdownPath) {
+		t.Errorf("Expected the sidecar at %q, got %q", SidecarPath(markdownPath), path)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"proficiency": "Expert"`) {
+		t.Errorf("Expected the proficiency saved by name, got %s", data)
+	}
+
+	sidecar, ok, err := ReadSidecar(markdownPath)
+	if err != nil || !ok {
+		t.Fatalf("ReadSidecar() = %v, %v", ok, err)
+	}
+	if sidecar.Version != SidecarVersion || sidecar.Model != "test-model" || sidecar.Preset != "new-grad" {
+		t.Errorf("Unexpected sidecar %+v", sidecar)
+	}
+	if sidecar.Resume.Name != "Jane Doe" || sidecar.Resume.Header != "jane@example.com" {
+		t.Errorf("Expected the title and header parsed from the resume, got %+v", sidecar.Resume)
+	}
+	if got := sidecar.Resume.Headings(); !reflect.DeepEqual(got, []string{"Experience", "Skills"}) {
+		t.Errorf("Expected the sections parsed from the resume, got %v", got)
+	}
+	if !reflect.DeepEqual(sidecar.Resume.Skills, skills) || sidecar.Resume.Academics.GPA != "3.8" {
+		t.Errorf("Expected the skills and academics kept, got %+v", sidecar.Resume)
+	}
+
+	if !sidecar.Describes(strings.ReplaceAll(content, "\n", "\r\n")) {
+		t.Error("Expected the sidecar to describe the resume with other line endings")
+	}
+	if sidecar.Describes(content + "\n- Edited by hand\n") {
+		t.Error("Expected the sidecar not to describe an edited resume")
+	}
+}
+
+func TestReadSidecar(t *testing.T) {
+	dir := t.TempDir()
+	markdownPath := filepath.Join(dir, "resume.md")
+
+	// Test case 1: A resume without a sidecar
+	if _, ok, err := ReadSidecar(markdownPath); ok || err != nil {
+		t.Errorf("Expected no sidecar, got %v, %v", ok, err)
+	}
+
+	// Test case 2: A sidecar from a newer version is ignored
+	if err := os.WriteFile(SidecarPath(markdownPath), []byte(`{"version": 99}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := ReadSidecar(markdownPath); ok || err != nil {
+		t.Errorf("Expected a newer sidecar to be ignored, got %v, %v", ok, err)
+	}
+
+	// Test case 3: A damaged sidecar is an error
+	if err := os.WriteFile(SidecarPath(markdownPath), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := ReadSidecar(markdownPath); ok || err == nil {
+		t.Errorf("Expected an error for a damaged sidecar, got %v, %v", ok, err)
+	}
+}
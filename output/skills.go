@@ -0,0 +1,353 @@
+package output
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// skillSpellings lists the spellings of skills that differ by more than case
+// and punctuation. Spellings that only differ in case, spacing, dots, or
+// dashes, such as "Node.js" and "NodeJS", are recognized without an entry.
+var skillSpellings = [][]string{
+	{"Go", "Golang"},
+	{"JavaScript", "JS", "ECMAScript"},
+	{"TypeScript", "TS"},
+	{"Node.js", "NodeJS"},
+	{"React", "React.js", "ReactJS"},
+	{"Vue.js", "Vue"},
+	{"Kubernetes", "K8s"},
+	{"PostgreSQL", "Postgres"},
+	{"MongoDB", "Mongo"},
+	{"C#", "CSharp", "C Sharp"},
+	{"C++", "CPP"},
+	{".NET", "dotnet"},
+	{"Amazon Web Services", "AWS"},
+	{"Google Cloud Platform", "GCP", "Google Cloud"},
+	{"Microsoft Azure", "Azure"},
+	{"Machine Learning", "ML"},
+}
+
+var (
+	// skillAliases maps the key of every spelling in skillSpellings to the key
+	// of the first spelling in its group
+	skillAliases = compileSkillAliases(skillSpellings)
+
+	// skillLabelRegex matches a category label at the start of a skills line,
+	// such as "**Languages:**" or "Tools:"
+	skillLabelRegex = regexp.MustCompile(`^(\*\*[^*]+:\*\*|\*\*[^*]+\*\*:|[A-Za-z][\w &/-]*:)\s*`)
+
+	// skillBulletRegex matches the list marker at the start of a skills line
+	skillBulletRegex = regexp.MustCompile(`^\s*(?:[-*+]|\d+\.)\s+`)
+
+	// protectedSpanRegex matches text that must not be rewritten: code spans,
+	// link targets, and URLs or email addresses, where a skill name may be
+	// part of a path such as github.com/jane/golang-tools
+	protectedSpanRegex = regexp.MustCompile("`[^`]*`" + `|\]\([^)]*\)|<[^>\s]+>|(?:https?://|www\.)\S+|[\w.+-]+@[\w-]+\.[\w.]+`)
+)
+
+// compileSkillAliases builds the lookup from each spelling's key to its group's key.
+func compileSkillAliases(groups [][]string) map[string]string {
+	aliases := make(map[string]string)
+	for _, group := range groups {
+		for _, spelling := range group {
+			aliases[skillKey(spelling)] = skillKey(group[0])
+		}
+	}
+	return aliases
+}
+
+// skillKey returns the key identifying a skill regardless of how it is
+// written: lowercase letters, digits, '+', and '#' only. Keys of known
+// alternative spellings are not resolved; see canonicalSkillKey.
+func skillKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// canonicalSkillKey returns the key of a skill, resolving known alternative
+// spellings such as "Golang" to the key of their group ("go").
+func canonicalSkillKey(name string) string {
+	key := skillKey(name)
+	if alias, ok := skillAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// maxSkillWords is the most words a Skills section item can have to be
+// treated as a skill; longer items are sentences and are left alone.
+const maxSkillWords = 4
+
+// skillItem is one skill in a Skills section line, such as "**Go** (5 years)".
+type skillItem struct {
+	text string // The item as written
+	name string // The skill name, without emphasis or details
+}
+
+// parseSkillLine splits a Skills section line into its prefix (list marker
+// and category label) and its comma-separated skills. Commas inside
+// parentheses, such as "(5 years, expert)", do not separate skills.
+func parseSkillLine(line string) (string, []skillItem) {
+	prefix := ""
+	rest := line
+	if loc := skillBulletRegex.FindStringIndex(rest); loc != nil {
+		prefix, rest = rest[:loc[1]], rest[loc[1]:]
+	}
+	if loc := skillLabelRegex.FindStringIndex(rest); loc != nil {
+		prefix, rest = prefix+rest[:loc[1]], rest[loc[1]:]
+	}
+
+	var items []skillItem
+	depth, start := 0, 0
+	add := func(end int) {
+		text := strings.TrimSpace(rest[start:end])
+		if text == "" {
+			return
+		}
+		name := text
+		if i := strings.Index(name, "("); i > 0 {
+			name = name[:i]
+		}
+		name = strings.TrimSpace(strings.Trim(strings.TrimSpace(name), "*_"))
+		items = append(items, skillItem{text: text, name: name})
+	}
+	for i, r := range rest {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				add(i)
+				start = i + 1
+			}
+		}
+	}
+	add(len(rest))
+	return prefix, items
+}
+
+// skillReplacer rewrites other spellings of a skill to the spelling used in
+// the Skills section.
+type skillReplacer struct {
+	pattern   *regexp.Regexp
+	canonical string
+}
+
+// newSkillReplacer builds the replacer for a skill written as canonical in
+// the Skills section. It matches the known alternative spellings of the
+// skill and spellings differing in case, spacing, dots, or dashes, along
+// with an alternative spelling in parentheses right after one, so
+// "Amazon Web Services (AWS)" becomes "AWS" rather than "AWS (AWS)".
+func newSkillReplacer(canonical string) skillReplacer {
+	key := canonicalSkillKey(canonical)
+	spellings := []string{canonical}
+	for _, group := range skillSpellings {
+		if skillKey(group[0]) == key {
+			spellings = append(spellings, group...)
+		}
+	}
+
+	// Longest spellings first so "Google Cloud Platform" wins over "Google Cloud"
+	sort.SliceStable(spellings, func(i, j int) bool { return len(spellings[i]) > len(spellings[j]) })
+
+	alternatives := make([]string, 0, len(spellings))
+	for _, spelling := range spellings {
+		if pattern := skillPattern(spelling); pattern != "" {
+			alternatives = append(alternatives, pattern)
+		}
+	}
+	group := "(?:" + strings.Join(alternatives, "|") + ")"
+	return skillReplacer{
+		pattern:   regexp.MustCompile(group + `(?:\s*\(` + group + `\))?`),
+		canonical: canonical,
+	}
+}
+
+// skillPattern converts one spelling into a regular expression fragment.
+// Runs of letters and digits may be separated by nothing or by a space, dot,
+// or dash, and spellings containing lowercase letters match
+// case-insensitively while all-caps acronyms such as "AWS" must match
+// exactly.
+func skillPattern(spelling string) string {
+	var runs []string
+	var run strings.Builder
+	for _, r := range spelling {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' {
+			run.WriteString(regexp.QuoteMeta(string(r)))
+			continue
+		}
+		if run.Len() > 0 {
+			runs = append(runs, run.String())
+			run.Reset()
+		}
+	}
+	if run.Len() > 0 {
+		runs = append(runs, run.String())
+	}
+	if len(runs) == 0 {
+		return ""
+	}
+
+	pattern := strings.Join(runs, `[\s.\-]?`)
+	trimmed := []rune(strings.TrimLeft(spelling, "."))
+	if first := trimmed[0]; unicode.IsLetter(first) || unicode.IsDigit(first) {
+		pattern = `\b` + pattern
+	}
+	if strings.HasPrefix(spelling, ".") {
+		pattern = `\.?` + pattern
+	}
+	if last := trimmed[len(trimmed)-1]; unicode.IsLetter(last) || unicode.IsDigit(last) {
+		pattern += `\b`
+	}
+	if strings.ToUpper(spelling) != spelling {
+		return "(?i:" + pattern + ")"
+	}
+	return pattern
+}
+
+// replace rewrites every other spelling of the skill in text. Spellings that
+// only differ in case are left alone when written in lowercase, since words
+// like "go" or "react" are also ordinary words, and so are matches that are
+// part of a longer name such as "Node.JS" or "golang-tools".
+func (r skillReplacer) replace(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range r.pattern.FindAllStringIndex(text, -1) {
+		match := text[loc[0]:loc[1]]
+		switch {
+		case match == r.canonical:
+			continue
+		case skillKey(match) == skillKey(r.canonical) && match == strings.ToLower(match):
+			continue
+		case loc[0] > 0 && strings.ContainsRune("./_@-", rune(text[loc[0]-1])):
+			continue
+		case loc[1] < len(text) && strings.ContainsRune("/_-", rune(text[loc[1]])):
+			continue
+		}
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(r.canonical)
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// replaceOutsideProtected applies replace to the parts of line outside code
+// spans, link targets, URLs, and email addresses.
+func replaceOutsideProtected(line string, replace func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range protectedSpanRegex.FindAllStringIndex(line, -1) {
+		b.WriteString(replace(line[last:loc[0]]))
+		b.WriteString(line[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(replace(line[last:]))
+	return b.String()
+}
+
+// NormalizeSkills merges skills listed more than once and makes the rest of
+// the resume use the Skills section's spelling of each skill. A skill is
+// recognized however it is written: "Golang" is the same skill as "Go",
+// "K8s" as "Kubernetes", and "NodeJS" as "Node.js". Within sections whose
+// heading mentions Skills, only the first listing of each skill is kept;
+// elsewhere, such as in Experience bullets, other spellings are rewritten to
+// the first listing's. Headings, code spans, links, and URLs are never
+// changed, and a resume without a Skills section is returned unchanged.
+//
+// Parameters:
+//   - content: The Markdown resume
+//
+// Returns:
+//   - string: The resume with one spelling per skill and no repeated skills
+//
+// Example:
+//
+//	resume := output.NormalizeSkills("## Experience\n\n- Built Golang services on k8s\n\n## Skills\n\n- Go, Kubernetes, Golang")
+//	// "## Experience\n\n- Built Go services on Kubernetes\n\n## Skills\n\n- Go, Kubernetes"
+func NormalizeSkills(content string) string {
+	lines := strings.Split(content, "\n")
+
+	// Find the skills sections, keeping the first listing of each skill
+	inSkills := make([]bool, len(lines))
+	removed := make([]bool, len(lines))
+	seen := make(map[string]bool)
+	var canonical []string
+	skillsLevel := 0 // Heading level of the current Skills section, 0 outside one
+	for i, line := range lines {
+		if match := sectionHeadingRegex.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			level := len(match[1])
+			if skillsLevel > 0 && level <= skillsLevel {
+				skillsLevel = 0
+			}
+			if skillsLevel == 0 && level > 1 && strings.Contains(strings.ToLower(match[2]), "skill") {
+				skillsLevel = level
+			}
+			continue
+		}
+		if skillsLevel == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+		inSkills[i] = true
+
+		prefix, items := parseSkillLine(line)
+		kept := make([]string, 0, len(items))
+		for _, item := range items {
+			key := canonicalSkillKey(item.name)
+			if key == "" || len(strings.Fields(item.name)) > maxSkillWords {
+				kept = append(kept, item.text)
+				continue
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			canonical = append(canonical, item.name)
+			kept = append(kept, item.text)
+		}
+
+		// Lines are only rebuilt when a repeated skill was dropped
+		switch {
+		case len(kept) == 0 && len(items) > 0:
+			removed[i] = true
+		case len(kept) < len(items):
+			lines[i] = prefix + strings.Join(kept, ", ")
+		}
+	}
+	if len(canonical) == 0 {
+		return content
+	}
+
+	replacers := make([]skillReplacer, len(canonical))
+	for i, name := range canonical {
+		replacers[i] = newSkillReplacer(name)
+	}
+
+	normalized := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if removed[i] {
+			continue
+		}
+		if !inSkills[i] && !sectionHeadingRegex.MatchString(strings.TrimSpace(line)) {
+			line = replaceOutsideProtected(line, func(text string) string {
+				for _, r := range replacers {
+					text = r.replace(text)
+				}
+				return text
+			})
+		}
+		normalized = append(normalized, line)
+	}
+	return strings.Join(normalized, "\n")
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestNormalizeSkills(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "experience uses the skills spelling",
+			content:  "## Experience\n\n- Built Golang services on k8s with NodeJS and Postgres\n\n## Skills\n\n- Go, Kubernetes, Node.js, PostgreSQL",
+			expected: "## Experience\n\n- Built Go services on Kubernetes with Node.js and PostgreSQL\n\n## Skills\n\n- Go, Kubernetes, Node.js, PostgreSQL",
+		},
+		{
+			name:     "repeated skills are merged",
+			content:  "## Skills\n\n- **Languages:** Go, TypeScript\n- **Backend:** Golang, gRPC, TS\n- **Golang** (3 years, expert)",
+			expected: "## Skills\n\n- **Languages:** Go, TypeScript\n- **Backend:** gRPC",
+		},
+		{
+			name:     "details in parentheses stay with their skill",
+			content:  "## Technical Skills\n\n- **Kubernetes** (4 years, advanced), **K8s** (2 years)",
+			expected: "## Technical Skills\n\n- **Kubernetes** (4 years, advanced)",
+		},
+		{
+			name:     "acronym in parentheses after the full name",
+			content:  "## Experience\n\n- Migrated to Amazon Web Services (AWS)\n\n## Skills\n\nAWS, Terraform",
+			expected: "## Experience\n\n- Migrated to AWS\n\n## Skills\n\nAWS, Terraform",
+		},
+		{
+			name:     "case differences are fixed unless lowercase",
+			content:  "## Summary\n\nJavascript engineer who will go far and react quickly.\n\n## Skills\n\n- JavaScript, Go, React",
+			expected: "## Summary\n\nJavaScript engineer who will go far and react quickly.\n\n## Skills\n\n- JavaScript, Go, React",
+		},
+		{
+			name:     "links, code, and headings are unchanged",
+			content:  "# Golang Jane\n\n[golang-tools](https://github.com/jane/golang-tools) and `golang.org/x/tools`\n\n## Skills\n\n- Go",
+			expected: "# Golang Jane\n\n[golang-tools](https://github.com/jane/golang-tools) and `golang.org/x/tools`\n\n## Skills\n\n- Go",
+		},
+		{
+			name:     "sentences in the skills section are left alone",
+			content:  "## Skills\n\nExperienced in building distributed systems with Go.\n- Go",
+			expected: "## Skills\n\nExperienced in building distributed systems with Go.\n- Go",
+		},
+		{
+			name:     "no skills section",
+			content:  "## Experience\n\n- Built Golang services",
+			expected: "## Experience\n\n- Built Golang services",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeSkills(tt.content); got != tt.expected {
+				t.Errorf("NormalizeSkills() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanonicalSkillKey(t *testing.T) {
+	for _, names := range [][]string{
+		{"Node.js", "NodeJS", "node js"},
+		{"Go", "Golang", "GO"},
+		{"C#", "C Sharp", "CSharp"},
+		{"Google Cloud Platform", "GCP", "Google Cloud"},
+	} {
+		for _, name := range names[1:] {
+			if got, want := canonicalSkillKey(name), canonicalSkillKey(names[0]); got != want {
+				t.Errorf("canonicalSkillKey(%q) = %q, want %q", name, got, want)
+			}
+		}
+	}
+	if canonicalSkillKey("C++") == canonicalSkillKey("C#") {
+		t.Error("C++ and C# have the same key")
+	}
+}
@@ -0,0 +1,170 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// heading is a heading line of a document, with its level and text.
+type heading struct {
+	line  int
+	level int
+	text  string
+}
+
+// documentHeadings returns the ATX headings of a document in order,
+// skipping lines inside fenced code blocks.
+func documentHeadings(lines []string) []heading {
+	var headings []heading
+	fenced := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+		if match := sectionHeadingRegex.FindStringSubmatch(trimmed); match != nil {
+			headings = append(headings, heading{line: i, level: len(match[1]), text: match[2]})
+		}
+	}
+	return headings
+}
+
+// FixStructure repairs the heading hierarchy of a resume: the first
+// level-one heading stays the title, later level-one headings become
+// sections below it, and headings that skip a level, such as a "###" right
+// below the title, are raised to the level after their parent's. Sections
+// below a moved heading move with it, so the outline keeps its shape. The
+// text of the document is otherwise left as it is.
+//
+// Parameters:
+//   - content: The Markdown document
+//
+// Returns:
+//   - string: The document with its headings at consistent levels
+//
+// Example:
+//
+//	fixed := output.FixStructure("# Jane Doe\n\n# Experience\n\n### Acme")
+//	// fixed == "# Jane Doe\n\n## Experience\n\n### Acme"
+func FixStructure(content string) string {
+	lines := strings.Split(content, "\n")
+
+	// Each open heading, by the level it was written at and the level it is moved to
+	type open struct{ written, level int }
+	stack := []open{{0, 0}}
+	titled := false
+	changed := false
+	for _, h := range documentHeadings(lines) {
+		var level int
+		if h.level == 1 && !titled {
+			titled = true
+			level = 1
+			stack = []open{{0, 0}, {1, 1}}
+		} else {
+			for len(stack) > 1 && stack[len(stack)-1].written >= h.level {
+				stack = stack[:len(stack)-1]
+			}
+			// A section is never raised to a title, even without one above it
+			level = min(max(stack[len(stack)-1].level+1, 2), 6)
+			stack = append(stack, open{h.level, level})
+		}
+
+		if level != h.level {
+			line := lines[h.line]
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			lines[h.line] = indent + strings.Repeat("#", level) + strings.TrimLeft(line, " \t")[h.level:]
+			changed = true
+		}
+	}
+
+	if !changed {
+		return content
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateStructure checks the heading hierarchy of a resume and that it
+// has the required sections. A resume has one level-one heading, the
+// candidate's name, and its headings go down one level at a time. The
+// problems FixStructure repairs are reported too, so run it first to be
+// left with those it cannot: a missing title and missing sections.
+//
+// Parameters:
+//   - content: The Markdown document
+//   - required: Keywords of the sections the resume must have, such as "Experience"
+//
+// Returns:
+//   - []string: A description of each problem, in reading order, or nil if there are none
+//
+// Example:
+//
+//	problems := output.ValidateStructure(resume, []string{"Experience", "Skills"})
+//	// problems == []string{"There is no Skills section."}
+func ValidateStructure(content string, required []string) []string {
+	headings := documentHeadings(strings.Split(content, "\n"))
+
+	var problems []string
+	titles := 0
+	previous := heading{level: 1}
+	for _, h := range headings {
+		if h.level == 1 {
+			titles++
+			if titles == 2 {
+				problems = append(problems, fmt.Sprintf("%q is a second level-one heading; a resume has one, the candidate's name.", h.text))
+			}
+		} else if h.level > previous.level+1 {
+			if previous.text == "" {
+				problems = append(problems, fmt.Sprintf("%q skips a heading level.", h.text))
+			} else {
+				problems = append(problems, fmt.Sprintf("%q skips a heading level after %q.", h.text, previous.text))
+			}
+		}
+		previous = h
+	}
+	if titles == 0 {
+		problems = append([]string{"There is no level-one heading with the candidate's name."}, problems...)
+	}
+
+	for _, section := range MissingSections(content, required) {
+		problems = append(problems, fmt.Sprintf("There is no %s section.", section))
+	}
+	return problems
+}
+
+// MissingSections returns the required sections a resume does not have. A
+// section is present when a heading below the title contains its keyword
+// (case-insensitive, ignoring a plural "s"), so "Work Experience" counts as
+// "Experience".
+//
+// Parameters:
+//   - content: The Markdown document
+//   - required: Keywords of the sections the resume must have, such as "Skills"
+//
+// Returns:
+//   - []string: The keywords with no matching section, in the order given
+func MissingSections(content string, required []string) []string {
+	headings := documentHeadings(strings.Split(content, "\n"))
+
+	var missing []string
+	for _, section := range required {
+		keyword := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(section)), "s")
+		if keyword == "" {
+			continue
+		}
+		found := false
+		for _, h := range headings {
+			if h.level > 1 && strings.Contains(strings.ToLower(h.text), keyword) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, section)
+		}
+	}
+	return missing
+}
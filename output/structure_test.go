@@ -0,0 +1,91 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFixStructure(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "well-formed resume is unchanged",
+			content:  "# Jane\n\n## Experience\n\n### Acme\n\n- Built things\n\n## Skills\n\n- Go",
+			expected: "# Jane\n\n## Experience\n\n### Acme\n\n- Built things\n\n## Skills\n\n- Go",
+		},
+		{
+			name:     "later level-one headings become sections with their subsections",
+			content:  "# Jane\n\n# Experience\n\n## Acme\n\n# Skills\n\n- Go",
+			expected: "# Jane\n\n## Experience\n\n### Acme\n\n## Skills\n\n- Go",
+		},
+		{
+			name:     "skipped levels are raised",
+			content:  "# Jane\n\n### Experience\n\n##### Acme\n\n### Skills",
+			expected: "# Jane\n\n## Experience\n\n### Acme\n\n## Skills",
+		},
+		{
+			name:     "sections are not raised to a title",
+			content:  "Jane Doe\n\n### Experience\n\n## Skills",
+			expected: "Jane Doe\n\n## Experience\n\n## Skills",
+		},
+		{
+			name:     "headings in code blocks are left alone",
+			content:  "# Jane\n\n```\n# comment\n```\n\n## Skills",
+			expected: "# Jane\n\n```\n# comment\n```\n\n## Skills",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FixStructure(tt.content); got != tt.expected {
+				t.Errorf("FixStructure() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateStructure(t *testing.T) {
+	required := []string{"Experience", "Education", "Skills"}
+
+	// Test case 1: A resume with a title and every section has no problems
+	content := "# Jane\n\n## Work Experience\n\n### Acme\n\n## Education\n\n## Technical Skills"
+	if problems := ValidateStructure(content, required); problems != nil {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+
+	// Test case 2: Each problem is described in reading order
+	content = "## Summary\n\n#### Acme\n\n# Jane\n\n# Experience"
+	want := []string{
+		`"Acme" skips a heading level after "Summary".`,
+		`"Experience" is a second level-one heading; a resume has one, the candidate's name.`,
+		"There is no Experience section.",
+		"There is no Education section.",
+		"There is no Skills section.",
+	}
+	if problems := ValidateStructure(content, required); !reflect.DeepEqual(problems, want) {
+		t.Errorf("ValidateStructure() = %q, want %q", problems, want)
+	}
+
+	// Test case 3: Fixing the headings leaves only what cannot be fixed
+	want = []string{"There is no level-one heading with the candidate's name.", "There is no Education section."}
+	if problems := ValidateStructure(FixStructure("## Experience\n\n#### Acme\n\n## Skills"), required); !reflect.DeepEqual(problems, want) {
+		t.Errorf("ValidateStructure() = %q, want %q", problems, want)
+	}
+}
+
+func TestMissingSections(t *testing.T) {
+	content := "# Jane\n\n## Projects\n\n## Skills"
+
+	// Test case 1: Plurals and the title do not count as sections
+	if got := MissingSections(content, []string{"Project", "Jane", "Skills"}); !reflect.DeepEqual(got, []string{"Jane"}) {
+		t.Errorf("Expected only the title to be missing, got %v", got)
+	}
+
+	// Test case 2: No required sections means nothing is missing
+	if got := MissingSections(content, nil); got != nil {
+		t.Errorf("Expected nothing missing, got %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// OutputTargets are the files a single generation run writes. Every file is
+// named after the Markdown resume, which is always written because the other
+// formats are converted from it.
+type OutputTargets struct {
+	MarkdownPath string         // Where the Markdown resume is written (empty for the default)
+	HTML         bool           // Also write an HTML resume (see HTMLPath)
+	JSON         bool           // Also write a JSON Resume (see JSONResumePath)
+	Exports      []ExportFormat // Document formats to convert the resume to (see ExportPath)
+}
+
+// targetFormats lists the formats ParseOutputTargets accepts, in the order
+// they are listed in errors.
+var targetFormats = []string{"md", "html", "json", "docx", "pdf", "odt"}
+
+// errStdoutFormats is returned when StdoutPath is combined with other formats.
+var errStdoutFormats = fmt.Errorf("-output %s writes only the Markdown resume to standard output, so it cannot be combined with other output formats", StdoutPath)
+
+// ParseOutputTargets combines the -output paths and the -formats list into
+// the files to write. Each path's extension selects its format; a path with
+// any other extension (such as .md or .txt) is the Markdown resume. The
+// formats are written next to the Markdown resume. StdoutPath writes the
+// Markdown resume to standard output, and has no name to give other files.
+//
+// Parameters:
+//   - paths: The output paths, such as "out.md" and "out.pdf"
+//   - formats: The comma-separated formats, such as "md,pdf,html" (case-insensitive)
+//
+// Returns:
+//   - OutputTargets: The files to write
+//   - error: An error if a format is unknown, if the paths do not share a
+//     name, if more than one path is a Markdown resume, or if StdoutPath is
+//     combined with other formats
+//
+// Example:
+//
+//	targets, err := output.ParseOutputTargets([]string{"out.md", "out.pdf"}, "html")
+func ParseOutputTargets(paths []string, formats string) (OutputTargets, error) {
+	var targets OutputTargets
+	base := ""
+	for _, path := range paths {
+		if path == StdoutPath && len(paths) > 1 {
+			return OutputTargets{}, errStdoutFormats
+		}
+		stem := strings.TrimSuffix(path, filepath.Ext(path))
+		if base != "" && stem != base {
+			return OutputTargets{}, fmt.Errorf("output paths %s and %s must share a name, such as %s and %s",
+				paths[0], path, paths[0], base+filepath.Ext(path))
+		}
+		base = stem
+
+		format := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+		if !targets.add(format) {
+			if targets.MarkdownPath != "" {
+				return OutputTargets{}, fmt.Errorf("more than one Markdown output path: %s and %s", targets.MarkdownPath, path)
+			}
+			targets.MarkdownPath = path
+		}
+	}
+
+	for _, name := range strings.Split(formats, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || name == "md" || name == "markdown" {
+			continue
+		}
+		if !targets.add(name) {
+			return OutputTargets{}, fmt.Errorf("unknown output format %q (choose from %s)", name, strings.Join(targetFormats, ", "))
+		}
+	}
+
+	if targets.MarkdownPath == StdoutPath && (targets.HTML || targets.JSON || len(targets.Exports) > 0) {
+		return OutputTargets{}, errStdoutFormats
+	}
+
+	// Only other formats were named, so the Markdown resume shares their name
+	if targets.MarkdownPath == "" && base != "" {
+		targets.MarkdownPath = base + filepath.Ext(DefaultOutputPath)
+	}
+	return targets, nil
+}
+
+// add selects the non-Markdown format with the given name and reports
+// whether the name was one. Formats selected twice are written once.
+func (t *OutputTargets) add(name string) bool {
+	switch name {
+	case "html":
+		t.HTML = true
+	case "json":
+		t.JSON = true
+	default:
+		format := ExportFormat(name)
+		if !isExportFormat(format) {
+			return false
+		}
+		for _, f := range t.Exports {
+			if f == format {
+				return true
+			}
+		}
+		t.Exports = append(t.Exports, format)
+	}
+	return true
+}
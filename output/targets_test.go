@@ -0,0 +1,97 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOutputTargets(t *testing.T) {
+	testCases := []struct {
+		name    string
+		paths   []string
+		formats string
+		want    OutputTargets
+		wantErr string
+	}{
+		{
+			name: "No flags",
+			want: OutputTargets{},
+		},
+		{
+			name:  "Single Markdown path",
+			paths: []string{"notes/resume.txt"},
+			want:  OutputTargets{MarkdownPath: "notes/resume.txt"},
+		},
+		{
+			name:  "Repeated output paths",
+			paths: []string{"out.md", "out.pdf", "out.HTML", "out.json"},
+			want:  OutputTargets{MarkdownPath: "out.md", HTML: true, JSON: true, Exports: []ExportFormat{FormatPDF}},
+		},
+		{
+			name:  "Only converted formats",
+			paths: []string{"out/cv.docx"},
+			want:  OutputTargets{MarkdownPath: "out/cv.md", Exports: []ExportFormat{FormatDOCX}},
+		},
+		{
+			name:    "Formats next to the default path",
+			formats: "md, PDF,html,pdf",
+			want:    OutputTargets{HTML: true, Exports: []ExportFormat{FormatPDF}},
+		},
+		{
+			name:    "Formats and paths combined",
+			paths:   []string{"out.md", "out.odt"},
+			formats: "odt,docx",
+			want:    OutputTargets{MarkdownPath: "out.md", Exports: []ExportFormat{FormatODT, FormatDOCX}},
+		},
+		{
+			name:    "Paths with different names",
+			paths:   []string{"out.md", "cv.pdf"},
+			wantErr: "such as out.md and out.pdf",
+		},
+		{
+			name:    "Two Markdown paths",
+			paths:   []string{"out.md", "out.txt"},
+			wantErr: "more than one Markdown output path",
+		},
+		{
+			name:  "Standard output",
+			paths: []string{"-"},
+			want:  OutputTargets{MarkdownPath: StdoutPath},
+		},
+		{
+			name:    "Standard output with another path",
+			paths:   []string{"-", "out.pdf"},
+			wantErr: "writes only the Markdown resume to standard output",
+		},
+		{
+			name:    "Standard output with formats",
+			paths:   []string{"-"},
+			formats: "html",
+			wantErr: "writes only the Markdown resume to standard output",
+		},
+		{
+			name:    "Unknown format",
+			formats: "md,rtf",
+			wantErr: "md, html, json, docx, pdf, odt",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			targets, err := ParseOutputTargets(tc.paths, tc.formats)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOutputTargets() error = %v", err)
+			}
+			if !reflect.DeepEqual(targets, tc.want) {
+				t.Errorf("ParseOutputTargets() = %+v, want %+v", targets, tc.want)
+			}
+		})
+	}
+}
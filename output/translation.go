@@ -0,0 +1,30 @@
+package output
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// TranslationPath returns the path of a translation written next to a
+// Markdown resume: the same name followed by the language, lowercased with
+// anything but letters and digits replaced by hyphens, before a .md
+// extension.
+//
+// Parameters:
+//   - markdownPath: The path of the Markdown resume
+//   - language: The language of the translation, such as "German" or "pt-BR"
+//
+// Returns:
+//   - string: The translation path
+//
+// Example:
+//
+//	path := output.TranslationPath("Jane_Doe_Resume.md", "pt-BR")
+//	// path == "Jane_Doe_Resume_pt-br.md"
+func TranslationPath(markdownPath, language string) string {
+	slug := strings.Join(strings.FieldsFunc(strings.ToLower(language), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + "_" + slug + ".md"
+}
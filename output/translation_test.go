@@ -0,0 +1,22 @@
+package output
+
+import "testing"
+
+func TestTranslationPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		language string
+		want     string
+	}{
+		{"Jane_Doe_Resume.md", "German", "Jane_Doe_Resume_german.md"},
+		{"resumes/cv.txt", "pt-BR", "resumes/cv_pt-br.md"},
+		{"resume.md", " Brazilian  Portuguese ", "resume_brazilian-portuguese.md"},
+		{"resume.md", "Español", "resume_español.md"},
+	}
+
+	for _, tt := range tests {
+		if got := TranslationPath(tt.path, tt.language); got != tt.want {
+			t.Errorf("TranslationPath(%q, %q) = %q, want %q", tt.path, tt.language, got, tt.want)
+		}
+	}
+}
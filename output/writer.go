@@ -2,17 +2,86 @@ package output
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 // DefaultOutputPath defines the default path for writing the generated resume.
 // This path is used when the user doesn't specify an output path via command-line flags.
 var DefaultOutputPath = "resume_out.md"
 
+// StdoutPath is the -output path that writes the Markdown resume to standard
+// output instead of a file, for piping into tools such as glow or pandoc.
+const StdoutPath = "-"
+
+// Stdout receives resumes written to StdoutPath. The TUI draws on standard
+// error and replaces Stdout with a buffer while it runs, so the resume is
+// written after the screen is restored rather than over it.
+var Stdout io.Writer = os.Stdout
+
+// DefaultFileMode is the permission mode of written files unless -output-mode
+// chooses another. Resumes hold personal data, so only the owner can read them.
+const DefaultFileMode os.FileMode = 0600
+
+// FileMode is the permission mode WriteToFile gives new files, before the
+// umask is applied. Directories it creates get the matching search bits.
+var FileMode = DefaultFileMode
+
+// ParseFileMode parses an octal permission mode such as "0600" or "640".
+// The owner must be able to read and write the file, so later runs can
+// overwrite it.
+//
+// Parameters:
+//   - value: The mode in octal, with or without a leading zero
+//
+// Returns:
+//   - os.FileMode: The parsed mode
+//   - error: An error if the value is not an octal mode the owner can read and write
+//
+// Example:
+//
+//	mode, err := output.ParseFileMode("0640")
+//	// mode == 0640
+func ParseFileMode(value string) (os.FileMode, error) {
+	parsed, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || parsed > 0777 {
+		return 0, fmt.Errorf("invalid file mode %q: use an octal mode such as 0600 or 0644", value)
+	}
+	mode := os.FileMode(parsed)
+	if mode&0600 != 0600 {
+		return 0, fmt.Errorf("invalid file mode %q: the owner must be able to read and write the file", value)
+	}
+	return mode, nil
+}
+
+// dirMode returns the mode of directories created for files with the given
+// mode: whoever can read the files can also list the directory.
+func dirMode(mode os.FileMode) os.FileMode {
+	return mode | (mode&0444)>>2
+}
+
+// restrictMode removes any permission bits FileMode does not grant from an
+// existing file, such as one written by an older version or by pandoc. Bits
+// are only ever removed, so a stricter umask or manual chmod is kept.
+func restrictMode(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if perm := info.Mode().Perm(); perm&^FileMode != 0 {
+		return os.Chmod(path, perm&FileMode)
+	}
+	return nil
+}
+
 // WriteToFile writes content to a file at the specified path.
 // It creates the file if it doesn't exist or overwrites it if it does.
 // This function also ensures the target directory exists, creating it if necessary.
+// New files are created with FileMode, less the umask; an existing file
+// loses any permission bits FileMode does not grant.
 //
 // Parameters:
 //   - path: The absolute or relative path where the file should be written
@@ -35,11 +104,16 @@ func WriteToFile(path string, content string) error {
 	}
 	
 	// Write the content to the file
-	err := os.WriteFile(path, []byte(content), 0644)
+	err := os.WriteFile(path, []byte(content), FileMode)
 	if err != nil {
 		return fmt.Errorf("failed to write to file: %w", err)
 	}
 	
+	// Overwriting keeps the old mode, which may be more permissive
+	if err := restrictMode(path); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	
 	return nil
 }
 
@@ -62,7 +136,7 @@ func ensureDirectoryExists(dirPath string) error {
 	}
 	
 	// Create the directory and any necessary parents
-	err = os.MkdirAll(dirPath, 0755)
+	err = os.MkdirAll(dirPath, dirMode(FileMode))
 	if err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
@@ -72,11 +146,12 @@ func ensureDirectoryExists(dirPath string) error {
 
 // WriteOutput writes content to the output file, handling path selection logic.
 // It's a higher-level function that decides which path to use (provided or default)
-// and then calls WriteToFile to perform the actual writing.
+// and then calls WriteToFile to perform the actual writing. StdoutPath writes
+// the content to Stdout instead. Either way its lines end with LineEnding.
 //
 // Parameters:
 //   - content: The string content to write to the file
-//   - outputPath: The path where the file should be written, or empty to use default
+//   - outputPath: The path where the file should be written, empty to use default, or StdoutPath
 //
 // Returns:
 //   - string: The actual path where the content was written (useful for reporting)
@@ -95,6 +170,20 @@ func WriteOutput(content string, outputPath string) (string, error) {
 		outputPath = DefaultOutputPath
 	}
 	
+	// Lines end with the -line-endings choice, in a file or piped
+	content = NormalizeLineEndings(content)
+	
+	// Piped output ends with a newline, as other command-line tools' does
+	if outputPath == StdoutPath {
+		if !strings.HasSuffix(content, "\n") {
+			content += LineEnding
+		}
+		if _, err := io.WriteString(Stdout, content); err != nil {
+			return "", fmt.Errorf("failed to write output: %w", err)
+		}
+		return StdoutPath, nil
+	}
+	
 	// Write the content to the file
 	err := WriteToFile(outputPath, content)
 	if err != nil {
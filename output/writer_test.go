@@ -3,6 +3,7 @@ package output
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -164,6 +165,24 @@ func TestWriteOutput(t *testing.T) {
 	}
 }
 
+func TestWriteOutputStdout(t *testing.T) {
+	var buf strings.Builder
+	origStdout := Stdout
+	Stdout = &buf
+	defer func() { Stdout = origStdout }()
+	
+	path, err := WriteOutput("# Test Resume", StdoutPath)
+	if err != nil || path != StdoutPath {
+		t.Fatalf("WriteOutput() = %q, %v; want %q, nil", path, err, StdoutPath)
+	}
+	if buf.String() != "# Test Resume\n" {
+		t.Errorf("Expected the resume and a newline on standard output, got %q", buf.String())
+	}
+	if _, err := os.Stat(StdoutPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no file named %q, got %v", StdoutPath, err)
+	}
+}
+
 func TestEnsureDirectoryExists(t *testing.T) {
 	tempDir, cleanup := setupTestEnvironment(t)
 	defer cleanup()
@@ -212,4 +231,89 @@ func TestEnsureDirectoryExists(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestParseFileMode(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected os.FileMode
+		wantErr  bool
+	}{
+		{"0600", 0600, false},
+		{"640", 0640, false},
+		{"0644", 0644, false},
+		{"0400", 0, true}, // The owner could not overwrite it
+		{"0888", 0, true},
+		{"01777", 0, true},
+		{"rw-------", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseFileMode(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.expected {
+				t.Errorf("ParseFileMode(%q) = %o, %v; want %o (error %v)", tt.value, got, err, tt.expected, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteToFilePermissions(t *testing.T) {
+	tempDir := t.TempDir()
+	original := FileMode
+	defer func() { FileMode = original }()
+
+	perm := func(path string) os.FileMode {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", path, err)
+		}
+		return info.Mode().Perm()
+	}
+
+	// Test case 1: New files and directories are private by default
+	path := filepath.Join(tempDir, "private", "resume.md")
+	if err := WriteToFile(path, "# Jane Doe"); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	if got := perm(path); got != 0600 {
+		t.Errorf("Expected a new file to be 0600, got %o", got)
+	}
+	if got := perm(filepath.Dir(path)); got&^0700 != 0 {
+		t.Errorf("Expected a new directory to be private, got %o", got)
+	}
+
+	// Test case 2: Overwriting a world-readable file makes it private
+	existing := filepath.Join(tempDir, "existing.md")
+	if err := os.WriteFile(existing, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(existing, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteToFile(existing, "# Jane Doe"); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	if got := perm(existing); got != 0600 {
+		t.Errorf("Expected the overwritten file to be 0600, got %o", got)
+	}
+
+	// Test case 3: A wider mode never grants more than it allows, and the
+	// umask still applies
+	FileMode = 0640
+	shared := filepath.Join(tempDir, "shared.md")
+	if err := WriteToFile(shared, "# Jane Doe"); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	if got := perm(shared); got&^0640 != 0 || got&0600 != 0600 {
+		t.Errorf("Expected at most 0640 with owner read and write, got %o", got)
+	}
+
+	// Test case 4: Existing files are never made more permissive
+	if err := WriteToFile(existing, "# Jane Doe"); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	if got := perm(existing); got != 0600 {
+		t.Errorf("Expected the file to stay 0600, got %o", got)
+	}
+}
@@ -0,0 +1,366 @@
+// Package resume generates resumes programmatically, for programs that use
+// resumake as a library instead of running its terminal interface.
+//
+// Generate runs the same pipeline as the TUI: it fits the inputs to the
+// model's context window, sends the prompt, continues a response cut off at
+// the token limit, and cleans up the Markdown. It returns the resume instead
+// of writing it, so callers decide where it goes. The steps it is built from
+// are exported for callers, such as the TUI, that add their own around them.
+package resume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/analysis"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/faults"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// TruncatedWarning is the note returned when a response stopped at the
+// token limit and could not be completed.
+const TruncatedWarning = "Warning: Response was truncated due to token limit"
+
+// Inputs are what a resume is generated from, and how.
+type Inputs struct {
+	Source        string             // An existing resume to improve (empty to write one from the notes)
+	Notes         string             // Details about the candidate's experience and skills
+	Skills        []document.Skill   // Structured skills, written as the resume's Skills section
+	Academics     document.Academics // GPA and coursework for the Education section
+	Preset        prompt.Preset      // The kind of candidate, which sets the tone and section order (empty for standard)
+	Audience      prompt.Audience    // Who reads the resume first, which sets what it emphasizes (empty for general)
+	Emphasize     []string           // Keywords to feature where the inputs support them
+	Grounding     prompt.Grounding   // Whether bullets must cite the input sentences they come from (empty for off)
+	Amend         bool               // The source is the previous resume and the notes are updates to it
+	Structure     *document.Resume   // The structured source from its sidecar, whose sections are kept (nil to let the model choose)
+	ProseWidth    int                // Write prose one sentence per line, wrapped at this column (0 to keep the model's lines)
+	Model         string             // The Gemini model to use (empty for api.DefaultModelName)
+	FallbackModel string             // Model to retry once with if the first one fails (empty to disable)
+	TrimOrder     []prompt.TrimStep  // Order to trim input that exceeds the context window (nil for the default)
+	Seed          *int64             // Sample deterministically with this seed and a temperature of 0 (nil for the model's usual sampling)
+	APIKey        string             // The Gemini API key (empty to read GEMINI_API_KEY); unused with Client or Sender
+	Client        *genai.Client      // An existing client to use (nil to create one for this call)
+	Sender        api.ChatSender     // Answers the prompts in place of the Gemini API, such as in tests (nil for the API)
+	Fixtures      api.Fixtures       // Record API responses to, or replay them from, disk fixtures
+}
+
+// Result is a generated resume.
+type Result struct {
+	Markdown        string   // The resume
+	Model           string   // The model that wrote it, which is the fallback if the first model failed
+	TruncatedMsg    string   // A warning when the response stopped at the token limit (empty if complete)
+	Trimmed         []string // Descriptions of input trimmed to fit the context window
+	MissingKeywords []string // Emphasized keywords the resume does not include
+	HeadingIssues   []string // Heading problems that could not be fixed, such as a missing required section
+	Uncited         []string // Bullets that cite none of the inputs in strict grounding, dropped with prompt.GroundingDrop
+}
+
+// Generate writes a resume from the inputs.
+//
+// Parameters:
+//   - ctx: The context for the API requests; cancelling it stops generation
+//   - in: The candidate's details and generation settings
+//
+// Returns:
+//   - Result: The resume and notes about how it was generated
+//   - error: An error if there are no inputs, the API key is missing, or the request fails
+//
+// Example:
+//
+//	result, err := resume.Generate(ctx, resume.Inputs{
+//	    Source: existing,
+//	    Notes:  "Led the payments team from 2021 to 2024",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result.Markdown)
+func Generate(ctx context.Context, in Inputs) (Result, error) {
+	if in.Source == "" && in.Notes == "" {
+		return Result{}, errors.New("no source resume or notes to generate from")
+	}
+	if in.Model == "" {
+		in.Model = api.DefaultModelName
+	}
+	if in.Seed != nil {
+		ctx = api.WithSeed(ctx, *in.Seed)
+	}
+
+	fitted, err := Fit(in)
+	if err != nil {
+		return Result{}, fmt.Errorf("error fitting prompt to the context window: %w", err)
+	}
+
+	session, fallback, closeClient, err := newSessions(ctx, in)
+	if err != nil {
+		return Result{}, err
+	}
+	defer closeClient()
+
+	source, notes, sentences := NumberInputs(fitted.SourceContent, fitted.StdinContent, in.Grounding)
+	content := promptContent(prompt.GeneratePromptContent(source, notes), in)
+	response, usedFallback, err := api.SendWithFallback(faults.WithStage(ctx, faults.StageResume), session, fallback, content)
+	if err != nil {
+		return Result{}, fmt.Errorf("error executing API request: %w", err)
+	}
+
+	result := Result{Model: in.Model, Trimmed: fitted.Trimmed}
+	if usedFallback {
+		result.Model = in.FallbackModel
+		session = fallback
+	}
+
+	markdown, err := output.ProcessResponseContent(response)
+	if err != nil {
+		if response == nil || len(response.Candidates) == 0 || response.Candidates[0].FinishReason != genai.FinishReasonMaxTokens {
+			return Result{}, fmt.Errorf("error processing API response: %w", err)
+		}
+		markdown, result.TruncatedMsg, err = CompleteTruncated(ctx, session, response, err)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	markdown, result.Uncited = CheckGrounding(markdown, sentences, in.Grounding)
+	result.Markdown = Polish(markdown, in)
+	result.MissingKeywords = analysis.MissingKeywords(result.Markdown, in.Emphasize)
+	result.HeadingIssues = output.ValidateStructure(result.Markdown, in.Preset.RequiredSections())
+	return result, nil
+}
+
+// newSessions starts the chat session with the model, and with the fallback
+// model when one is set, creating a client unless the inputs provide one.
+// The returned function closes a created client.
+func newSessions(ctx context.Context, in Inputs) (*api.Session, *api.Session, func(), error) {
+	closeClient := func() {}
+	replay := in.Fixtures.Mode == api.FixtureReplay
+
+	client := in.Client
+	if client == nil && !replay && in.Sender == nil {
+		apiKey := in.APIKey
+		if apiKey == "" {
+			var err error
+			if apiKey, err = api.GetAPIKey(); err != nil {
+				return nil, nil, closeClient, err
+			}
+		}
+		created, _, err := api.InitializeClientWithModel(ctx, apiKey, in.Model)
+		if err != nil {
+			return nil, nil, closeClient, fmt.Errorf("error initializing API client: %w", err)
+		}
+		client, closeClient = created, func() { created.Close() }
+	}
+
+	session := func(modelName string) (*api.Session, error) {
+		if in.Sender != nil {
+			return api.NewSessionWithSender(in.Fixtures.WrapSender(in.Sender, modelName)), nil
+		}
+		var model *genai.GenerativeModel
+		if !replay {
+			var err error
+			if model, err = api.NewResumeModel(client, modelName); err != nil {
+				return nil, err
+			}
+		}
+		return api.NewFixtureSession(model, modelName, in.Fixtures)
+	}
+
+	primary, err := session(in.Model)
+	if err != nil {
+		closeClient()
+		return nil, nil, func() {}, fmt.Errorf("error starting API session: %w", err)
+	}
+	var fallback *api.Session
+	if in.FallbackModel != "" && in.FallbackModel != in.Model {
+		if fallback, err = session(in.FallbackModel); err != nil {
+			closeClient()
+			return nil, nil, func() {}, fmt.Errorf("error preparing fallback model: %w", err)
+		}
+	}
+	return primary, fallback, closeClient, nil
+}
+
+// Fit trims the source resume and notes so the prompt and the longest
+// response fit the context window of the smaller of the two models.
+//
+// Parameters:
+//   - in: The inputs; all but the seed, API key, client, sender, and fixtures are used
+//
+// Returns:
+//   - prompt.FitResult: The trimmed source and notes, with what was trimmed
+//   - error: An error if the inputs cannot be made to fit
+func Fit(in Inputs) (prompt.FitResult, error) {
+	modelName := in.Model
+	if modelName == "" {
+		modelName = api.DefaultModelName
+	}
+	contextWindow := api.ContextWindow(modelName)
+	if in.FallbackModel != "" {
+		contextWindow = min(contextWindow, api.ContextWindow(in.FallbackModel))
+	}
+
+	reserved := api.SystemInstructions + "\n\n" + prompt.BuildSkillsSection(in.Skills) + "\n\n" + prompt.BuildEmphasisSection(in.Emphasize) +
+		"\n\n" + prompt.BuildAcademicsSection(in.Academics) + "\n\n" + prompt.BuildPresetSection(in.Preset) +
+		"\n\n" + prompt.BuildAudienceSection(in.Audience) + "\n\n" + prompt.BuildStructureSection(sourceHeadings(in))
+	if in.Amend {
+		reserved += "\n\n" + prompt.AmendInstructions
+	}
+
+	// Strict grounding adds its instructions and a number before each sentence
+	markers := 0
+	if in.Grounding.Strict() {
+		reserved += "\n\n" + prompt.GroundingInstructions
+		source, notes, _ := NumberInputs(in.Source, in.Notes, in.Grounding)
+		markers = prompt.EstimateTokens(source+notes) - prompt.EstimateTokens(in.Source+in.Notes)
+	}
+
+	budget := prompt.Budget{
+		ContextWindow:   contextWindow,
+		MaxOutputTokens: api.MaxOutputTokens,
+		ReservedTokens:  prompt.EstimateTokens(reserved) + markers,
+		Order:           in.TrimOrder,
+	}
+	return budget.Fit(in.Source, in.Notes)
+}
+
+// promptContent adds the skills, academics, emphasized keywords, preset,
+// audience, source structure, amendment, and grounding instructions of the
+// inputs to a prompt built from the source and notes.
+//
+// Parameters:
+//   - content: The prompt, such as one from prompt.GeneratePromptContent
+//   - in: The inputs; Skills, Academics, Emphasize, Preset, Audience, Structure, Amend, and Grounding are used
+//
+// Returns:
+//   - *genai.Content: The prompt to send
+func promptContent(content *genai.Content, in Inputs) *genai.Content {
+	content = prompt.AddSkillsToContent(content, in.Skills)
+	content = prompt.AddAcademicsToContent(content, in.Academics)
+	content = prompt.AddEmphasisToContent(content, in.Emphasize)
+	content = prompt.AddPresetToContent(content, in.Preset)
+	content = prompt.AddAudienceToContent(content, in.Audience)
+	content = prompt.AddStructureToContent(content, sourceHeadings(in))
+	if in.Amend {
+		content = prompt.AddAmendmentToContent(content)
+	}
+	return prompt.AddGroundingToContent(content, in.Grounding)
+}
+
+// NumberInputs numbers the sentences of the source and then the notes for
+// strict grounding, so the model can cite them. Without strict grounding
+// they are returned as they are.
+//
+// Parameters:
+//   - source: The source resume, as fitted to the context window
+//   - notes: The notes, as fitted to the context window
+//   - grounding: The grounding mode
+//
+// Returns:
+//   - string: The source, numbered from 1
+//   - string: The notes, numbered after the source
+//   - int: The number of sentences numbered, 0 without strict grounding
+func NumberInputs(source, notes string, grounding prompt.Grounding) (string, string, int) {
+	if !grounding.Strict() {
+		return source, notes, 0
+	}
+	source, inSource := prompt.NumberSentences(source, 1)
+	notes, inNotes := prompt.NumberSentences(notes, inSource+1)
+	return source, notes, inSource + inNotes
+}
+
+// CheckGrounding removes the citations from a resume generated in strict
+// grounding and finds the bullets that cite none of the input sentences,
+// leaving them out with prompt.GroundingDrop. Without strict grounding the
+// resume is returned as it is.
+//
+// Parameters:
+//   - markdown: The generated resume
+//   - sentences: The number of input sentences, as returned by NumberInputs
+//   - grounding: The grounding mode
+//
+// Returns:
+//   - string: The resume without citations
+//   - []string: The bullets that cite no input sentence
+func CheckGrounding(markdown string, sentences int, grounding prompt.Grounding) (string, []string) {
+	if !grounding.Strict() {
+		return markdown, nil
+	}
+	return output.CheckCitations(markdown, sentences, grounding == prompt.GroundingDrop)
+}
+
+// sourceHeadings returns the section headings of the structured source, or
+// nil when the source was not loaded from a sidecar.
+func sourceHeadings(in Inputs) []string {
+	if in.Structure == nil {
+		return nil
+	}
+	return in.Structure.Headings()
+}
+
+// CompleteTruncated asks the model to continue a response that stopped at
+// the token limit. If the continuation fails, or the text is still
+// incomplete afterwards, the partial resume is kept and TruncatedWarning is
+// returned with it.
+//
+// Parameters:
+//   - ctx: The context for the continuation requests
+//   - session: The session the response was received in
+//   - response: The truncated response
+//   - processErr: The error processing the response returned
+//
+// Returns:
+//   - string: The resume
+//   - string: TruncatedWarning if the resume is incomplete, or empty
+//   - error: An error if no resume could be recovered from the response
+func CompleteTruncated(ctx context.Context, session *api.Session, response *genai.GenerateContentResponse, processErr error) (string, string, error) {
+	fullContent, stillTruncated, err := session.ContinueTruncated(faults.WithStage(ctx, faults.StageContinuation), response)
+	if err == nil {
+		markdownContent, validateErr := output.ExtractAndValidateMarkdown(fullContent)
+		if validateErr == nil {
+			if stillTruncated {
+				return markdownContent, TruncatedWarning, nil
+			}
+			return markdownContent, "", nil
+		}
+	}
+
+	// Fall back to whatever the first response contained
+	partialContent, recoverErr := api.TryRecoverPartialContent(response)
+	if recoverErr != nil {
+		return "", "", fmt.Errorf("error processing API response: %w (recovery failed: %w)", processErr, recoverErr)
+	}
+
+	return output.ExtractFencedContent(partialContent), TruncatedWarning, nil
+}
+
+// Polish cleans up a generated resume: its headings are put at consistent
+// levels under a single title, structured skills replace the model's Skills
+// section, credentials use their canonical names, each skill is spelled the
+// same way everywhere and listed once, and the sections are put in the
+// preset's order, or else in the structured source's order. With a
+// ProseWidth, the prose is written one sentence per line for readable diffs.
+//
+// Parameters:
+//   - markdown: The generated resume
+//   - in: The inputs; Skills (nil to keep the model's), Preset, Structure, and ProseWidth are used
+//
+// Returns:
+//   - string: The cleaned-up resume
+func Polish(markdown string, in Inputs) string {
+	markdown = output.FixStructure(markdown)
+	if len(in.Skills) > 0 {
+		markdown = output.ReplaceSection(markdown, "Skills", document.Resume{Skills: in.Skills}.SkillsMarkdown())
+	}
+	markdown = output.NormalizeCredentials(markdown)
+	markdown = output.NormalizeSkills(markdown)
+	order := in.Preset.SectionOrder()
+	if order == nil {
+		order = sourceHeadings(in)
+	}
+	return output.FormatProse(output.ReorderSections(markdown, order), in.ProseWidth)
+}
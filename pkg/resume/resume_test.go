@@ -0,0 +1,228 @@
+package resume
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// fakeChatSender is an api.ChatSender that replays canned replies and
+// records the prompts it receives
+type fakeChatSender struct {
+	replies []*genai.GenerateContentResponse
+	err     error
+	prompts []string
+	seeds   []int64 // Seeds of the requests that were sent with one
+}
+
+// SendMessage returns the next canned reply or the configured error
+func (f *fakeChatSender) SendMessage(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	if seed, ok := api.SeedFromContext(ctx); ok {
+		f.seeds = append(f.seeds, seed)
+	}
+	for _, part := range parts {
+		if text, ok := part.(genai.Text); ok {
+			f.prompts = append(f.prompts, string(text))
+		}
+	}
+	if f.err != nil || len(f.replies) == 0 {
+		return nil, f.err
+	}
+	reply := f.replies[0]
+	f.replies = f.replies[1:]
+	return reply, nil
+}
+
+// textResponse returns a response with one candidate holding text
+func textResponse(text string, reason genai.FinishReason) *genai.GenerateContentResponse {
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			Content:      &genai.Content{Parts: []genai.Part{genai.Text(text)}},
+			FinishReason: reason,
+		}},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	reply := api.ResumeStartDelimiter + "\n# Jane Doe\n\n## Experience\n\n- Built Golang services\n\n## Skills\n\n- Golang, Go\n" + api.ResumeEndDelimiter
+
+	// Test case 1: The resume is generated from the notes and cleaned up
+	t.Run("Generates a resume", func(t *testing.T) {
+		sender := &fakeChatSender{replies: []*genai.GenerateContentResponse{textResponse(reply, genai.FinishReasonStop)}}
+
+		result, err := Generate(ctx, Inputs{
+			Notes:     "Led the payments team",
+			Skills:    []document.Skill{{Name: "Go", Years: 6}},
+			Emphasize: []string{"Go", "Rust"},
+			Audience:  prompt.AudienceATS,
+			Sender:    sender,
+		})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if !strings.HasPrefix(result.Markdown, "# Jane Doe") || strings.Contains(result.Markdown, api.ResumeEndDelimiter) {
+			t.Errorf("Expected the resume without delimiters, got %q", result.Markdown)
+		}
+		if !strings.Contains(result.Markdown, "## Skills\n\n- **Go** (6 years)") {
+			t.Errorf("Expected the structured skills to replace the Skills section, got %q", result.Markdown)
+		}
+		if result.Model != api.DefaultModelName {
+			t.Errorf("Expected model %q, got %q", api.DefaultModelName, result.Model)
+		}
+		if len(result.MissingKeywords) != 1 || result.MissingKeywords[0] != "Rust" {
+			t.Errorf("Expected Rust to be reported missing, got %v", result.MissingKeywords)
+		}
+		if len(result.HeadingIssues) != 1 || result.HeadingIssues[0] != "There is no Education section." {
+			t.Errorf("Expected the missing Education section to be reported, got %v", result.HeadingIssues)
+		}
+		if sent := strings.Join(sender.prompts, "\n"); !strings.Contains(sent, "Led the payments team") || !strings.Contains(sent, "Rust") {
+			t.Errorf("Expected the notes and emphasized keywords in the prompt, got %q", sent)
+		}
+		if sent := strings.Join(sender.prompts, "\n"); !strings.Contains(sent, "RESUME AUDIENCE: applicant tracking systems") {
+			t.Errorf("Expected the audience instructions in the prompt, got %q", sent)
+		}
+	})
+
+	// Test case 2: A failed first model is retried with the fallback
+	t.Run("Falls back to the second model", func(t *testing.T) {
+		failing := &fakeChatSender{err: errors.New("googleapi: Error 503: The model is overloaded")}
+		_, err := Generate(ctx, Inputs{Notes: "Led the payments team", Sender: failing, FallbackModel: "backup-model"})
+		if err == nil || !strings.Contains(err.Error(), "fallback model also failed") {
+			t.Errorf("Expected both models to be tried, got %v", err)
+		}
+	})
+
+	// Test case 3: A seed is sent with the request
+	t.Run("Sends the seed", func(t *testing.T) {
+		sender := &fakeChatSender{replies: []*genai.GenerateContentResponse{textResponse(reply, genai.FinishReasonStop)}}
+		seed := int64(42)
+		if _, err := Generate(ctx, Inputs{Notes: "Led the payments team", Sender: sender, Seed: &seed}); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if len(sender.seeds) != 1 || sender.seeds[0] != 42 {
+			t.Errorf("Expected the request to carry seed 42, got %v", sender.seeds)
+		}
+	})
+
+	// Test case 4: Without inputs there is nothing to generate from
+	t.Run("Requires inputs", func(t *testing.T) {
+		if _, err := Generate(ctx, Inputs{APIKey: "test-key"}); err == nil {
+			t.Error("Expected an error without a source or notes")
+		}
+	})
+
+	// Test case 5: Without a key or client the API key is required
+	t.Run("Requires an API key", func(t *testing.T) {
+		t.Setenv("GEMINI_API_KEY", "")
+		if _, err := Generate(ctx, Inputs{Notes: "Led the payments team"}); err == nil || !strings.Contains(err.Error(), "GEMINI_API_KEY") {
+			t.Errorf("Expected a missing API key error, got %v", err)
+		}
+	})
+
+	// Test case 6: Strict grounding numbers the inputs and drops uncited bullets
+	t.Run("Drops bullets that cite no input", func(t *testing.T) {
+		grounded := api.ResumeStartDelimiter + "\n# Jane Doe\n\n## Experience\n\n- Led the payments team [S2]\n- Won an award\n" + api.ResumeEndDelimiter
+		sender := &fakeChatSender{replies: []*genai.GenerateContentResponse{textResponse(grounded, genai.FinishReasonStop)}}
+
+		result, err := Generate(ctx, Inputs{Source: "Backend engineer.", Notes: "Led the payments team.", Grounding: prompt.GroundingDrop, Sender: sender})
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if sent := strings.Join(sender.prompts, "\n"); !strings.Contains(sent, "[S2] Led the payments team.") || !strings.Contains(sent, "STRICT GROUNDING") {
+			t.Errorf("Expected the numbered notes and grounding instructions in the prompt, got %q", sent)
+		}
+		if strings.Contains(result.Markdown, "award") || strings.Contains(result.Markdown, "[S2]") {
+			t.Errorf("Expected the uncited bullet dropped and the citation removed, got %q", result.Markdown)
+		}
+		if len(result.Uncited) != 1 || result.Uncited[0] != "Won an award" {
+			t.Errorf("Expected the uncited bullet reported, got %v", result.Uncited)
+		}
+	})
+}
+
+func TestPolish(t *testing.T) {
+	// Test case 1: Structured skills replace the model's Skills section
+	got := Polish("# Jane Doe\n\n## Skills\n\n- Golang", Inputs{Skills: []document.Skill{{Name: "Kubernetes"}}})
+	if !strings.Contains(got, "- **Kubernetes**") || strings.Contains(got, "Golang") {
+		t.Errorf("Expected the structured skills, got %q", got)
+	}
+
+	// Test case 2: Without structured skills, skill spellings are made consistent
+	got = Polish("# Jane Doe\n\n## Experience\n\n- Built Golang services\n\n## Skills\n\n- Go, Golang", Inputs{})
+	if strings.Contains(got, "Golang") {
+		t.Errorf("Expected Golang to be spelled Go, got %q", got)
+	}
+
+	// Test case 3: The new-grad preset leads with education and projects
+	got = Polish("# Jane Doe\n\n## Experience\n\n- Tutor\n\n## Projects\n\n- Compiler\n\n## Education\n\n- BS, State University", Inputs{Preset: prompt.PresetNewGrad})
+	if want := "# Jane Doe\n\n## Education\n\n- BS, State University\n\n## Projects\n\n- Compiler\n\n## Experience\n\n- Tutor"; got != want {
+		t.Errorf("Expected the new-grad section order, got %q", got)
+	}
+
+	// Test case 4: A structured source keeps its section order
+	structure := &document.Resume{Sections: []document.Section{{Heading: "Projects"}, {Heading: "Experience"}}}
+	got = Polish("# Jane Doe\n\n## Experience\n\n- Tutor\n\n## Projects\n\n- Compiler", Inputs{Structure: structure})
+	if want := "# Jane Doe\n\n## Projects\n\n- Compiler\n\n## Experience\n\n- Tutor"; got != want {
+		t.Errorf("Expected the source's section order, got %q", got)
+	}
+
+	// Test case 5: Headings are put under a single title
+	got = Polish("# Jane Doe\n\n# Experience\n\n### Tutor", Inputs{})
+	if want := "# Jane Doe\n\n## Experience\n\n### Tutor"; got != want {
+		t.Errorf("Expected the headings fixed, got %q", got)
+	}
+
+	// Test case 6: With a prose width, each sentence is on its own line
+	got = Polish("# Jane Doe\n\n## Summary\n\nBackend engineer. Loves Go.", Inputs{ProseWidth: 80})
+	if want := "# Jane Doe\n\n## Summary\n\nBackend engineer.\nLoves Go."; got != want {
+		t.Errorf("Expected one sentence per line, got %q", got)
+	}
+}
+
+// TestCompleteTruncated tests continuing truncated output on the same session
+func TestCompleteTruncated(t *testing.T) {
+	ctx := context.Background()
+	processErr := errors.New("response was truncated")
+	truncated := textResponse(api.ResumeStartDelimiter+"\n# Jane Doe\n\n## Exper", genai.FinishReasonMaxTokens)
+
+	// Test case 1: The continuation completes the resume
+	t.Run("Continuation completes the resume", func(t *testing.T) {
+		session := api.NewSessionWithSender(&fakeChatSender{replies: []*genai.GenerateContentResponse{
+			textResponse("ience\n\n- Go", genai.FinishReasonStop),
+		}})
+
+		content, truncatedMsg, err := CompleteTruncated(ctx, session, truncated, processErr)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if truncatedMsg != "" {
+			t.Errorf("Expected no truncation warning, got %q", truncatedMsg)
+		}
+		if !strings.Contains(content, "## Experience") || !strings.Contains(content, "- Go") {
+			t.Errorf("Expected the continued resume, got %q", content)
+		}
+	})
+
+	// Test case 2: A failed continuation keeps the partial content with a warning
+	t.Run("Continuation fails", func(t *testing.T) {
+		session := api.NewSessionWithSender(&fakeChatSender{err: errors.New("connection reset")})
+
+		content, truncatedMsg, err := CompleteTruncated(ctx, session, truncated, processErr)
+		if err != nil {
+			t.Fatalf("Expected partial content, got error %v", err)
+		}
+		if truncatedMsg != TruncatedWarning {
+			t.Errorf("Expected the truncation warning, got %q", truncatedMsg)
+		}
+		if !strings.Contains(content, "# Jane Doe") {
+			t.Errorf("Expected the partial resume, got %q", content)
+		}
+	})
+}
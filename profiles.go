@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/analysis"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// comparisonReportName is the file the comparison report is written to in
+// the -output-dir of compare-profiles.
+const comparisonReportName = "comparison.md"
+
+// profileResume is the resume generated for a profile, with the measures the
+// comparison report shows.
+type profileResume struct {
+	profile  input.Profile
+	markdown string
+	words    int
+	bullets  int
+	pages    int
+	headings []string
+	missing  []string // Keywords the resume does not mention
+}
+
+// runCompareProfiles generates a resume for each profile at once from the
+// same inputs and prints a report comparing them to w, writing the resumes
+// and the report to -output-dir when it is given. Profiles that fail are
+// reported and left out of the comparison. It calls the API directly,
+// without the TUI.
+func runCompareProfiles(ctx context.Context, flags input.CompareProfilesFlags, w io.Writer) error {
+	apiKey, err := api.GetAPIKey()
+	if err != nil {
+		return fmt.Errorf("API key error: %w", err)
+	}
+
+	client, _, err := api.InitializeClientWithModel(ctx, apiKey, flags.Model)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+	defer client.Close()
+
+	models := make([]api.ModelInterface, len(flags.Profiles))
+	contents := make([]*genai.Content, len(flags.Profiles))
+	labels := make([]string, len(flags.Profiles))
+	for i, profile := range flags.Profiles {
+		model, err := api.NewResumeModelWithInstructions(client, flags.Model, profile.Instructions)
+		if err != nil {
+			return err
+		}
+		models[i] = model
+		contents[i] = profileContent(flags, profile)
+		labels[i] = profile.Spec
+	}
+
+	for _, warning := range []string{flags.SourceWarning, flags.JobWarning} {
+		if warning != "" {
+			fmt.Fprintf(w, "Warning: %s\n", warning)
+		}
+	}
+	fmt.Fprintf(w, "Generating %d profiles (%s)...\n", len(flags.Profiles), strings.Join(labels, ", "))
+	results := generateAll(ctx, models, contents)
+
+	keywords := comparisonKeywords(flags)
+	var resumes []profileResume
+	var failures []error
+	for i, result := range results {
+		if result.err != nil {
+			failures = append(failures, fmt.Errorf("profile %s failed: %w", labels[i], result.err))
+			continue
+		}
+		resumes = append(resumes, measureProfile(flags.Profiles[i], result.markdown, keywords))
+	}
+	if len(resumes) == 0 {
+		return errors.Join(failures...)
+	}
+	for _, failure := range failures {
+		fmt.Fprintf(w, "Warning: %v\n", failure)
+	}
+
+	report := formatProfileComparison(resumes, keywords, flags.JobDescription != "")
+	fmt.Fprint(w, "\n"+report)
+
+	if flags.OutputDir == "" {
+		return nil
+	}
+	for _, resume := range resumes {
+		path := filepath.Join(flags.OutputDir, "resume_"+profileFileName(resume.profile.Spec)+".md")
+		if err := output.WriteToFile(path, resume.markdown); err != nil {
+			return fmt.Errorf("error writing the %s resume: %w", resume.profile.Spec, err)
+		}
+	}
+	reportPath := filepath.Join(flags.OutputDir, comparisonReportName)
+	if err := output.WriteToFile(reportPath, report); err != nil {
+		return fmt.Errorf("error writing the comparison report: %w", err)
+	}
+	fmt.Fprintf(w, "\nThe resumes and the report were written to %s\n", flags.OutputDir)
+	return nil
+}
+
+// profileContent builds the prompt for a profile: the same inputs for every
+// profile, with the profile's preset and audience sections.
+func profileContent(flags input.CompareProfilesFlags, profile input.Profile) *genai.Content {
+	content := prompt.GeneratePromptContent(flags.SourceContent, flags.StdinContent)
+	content = prompt.AddPresetToContent(content, profile.Preset)
+	return prompt.AddAudienceToContent(content, profile.Audience)
+}
+
+// comparisonKeywords returns the keywords each resume's coverage is measured
+// against: the top terms of the job description, or of the inputs without one.
+func comparisonKeywords(flags input.CompareProfilesFlags) []string {
+	text := flags.JobDescription
+	if strings.TrimSpace(text) == "" {
+		text = flags.SourceContent + "\n\n" + flags.StdinContent
+	}
+	var keywords []string
+	for _, term := range analysis.TopTerms(text, analysis.GapTerms) {
+		keywords = append(keywords, term.Term)
+	}
+	return keywords
+}
+
+// measureProfile measures the resume generated for a profile.
+func measureProfile(profile input.Profile, markdown string, keywords []string) profileResume {
+	resume := document.Parse(markdown)
+	measured := profileResume{
+		profile:  profile,
+		markdown: markdown,
+		words:    len(analysis.Words(markdown)),
+		pages:    len(output.EstimatePageBreaks(resume, output.LayoutStandard)) + 1,
+		headings: resume.Headings(),
+		missing:  analysis.MissingKeywords(markdown, keywords),
+	}
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
+			measured.bullets++
+		}
+	}
+	return measured
+}
+
+// formatProfileComparison renders the comparison report in Markdown: a
+// table of each resume's length and keyword coverage, the sections only
+// some of them have, the keywords each misses, and the strongest profile.
+func formatProfileComparison(resumes []profileResume, keywords []string, fromJob bool) string {
+	var b strings.Builder
+	b.WriteString("# Profile Comparison\n\n")
+	b.WriteString("| Profile | Words | Bullets | Pages | Keywords |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, resume := range resumes {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d/%d |\n", resume.profile.Spec, resume.words, resume.bullets, resume.pages,
+			len(keywords)-len(resume.missing), len(keywords))
+	}
+
+	b.WriteString("\n## Sections\n\n")
+	if differences := sectionDifferences(resumes); len(differences) == 0 {
+		b.WriteString("Every profile has the same sections.\n")
+	} else {
+		for _, difference := range differences {
+			b.WriteString("- " + difference + "\n")
+		}
+	}
+
+	b.WriteString("\n## Keywords\n\n")
+	source := "your inputs"
+	if fromJob {
+		source = "the job description"
+	}
+	fmt.Fprintf(&b, "Coverage of the top %d terms of %s.\n\n", len(keywords), source)
+	for _, resume := range resumes {
+		if len(resume.missing) == 0 {
+			fmt.Fprintf(&b, "- %s: mentions every keyword\n", resume.profile.Spec)
+		} else {
+			fmt.Fprintf(&b, "- %s: misses %s\n", resume.profile.Spec, strings.Join(resume.missing, ", "))
+		}
+	}
+
+	if len(resumes) > 1 {
+		best := strongestProfile(resumes)
+		pages := "pages"
+		if best.pages == 1 {
+			pages = "page"
+		}
+		fmt.Fprintf(&b, "\nStrongest: %s, which covers %d of %d keywords on %d %s.\n", best.profile.Spec,
+			len(keywords)-len(best.missing), len(keywords), best.pages, pages)
+	}
+	return b.String()
+}
+
+// sectionDifferences describes each section that only some resumes have,
+// in the order the sections first appear, such as "Projects: only in new-grad".
+func sectionDifferences(resumes []profileResume) []string {
+	var order []string
+	holders := make(map[string][]string)
+	names := make(map[string]string)
+	for _, resume := range resumes {
+		for _, heading := range resume.headings {
+			key := strings.ToLower(strings.TrimSpace(heading))
+			if _, ok := names[key]; !ok {
+				names[key] = heading
+				order = append(order, key)
+			}
+			if n := len(holders[key]); n == 0 || holders[key][n-1] != resume.profile.Spec {
+				holders[key] = append(holders[key], resume.profile.Spec)
+			}
+		}
+	}
+
+	var differences []string
+	for _, key := range order {
+		if len(holders[key]) < len(resumes) {
+			differences = append(differences, fmt.Sprintf("%s: only in %s", names[key], strings.Join(holders[key], ", ")))
+		}
+	}
+	return differences
+}
+
+// strongestProfile returns the resume that covers the most keywords, then
+// the one on the fewest pages, then the shortest.
+func strongestProfile(resumes []profileResume) profileResume {
+	best := resumes[0]
+	for _, resume := range resumes[1:] {
+		switch {
+		case len(resume.missing) != len(best.missing):
+			if len(resume.missing) < len(best.missing) {
+				best = resume
+			}
+		case resume.pages != best.pages:
+			if resume.pages < best.pages {
+				best = resume
+			}
+		case resume.words < best.words:
+			best = resume
+		}
+	}
+	return best
+}
+
+// profileFileName returns a profile as part of a file name, such as
+// "new-grad-recruiter" for "new-grad+recruiter".
+func profileFileName(spec string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, spec)
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	return strings.Trim(name, "-")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/input"
+)
+
+func TestFormatProfileComparison(t *testing.T) {
+	keywords := []string{"go", "kubernetes", "mentoring"}
+	standard := measureProfile(input.Profile{Spec: "standard"},
+		"# Jane Doe\n\n## Experience\n\n- Built Go services on Kubernetes\n\n## Skills\n\n- Go", keywords)
+	newGrad := measureProfile(input.Profile{Spec: "new-grad+ats"},
+		"# Jane Doe\n\n## Education\n\n- BS, State\n\n## Projects\n\n- Go CLI\n\n## Skills\n\n- Go, mentoring, Kubernetes", keywords)
+
+	// Test case 1: Each resume is measured
+	if standard.bullets != 2 || standard.pages != 1 || !reflect.DeepEqual(standard.missing, []string{"mentoring"}) {
+		t.Errorf("Unexpected measures: %+v", standard)
+	}
+
+	// Test case 2: The report compares length, sections, and keywords, and names the strongest
+	report := formatProfileComparison([]profileResume{standard, newGrad}, keywords, true)
+	for _, want := range []string{
+		"| standard | ",
+		" | 2 | 1 | 2/3 |",
+		"| new-grad+ats | ",
+		"- Experience: only in standard",
+		"- Education: only in new-grad+ats",
+		"- Projects: only in new-grad+ats",
+		"Coverage of the top 3 terms of the job description.",
+		"- standard: misses mentoring",
+		"- new-grad+ats: mentions every keyword",
+		"Strongest: new-grad+ats, which covers 3 of 3 keywords on 1 page.",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected the report to contain %q, got:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Skills: only") {
+		t.Error("Expected a section every profile has not to be listed")
+	}
+
+	// Test case 3: Ties go to the shorter resume
+	if best := strongestProfile([]profileResume{{words: 300, pages: 1}, {words: 200, pages: 1}}); best.words != 200 {
+		t.Errorf("Expected the shorter resume, got %+v", best)
+	}
+}
+
+func TestProfileFileName(t *testing.T) {
+	for spec, want := range map[string]string{"new-grad+recruiter": "new-grad-recruiter", "standard+prompts/Terse.txt": "standard-prompts-terse-txt"} {
+		if got := profileFileName(spec); got != want {
+			t.Errorf("profileFileName(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
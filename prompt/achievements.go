@@ -0,0 +1,71 @@
+package prompt
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// achievementItemPattern matches a bullet or numbered item in the model's list.
+var achievementItemPattern = regexp.MustCompile(`^(?:[-*•]|\d+[.)])\s+(.+)$`)
+
+// BuildAchievementsPrompt creates the prompt that summarizes a developer's
+// activity into candidate achievement bullets. Each activity line describes
+// one commit or merged pull request, such as a gitlog.Commit's String.
+//
+// Parameters:
+//   - activity: The commits and pull requests, one per entry
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildAchievementsPrompt([]string{"2024-05-01 widgets: Add PDF export (PR #42)"})
+func BuildAchievementsPrompt(activity []string) string {
+	var b strings.Builder
+	b.WriteString("Summarize my notable work from these commits and merged pull requests as candidate resume achievement bullets.\n\nMY ACTIVITY:")
+	for _, line := range activity {
+		if line = strings.TrimSpace(line); line != "" {
+			b.WriteString("\n" + line)
+		}
+	}
+	return b.String()
+}
+
+// GenerateAchievementsPromptContent creates a genai.Content object for an
+// achievements request. It wraps BuildAchievementsPrompt.
+//
+// Parameters:
+//   - activity: The commits and pull requests, one per entry
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending to the achievements model
+func GenerateAchievementsPromptContent(activity []string) *genai.Content {
+	return genai.NewUserContent(genai.Text(BuildAchievementsPrompt(activity)))
+}
+
+// ParseAchievements extracts the bullets from the model's list. Bullets may
+// be marked with "-", "*", or numbers; any other lines, such as a preamble,
+// are ignored.
+//
+// Parameters:
+//   - text: The model's response
+//
+// Returns:
+//   - []string: The bullets without their markers, in the order they were listed
+//
+// Example:
+//
+//	bullets := prompt.ParseAchievements("Here you go:\n- Built PDF export (widgets, May 2024)")
+//	// bullets == []string{"Built PDF export (widgets, May 2024)"}
+func ParseAchievements(text string) []string {
+	var bullets []string
+	for _, line := range strings.Split(text, "\n") {
+		if match := achievementItemPattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			bullets = append(bullets, strings.TrimSpace(match[1]))
+		}
+	}
+	return bullets
+}
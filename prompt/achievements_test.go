@@ -0,0 +1,59 @@
+package prompt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildAchievementsPrompt(t *testing.T) {
+	result := BuildAchievementsPrompt([]string{"2024-05-01 widgets: Add PDF export (PR #42)", "  ", "2024-04-02 widgets: Cache renders"})
+
+	// Test case 1: Every activity line is listed under the activity heading
+	if !strings.Contains(result, "MY ACTIVITY:\n2024-05-01 widgets: Add PDF export (PR #42)\n2024-04-02 widgets: Cache renders") {
+		t.Errorf("Expected the activity to be listed, got %q", result)
+	}
+
+	// Test case 2: Blank lines are dropped
+	if strings.Contains(result, "\n\n2024") || strings.HasSuffix(result, "\n") {
+		t.Errorf("Expected blank activity lines to be dropped, got %q", result)
+	}
+
+	// Test case 3: The content wraps the prompt text
+	content := GenerateAchievementsPromptContent([]string{"2024-05-01 widgets: Add PDF export"})
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Errorf("Expected a single user part, got %+v", content)
+	}
+}
+
+func TestParseAchievements(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "dash bullets with preamble",
+			text: "Here are your achievements:\n\n- Built PDF export (widgets, May 2024)\n- Cut render time by [X%] (widgets, Apr 2024)",
+			want: []string{"Built PDF export (widgets, May 2024)", "Cut render time by [X%] (widgets, Apr 2024)"},
+		},
+		{
+			name: "asterisks and numbers",
+			text: "* Led the API migration (api, Jan-Mar 2024)\n2. Added SSO (auth, Feb 2024)",
+			want: []string{"Led the API migration (api, Jan-Mar 2024)", "Added SSO (auth, Feb 2024)"},
+		},
+		{
+			name: "no list",
+			text: "I could not find any notable work.",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAchievements(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAchievements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
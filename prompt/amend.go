@@ -0,0 +1,34 @@
+package prompt
+
+import (
+	"github.com/google/generative-ai-go/genai"
+)
+
+// AmendInstructions asks the model to update a resume it wrote before
+// instead of starting from scratch. It is added to the prompt in -amend mode,
+// where the existing resume is the previously generated one and the user's
+// notes describe what changed since.
+const AmendInstructions = "UPDATE MODE: The EXISTING RESUME is the resume you wrote for me last time. " +
+	"Treat the USER INPUT as updates to it, such as a new job, a promotion, a finished project, or a detail to correct, " +
+	"not as my full history. Apply every update, placing new roles in date order, and keep everything the updates do not touch " +
+	"as it is, with the same wording, structure, and section order."
+
+// AddAmendmentToContent appends the update instructions to prompt content as
+// an additional text part, so the notes are applied to the existing resume.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//
+// Returns:
+//   - *genai.Content: The same content object, with the instructions appended
+//
+// Example:
+//
+//	content := prompt.GeneratePromptContent(lastResume, "Add my new job at Acme")
+//	content = prompt.AddAmendmentToContent(content)
+func AddAmendmentToContent(content *genai.Content) *genai.Content {
+	if content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+AmendInstructions))
+	}
+	return content
+}
@@ -0,0 +1,23 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestAddAmendmentToContent(t *testing.T) {
+	content := AddAmendmentToContent(GeneratePromptContent("# Jane Doe", "Add my new job at Acme"))
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the instructions as a second part, got %d parts", len(content.Parts))
+	}
+	if text := string(content.Parts[1].(genai.Text)); !strings.Contains(text, "UPDATE MODE") {
+		t.Errorf("Unexpected instructions %q", text)
+	}
+
+	// Nil content is left alone
+	if AddAmendmentToContent(nil) != nil {
+		t.Error("Expected nil content to stay nil")
+	}
+}
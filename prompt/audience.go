@@ -0,0 +1,124 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// Audience is who reads the resume first, which changes what the prompt asks
+// the model to emphasize.
+type Audience string
+
+const (
+	// AudienceGeneral balances the needs of every reader. The system
+	// instructions already describe such a resume, so it adds nothing to the prompt.
+	AudienceGeneral Audience = "general"
+
+	// AudienceATS writes for applicant tracking systems: the most keyword
+	// coverage and plain formatting that parsers read reliably.
+	AudienceATS Audience = "ats"
+
+	// AudienceRecruiter writes for recruiters who skim many resumes: short,
+	// scannable bullets with the headline facts first.
+	AudienceRecruiter Audience = "recruiter"
+
+	// AudienceHiringManager writes for the person the candidate would work
+	// for: depth, scope, and the story of the candidate's impact.
+	AudienceHiringManager Audience = "hiring-manager"
+)
+
+// Audiences lists the available audiences in the order they are documented.
+var Audiences = []Audience{AudienceGeneral, AudienceATS, AudienceRecruiter, AudienceHiringManager}
+
+// audienceInstructions are the prompt lines of each audience but the general one.
+var audienceInstructions = map[Audience][]string{
+	AudienceATS: {
+		"RESUME AUDIENCE: applicant tracking systems",
+		"- Keywords: use the exact names of the skills, tools, certifications, and job titles in the inputs and the emphasized keywords, when given. Spell out each acronym once alongside it, as in \"Amazon Web Services (AWS)\".",
+		"- Formatting: plain Markdown only. Use standard headings (Summary, Experience, Education, Skills), one bullet level, and no tables, columns, icons, emoji, or bold and italic text inside bullets.",
+		"- Dates: give every role's dates in the same format, such as \"Jan 2021 - Mar 2024\", on the line with the title and company.",
+		"- Skills: list every relevant skill from the inputs in the Skills section, even when it also appears in a bullet.",
+	},
+	AudienceRecruiter: {
+		"RESUME AUDIENCE: recruiters skimming many resumes",
+		"- Scannability: keep the Summary to two or three lines that state the candidate's title, years of experience, and strongest specialty.",
+		"- Bullets: at most four per role and one line each where possible, leading with the result or the number that matters.",
+		"- Order: put the most recent and most relevant roles and skills first, and shorten older roles to a line or two.",
+		"- Wording: prefer familiar job titles and technology names over internal team or product names.",
+	},
+	AudienceHiringManager: {
+		"RESUME AUDIENCE: the hiring manager",
+		"- Depth: for recent roles, describe the problems the candidate owned, the decisions they made, and the trade-offs involved, not only the tools used.",
+		"- Impact: tie each bullet to its outcome for the team, product, or business, with the scope (team size, users, budget, systems) where the inputs give it.",
+		"- Narrative: let the Summary and the order of the bullets show how the candidate's responsibilities grew from role to role.",
+		"- Length: bullets may run to two lines when the detail shows judgment or ownership, but do not invent detail the inputs do not support.",
+	},
+}
+
+// ParseAudience converts an audience name (case-insensitive) to an Audience.
+// An empty name is the general audience.
+//
+// Parameters:
+//   - name: The audience name, such as "ats"
+//
+// Returns:
+//   - Audience: The matching audience
+//   - error: An error listing the valid audiences if the name is unknown
+//
+// Example:
+//
+//	audience, err := prompt.ParseAudience("hiring-manager")
+//	// audience == prompt.AudienceHiringManager
+func ParseAudience(name string) (Audience, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return AudienceGeneral, nil
+	}
+	for _, audience := range Audiences {
+		if string(audience) == name {
+			return audience, nil
+		}
+	}
+
+	names := make([]string, len(Audiences))
+	for i, audience := range Audiences {
+		names[i] = string(audience)
+	}
+	return "", fmt.Errorf("unknown audience %q (valid audiences: %s)", name, strings.Join(names, ", "))
+}
+
+// BuildAudienceSection formats the instructions for an audience as an
+// additional prompt section.
+//
+// Parameters:
+//   - audience: The audience to write for
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string for the general audience
+//
+// Example:
+//
+//	section := prompt.BuildAudienceSection(prompt.AudienceATS)
+//	// RESUME AUDIENCE: applicant tracking systems
+//	// ...
+func BuildAudienceSection(audience Audience) string {
+	return strings.Join(audienceInstructions[audience], "\n")
+}
+
+// AddAudienceToContent appends the audience section to prompt content as an
+// additional text part. Content is returned unchanged for the general audience.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//   - audience: The audience to write for
+//
+// Returns:
+//   - *genai.Content: The same content object, with the audience part appended
+func AddAudienceToContent(content *genai.Content, audience Audience) *genai.Content {
+	if section := BuildAudienceSection(audience); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
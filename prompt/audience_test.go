@@ -0,0 +1,85 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestParseAudience(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  Audience
+		expectErr bool
+	}{
+		{"empty is general", "", AudienceGeneral, false},
+		{"ats", "ATS", AudienceATS, false},
+		{"recruiter", " recruiter ", AudienceRecruiter, false},
+		{"hiring manager", "hiring-manager", AudienceHiringManager, false},
+		{"unknown", "ceo", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAudience(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseAudience(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseAudience(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			if err != nil && !strings.Contains(err.Error(), "hiring-manager") {
+				t.Errorf("Expected the error to list the valid audiences, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildAudienceSection(t *testing.T) {
+	tests := []struct {
+		audience Audience
+		want     []string
+	}{
+		{AudienceATS, []string{"RESUME AUDIENCE: applicant tracking systems", "exact names", "plain Markdown only"}},
+		{AudienceRecruiter, []string{"RESUME AUDIENCE: recruiters", "Scannability", "at most four per role"}},
+		{AudienceHiringManager, []string{"RESUME AUDIENCE: the hiring manager", "trade-offs", "do not invent detail"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.audience), func(t *testing.T) {
+			section := BuildAudienceSection(tt.audience)
+			for _, want := range tt.want {
+				if !strings.Contains(section, want) {
+					t.Errorf("Expected the section to contain %q, got %q", want, section)
+				}
+			}
+		})
+	}
+
+	if section := BuildAudienceSection(AudienceGeneral); section != "" {
+		t.Errorf("Expected the general audience to add no instructions, got %q", section)
+	}
+}
+
+func TestAddAudienceToContent(t *testing.T) {
+	content := GeneratePromptContent("", "notes")
+
+	AddAudienceToContent(content, AudienceGeneral)
+	if len(content.Parts) != 1 {
+		t.Fatalf("Expected the general audience to add nothing, got %d parts", len(content.Parts))
+	}
+
+	AddAudienceToContent(content, AudienceATS)
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the audience section to be appended, got %d parts", len(content.Parts))
+	}
+	if section := string(content.Parts[1].(genai.Text)); !strings.HasPrefix(section, "\n\nRESUME AUDIENCE") {
+		t.Errorf("Expected the audience section, got %q", section)
+	}
+
+	if got := AddAudienceToContent(nil, AudienceATS); got != nil {
+		t.Errorf("Expected nil content to stay nil, got %v", got)
+	}
+}
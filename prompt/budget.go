@@ -0,0 +1,432 @@
+package prompt
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// charsPerToken is the average number of characters per token used to
+// estimate prompt size without calling the API. English prose averages
+// about four characters per token.
+const charsPerToken = 4
+
+// EstimateTokens estimates the number of tokens in text, rounding up.
+//
+// Parameters:
+//   - text: The text to measure
+//
+// Returns:
+//   - int: The estimated token count
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// TrimStep is one way of shrinking the input when a prompt does not fit.
+type TrimStep string
+
+const (
+	// TrimCondenseRoles shortens roles in the existing resume to their
+	// heading, dates, and first highlight, oldest role first.
+	TrimCondenseRoles TrimStep = "condense-roles"
+
+	// TrimDropRoles removes roles from the existing resume, oldest role first.
+	TrimDropRoles TrimStep = "drop-roles"
+
+	// TrimDropSections removes secondary sections of the existing resume
+	// (anything other than the summary, experience, education, and skills),
+	// largest section first.
+	TrimDropSections TrimStep = "drop-sections"
+
+	// TrimNotes removes paragraphs from the start of the user's notes.
+	TrimNotes TrimStep = "trim-notes"
+)
+
+// DefaultTrimOrder trims the oldest roles first, then secondary sections,
+// and the user's notes last.
+var DefaultTrimOrder = []TrimStep{TrimCondenseRoles, TrimDropRoles, TrimDropSections, TrimNotes}
+
+// ParseTrimOrder parses a comma-separated list of trim steps, such as
+// "drop-sections,condense-roles". An empty string returns DefaultTrimOrder.
+//
+// Parameters:
+//   - value: The comma-separated step names
+//
+// Returns:
+//   - []TrimStep: The steps in the given order
+//   - error: An error if a step name is unknown
+func ParseTrimOrder(value string) ([]TrimStep, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultTrimOrder, nil
+	}
+
+	var order []TrimStep
+	for _, name := range strings.Split(value, ",") {
+		step := TrimStep(strings.ToLower(strings.TrimSpace(name)))
+		known := false
+		for _, candidate := range DefaultTrimOrder {
+			known = known || candidate == step
+		}
+		if !known {
+			names := make([]string, len(DefaultTrimOrder))
+			for i, candidate := range DefaultTrimOrder {
+				names[i] = string(candidate)
+			}
+			return nil, fmt.Errorf("unknown trim step %q (expected some of %s)", name, strings.Join(names, ", "))
+		}
+		order = append(order, step)
+	}
+	return order, nil
+}
+
+// Budget describes how many tokens a request may use, so the prompt can be
+// trimmed to fit before it is sent.
+type Budget struct {
+	ContextWindow   int        // Total tokens the model accepts, input and output combined
+	MaxOutputTokens int        // Tokens reserved for the response
+	ReservedTokens  int        // Tokens used by other parts of the request, such as system instructions
+	Order           []TrimStep // Trim steps in the order they are tried (nil for DefaultTrimOrder)
+}
+
+// FitResult is the input after trimming it to fit a Budget.
+type FitResult struct {
+	SourceContent string   // The existing resume, possibly trimmed
+	StdinContent  string   // The user's notes, possibly trimmed
+	Tokens        int      // Estimated tokens of the prompt built from the trimmed input
+	Trimmed       []string // What was trimmed, in order (empty when the input fit as-is)
+}
+
+// InputTokens returns the number of tokens left for the prompt built by BuildPrompt.
+func (b Budget) InputTokens() int {
+	return b.ContextWindow - b.MaxOutputTokens - b.ReservedTokens
+}
+
+// Fit trims the existing resume and notes until the prompt built from them
+// fits the budget. The trim steps are applied in order, one unit at a time,
+// stopping as soon as the prompt fits. If the prompt is still too large
+// after every step, the existing resume and then the notes are cut short,
+// so the result always fits. Input that already fits is returned unchanged.
+//
+// Parameters:
+//   - sourceContent: Content from an existing resume file (can be empty)
+//   - stdinContent: User input from stdin (can be empty)
+//
+// Returns:
+//   - FitResult: The trimmed input and a description of what was trimmed
+//   - error: An error if the budget leaves no room for any input
+//
+// Example:
+//
+//	budget := prompt.Budget{ContextWindow: 32768, MaxOutputTokens: 8192}
+//	fitted, err := budget.Fit(resumeContent, userInput)
+//	for _, trimmed := range fitted.Trimmed {
+//	    fmt.Println("Trimmed:", trimmed)
+//	}
+func (b Budget) Fit(sourceContent, stdinContent string) (FitResult, error) {
+	available := b.InputTokens()
+	if overhead := EstimateTokens(BuildPrompt("", "")); available < overhead {
+		return FitResult{}, fmt.Errorf("a context window of %d tokens leaves no room for input after reserving %d output and %d other tokens",
+			b.ContextWindow, b.MaxOutputTokens, b.ReservedTokens)
+	}
+
+	result := FitResult{SourceContent: sourceContent, StdinContent: stdinContent}
+	fits := func() bool {
+		result.Tokens = EstimateTokens(BuildPrompt(result.SourceContent, result.StdinContent))
+		return result.Tokens <= available
+	}
+	if fits() {
+		return result, nil
+	}
+
+	order := b.Order
+	if order == nil {
+		order = DefaultTrimOrder
+	}
+
+	source := parseSource(sourceContent)
+	notes := splitParagraphs(stdinContent)
+	removedNotes := 0
+
+	for _, step := range order {
+		for !fits() {
+			trimmed := ""
+			switch step {
+			case TrimCondenseRoles:
+				trimmed = source.condenseOldestRole()
+			case TrimDropRoles:
+				trimmed = source.dropOldestRole()
+			case TrimDropSections:
+				trimmed = source.dropLargestSecondarySection()
+			case TrimNotes:
+				if len(notes) > 1 {
+					notes = notes[1:]
+					removedNotes++
+					result.StdinContent = strings.Join(notes, "\n\n")
+					continue
+				}
+			}
+			if trimmed == "" {
+				break
+			}
+			result.SourceContent = source.String()
+			result.Trimmed = append(result.Trimmed, trimmed)
+		}
+	}
+	if removedNotes > 0 {
+		result.Trimmed = append(result.Trimmed, fmt.Sprintf("Removed the first %d paragraph(s) of your notes", removedNotes))
+	}
+
+	// Cut the remaining text short as a last resort. An emptied input is
+	// replaced by placeholder text, so this repeats until the prompt fits;
+	// with both inputs empty it always does.
+	sourceCut, notesCut := 0, 0
+	for !fits() && (result.SourceContent != "" || result.StdinContent != "") {
+		excess := (result.Tokens - available) * charsPerToken
+		var cut int
+		result.SourceContent, cut = truncateEnd(result.SourceContent, excess)
+		sourceCut += cut
+		result.StdinContent, cut = truncateEnd(result.StdinContent, excess-cut)
+		notesCut += cut
+	}
+	if sourceCut > 0 {
+		result.Trimmed = append(result.Trimmed, fmt.Sprintf("Cut the existing resume short by %d characters", sourceCut))
+	}
+	if notesCut > 0 {
+		result.Trimmed = append(result.Trimmed, fmt.Sprintf("Cut your notes short by %d characters", notesCut))
+	}
+
+	return result, nil
+}
+
+// truncateEnd removes up to excess characters from the end of text and
+// returns the shortened text and the number of characters removed.
+func truncateEnd(text string, excess int) (string, int) {
+	runes := []rune(text)
+	if excess <= 0 {
+		return text, 0
+	}
+	if excess > len(runes) {
+		excess = len(runes)
+	}
+	return string(runes[:len(runes)-excess]), excess
+}
+
+// splitParagraphs splits text into paragraphs separated by blank lines.
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, paragraph := range paragraphBreakRegex.Split(strings.TrimSpace(text), -1) {
+		if strings.TrimSpace(paragraph) != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return paragraphs
+}
+
+// chunk is a run of lines in the existing resume: the preamble before the
+// first section, a section heading with its introduction, or one role.
+type chunk struct {
+	lines     []string
+	section   int  // Index of the section the chunk belongs to (-1 for the preamble)
+	role      bool // Whether the chunk is a role in an experience section
+	year      int  // Latest year mentioned in a role, used to find the oldest
+	condensed bool
+	removed   bool
+}
+
+// title returns the first line of the chunk without Markdown heading markers.
+func (c *chunk) title() string {
+	if len(c.lines) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimLeft(c.lines[0], "# "))
+}
+
+// source is the existing resume split into chunks that can be trimmed.
+type source struct {
+	chunks []*chunk
+}
+
+var (
+	// paragraphBreakRegex matches the blank lines between paragraphs
+	paragraphBreakRegex = regexp.MustCompile(`\n\s*\n`)
+
+	// yearRegex matches a four-digit year
+	yearRegex = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+
+	// presentRegex matches the end of an ongoing role
+	presentRegex = regexp.MustCompile(`(?i)\b(?:present|current|now)\b`)
+
+	// experienceKeywords identify sections whose "###" entries are roles
+	experienceKeywords = []string{"experience", "employment", "work", "history"}
+
+	// primaryKeywords identify sections that are never dropped
+	primaryKeywords = []string{"summary", "profile", "experience", "employment", "work", "history", "education", "skills"}
+)
+
+// parseSource splits an existing resume into chunks. Each "##" heading
+// starts a section and each "###" heading in an experience section starts a role.
+func parseSource(text string) *source {
+	s := &source{}
+	current := &chunk{section: -1}
+	s.chunks = append(s.chunks, current)
+	section := -1
+	inExperience := false
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "## "):
+			section++
+			inExperience = containsKeyword(trimmed, experienceKeywords)
+			current = &chunk{section: section}
+			s.chunks = append(s.chunks, current)
+		case strings.HasPrefix(trimmed, "### ") && inExperience:
+			current = &chunk{section: section, role: true}
+			s.chunks = append(s.chunks, current)
+		}
+		current.lines = append(current.lines, line)
+	}
+
+	// Roles without a year are assumed to be older than the role above them,
+	// since resumes list the most recent role first
+	lastYear := math.MaxInt
+	for _, c := range s.chunks {
+		if !c.role {
+			continue
+		}
+		c.year = lastYear
+		text := strings.Join(c.lines, "\n")
+		if presentRegex.MatchString(text) {
+			c.year = math.MaxInt
+		} else if years := yearRegex.FindAllString(text, -1); len(years) > 0 {
+			c.year = 0
+			for _, y := range years {
+				if n, _ := strconv.Atoi(y); n > c.year {
+					c.year = n
+				}
+			}
+		}
+		lastYear = c.year
+	}
+	return s
+}
+
+// String rebuilds the resume from the chunks that were not removed.
+func (s *source) String() string {
+	var lines []string
+	for _, c := range s.chunks {
+		if !c.removed {
+			lines = append(lines, c.lines...)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rolesOldestFirst returns the remaining roles, oldest first; roles from the
+// same year are ordered bottom to top.
+func (s *source) rolesOldestFirst() []*chunk {
+	var roles []*chunk
+	for _, c := range s.chunks {
+		if c.role && !c.removed {
+			roles = append(roles, c)
+		}
+	}
+	// The chunks are in document order, so reversing before a stable sort
+	// puts lower roles first within a year
+	for i, j := 0, len(roles)-1; i < j; i, j = i+1, j-1 {
+		roles[i], roles[j] = roles[j], roles[i]
+	}
+	sort.SliceStable(roles, func(i, j int) bool { return roles[i].year < roles[j].year })
+	return roles
+}
+
+// condenseOldestRole keeps only the heading, dates, and first highlight of
+// the oldest role that has not been condensed yet, and describes the change.
+func (s *source) condenseOldestRole() string {
+	for _, role := range s.rolesOldestFirst() {
+		if role.condensed {
+			continue
+		}
+		role.condensed = true
+
+		var kept []string
+		highlights := 0
+		for _, line := range role.lines {
+			trimmed := strings.TrimSpace(line)
+			if isBullet(trimmed) {
+				highlights++
+				if highlights > 1 {
+					continue
+				}
+			}
+			kept = append(kept, line)
+		}
+		if len(kept) < len(role.lines) {
+			role.lines = kept
+			return fmt.Sprintf("Condensed the role %q to its first highlight", role.title())
+		}
+	}
+	return ""
+}
+
+// dropOldestRole removes the oldest remaining role and describes the change.
+func (s *source) dropOldestRole() string {
+	roles := s.rolesOldestFirst()
+	if len(roles) == 0 {
+		return ""
+	}
+	roles[0].removed = true
+	return fmt.Sprintf("Removed the role %q", roles[0].title())
+}
+
+// dropLargestSecondarySection removes the largest remaining section that is
+// not a summary, experience, education, or skills section.
+func (s *source) dropLargestSecondarySection() string {
+	sizes := map[int]int{}
+	for _, c := range s.chunks {
+		if c.section >= 0 && !c.removed {
+			sizes[c.section] += len(strings.Join(c.lines, "\n"))
+		}
+	}
+
+	// Each section has one chunk that is not a role: its heading
+	var largest *chunk
+	for _, c := range s.chunks {
+		if c.section < 0 || c.role || c.removed || containsKeyword(c.title(), primaryKeywords) {
+			continue
+		}
+		if largest == nil || sizes[c.section] > sizes[largest.section] {
+			largest = c
+		}
+	}
+	if largest == nil {
+		return ""
+	}
+
+	for _, c := range s.chunks {
+		if c.section == largest.section {
+			c.removed = true
+		}
+	}
+	return fmt.Sprintf("Removed the %q section of the existing resume", largest.title())
+}
+
+// isBullet reports whether a trimmed line is a list item.
+func isBullet(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "+ ")
+}
+
+// containsKeyword reports whether text contains any of the keywords, ignoring case.
+func containsKeyword(text string, keywords []string) bool {
+	text = strings.ToLower(text)
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
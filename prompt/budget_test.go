@@ -0,0 +1,167 @@
+package prompt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const budgetTestResume = `# Jane Doe
+
+## Experience
+
+### Staff Engineer | Acme | 2021 - Present
+- Led the platform team through a migration to Kubernetes
+- Mentored six engineers
+
+### Engineer | Initech | 2015 - 2018
+- Built the billing system used by every customer
+- Rewrote the reporting pipeline to cut nightly runtime in half
+
+### Senior Engineer | Globex | 2018 - 2021
+- Designed the public API
+- Ran the on-call rotation
+
+## Volunteering
+- Taught weekend programming classes at the public library for five years
+
+## Education
+- BS Computer Science, 2015`
+
+// budgetFor returns a budget with exactly enough room for the prompt built
+// from the given input
+func budgetFor(sourceContent, stdinContent string, order []TrimStep) Budget {
+	return Budget{ContextWindow: EstimateTokens(BuildPrompt(sourceContent, stdinContent)), Order: order}
+}
+
+func TestBudgetFit(t *testing.T) {
+	notes := "First paragraph of notes.\n\nSecond paragraph of notes.\n\nMost recent update."
+
+	t.Run("input that fits is unchanged", func(t *testing.T) {
+		budget := Budget{ContextWindow: 100000, MaxOutputTokens: 8192}
+		result, err := budget.Fit(budgetTestResume, notes)
+		if err != nil {
+			t.Fatalf("Fit() error = %v", err)
+		}
+		if result.SourceContent != budgetTestResume || result.StdinContent != notes || len(result.Trimmed) != 0 {
+			t.Errorf("Expected the input unchanged, got %+v", result)
+		}
+	})
+
+	t.Run("oldest role is condensed first", func(t *testing.T) {
+		want := strings.Replace(budgetTestResume, "- Rewrote the reporting pipeline to cut nightly runtime in half\n", "", 1)
+		result, err := budgetFor(want, notes, nil).Fit(budgetTestResume, notes)
+		if err != nil {
+			t.Fatalf("Fit() error = %v", err)
+		}
+		if result.SourceContent != want {
+			t.Errorf("SourceContent = %q, want %q", result.SourceContent, want)
+		}
+		wantTrimmed := []string{`Condensed the role "Engineer | Initech | 2015 - 2018" to its first highlight`}
+		if !reflect.DeepEqual(result.Trimmed, wantTrimmed) {
+			t.Errorf("Trimmed = %v, want %v", result.Trimmed, wantTrimmed)
+		}
+	})
+
+	t.Run("oldest role is dropped after condensing", func(t *testing.T) {
+		condensed := strings.NewReplacer(
+			"- Mentored six engineers\n", "",
+			"- Rewrote the reporting pipeline to cut nightly runtime in half\n", "",
+			"- Ran the on-call rotation\n", "",
+		).Replace(budgetTestResume)
+		want := strings.Replace(condensed, "### Engineer | Initech | 2015 - 2018\n- Built the billing system used by every customer\n\n", "", 1)
+
+		result, err := budgetFor(want, notes, nil).Fit(budgetTestResume, notes)
+		if err != nil {
+			t.Fatalf("Fit() error = %v", err)
+		}
+		if result.SourceContent != want {
+			t.Errorf("SourceContent = %q, want %q", result.SourceContent, want)
+		}
+		if last := result.Trimmed[len(result.Trimmed)-1]; last != `Removed the role "Engineer | Initech | 2015 - 2018"` {
+			t.Errorf("Expected the oldest role to be removed last, got %v", result.Trimmed)
+		}
+	})
+
+	t.Run("custom order drops secondary sections first", func(t *testing.T) {
+		want := strings.Replace(budgetTestResume, "## Volunteering\n- Taught weekend programming classes at the public library for five years\n\n", "", 1)
+		order := []TrimStep{TrimDropSections, TrimCondenseRoles}
+
+		result, err := budgetFor(want, notes, order).Fit(budgetTestResume, notes)
+		if err != nil {
+			t.Fatalf("Fit() error = %v", err)
+		}
+		if result.SourceContent != want {
+			t.Errorf("SourceContent = %q, want %q", result.SourceContent, want)
+		}
+		wantTrimmed := []string{`Removed the "Volunteering" section of the existing resume`}
+		if !reflect.DeepEqual(result.Trimmed, wantTrimmed) {
+			t.Errorf("Trimmed = %v, want %v", result.Trimmed, wantTrimmed)
+		}
+	})
+
+	t.Run("notes lose their oldest paragraphs", func(t *testing.T) {
+		result, err := budgetFor(budgetTestResume, "Most recent update.", []TrimStep{TrimNotes}).Fit(budgetTestResume, notes)
+		if err != nil {
+			t.Fatalf("Fit() error = %v", err)
+		}
+		if result.StdinContent != "Most recent update." || result.SourceContent != budgetTestResume {
+			t.Errorf("Expected only the last paragraph of the notes to remain, got %+v", result)
+		}
+		wantTrimmed := []string{"Removed the first 2 paragraph(s) of your notes"}
+		if !reflect.DeepEqual(result.Trimmed, wantTrimmed) {
+			t.Errorf("Trimmed = %v, want %v", result.Trimmed, wantTrimmed)
+		}
+	})
+
+	t.Run("input is cut short as a last resort", func(t *testing.T) {
+		budget := Budget{ContextWindow: EstimateTokens(BuildPrompt("", "")) + 5}
+		result, err := budget.Fit(budgetTestResume, notes)
+		if err != nil {
+			t.Fatalf("Fit() error = %v", err)
+		}
+		if result.Tokens > budget.InputTokens() {
+			t.Errorf("Expected the prompt to fit in %d tokens, got %d", budget.InputTokens(), result.Tokens)
+		}
+		if last := result.Trimmed[len(result.Trimmed)-1]; !strings.HasPrefix(last, "Cut ") {
+			t.Errorf("Expected the input to be cut short, got %v", result.Trimmed)
+		}
+	})
+
+	t.Run("budget without room for input", func(t *testing.T) {
+		budget := Budget{ContextWindow: 8192, MaxOutputTokens: 8192}
+		if _, err := budget.Fit(budgetTestResume, notes); err == nil {
+			t.Error("Expected an error when the output reservation fills the context window")
+		}
+	})
+}
+
+func TestParseTrimOrder(t *testing.T) {
+	order, err := ParseTrimOrder("")
+	if err != nil || !reflect.DeepEqual(order, DefaultTrimOrder) {
+		t.Errorf("ParseTrimOrder(\"\") = %v, %v, want the default order", order, err)
+	}
+
+	order, err = ParseTrimOrder(" Trim-Notes , drop-roles")
+	if want := []TrimStep{TrimNotes, TrimDropRoles}; err != nil || !reflect.DeepEqual(order, want) {
+		t.Errorf("ParseTrimOrder() = %v, %v, want %v", order, err, want)
+	}
+
+	if _, err := ParseTrimOrder("condense-roles,drop-everything"); err == nil || !strings.Contains(err.Error(), "drop-everything") {
+		t.Errorf("Expected an error naming the unknown step, got %v", err)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := map[string]int{
+		"":          0,
+		"abcd":      1,
+		"abcde":     2,
+		"héllo wör": 3,
+	}
+	for text, want := range tests {
+		if got := EstimateTokens(text); got != want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// BuildBulletPrompt creates the follow-up prompt that strengthens one bullet
+// of a generated resume: it asks for an action verb, a metric, and an
+// outcome, drawn only from the inputs. Like BuildSectionPrompt, it is sent on
+// the session that produced the resume.
+//
+// Parameters:
+//   - heading: The heading of the section the bullet is in
+//   - bullet: The bullet's current text, without its list marker
+//   - missing: What the bullet lacks, such as "a metric" (can be empty)
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildBulletPrompt("Experience", "Worked on billing", []string{"an action verb", "a metric"})
+func BuildBulletPrompt(heading, bullet string, missing []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Rewrite this bullet from the %q section of the resume so it shows more impact: ", heading)
+	b.WriteString("start with a strong action verb, include a metric such as a number, percentage, or scale, and state the outcome.")
+	b.WriteString("\n\n" + strings.TrimSpace(bullet))
+
+	if n := len(missing); n > 0 {
+		lacks := missing[n-1]
+		if n > 1 {
+			lacks = strings.Join(missing[:n-1], ", ") + " and " + lacks
+		}
+		b.WriteString("\n\nIt currently lacks " + lacks + ".")
+	}
+	b.WriteString("\n\nUse only facts from my inputs and the resume; do not invent numbers, tools, or results. " +
+		"If my inputs do not state a metric or an outcome, leave it out rather than guessing.")
+	b.WriteString("\n\nReply with only the new bullet, on one line, without a list marker.")
+	return b.String()
+}
+
+// GenerateBulletPromptContent creates a genai.Content object for the
+// follow-up turn that strengthens one bullet. It wraps BuildBulletPrompt.
+//
+// Parameters:
+//   - heading: The heading of the section the bullet is in
+//   - bullet: The bullet's current text, without its list marker
+//   - missing: What the bullet lacks, such as "a metric" (can be empty)
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending on the resume's session
+//
+// Example:
+//
+//	response, err := session.Send(ctx, prompt.GenerateBulletPromptContent("Experience", bullet.Text, bullet.Missing()))
+func GenerateBulletPromptContent(heading, bullet string, missing []string) *genai.Content {
+	return genai.NewUserContent(genai.Text(BuildBulletPrompt(heading, bullet, missing)))
+}
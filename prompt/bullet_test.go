@@ -0,0 +1,33 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestBuildBulletPrompt(t *testing.T) {
+	// Test case 1: The bullet and what it lacks are included
+	text := BuildBulletPrompt("Experience", "Worked on billing", []string{"an action verb", "a metric"})
+	for _, want := range []string{`from the "Experience" section`, "\n\nWorked on billing\n\n", "lacks an action verb and a metric.", "without a list marker"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the prompt to contain %q, got %q", want, text)
+		}
+	}
+
+	// Test case 2: Nothing is said to be missing when the list is empty
+	if text := BuildBulletPrompt("Experience", "Led billing", nil); strings.Contains(text, "lacks") {
+		t.Errorf("Expected no missing parts, got %q", text)
+	}
+}
+
+func TestGenerateBulletPromptContent(t *testing.T) {
+	content := GenerateBulletPromptContent("Projects", "Built a CLI", nil)
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Fatalf("Expected one user part, got %+v", content)
+	}
+	if text := string(content.Parts[0].(genai.Text)); !strings.Contains(text, "Built a CLI") {
+		t.Errorf("Unexpected prompt: %q", text)
+	}
+}
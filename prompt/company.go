@@ -0,0 +1,163 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// CompanyProfile is what the research step learned about a target employer.
+type CompanyProfile struct {
+	Name     string   // The company name
+	Values   []string // What the employer values or prioritizes
+	Keywords []string // Skills, terms, and phrases the employer uses
+	Tone     string   // The tone of the employer's writing (can be empty)
+}
+
+// Empty reports whether the research found nothing to tailor the resume to.
+func (p CompanyProfile) Empty() bool {
+	return len(p.Values) == 0 && len(p.Keywords) == 0
+}
+
+// BuildCompanyResearchPrompt creates the prompt asking for the values and
+// language of a target employer. When the target is a job posting URL, the
+// text of the posting is included so the model does not have to guess.
+//
+// Parameters:
+//   - target: A company name or job posting URL
+//   - postingText: The text of the job posting (can be empty)
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildCompanyResearchPrompt("Acme Corp", "")
+func BuildCompanyResearchPrompt(target, postingText string) string {
+	var b strings.Builder
+	b.WriteString("Research this employer so a resume can be tailored to it: " + strings.TrimSpace(target))
+	if postingText = strings.TrimSpace(postingText); postingText != "" {
+		b.WriteString("\n\nJOB POSTING:\n" + postingText)
+	}
+	return b.String()
+}
+
+// GenerateCompanyResearchPromptContent creates a genai.Content object for the
+// company research step. It wraps BuildCompanyResearchPrompt in a structured
+// Content object.
+//
+// Parameters:
+//   - target: A company name or job posting URL
+//   - postingText: The text of the job posting (can be empty)
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending to the Gemini API
+func GenerateCompanyResearchPromptContent(target, postingText string) *genai.Content {
+	return &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(BuildCompanyResearchPrompt(target, postingText)),
+		},
+	}
+}
+
+// ParseCompanyProfile extracts the profile from the model's "Company:",
+// "Values:", "Keywords:", and "Tone:" lines. Other lines are ignored, and
+// list items are trimmed and de-duplicated.
+//
+// Parameters:
+//   - text: The model's response
+//
+// Returns:
+//   - CompanyProfile: The profile (Empty when the response had no values or keywords)
+//
+// Example:
+//
+//	profile := prompt.ParseCompanyProfile("Company: Acme\nValues: frugality, ownership")
+//	// profile.Values == []string{"frugality", "ownership"}
+func ParseCompanyProfile(text string) CompanyProfile {
+	var profile CompanyProfile
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*• "))
+		line = strings.ReplaceAll(line, "**", "")
+
+		if value, ok := cutPrefixFold(line, "company:"); ok {
+			profile.Name = strings.TrimSpace(value)
+		} else if value, ok := cutPrefixFold(line, "values:"); ok {
+			profile.Values = splitList(value)
+		} else if value, ok := cutPrefixFold(line, "keywords:"); ok {
+			profile.Keywords = splitList(value)
+		} else if value, ok := cutPrefixFold(line, "tone:"); ok {
+			profile.Tone = strings.TrimSpace(value)
+		}
+	}
+	return profile
+}
+
+// splitList splits a comma-separated list, dropping empty and repeated items.
+func splitList(list string) []string {
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(item), "."))
+		if item == "" || seen[strings.ToLower(item)] {
+			continue
+		}
+		seen[strings.ToLower(item)] = true
+		items = append(items, item)
+	}
+	return items
+}
+
+// BuildCompanySection formats the company profile as an additional prompt
+// section. The model is asked to echo the employer's language only where the
+// user's own experience supports it.
+//
+// Parameters:
+//   - profile: The researched company profile
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string if the profile is empty
+//
+// Example:
+//
+//	section := prompt.BuildCompanySection(profile)
+//	// TARGET EMPLOYER (tailor phrasing to it, but never add experience the inputs do not support):
+//	// Company: Acme
+//	// Values: frugality, ownership
+func BuildCompanySection(profile CompanyProfile) string {
+	if profile.Empty() {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("TARGET EMPLOYER (tailor phrasing to it, but never add experience the inputs do not support):")
+	if profile.Name != "" {
+		b.WriteString("\nCompany: " + profile.Name)
+	}
+	if len(profile.Values) > 0 {
+		b.WriteString("\nValues: " + strings.Join(profile.Values, ", "))
+	}
+	if len(profile.Keywords) > 0 {
+		b.WriteString("\nKeywords: " + strings.Join(profile.Keywords, ", "))
+	}
+	if profile.Tone != "" {
+		b.WriteString("\nTone: " + profile.Tone)
+	}
+	return b.String()
+}
+
+// AddCompanyToContent appends the company section to prompt content as an
+// additional text part. Content is returned unchanged when the profile is empty.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent or GenerateCoverLetterPromptContent
+//   - profile: The researched company profile
+//
+// Returns:
+//   - *genai.Content: The same content object, with the company part appended
+func AddCompanyToContent(content *genai.Content, profile CompanyProfile) *genai.Content {
+	if section := BuildCompanySection(profile); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
@@ -0,0 +1,85 @@
+package prompt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestBuildCompanyResearchPrompt(t *testing.T) {
+	got := BuildCompanyResearchPrompt(" Acme Corp ", "")
+	if !strings.HasSuffix(got, ": Acme Corp") || strings.Contains(got, "JOB POSTING") {
+		t.Errorf("Expected only the company name, got %q", got)
+	}
+
+	got = BuildCompanyResearchPrompt("https://acme.dev/jobs/1", "Senior Go Engineer\nWe value ownership.")
+	if !strings.Contains(got, "JOB POSTING:\nSenior Go Engineer\nWe value ownership.") {
+		t.Errorf("Expected the posting text in the prompt, got %q", got)
+	}
+}
+
+func TestParseCompanyProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected CompanyProfile
+	}{
+		{
+			name: "All four lines",
+			text: "Company: Acme Corp\nValues: ownership, frugality, Ownership\nKeywords: distributed systems, Go, on-call.\nTone: direct and plain",
+			expected: CompanyProfile{
+				Name:     "Acme Corp",
+				Values:   []string{"ownership", "frugality"},
+				Keywords: []string{"distributed systems", "Go", "on-call"},
+				Tone:     "direct and plain",
+			},
+		},
+		{
+			name: "Markdown list and emphasis are ignored",
+			text: "Here is the profile:\n- **Company:** Acme\n* **values:** customer obsession",
+			expected: CompanyProfile{
+				Name:   "Acme",
+				Values: []string{"customer obsession"},
+			},
+		},
+		{
+			name:     "Unrecognized company",
+			text:     "I do not recognize this company.",
+			expected: CompanyProfile{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCompanyProfile(tt.text)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseCompanyProfile() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddCompanyToContent(t *testing.T) {
+	content := GeneratePromptContent("", "notes")
+
+	AddCompanyToContent(content, CompanyProfile{Name: "Acme"})
+	if len(content.Parts) != 1 {
+		t.Fatalf("Expected an empty profile to add nothing, got %d parts", len(content.Parts))
+	}
+
+	AddCompanyToContent(content, CompanyProfile{Name: "Acme", Keywords: []string{"Go", "Kafka"}})
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the company section to be appended, got %d parts", len(content.Parts))
+	}
+	section := string(content.Parts[1].(genai.Text))
+	for _, want := range []string{"TARGET EMPLOYER", "never add experience", "Company: Acme", "Keywords: Go, Kafka"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected the section to contain %q, got %q", want, section)
+		}
+	}
+	if strings.Contains(section, "Values:") || strings.Contains(section, "Tone:") {
+		t.Errorf("Expected empty fields to be left out, got %q", section)
+	}
+}
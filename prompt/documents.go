@@ -0,0 +1,157 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// DocumentType names a document written from the same inputs as the resume,
+// after it, such as the thank-you email sent after an interview.
+type DocumentType string
+
+const (
+	// DocumentThankYou is the email thanking an interviewer after an interview.
+	DocumentThankYou DocumentType = "thank-you"
+
+	// DocumentFollowUp is the short note asking about an application that
+	// has not been answered.
+	DocumentFollowUp DocumentType = "follow-up"
+
+	// DocumentLinkedIn is the About section of the candidate's LinkedIn profile.
+	DocumentLinkedIn DocumentType = "linkedin"
+)
+
+// DocumentTypes lists the available documents in the order they are documented.
+var DocumentTypes = []DocumentType{DocumentThankYou, DocumentFollowUp, DocumentLinkedIn}
+
+// documentTemplate is what makes each document its own: the title shown to
+// the user, the name of its file, and the task its prompt sets.
+type documentTemplate struct {
+	title    string
+	fileName string
+	task     string
+}
+
+// documentTemplates holds the template of each document type.
+var documentTemplates = map[DocumentType]documentTemplate{
+	DocumentThankYou: {
+		title:    "thank-you email",
+		fileName: "thank_you_email",
+		task: "Write the thank-you email I will send to the interviewer after an interview for this role. " +
+			"Start with a \"Subject:\" line, then thank them for their time, recall one or two strengths from the resume that fit the role, " +
+			"and end by restating my interest. Keep the body under 150 words, and write [Interviewer name] where their name belongs.",
+	},
+	DocumentFollowUp: {
+		title:    "follow-up note",
+		fileName: "follow_up_note",
+		task: "Write a short, polite note following up on my application for this role, for when I have not heard back. " +
+			"Start with a \"Subject:\" line, then mention when I applied as [date applied], restate in one sentence why I fit the role, " +
+			"and ask about the next steps. Keep the body under 100 words.",
+	},
+	DocumentLinkedIn: {
+		title:    "LinkedIn summary",
+		fileName: "linkedin_summary",
+		task: "Write the About section of my LinkedIn profile. Write it in the first person, in two or three short paragraphs: " +
+			"what I do and for whom, the strongest results from the resume, and what I am looking for next. " +
+			"Keep it under 250 words, with no headings or bullet points.",
+	},
+}
+
+// documentPromptRules end every document prompt, so each document stays
+// consistent with the resume and is easy to save as its own file.
+const documentPromptRules = "Use only facts from my inputs and the resume you just wrote; do not invent employers, dates, metrics, or skills. " +
+	"Do not repeat the resume and do not use the resume delimiters. Reply with only the %s, in Markdown."
+
+// ParseDocumentTypes converts a comma-separated list of document names
+// (case-insensitive) to document types, in order and without duplicates.
+//
+// Parameters:
+//   - list: The document names, such as "thank-you,linkedin"
+//
+// Returns:
+//   - []DocumentType: The documents to write (nil for an empty list)
+//   - error: An error listing the valid names if a name is unknown
+//
+// Example:
+//
+//	documents, err := prompt.ParseDocumentTypes("thank-you, follow-up")
+//	// documents == []prompt.DocumentType{prompt.DocumentThankYou, prompt.DocumentFollowUp}
+func ParseDocumentTypes(list string) ([]DocumentType, error) {
+	var documents []DocumentType
+	seen := make(map[DocumentType]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		document := DocumentType(name)
+		if _, ok := documentTemplates[document]; !ok {
+			names := make([]string, len(DocumentTypes))
+			for i, d := range DocumentTypes {
+				names[i] = string(d)
+			}
+			return nil, fmt.Errorf("unknown document %q (choose from %s)", name, strings.Join(names, ", "))
+		}
+		if !seen[document] {
+			seen[document] = true
+			documents = append(documents, document)
+		}
+	}
+	return documents, nil
+}
+
+// Title returns the document's name for messages, such as "thank-you email".
+func (d DocumentType) Title() string {
+	return documentTemplates[d].title
+}
+
+// FileName returns the name the document's file is given, without an
+// extension, such as "thank_you_email".
+func (d DocumentType) FileName() string {
+	return documentTemplates[d].fileName
+}
+
+// BuildDocumentPrompt creates the follow-up prompt that writes one document
+// from the inputs and the resume. Like GapsPrompt, it is sent on the session
+// that produced the resume, so the inputs are not sent again.
+//
+// Parameters:
+//   - document: The document to write
+//   - company: The employer the resume was tailored to (empty for none)
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildDocumentPrompt(prompt.DocumentThankYou, "Acme")
+func BuildDocumentPrompt(document DocumentType, company string) string {
+	template := documentTemplates[document]
+	var b strings.Builder
+	b.WriteString(template.task)
+	if company = strings.TrimSpace(company); company != "" {
+		fmt.Fprintf(&b, " The employer is %s.", company)
+	}
+	b.WriteString("\n\n" + fmt.Sprintf(documentPromptRules, template.title))
+	return b.String()
+}
+
+// GenerateDocumentPromptContent creates a genai.Content object for the
+// follow-up turn that writes one document. It wraps BuildDocumentPrompt.
+//
+// Parameters:
+//   - document: The document to write
+//   - company: The employer the resume was tailored to (empty for none)
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending on the resume's session
+//
+// Example:
+//
+//	response, err := session.Send(ctx, prompt.GenerateDocumentPromptContent(prompt.DocumentLinkedIn, ""))
+func GenerateDocumentPromptContent(document DocumentType, company string) *genai.Content {
+	return genai.NewUserContent(genai.Text(BuildDocumentPrompt(document, company)))
+}
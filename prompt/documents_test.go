@@ -0,0 +1,62 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestParseDocumentTypes(t *testing.T) {
+	// Test case 1: Names are matched case-insensitively, in order, without duplicates
+	documents, err := ParseDocumentTypes(" Thank-You, linkedin,thank-you,")
+	if err != nil {
+		t.Fatalf("ParseDocumentTypes() error = %v", err)
+	}
+	if len(documents) != 2 || documents[0] != DocumentThankYou || documents[1] != DocumentLinkedIn {
+		t.Errorf("Expected thank-you and linkedin, got %v", documents)
+	}
+
+	// Test case 2: An empty list asks for no documents
+	if documents, err := ParseDocumentTypes(""); err != nil || documents != nil {
+		t.Errorf("Expected no documents, got %v (%v)", documents, err)
+	}
+
+	// Test case 3: Unknown names list the valid ones
+	if _, err := ParseDocumentTypes("thank-you,resignation"); err == nil || !strings.Contains(err.Error(), "follow-up") {
+		t.Errorf("Expected an error listing the documents, got %v", err)
+	}
+}
+
+func TestDocumentTypes(t *testing.T) {
+	// Every document has its own title, file name, and prompt
+	fileNames := make(map[string]bool)
+	for _, document := range DocumentTypes {
+		if document.Title() == "" || document.FileName() == "" {
+			t.Errorf("Expected %s to have a title and a file name", document)
+		}
+		if fileNames[document.FileName()] {
+			t.Errorf("Expected %s to have its own file name, got %q", document, document.FileName())
+		}
+		fileNames[document.FileName()] = true
+
+		text := BuildDocumentPrompt(document, "")
+		if !strings.Contains(text, "Reply with only the "+document.Title()) || !strings.Contains(text, "do not invent") {
+			t.Errorf("Expected the %s prompt to ask for only the document from the inputs, got %q", document, text)
+		}
+	}
+}
+
+func TestGenerateDocumentPromptContent(t *testing.T) {
+	content := GenerateDocumentPromptContent(DocumentThankYou, "Acme")
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Fatalf("Expected one user part, got %+v", content)
+	}
+
+	text := string(content.Parts[0].(genai.Text))
+	for _, want := range []string{"thank-you email", "Subject:", "[Interviewer name]", "The employer is Acme."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the prompt to contain %q, got %q", want, text)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// ParseEmphasis splits the -emphasize list into keywords, dropping empty and
+// repeated entries. Keywords may be phrases, such as "distributed systems".
+//
+// Parameters:
+//   - list: Comma-separated keywords
+//
+// Returns:
+//   - []string: The keywords in the order given
+//
+// Example:
+//
+//	keywords := prompt.ParseEmphasis("kubernetes, leadership,grpc")
+//	// keywords == []string{"kubernetes", "leadership", "grpc"}
+func ParseEmphasis(list string) []string {
+	return splitList(list)
+}
+
+// BuildEmphasisSection formats the keywords to emphasize as an additional
+// prompt section. The model is asked to feature each keyword only where the
+// inputs show the experience behind it, and to leave out the rest.
+//
+// Parameters:
+//   - keywords: The keywords to emphasize
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string if there are no keywords
+//
+// Example:
+//
+//	section := prompt.BuildEmphasisSection([]string{"kubernetes", "grpc"})
+//	// KEYWORDS TO EMPHASIZE (use each one only where the inputs truthfully support it; omit any they do not):
+//	// - kubernetes
+//	// - grpc
+func BuildEmphasisSection(keywords []string) string {
+	if len(keywords) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("KEYWORDS TO EMPHASIZE (use each one only where the inputs truthfully support it; omit any they do not):")
+	for _, keyword := range keywords {
+		b.WriteString("\n- " + keyword)
+	}
+	b.WriteString("\nWhere a keyword is supported, use its exact wording in the Summary, Skills, or the bullet that demonstrates it.")
+	return b.String()
+}
+
+// AddEmphasisToContent appends the emphasis section to prompt content as an
+// additional text part. Content is returned unchanged when there are no keywords.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//   - keywords: The keywords to emphasize
+//
+// Returns:
+//   - *genai.Content: The same content object, with the emphasis part appended
+func AddEmphasisToContent(content *genai.Content, keywords []string) *genai.Content {
+	if section := BuildEmphasisSection(keywords); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
@@ -0,0 +1,41 @@
+package prompt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestParseEmphasis(t *testing.T) {
+	got := ParseEmphasis(" kubernetes, leadership,,grpc, Kubernetes, distributed systems ")
+	expected := []string{"kubernetes", "leadership", "grpc", "distributed systems"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := ParseEmphasis(""); len(got) != 0 {
+		t.Errorf("Expected no keywords from an empty list, got %v", got)
+	}
+}
+
+func TestAddEmphasisToContent(t *testing.T) {
+	content := GeneratePromptContent("", "notes")
+
+	AddEmphasisToContent(content, nil)
+	if len(content.Parts) != 1 {
+		t.Fatalf("Expected no keywords to add nothing, got %d parts", len(content.Parts))
+	}
+
+	AddEmphasisToContent(content, []string{"kubernetes", "grpc"})
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the emphasis section to be appended, got %d parts", len(content.Parts))
+	}
+	section := string(content.Parts[1].(genai.Text))
+	for _, want := range []string{"KEYWORDS TO EMPHASIZE", "truthfully support", "\n- kubernetes", "\n- grpc"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected the section to contain %q, got %q", want, section)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package prompt
+
+import (
+	"github.com/google/generative-ai-go/genai"
+)
+
+// ExplanationPrompt asks the model why it made the major changes between the
+// user's inputs and the resume it wrote. It is sent as a follow-up turn on
+// the session that produced the resume, so the inputs are not resent.
+const ExplanationPrompt = "Explain the major changes you made between my inputs and the resume you just wrote, " +
+	"so I can learn the resume-writing principles behind them. Do not repeat the resume and do not use the resume delimiters. " +
+	"Answer in Markdown with these sections:\n" +
+	"## Removed\nWhat you left out of my inputs and why.\n" +
+	"## Rephrased\nEach bullet you rewrote substantially, as the original wording, the new wording, and the reason for the change.\n" +
+	"## Principles\nA short list of the resume-writing principles these changes follow.\n" +
+	"Only describe changes you actually made. Leave out a section with nothing to report."
+
+// GenerateExplanationPromptContent creates a genai.Content object for the
+// follow-up turn that asks for the rationale behind the generated resume.
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending on the resume's session
+//
+// Example:
+//
+//	response, err := session.Send(ctx, prompt.GenerateExplanationPromptContent())
+func GenerateExplanationPromptContent() *genai.Content {
+	return genai.NewUserContent(genai.Text(ExplanationPrompt))
+}
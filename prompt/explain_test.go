@@ -0,0 +1,22 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestGenerateExplanationPromptContent(t *testing.T) {
+	content := GenerateExplanationPromptContent()
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Fatalf("Expected one user part, got %+v", content)
+	}
+
+	text := string(content.Parts[0].(genai.Text))
+	for _, want := range []string{"## Removed", "## Rephrased", "## Principles", "Do not repeat the resume"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the prompt to contain %q, got %q", want, text)
+		}
+	}
+}
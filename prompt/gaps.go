@@ -0,0 +1,40 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// GapsPrompt asks the model how the candidate could close the gaps between
+// the resume it just wrote and a job: the job description's keywords that
+// the resume never mentions. It is sent as a follow-up turn on the session
+// that produced the resume, so only the keywords are sent, not the job
+// description itself. The %s verb is replaced with the keywords.
+const GapsPrompt = "The job I am applying for asks for these skills and keywords, which the resume you just wrote does not show: %s. " +
+	"Write a short appendix of practical next steps to close these gaps. Do not repeat the resume and do not use the resume delimiters. " +
+	"Answer in Markdown with these sections:\n" +
+	"## Gaps\nEach gap that matters for the job, in one line, and whether my inputs suggest I am close to it. Skip keywords that are not real skills.\n" +
+	"## Courses\nWell-known courses or books that teach the missing skills, with the provider.\n" +
+	"## Certifications\nRecognized certifications that would show the skills, only where one exists.\n" +
+	"## Projects\nSmall projects I could build to demonstrate the skills, each in one or two sentences.\n" +
+	"Keep it under 300 words. Only name courses and certifications you are confident exist, and never suggest claiming experience I do not have. " +
+	"Leave out a section with nothing to suggest."
+
+// GenerateGapsPromptContent creates a genai.Content object for the follow-up
+// turn that asks how to close the gaps between the resume and a job.
+//
+// Parameters:
+//   - gaps: The job description keywords the resume does not mention
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending on the resume's session
+//
+// Example:
+//
+//	gaps := analysis.JobGaps(resume, jobDescription, analysis.GapTerms)
+//	response, err := session.Send(ctx, prompt.GenerateGapsPromptContent(gaps))
+func GenerateGapsPromptContent(gaps []string) *genai.Content {
+	return genai.NewUserContent(genai.Text(fmt.Sprintf(GapsPrompt, strings.Join(gaps, ", "))))
+}
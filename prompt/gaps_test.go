@@ -0,0 +1,22 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestGenerateGapsPromptContent(t *testing.T) {
+	content := GenerateGapsPromptContent([]string{"terraform", "aws"})
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Fatalf("Expected one user part, got %+v", content)
+	}
+
+	text := string(content.Parts[0].(genai.Text))
+	for _, want := range []string{"terraform, aws", "## Courses", "## Certifications", "## Projects", "never suggest claiming experience"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the prompt to contain %q, got %q", want, text)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// Grounding selects how strictly a resume must stick to the user's inputs.
+// In strict grounding, each sentence of the inputs is numbered and the model
+// cites the sentences every bullet comes from; bullets that cite none are
+// flagged for review or dropped once the resume is generated.
+type Grounding string
+
+const (
+	// GroundingOff relies on the system instructions alone to keep the
+	// resume to what the inputs say.
+	GroundingOff Grounding = "off"
+
+	// GroundingFlag keeps bullets that cite none of the inputs and lists
+	// them for review.
+	GroundingFlag Grounding = "flag"
+
+	// GroundingDrop leaves out bullets that cite none of the inputs.
+	GroundingDrop Grounding = "drop"
+)
+
+// Groundings lists the grounding modes in the order they are documented.
+var Groundings = []Grounding{GroundingOff, GroundingFlag, GroundingDrop}
+
+// GroundingInstructions asks the model to cite the numbered input sentences
+// each bullet is based on. It is added to the prompt in strict grounding.
+const GroundingInstructions = "STRICT GROUNDING: Each sentence of the EXISTING RESUME and USER INPUT above starts with a number in " +
+	"brackets, such as [S12]. End every bullet you write with the numbers of the sentences it is based on, such as " +
+	"\"- Cut build times by 40% [S3, S7]\". Only write bullets those sentences support; do not add numbers, employers, " +
+	"tools, or results they do not state. Do not cite anything outside the bullets."
+
+// sentenceEndRegex matches the end of a sentence: its punctuation, any
+// closing quotes or brackets, and the space after it.
+var sentenceEndRegex = regexp.MustCompile(`[.!?]["')\]”’]*\s+`)
+
+// lineMarkerRegex matches the list marker that starts a line, which is kept
+// before the line's first sentence number.
+var lineMarkerRegex = regexp.MustCompile(`^\s*(?:[-*+]|\d{1,9}[.)])\s+`)
+
+// ParseGrounding converts a grounding mode name (case-insensitive) to a
+// Grounding. An empty name is GroundingOff.
+//
+// Parameters:
+//   - name: The mode name, such as "drop"
+//
+// Returns:
+//   - Grounding: The matching mode
+//   - error: An error listing the valid modes if the name is unknown
+//
+// Example:
+//
+//	grounding, err := prompt.ParseGrounding("flag")
+//	// grounding == prompt.GroundingFlag
+func ParseGrounding(name string) (Grounding, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return GroundingOff, nil
+	}
+	for _, grounding := range Groundings {
+		if string(grounding) == name {
+			return grounding, nil
+		}
+	}
+
+	names := make([]string, len(Groundings))
+	for i, grounding := range Groundings {
+		names[i] = string(grounding)
+	}
+	return "", fmt.Errorf("unknown grounding mode %q (valid modes: %s)", name, strings.Join(names, ", "))
+}
+
+// Strict reports whether bullets must cite the inputs they are based on.
+func (g Grounding) Strict() bool {
+	return g == GroundingFlag || g == GroundingDrop
+}
+
+// NumberSentences starts each sentence of text with its number in brackets,
+// counting from first, so the model can cite it. List markers stay at the
+// start of their lines, and headings and blank lines are left as they are.
+//
+// Parameters:
+//   - text: The source resume or notes
+//   - first: The number of the first sentence
+//
+// Returns:
+//   - string: The text with its sentences numbered
+//   - int: The number of sentences numbered
+//
+// Example:
+//
+//	numbered, count := prompt.NumberSentences("- Led the team. Shipped the app.", 1)
+//	// numbered == "- [S1] Led the team. [S2] Shipped the app.", count == 2
+func NumberSentences(text string, first int) (string, int) {
+	next := first
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		marker := lineMarkerRegex.FindString(line)
+		rest := line[len(marker):]
+		if marker == "" {
+			trimmed := strings.TrimLeft(rest, " \t")
+			marker, rest = rest[:len(rest)-len(trimmed)], trimmed
+		}
+
+		var b strings.Builder
+		b.WriteString(marker)
+		start := 0
+		for _, end := range sentenceEndRegex.FindAllStringIndex(rest, -1) {
+			// A sentence only ends before a capital letter or a number
+			r, _ := utf8.DecodeRuneInString(strings.TrimLeft(rest[end[1]:], `("'“‘*_[`))
+			if end[1] == len(rest) || !(unicode.IsUpper(r) || unicode.IsDigit(r)) {
+				continue
+			}
+			fmt.Fprintf(&b, "[S%d] %s", next, rest[start:end[1]])
+			next++
+			start = end[1]
+		}
+		if start < len(rest) {
+			fmt.Fprintf(&b, "[S%d] %s", next, rest[start:])
+			next++
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n"), next - first
+}
+
+// AddGroundingToContent appends the strict grounding instructions to prompt
+// content as an additional text part. Content is returned unchanged unless
+// grounding is strict.
+//
+// Parameters:
+//   - content: The prompt content, built from inputs numbered with NumberSentences
+//   - grounding: The grounding mode
+//
+// Returns:
+//   - *genai.Content: The same content object, with the instructions appended
+func AddGroundingToContent(content *genai.Content, grounding Grounding) *genai.Content {
+	if grounding.Strict() && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+GroundingInstructions))
+	}
+	return content
+}
@@ -0,0 +1,99 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestParseGrounding(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  Grounding
+		expectErr bool
+	}{
+		{"empty is off", "", GroundingOff, false},
+		{"flag", "Flag", GroundingFlag, false},
+		{"drop", " drop ", GroundingDrop, false},
+		{"unknown", "strict", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGrounding(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseGrounding(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseGrounding(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			if err != nil && !strings.Contains(err.Error(), "flag, drop") {
+				t.Errorf("Expected the error to list the valid modes, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNumberSentences(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		first int
+		want  string
+		count int
+	}{
+		{
+			name:  "each sentence of a paragraph",
+			text:  "Led the payments team. Cut costs by 30%! Mentored 4 engineers.",
+			first: 1,
+			want:  "[S1] Led the payments team. [S2] Cut costs by 30%! [S3] Mentored 4 engineers.",
+			count: 3,
+		},
+		{
+			name:  "list markers stay first and headings are not numbered",
+			text:  "## Experience\n\n- Built the API. 2 releases a week.\n  1. Wrote the docs",
+			first: 4,
+			want:  "## Experience\n\n- [S4] Built the API. [S5] 2 releases a week.\n  1. [S6] Wrote the docs",
+			count: 3,
+		},
+		{
+			name:  "lowercase words do not start a sentence",
+			text:  "Used Go, e.g. for the billing service.",
+			first: 1,
+			want:  "[S1] Used Go, e.g. for the billing service.",
+			count: 1,
+		},
+		{
+			name:  "empty text",
+			text:  "",
+			first: 1,
+			want:  "",
+			count: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := NumberSentences(tt.text, tt.first)
+			if got != tt.want || count != tt.count {
+				t.Errorf("NumberSentences() = %q, %d, want %q, %d", got, count, tt.want, tt.count)
+			}
+		})
+	}
+}
+
+func TestAddGroundingToContent(t *testing.T) {
+	// Test case 1: Strict grounding appends the citation instructions
+	content := AddGroundingToContent(GeneratePromptContent("", "[S1] Led the team."), GroundingDrop)
+	if len(content.Parts) != 2 || !strings.Contains(string(content.Parts[1].(genai.Text)), "STRICT GROUNDING") {
+		t.Errorf("Expected the grounding instructions appended, got %v", content.Parts)
+	}
+
+	// Test case 2: Without strict grounding the content is unchanged
+	content = AddGroundingToContent(GeneratePromptContent("", "Led the team."), GroundingOff)
+	if len(content.Parts) != 1 {
+		t.Errorf("Expected no grounding part, got %d parts", len(content.Parts))
+	}
+}
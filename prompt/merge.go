@@ -0,0 +1,49 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// BuildMergeSourcesPrompt creates the prompt that merges several versions of
+// a resume into one master history. Each resume is labelled with its number
+// and name, so the model can tell which facts come from which version.
+//
+// Parameters:
+//   - names: The names of the resumes, such as their file names
+//   - resumes: The resumes, in the same order as names
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildMergeSourcesPrompt([]string{"cv_2019.md", "resume_2022.md"}, resumes)
+func BuildMergeSourcesPrompt(names, resumes []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Merge these %d versions of my resume into one master history.", len(resumes))
+	for i, resume := range resumes {
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+		fmt.Fprintf(&b, "\n\nRESUME %d (%s):\n", i+1, name)
+		b.WriteString(strings.TrimSpace(resume))
+	}
+	return b.String()
+}
+
+// GenerateMergeSourcesPromptContent creates a genai.Content object for a
+// merge request. It wraps BuildMergeSourcesPrompt.
+//
+// Parameters:
+//   - names: The names of the resumes
+//   - resumes: The resumes, in the same order as names
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending to the merge model
+func GenerateMergeSourcesPromptContent(names, resumes []string) *genai.Content {
+	return genai.NewUserContent(genai.Text(BuildMergeSourcesPrompt(names, resumes)))
+}
@@ -0,0 +1,26 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildMergeSourcesPrompt(t *testing.T) {
+	result := BuildMergeSourcesPrompt([]string{"cv_2019.md", "resume_2022.md"}, []string{"\n# Jane Doe\n- Built X\n", "Jane Doe\n- Built X and Y"})
+
+	// Test case 1: The number of resumes is named
+	if !strings.HasPrefix(result, "Merge these 2 versions of my resume") {
+		t.Errorf("Expected the number of resumes in the request, got %q", result)
+	}
+
+	// Test case 2: Each resume follows its numbered label, trimmed
+	if !strings.Contains(result, "RESUME 1 (cv_2019.md):\n# Jane Doe\n- Built X\n\nRESUME 2 (resume_2022.md):\nJane Doe\n- Built X and Y") {
+		t.Errorf("Expected each resume after its label, got %q", result)
+	}
+
+	// Test case 3: The content wraps the prompt text
+	content := GenerateMergeSourcesPromptContent([]string{"a.md"}, []string{"# Jane Doe"})
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Errorf("Expected a single user part, got %+v", content)
+	}
+}
@@ -0,0 +1,189 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/document"
+)
+
+// Preset selects the kind of candidate a resume is written for, which
+// changes the tone the model is asked for and the order of the sections.
+type Preset string
+
+const (
+	// PresetStandard writes an experience-first resume for working professionals.
+	PresetStandard Preset = "standard"
+
+	// PresetNewGrad writes an education-first resume for students and new
+	// graduates, leading with education, projects, and internships.
+	PresetNewGrad Preset = "new-grad"
+)
+
+// Presets lists the available presets in the order they are documented.
+var Presets = []Preset{PresetStandard, PresetNewGrad}
+
+// NewGradSectionOrder is the order of the sections that lead a new-grad
+// resume: a short summary, then education, projects, and internships. Any
+// other sections follow them in the order the model wrote them.
+var NewGradSectionOrder = []string{"Summary", "Education", "Projects", "Internships"}
+
+// StandardRequiredSections and NewGradRequiredSections are the sections a
+// resume of each preset must have, checked once it is generated.
+var (
+	StandardRequiredSections = []string{"Experience", "Education", "Skills"}
+	NewGradRequiredSections  = []string{"Education", "Projects", "Skills"}
+)
+
+// ParsePreset converts a preset name (case-insensitive) to a Preset. An
+// empty name is the standard preset.
+//
+// Parameters:
+//   - name: The preset name, such as "new-grad"
+//
+// Returns:
+//   - Preset: The matching preset
+//   - error: An error listing the valid presets if the name is unknown
+//
+// Example:
+//
+//	preset, err := prompt.ParsePreset("new-grad")
+//	// preset == prompt.PresetNewGrad
+func ParsePreset(name string) (Preset, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return PresetStandard, nil
+	}
+	for _, preset := range Presets {
+		if string(preset) == name {
+			return preset, nil
+		}
+	}
+
+	names := make([]string, len(Presets))
+	for i, preset := range Presets {
+		names[i] = string(preset)
+	}
+	return "", fmt.Errorf("unknown preset %q (valid presets: %s)", name, strings.Join(names, ", "))
+}
+
+// SectionOrder returns the headings of the sections the preset puts first,
+// or nil when the model's order is kept.
+//
+// Returns:
+//   - []string: The leading section headings, in order
+func (p Preset) SectionOrder() []string {
+	if p == PresetNewGrad {
+		return NewGradSectionOrder
+	}
+	return nil
+}
+
+// RequiredSections returns the headings of the sections a resume written
+// for the preset must have. Internships are not required of a new graduate,
+// since the preset leaves that section out when there are none.
+//
+// Returns:
+//   - []string: The required section headings
+func (p Preset) RequiredSections() []string {
+	if p == PresetNewGrad {
+		return NewGradRequiredSections
+	}
+	return StandardRequiredSections
+}
+
+// BuildPresetSection formats the instructions for a preset as an additional
+// prompt section. The standard preset needs none, since the system
+// instructions already describe an experience-first resume.
+//
+// Parameters:
+//   - preset: The preset to describe
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string for the standard preset
+//
+// Example:
+//
+//	section := prompt.BuildPresetSection(prompt.PresetNewGrad)
+//	// RESUME PRESET: student or new graduate
+//	// ...
+func BuildPresetSection(preset Preset) string {
+	if preset != PresetNewGrad {
+		return ""
+	}
+
+	return "RESUME PRESET: student or new graduate\n" +
+		"- Order the sections: " + strings.Join(NewGradSectionOrder, ", ") + ", then Experience, Skills, and any others. Keep the Summary to two sentences at most.\n" +
+		"- Education: the degree, school, and graduation or expected graduation date, with the GPA and relevant coursework when given.\n" +
+		"- Projects: academic, personal, and hackathon projects, each with what was built, the tools used, and its outcome.\n" +
+		"- Internships: internships and co-ops, written like professional experience. Leave the section out if there are none.\n" +
+		"- Tone: confident and concrete about coursework, projects, and early results, without overstating seniority. Do not invent experience to fill space; a one-page resume is expected."
+}
+
+// AddPresetToContent appends the preset section to prompt content as an
+// additional text part. Content is returned unchanged for the standard preset.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//   - preset: The preset to apply
+//
+// Returns:
+//   - *genai.Content: The same content object, with the preset part appended
+func AddPresetToContent(content *genai.Content, preset Preset) *genai.Content {
+	if section := BuildPresetSection(preset); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
+
+// BuildAcademicsSection formats the GPA and coursework as an additional
+// prompt section, to be shown in the Education section as given.
+//
+// Parameters:
+//   - academics: The GPA and coursework
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string if neither is set
+//
+// Example:
+//
+//	section := prompt.BuildAcademicsSection(document.Academics{GPA: "3.8/4.0", Coursework: []string{"Algorithms"}})
+//	// ACADEMIC DETAILS (show these in the Education section exactly as given):
+//	// - GPA: 3.8/4.0
+//	// - Relevant coursework: Algorithms
+func BuildAcademicsSection(academics document.Academics) string {
+	lines := academics.Lines()
+	if len(lines) == 0 {
+		return ""
+	}
+	return "ACADEMIC DETAILS (show these in the Education section exactly as given):\n- " + strings.Join(lines, "\n- ")
+}
+
+// AddAcademicsToContent appends the academics section to prompt content as
+// an additional text part. Content is returned unchanged when it is empty.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//   - academics: The GPA and coursework
+//
+// Returns:
+//   - *genai.Content: The same content object, with the academics part appended
+func AddAcademicsToContent(content *genai.Content, academics document.Academics) *genai.Content {
+	if section := BuildAcademicsSection(academics); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
+
+// ParseCoursework splits the -coursework list into course names, dropping
+// empty and repeated entries.
+//
+// Parameters:
+//   - list: Comma-separated course names
+//
+// Returns:
+//   - []string: The courses in the order given
+func ParseCoursework(list string) []string {
+	return splitList(list)
+}
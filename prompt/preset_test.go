@@ -0,0 +1,103 @@
+package prompt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/document"
+)
+
+func TestParsePreset(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  Preset
+		expectErr bool
+	}{
+		{"empty is standard", "", PresetStandard, false},
+		{"standard", "standard", PresetStandard, false},
+		{"new grad", " New-Grad ", PresetNewGrad, false},
+		{"unknown", "executive", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePreset(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParsePreset(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParsePreset(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			if err != nil && !strings.Contains(err.Error(), "new-grad") {
+				t.Errorf("Expected the error to list the valid presets, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAddPresetToContent(t *testing.T) {
+	content := GeneratePromptContent("", "notes")
+
+	AddPresetToContent(content, PresetStandard)
+	if len(content.Parts) != 1 {
+		t.Fatalf("Expected the standard preset to add nothing, got %d parts", len(content.Parts))
+	}
+
+	AddPresetToContent(content, PresetNewGrad)
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the preset section to be appended, got %d parts", len(content.Parts))
+	}
+	section := string(content.Parts[1].(genai.Text))
+	for _, want := range []string{"RESUME PRESET", "Summary, Education, Projects, Internships", "Do not invent experience"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected the section to contain %q, got %q", want, section)
+		}
+	}
+
+	if order := PresetStandard.SectionOrder(); order != nil {
+		t.Errorf("Expected the standard preset to keep the model's order, got %v", order)
+	}
+}
+
+func TestRequiredSections(t *testing.T) {
+	// Test case 1: A standard resume needs experience
+	if got := PresetStandard.RequiredSections(); !reflect.DeepEqual(got, []string{"Experience", "Education", "Skills"}) {
+		t.Errorf("Expected the standard sections, got %v", got)
+	}
+
+	// Test case 2: A new graduate's resume needs projects, but not internships
+	if got := PresetNewGrad.RequiredSections(); !reflect.DeepEqual(got, []string{"Education", "Projects", "Skills"}) {
+		t.Errorf("Expected the new-grad sections, got %v", got)
+	}
+}
+
+func TestAddAcademicsToContent(t *testing.T) {
+	content := GeneratePromptContent("", "notes")
+
+	AddAcademicsToContent(content, document.Academics{})
+	if len(content.Parts) != 1 {
+		t.Fatalf("Expected no academics to add nothing, got %d parts", len(content.Parts))
+	}
+
+	AddAcademicsToContent(content, document.Academics{GPA: "3.8/4.0", Coursework: []string{"Algorithms", "Databases"}})
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the academics section to be appended, got %d parts", len(content.Parts))
+	}
+	section := string(content.Parts[1].(genai.Text))
+	for _, want := range []string{"ACADEMIC DETAILS", "\n- GPA: 3.8/4.0", "\n- Relevant coursework: Algorithms, Databases"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected the section to contain %q, got %q", want, section)
+		}
+	}
+}
+
+func TestParseCoursework(t *testing.T) {
+	got := ParseCoursework(" Algorithms, Databases,,algorithms ")
+	expected := []string{"Algorithms", "Databases"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -6,7 +6,10 @@
 package prompt
 
 import (
+	"strings"
+
 	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/document"
 )
 
 // BuildPrompt combines existing resume content and user input into a formatted prompt string.
@@ -70,4 +73,119 @@ func GeneratePromptContent(sourceContent, stdinContent string) *genai.Content {
 			genai.Text(promptText),
 		},
 	}
-}
\ No newline at end of file
+}
+
+// UploadedSourceNote stands in for the existing resume in the prompt text
+// when the resume is attached as an uploaded document.
+const UploadedSourceNote = "(Attached as a document above; read all of it)"
+
+// GenerateUploadedPromptContent creates a genai.Content object for an existing
+// resume uploaded with the Files API. The document is referenced by its file
+// part instead of being inlined, and the text that follows is the same as
+// GeneratePromptContent's, with UploadedSourceNote in place of the resume.
+//
+// Parameters:
+//   - source: The uploaded resume, from api.UploadSource
+//   - stdinContent: User input from stdin (can be empty)
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending to the Gemini API
+//
+// Example:
+//
+//	file, err := api.UploadSource(ctx, client, "resume.pdf", input.PDFMIMEType, f)
+//	content := prompt.GenerateUploadedPromptContent(genai.FileData{MIMEType: file.MIMEType, URI: file.URI}, userInput)
+func GenerateUploadedPromptContent(source genai.FileData, stdinContent string) *genai.Content {
+	return &genai.Content{
+		Parts: []genai.Part{
+			source,
+			genai.Text(BuildPrompt(UploadedSourceNote, stdinContent)),
+		},
+	}
+}
+
+// BuildCoverLetterPrompt combines a generated resume with the original inputs
+// into a prompt for writing a matching cover letter. Including the original
+// notes lets the cover letter draw on details that did not make it into the
+// resume while the resume keeps both documents consistent.
+//
+// Parameters:
+//   - resumeContent: The generated resume in Markdown
+//   - sourceContent: Content from an existing resume file (can be empty)
+//   - stdinContent: User input from stdin (can be empty)
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildCoverLetterPrompt(resume, resumeContent, userInput)
+func BuildCoverLetterPrompt(resumeContent, sourceContent, stdinContent string) string {
+	return "GENERATED RESUME:\n" + resumeContent + "\n\n" + BuildPrompt(sourceContent, stdinContent)
+}
+
+// GenerateCoverLetterPromptContent creates a genai.Content object for cover letter generation.
+// It wraps BuildCoverLetterPrompt in a structured Content object.
+//
+// Parameters:
+//   - resumeContent: The generated resume in Markdown
+//   - sourceContent: Content from an existing resume file (can be empty)
+//   - stdinContent: User input from stdin (can be empty)
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending to the Gemini API
+func GenerateCoverLetterPromptContent(resumeContent, sourceContent, stdinContent string) *genai.Content {
+	return &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(BuildCoverLetterPrompt(resumeContent, sourceContent, stdinContent)),
+		},
+	}
+}
+
+// BuildSkillsSection formats structured skills as an additional prompt section.
+// The model is asked to use exactly these skills so the Skills section matches
+// what the user entered in the skills form. It returns an empty string when
+// there are no skills.
+//
+// Parameters:
+//   - skills: The structured skills entered by the user
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string
+//
+// Example:
+//
+//	section := prompt.BuildSkillsSection([]document.Skill{{Name: "Go", Years: 6}})
+//	// STRUCTURED SKILLS (use exactly these in the Skills section):
+//	// - Go: 6 years
+func BuildSkillsSection(skills []document.Skill) string {
+	if len(skills) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("STRUCTURED SKILLS (use exactly these in the Skills section):")
+	for _, skill := range skills {
+		b.WriteString("\n- " + strings.TrimSpace(skill.Name))
+		if details := skill.Details(); details != "" {
+			b.WriteString(": " + details)
+		}
+	}
+	return b.String()
+}
+
+// AddSkillsToContent appends the structured skills section to prompt content
+// as an additional text part. Content is returned unchanged when there are no skills.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//   - skills: The structured skills entered by the user
+//
+// Returns:
+//   - *genai.Content: The same content object, with the skills part appended
+func AddSkillsToContent(content *genai.Content, skills []document.Skill) *genai.Content {
+	if section := BuildSkillsSection(skills); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
@@ -2,6 +2,9 @@ package prompt
 
 import (
 	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/document"
 )
 
 func TestBuildPrompt(t *testing.T) {
@@ -75,4 +78,59 @@ func TestGeneratePromptContent(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestGenerateUploadedPromptContent(t *testing.T) {
+	source := genai.FileData{MIMEType: "application/pdf", URI: "https://example.com/files/abc"}
+	content := GenerateUploadedPromptContent(source, "new notes")
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the file and a text part, got %d parts", len(content.Parts))
+	}
+	if content.Parts[0] != source {
+		t.Errorf("Expected the uploaded file first, got %v", content.Parts[0])
+	}
+	want := "EXISTING RESUME:\n" + UploadedSourceNote + "\n\nUSER INPUT:\nnew notes"
+	if got := string(content.Parts[1].(genai.Text)); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestBuildCoverLetterPrompt(t *testing.T) {
+	got := BuildCoverLetterPrompt("# Jane Doe", "old resume", "new notes")
+	want := "GENERATED RESUME:\n# Jane Doe\n\nEXISTING RESUME:\nold resume\n\nUSER INPUT:\nnew notes"
+
+	if got != want {
+		t.Errorf("BuildCoverLetterPrompt() = %q, want %q", got, want)
+	}
+
+	content := GenerateCoverLetterPromptContent("# Jane Doe", "old resume", "new notes")
+	if content == nil || len(content.Parts) != 1 {
+		t.Fatal("Expected content with a single part")
+	}
+}
+
+func TestBuildSkillsSection(t *testing.T) {
+	// Test case 1: No skills produce no section
+	if got := BuildSkillsSection(nil); got != "" {
+		t.Errorf("Expected empty section, got %q", got)
+	}
+
+	// Test case 2: Skills are listed with their details
+	skills := []document.Skill{
+		{Name: "Go", Years: 6, Proficiency: document.ProficiencyExpert},
+		{Name: "SQL"},
+	}
+	want := "STRUCTURED SKILLS (use exactly these in the Skills section):\n- Go: Expert, 6 years\n- SQL"
+	if got := BuildSkillsSection(skills); got != want {
+		t.Errorf("BuildSkillsSection() = %q, want %q", got, want)
+	}
+
+	// Test case 3: The section is appended to prompt content as a separate part
+	content := AddSkillsToContent(GeneratePromptContent("", "notes"), skills)
+	if len(content.Parts) != 2 {
+		t.Errorf("Expected 2 parts after adding skills, got %d", len(content.Parts))
+	}
+	if content = AddSkillsToContent(GeneratePromptContent("", "notes"), nil); len(content.Parts) != 1 {
+		t.Errorf("Expected content to be unchanged without skills, got %d parts", len(content.Parts))
+	}
+}
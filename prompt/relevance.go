@@ -0,0 +1,57 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// MaxRelevantExperiences is the number of experiences most relevant to the
+// job description that the prompt asks the model to feature.
+const MaxRelevantExperiences = 5
+
+// BuildRelevanceSection formats the candidate's experiences that best match
+// the job description as an additional prompt section asking the model to
+// feature them. Only the experiences, which are already in the prompt, are
+// included; the job description itself is not.
+//
+// Parameters:
+//   - experiences: The most relevant experiences, most relevant first
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string if there are no experiences
+//
+// Example:
+//
+//	section := prompt.BuildRelevanceSection([]string{"Built the Kubernetes deployment pipeline"})
+//	// MOST RELEVANT EXPERIENCE: ...
+//	// - Built the Kubernetes deployment pipeline
+func BuildRelevanceSection(experiences []string) string {
+	if len(experiences) == 0 {
+		return ""
+	}
+
+	lines := []string{"MOST RELEVANT EXPERIENCE: These items from the inputs best match the role the candidate is applying for, most relevant first. " +
+		"Feature them prominently: keep their detail, lead their roles with them, and reflect them in the Summary. Keep less relevant items brief, but do not drop them."}
+	for _, experience := range experiences {
+		lines = append(lines, "- "+experience)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AddRelevanceToContent appends the relevant experience section to prompt
+// content as an additional text part. Content is returned unchanged when
+// there are no experiences.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//   - experiences: The most relevant experiences, most relevant first
+//
+// Returns:
+//   - *genai.Content: The same content object, with the relevance part appended
+func AddRelevanceToContent(content *genai.Content, experiences []string) *genai.Content {
+	if section := BuildRelevanceSection(experiences); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
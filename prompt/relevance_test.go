@@ -0,0 +1,28 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestAddRelevanceToContent(t *testing.T) {
+	content := GeneratePromptContent("# Jane Doe", "notes")
+
+	AddRelevanceToContent(content, nil)
+	if len(content.Parts) != 1 {
+		t.Fatalf("Expected no experiences to add nothing, got %d parts", len(content.Parts))
+	}
+
+	AddRelevanceToContent(content, []string{"Built the Kubernetes deployment pipeline", "Mentored three engineers"})
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the relevance section to be appended, got %d parts", len(content.Parts))
+	}
+	section := string(content.Parts[1].(genai.Text))
+	for _, want := range []string{"MOST RELEVANT EXPERIENCE", "most relevant first", "- Built the Kubernetes deployment pipeline\n- Mentored three engineers"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected the section to contain %q, got %q", want, section)
+		}
+	}
+}
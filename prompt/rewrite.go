@@ -0,0 +1,101 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// Rewrite mode asks for between MinRewrites and MaxRewrites alternatives.
+const (
+	MinRewrites     = 3
+	MaxRewrites     = 5
+	DefaultRewrites = 3
+)
+
+// Rewrite is one stronger alternative to a resume bullet.
+type Rewrite struct {
+	Bullet       string // The rewritten bullet, possibly with [placeholders] for metrics
+	MetricPrompt string // Question asking for the value behind a placeholder (can be empty)
+}
+
+// rewriteItemPattern matches a numbered list item such as "1. Led" or "2) Cut".
+var rewriteItemPattern = regexp.MustCompile(`^(\d+)[.)]\s+(.+)$`)
+
+// BuildRewritePrompt creates the prompt asking for count stronger versions of
+// a single resume bullet. Leading list markers are stripped from the bullet.
+//
+// Parameters:
+//   - bullet: The bullet to rewrite
+//   - count: The number of alternatives to ask for (MinRewrites to MaxRewrites)
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildRewritePrompt("- Worked on the checkout page", 3)
+func BuildRewritePrompt(bullet string, count int) string {
+	bullet = strings.TrimLeft(strings.TrimSpace(bullet), "-*• ")
+	return fmt.Sprintf("Rewrite this resume bullet into %d stronger alternatives:\n\n%s", count, bullet)
+}
+
+// GenerateRewritePromptContent creates a genai.Content object for rewriting a bullet.
+// It wraps BuildRewritePrompt in a structured Content object.
+//
+// Parameters:
+//   - bullet: The bullet to rewrite
+//   - count: The number of alternatives to ask for
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending to the Gemini API
+func GenerateRewritePromptContent(bullet string, count int) *genai.Content {
+	return &genai.Content{
+		Parts: []genai.Part{
+			genai.Text(BuildRewritePrompt(bullet, count)),
+		},
+	}
+}
+
+// ParseRewrites extracts the alternatives from the model's numbered list.
+// A "Metric:" line is attached to the alternative above it, and any other
+// lines, such as a preamble, are ignored.
+//
+// Parameters:
+//   - text: The model's response
+//
+// Returns:
+//   - []Rewrite: The alternatives in the order they were listed
+//
+// Example:
+//
+//	rewrites := prompt.ParseRewrites("1. Cut page load time by [X%]\n   Metric: How much faster did it load?")
+//	// rewrites[0].MetricPrompt == "How much faster did it load?"
+func ParseRewrites(text string) []Rewrite {
+	var rewrites []Rewrite
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.Trim(strings.TrimSpace(line), "*_"))
+
+		if match := rewriteItemPattern.FindStringSubmatch(line); match != nil {
+			rewrites = append(rewrites, Rewrite{Bullet: strings.TrimSpace(match[2])})
+			continue
+		}
+
+		if len(rewrites) > 0 {
+			if question, ok := cutPrefixFold(line, "metric:"); ok {
+				rewrites[len(rewrites)-1].MetricPrompt = strings.TrimSpace(strings.Trim(question, "*_ "))
+			}
+		}
+	}
+	return rewrites
+}
+
+// cutPrefixFold is strings.CutPrefix ignoring case.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,60 @@
+package prompt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildRewritePrompt(t *testing.T) {
+	got := BuildRewritePrompt("  - Worked on the checkout page\n", 4)
+
+	if !strings.Contains(got, "4 stronger alternatives") {
+		t.Errorf("Expected the prompt to ask for 4 alternatives, got %q", got)
+	}
+	if !strings.HasSuffix(got, "\n\nWorked on the checkout page") {
+		t.Errorf("Expected the bullet without its list marker, got %q", got)
+	}
+}
+
+func TestParseRewrites(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Rewrite
+	}{
+		{
+			name: "Numbered list with metric prompts",
+			text: "1. Cut checkout latency by [X%] by caching prices\n" +
+				"   Metric: By how much did checkout latency drop?\n" +
+				"2) Rebuilt the checkout page in React\n" +
+				"3. Shipped a checkout redesign used by [N] customers\n" +
+				"   metric: How many customers used it in the first month?",
+			expected: []Rewrite{
+				{Bullet: "Cut checkout latency by [X%] by caching prices", MetricPrompt: "By how much did checkout latency drop?"},
+				{Bullet: "Rebuilt the checkout page in React"},
+				{Bullet: "Shipped a checkout redesign used by [N] customers", MetricPrompt: "How many customers used it in the first month?"},
+			},
+		},
+		{
+			name: "Preamble and Markdown emphasis are ignored",
+			text: "Here are some stronger versions:\n\n**1. Led the checkout rewrite**\n*Metric:* What revenue did it add?",
+			expected: []Rewrite{
+				{Bullet: "Led the checkout rewrite", MetricPrompt: "What revenue did it add?"},
+			},
+		},
+		{
+			name:     "No list",
+			text:     "Sorry, I can't help with that.",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseRewrites(tt.text); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseRewrites() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// BuildSectionPrompt creates the follow-up prompt that rewrites one section
+// of a generated resume. It is sent on the session that produced the resume,
+// so the inputs are not resent; the section's current text is included
+// because it may already have been rewritten since the resume was generated.
+//
+// Parameters:
+//   - heading: The section heading, without the leading "##"
+//   - current: The section's current Markdown, without its heading
+//   - instructions: Extra instructions for the new version (can be empty)
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildSectionPrompt("Summary", "Go engineer.", "Mention my open source work")
+func BuildSectionPrompt(heading, current, instructions string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Rewrite only the %q section of the resume. Leave every other section as it is and do not repeat them. ", heading)
+	b.WriteString("Use only facts from my inputs and the resume; do not invent employers, titles, dates, or metrics.")
+
+	if current = strings.TrimSpace(current); current != "" {
+		b.WriteString("\n\nThe section currently reads:\n\n" + current)
+	}
+	if instructions = strings.TrimSpace(instructions); instructions != "" {
+		b.WriteString("\n\nADDITIONAL INSTRUCTIONS:\n" + instructions)
+	}
+
+	fmt.Fprintf(&b, "\n\nReply with only the new section in Markdown, starting with the heading line \"## %s\".", heading)
+	return b.String()
+}
+
+// GenerateSectionPromptContent creates a genai.Content object for the
+// follow-up turn that rewrites one section. It wraps BuildSectionPrompt.
+//
+// Parameters:
+//   - heading: The section heading, without the leading "##"
+//   - current: The section's current Markdown, without its heading
+//   - instructions: Extra instructions for the new version (can be empty)
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending on the resume's session
+//
+// Example:
+//
+//	response, err := session.Send(ctx, prompt.GenerateSectionPromptContent("Summary", body, ""))
+func GenerateSectionPromptContent(heading, current, instructions string) *genai.Content {
+	return genai.NewUserContent(genai.Text(BuildSectionPrompt(heading, current, instructions)))
+}
@@ -0,0 +1,33 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestBuildSectionPrompt(t *testing.T) {
+	// Test case 1: The current text and instructions are included
+	text := BuildSectionPrompt("Summary", "Go engineer.", "Mention my open source work")
+	for _, want := range []string{`Rewrite only the "Summary" section`, "currently reads:\n\nGo engineer.", "ADDITIONAL INSTRUCTIONS:\nMention my open source work", `"## Summary"`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the prompt to contain %q, got %q", want, text)
+		}
+	}
+
+	// Test case 2: Blank instructions are left out
+	if text := BuildSectionPrompt("Summary", "Go engineer.", "  "); strings.Contains(text, "ADDITIONAL INSTRUCTIONS") {
+		t.Errorf("Expected no instructions section, got %q", text)
+	}
+}
+
+func TestGenerateSectionPromptContent(t *testing.T) {
+	content := GenerateSectionPromptContent("Experience", "- Acme", "")
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Fatalf("Expected one user part, got %+v", content)
+	}
+	if text := string(content.Parts[0].(genai.Text)); !strings.Contains(text, `"## Experience"`) {
+		t.Errorf("Unexpected prompt: %q", text)
+	}
+}
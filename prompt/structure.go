@@ -0,0 +1,47 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// BuildStructureSection formats the sections of a source resume that
+// resumake wrote before, loaded from its sidecar, as an additional prompt
+// section asking the model to keep them.
+//
+// Parameters:
+//   - headings: The headings of the source's sections, in order
+//
+// Returns:
+//   - string: A formatted prompt section, or an empty string if there are no headings
+//
+// Example:
+//
+//	section := prompt.BuildStructureSection([]string{"Summary", "Experience", "Skills"})
+//	// SOURCE STRUCTURE: The EXISTING RESUME was written by resumake and has these sections, in this order: Summary, Experience, Skills. ...
+func BuildStructureSection(headings []string) string {
+	if len(headings) == 0 {
+		return ""
+	}
+	return "SOURCE STRUCTURE: The EXISTING RESUME was written by resumake and has these sections, in this order: " +
+		strings.Join(headings, ", ") + ". Keep each of them, with its exact heading and in this order, " +
+		"and add a section only when the USER INPUT calls for one."
+}
+
+// AddStructureToContent appends the source structure section to prompt
+// content as an additional text part. Content is returned unchanged when
+// there are no headings.
+//
+// Parameters:
+//   - content: The prompt content built by GeneratePromptContent
+//   - headings: The headings of the source's sections, in order
+//
+// Returns:
+//   - *genai.Content: The same content object, with the structure part appended
+func AddStructureToContent(content *genai.Content, headings []string) *genai.Content {
+	if section := BuildStructureSection(headings); section != "" && content != nil {
+		content.Parts = append(content.Parts, genai.Text("\n\n"+section))
+	}
+	return content
+}
@@ -0,0 +1,28 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestAddStructureToContent(t *testing.T) {
+	content := GeneratePromptContent("# Jane Doe", "notes")
+
+	AddStructureToContent(content, nil)
+	if len(content.Parts) != 1 {
+		t.Fatalf("Expected no headings to add nothing, got %d parts", len(content.Parts))
+	}
+
+	AddStructureToContent(content, []string{"Summary", "Experience", "Skills"})
+	if len(content.Parts) != 2 {
+		t.Fatalf("Expected the structure section to be appended, got %d parts", len(content.Parts))
+	}
+	section := string(content.Parts[1].(genai.Text))
+	for _, want := range []string{"SOURCE STRUCTURE", "in this order: Summary, Experience, Skills.", "exact heading"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected the section to contain %q, got %q", want, section)
+		}
+	}
+}
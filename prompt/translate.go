@@ -0,0 +1,42 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// BuildTranslatePrompt creates the prompt that localizes a resume into
+// another language. The resume is sent as it is, so its Markdown structure
+// can be kept.
+//
+// Parameters:
+//   - resume: The resume in Markdown
+//   - language: The target language, such as "German" or "pt-BR"
+//
+// Returns:
+//   - string: A formatted prompt string suitable for the Gemini API
+//
+// Example:
+//
+//	promptText := prompt.BuildTranslatePrompt(resume, "German")
+func BuildTranslatePrompt(resume, language string) string {
+	var b strings.Builder
+	b.WriteString("Translate my resume into " + strings.TrimSpace(language) + ", with the section headings and date formats used in that language.")
+	b.WriteString("\n\nMY RESUME:\n")
+	b.WriteString(strings.TrimSpace(resume))
+	return b.String()
+}
+
+// GenerateTranslatePromptContent creates a genai.Content object for a
+// translation request. It wraps BuildTranslatePrompt.
+//
+// Parameters:
+//   - resume: The resume in Markdown
+//   - language: The target language
+//
+// Returns:
+//   - *genai.Content: A content object ready for sending to the translation model
+func GenerateTranslatePromptContent(resume, language string) *genai.Content {
+	return genai.NewUserContent(genai.Text(BuildTranslatePrompt(resume, language)))
+}
@@ -0,0 +1,26 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildTranslatePrompt(t *testing.T) {
+	result := BuildTranslatePrompt("\n# Jane Doe\n\n## Experience\n", " German ")
+
+	// Test case 1: The target language is named, trimmed
+	if !strings.HasPrefix(result, "Translate my resume into German,") {
+		t.Errorf("Expected the target language in the request, got %q", result)
+	}
+
+	// Test case 2: The resume follows its heading, trimmed
+	if !strings.HasSuffix(result, "MY RESUME:\n# Jane Doe\n\n## Experience") {
+		t.Errorf("Expected the resume after its heading, got %q", result)
+	}
+
+	// Test case 3: The content wraps the prompt text
+	content := GenerateTranslatePromptContent("# Jane Doe", "German")
+	if content.Role != "user" || len(content.Parts) != 1 {
+		t.Errorf("Expected a single user part, got %+v", content)
+	}
+}
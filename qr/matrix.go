@@ -0,0 +1,273 @@
+package qr
+
+import "slices"
+
+// matrix is a QR code being built: its modules, and which of them belong to
+// function patterns that data and masks must not touch.
+type matrix struct {
+	size       int
+	number     int // The QR version, from 1 to 10
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// newMatrix returns an empty matrix for the given version.
+func newMatrix(number int) *matrix {
+	size := 17 + 4*number
+	m := &matrix{size: size, number: number}
+	m.modules = make([][]bool, size)
+	m.isFunction = make([][]bool, size)
+	for y := range m.modules {
+		m.modules[y] = make([]bool, size)
+		m.isFunction[y] = make([]bool, size)
+	}
+	return m
+}
+
+// setFunction sets the module at column x and row y and marks it as part of
+// a function pattern.
+func (m *matrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws the timing, finder, and alignment patterns,
+// and reserves the format and version areas.
+func (m *matrix) drawFunctionPatterns(v version) {
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinder(3, 3)
+	m.drawFinder(m.size-4, 3)
+	m.drawFinder(3, m.size-4)
+
+	// Alignment patterns go everywhere except over the three finders
+	last := len(v.alignment) - 1
+	for i, x := range v.alignment {
+		for j, y := range v.alignment {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					m.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// The format bits are drawn for each mask; reserve their area first
+	m.drawFormatBits(0)
+	m.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator centered on x, y.
+func (m *matrix) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx >= 0 && xx < m.size && yy >= 0 && yy < m.size {
+				distance := max(abs(dx), abs(dy))
+				m.setFunction(xx, yy, distance != 2 && distance != 4)
+			}
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information: the error
+// correction level, M, and the mask, protected by a BCH code.
+func (m *matrix) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(bits, i))
+	}
+	m.setFunction(8, 7, bit(bits, 6))
+	m.setFunction(8, 8, bit(bits, 7))
+	m.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	// Split between the other two finders, with the module that is always dark
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(bits, i))
+	}
+	m.setFunction(8, m.size-8, true)
+}
+
+// drawVersion draws both copies of the version information, which versions
+// 7 and above carry, protected by a BCH code.
+func (m *matrix) drawVersion() {
+	if m.number < 7 {
+		return
+	}
+	bits := versionBits(m.number)
+
+	for i := 0; i < 18; i++ {
+		a, b := m.size-11+i%3, i/3
+		m.setFunction(a, b, bit(bits, i))
+		m.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// formatBits returns the 15 format bits for error correction level M and
+// mask: the 5 data bits, 10 BCH bits, and the fixed XOR pattern.
+func formatBits(mask int) int {
+	const levelM = 0b00
+	data := levelM<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = remainder<<1 ^ (remainder>>9)*0x537
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// versionBits returns the 18 version bits: the 6 bits of the version number
+// and 12 BCH bits.
+func versionBits(number int) int {
+	remainder := number
+	for i := 0; i < 12; i++ {
+		remainder = remainder<<1 ^ (remainder>>11)*0x1F25
+	}
+	return number<<12 | remainder
+}
+
+// drawCodewords places the codewords in the modules that are not function
+// patterns, in two-column strips zigzagging up and down from the bottom
+// right corner.
+func (m *matrix) drawCodewords(codewords []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		// The vertical timing pattern is skipped
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < m.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vertical
+				}
+				if !m.isFunction[y][x] && i < len(codewords)*8 {
+					m.modules[y][x] = bit(int(codewords[i/8]), 7-i%8)
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by mask. Applying the same
+// mask twice undoes it.
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			m.modules[y][x] = m.modules[y][x] != invert
+		}
+	}
+}
+
+// penalty scores how hard the masked code is to scan, by the four rules of
+// the QR specification: long runs of one color, 2×2 blocks of one color,
+// patterns that look like finders, and an uneven balance of dark and light.
+func (m *matrix) penalty() int {
+	penalty := 0
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	for _, horizontal := range []bool{true, false} {
+		for a := 0; a < m.size; a++ {
+			line := make([]bool, m.size)
+			for b := 0; b < m.size; b++ {
+				if horizontal {
+					line[b] = m.modules[a][b]
+				} else {
+					line[b] = m.modules[b][a]
+				}
+			}
+
+			run := 1
+			for b := 1; b <= m.size; b++ {
+				if b < m.size && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += 3 + run - 5
+				}
+				run = 1
+			}
+
+			for b := 0; b+11 <= m.size; b++ {
+				for _, pattern := range finderLike {
+					if slices.Equal(line[b:b+11], pattern) {
+						penalty += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.modules[y][x] {
+				dark++
+			}
+			if x+1 < m.size && y+1 < m.size {
+				color := m.modules[y][x]
+				if m.modules[y][x+1] == color && m.modules[y+1][x] == color && m.modules[y+1][x+1] == color {
+					penalty += 3
+				}
+			}
+		}
+	}
+	total := m.size * m.size
+	penalty += abs(dark*20-total*10) / total * 10
+	return penalty
+}
+
+// bit reports whether bit i of value is set.
+func bit(value, i int) bool {
+	return (value>>i)&1 == 1
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,191 @@
+// Package qr encodes short text, such as a profile URL, as a QR code and
+// renders it as SVG for HTML resumes or as a PNG image for PDF exports.
+//
+// Only what a resume needs is implemented: byte mode, error correction level
+// M, which survives a smudged or creased print, and versions 1 to 10, which
+// hold up to 213 bytes. Longer text is rejected rather than producing a
+// code too dense to scan from paper.
+package qr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxLength is the longest text, in bytes, that Encode accepts.
+const MaxLength = 213
+
+// ErrTooLong is returned by Encode for text longer than MaxLength bytes.
+var ErrTooLong = errors.New("text is too long for a QR code")
+
+// version describes the error correction blocks of one QR version at level
+// M, and where its alignment patterns are.
+type version struct {
+	ecPerBlock int   // Error correction codewords in each block
+	blocks     []int // Data codewords in each block, shorter blocks first
+	alignment  []int // Row and column centers of the alignment patterns
+}
+
+// versions holds versions 1 to 10 at error correction level M.
+var versions = []version{
+	{10, []int{16}, nil},
+	{16, []int{28}, []int{6, 18}},
+	{26, []int{44}, []int{6, 22}},
+	{18, []int{32, 32}, []int{6, 26}},
+	{24, []int{43, 43}, []int{6, 30}},
+	{16, []int{27, 27, 27, 27}, []int{6, 34}},
+	{18, []int{31, 31, 31, 31}, []int{6, 22, 38}},
+	{22, []int{38, 38, 39, 39}, []int{6, 24, 42}},
+	{22, []int{36, 36, 36, 37, 37}, []int{6, 26, 46}},
+	{26, []int{43, 43, 43, 43, 44}, []int{6, 28, 50}},
+}
+
+// dataCodewords returns the number of data codewords the version holds.
+func (v version) dataCodewords() int {
+	total := 0
+	for _, n := range v.blocks {
+		total += n
+	}
+	return total
+}
+
+// Code is an encoded QR code: a square of dark and light modules, without
+// the quiet zone around it.
+type Code struct {
+	Size    int // Modules on each side
+	modules [][]bool
+}
+
+// Dark reports whether the module at column x and row y is dark. Modules
+// outside the code, in the quiet zone, are light.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Encode encodes text as a QR code in byte mode at error correction level M,
+// using the smallest version that holds it and the mask that makes it
+// easiest to scan.
+//
+// Parameters:
+//   - text: The text to encode, such as "https://www.linkedin.com/in/janedoe"
+//
+// Returns:
+//   - *Code: The QR code
+//   - error: ErrTooLong if the text is longer than MaxLength bytes, or an
+//     error if it is empty
+//
+// Example:
+//
+//	code, err := qr.Encode("https://janedoe.dev")
+//	svg := code.SVG()
+func Encode(text string) (*Code, error) {
+	if text == "" {
+		return nil, errors.New("nothing to encode in a QR code")
+	}
+	if len(text) > MaxLength {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d", ErrTooLong, len(text), MaxLength)
+	}
+
+	number := 1
+	for ; number <= len(versions); number++ {
+		if 4+countBits(number)+8*len(text) <= 8*versions[number-1].dataCodewords() {
+			break
+		}
+	}
+	v := versions[number-1]
+
+	m := newMatrix(number)
+	m.drawFunctionPatterns(v)
+	m.drawCodewords(interleave(encodeData(text, number, v), v))
+
+	// The mask with the lowest penalty is kept
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormatBits(mask)
+		if penalty := m.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		m.applyMask(mask)
+	}
+	m.applyMask(best)
+	m.drawFormatBits(best)
+
+	return &Code{Size: m.size, modules: m.modules}, nil
+}
+
+// countBits returns the length of the character count in byte mode.
+func countBits(number int) int {
+	if number < 10 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData returns the data codewords: the mode, the length, the text,
+// and padding up to the version's capacity.
+func encodeData(text string, number int, v version) []byte {
+	var bits bitBuffer
+	bits.append(0b0100, 4)
+	bits.append(len(text), countBits(number))
+	for i := 0; i < len(text); i++ {
+		bits.append(int(text[i]), 8)
+	}
+
+	capacity := 8 * v.dataCodewords()
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	data := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return data
+}
+
+// interleave splits data into the version's blocks, adds the error
+// correction codewords to each, and interleaves them in the order they are
+// placed in the code.
+func interleave(data []byte, v version) []byte {
+	divisor := reedSolomonDivisor(v.ecPerBlock)
+	dataBlocks := make([][]byte, len(v.blocks))
+	ecBlocks := make([][]byte, len(v.blocks))
+	for i, n := range v.blocks {
+		dataBlocks[i], data = data[:n], data[n:]
+		ecBlocks[i] = reedSolomonRemainder(dataBlocks[i], divisor)
+	}
+
+	var result []byte
+	longest := v.blocks[len(v.blocks)-1]
+	for i := 0; i < longest; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// bitBuffer is a sequence of bits, most significant first.
+type bitBuffer []bool
+
+// append adds the low n bits of value to the buffer.
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
@@ -0,0 +1,178 @@
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// "HELLO WORLD" as version 1-M, from the worked example of the specification
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := reedSolomonRemainder(data, reedSolomonDivisor(10)); !slices.Equal(got, want) {
+		t.Errorf("reedSolomonRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	formats := []string{
+		"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+		"100010111111001", "100000011001110", "100111110010111", "100101010100000",
+	}
+	for mask, want := range formats {
+		if got := fmt.Sprintf("%015b", formatBits(mask)); got != want {
+			t.Errorf("formatBits(%d) = %s, want %s", mask, got, want)
+		}
+	}
+
+	versionInfo := map[int]string{7: "000111110010010100", 8: "001000010110111100", 9: "001001101010011001", 10: "001010010011010011"}
+	for number, want := range versionInfo {
+		if got := fmt.Sprintf("%018b", versionBits(number)); got != want {
+			t.Errorf("versionBits(%d) = %s, want %s", number, got, want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		length int
+		size   int
+	}{
+		{1, 21},
+		{14, 21},
+		{15, 25},
+		{122, 45},
+		{123, 49},
+		{MaxLength, 57},
+	}
+	for _, tt := range tests {
+		text := strings.Repeat("a", tt.length)
+		code, err := Encode(text)
+		if err != nil {
+			t.Fatalf("Encode(%d bytes) error = %v", tt.length, err)
+		}
+		if code.Size != tt.size {
+			t.Errorf("Encode(%d bytes) size = %d, want %d", tt.length, code.Size, tt.size)
+		}
+
+		// The three finders and the dark module are in place
+		for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+			if !code.Dark(corner[0], corner[1]) || !code.Dark(corner[0]+3, corner[1]+3) || code.Dark(corner[0]+1, corner[1]+1) {
+				t.Errorf("Expected a finder pattern at %v", corner)
+			}
+		}
+		if !code.Dark(8, code.Size-8) {
+			t.Error("Expected the dark module next to the bottom left finder")
+		}
+
+		// Unmasking with the mask named in the format bits gives back the codewords
+		number := (code.Size - 17) / 4
+		if got, want := readCodewords(t, code), interleave(encodeData(text, number, versions[number-1]), versions[number-1]); !slices.Equal(got, want) {
+			t.Errorf("Encode(%d bytes) codewords do not match the encoded data", tt.length)
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", MaxLength+1)); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Expected ErrTooLong, got %v", err)
+	}
+	if _, err := Encode(""); err == nil {
+		t.Error("Expected an error for empty text")
+	}
+}
+
+// readCodewords reads the codewords back from a code, as a scanner would:
+// it finds the mask in the format bits, undoes it, and reads the data
+// modules in placement order.
+func readCodewords(t *testing.T, code *Code) []byte {
+	t.Helper()
+	bits := 0
+	for i := 14; i >= 9; i-- {
+		bits = bits<<1 | boolBit(code.Dark(14-i, 8))
+	}
+	bits = bits<<1 | boolBit(code.Dark(7, 8))
+	bits = bits<<1 | boolBit(code.Dark(8, 8))
+	bits = bits<<1 | boolBit(code.Dark(8, 7))
+	for i := 5; i >= 0; i-- {
+		bits = bits<<1 | boolBit(code.Dark(8, i))
+	}
+	mask := -1
+	for candidate := 0; candidate < 8; candidate++ {
+		if formatBits(candidate) == bits {
+			mask = candidate
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("Format bits %015b name no mask", bits)
+	}
+
+	m := newMatrix((code.Size - 17) / 4)
+	m.drawFunctionPatterns(versions[m.number-1])
+	for y := range m.modules {
+		copy(m.modules[y], code.modules[y])
+	}
+	m.applyMask(mask)
+
+	var codewords []byte
+	var current, count int
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < m.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vertical
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vertical
+				}
+				if m.isFunction[y][x] {
+					continue
+				}
+				current = current<<1 | boolBit(m.modules[y][x])
+				if count++; count%8 == 0 {
+					codewords = append(codewords, byte(current))
+					current = 0
+				}
+			}
+		}
+	}
+	return codewords
+}
+
+func boolBit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode("https://janedoe.dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svg := code.SVG()
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `viewBox="0 0 33 33"`) {
+		t.Errorf("Expected an SVG with a 4 module quiet zone, got %.80s", svg)
+	}
+	if !strings.Contains(svg, "M4 4h7v1h-7z") {
+		t.Error("Expected the top row of the first finder as one rectangle")
+	}
+
+	data, err := code.PNG(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Expected a valid PNG, got %v", err)
+	}
+	if size := img.Bounds().Dx(); size != 33*4 {
+		t.Errorf("Expected a %d pixel image, got %d", 33*4, size)
+	}
+}
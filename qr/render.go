@@ -0,0 +1,75 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// QuietZone is the number of light modules kept around the code, as the
+// QR specification requires for scanners to find it.
+const QuietZone = 4
+
+// SVG renders the code as an SVG image with its quiet zone, one unit per
+// module, so it scales to whatever size CSS gives it. Each row's runs of
+// dark modules are drawn as rectangles of a single path.
+//
+// Returns:
+//   - string: The <svg> element
+//
+// Example:
+//
+//	page := "<div class=\"qr\">" + code.SVG() + "</div>"
+func (c *Code) SVG() string {
+	size := c.Size + 2*QuietZone
+	var path strings.Builder
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.Dark(x, y) || c.Dark(x-1, y) {
+				continue
+			}
+			run := 1
+			for c.Dark(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", x+QuietZone, y+QuietZone, run, run)
+		}
+	}
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+		`<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="%s"/></svg>`,
+		size, size, size, size, path.String())
+}
+
+// PNG renders the code as a black and white PNG image with its quiet zone,
+// scale pixels per module.
+//
+// Parameters:
+//   - scale: The width and height of each module in pixels
+//
+// Returns:
+//   - []byte: The PNG file
+//   - error: An error if the image could not be encoded
+//
+// Example:
+//
+//	data, err := code.PNG(8)
+func (c *Code) PNG(scale int) ([]byte, error) {
+	size := (c.Size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if c.Dark(x/scale-QuietZone, y/scale-QuietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+)
+
+// staleError is returned by remind -cron when resumes need regenerating, so
+// the run exits with exitStale.
+type staleError struct {
+	profiles []string
+	days     int
+}
+
+// Error lists the stale profiles and the threshold they passed.
+func (e staleError) Error() string {
+	return fmt.Sprintf("%s (older than %d days)", strings.Join(e.profiles, ", "), e.days)
+}
+
+// freshness is how long ago a profile's resume was last generated.
+type freshness struct {
+	revision history.Revision
+	days     int  // Whole days since the resume was generated
+	stale    bool // Whether it is older than the threshold
+}
+
+// checkFreshness reports how long ago each revision was generated and
+// whether it is older than staleAfter days.
+func checkFreshness(latest []history.Revision, staleAfter int, now time.Time) []freshness {
+	results := make([]freshness, len(latest))
+	for i, revision := range latest {
+		age := now.Sub(revision.Saved)
+		results[i] = freshness{
+			revision: revision,
+			days:     int(age.Hours() / 24),
+			stale:    age > time.Duration(staleAfter)*24*time.Hour,
+		}
+	}
+	return results
+}
+
+// runRemind writes when each profile's resume was last generated to w, and
+// how to regenerate the ones older than the threshold. With -cron only the
+// stale resumes are written, one tab-separated line each, and a staleError
+// is returned when there are any.
+func runRemind(flags input.RemindFlags, historyDir string, now time.Time, w io.Writer) error {
+	latest, err := history.LatestOfEach(historyDir)
+	if err != nil {
+		return err
+	}
+	if flags.Profile != "" {
+		var matching []history.Revision
+		for _, revision := range latest {
+			if revision.Profile == flags.Profile {
+				matching = append(matching, revision)
+			}
+		}
+		latest = matching
+	}
+
+	results := checkFreshness(latest, flags.StaleAfter, now)
+	if flags.Cron {
+		var stale []string
+		for _, result := range results {
+			if result.stale {
+				fmt.Fprintf(w, "stale\t%s\t%d\t%s\t%s\n", result.revision.Profile, result.days, result.revision.Saved.Format("2006-01-02"), result.revision.Path)
+				stale = append(stale, result.revision.Profile)
+			}
+		}
+		if len(stale) > 0 {
+			return staleError{profiles: stale, days: flags.StaleAfter}
+		}
+		return nil
+	}
+
+	if len(results) == 0 {
+		if flags.Profile != "" {
+			fmt.Fprintf(w, "No saved resumes for %s in %s.\n", flags.Profile, historyDir)
+		} else {
+			fmt.Fprintf(w, "No saved resumes in %s yet; every resume you generate is kept there, with when it was generated.\n", historyDir)
+		}
+		return nil
+	}
+	fmt.Fprint(w, formatReminders(results, flags.StaleAfter))
+	return nil
+}
+
+// formatReminders lists when each profile's resume was generated, oldest
+// first, followed by the command that regenerates each stale one from its
+// last version.
+func formatReminders(results []freshness, staleAfter int) string {
+	width, ageWidth := 0, 0
+	for _, result := range results {
+		width = max(width, len(result.revision.Profile))
+		ageWidth = max(ageWidth, len(plural(result.days, "day")))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Resumes are due for regenerating after %d days.\n\n", staleAfter)
+	var stale []freshness
+	for _, result := range results {
+		status := fmt.Sprintf("fresh for %s", plural(staleAfter-result.days, "more day"))
+		if staleAfter <= result.days {
+			status = "due today"
+		}
+		if result.stale {
+			status = "stale"
+			stale = append(stale, result)
+		}
+		fmt.Fprintf(&b, "  %-*s  %s  %*s ago  %s\n", width, result.revision.Profile, result.revision.Saved.Format("2006-01-02"), ageWidth, plural(result.days, "day"), status)
+	}
+
+	for _, result := range stale {
+		fmt.Fprintf(&b, "\n%s was last generated %s ago. Regenerate it from its last version, adding what has changed since:\n  resumake -source %s\n",
+			result.revision.Profile, plural(result.days, "day"), result.revision.Path)
+	}
+	return b.String()
+}
+
+// plural returns n followed by unit, with an "s" unless n is 1.
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+)
+
+func TestRunRemind(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.Local)
+	flags := input.RemindFlags{StaleAfter: 90}
+
+	// Test case 1: An empty history says so
+	var out strings.Builder
+	if err := runRemind(flags, dir, now, &out); err != nil || !strings.Contains(out.String(), "No saved resumes") {
+		t.Fatalf("Expected an empty history, got %q (%v)", out.String(), err)
+	}
+
+	jane, err := history.Save(dir, "# Jane Doe\n- Built X", now.AddDate(0, 0, -120))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := history.Save(dir, "# John Roe\n- Sold Y", now.AddDate(0, 0, -10)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 2: Each profile is listed, oldest first, with how to regenerate the stale one
+	out.Reset()
+	if err := runRemind(flags, dir, now, &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"jane-doe  2024-05-04  120 days ago  stale",
+		"john-roe  2024-08-22   10 days ago  fresh for 80 more days",
+		"resumake -source " + jane.Path,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the reminders, got %q", want, out.String())
+		}
+	}
+	if strings.Index(out.String(), "jane-doe") > strings.Index(out.String(), "john-roe") {
+		t.Errorf("Expected the oldest resume first, got %q", out.String())
+	}
+
+	// Test case 3: Cron mode prints only the stale resumes and fails with exitStale
+	out.Reset()
+	flags.Cron = true
+	err = runRemind(flags, dir, now, &out)
+	if exitCode(err) != exitStale || !errors.As(err, &staleError{}) {
+		t.Errorf("Expected a stale error, got %v", err)
+	}
+	if out.String() != "stale\tjane-doe\t120\t2024-05-04\t"+jane.Path+"\n" {
+		t.Errorf("Unexpected cron status %q", out.String())
+	}
+
+	// Test case 4: Cron mode is silent when every resume is fresh
+	out.Reset()
+	flags.StaleAfter = 365
+	if err := runRemind(flags, dir, now, &out); err != nil || out.String() != "" {
+		t.Errorf("Expected no output and no error, got %q (%v)", out.String(), err)
+	}
+
+	// Test case 5: A profile is checked on its own
+	out.Reset()
+	flags = input.RemindFlags{StaleAfter: 90, Profile: "john-roe"}
+	if err := runRemind(flags, dir, now, &out); err != nil || strings.Contains(out.String(), "jane-doe") {
+		t.Errorf("Expected only john-roe's resume, got %q (%v)", out.String(), err)
+	}
+}
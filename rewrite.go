@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// runRewrite rewrites a single bullet and prints the alternatives to w.
+// It calls the API directly, without the TUI or the resume pipeline.
+func runRewrite(ctx context.Context, flags input.RewriteFlags, w io.Writer) error {
+	apiKey, err := api.GetAPIKey()
+	if err != nil {
+		return fmt.Errorf("API key error: %w", err)
+	}
+
+	client, _, err := api.InitializeClientWithModel(ctx, apiKey, flags.Model)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+	defer client.Close()
+
+	model, err := api.NewRewriteModel(client, flags.Model)
+	if err != nil {
+		return err
+	}
+
+	response, err := api.ExecuteRequest(ctx, model, prompt.GenerateRewritePromptContent(flags.Bullet, flags.Count))
+	if err != nil {
+		return err
+	}
+
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return err
+	}
+
+	rewrites := prompt.ParseRewrites(text)
+	if len(rewrites) == 0 {
+		return errors.New("the response contained no rewritten bullets; try rephrasing the bullet")
+	}
+
+	fmt.Fprint(w, formatRewrites(rewrites))
+	return nil
+}
+
+// formatRewrites renders the alternatives as a numbered list, with each
+// metric prompt indented under its bullet.
+func formatRewrites(rewrites []prompt.Rewrite) string {
+	var b strings.Builder
+	for i, rewrite := range rewrites {
+		fmt.Fprintf(&b, "\n%d. %s\n", i+1, rewrite.Bullet)
+		if rewrite.MetricPrompt != "" {
+			fmt.Fprintf(&b, "   ↳ %s\n", rewrite.MetricPrompt)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/phrazzld/resumake/prompt"
+)
+
+func TestFormatRewrites(t *testing.T) {
+	got := formatRewrites([]prompt.Rewrite{
+		{Bullet: "Cut checkout latency by [X%]", MetricPrompt: "By how much did latency drop?"},
+		{Bullet: "Rebuilt the checkout page in React"},
+	})
+
+	want := "\n1. Cut checkout latency by [X%]\n   ↳ By how much did latency drop?\n" +
+		"\n2. Rebuilt the checkout page in React\n"
+	if got != want {
+		t.Errorf("formatRewrites() = %q, want %q", got, want)
+	}
+}
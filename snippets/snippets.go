@@ -0,0 +1,219 @@
+// Package snippets manages a library of reusable resume blurbs.
+//
+// Snippets are plain text or Markdown files stored in the snippets directory
+// inside the resumake configuration directory (see config.Dir). Each file is
+// one snippet, named after the file without its extension, e.g. a standard
+// professional summary, a certifications block, or a publications list.
+// Snippets can be searched with a fuzzy matcher and inserted into the notes
+// the user is writing.
+package snippets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/phrazzld/resumake/config"
+)
+
+// DirName is the name of the snippets directory inside the configuration directory.
+const DirName = "snippets"
+
+// SupportedExtensions lists the file extensions that are loaded as snippets.
+var SupportedExtensions = []string{".md", ".txt", ".markdown"}
+
+// Snippet is a single reusable blurb.
+type Snippet struct {
+	// Name is the display name of the snippet, derived from its file name.
+	Name string
+
+	// Content is the text inserted when the snippet is chosen.
+	Content string
+
+	// Path is the file the snippet was loaded from.
+	Path string
+}
+
+// DefaultDir returns the default snippets directory inside the configuration
+// directory, or inside the sync directory when one is set (see config.DataPath).
+//
+// Returns:
+//   - string: The snippets directory path
+//   - error: An error if the configuration directory cannot be determined
+func DefaultDir() (string, error) {
+	return config.DataPath(DirName)
+}
+
+// Load reads every supported snippet file from the given directory.
+// A missing directory is not an error; it simply yields no snippets.
+// Snippets are returned sorted by name.
+//
+// Parameters:
+//   - dir: The directory to load snippets from
+//
+// Returns:
+//   - []Snippet: The loaded snippets
+//   - error: Any error that occurred while reading the directory or its files
+//
+// Example:
+//
+//	dir, _ := snippets.DefaultDir()
+//	library, err := snippets.Load(dir)
+//	if err != nil {
+//	    log.Printf("Could not load snippets: %v", err)
+//	}
+func Load(dir string) ([]Snippet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading snippets directory %s: %w", dir, err)
+	}
+
+	var result []Snippet
+	for _, entry := range entries {
+		if entry.IsDir() || !isSupported(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading snippet %s: %w", path, err)
+		}
+
+		result = append(result, Snippet{
+			Name:    strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Content: strings.TrimSpace(string(content)),
+			Path:    path,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+
+	return result, nil
+}
+
+// Save writes a snippet to the given directory as name.md, creating the
+// directory if needed, so it can be inserted into the notes later. An
+// existing snippet with the same name is replaced.
+//
+// Parameters:
+//   - dir: The snippets directory
+//   - name: The snippet name, used as the file name without its extension
+//   - content: The snippet text
+//
+// Returns:
+//   - string: The path of the saved snippet
+//   - error: An error if the name is not a plain file name or the file cannot be written
+//
+// Example:
+//
+//	dir, _ := snippets.DefaultDir()
+//	path, err := snippets.Save(dir, "achievements-2024", bullets)
+func Save(dir, name, content string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid snippet name %q: use a plain name such as achievements-2024", name)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("error creating snippets directory %s: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, name+".md")
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("error writing snippet %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// Search filters snippets with a fuzzy match of the query against snippet names.
+// A snippet matches when all query characters appear in its name in order
+// (case-insensitive). Results are ranked so that prefix and contiguous matches
+// come first. An empty query returns all snippets unchanged.
+//
+// Parameters:
+//   - library: The snippets to search
+//   - query: The fuzzy search text
+//
+// Returns:
+//   - []Snippet: The matching snippets, best match first
+//
+// Example:
+//
+//	matches := snippets.Search(library, "cert")
+func Search(library []Snippet, query string) []Snippet {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return library
+	}
+
+	type scored struct {
+		snippet Snippet
+		score   int
+	}
+
+	var matches []scored
+	for _, s := range library {
+		if score, ok := fuzzyScore(strings.ToLower(s.Name), query); ok {
+			matches = append(matches, scored{snippet: s, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]Snippet, len(matches))
+	for i, m := range matches {
+		result[i] = m.snippet
+	}
+	return result
+}
+
+// fuzzyScore reports whether query is a subsequence of name and, if so,
+// a score that rewards matches at the start of the name and consecutive runs.
+func fuzzyScore(name, query string) (int, bool) {
+	score := 0
+	qi := 0
+	queryRunes := []rune(query)
+	prevMatch := -2
+
+	for i, r := range []rune(name) {
+		if qi == len(queryRunes) {
+			break
+		}
+		if r != queryRunes[qi] {
+			continue
+		}
+
+		score++
+		if i == 0 {
+			score += 3
+		}
+		if prevMatch == i-1 {
+			score += 2
+		}
+		prevMatch = i
+		qi++
+	}
+
+	return score, qi == len(queryRunes)
+}
+
+// isSupported reports whether the file name has a supported snippet extension.
+func isSupported(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, supported := range SupportedExtensions {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package snippets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("Missing directory", func(t *testing.T) {
+		library, err := Load(filepath.Join(t.TempDir(), "missing"))
+		if err != nil {
+			t.Errorf("Expected no error for missing directory, got %v", err)
+		}
+		if len(library) != 0 {
+			t.Errorf("Expected no snippets, got %d", len(library))
+		}
+	})
+
+	t.Run("Loads supported files sorted by name", func(t *testing.T) {
+		dir := t.TempDir()
+		files := map[string]string{
+			"summary.md":         "  Seasoned engineer.  \n",
+			"Certifications.txt": "- CKA",
+			"notes.json":         "{}",
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write snippet: %v", err)
+			}
+		}
+		if err := os.Mkdir(filepath.Join(dir, "archive.md"), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+
+		library, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(library) != 2 {
+			t.Fatalf("Expected 2 snippets, got %d", len(library))
+		}
+		if library[0].Name != "Certifications" || library[1].Name != "summary" {
+			t.Errorf("Unexpected snippet order: %q, %q", library[0].Name, library[1].Name)
+		}
+		if library[1].Content != "Seasoned engineer." {
+			t.Errorf("Expected trimmed content, got %q", library[1].Content)
+		}
+	})
+}
+
+func TestSave(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snippets")
+
+	// Test case 1: The snippet is saved as Markdown and loads back under its name
+	path, err := Save(dir, "achievements-2024", "- Built PDF export\n")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if path != filepath.Join(dir, "achievements-2024.md") {
+		t.Errorf("Unexpected snippet path %q", path)
+	}
+	library, err := Load(dir)
+	if err != nil || len(library) != 1 || library[0].Name != "achievements-2024" || library[0].Content != "- Built PDF export" {
+		t.Errorf("Expected the saved snippet to load, got %+v (%v)", library, err)
+	}
+
+	// Test case 2: Names that are not plain file names are rejected
+	for _, name := range []string{"", "../escape", "a/b", ".."} {
+		if _, err := Save(dir, name, "text"); err == nil {
+			t.Errorf("Expected an error for snippet name %q", name)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	library := []Snippet{
+		{Name: "publications"},
+		{Name: "certifications"},
+		{Name: "summary"},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"empty query returns all", "", []string{"publications", "certifications", "summary"}},
+		{"prefix match", "sum", []string{"summary"}},
+		{"subsequence match", "crt", []string{"certifications"}},
+		{"case insensitive", "PUB", []string{"publications"}},
+		{"ranked by quality", "ca", []string{"certifications", "publications"}},
+		{"no match", "xyz", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Search(library, tt.query)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected %d results, got %d", len(tt.expected), len(got))
+			}
+			for i, name := range tt.expected {
+				if got[i].Name != name {
+					t.Errorf("Result %d: expected %q, got %q", i, name, got[i].Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/phrazzld/resumake/config"
+)
+
+// syncCommitMessage is the message of the commits sync makes, followed by
+// the name of the machine the changes were made on.
+const syncCommitMessage = "Sync resumake data from "
+
+// runSync brings the sync directory up to date with the other machines. In
+// a git repository it commits the local changes, then pulls and pushes them
+// when the repository has a remote; a synced folder is kept up to date by
+// its sync client, so there is nothing to do. It reports what it did to w.
+func runSync(ctx context.Context, dir string, w io.Writer) error {
+	if dir == "" {
+		path, _ := config.SettingsPath()
+		return fmt.Errorf("no sync directory is set; add a line such as \"%s = ~/resumake-data\" to %s", config.SettingSyncDir, path)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("sync directory %s does not exist; create it, or clone your repository there", dir)
+	}
+
+	if _, err := syncGit(ctx, dir, "rev-parse", "--show-toplevel"); err != nil {
+		fmt.Fprintf(w, "%s is not a git repository, so its sync client (such as Dropbox or iCloud Drive) keeps it up to date; there is nothing to do.\n", dir)
+		return nil
+	}
+
+	if _, err := syncGit(ctx, dir, "add", "--all", "--", "."); err != nil {
+		return err
+	}
+	status, err := syncGit(ctx, dir, "status", "--porcelain", "--", ".")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(status) == "" {
+		fmt.Fprintln(w, "No local changes to commit.")
+	} else {
+		host, _ := os.Hostname()
+		if host == "" {
+			host = "another machine"
+		}
+		if _, err := syncGit(ctx, dir, "commit", "--quiet", "-m", syncCommitMessage+host, "--", "."); err != nil {
+			return err
+		}
+		changed := len(strings.Split(strings.TrimSpace(status), "\n"))
+		fmt.Fprintf(w, "Committed %s.\n", plural(changed, "changed file"))
+	}
+
+	remotes, err := syncGit(ctx, dir, "remote")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(remotes) == "" {
+		fmt.Fprintf(w, "%s has no remote, so the changes stay on this machine; add one with git remote add to share them.\n", dir)
+		return nil
+	}
+	if _, err := syncGit(ctx, dir, "pull", "--rebase", "--quiet"); err != nil {
+		return err
+	}
+	if _, err := syncGit(ctx, dir, "push", "--quiet"); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Pulled and pushed %s; it is up to date with the other machines.\n", dir)
+	return nil
+}
+
+// syncGit runs git in dir and returns its output. A failure reports what git
+// printed, such as a merge conflict to resolve.
+func syncGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New("git " + args[0] + " failed: " + message)
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return string(out), nil
+}
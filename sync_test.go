@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunSync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("RESUMAKE_CONFIG_DIR", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "Jane Doe")
+	t.Setenv("GIT_AUTHOR_EMAIL", "jane@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Jane Doe")
+	t.Setenv("GIT_COMMITTER_EMAIL", "jane@example.com")
+
+	root := t.TempDir()
+	git := func(dir string, args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	run := func(dir string) (string, error) {
+		var out strings.Builder
+		err := runSync(context.Background(), dir, &out)
+		return out.String(), err
+	}
+
+	// Test case 1: Without a sync directory there is nothing to sync
+	if _, err := run(""); err == nil || !strings.Contains(err.Error(), "sync-dir") {
+		t.Errorf("Expected a missing setting error, got %v", err)
+	}
+
+	// Test case 2: A folder that is not a git repository needs nothing
+	folder := filepath.Join(root, "Dropbox")
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run(folder); err != nil || !strings.Contains(out, "nothing to do") {
+		t.Errorf("Expected nothing to do, got %q (%v)", out, err)
+	}
+
+	// Test case 3: Changes are committed and pushed, then pulled on another machine
+	remote := filepath.Join(root, "remote.git")
+	git(root, "init", "--quiet", "--bare", remote)
+	laptop, desktop := filepath.Join(root, "laptop"), filepath.Join(root, "desktop")
+	git(root, "clone", "--quiet", remote, laptop)
+	if err := os.MkdirAll(filepath.Join(laptop, "snippets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(laptop, "snippets", "summary.md"), []byte("Backend engineer."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git(laptop, "add", "--all")
+	git(laptop, "commit", "--quiet", "-m", "Add summary")
+	git(laptop, "push", "--quiet", "origin", "HEAD")
+	git(root, "clone", "--quiet", remote, desktop)
+
+	if err := os.WriteFile(filepath.Join(laptop, "snippets", "certifications.md"), []byte("CKA"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := run(laptop)
+	if err != nil || !strings.Contains(out, "Committed 1 changed file") || !strings.Contains(out, "Pulled and pushed") {
+		t.Fatalf("Expected the change to be committed and pushed, got %q (%v)", out, err)
+	}
+	if out, err := run(desktop); err != nil || !strings.Contains(out, "No local changes") {
+		t.Fatalf("Expected only a pull, got %q (%v)", out, err)
+	}
+	if _, err := os.Stat(filepath.Join(desktop, "snippets", "certifications.md")); err != nil {
+		t.Errorf("Expected the snippet to be pulled, got %v", err)
+	}
+	if log := git(desktop, "log", "-1", "--format=%s"); !strings.HasPrefix(log, syncCommitMessage) {
+		t.Errorf("Expected a sync commit, got %q", log)
+	}
+
+	// Test case 4: A repository without a remote keeps its commits
+	local := filepath.Join(root, "local")
+	git(root, "init", "--quiet", local)
+	if err := os.WriteFile(filepath.Join(local, "settings"), []byte("qr = example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run(local); err != nil || !strings.Contains(out, "has no remote") {
+		t.Errorf("Expected a note about the remote, got %q (%v)", out, err)
+	}
+}
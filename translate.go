@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// runTranslate localizes the resume into the requested language and writes
+// the translation next to it, or to -output, reporting the path to w.
+func runTranslate(ctx context.Context, flags input.TranslateFlags, w io.Writer) error {
+	path, err := translationPath(flags)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Translating %s into %s...\n", flags.SourcePath, flags.Language)
+	if flags.SourceWarning != "" {
+		fmt.Fprintf(w, "Warning: %s\n", flags.SourceWarning)
+	}
+
+	apiKey, err := api.GetAPIKey()
+	if err != nil {
+		return fmt.Errorf("API key error: %w", err)
+	}
+
+	client, _, err := api.InitializeClientWithModel(ctx, apiKey, flags.Model)
+	if err != nil {
+		return fmt.Errorf("failed to initialize API client: %w", err)
+	}
+	defer client.Close()
+
+	model, err := api.NewTranslateModel(client, flags.Model)
+	if err != nil {
+		return err
+	}
+
+	response, err := api.ExecuteRequest(ctx, model, prompt.GenerateTranslatePromptContent(flags.SourceContent, flags.Language))
+	if err != nil {
+		return err
+	}
+
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return err
+	}
+
+	translation, err := output.ExtractAndValidateMarkdown(text)
+	if err != nil {
+		return fmt.Errorf("the translation is not a usable resume: %w", err)
+	}
+
+	if err := output.WriteToFile(path, translation); err != nil {
+		return fmt.Errorf("error writing the translation: %w", err)
+	}
+	fmt.Fprintf(w, "Saved the %s resume at %s\n", flags.Language, path)
+	return nil
+}
+
+// translationPath returns where the translation is written: -output, or
+// next to the resume, named after the language. It never replaces the
+// resume being translated.
+func translationPath(flags input.TranslateFlags) (string, error) {
+	path := flags.OutputPath
+	if path == "" {
+		path = output.TranslationPath(flags.SourcePath, flags.Language)
+	}
+
+	source, sourceErr := filepath.Abs(flags.SourcePath)
+	target, targetErr := filepath.Abs(path)
+	if sourceErr == nil && targetErr == nil && source == target {
+		return "", errors.New("the translation would replace the resume being translated; choose another -output path")
+	}
+	return path, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+)
+
+func TestTranslationPath(t *testing.T) {
+	// Test case 1: By default the translation is named after the language
+	path, err := translationPath(input.TranslateFlags{SourcePath: "cv/jane.md", Language: "German"})
+	if err != nil || path != "cv/jane_german.md" {
+		t.Errorf("translationPath() = %q, %v; want cv/jane_german.md", path, err)
+	}
+
+	// Test case 2: -output is used as given
+	path, err = translationPath(input.TranslateFlags{SourcePath: "cv/jane.md", Language: "German", OutputPath: "lebenslauf.md"})
+	if err != nil || path != "lebenslauf.md" {
+		t.Errorf("translationPath() = %q, %v; want lebenslauf.md", path, err)
+	}
+
+	// Test case 3: The resume being translated is never replaced
+	if _, err := translationPath(input.TranslateFlags{SourcePath: "cv/jane.md", Language: "German", OutputPath: "cv/../cv/jane.md"}); err == nil {
+		t.Error("Expected an error when -output is the resume itself")
+	}
+}
+
+func TestRunTranslate(t *testing.T) {
+	// The API is stood in for by a server reached through the gateway setting
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Gern!\n` + api.ResumeStartDelimiter + `\n# Jane Doe\n\n## Berufserfahrung\n\n### Softwareentwicklerin, Acme (2020 - 2023)\n- Entwickelte den Checkout-Service in Go"}]}, "finishReason": "STOP"}]}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("GEMINI_API_KEY", "test-key")
+	t.Setenv(api.GatewayTokenEnv, "")
+	if err := api.SetGateway(server.URL, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { api.ActiveGateway = api.Gateway{} })
+
+	sourcePath := writeConvertResume(t)
+	var out strings.Builder
+	flags := input.TranslateFlags{SourcePath: sourcePath, SourceContent: convertResume, Language: "German", Model: api.DefaultModelName}
+	if err := runTranslate(context.Background(), flags, &out); err != nil {
+		t.Fatalf("runTranslate returned error: %v", err)
+	}
+
+	if !strings.Contains(requestBody, "into German") || !strings.Contains(requestBody, "Built the checkout service") {
+		t.Errorf("Expected the language and resume in the request, got %s", requestBody)
+	}
+
+	path := filepath.Join(filepath.Dir(sourcePath), "jane_german.md")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the translation at %s: %v", path, err)
+	}
+	if !strings.HasPrefix(string(content), "# Jane Doe") || !strings.Contains(string(content), "## Berufserfahrung") || strings.Contains(string(content), "Gern!") {
+		t.Errorf("Expected only the translated resume to be saved, got %q", content)
+	}
+	if !strings.Contains(out.String(), path) {
+		t.Errorf("Expected the translation path to be reported, got %q", out.String())
+	}
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/analysis"
+)
+
+// renderAnalysisView generates the keyword density and buzzword report for the
+// generated resume. When a job description was provided with --job, the report
+// also shows which of its top keywords the resume covers.
+func renderAnalysisView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+	report := analysis.Analyze(m.resultContent, m.jobDescription)
+
+	title := titleStyle.Render("📈 Keyword Analysis")
+
+	sectionTitle := func(text string) string {
+		return lipgloss.NewStyle().
+			Bold(true).
+			Foreground(highlightColor).
+			Render(text)
+	}
+
+	box := func(color lipgloss.CompleteAdaptiveColor, content string) string {
+		return lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(color).
+			Padding(1, 2).
+			Width(displayWidth - 10).
+			Render(content)
+	}
+
+	// Most frequent terms with their density
+	var terms strings.Builder
+	if len(report.TopTerms) == 0 {
+		terms.WriteString("No terms found in the generated resume.")
+	}
+	for i, tc := range report.TopTerms {
+		fmt.Fprintf(&terms, "%2d. %-20s %3d  (%.1f%%)\n", i+1, tc.Term, tc.Count, report.Density(tc))
+	}
+	termsBox := box(primaryColor, sectionTitle(fmt.Sprintf("🔤 Top Terms (%d words total)", report.TotalWords))+
+		"\n\n"+strings.TrimRight(terms.String(), "\n"))
+
+	// Overused buzzwords
+	var buzzwords string
+	if len(report.Buzzwords) == 0 {
+		buzzwords = successStyle.Render("✓ No common buzzwords found")
+	} else {
+		var b strings.Builder
+		for _, tc := range report.Buzzwords {
+			fmt.Fprintf(&b, "⚠️ %s (%d)\n", tc.Term, tc.Count)
+		}
+		buzzwords = strings.TrimRight(b.String(), "\n") + "\n\n" +
+			italicStyle.Render("Consider replacing these with concrete achievements.")
+	}
+	buzzwordsBox := box(accentColor, sectionTitle("🐝 Buzzwords")+"\n\n"+buzzwords)
+
+	// Job description keyword coverage
+	var keywords string
+	if len(report.Keywords) == 0 {
+		keywords = italicStyle.Render("Run with --job <file> to compare against a job description.")
+	} else {
+		var b strings.Builder
+		covered := 0
+		for _, kc := range report.Keywords {
+			if kc.Covered() {
+				covered++
+				fmt.Fprintf(&b, "%s %-20s job %2d • resume %2d\n", successStyle.Render("✓"), kc.Term, kc.JobCount, kc.ResumeCount)
+			} else {
+				fmt.Fprintf(&b, "%s %-20s job %2d • missing\n", errorStyle.Render("✗"), kc.Term, kc.JobCount)
+			}
+		}
+		keywords = fmt.Sprintf("%d of %d top job keywords covered\n\n", covered, len(report.Keywords)) +
+			strings.TrimRight(b.String(), "\n")
+	}
+	keywordsBox := box(secondaryColor, sectionTitle("🎯 Job Description Keywords")+"\n\n"+keywords)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		termsBox,
+		"",
+		buzzwordsBox,
+		"",
+		keywordsBox,
+	)
+}
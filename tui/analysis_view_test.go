@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAnalysisView(t *testing.T) {
+	resume := "# Jane Doe\n\n- Passionate team player\n- Built Go services in Go\n"
+
+	// Test case 1: Report without a job description
+	t.Run("Without job description", func(t *testing.T) {
+		model := Model{
+			state:         stateAnalysis,
+			resultContent: resume,
+			width:         100,
+		}
+
+		view := renderAnalysisView(model)
+
+		for _, element := range []string{"Keyword Analysis", "Top Terms", "go", "passionate", "team player", "--job"} {
+			if !strings.Contains(view, element) {
+				t.Errorf("Analysis view should contain %q", element)
+			}
+		}
+	})
+
+	// Test case 2: Report with a job description
+	t.Run("With job description", func(t *testing.T) {
+		model := Model{
+			state:          stateAnalysis,
+			resultContent:  resume,
+			jobDescription: "Go engineer with Kubernetes experience",
+			width:          100,
+		}
+
+		view := renderAnalysisView(model)
+
+		if !strings.Contains(view, "top job keywords covered") {
+			t.Error("Analysis view should summarize job keyword coverage")
+		}
+		if !strings.Contains(view, "kubernetes") || !strings.Contains(view, "missing") {
+			t.Error("Analysis view should flag keywords missing from the resume")
+		}
+	})
+}
+
+func TestAnalysisStateTransitions(t *testing.T) {
+	model := NewModel().WithJobDescription("Go engineer")
+	model.state = stateResultSuccess
+	model.resultContent = "# Jane Doe"
+
+	keyA := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}
+
+	// Test case 1: 'a' opens the analysis view from the success view
+	updated, _ := model.Update(keyA)
+	m := updated.(Model)
+	if m.state != stateAnalysis {
+		t.Fatalf("Expected state to be stateAnalysis, got %v", m.state)
+	}
+	if m.jobDescription != "Go engineer" {
+		t.Errorf("Expected job description to be kept, got %q", m.jobDescription)
+	}
+
+	// Test case 2: Enter returns to the success view
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.state != stateResultSuccess {
+		t.Errorf("Expected state to be stateResultSuccess, got %v", m.state)
+	}
+
+	// Test case 3: 'a' has no effect in the error view
+	m.state = stateResultError
+	updated, _ = m.Update(keyA)
+	if updated.(Model).state != stateResultError {
+		t.Error("Expected the error view to ignore the analysis key")
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/generative-ai-go/genai"
@@ -199,10 +200,13 @@ func TestExitHandlersCallCleanup(t *testing.T) {
 			Success: false,
 			Error:   fmt.Errorf("API request failed"),
 		})
-		// Now model should be in error state
+		// Now model should be in error state, where Enter exits once the
+		// guard against a repeated Enter has expired
+		errorModel := updatedModel.(Model)
+		errorModel.acceptGuard = time.Time{}
 		
 		// Send Enter key to exit from error state
-		_, _ = updatedModel.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+		_, _ = errorModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
 		
 		// Verify that cleanup was called
 		if cleanupCalled == 0 {
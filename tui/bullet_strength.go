@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/analysis"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/faults"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// maxWeakBullets is the number of weak bullets listed for a section under
+// review, one for each number key.
+const maxWeakBullets = 9
+
+// weakBullets returns the bullets of the section under review that score
+// low on the impact rubric, in order. Only experience, project, and
+// achievement sections are scored.
+func weakBullets(r sectionReview) []analysis.BulletScore {
+	if r.done() || r.onHeader() || !analysis.ImpactSection(r.current().Heading) {
+		return nil
+	}
+	var weak []analysis.BulletScore
+	for _, bullet := range analysis.ScoreBullets(r.current().Body) {
+		if bullet.Weak() && len(weak) < maxWeakBullets {
+			weak = append(weak, bullet)
+		}
+	}
+	return weak
+}
+
+// describeWeakBullet returns a weak bullet's score and what it lacks, such
+// as "1/3 · needs a metric and an outcome".
+func describeWeakBullet(bullet analysis.BulletScore) string {
+	missing := bullet.Missing()
+	needs := missing[len(missing)-1]
+	if len(missing) > 1 {
+		needs = strings.Join(missing[:len(missing)-1], ", ") + " and " + needs
+	}
+	return fmt.Sprintf("%d/3 · needs %s", bullet.Score(), needs)
+}
+
+// strengthenBullet starts rewriting the weak bullet listed at number n (from
+// 1) in the section under review.
+func strengthenBullet(m Model, n int) (Model, tea.Cmd) {
+	weak := weakBullets(m.review)
+	if n < 1 || n > len(weak) {
+		return m, nil
+	}
+	m.reviewBusy = fmt.Sprintf("Strengthening bullet %d…", n)
+	m.reviewErr = ""
+	return m, StrengthenBulletCmd(m.ctx, m.apiSession, m.review.index, m.review.current().Heading, weak[n-1], generateOptions(m))
+}
+
+// StrengthenBulletCmd returns a command that asks the model, on the
+// conversation that produced the resume, to rewrite one weak bullet of a
+// section under review with an action verb, a metric, and an outcome.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - session: The conversation that produced the resume
+//   - index: The position of the section in the review
+//   - heading: The heading of the section the bullet is in
+//   - bullet: The bullet and its score
+//   - opts: The generation options, for the seed
+//
+// Returns:
+//   - tea.Cmd: A command that returns a BulletStrengthenedMsg
+func StrengthenBulletCmd(ctx context.Context, session *api.Session, index int, heading string, bullet analysis.BulletScore, opts GenerateOptions) tea.Cmd {
+	return func() tea.Msg {
+		strengthened, err := rewriteBullet(ctx, session, heading, bullet, opts)
+		return BulletStrengthenedMsg{Index: index, Original: bullet.Text, Bullet: strengthened, Error: err}
+	}
+}
+
+// rewriteBullet asks the model, on the conversation that produced the
+// resume, to strengthen one bullet, and returns the new bullet without a
+// list marker.
+func rewriteBullet(ctx context.Context, session *api.Session, heading string, bullet analysis.BulletScore, opts GenerateOptions) (string, error) {
+	if session == nil {
+		return "", errors.New("the conversation that wrote the resume is no longer open")
+	}
+
+	response, err := session.Send(faults.WithStage(seededContext(ctx, opts), faults.StageSection), prompt.GenerateBulletPromptContent(heading, bullet.Text, bullet.Missing()))
+	if err != nil {
+		return "", err
+	}
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return "", err
+	}
+	return strengthenedBullet(text)
+}
+
+// strengthenedBullet extracts the new bullet from the model's reply: its
+// first line of text, without a list marker or citations.
+func strengthenedBullet(text string) (string, error) {
+	for _, line := range strings.Split(output.StripCitations(output.ExtractFencedContent(text)), "\n") {
+		line = strings.TrimSpace(line)
+		for _, marker := range []string{"- ", "* ", "+ ", "• "} {
+			line = strings.TrimSpace(strings.TrimPrefix(line, marker))
+		}
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return output.NormalizeCredentials(line), nil
+		}
+	}
+	return "", errors.New("the response did not contain a bullet")
+}
+
+// applyStrengthenedBullet puts a strengthened bullet in place of the weak
+// one in the section it came from, which stays under review.
+func applyStrengthenedBullet(m Model, msg BulletStrengthenedMsg) Model {
+	m.reviewBusy = ""
+	if msg.Error != nil {
+		m.reviewErr = fmt.Sprintf("The bullet could not be strengthened: %v", msg.Error)
+		return m
+	}
+	if msg.Index >= len(m.review.sections) {
+		return m
+	}
+	body, ok := output.ReplaceBullet(m.review.sections[msg.Index].Body, msg.Original, msg.Bullet)
+	if !ok {
+		m.reviewErr = "The bullet is no longer in the section, so the strengthened version was not used."
+		return m
+	}
+	m.review.sections[msg.Index].Body = body
+	return m
+}
+
+// renderWeakBullets lists the weak bullets of the section under review with
+// their numbers and what each lacks, or returns an empty string when there
+// are none.
+func renderWeakBullets(weak []analysis.BulletScore, width int) string {
+	if len(weak) == 0 {
+		return ""
+	}
+	keys := "1"
+	if len(weak) > 1 {
+		keys = fmt.Sprintf("1-%d", len(weak))
+	}
+	lines := []string{layout.Wrap("💪 Weak bullets: press "+keys+" to strengthen one", width)}
+	for i, bullet := range weak {
+		lines = append(lines, layout.Truncate(fmt.Sprintf("%d. %s", i+1, bullet.Text), width))
+		lines = append(lines, italicStyle.Render("   "+describeWeakBullet(bullet)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -2,14 +2,29 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/analysis"
 	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/faults"
+	"github.com/phrazzld/resumake/history"
 	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/logging"
 	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/pkg/resume"
 	"github.com/phrazzld/resumake/prompt"
+	"github.com/phrazzld/resumake/snippets"
+	"github.com/phrazzld/resumake/usage"
 )
 
 // ReadSourceFileCmd returns a command that reads a source file
@@ -25,7 +40,18 @@ func ReadSourceFileCmd(filePath string) tea.Cmd {
 			}
 		}
 
-		content, err := input.ReadSourceFile(filePath)
+		// PDFs are uploaded to the model as documents instead of read as text
+		isPDF, err := input.DetectPDF(filePath)
+		if isPDF || err != nil {
+			return FileReadResultMsg{
+				Success: err == nil,
+				PDF:     isPDF,
+				Error:   err,
+			}
+		}
+
+		// Instructions planted for AI models, as in resumes from the web, are not sent
+		content, warning, err := input.ReadPromptFile(filePath)
 		if err != nil {
 			return FileReadResultMsg{
 				Success: false,
@@ -35,21 +61,98 @@ func ReadSourceFileCmd(filePath string) tea.Cmd {
 		}
 
 		return FileReadResultMsg{
-			Success: true,
-			Content: content,
-			Error:   nil,
+			Success:   true,
+			Content:   content,
+			Warning:   warning,
+			Structure: readStructure(filePath, content),
+			Error:     nil,
 		}
 	}
 }
 
 
+// readStructure loads the structured form of a source resume that resumake
+// wrote, from its sidecar. A resume without a sidecar, or edited since the
+// sidecar was written, is parsed from its Markdown as usual, so nil is returned.
+func readStructure(filePath, content string) *document.Resume {
+	sidecar, ok, err := output.ReadSidecar(filePath)
+	if err != nil {
+		logging.Debugf("Ignoring the sidecar of %s: %v", filePath, err)
+	}
+	if !ok {
+		return nil
+	}
+	if !sidecar.Describes(content) {
+		logging.Debugf("Ignoring the sidecar of %s: the resume was edited after it was written", filePath)
+		return nil
+	}
+	return &sidecar.Resume
+}
+
+
+// GenerateOptions holds settings that change how GenerateResumeWithOptionsCmd runs.
+type GenerateOptions struct {
+	OutputPath    string                // Flag-provided output path (empty to name the file after the candidate and date)
+	LegacyOutput  bool                  // Write to resume_out.md when no output path is given
+	Bundle        bool                  // Also generate a matching cover letter into a dated directory
+	FallbackModel string                // Model to retry once with if the primary model fails (empty to disable)
+	Skills        []document.Skill      // Structured skills from the skills form
+	Academics     document.Academics    // GPA and coursework for the Education section
+	Preset        prompt.Preset         // The kind of candidate, which sets the tone and section order (empty for standard)
+	Audience      prompt.Audience       // Who reads the resume first, which sets what it emphasizes (empty for general)
+	Structure     *document.Resume      // The structured source from its sidecar, whose sections are kept (nil if it has none)
+	ProseWidth    int                   // Write prose one sentence per line, wrapped at this column (0 to keep the model's lines)
+	Layout        output.Layout         // HTML layout to render alongside the Markdown (empty to skip)
+	Timeline      bool                  // Add a timeline of roles and education to the HTML resume
+	PageBreaks    bool                  // Mark estimated page breaks in the HTML resume, and warn where they split a role's bullets
+	QR            string                // URL to link with a QR code in the HTML and PDF header (empty to skip)
+	QRLayouts     []output.Layout       // Layouts that show the QR code (nil for all)
+	JSONResume    bool                  // Also export the resume in JSON Resume format
+	Exports       []output.ExportFormat // Document formats to convert the resume to
+	Company       string                // Company name or job posting URL to research and tailor to (empty to skip)
+	Emphasize     []string              // Keywords to feature where the inputs support them
+	Grounding     prompt.Grounding      // Whether bullets must cite the input sentences they come from (empty for off)
+	Explain       bool                  // Also ask why the major changes were made and save the notes
+	Gaps          bool                  // Also ask how to close the gaps with the job description and save the suggestions
+	Documents     []prompt.DocumentType // Also write these documents, such as a thank-you email, and save them next to the resume
+	Review        bool                  // Return the resume for its sections to be accepted one at a time instead of saving it
+	Amend         bool                  // The source is the previous resume and the notes are updates to it
+	Fixtures      api.Fixtures          // Record API responses to, or replay them from, disk fixtures
+	TrimOrder     []prompt.TrimStep     // Order to trim input that exceeds the context window (nil for the default)
+	HistoryDir    string                // Directory saved resumes are kept in for comparison (empty to skip)
+	RecentPath    string                // List of recently used files to add the source and output to (empty to skip)
+	SourcePath    string                // Path of the source file, uploaded instead of inlined when it is a PDF or large
+	SourcePDF     bool                  // The source is a PDF, uploaded as a document instead of read as text
+	Pack          bool                  // Also zip the written files, job description, and metadata into one archive
+	JobPath       string                // The file the job description was read from (empty for none)
+	Job           string                // The job description, included in the pack
+	MaxDuration   time.Duration         // Stop generating after this long, keeping the complete sections streamed (0 for no limit)
+	Seed          *int64                // Sample deterministically with this seed and a temperature of 0 (nil for the model's usual sampling)
+	PromptCache   *api.PromptCache      // Caches the system instructions once they are sent again in the session (nil to send them each time)
+	Metrics       *RequestMetrics       // Measures the request and the response as it streams, for the generating screen (nil to skip)
+	DryRun        bool                  // Skip the API call and return placeholder content (for testing)
+}
+
 // GenerateResumeCmd returns a command that generates a resume using the API
 // and returns an APIResultMsg with the result.
 // It now includes multiple progress update points for better UX.
 func GenerateResumeCmd(ctx context.Context, client *genai.Client, model *genai.GenerativeModel, sourceContent, stdinContent, outputFlagPath string, dryRun bool) tea.Cmd {
-	return func() tea.Msg {
+	return GenerateResumeWithOptionsCmd(ctx, client, model, sourceContent, stdinContent, GenerateOptions{
+		OutputPath: outputFlagPath,
+		DryRun:     dryRun,
+	})
+}
+
+// GenerateResumeWithOptionsCmd returns a command that generates a resume using
+// the API with the given options and returns an APIResultMsg with the result.
+// In bundle mode a second, coordinated API call writes a cover letter from the
+// generated resume and the original inputs, and both are saved to a dated directory.
+func GenerateResumeWithOptionsCmd(ctx context.Context, client *genai.Client, model *genai.GenerativeModel, sourceContent, stdinContent string, opts GenerateOptions) tea.Cmd {
+	return func() (msg tea.Msg) {
+		outputFlagPath := opts.OutputPath
+		
 		// Skip actual API call if this is a dry run (for testing)
-		if dryRun {
+		if opts.DryRun {
 			return APIResultMsg{
 				Success:    true,
 				Content:    "Test content (dry run)",
@@ -58,8 +161,9 @@ func GenerateResumeCmd(ctx context.Context, client *genai.Client, model *genai.G
 			}
 		}
 
-		// Verify client and model are provided
-		if client == nil || model == nil {
+		// Verify client and model are provided (replayed responses need neither)
+		replay := opts.Fixtures.Mode == api.FixtureReplay
+		if !replay && (client == nil || model == nil) {
 			return APIResultMsg{
 				Success: false,
 				Error:   fmt.Errorf("API client or model is nil"),
@@ -72,30 +176,175 @@ func GenerateResumeCmd(ctx context.Context, client *genai.Client, model *genai.G
 		// Use the provided context for the API request
 		// This allows for proper cancellation if the user quits the application
 		
+		// A seed makes every request of the run reproducible
+		ctx = seededContext(ctx, opts)
+		
+		// A time budget cancels generation at its deadline; parent tells the
+		// deadline apart from the user quitting
+		parent := ctx
+		if opts.MaxDuration > 0 {
+			var cancelBudget context.CancelFunc
+			ctx, cancelBudget = context.WithTimeout(ctx, opts.MaxDuration)
+			defer cancelBudget()
+		}
+		
+		// Bundle mode adds a cover letter step
+		totalSteps := 4
+		if opts.Bundle {
+			totalSteps = 5
+		}
+		step := func(n int) string {
+			return fmt.Sprintf("%d of %d", n, totalSteps)
+		}
+		
 		// PROGRESS UPDATE 1: Building prompt
-		tea.Cmd(SendProgressUpdateCmd("1 of 4", "Building prompt from your inputs..."))()
+		tea.Cmd(SendProgressUpdateCmd(step(1), "Building prompt from your inputs..."))()
+		
+		// Trim the inputs so the request fits the model's context window
+		fitted, err := fitPrompt(sourceContent, stdinContent, opts)
+		if err != nil {
+			return APIResultMsg{
+				Success: false,
+				Error:   fmt.Errorf("error fitting prompt to the context window: %w", err),
+			}
+		}
+		sourceContent, stdinContent = fitted.SourceContent, fitted.StdinContent
 		
-		// Build the prompt from source content and stdin input
-		promptContent := prompt.GeneratePromptContent(sourceContent, stdinContent)
+		// Research the target employer so the resume echoes its language
+		var company prompt.CompanyProfile
+		companyNote := ""
+		if opts.Company != "" {
+			tea.Cmd(SendProgressUpdateCmd(step(1), "Researching the target employer..."))()
+			company, companyNote = researchCompany(ctx, client, opts)
+		}
+		
+		// Strict grounding numbers each sentence of the inputs for the model to
+		// cite, which needs the source as text
+		if opts.Grounding.Strict() && opts.SourcePDF {
+			return APIResultMsg{
+				Success: false,
+				Error:   errors.New("strict grounding cites the sentences of your inputs, but a PDF source is sent as a document; save the resume as text or Markdown, or generate without -grounding"),
+			}
+		}
+		numberedSource, numberedNotes, sentences := resume.NumberInputs(sourceContent, stdinContent, opts.Grounding)
+		
+		// Build the prompt from source content and stdin input, uploading a
+		// PDF or large source with the Files API instead of inlining it
+		if uploadsSource(sourceContent, opts) {
+			tea.Cmd(SendProgressUpdateCmd(step(1), "Uploading your existing resume..."))()
+		}
+		promptContent, uploadedFile, err := sourcePromptContent(ctx, client, numberedSource, numberedNotes, opts)
+		if err != nil {
+			return APIResultMsg{
+				Success: false,
+				Error:   fmt.Errorf("error uploading the existing resume: %w", err),
+			}
+		}
+		defer func() {
+			msg = keepUpload(msg, client, uploadedFile)
+		}()
+		promptContent = prompt.AddSkillsToContent(promptContent, opts.Skills)
+		promptContent = prompt.AddAcademicsToContent(promptContent, opts.Academics)
+		promptContent = prompt.AddCompanyToContent(promptContent, company)
+		promptContent = prompt.AddEmphasisToContent(promptContent, opts.Emphasize)
+		promptContent = prompt.AddPresetToContent(promptContent, opts.Preset)
+		promptContent = prompt.AddAudienceToContent(promptContent, opts.Audience)
+		promptContent = prompt.AddStructureToContent(promptContent, sourceHeadings(opts))
+		promptContent = prompt.AddRelevanceToContent(promptContent, relevantExperiences(sourceContent, stdinContent, opts))
+		if opts.Amend {
+			promptContent = prompt.AddAmendmentToContent(promptContent)
+		}
+		promptContent = prompt.AddGroundingToContent(promptContent, opts.Grounding)
+		logging.Debugf("Sending prompt to %s:\n%s", api.DefaultModelName, contentText(promptContent))
+		opts.Metrics.Sent(contentText(promptContent))
 
 		// PROGRESS UPDATE 2: Sending to API
-		tea.Cmd(SendProgressUpdateCmd("2 of 4", "Sending request to Gemini AI..."))()
+		tea.Cmd(SendProgressUpdateCmd(step(2), "Sending request to Gemini AI..."))()
+		
+		// Generating again in the session reads the system instructions from
+		// a cache instead of sending them again
+		model = opts.PromptCache.Model(ctx, model, api.DefaultModelName)
+		
+		// Start a chat session so continuations and later refinements reuse the
+		// conversation instead of resending the whole prompt
+		session, err := api.NewFixtureSession(model, api.DefaultModelName, opts.Fixtures)
+		if err != nil {
+			return APIResultMsg{
+				Success: false,
+				Error:   fmt.Errorf("error starting API session: %w", err),
+			}
+		}
+		
+		// Prepare the fallback session that is retried once if the primary model fails
+		var fallbackSession *api.Session
+		if opts.FallbackModel != "" && opts.FallbackModel != api.DefaultModelName {
+			var fallbackModel *genai.GenerativeModel
+			if !replay {
+				fallbackModel, err = api.NewResumeModel(client, opts.FallbackModel)
+			}
+			if err == nil {
+				fallbackSession, err = api.NewFixtureSession(fallbackModel, opts.FallbackModel, opts.Fixtures)
+			}
+			if err != nil {
+				return APIResultMsg{
+					Success: false,
+					Error:   fmt.Errorf("error preparing fallback model: %w", err),
+				}
+			}
+		}
+		
+		// Execute API request with the prompt content, saving the response
+		// as it streams in so a crash or dropped connection still leaves the
+		// text received so far on disk
+		draft := output.NewDraftWriter(outputFlagPath, output.DraftInterval)
+		streamed := ""
+		response, usedFallback, err := api.SendWithFallbackStreaming(faults.WithStage(ctx, faults.StageResume), session, fallbackSession, promptContent, func(text string) {
+			streamed = text
+			opts.Metrics.Received(text)
+			// Drafts are best-effort; a failed save must not stop generation
+			_ = draft.Update(text)
+		})
+		_ = draft.Flush()
 		
-		// Execute API request with the prompt content
-		response, err := api.ExecuteRequest(ctx, model, promptContent)
+		// At the time limit, keep the complete sections received so far
+		partialContent, partialMsg := "", ""
+		if err != nil && budgetExpired(ctx, parent) {
+			partial, partialErr := output.AcceptPartialResume(streamed, output.ExpectedSections(sourceContent))
+			if partialErr != nil {
+				logging.Debugf("Time limit reached with no usable sections: %v", partialErr)
+				return APIResultMsg{
+					Success: false,
+					Error:   withDraftNote(fmt.Errorf("generation stopped at the %s time limit before a complete section was received; allow more time with -max-duration", opts.MaxDuration), draft),
+				}
+			}
+			partialContent, partialMsg, err = partial.Content, partialResumeNote(partial, opts), nil
+			
+			// The cover letter, notes, gaps appendix, and documents need more requests, which the budget no longer allows
+			opts.Bundle, opts.Explain, opts.Gaps, opts.Documents = false, false, false, nil
+		}
 		if err != nil {
+			logging.Debugf("API request failed: %v", err)
 			return APIResultMsg{
 				Success: false,
-				Error:   fmt.Errorf("error executing API request: %w", err),
+				Error:   withDraftNote(fmt.Errorf("error executing API request: %w", err), draft),
 			}
 		}
+		
+		// Remember which model produced the resume so follow-up requests use it too
+		modelName := api.DefaultModelName
+		if usedFallback {
+			modelName = opts.FallbackModel
+			session = fallbackSession
+		}
 
 		// PROGRESS UPDATE 3: Processing response
-		tea.Cmd(SendProgressUpdateCmd("3 of 4", "Processing AI response..."))()
+		tea.Cmd(SendProgressUpdateCmd(step(3), "Processing AI response..."))()
 		
-		// Process the API response
-		markdownContent, err := output.ProcessResponseContent(response)
-		truncatedMsg := ""
+		// Process the API response, unless the time limit cut it short
+		markdownContent, truncatedMsg := partialContent, partialMsg
+		if partialMsg == "" {
+			markdownContent, err = output.ProcessResponseContent(response)
+		}
 
 		// Handle truncation error
 		if err != nil {
@@ -103,52 +352,727 @@ func GenerateResumeCmd(ctx context.Context, client *genai.Client, model *genai.G
 			if response != nil && len(response.Candidates) > 0 &&
 				response.Candidates[0].FinishReason == genai.FinishReasonMaxTokens {
 				
-				truncatedMsg = "Warning: Response was truncated due to token limit"
-				
 				// PROGRESS UPDATE: Handling truncated response
-				tea.Cmd(SendProgressUpdateCmd("3 of 4", "Handling truncated response..."))()
+				tea.Cmd(SendProgressUpdateCmd(step(3), "Continuing truncated response..."))()
 				
-				// Try to recover partial content
-				partialContent, recoverErr := api.TryRecoverPartialContent(response)
-				if recoverErr == nil && partialContent != "" {
-					markdownContent = partialContent
-				} else {
+				markdownContent, truncatedMsg, err = resume.CompleteTruncated(ctx, session, response, err)
+				if err != nil {
 					return APIResultMsg{
 						Success: false,
-						Error:   fmt.Errorf("error processing API response: %w (recovery failed: %w)", err, recoverErr),
+						Error:   withDraftNote(err, draft),
 					}
 				}
 			} else {
 				return APIResultMsg{
 					Success: false,
-					Error:   fmt.Errorf("error processing API response: %w", err),
+					Error:   withDraftNote(fmt.Errorf("error processing API response: %w", err), draft),
+				}
+			}
+		}
+
+		// Remove the citations of strict grounding, finding the bullets that cite none of the inputs
+		markdownContent, uncited := resume.CheckGrounding(markdownContent, sentences, opts.Grounding)
+		
+		// Write the structured skills, canonical credential names, one
+		// spelling of each skill, and the preset's section order
+		markdownContent = resume.Polish(markdownContent, resume.Inputs{Skills: opts.Skills, Preset: opts.Preset, Structure: opts.Structure, ProseWidth: opts.ProseWidth})
+		
+		// Report the emphasized keywords the model could not truthfully include
+		missingKeywords := analysis.MissingKeywords(markdownContent, opts.Emphasize)
+		
+		// Report the heading problems Polish could not fix, such as a missing required section
+		headingIssues := output.ValidateStructure(markdownContent, opts.Preset.RequiredSections())
+		
+		// Warn when the resume came back in another language than the inputs
+		languageWarning := languageMismatch(sourceContent+"\n"+stdinContent, markdownContent)
+		
+		// Ask why the major changes were made, for the notes saved next to the resume
+		explanation, explanationNote := "", ""
+		if opts.Explain {
+			tea.Cmd(SendProgressUpdateCmd(step(3), "Asking why the major changes were made..."))()
+			explanation, explanationNote = explainChanges(ctx, session)
+		}
+		
+		// Ask how to close the gaps with the job, for the appendix saved next to the resume
+		gaps := jobGaps{}
+		if opts.Gaps {
+			tea.Cmd(SendProgressUpdateCmd(step(3), "Suggesting how to close the gaps with the job..."))()
+			gaps = closeGaps(ctx, session, markdownContent, opts.Job)
+		}
+		
+		// Write the other documents asked for, such as a thank-you email, in the same conversation
+		var documents []GeneratedDocument
+		documentsNote := ""
+		if len(opts.Documents) > 0 {
+			tea.Cmd(SendProgressUpdateCmd(step(3), "Writing "+documentTitles(opts.Documents)+"..."))()
+			documents, documentsNote = writeDocuments(ctx, session, opts.Documents, targetCompany(&APIResultMsg{Company: company}, opts), opts)
+		}
+
+		if opts.Bundle {
+			msg := generateBundle(ctx, client, session, modelName, markdownContent, sourceContent, stdinContent, company, opts, truncatedMsg, explanation, gaps, documents, fitted.Trimmed, step)
+			switch result := msg.(type) {
+			case APIResultMsg:
+				if result.Success {
+					_ = draft.Remove()
+					result.Company, result.CompanyNote = company, companyNote
+					result.MissingKeywords = missingKeywords
+					result.HeadingIssues = headingIssues
+					result.Uncited = uncited
+					result.LanguageWarning = languageWarning
+					result.ExplanationNote = explanationNote
+					result.GapsNote = gaps.note
+					result.DocumentsNote = documentsNote
 				}
+				return result
+			case SaveFailedMsg:
+				result.Result.Company, result.Result.CompanyNote = company, companyNote
+				result.Result.MissingKeywords = missingKeywords
+				result.Result.HeadingIssues = headingIssues
+				result.Result.Uncited = uncited
+				result.Result.LanguageWarning = languageWarning
+				result.Result.ExplanationNote = explanationNote
+				result.Result.GapsNote = gaps.note
+				result.Result.DocumentsNote = documentsNote
+				return result
+			case ReviewReadyMsg:
+				result.Result.Company, result.Result.CompanyNote = company, companyNote
+				result.Result.MissingKeywords = missingKeywords
+				result.Result.HeadingIssues = headingIssues
+				result.Result.Uncited = uncited
+				result.Result.LanguageWarning = languageWarning
+				result.Result.ExplanationNote = explanationNote
+				result.Result.GapsNote = gaps.note
+				result.Result.DocumentsNote = documentsNote
+				return result
 			}
+			return msg
 		}
 
 		// PROGRESS UPDATE 4: Saving result
-		tea.Cmd(SendProgressUpdateCmd("4 of 4", "Saving generated resume to file..."))()
+		tea.Cmd(SendProgressUpdateCmd(step(4), "Saving generated resume to file..."))()
+		
+		result := APIResultMsg{
+			Success:         true,
+			Content:         markdownContent,
+			TruncatedMsg:    truncatedMsg,
+			TrimmedInputs:   fitted.Trimmed,
+			Company:         company,
+			CompanyNote:     companyNote,
+			MissingKeywords: missingKeywords,
+			HeadingIssues:   headingIssues,
+			Uncited:         uncited,
+			LanguageWarning: languageWarning,
+			Explanation:     explanation,
+			ExplanationNote: explanationNote,
+			Gaps:            gaps.keywords,
+			Suggestions:     gaps.suggestions,
+			GapsNote:        gaps.note,
+			Documents:       documents,
+			DocumentsNote:   documentsNote,
+			ModelName:       modelName,
+			Session:         session,
+			Error:           nil,
+		}
 		
-		// Write the generated markdown to a file
-		outputPath, err := output.WriteOutput(markdownContent, outputFlagPath)
+		// With --review nothing is saved until the sections have been accepted
+		outputPath, err := resolveOutputPath(markdownContent, opts)
+		if err == nil && opts.Review {
+			return ReviewReadyMsg{Result: result, Path: outputPath}
+		}
+		
+		// A failed write keeps the resume in memory so it can be saved elsewhere
+		if err == nil {
+			err = saveResume(&result, "", outputPath, opts)
+		}
 		if err != nil {
-			return APIResultMsg{
-				Success: false,
-				Error:   fmt.Errorf("error writing output file: %w", err),
-			}
+			return SaveFailedMsg{Result: result, Path: outputPath, Error: err}
 		}
+		
+		// The finished resume replaces the draft
+		_ = draft.Remove()
 
 		// PROGRESS UPDATE: Complete
 		tea.Cmd(SendProgressUpdateCmd("Complete", "Resume generation completed successfully!"))()
 		
+		return result
+	}
+}
+
+// budgetExpired reports whether generation stopped because the -max-duration
+// deadline passed, rather than the user quitting.
+func budgetExpired(ctx, parent context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil
+}
+
+// partialResumeNote tells the user that the time limit cut the resume short,
+// which sections were kept, and which are marked TODO.
+func partialResumeNote(partial output.PartialResume, opts GenerateOptions) string {
+	sections := "sections"
+	if len(partial.Kept) == 1 {
+		sections = "section"
+	}
+	note := fmt.Sprintf("Generation stopped at the %s time limit. Kept %d complete %s", opts.MaxDuration, len(partial.Kept), sections)
+	if len(partial.Missing) > 0 {
+		note += "; marked TODO: " + strings.Join(partial.Missing, ", ")
+	}
+	var skipped []string
+	if opts.Bundle {
+		skipped = append(skipped, "the cover letter")
+	}
+	if opts.Explain {
+		skipped = append(skipped, "the notes on what changed")
+	}
+	if opts.Gaps {
+		skipped = append(skipped, "the gaps appendix")
+	}
+	if len(opts.Documents) > 0 {
+		skipped = append(skipped, documentTitles(opts.Documents))
+	}
+	if len(skipped) > 0 {
+		note += ". Not written: " + strings.Join(skipped, ", ")
+	}
+	return note
+}
+
+// resolveOutputPath returns the path the resume is written to: the -output
+// path when one was given, and otherwise a new file named after the candidate
+// and today's date (see output.DatedOutputPath). With -legacy-output, and in
+// bundle mode, which names its own files, an empty path is returned so the
+// default is used.
+func resolveOutputPath(markdownContent string, opts GenerateOptions) (string, error) {
+	if opts.OutputPath != "" || opts.LegacyOutput || opts.Bundle {
+		return opts.OutputPath, nil
+	}
+	return output.DatedOutputPath(markdownContent, time.Now())
+}
+
+// saveResume writes the generated resume to outputPath, or in bundle mode
+// writes the resume and cover letter to a dated directory next to it, then
+// writes any HTML, JSON Resume, document exports, notes, and documents such
+// as a thank-you email alongside. The paths
+// written are recorded on result.
+func saveResume(result *APIResultMsg, letterContent, outputPath string, opts GenerateOptions) error {
+	if err := injectedWriteError(outputPath); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+	
+	if opts.Bundle {
+		// The bundle directory goes next to the requested output file, if any
+		baseDir := ""
+		if outputPath != "" {
+			baseDir = filepath.Dir(outputPath)
+		}
+		
+		paths, err := output.WriteBundle(baseDir, result.Content, letterContent, time.Now())
+		if err != nil {
+			return fmt.Errorf("error writing output file: %w", err)
+		}
+		result.OutputPath = paths.ResumePath
+		result.CoverLetterPath = paths.CoverLetterPath
+	} else {
+		path, err := output.WriteOutput(result.Content, outputPath)
+		if err != nil {
+			return fmt.Errorf("error writing output file: %w", err)
+		}
+		result.OutputPath = path
+	}
+	
+	htmlPath, err := writeLayout(result.Content, result.OutputPath, opts)
+	if err != nil {
+		return fmt.Errorf("error writing HTML file: %w", err)
+	}
+	result.HTMLPath = htmlPath
+	
+	jsonPath, jsonWarnings, pendingJSON, err := writeJSONResume(result.Content, result.OutputPath, opts)
+	if err != nil {
+		return fmt.Errorf("error writing JSON Resume file: %w", err)
+	}
+	result.JSONPath, result.JSONWarnings, result.PendingJSON = jsonPath, jsonWarnings, pendingJSON
+	
+	exports, err := output.WriteExports(result.Content, result.OutputPath, opts.Exports, output.ExportOptions{
+		Pandoc:   output.PandocPath(),
+		HTMLPath: htmlPath,
+		QR:       qrLink(output.LayoutStandard, opts),
+	})
+	if err != nil {
+		return fmt.Errorf("error exporting resume: %w", err)
+	}
+	result.Exports = exports
+	
+	if result.Explanation != "" {
+		notesPath, err := output.WriteNotes(result.Explanation, result.OutputPath)
+		if err != nil {
+			return fmt.Errorf("error writing notes file: %w", err)
+		}
+		result.NotesPath = notesPath
+	}
+	
+	if result.Suggestions != "" {
+		gapsPath, err := output.WriteGaps(result.Suggestions, result.Gaps, result.OutputPath)
+		if err != nil {
+			return fmt.Errorf("error writing gaps appendix: %w", err)
+		}
+		result.GapsPath = gapsPath
+	}
+	
+	if err := saveDocuments(result); err != nil {
+		return fmt.Errorf("error writing documents: %w", err)
+	}
+	
+	writeSidecar(result.Content, result.OutputPath, output.Sidecar{
+		Generated: time.Now(),
+		Model:     result.ModelName,
+		Seed:      opts.Seed,
+		Preset:    string(opts.Preset),
+		Audience:  string(opts.Audience),
+		Resume:    document.Resume{Skills: opts.Skills, Academics: opts.Academics},
+	})
+	
+	if opts.Pack {
+		packPath, err := writePack(result, opts)
+		if err != nil {
+			return fmt.Errorf("error writing resume pack: %w", err)
+		}
+		result.PackPath = packPath
+	}
+	result.Previous = recordRevision(result, opts)
+	recordRecent(result.Content, result.OutputPath, opts)
+	return nil
+}
+
+// injectedWriteError returns the permission error forced by a
+// --inject-error write-permission fault, or nil when none is set.
+func injectedWriteError(outputPath string) error {
+	if category, ok := faults.At(faults.StageWrite); !ok || category != faults.WritePermission {
+		return nil
+	}
+	if outputPath == "" {
+		outputPath = output.DefaultOutputPath
+	}
+	return &fs.PathError{Op: "open", Path: outputPath, Err: fs.ErrPermission}
+}
+
+// writePack zips the files saveResume wrote, the job description, and a
+// metadata file describing the application into one archive next to the
+// resume, and returns its path.
+func writePack(result *APIResultMsg, opts GenerateOptions) (string, error) {
+	files := []string{result.OutputPath, result.CoverLetterPath}
+	for _, export := range result.Exports {
+		files = append(files, export.Path)
+	}
+	files = append(files, result.HTMLPath, result.JSONPath, result.NotesPath, result.GapsPath)
+	for _, document := range result.Documents {
+		files = append(files, document.Path)
+	}
+	
+	now := time.Now()
+	company := targetCompany(result, opts)
+	metadata := output.PackMetadata{
+		Candidate: document.Parse(result.Content).Name,
+		Company:   company,
+		Generated: now,
+		Model:     result.ModelName,
+		Keywords:  opts.Emphasize,
+		Seed:      opts.Seed,
+	}
+	if opts.SourcePath != "" {
+		metadata.Source = filepath.Base(opts.SourcePath)
+	}
+	if opts.JobPath != "" {
+		metadata.JobDescription = filepath.Base(opts.JobPath)
+	}
+	
+	return output.WritePack(output.PackPath(result.OutputPath, company, now), output.Pack{
+		Files:          files,
+		JobDescription: opts.Job,
+		JobPath:        opts.JobPath,
+		Metadata:       metadata,
+	})
+}
+
+// targetCompany returns the name of the employer the resume was tailored
+// to: the one company research found, or the -company given when it is a
+// name rather than a job posting URL.
+func targetCompany(result *APIResultMsg, opts GenerateOptions) string {
+	if result.Company.Name != "" || strings.Contains(opts.Company, "://") {
+		return result.Company.Name
+	}
+	return opts.Company
+}
+
+// writeSidecar writes the structured form of the resume next to it, so a
+// later run that uses the resume as its source can load it directly. The
+// sidecar only saves re-parsing the resume, so failures are logged rather
+// than returned.
+func writeSidecar(content, markdownPath string, sidecar output.Sidecar) {
+	if markdownPath == output.StdoutPath {
+		return
+	}
+	if _, err := output.WriteSidecar(content, markdownPath, sidecar); err != nil {
+		logging.Debugf("Could not write the resume's sidecar: %v", err)
+	}
+}
+
+// sourceHeadings returns the section headings of the structured source, or
+// nil when the source has no sidecar.
+func sourceHeadings(opts GenerateOptions) []string {
+	if opts.Structure == nil {
+		return nil
+	}
+	return opts.Structure.Headings()
+}
+
+// relevantExperiences returns the candidate's experiences from the source
+// and notes that best match the job description, most relevant first. They
+// are ranked on this computer, so the job description itself is not sent.
+func relevantExperiences(sourceContent, stdinContent string, opts GenerateOptions) []string {
+	if opts.Job == "" {
+		return nil
+	}
+	
+	ranked := analysis.RankExperiences(analysis.Experiences(sourceContent, stdinContent), opts.Job)
+	experiences := make([]string, 0, min(len(ranked), prompt.MaxRelevantExperiences))
+	for _, experience := range ranked[:min(len(ranked), prompt.MaxRelevantExperiences)] {
+		experiences = append(experiences, experience.Text)
+	}
+	return experiences
+}
+
+// seededContext returns ctx carrying the seed of opts, so its requests are
+// sampled deterministically, or ctx unchanged when no seed is set.
+func seededContext(ctx context.Context, opts GenerateOptions) context.Context {
+	if opts.Seed == nil {
+		return ctx
+	}
+	return api.WithSeed(ctx, *opts.Seed)
+}
+
+// recordRevision keeps the saved resume in the history, with where it was
+// written and the job it was written for, and returns the resume saved
+// before it for the same profile, if any. The history is only used for
+// comparison and the workspace dashboard, so failures are logged rather
+// than returned.
+func recordRevision(result *APIResultMsg, opts GenerateOptions) *history.Revision {
+	if opts.HistoryDir == "" {
+		return nil
+	}
+	
+	previous, ok, err := history.Latest(opts.HistoryDir, history.Profile(result.Content))
+	if err != nil {
+		logging.Debugf("Could not read the resume history: %v", err)
+	}
+	details := history.Details{Company: targetCompany(result, opts), JobPath: input.ResolvePath(opts.JobPath)}
+	if result.OutputPath != output.StdoutPath {
+		details.OutputPath = input.ResolvePath(result.OutputPath)
+	}
+	if _, err := history.SaveWithDetails(opts.HistoryDir, result.Content, time.Now(), details); err != nil {
+		logging.Debugf("Could not save the resume to the history: %v", err)
+	}
+	if !ok {
+		return nil
+	}
+	return &previous
+}
+
+// SaveResumeCmd returns a command that saves an already generated resume to
+// a new location after the first write failed. It returns the completed
+// APIResultMsg on success, or another SaveFailedMsg so the user can try
+// somewhere else.
+//
+// Parameters:
+//   - result: The generated result, as carried by SaveFailedMsg
+//   - letterContent: The generated cover letter (bundle mode only)
+//   - outputPath: The new path for the resume; in bundle mode the bundle
+//     directory is created next to it
+//   - opts: The generation options, for the HTML, JSON Resume, and document exports
+//
+// Returns:
+//   - tea.Cmd: A command that returns an APIResultMsg or a SaveFailedMsg
+func SaveResumeCmd(result APIResultMsg, letterContent, outputPath string, opts GenerateOptions) tea.Cmd {
+	return func() tea.Msg {
+		if err := saveResume(&result, letterContent, outputPath, opts); err != nil {
+			return SaveFailedMsg{Result: result, CoverLetter: letterContent, Path: outputPath, Error: err}
+		}
+		
+		// The resume is saved, so the draft of the original output is no longer needed
+		_ = os.Remove(output.DraftPath(opts.OutputPath))
+		return result
+	}
+}
+
+// contentText joins the text parts of prompt content, for the debug log.
+// Uploaded documents are shown by their MIME type.
+func contentText(content *genai.Content) string {
+	var b strings.Builder
+	for _, part := range content.Parts {
+		switch part := part.(type) {
+		case genai.Text:
+			b.WriteString(string(part))
+		case genai.FileData:
+			b.WriteString(fmt.Sprintf("[Attached document (%s)]\n\n", part.MIMEType))
+		}
+	}
+	return b.String()
+}
+
+// withDraftNote adds the location of the saved draft to a generation error,
+// so the text received before the failure can be recovered.
+func withDraftNote(err error, draft *output.DraftWriter) error {
+	if !draft.Written() {
+		return err
+	}
+	return fmt.Errorf("%w (the partial response was saved to %s)", err, draft.Path())
+}
+
+// generateBundle writes a cover letter matching the generated resume and saves
+// both documents into a dated bundle directory next to the requested output path.
+// The cover letter uses the same model that produced the resume.
+func generateBundle(ctx context.Context, client *genai.Client, session *api.Session, modelName, resumeContent, sourceContent, stdinContent string, company prompt.CompanyProfile, opts GenerateOptions, truncatedMsg, explanation string, gaps jobGaps, documents []GeneratedDocument, trimmed []string, step func(int) string) tea.Msg {
+	// PROGRESS UPDATE 4: Cover letter
+	tea.Cmd(SendProgressUpdateCmd(step(4), "Writing a matching cover letter..."))()
+	
+	// Replayed cover letters need no model; recorded ones wrap the real model
+	var letterModel api.ModelInterface
+	if opts.Fixtures.Mode != api.FixtureReplay {
+		model, err := api.NewCoverLetterModel(client, modelName)
+		if err != nil {
+			return APIResultMsg{
+				Success: false,
+				Error:   fmt.Errorf("error preparing cover letter model: %w", err),
+			}
+		}
+		letterModel = opts.PromptCache.Model(ctx, model, modelName)
+	}
+	letterModel = opts.Fixtures.WrapModel(letterModel, modelName)
+	
+	// The cover letter prompt carries the generated resume so both documents agree
+	letterPrompt := prompt.GenerateCoverLetterPromptContent(resumeContent, sourceContent, stdinContent)
+	letterPrompt = prompt.AddCompanyToContent(letterPrompt, company)
+	letterResponse, err := api.ExecuteRequest(faults.WithStage(ctx, faults.StageCoverLetter), letterModel, letterPrompt)
+	if err != nil {
 		return APIResultMsg{
-			Success:      true,
-			Content:      markdownContent,
-			OutputPath:   outputPath,
-			TruncatedMsg: truncatedMsg,
-			Error:        nil,
+			Success: false,
+			Error:   fmt.Errorf("error executing cover letter request: %w", err),
 		}
 	}
+	
+	letterContent, err := output.ProcessResponseContent(letterResponse)
+	if err != nil {
+		return APIResultMsg{
+			Success: false,
+			Error:   fmt.Errorf("error processing cover letter response: %w", err),
+		}
+	}
+	if opts.Grounding.Strict() {
+		letterContent = output.StripCitations(letterContent)
+	}
+	letterContent = output.FormatProse(letterContent, opts.ProseWidth)
+	
+	// PROGRESS UPDATE 5: Saving bundle
+	tea.Cmd(SendProgressUpdateCmd(step(5), "Saving resume and cover letter..."))()
+	
+	result := APIResultMsg{
+		Success:       true,
+		Content:       resumeContent,
+		TruncatedMsg:  truncatedMsg,
+		TrimmedInputs: trimmed,
+		Explanation:   explanation,
+		Gaps:          gaps.keywords,
+		Suggestions:   gaps.suggestions,
+		Documents:     documents,
+		ModelName:     modelName,
+		Session:       session,
+		Error:         nil,
+	}
+	
+	// With --review nothing is saved until the sections have been accepted
+	if opts.Review {
+		return ReviewReadyMsg{Result: result, CoverLetter: letterContent, Path: opts.OutputPath}
+	}
+	
+	// A failed write keeps both documents in memory so they can be saved elsewhere
+	if err := saveResume(&result, letterContent, opts.OutputPath, opts); err != nil {
+		return SaveFailedMsg{Result: result, CoverLetter: letterContent, Path: opts.OutputPath, Error: err}
+	}
+	
+	// PROGRESS UPDATE: Complete
+	tea.Cmd(SendProgressUpdateCmd("Complete", "Resume and cover letter generated successfully!"))()
+	
+	return result
+}
+
+// languageMismatch returns a warning when a generated resume is written in
+// another language than the inputs it was written from, which models
+// sometimes do with notes in one language and a source in another. It
+// returns an empty string when they match or either language is unclear.
+func languageMismatch(inputs, resumeContent string) string {
+	want, got := analysis.DetectLanguage(inputs), analysis.DetectLanguage(resumeContent)
+	if want == "" || got == "" || want == got {
+		return ""
+	}
+	return fmt.Sprintf("The resume is written in %s, but your inputs are mostly in %s.", got, want)
+}
+
+// explainChanges asks the model, on the session that produced the resume,
+// why it made its major changes. The notes are optional, so failures return
+// an empty explanation and a note saying why, and generation carries on.
+func explainChanges(ctx context.Context, session *api.Session) (string, string) {
+	response, err := session.Send(faults.WithStage(ctx, faults.StageExplain), prompt.GenerateExplanationPromptContent())
+	if err != nil {
+		return "", fmt.Sprintf("The explanation of the changes could not be generated (%v), so no notes were saved", err)
+	}
+	text, err := api.ProcessResponse(response)
+	if err != nil || strings.TrimSpace(text) == "" {
+		if err == nil {
+			err = errors.New("the response was empty")
+		}
+		return "", fmt.Sprintf("The explanation of the changes could not be generated (%v), so no notes were saved", err)
+	}
+	return text, ""
+}
+
+// jobGaps holds the job description keywords the resume lacks and the
+// model's suggestions for closing those gaps, or a note saying why there
+// are no suggestions.
+type jobGaps struct {
+	keywords    []string
+	suggestions string
+	note        string
+}
+
+// closeGaps finds the top keywords of the job description that the resume
+// does not mention, and asks the model, on the session that produced the
+// resume, for courses, certifications, and projects that would close those
+// gaps. Only the keywords are sent. The appendix is optional, so failures
+// return a note saying why there are no suggestions, and generation carries on.
+func closeGaps(ctx context.Context, session *api.Session, resumeContent, jobDescription string) jobGaps {
+	gaps := jobGaps{keywords: analysis.JobGaps(resumeContent, jobDescription, analysis.GapTerms)}
+	if len(gaps.keywords) == 0 {
+		gaps.note = "Your resume mentions every top keyword of the job description, so no gaps appendix was saved"
+		return gaps
+	}
+	
+	response, err := session.Send(faults.WithStage(ctx, faults.StageGaps), prompt.GenerateGapsPromptContent(gaps.keywords))
+	if err == nil {
+		gaps.suggestions, err = api.ProcessResponse(response)
+		if err == nil && strings.TrimSpace(gaps.suggestions) == "" {
+			err = errors.New("the response was empty")
+		}
+	}
+	if err != nil {
+		gaps.suggestions = ""
+		gaps.note = fmt.Sprintf("The suggestions for closing the gaps with the job could not be generated (%v), so no gaps appendix was saved", err)
+	}
+	return gaps
+}
+
+// researchCompany asks the model for the values and language of the employer
+// named by opts.Company, downloading the job posting first when it is a URL.
+// Research is optional, so failures return an empty profile and a note
+// explaining why the resume was not tailored, and generation carries on.
+func researchCompany(ctx context.Context, client *genai.Client, opts GenerateOptions) (prompt.CompanyProfile, string) {
+	postingText, postingNote := "", ""
+	if input.IsURL(opts.Company) {
+		text, err := input.FetchPosting(ctx, opts.Company)
+		if err != nil {
+			// The model may still recognize the employer from the URL
+			postingNote = fmt.Sprintf("The job posting could not be read (%v), so only its URL was researched", err)
+		}
+		postingText = text
+	}
+	
+	// Replayed research needs no model; recorded research wraps the real model
+	var researchModel api.ModelInterface
+	if opts.Fixtures.Mode != api.FixtureReplay {
+		model, err := api.NewResearchModel(client, api.DefaultModelName)
+		if err != nil {
+			return prompt.CompanyProfile{}, fmt.Sprintf("Company research failed (%v), so the resume was not tailored", err)
+		}
+		researchModel = model
+	}
+	researchModel = opts.Fixtures.WrapModel(researchModel, api.DefaultModelName)
+	
+	response, err := api.ExecuteRequest(faults.WithStage(ctx, faults.StageCompany), researchModel, prompt.GenerateCompanyResearchPromptContent(opts.Company, postingText))
+	if err != nil {
+		return prompt.CompanyProfile{}, fmt.Sprintf("Company research failed (%v), so the resume was not tailored", err)
+	}
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return prompt.CompanyProfile{}, fmt.Sprintf("Company research failed (%v), so the resume was not tailored", err)
+	}
+	
+	profile := prompt.ParseCompanyProfile(text)
+	if profile.Empty() {
+		return profile, fmt.Sprintf("Nothing was found about %s, so the resume was not tailored", opts.Company)
+	}
+	return profile, postingNote
+}
+
+// writeLayout renders the resume as HTML in the requested layout next to the
+// Markdown file. It returns an empty path when no layout was requested.
+func writeLayout(markdownContent, markdownPath string, opts GenerateOptions) (string, error) {
+	if opts.Layout == "" {
+		return "", nil
+	}
+	
+	resume := document.Parse(markdownContent)
+	resume.Skills, resume.Academics = opts.Skills, opts.Academics
+	return output.WriteHTMLWithOptions(resume, opts.Layout, output.HTMLOptions{Timeline: opts.Timeline, QR: qrLink(opts.Layout, opts), PageBreaks: opts.PageBreaks}, markdownPath)
+}
+
+// qrLink returns the URL to link with a QR code in the given layout, or an
+// empty string when there is none or the layout does not show it. PDF
+// exports follow the standard layout, which they fall back to.
+func qrLink(layout output.Layout, opts GenerateOptions) string {
+	if opts.QRLayouts != nil && !slices.Contains(opts.QRLayouts, layout) {
+		return ""
+	}
+	return opts.QR
+}
+
+// writeJSONResume converts the resume to JSON Resume format and writes it next
+// to the Markdown file, returning the path and the fields the export left
+// empty. When the export breaks the schema nothing is written and the export
+// is returned instead, so it can be fixed before saving. It returns empty
+// results when no JSON export was requested.
+func writeJSONResume(markdownContent, markdownPath string, opts GenerateOptions) (string, []document.Violation, *document.JSONResume, error) {
+	if !opts.JSONResume {
+		return "", nil, nil, nil
+	}
+	
+	resume := document.Parse(markdownContent)
+	resume.Skills, resume.Academics = opts.Skills, opts.Academics
+	jsonResume := resume.JSONResume()
+	
+	jsonPath, err := output.WriteJSONResume(jsonResume, markdownPath)
+	var invalid *output.InvalidJSONResumeError
+	if errors.As(err, &invalid) {
+		return "", nil, &jsonResume, nil
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return jsonPath, jsonResume.Warnings(), nil, nil
+}
+
+// fitPrompt trims the inputs so the prompt fits the smaller context window of
+// the primary and fallback models, after reserving room for the response,
+// the system instructions, and the structured skills.
+func fitPrompt(sourceContent, stdinContent string, opts GenerateOptions) (prompt.FitResult, error) {
+	return resume.Fit(resume.Inputs{
+		Source:        sourceContent,
+		Notes:         stdinContent,
+		Skills:        opts.Skills,
+		Academics:     opts.Academics,
+		Preset:        opts.Preset,
+		Audience:      opts.Audience,
+		Structure:     opts.Structure,
+		Emphasize:     opts.Emphasize,
+		Grounding:     opts.Grounding,
+		Amend:         opts.Amend,
+		FallbackModel: opts.FallbackModel,
+		TrimOrder:     opts.TrimOrder,
+	})
 }
 
 // SubmitStdinInputCmd returns a command that submits stdin input
@@ -170,4 +1094,61 @@ func SendProgressUpdateCmd(step, message string) tea.Cmd {
 			Message: message,
 		}
 	}
-}
\ No newline at end of file
+}
+
+// keyCheckTimeout bounds the API key check, so an unreachable API is
+// reported instead of leaving the welcome screen waiting.
+const keyCheckTimeout = 10 * time.Second
+
+// VerifyAPIKeyCmd returns a command that checks the API key with a
+// lightweight API request and returns an APIKeyCheckedMsg with the result.
+func VerifyAPIKeyCmd(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, keyCheckTimeout)
+		defer cancel()
+		
+		apiKey, _ := api.GetAPIKey()
+		return APIKeyCheckedMsg{Diagnosis: api.VerifyAPIKey(ctx, apiKey)}
+	}
+}
+
+// CheckQuotaCmd returns a command that reads the usage ledger at path and
+// returns a QuotaCheckedMsg with the recent requests to modelName and to
+// fallbackModel, which is skipped when it is empty or the same model.
+func CheckQuotaCmd(path, modelName, fallbackModel string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := usage.Load(path)
+		if err != nil {
+			return QuotaCheckedMsg{Error: err}
+		}
+		now := time.Now()
+		msg := QuotaCheckedMsg{Status: usage.Check(entries, modelName, now)}
+		if fallbackModel != "" && !strings.EqualFold(fallbackModel, modelName) {
+			fallback := usage.Check(entries, fallbackModel, now)
+			msg.Fallback = &fallback
+		}
+		return msg
+	}
+}
+
+// LoadSnippetsCmd returns a command that loads the snippets library from dir
+// and returns a SnippetsLoadedMsg with the result.
+func LoadSnippetsCmd(dir string) tea.Cmd {
+	return func() tea.Msg {
+		// Without a directory there is simply nothing to load
+		if dir == "" {
+			return SnippetsLoadedMsg{}
+		}
+
+		library, err := snippets.Load(dir)
+		if err != nil {
+			return SnippetsLoadedMsg{
+				Error: fmt.Errorf("failed to load snippets: %w", err),
+			}
+		}
+
+		return SnippetsLoadedMsg{
+			Snippets: library,
+		}
+	}
+}
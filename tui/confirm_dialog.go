@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+)
+
+// confirmAction identifies a destructive or costly action awaiting confirmation.
+type confirmAction int
+
+const (
+	// confirmNone means no confirmation dialog is open.
+	confirmNone confirmAction = iota
+
+	// confirmOverwrite asks before generation replaces an existing output file.
+	confirmOverwrite
+
+	// confirmQuit asks before quitting discards notes typed in the textarea,
+	// or a generated resume that has not been saved.
+	confirmQuit
+
+	// confirmRegenerate asks before generation is run again, which uses API quota.
+	confirmRegenerate
+
+	// confirmDeleteResume asks before the history of the resume selected on
+	// the workspace dashboard is deleted.
+	confirmDeleteResume
+)
+
+// openConfirmDialog opens a confirmation dialog for action over the current screen.
+func openConfirmDialog(m Model, action confirmAction) Model {
+	m.pendingConfirm = action
+	m.stdinInput.Blur()
+	return m
+}
+
+// updateConfirmDialog handles key presses while a confirmation dialog is open.
+// 'y' carries out the action and 'n' or Esc cancels it; every other key is
+// ignored so a stray keypress cannot confirm. Ctrl+C in the quit dialog
+// quits, so pressing it twice always leaves the application.
+func updateConfirmDialog(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	action := m.pendingConfirm
+	keys := m.keyMap()
+
+	confirmed := key.Matches(msg, keys.Yes)
+	if action == confirmQuit && msg.Type == tea.KeyCtrlC {
+		confirmed = true
+	}
+	cancelled := key.Matches(msg, keys.No, keys.Cancel)
+
+	switch {
+	case confirmed:
+		m.pendingConfirm = confirmNone
+		switch action {
+		case confirmQuit:
+			m = cleanupAPIClient(m)
+			return m, tea.Quit
+		case confirmOverwrite, confirmRegenerate:
+			m.autoRetries = 0
+			return startGeneration(m)
+		case confirmDeleteResume:
+			return deleteSelectedResume(m)
+		}
+	case cancelled:
+		m.pendingConfirm = confirmNone
+		if m.state == stateInputStdin {
+			return m, m.stdinInput.Focus()
+		}
+	}
+	return m, nil
+}
+
+// hasUnsavedInput reports whether quitting now would discard notes typed in
+// the textarea, a generated resume that could not be written and has not
+// been copied to the clipboard, or one whose sections are still being
+// reviewed. The notes are only kept once a resume has been generated.
+func hasUnsavedInput(m Model) bool {
+	switch m.state {
+	case stateInputStdin, stateConfirmGenerate, stateInputSkills, stateConsent:
+		return strings.TrimSpace(m.stdinInput.Value()) != ""
+	case stateSaveFallback:
+		return !m.saveCopied
+	case stateReviewSections:
+		return true
+	}
+	return false
+}
+
+// existingOutputPath returns the Markdown file generation would overwrite, or
+// "" if there is none. Bundles are written to a new directory every time, and
+// resumes without an -output path to a new dated file, so they never
+// overwrite anything.
+func existingOutputPath(m Model) string {
+	if m.flagBundle || (m.flagOutputPath == "" && !m.flagLegacyOutput) || m.flagOutputPath == output.StdoutPath {
+		return ""
+	}
+
+	path := m.flagOutputPath
+	if path == "" {
+		path = output.DefaultOutputPath
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// confirmDialogContent returns the title and message of the open dialog.
+func confirmDialogContent(m Model) (string, string) {
+	switch m.pendingConfirm {
+	case confirmOverwrite:
+		return "Overwrite existing resume?",
+			fmt.Sprintf("%s already exists and will be replaced by the new resume.", existingOutputPath(m))
+	case confirmQuit:
+		if m.state == stateSaveFallback || m.state == stateReviewSections {
+			return "Quit without saving?",
+				"The generated resume has not been saved anywhere and will be lost."
+		}
+		return "Quit without generating?",
+			"The notes you typed have not been saved and will be lost."
+	case confirmRegenerate:
+		message := "Generating again sends another request and uses API quota."
+		if path := existingOutputPath(m); path != "" {
+			message += fmt.Sprintf(" %s will be replaced by the new resume.", path)
+		}
+		return "Generate the resume again?", message
+	case confirmDeleteResume:
+		entry := m.workspace[m.workspaceCursor]
+		message := fmt.Sprintf("Every saved version of %s will be deleted from the history.", entry.revision.Profile)
+		if len(entry.files) > 0 {
+			message += " The files it was written to are kept."
+		}
+		return "Delete this resume?", message
+	}
+	return "", ""
+}
+
+// renderConfirmDialog renders the open confirmation dialog box
+func renderConfirmDialog(m Model, width int) string {
+	heading, message := confirmDialogContent(m)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(accentColor).
+		Render("⚠️  " + heading)
+
+	keys := newHelp(width).View(screenKeyMap(m))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accentColor).
+		Padding(1, 2).
+		Width(width).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			"",
+			layout.Wrap(message, width-8),
+			"",
+			keys,
+		))
+}
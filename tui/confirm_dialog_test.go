@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/output"
+)
+
+func TestConfirmDialog(t *testing.T) {
+	// press sends a key to the model and returns the updated model and command
+	press := func(m Model, key tea.KeyMsg) (Model, tea.Cmd) {
+		updated, cmd := m.Update(key)
+		return updated.(Model), cmd
+	}
+	runes := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	isQuit := func(cmd tea.Cmd) bool {
+		if cmd == nil {
+			return false
+		}
+		_, ok := cmd().(tea.QuitMsg)
+		return ok
+	}
+
+	t.Run("Quitting with typed notes asks first", func(t *testing.T) {
+		m := NewModel()
+		m.state = stateInputStdin
+		m.stdinInput.SetValue("Led the payments team")
+
+		m, cmd := press(m, tea.KeyMsg{Type: tea.KeyEsc})
+		if m.pendingConfirm != confirmQuit || isQuit(cmd) {
+			t.Fatal("Expected a quit confirmation instead of quitting")
+		}
+
+		// Test case 1: Esc cancels and keeps the notes
+		m, cmd = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+		if m.pendingConfirm != confirmNone || isQuit(cmd) || m.stdinInput.Value() == "" {
+			t.Error("Expected Esc to close the dialog and keep the notes")
+		}
+
+		// Test case 2: Other keys are ignored while the dialog is open
+		m, _ = press(m, tea.KeyMsg{Type: tea.KeyCtrlC})
+		m, cmd = press(m, runes("x"))
+		if m.pendingConfirm != confirmQuit || isQuit(cmd) {
+			t.Error("Expected unrelated keys to leave the dialog open")
+		}
+
+		// Test case 3: 'y' quits
+		if _, cmd = press(m, runes("y")); !isQuit(cmd) {
+			t.Error("Expected 'y' to quit")
+		}
+
+		// Test case 4: A second Ctrl+C quits
+		if _, cmd = press(m, tea.KeyMsg{Type: tea.KeyCtrlC}); !isQuit(cmd) {
+			t.Error("Expected a second Ctrl+C to quit")
+		}
+	})
+
+	t.Run("Quitting without notes is immediate", func(t *testing.T) {
+		m := NewModel()
+		m.state = stateInputStdin
+		m.stdinInput.SetValue("   ")
+
+		if _, cmd := press(m, tea.KeyMsg{Type: tea.KeyEsc}); !isQuit(cmd) {
+			t.Error("Expected to quit without asking when no notes were typed")
+		}
+	})
+
+	t.Run("Overwriting an existing resume asks first", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "resume.md")
+		m := NewModel().WithOutputPath(outputPath)
+		m.state = stateConfirmGenerate
+
+		// Test case 1: Nothing to overwrite starts generating right away
+		if generating, cmd := press(m, tea.KeyMsg{Type: tea.KeyEnter}); generating.state != stateGenerating || cmd == nil {
+			t.Errorf("Expected generation to start, got state %v", generating.state)
+		}
+
+		if err := os.WriteFile(outputPath, []byte("# Old resume"), 0644); err != nil {
+			t.Fatalf("Failed to write output file: %v", err)
+		}
+
+		m, _ = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m.pendingConfirm != confirmOverwrite || m.state != stateConfirmGenerate {
+			t.Fatal("Expected an overwrite confirmation")
+		}
+		if view := m.View(); !strings.Contains(view, "Overwrite existing resume?") || !strings.Contains(view, "resume.md") {
+			t.Error("Expected the dialog to name the file being replaced")
+		}
+
+		// Test case 2: 'n' cancels without generating
+		if cancelled, _ := press(m, runes("n")); cancelled.pendingConfirm != confirmNone || cancelled.state != stateConfirmGenerate {
+			t.Error("Expected 'n' to cancel and stay on the confirm screen")
+		}
+
+		// Test case 3: 'y' starts generating
+		m, cmd := press(m, runes("y"))
+		if m.pendingConfirm != confirmNone || m.state != stateGenerating || cmd == nil {
+			t.Errorf("Expected 'y' to start generation, got state %v", m.state)
+		}
+	})
+
+	t.Run("Bundles never ask to overwrite", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "resume.md")
+		if err := os.WriteFile(outputPath, []byte("# Old resume"), 0644); err != nil {
+			t.Fatalf("Failed to write output file: %v", err)
+		}
+		m := NewModel().WithOutputPath(outputPath).WithBundle(true)
+
+		if existingOutputPath(m) != "" {
+			t.Error("Bundles are written to a new directory and should not overwrite")
+		}
+	})
+
+	t.Run("Dated output names never ask to overwrite", func(t *testing.T) {
+		wd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(t.TempDir()); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(wd)
+		if err := os.WriteFile(output.DefaultOutputPath, []byte("# Old resume"), 0644); err != nil {
+			t.Fatalf("Failed to write output file: %v", err)
+		}
+
+		// Test case 1: Without -output the resume goes to a new dated file
+		if path := existingOutputPath(NewModel()); path != "" {
+			t.Errorf("Expected nothing to overwrite, got %q", path)
+		}
+
+		// Test case 2: -legacy-output writes to resume_out.md, so it asks
+		if path := existingOutputPath(NewModel().WithLegacyOutput(true)); path != output.DefaultOutputPath {
+			t.Errorf("Expected %s to be overwritten, got %q", output.DefaultOutputPath, path)
+		}
+	})
+
+	t.Run("Generating again asks first", func(t *testing.T) {
+		m := NewModel()
+		m.state = stateResultSuccess
+		m.width = 120
+
+		m, _ = press(m, runes("r"))
+		if m.pendingConfirm != confirmRegenerate {
+			t.Fatal("Expected a regenerate confirmation")
+		}
+		if view := m.View(); !strings.Contains(view, "API quota") {
+			t.Error("Expected the dialog to mention API quota")
+		}
+
+		m, cmd := press(m, runes("y"))
+		if m.state != stateGenerating || cmd == nil {
+			t.Errorf("Expected 'y' to start generation again, got state %v", m.state)
+		}
+	})
+
+	t.Run("Errors before generating cannot be retried", func(t *testing.T) {
+		m := NewModel()
+		m.state = stateResultError
+
+		if m, _ = press(m, runes("r")); m.pendingConfirm != confirmNone {
+			t.Error("Expected 'r' to be ignored when nothing was generated")
+		}
+
+		m.generateAttempted = true
+		if m, _ = press(m, runes("r")); m.pendingConfirm != confirmRegenerate {
+			t.Error("Expected 'r' to offer a retry after a failed generation")
+		}
+	})
+}
@@ -1,8 +1,17 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/usage"
 )
 
 func TestConfirmViewEnhancements(t *testing.T) {
@@ -21,7 +30,6 @@ func TestConfirmViewEnhancements(t *testing.T) {
 			"Source file:",                // Source info
 			"Input:",                      // Input info
 			"Press Enter to confirm",      // Action instruction
-			"Press ESC to go back",        // Back instruction
 		}
 		
 		for _, element := range requiredElements {
@@ -71,4 +79,145 @@ func TestConfirmViewEnhancements(t *testing.T) {
 			t.Error("Confirm view should display the output path when specified")
 		}
 	})
-}
\ No newline at end of file
+}
+func TestConfirmViewPreviewIsUTF8Safe(t *testing.T) {
+	model := createTestModelWithAllFields()
+	model.stdinContent = strings.Repeat("Développeur à Zürich — ", 40)
+
+	// Test case 1: The preview never splits a multi-byte character
+	model.width = 60
+	narrow := renderConfirmGenerateView(model)
+	if !utf8.ValidString(narrow) {
+		t.Error("The confirm view should be valid UTF-8")
+	}
+	if !strings.Contains(narrow, "…") {
+		t.Error("A long input preview should end with an ellipsis")
+	}
+	if !strings.Contains(narrow, fmt.Sprintf("Input: %d characters", utf8.RuneCountInString(model.stdinContent))) {
+		t.Error("The input length should count characters, not bytes")
+	}
+
+	// Test case 2: A wider terminal previews more of the input
+	model.width = 100
+	wide := renderConfirmGenerateView(model)
+	if strings.Count(wide, "Zürich") <= strings.Count(narrow, "Zürich") {
+		t.Error("A wider terminal should show a longer preview")
+	}
+}
+
+func TestConfirmViewQuota(t *testing.T) {
+	model := createTestModelWithAllFields()
+	model.width = 100
+
+	// Test case 1: Nothing is shown until the ledger has been read
+	if strings.Contains(renderConfirmGenerateView(model), "Quota") {
+		t.Error("The confirm view should not show the quota before it is checked")
+	}
+
+	// Test case 2: The usage is shown once the ledger has been read
+	status := usage.Check(nil, "gemini-1.5-pro", time.Now())
+	updated, _ := model.Update(QuotaCheckedMsg{Status: status})
+	model = updated.(Model)
+	view := renderConfirmGenerateView(model)
+	if !strings.Contains(view, "Quota for gemini-1.5-pro: 0 of 50 requests") {
+		t.Error("The confirm view should show the quota used")
+	}
+	if strings.Contains(view, "⚠️") {
+		t.Error("The confirm view should not warn when the quota is not used")
+	}
+
+	// Test case 3: A run that needs more requests than are left is warned about
+	model.quota.LastDay = 49
+	model.flagBundle = true
+	if view := renderConfirmGenerateView(model); !strings.Contains(view, "probably fail") {
+		t.Error("The confirm view should warn that the run is likely to exceed the quota")
+	}
+	
+	// Test case 4: The fallback model's quota is shown, and warned about
+	// only when the run is likely to fall back to it
+	fallback := usage.Check(nil, "gemini-1.5-flash", time.Now())
+	fallback.LastDay = 1500
+	model.fallbackQuota = &fallback
+	view = renderConfirmGenerateView(model)
+	if !strings.Contains(view, "fallback model, gemini-1.5-flash: 1500 of 1500") || strings.Count(view, "probably fail") != 2 {
+		t.Errorf("Expected the fallback quota and both warnings, got:\n%s", view)
+	}
+	model.quota.LastDay = 0
+	if view = renderConfirmGenerateView(model); strings.Contains(view, "probably fail") {
+		t.Error("The fallback model should not be warned about when the primary model has room")
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	path := filepath.Join(t.TempDir(), usage.FileName)
+	if err := usage.Record(path, usage.Entry{Time: time.Now(), Model: "gemini-1.5-pro", Tokens: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: Submitting the notes reads the ledger for the confirm screen
+	model := NewModel().WithUsagePath(path)
+	if checkQuota(model) == nil {
+		t.Fatal("Expected the ledger to be checked")
+	}
+	msg := CheckQuotaCmd(path, "gemini-1.5-pro", "")().(QuotaCheckedMsg)
+	if msg.Error != nil || msg.Status.LastDay != 1 || msg.Fallback != nil {
+		t.Errorf("Expected one request today, got %+v", msg)
+	}
+	
+	// Test case 2: The fallback model is checked too, unless it is the same model
+	msg = CheckQuotaCmd(path, api.DefaultModelName, "gemini-1.5-pro")().(QuotaCheckedMsg)
+	if msg.Status.Model != api.DefaultModelName || msg.Fallback == nil || msg.Fallback.LastDay != 1 {
+		t.Errorf("Expected the fallback model's request, got %+v", msg)
+	}
+	if msg = CheckQuotaCmd(path, "gemini-1.5-pro", "gemini-1.5-pro")().(QuotaCheckedMsg); msg.Fallback != nil {
+		t.Errorf("Expected the same model to be checked once, got %+v", msg.Fallback)
+	}
+
+	// Test case 3: Replayed responses use no quota, so nothing is checked
+	model = model.WithFixtures(api.Fixtures{Mode: api.FixtureReplay, Dir: t.TempDir()})
+	if checkQuota(model) != nil {
+		t.Error("Expected no quota check when replaying fixtures")
+	}
+
+	// Test case 4: Each optional request counts toward the run
+	model = NewModel().WithCompany("Acme").WithExplain(true)
+	if got := plannedRequests(model); got != 3 {
+		t.Errorf("plannedRequests() = %d, want 3", got)
+	}
+}
+
+func TestConfirmViewResolvesPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: A ~ typed at the source prompt is expanded before reading
+	model := NewModel()
+	model.state = stateInputSourcePath
+	model.sourcePathInput.SetValue("~/resume.md")
+	model = pressKey(model, tea.KeyEnter)
+	if want := filepath.Join(home, "resume.md"); model.sourcePathInput.Value() != want {
+		t.Errorf("Expected the source path to be expanded to %q, got %q", want, model.sourcePathInput.Value())
+	}
+
+	// Test case 2: The confirm screen shows absolute paths
+	model = createTestModelWithAllFields()
+	model.width = 200
+	model.sourcePathInput.SetValue("resume.md")
+	model.flagOutputPath = "out/new.md"
+	view := renderConfirmGenerateView(model)
+	for _, want := range []string{"Source file: " + filepath.Join(wd, "resume.md"), "Output path: " + filepath.Join(wd, "out", "new.md")} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the confirm view to contain %q", want)
+		}
+	}
+
+	// Test case 3: Without -output the folder the resume is written to is shown
+	model.flagOutputPath = ""
+	if view := renderConfirmGenerateView(model); !strings.Contains(view, "Output folder: "+wd) {
+		t.Error("Expected the confirm view to show the output folder")
+	}
+}
@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/logging"
+)
+
+// beginGeneration starts generation from the confirm screen. Before the
+// first request it shows what will be sent to the API and asks for consent,
+// and it asks before replacing a resume from an earlier run.
+func beginGeneration(m Model) (Model, tea.Cmd) {
+	// Replayed responses never reach the API, so nothing is sent
+	if m.consentRequired && m.fixtures.Mode != api.FixtureReplay {
+		m.state = stateConsent
+		m.promptPreviewOpen = false
+		return m, nil
+	}
+
+	if existingOutputPath(m) != "" {
+		return openConfirmDialog(m, confirmOverwrite), nil
+	}
+	return startGeneration(m)
+}
+
+// updateConsent handles key presses on the consent screen. 'y' or Enter
+// agrees, remembers the answer, and continues to generation; 'n' returns to
+// the confirm screen without sending anything.
+func updateConsent(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := m.keyMap()
+	agreed := key.Matches(msg, keys.Accept, keys.Yes)
+	declined := key.Matches(msg, keys.No)
+
+	switch {
+	case agreed:
+		// Consent that cannot be saved still holds for this run; it is
+		// asked for again next time
+		if err := config.RecordConsent(time.Now()); err != nil {
+			logging.Debugf("Could not remember consent: %v", err)
+		}
+		m.consentRequired = false
+		m.state = stateConfirmGenerate
+		return beginGeneration(m)
+	case declined:
+		m.state = stateConfirmGenerate
+	}
+	return m, nil
+}
+
+// consentSummary lists exactly what generation sends to the API, one item
+// per line.
+func consentSummary(m Model) []string {
+	var items []string
+	switch {
+	case m.sourcePDF:
+		items = append(items, fmt.Sprintf("📄 Your existing resume, %s, uploaded as a PDF document (deleted when you quit)",
+			m.sourcePathInput.Value()))
+	case uploadsSource(m.sourceContent, generateOptions(m)):
+		items = append(items, fmt.Sprintf("📄 Your existing resume, %s (%d characters), uploaded as a file (deleted when you quit)",
+			m.sourcePathInput.Value(), utf8.RuneCountInString(m.sourceContent)))
+	case m.sourceContent != "":
+		items = append(items, fmt.Sprintf("📄 Your existing resume, %s (%d characters)",
+			m.sourcePathInput.Value(), utf8.RuneCountInString(m.sourceContent)))
+	}
+	if m.stdinContent != "" {
+		items = append(items, fmt.Sprintf("✏️ The notes you typed (%d characters)", utf8.RuneCountInString(m.stdinContent)))
+	}
+	if len(m.skills) > 0 {
+		items = append(items, fmt.Sprintf("🧰 Your structured skills (%d entered)", len(m.skills)))
+	}
+	if lines := m.academics.Lines(); len(lines) > 0 {
+		items = append(items, "🎓 Your academic details: "+strings.Join(lines, "; "))
+	}
+	if m.flagCompany != "" {
+		items = append(items, fmt.Sprintf("🏢 The employer to research, %s, and the posting's text if it is a link", m.flagCompany))
+	}
+	if len(m.flagEmphasize) > 0 {
+		items = append(items, "🔑 The keywords to emphasize: "+strings.Join(m.flagEmphasize, ", "))
+	}
+	if m.flagAmend {
+		items = append(items, "🔁 Instructions to apply your notes as updates to the existing resume")
+	}
+	if m.flagExplain {
+		items = append(items, "💡 A follow-up question asking why the major changes were made")
+	}
+	if m.flagGaps && m.jobDescription != "" {
+		items = append(items, "🎯 The job description keywords your resume lacks, to suggest how to close the gaps")
+	}
+	if m.flagBundle {
+		items = append(items, "✉️ The generated resume, to write the matching cover letter")
+	}
+	if len(m.flagDocuments) > 0 {
+		items = append(items, "📝 Follow-up requests to write "+documentTitles(m.flagDocuments))
+	}
+	return items
+}
+
+// consentModels names the models the inputs may be sent to, and the API
+// gateway they pass through when one is configured.
+func consentModels(m Model) string {
+	models := api.DefaultModelName
+	if m.fallbackModel != "" {
+		models += ", or " + m.fallbackModel + " if it fails"
+	}
+	if endpoint := api.ActiveGateway.Endpoint; endpoint != "" {
+		models += ", through the gateway at " + endpoint
+	}
+	return models
+}
+
+// renderConsentView renders the summary of the data that will be sent to
+// Google and asks the user to agree before the first request.
+func renderConsentView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(1).
+		Width(displayWidth - 4).
+		Align(lipgloss.Center).
+		Render("🔒 Before Your Data Is Sent")
+
+	description := layout.Wrap(fmt.Sprintf("Generating your resume sends the following to Google's Gemini API (%s). "+
+		"Google processes it under the Gemini API terms, so leave out anything you may not share.", consentModels(m)), displayWidth-8)
+
+	var items []string
+	for _, item := range consentSummary(m) {
+		items = append(items, "• "+item)
+	}
+	if m.jobDescription != "" {
+		items = append(items, "", jobDescriptionNote(m))
+	}
+	itemsBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(focusBorderColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(layout.Wrap(strings.Join(items, "\n"), displayWidth-12))
+
+	remembered := "Your answer is remembered, so you are only asked once."
+	if path, err := config.ConsentPath(); err == nil {
+		remembered = fmt.Sprintf("Your answer is remembered in %s; delete that file to be asked again.", path)
+	}
+
+	instruction := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(accentColor).
+		Render(layout.Wrap("Press Y to agree and generate, or N to go back", displayWidth-8))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		description,
+		"",
+		itemsBox,
+		"",
+		italicStyle.Render(layout.Wrap(remembered, displayWidth-8)),
+		"",
+		instruction,
+	)
+}
+
+// jobDescriptionNote tells the user that the job description stays on this
+// computer, and with -gaps that only the keywords the resume lacks are sent.
+func jobDescriptionNote(m Model) string {
+	if m.flagGaps {
+		return "The job description is used on this computer, for the keyword analysis and to pick which of your experiences to feature. It is not sent; only its keywords your resume lacks are."
+	}
+	return "The job description is only used on this computer, for the keyword analysis and to pick which of your experiences to feature, and is not sent."
+}
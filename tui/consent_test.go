@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+)
+
+// consentModel returns a model on the confirm screen that has not been given
+// consent, with the consent file kept in a temporary directory
+func consentModel(t *testing.T) Model {
+	t.Setenv(config.DirEnvVar, t.TempDir())
+
+	m := NewModel().WithConsentRequired(true).WithCompany("Acme").WithFallbackModel("backup-model")
+	m.width = 100
+	m.state = stateConfirmGenerate
+	m.sourcePathInput.SetValue("resume.md")
+	m.sourceContent = "# Jane Doe"
+	m.stdinContent = "Led the payments team"
+	return m
+}
+
+func TestConsentScreen(t *testing.T) {
+	m := consentModel(t)
+
+	// Test case 1: Enter on the confirm screen asks for consent first
+	m = pressKey(m, tea.KeyEnter)
+	if m.state != stateConsent {
+		t.Fatalf("Expected the consent screen, got state %v", m.state)
+	}
+	view := renderConsentView(m)
+	for _, want := range []string{"resume.md (10 characters)", "notes you typed (21 characters)", "Acme", "backup-model", config.ConsentFileName} {
+		if !strings.Contains(strings.Join(strings.Fields(view), " "), want) {
+			t.Errorf("Expected the consent screen to mention %q", want)
+		}
+	}
+
+	// Test case 2: 'n' goes back without sending anything or remembering
+	m = typeText(m, "n")
+	if m.state != stateConfirmGenerate || !m.consentRequired {
+		t.Fatalf("Expected 'n' to return to the confirm screen, got state %v", m.state)
+	}
+	if consented, _ := config.HasConsent(); consented {
+		t.Error("Expected declining not to be remembered")
+	}
+
+	// Test case 3: 'y' remembers the answer and starts generating, once
+	// the guard against a repeated Enter has expired
+	m.acceptGuard = time.Time{}
+	m = pressKey(m, tea.KeyEnter)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if m.state != stateGenerating || cmd == nil {
+		t.Fatalf("Expected 'y' to start generating, got state %v", m.state)
+	}
+	if consented, err := config.HasConsent(); !consented || err != nil {
+		t.Errorf("Expected consent to be remembered, got %v (%v)", consented, err)
+	}
+	if m.consentRequired {
+		t.Error("Expected consent not to be asked for again in this run")
+	}
+}
+
+func TestConsentBeforeOverwrite(t *testing.T) {
+	m := consentModel(t)
+	path := filepath.Join(t.TempDir(), "resume_out.md")
+	if err := os.WriteFile(path, []byte("# Old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m = m.WithOutputPath(path)
+
+	// Consent comes first, then the overwrite confirmation
+	m = pressKey(m, tea.KeyEnter)
+	m = typeText(m, "y")
+	if m.state != stateConfirmGenerate || m.pendingConfirm != confirmOverwrite {
+		t.Errorf("Expected the overwrite confirmation after consent, got state %v and dialog %v", m.state, m.pendingConfirm)
+	}
+}
+
+func TestConsentSkippedForReplay(t *testing.T) {
+	m := consentModel(t).WithFixtures(api.Fixtures{Mode: api.FixtureReplay, Dir: t.TempDir()})
+
+	// Replayed responses send nothing, so no consent is needed
+	m, _ = beginGeneration(m)
+	if m.state != stateGenerating {
+		t.Errorf("Expected replay to generate without consent, got state %v", m.state)
+	}
+}
+
+func TestConsentSummary(t *testing.T) {
+	m := NewModel()
+	m.stdinContent = "Built X"
+
+	// Only the inputs that will be sent are listed
+	items := consentSummary(m)
+	if len(items) != 1 || !strings.Contains(items[0], "notes") {
+		t.Errorf("Expected only the notes to be listed, got %v", items)
+	}
+
+	m = m.WithBundle(true)
+	if items := consentSummary(m); len(items) != 2 || !strings.Contains(items[1], "cover letter") {
+		t.Errorf("Expected bundle mode to list the cover letter request, got %v", items)
+	}
+
+	m = m.WithEmphasize([]string{"kubernetes", "grpc"})
+	if items := consentSummary(m); len(items) != 3 || !strings.Contains(items[1], "kubernetes, grpc") {
+		t.Errorf("Expected the emphasized keywords to be listed, got %v", items)
+	}
+
+	m = m.WithAmend(true)
+	if items := consentSummary(m); len(items) != 4 || !strings.Contains(items[2], "updates to the existing resume") {
+		t.Errorf("Expected amend mode to be listed, got %v", items)
+	}
+}
+
+func TestRepeatedEnterGuard(t *testing.T) {
+	m := consentModel(t)
+
+	// Test case 1: Mashing Enter on the confirm screen does not agree to sending the inputs
+	m = pressKey(pressKey(pressKey(m, tea.KeyEnter), tea.KeyEnter), tea.KeyEnter)
+	if m.state != stateConsent || !m.consentRequired {
+		t.Fatalf("Expected the consent screen to wait for an answer, got state %v", m.state)
+	}
+
+	// Test case 2: While generating, Enter and a second start are ignored
+	m = typeText(m, "y")
+	if m.state != stateGenerating || !m.generating {
+		t.Fatalf("Expected generation to start, got state %v", m.state)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || updated.(Model).state != stateGenerating {
+		t.Error("Expected Enter to be ignored while generating")
+	}
+	if _, cmd := runGeneration(m); cmd != nil {
+		t.Error("Expected no second request while one is in flight")
+	}
+
+	// Test case 3: Enter right after the result arrives does not quit, and does once the guard expires
+	updated, _ = m.Update(APIResultMsg{Success: false, Error: errors.New("quota exceeded")})
+	m = updated.(Model)
+	if m.generating {
+		t.Error("Expected the result to end the generation")
+	}
+	if updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || updated.(Model).state != stateResultError {
+		t.Error("Expected a repeated Enter not to quit from the result")
+	}
+	m.acceptGuard = time.Now().Add(-time.Millisecond)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Error("Expected Enter to quit once the guard expired")
+	}
+}
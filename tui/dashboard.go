@@ -0,0 +1,335 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+)
+
+// maxDashboardEntries is the number of resumes the dashboard lists at once.
+const maxDashboardEntries = 8
+
+// workspaceEntry is one resume listed on the workspace dashboard: the latest
+// revision of a profile and the files it was written to that still exist.
+type workspaceEntry struct {
+	revision history.Revision
+	files    []string // The Markdown file and the exports next to it, by base name
+	missing  bool     // Whether the recorded Markdown file no longer exists
+}
+
+// workspaceLoadedMsg carries the resumes read from the history.
+type workspaceLoadedMsg struct {
+	entries []workspaceEntry
+	err     error
+}
+
+// loadWorkspaceCmd reads the latest resume of each profile from the history
+// in dir, newest first, and checks which of the files it was written to are
+// still there.
+func loadWorkspaceCmd(dir string) tea.Cmd {
+	return func() tea.Msg {
+		latest, err := history.LatestOfEach(dir)
+		if err != nil {
+			return workspaceLoadedMsg{err: err}
+		}
+		entries := make([]workspaceEntry, 0, len(latest))
+		for i := len(latest) - 1; i >= 0; i-- {
+			entry := workspaceEntry{revision: latest[i]}
+			if path := latest[i].Details.OutputPath; path != "" {
+				entry.files = writtenFiles(path)
+				entry.missing = len(entry.files) == 0
+			}
+			entries = append(entries, entry)
+		}
+		return workspaceLoadedMsg{entries: entries}
+	}
+}
+
+// writtenFiles returns the base names of the Markdown file at path and the
+// HTML, JSON Resume, and document exports written next to it that exist.
+func writtenFiles(path string) []string {
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	candidates := []string{path, output.HTMLPath(path), output.JSONResumePath(path)}
+	for _, format := range output.ExportFormats {
+		candidates = append(candidates, output.ExportPath(path, format))
+	}
+
+	var files []string
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			files = append(files, filepath.Base(candidate))
+		}
+	}
+	return files
+}
+
+// openDashboard shows the workspace dashboard and reads the resumes it lists.
+func openDashboard(m Model) (Model, tea.Cmd) {
+	m.state = stateDashboard
+	m.workspaceLoaded = false
+	m.workspaceErr, m.workspaceNote = "", ""
+	return m, loadWorkspaceCmd(m.historyDir)
+}
+
+// handleWorkspaceLoaded lists the resumes read from the history, keeping the
+// selection in range.
+func handleWorkspaceLoaded(m Model, msg workspaceLoadedMsg) Model {
+	m.workspace, m.workspaceLoaded = msg.entries, true
+	m.workspaceErr = ""
+	if msg.err != nil {
+		m.workspaceErr = msg.err.Error()
+	}
+	m.workspaceCursor = max(min(m.workspaceCursor, len(m.workspace)-1), 0)
+	return m
+}
+
+// updateDashboard handles key presses on the workspace dashboard: arrows
+// select a resume, Enter opens it, 'r' starts a new resume from it, 't'
+// asks for a job description to tailor it to, 'x' deletes its history, and
+// 'w' returns to the welcome screen.
+func updateDashboard(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := m.keyMap()
+	if m.workspaceTailoring {
+		return updateTailorInput(m, msg)
+	}
+
+	if key.Matches(msg, keys.Workspace) {
+		m.state = stateWelcome
+		m.workspaceErr, m.workspaceNote = "", ""
+		return m, nil
+	}
+	if len(m.workspace) == 0 {
+		return m, nil
+	}
+
+	entry := m.workspace[m.workspaceCursor]
+	switch {
+	case key.Matches(msg, keys.Up):
+		m.workspaceCursor = max(m.workspaceCursor-1, 0)
+	case key.Matches(msg, keys.Down):
+		m.workspaceCursor = min(m.workspaceCursor+1, len(m.workspace)-1)
+	case key.Matches(msg, keys.Accept):
+		m.resultContent = entry.revision.Content
+		if path := entry.revision.Details.OutputPath; len(entry.files) > 0 {
+			if content, err := os.ReadFile(path); err == nil {
+				m.resultContent = string(content)
+			}
+		}
+		m.workspaceErr, m.workspaceNote = "", ""
+		return openResumePreview(m)
+	case key.Matches(msg, keys.Again):
+		return regenerateFromWorkspace(m)
+	case key.Matches(msg, keys.Tailor):
+		if _, ok := workspaceSource(m); !ok {
+			m.workspaceErr = sourceMissingMessage
+			return m, nil
+		}
+		m.workspaceJobInput = textinput.New()
+		m.workspaceJobInput.Placeholder = "path/to/job.txt"
+		m.workspaceJobInput.Width = getConstrainedWidth(m.width) - 16
+		m.workspaceTailoring = true
+		m.workspaceErr, m.workspaceNote = "", ""
+		return m, m.workspaceJobInput.Focus()
+	case key.Matches(msg, keys.Delete):
+		return openConfirmDialog(m, confirmDeleteResume), nil
+	}
+	return m, nil
+}
+
+// updateTailorInput handles key presses while the job description to tailor
+// the selected resume to is entered: Enter reads it and starts a new resume
+// from the selected one, and Ctrl+X goes back to the list.
+func updateTailorInput(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := m.keyMap()
+	switch {
+	case key.Matches(msg, keys.Remove):
+		m.workspaceTailoring = false
+		m.workspaceJobInput.Blur()
+		m.workspaceErr = ""
+		return m, nil
+	case key.Matches(msg, keys.Accept):
+		path := input.ExpandPath(strings.TrimSpace(m.workspaceJobInput.Value()))
+		if path == "" {
+			m.workspaceErr = "Enter the path of the job description to tailor the resume to."
+			return m, nil
+		}
+		content, warning, err := input.ReadPromptFile(path)
+		if err != nil {
+			m.workspaceErr = err.Error()
+			return m, nil
+		}
+		m.workspaceTailoring = false
+		m.workspaceJobInput.Blur()
+		m.jobDescription, m.jobPath, m.jobWarning = content, path, warning
+		return regenerateFromWorkspace(m)
+	}
+
+	var cmd tea.Cmd
+	m.workspaceJobInput, cmd = m.workspaceJobInput.Update(msg)
+	return m, cmd
+}
+
+// sourceMissingMessage explains why a resume whose file is gone cannot be
+// regenerated or tailored.
+const sourceMissingMessage = "The file this resume was written to is not available; open it to see the saved copy."
+
+// workspaceSource returns the file the selected resume was written to, to
+// generate a new resume from, if it still exists.
+func workspaceSource(m Model) (string, bool) {
+	entry := m.workspace[m.workspaceCursor]
+	if len(entry.files) == 0 {
+		return "", false
+	}
+	return entry.revision.Details.OutputPath, true
+}
+
+// regenerateFromWorkspace starts the wizard with the selected resume's file
+// as the source resume, so the notes and job description can be added to it.
+func regenerateFromWorkspace(m Model) (Model, tea.Cmd) {
+	path, ok := workspaceSource(m)
+	if !ok {
+		m.workspaceErr = sourceMissingMessage
+		return m, nil
+	}
+	m.flagSourcePath = path
+	m.sourcePathInput.SetValue(path)
+	m.workspaceErr, m.workspaceNote = "", ""
+	return beginInputs(m)
+}
+
+// deleteSelectedResume deletes every saved version of the selected resume
+// from the history, leaving the files it was written to in place.
+func deleteSelectedResume(m Model) (Model, tea.Cmd) {
+	entry := m.workspace[m.workspaceCursor]
+	if err := history.DeleteProfile(m.historyDir, entry.revision.Profile); err != nil {
+		m.workspaceErr = err.Error()
+		return m, nil
+	}
+	m.workspace = append(m.workspace[:m.workspaceCursor:m.workspaceCursor], m.workspace[m.workspaceCursor+1:]...)
+	m.workspaceCursor = max(min(m.workspaceCursor, len(m.workspace)-1), 0)
+	m.workspaceErr = ""
+	m.workspaceNote = fmt.Sprintf("Deleted the history of %s.", entry.revision.Profile)
+	return m, nil
+}
+
+// relativeDay describes when t was, relative to now, such as "today" or "3 days ago".
+func relativeDay(t, now time.Time) string {
+	day := func(t time.Time) time.Time {
+		year, month, d := t.Date()
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	days := int(day(now).Sub(day(t.In(now.Location()))).Hours() / 24)
+	switch {
+	case days <= 0:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	case days < 30:
+		return fmt.Sprintf("%d days ago", days)
+	}
+	return t.Format("Jan 2, 2006")
+}
+
+// describeEntry returns the two lines that list a resume on the dashboard:
+// its profile and when it was last generated, then what it was written for
+// and the files it was written to.
+func describeEntry(entry workspaceEntry, now time.Time) (string, string) {
+	heading := fmt.Sprintf("%s · %s", entry.revision.Profile, relativeDay(entry.revision.Saved, now))
+
+	details := entry.revision.Details
+	target := "no target job"
+	switch {
+	case details.Company != "":
+		target = details.Company
+	case details.JobPath != "":
+		target = filepath.Base(details.JobPath)
+	}
+
+	files := strings.Join(entry.files, ", ")
+	switch {
+	case details.OutputPath == "":
+		files = "files not recorded"
+	case entry.missing:
+		files = filepath.Base(details.OutputPath) + " is missing"
+	}
+	return heading, target + " · " + files
+}
+
+// renderDashboardView renders the workspace dashboard: the latest resume of
+// each profile, with when it was generated, its target job, and its files.
+func renderDashboardView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(1).
+		Width(displayWidth - 4).
+		Align(lipgloss.Center).
+		Render("🗂 Workspace")
+
+	description := layout.Wrap("The latest resume of each person you have generated one for. "+
+		"Open one to read it, regenerate it with new notes, or tailor it to a job.", displayWidth-8)
+
+	var body strings.Builder
+	switch {
+	case !m.workspaceLoaded:
+		body.WriteString(italicStyle.Render("Reading the history..."))
+	case len(m.workspace) == 0 && m.workspaceErr == "":
+		body.WriteString(layout.Wrap("No resumes yet. The resumes you generate are listed here.", displayWidth-12))
+	default:
+		start := max(0, m.workspaceCursor-maxDashboardEntries+1)
+		end := min(len(m.workspace), start+maxDashboardEntries)
+		for i := start; i < end; i++ {
+			if i > start {
+				body.WriteString("\n\n")
+			}
+			heading, details := describeEntry(m.workspace[i], time.Now())
+			heading = layout.Truncate(heading, displayWidth-14)
+			details = layout.Truncate(details, displayWidth-14)
+			if i == m.workspaceCursor {
+				body.WriteString(lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("▸ " + heading))
+			} else {
+				body.WriteString("  " + heading)
+			}
+			body.WriteString("\n  " + italicStyle.Render(details))
+		}
+		if more := len(m.workspace) - end; more > 0 {
+			body.WriteString(fmt.Sprintf("\n\n  … %d more", more))
+		}
+	}
+
+	listBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(focusBorderColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(body.String())
+
+	parts := []string{title, "", description, "", listBox}
+	if m.workspaceTailoring {
+		label := layout.Wrap("Path of the job description to tailor "+m.workspace[m.workspaceCursor].revision.Profile+" to:", displayWidth-8)
+		parts = append(parts, "", label, m.workspaceJobInput.View())
+	}
+	if m.workspaceNote != "" {
+		parts = append(parts, "", successStyle.Render(layout.Wrap(m.workspaceNote, displayWidth-8)))
+	}
+	if m.workspaceErr != "" {
+		parts = append(parts, "", errorStyle.Render(layout.Wrap(m.workspaceErr, displayWidth-8)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, parts...)
+}
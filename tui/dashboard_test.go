@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/output"
+)
+
+func TestWorkspaceDashboard(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", "AIza"+strings.Repeat("x", 35))
+
+	// Two resumes in the history: Jane's, written to a file with an HTML
+	// copy, and John's older one, whose file has since been deleted
+	setup := func(t *testing.T) (Model, string, string) {
+		historyDir := filepath.Join(t.TempDir(), "history")
+		work := t.TempDir()
+		janePath := filepath.Join(work, "jane.md")
+		if err := os.WriteFile(janePath, []byte("# Jane Doe\n\nEdited by hand."), 0644); err != nil {
+			t.Fatalf("Failed to write resume: %v", err)
+		}
+		if err := os.WriteFile(output.HTMLPath(janePath), []byte("<html></html>"), 0644); err != nil {
+			t.Fatalf("Failed to write HTML: %v", err)
+		}
+
+		saved := time.Now()
+		if _, err := history.SaveWithDetails(historyDir, "# John Roe\n\nGo developer.", saved.Add(-time.Hour),
+			history.Details{OutputPath: filepath.Join(work, "john.md")}); err != nil {
+			t.Fatalf("Failed to save revision: %v", err)
+		}
+		if _, err := history.SaveWithDetails(historyDir, "# Jane Doe\n\nBackend engineer.", saved,
+			history.Details{OutputPath: janePath, Company: "Acme"}); err != nil {
+			t.Fatalf("Failed to save revision: %v", err)
+		}
+
+		m := NewModel().WithHistoryDir(historyDir)
+		m.apiKeyOk = true
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+		m = updated.(Model)
+		if m.state != stateDashboard || cmd == nil {
+			t.Fatalf("Expected 'w' to open the dashboard and load it, got state %v", m.state)
+		}
+		updated, _ = m.Update(cmd())
+		return updated.(Model), historyDir, janePath
+	}
+
+	t.Run("Lists the latest resume of each profile, newest first", func(t *testing.T) {
+		m, _, _ := setup(t)
+		m.width = 100
+
+		if len(m.workspace) != 2 || m.workspace[0].revision.Profile != "jane-doe" {
+			t.Fatalf("Expected Jane's resume first of 2, got %+v", m.workspace)
+		}
+		view := renderDashboardView(m)
+		for _, want := range []string{"jane-doe · today", "Acme · jane.md, jane.html", "no target job · john.md is missing"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected the dashboard to show %q, got:\n%s", want, view)
+			}
+		}
+	})
+
+	t.Run("Enter opens the written file and returns to the dashboard", func(t *testing.T) {
+		m, _, _ := setup(t)
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+		if m.state != stateResumePreview || m.resultContent != "# Jane Doe\n\nEdited by hand." {
+			t.Fatalf("Expected the written file in the preview, got state %v and %q", m.state, m.resultContent)
+		}
+
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if m = updated.(Model); m.state != stateDashboard {
+			t.Errorf("Expected the preview to return to the dashboard, got state %v", m.state)
+		}
+	})
+
+	t.Run("Regenerating uses the written file as the source", func(t *testing.T) {
+		m, _, janePath := setup(t)
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		m = updated.(Model)
+		if m.state != stateInputSourcePath || m.sourcePathInput.Value() != janePath {
+			t.Errorf("Expected the source path step with %s, got state %v and %q", janePath, m.state, m.sourcePathInput.Value())
+		}
+	})
+
+	t.Run("A resume whose file is missing cannot be regenerated", func(t *testing.T) {
+		m, _, _ := setup(t)
+		m.workspaceCursor = 1
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		m = updated.(Model)
+		if m.state != stateDashboard || m.workspaceErr != sourceMissingMessage {
+			t.Errorf("Expected to stay on the dashboard with an error, got state %v and %q", m.state, m.workspaceErr)
+		}
+	})
+
+	t.Run("Tailoring reads the job description before regenerating", func(t *testing.T) {
+		m, _, janePath := setup(t)
+		jobPath := filepath.Join(t.TempDir(), "job.txt")
+		if err := os.WriteFile(jobPath, []byte("Senior Go engineer"), 0644); err != nil {
+			t.Fatalf("Failed to write job description: %v", err)
+		}
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+		m = updated.(Model)
+		if !m.workspaceTailoring || helpAvailable(m) {
+			t.Fatal("Expected 't' to ask for a job description with help off")
+		}
+
+		// A missing file keeps the question open
+		m.workspaceJobInput.SetValue(jobPath + ".missing")
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if m = updated.(Model); !m.workspaceTailoring || m.workspaceErr == "" {
+			t.Fatalf("Expected an error for a missing job description, got %q", m.workspaceErr)
+		}
+
+		m.workspaceJobInput.SetValue(jobPath)
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+		if m.state != stateInputSourcePath || m.jobDescription != "Senior Go engineer" || m.jobPath != jobPath {
+			t.Errorf("Expected the job description set and the source step, got state %v, %q", m.state, m.jobDescription)
+		}
+		if m.sourcePathInput.Value() != janePath {
+			t.Errorf("Expected the source path %s, got %q", janePath, m.sourcePathInput.Value())
+		}
+	})
+
+	t.Run("Deleting asks first and keeps the written files", func(t *testing.T) {
+		m, historyDir, janePath := setup(t)
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		m = updated.(Model)
+		if m.pendingConfirm != confirmDeleteResume {
+			t.Fatal("Expected 'x' to ask before deleting")
+		}
+
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		m = updated.(Model)
+		if len(m.workspace) != 1 || m.workspace[0].revision.Profile != "john-roe" {
+			t.Fatalf("Expected only John's resume left, got %+v", m.workspace)
+		}
+		if _, err := os.Stat(filepath.Join(historyDir, "jane-doe")); !os.IsNotExist(err) {
+			t.Errorf("Expected Jane's history to be deleted, got %v", err)
+		}
+		if _, err := os.Stat(janePath); err != nil {
+			t.Errorf("Expected Jane's resume file to be kept, got %v", err)
+		}
+	})
+}
+
+func TestRelativeDay(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		saved time.Time
+		want  string
+	}{
+		// Test case 1: Earlier the same day
+		{time.Date(2024, 5, 10, 1, 0, 0, 0, time.UTC), "today"},
+		// Test case 2: Late the day before
+		{time.Date(2024, 5, 9, 23, 0, 0, 0, time.UTC), "yesterday"},
+		// Test case 3: Within a month
+		{time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), "9 days ago"},
+		// Test case 4: Longer ago shows the date
+		{time.Date(2023, 12, 24, 12, 0, 0, 0, time.UTC), "Dec 24, 2023"},
+	}
+
+	for _, tt := range tests {
+		if got := relativeDay(tt.saved, now); got != tt.want {
+			t.Errorf("relativeDay(%v) = %q, want %q", tt.saved, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/faults"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// GeneratedDocument is a document written from the same inputs as the
+// resume, such as a thank-you email, and where it was saved.
+type GeneratedDocument struct {
+	Type    prompt.DocumentType // Which document it is
+	Content string              // The document in Markdown
+	Path    string              // Where it was saved (empty until it is)
+}
+
+// writeDocuments asks the model, on the session that produced the resume,
+// for each of the documents requested with -documents, in order. The
+// documents are optional, so a failed one is left out with a note saying
+// why, and generation carries on.
+func writeDocuments(ctx context.Context, session *api.Session, documents []prompt.DocumentType, company string, opts GenerateOptions) ([]GeneratedDocument, string) {
+	var written []GeneratedDocument
+	var notes []string
+	for _, document := range documents {
+		content, err := writeDocument(ctx, session, document, company)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("The %s could not be written (%v), so it was not saved", document.Title(), err))
+			continue
+		}
+		if opts.Grounding.Strict() {
+			content = output.StripCitations(content)
+		}
+		written = append(written, GeneratedDocument{Type: document, Content: output.FormatProse(content, opts.ProseWidth)})
+	}
+	return written, strings.Join(notes, ". ")
+}
+
+// writeDocument asks the model for one document and returns its text.
+func writeDocument(ctx context.Context, session *api.Session, document prompt.DocumentType, company string) (string, error) {
+	if session == nil {
+		return "", errors.New("the conversation that wrote the resume is no longer open")
+	}
+
+	response, err := session.Send(faults.WithStage(ctx, faults.StageDocument), prompt.GenerateDocumentPromptContent(document, company))
+	if err != nil {
+		return "", err
+	}
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return "", err
+	}
+	text = output.ExtractFencedContent(text)
+	if strings.TrimSpace(text) == "" {
+		return "", errors.New("the response was empty")
+	}
+	return text, nil
+}
+
+// saveDocuments writes the documents generated alongside the resume next to
+// it and records where.
+func saveDocuments(result *APIResultMsg) error {
+	// The documents are copied, so a failed save leaves the paths of other copies of the result alone
+	documents := append([]GeneratedDocument(nil), result.Documents...)
+	for i, document := range documents {
+		path, err := output.WriteDocument(document.Content, result.OutputPath, document.Type.FileName())
+		if err != nil {
+			return err
+		}
+		documents[i].Path = path
+	}
+	result.Documents = documents
+	return nil
+}
+
+// documentTitles joins the titles of documents for messages, such as "the
+// thank-you email and the LinkedIn summary".
+func documentTitles(documents []prompt.DocumentType) string {
+	titles := make([]string, len(documents))
+	for i, document := range documents {
+		titles[i] = "the " + document.Title()
+	}
+	if len(titles) < 2 {
+		return strings.Join(titles, "")
+	}
+	return strings.Join(titles[:len(titles)-1], ", ") + " and " + titles[len(titles)-1]
+}
@@ -1,7 +1,12 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
 )
 
 // Error categories
@@ -10,6 +15,9 @@ const (
 	categoryAPIAuth       = "API Authentication Error"
 	categoryAPIQuota      = "API Quota Error"
 	categoryAPINetwork    = "Network Error"
+	categoryAPIRequest    = "API Request Error"
+	categoryAPIServer     = "API Server Error"
+	categoryAPIModel      = "Model Unavailable"
 	categoryAPISafety     = "Safety Filter Error"
 	categoryAPITruncation = "Content Truncation Error"
 	
@@ -17,6 +25,7 @@ const (
 	categoryFileNotFound  = "File Error"
 	categoryFileSize      = "File Size Error"
 	categoryFilePermission = "File Permission Error"
+	categoryFileFormat     = "File Format Error"
 	
 	// Output-related errors
 	categoryWritePermission = "Write Permission Error"
@@ -32,11 +41,13 @@ const (
 	geminiDocsRef = "Gemini API documentation: https://ai.google.dev/docs"
 )
 
-// analyzeError examines the error message and returns:
+// analyzeError examines the error and returns:
 // 1. A category to help the user understand what went wrong
 // 2. Specific troubleshooting hints based on the error type
 // 3. Optional documentation reference (if available)
-func analyzeError(errorMsg string) (category string, hints []string, docRef string) {
+// API failures are categorized by their api.APIError code; other errors by
+// their message.
+func analyzeError(err error) (category string, hints []string, docRef string) {
 	// Default to generic category
 	category = categoryGeneric
 	
@@ -47,57 +58,18 @@ func analyzeError(errorMsg string) (category string, hints []string, docRef stri
 		"Restart the application and try again",
 	}
 	
-	// Now check for specific error patterns, starting with API errors
-	
-	// API authentication errors
-	if containsAny(errorMsg, []string{
-		"API authentication error", 
-		"UNAUTHENTICATED", 
-		"Invalid API key",
-		"API key not valid",
-	}) {
-		category = categoryAPIAuth
-		hints = []string{
-			"Check your GEMINI_API_KEY environment variable is set correctly",
-			"Verify your API key is valid and not expired",
-			"Make sure you're using the correct API key format",
-		}
-		docRef = apiDocRef
+	if err == nil {
 		return
 	}
 	
-	// API quota or rate limit errors
-	if containsAny(errorMsg, []string{
-		"quota or rate limit exceeded",
-		"RESOURCE_EXHAUSTED",
-		"Quota exceeded",
-		"rate limit",
-	}) {
-		category = categoryAPIQuota
-		hints = []string{
-			"Wait a few minutes and try again",
-			"Check if you've reached your API quota limit for the day",
-			"Consider creating a new API key or upgrading your account",
-		}
-		docRef = apiDocRef
-		return
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		return analyzeAPIError(apiErr, hints)
 	}
 	
-	// Network errors
-	if containsAny(errorMsg, []string{
-		"network error",
-		"deadline exceeded",
-		"connection",
-		"timeout",
-	}) {
-		category = categoryAPINetwork
-		hints = []string{
-			"Check your internet connection",
-			"Verify you can access the Gemini API (ping ai.google.dev)",
-			"If using a proxy or VPN, try disabling it temporarily",
-		}
-		return
-	}
+	errorMsg := err.Error()
+	
+	// Now check for specific error patterns in responses and files
 	
 	// Safety filter errors
 	if containsAny(errorMsg, []string{
@@ -147,6 +119,19 @@ func analyzeError(errorMsg string) (category string, hints []string, docRef stri
 		return
 	}
 	
+	// Binary or non-text source files
+	if containsAny(errorMsg, []string{
+		"is not a text file",
+	}) {
+		category = categoryFileFormat
+		hints = []string{
+			"Resumake reads text, Markdown, Word (.docx), JSON, CSV, and ZIP files",
+			"A PDF is uploaded as it is when it is the source file; export it as text to use it anywhere else",
+			"Re-save the file with UTF-8 encoding if it came from an older editor",
+		}
+		return
+	}
+	
 	// File size errors
 	if containsAny(errorMsg, []string{
 		"file size exceeds",
@@ -155,7 +140,8 @@ func analyzeError(errorMsg string) (category string, hints []string, docRef stri
 	}) {
 		category = categoryFileSize
 		hints = []string{
-			"Your file exceeds the 10MB size limit",
+			fmt.Sprintf("Your file exceeds the %s size limit", input.FormatFileSize(input.MaxFileSize)),
+			"Raise the limit with -max-file-size, or max-file-size in the settings file",
 			"Try splitting your content into smaller files",
 			"Remove unnecessary content to reduce file size",
 		}
@@ -212,6 +198,72 @@ func analyzeError(errorMsg string) (category string, hints []string, docRef stri
 	return
 }
 
+// analyzeAPIError returns the category, hints, and documentation reference
+// for a classified API failure. Unrecognized failures keep the generic hints.
+// The HTTP status is added to the category when known, and retryable
+// failures suggest trying again from the error screen.
+func analyzeAPIError(apiErr *api.APIError, genericHints []string) (category string, hints []string, docRef string) {
+	category, hints = categoryGeneric, genericHints
+	
+	switch apiErr.Code {
+	case api.CodeAuth:
+		category = categoryAPIAuth
+		hints = []string{
+			"Check your GEMINI_API_KEY environment variable is set correctly",
+			"Verify your API key is valid and not expired",
+			"Make sure you're using the correct API key format",
+		}
+		docRef = apiDocRef
+	case api.CodeQuota:
+		category = categoryAPIQuota
+		hints = []string{
+			"Wait a few minutes and try again",
+			"Check if you've reached your API quota limit for the day",
+			"Consider creating a new API key or upgrading your account",
+		}
+		docRef = apiDocRef
+		if apiErr.RetryAfter > 0 {
+			hints[0] = fmt.Sprintf("The API asks to wait %s before trying again", api.FormatWait(apiErr.RetryAfter))
+		}
+	case api.CodeNetwork:
+		category = categoryAPINetwork
+		hints = []string{
+			"Check your internet connection",
+			"Verify you can access the Gemini API (ping ai.google.dev)",
+			"If using a proxy or VPN, try disabling it temporarily",
+		}
+	case api.CodeInvalidRequest:
+		category = categoryAPIRequest
+		hints = []string{
+			"The API rejected the request; check your input for unusual content",
+			"Try shortening very long inputs",
+		}
+		docRef = apiDocRef
+	case api.CodeServer:
+		category = categoryAPIServer
+		hints = []string{
+			"The Gemini API is having problems or is overloaded",
+			"Wait a moment and try again",
+		}
+		docRef = apiDocRef
+	case api.CodeModelNotFound:
+		category = categoryAPIModel
+		hints = []string{
+			"The model may have been renamed or retired",
+			"Set -fallback-model to a model that is available to your key",
+		}
+		docRef = geminiDocsRef
+	}
+	
+	if apiErr.HTTPStatus != 0 {
+		category = fmt.Sprintf("%s (HTTP %d)", category, apiErr.HTTPStatus)
+	}
+	if apiErr.Retryable {
+		hints = append(hints, "This is usually temporary: press R to try again")
+	}
+	return
+}
+
 // containsAny checks if the string contains any of the patterns
 func containsAny(s string, patterns []string) bool {
 	for _, pattern := range patterns {
@@ -1,22 +1,32 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/phrazzld/resumake/api"
 )
 
+// apiRequestError returns the error the generator reports for a failed API
+// request with the given client error message.
+func apiRequestError(message string) error {
+	return fmt.Errorf("error executing API request: %w", api.ClassifyError(errors.New(message)))
+}
+
 func TestErrorAnalyzer(t *testing.T) {
 	testCases := []struct {
 		name                string
-		errorMsg            string
+		err                 error
 		expectedCategory    string
 		expectedHints       []string
 		shouldContainDocRef bool
 	}{
 		{
 			name:             "API authentication error",
-			errorMsg:         "error executing API request: API authentication error: UNAUTHENTICATED: Invalid API key",
-			expectedCategory: "API Authentication Error",
+			err:              apiRequestError("UNAUTHENTICATED: Invalid API key"),
+			expectedCategory: "API Authentication Error (HTTP 401)",
 			expectedHints: []string{
 				"Check your GEMINI_API_KEY environment variable is set correctly",
 				"Verify your API key is valid and not expired",
@@ -26,8 +36,8 @@ func TestErrorAnalyzer(t *testing.T) {
 		},
 		{
 			name:             "API quota exceeded",
-			errorMsg:         "error executing API request: API quota or rate limit exceeded: RESOURCE_EXHAUSTED: Quota exceeded",
-			expectedCategory: "API Quota Error",
+			err:              apiRequestError("googleapi: Error 429: RESOURCE_EXHAUSTED: Quota exceeded"),
+			expectedCategory: "API Quota Error (HTTP 429)",
 			expectedHints: []string{
 				"Wait a few minutes and try again",
 				"Check if you've reached your API quota limit for the day",
@@ -37,18 +47,48 @@ func TestErrorAnalyzer(t *testing.T) {
 		},
 		{
 			name:             "Network error",
-			errorMsg:         "error executing API request: network error while contacting API: deadline exceeded",
+			err:              apiRequestError("rpc error: code = DeadlineExceeded desc = deadline exceeded"),
 			expectedCategory: "Network Error",
 			expectedHints: []string{
 				"Check your internet connection",
 				"Verify you can access the Gemini API (ping ai.google.dev)",
 				"If using a proxy or VPN, try disabling it temporarily",
+				"This is usually temporary: press R to try again",
+			},
+			shouldContainDocRef: false,
+		},
+		{
+			name:             "Retired model",
+			err:              apiRequestError("googleapi: Error 404: models/gemini-1.0-pro is not found"),
+			expectedCategory: "Model Unavailable (HTTP 404)",
+			expectedHints: []string{
+				"Set -fallback-model to a model that is available to your key",
+			},
+			shouldContainDocRef: true,
+		},
+		{
+			name:             "API message without a classified error",
+			err:              errors.New("error executing API request: API authentication error: UNAUTHENTICATED"),
+			expectedCategory: "Error",
+			expectedHints: []string{
+				"Try running the command again",
+			},
+			shouldContainDocRef: false,
+		},
+		{
+			name:             "Binary source file",
+			err:              errors.New("failed to read source file: resume.pdf is not a text file: it is a PDF document. Export it as text (for example with pdftotext) or copy its contents into a .txt file."),
+			expectedCategory: "File Format Error",
+			expectedHints: []string{
+				"Resumake reads text, Markdown, Word (.docx), JSON, CSV, and ZIP files",
+				"A PDF is uploaded as it is when it is the source file; export it as text to use it anywhere else",
+				"Re-save the file with UTF-8 encoding if it came from an older editor",
 			},
 			shouldContainDocRef: false,
 		},
 		{
 			name:             "File not found error",
-			errorMsg:         "failed to read source file: file does not exist: /path/to/nonexistent.md",
+			err:              errors.New("failed to read source file: file does not exist: /path/to/nonexistent.md"),
 			expectedCategory: "File Error",
 			expectedHints: []string{
 				"Verify the file path is correct",
@@ -59,7 +99,7 @@ func TestErrorAnalyzer(t *testing.T) {
 		},
 		{
 			name:             "File size error",
-			errorMsg:         "failed to read source file: file size exceeds the maximum allowed size of 10485760 bytes: /path/to/large.md",
+			err:              errors.New("failed to read source file: file size exceeds the maximum allowed size of 10485760 bytes: /path/to/large.md"),
 			expectedCategory: "File Size Error",
 			expectedHints: []string{
 				"Your file exceeds the 10MB size limit",
@@ -70,7 +110,7 @@ func TestErrorAnalyzer(t *testing.T) {
 		},
 		{
 			name:             "Write permission error",
-			errorMsg:         "error writing output file: failed to write output: failed to write to file: permission denied",
+			err:              errors.New("error writing output file: failed to write output: failed to write to file: permission denied"),
 			expectedCategory: "Write Permission Error",
 			expectedHints: []string{
 				"You don't have permission to write to the output location",
@@ -81,7 +121,7 @@ func TestErrorAnalyzer(t *testing.T) {
 		},
 		{
 			name:             "Content truncation error",
-			errorMsg:         "error processing API response: response was truncated because it reached maximum token limit",
+			err:              errors.New("error processing API response: response was truncated because it reached maximum token limit"),
 			expectedCategory: "Content Truncation Error",
 			expectedHints: []string{
 				"Your input generated too much output",
@@ -92,7 +132,7 @@ func TestErrorAnalyzer(t *testing.T) {
 		},
 		{
 			name:             "Safety filter error",
-			errorMsg:         "error processing API response: Content was blocked due to safety filters",
+			err:              errors.New("error processing API response: Content was blocked due to safety filters"),
 			expectedCategory: "Safety Filter Error",
 			expectedHints: []string{
 				"Your content was flagged by the AI safety system",
@@ -103,7 +143,7 @@ func TestErrorAnalyzer(t *testing.T) {
 		},
 		{
 			name:             "Generic unrecognized error",
-			errorMsg:         "an unknown error occurred: something went wrong",
+			err:              errors.New("an unknown error occurred: something went wrong"),
 			expectedCategory: "Error",
 			expectedHints: []string{
 				"Try running the command again",
@@ -116,7 +156,7 @@ func TestErrorAnalyzer(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			category, hints, docRef := analyzeError(tc.errorMsg)
+			category, hints, docRef := analyzeError(tc.err)
 			
 			// Check category
 			if category != tc.expectedCategory {
@@ -149,8 +189,8 @@ func TestErrorAnalyzer(t *testing.T) {
 
 func TestEnhancedErrorView(t *testing.T) {
 	// Test that the error view includes troubleshooting tips
-	errorMsg := "error executing API request: API authentication error: UNAUTHENTICATED: Invalid API key"
-	model := Model{errorMsg: errorMsg, width: 100, height: 40}
+	err := apiRequestError("UNAUTHENTICATED: Invalid API key")
+	model := Model{errorMsg: err.Error(), err: err, width: 100, height: 40}
 	
 	errorView := renderErrorView(model)
 	
@@ -184,6 +224,20 @@ func TestEnhancedErrorView(t *testing.T) {
 	}
 }
 
+func TestErrorViewHyperlinks(t *testing.T) {
+	err := apiRequestError("UNAUTHENTICATED: Invalid API key")
+	model := Model{errorMsg: err.Error(), err: err, width: 100, height: 40}
+
+	if strings.Contains(renderErrorView(model), "\x1b]8;") {
+		t.Error("Error view should not write hyperlinks when the terminal does not support them")
+	}
+
+	model.hyperlinks = true
+	if !strings.Contains(renderErrorView(model), "\x1b]8;;https://ai.google.dev/docs/api_errors\x1b\\") {
+		t.Error("Error view should link the documentation reference")
+	}
+}
+
 // Helper function for string checking
 func containsString(haystack, needle string) bool {
 	return strings.Contains(haystack, needle)
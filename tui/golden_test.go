@@ -0,0 +1,255 @@
+package tui
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/usage"
+)
+
+// updateGolden rewrites the golden files instead of comparing against them:
+//
+//	go test ./tui -run TestViewSnapshots -update
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden with the current views")
+
+// goldenDir holds one golden file per view snapshot
+const goldenDir = "testdata/golden"
+
+// normalizeView makes a rendered view comparable across terminals: escape
+// codes are stripped, so color profiles do not matter, and the padding
+// lipgloss adds to fill a block's width is trimmed from each line.
+func normalizeView(view string) string {
+	lines := strings.Split(ansi.Strip(strings.ReplaceAll(view, "\r\n", "\n")), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// assertGolden compares a view with testdata/golden/<name>.golden, or
+// rewrites the file when -update is given. It also fails when a line of
+// the view is wider than the terminal it was rendered for.
+func assertGolden(t *testing.T, name, view string, width int) {
+	t.Helper()
+	got := normalizeView(view)
+
+	for i, line := range strings.Split(got, "\n") {
+		if w := layout.Width(line); w > width {
+			t.Errorf("Line %d is %d cells wide, more than the %d-cell terminal: %q", i+1, w, width, line)
+		}
+	}
+
+	path := filepath.Join(goldenDir, name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(goldenDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Could not read the golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("View does not match %s (run with -update if the change is intended)\n%s", path, goldenDiff(string(want), got))
+	}
+}
+
+// goldenDiff lists the lines that differ between the golden file and the view
+func goldenDiff(want, got string) string {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+	var b strings.Builder
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			b.WriteString("line " + strconv.Itoa(i+1) + ":\n  want: " + w + "\n  got:  " + g + "\n")
+		}
+	}
+	return b.String()
+}
+
+// snapshotResume is the generated resume shown by the result views
+const snapshotResume = "# Jane Doe\n\n## Summary\n\nBackend engineer who ships reliable Go services.\n\n## Experience\n\n### Senior Engineer | Globex | Jun 2019 – Mar 2023\n\n- Led the **checkout** rewrite\n- Cut latency by 40%\n\n### Engineer | Initech | 2015 - Dec 2018\n\n- Built the billing pipeline\n\n## Skills\n\n- Go\n- Kubernetes"
+
+// TestViewSnapshots renders every screen and compares it with its golden
+// file, so unintended changes to a view show up as a failing line diff.
+func TestViewSnapshots(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(m Model) Model
+	}{
+		{"welcome", func(m Model) Model {
+			return m
+		}},
+		{"welcome_missing_key", func(m Model) Model {
+			m.apiKeyOk = false
+			m.keyDiagnosis = api.DiagnoseAPIKey("")
+			return m
+		}},
+		{"source_input", func(m Model) Model {
+			m.state = stateInputSourcePath
+			return m
+		}},
+		{"stdin_input", func(m Model) Model {
+			m.state = stateInputStdin
+			m.stdinInput.SetValue("Led the payments team")
+			return m
+		}},
+		{"confirm_generate", func(m Model) Model {
+			m.state = stateConfirmGenerate
+			m.sourcePathInput.SetValue("/path/to/source.md")
+			m.sourceContent = "# Jane Doe"
+			m.stdinContent = "Led the payments team"
+			m.flagOutputPath = "/path/to/resume.md"
+			return m
+		}},
+		{"confirm_quota", func(m Model) Model {
+			m.state = stateConfirmGenerate
+			m.stdinContent = "Led the payments team"
+			m.flagOutputPath = "/path/to/resume.md"
+			status := usage.Check(nil, api.DefaultModelName, time.Now())
+			status.LastDay = status.Limits.RequestsPerDay
+			m.quota = &status
+			return m
+		}},
+		{"confirm_dialog", func(m Model) Model {
+			m.state = stateInputStdin
+			m.stdinInput.SetValue("Led the payments team")
+			return pressKey(m, tea.KeyEsc)
+		}},
+		{"generating", func(m Model) Model {
+			m.state = stateGenerating
+			m.progressStep = "2 of 4"
+			m.progressMsg = "Sending request to Gemini AI..."
+			return m
+		}},
+		{"success", func(m Model) Model {
+			m.state = stateResultSuccess
+			m.outputPath = "/tmp/resume_output.md"
+			m.resultContent = snapshotResume
+			m.resultMessage = "1500"
+			return m
+		}},
+		{"error", func(m Model) Model {
+			m.state = stateResultError
+			m.errorMsg = "API connection failed"
+			return m
+		}},
+		{"analysis", func(m Model) Model {
+			m = m.WithJobDescription("Senior Go engineer for checkout and billing")
+			m.state = stateAnalysis
+			m.resultContent = snapshotResume
+			return m
+		}},
+		{"skills_input", func(m Model) Model {
+			m.state = stateInputSkills
+			m.skills = []document.Skill{{Name: "Kubernetes", Years: 3, Proficiency: document.ProficiencyAdvanced}}
+			return m
+		}},
+		{"json_fixer", func(m Model) Model {
+			pending := invalidJSONResume()
+			updated, _ := m.Update(APIResultMsg{Success: true, Content: "# Jane Doe", OutputPath: "/tmp/resume.md", PendingJSON: &pending})
+			return updated.(Model)
+		}},
+		{"timeline", func(m Model) Model {
+			m.state = stateTimeline
+			m.resultContent = snapshotResume
+			return m
+		}},
+		{"save_fallback", func(m Model) Model {
+			updated, _ := m.Update(SaveFailedMsg{
+				Result: APIResultMsg{Success: true, Content: "# Jane Doe", ModelName: "test-model"},
+				Path:   "/tmp/blocker/resume.md",
+				Error:  errors.New("error writing output file: not a directory"),
+			})
+			return updated.(Model)
+		}},
+		{"consent", func(m Model) Model {
+			m = m.WithCompany("Acme").WithFallbackModel("backup-model")
+			m.state = stateConsent
+			m.sourcePathInput.SetValue("resume.md")
+			m.sourceContent = "# Jane Doe"
+			m.stdinContent = "Led the payments team"
+			return m
+		}},
+		{"revision_diff", func(m Model) Model {
+			updated, _ := m.Update(APIResultMsg{
+				Success: true,
+				Content: "# Jane Doe\n\n## Summary\n\nGo engineer.\n\n## Experience\n- Mentored three engineers\n- Cut latency by 40%",
+				Previous: &history.Revision{
+					Content: "# Jane Doe\n\n## Experience\n- Mentored two engineers\n- Wrote docs",
+					Saved:   time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC),
+					Path:    "/config/history/jane-doe/2024-06-01T093000.000.md",
+				},
+			})
+			return typeText(updated.(Model), "d")
+		}},
+		{"section_editor", func(m Model) Model {
+			m.state = stateRegenerateSection
+			m.resultContent = snapshotResume
+			m.sectionHeadings = []string{"Summary", "Experience"}
+			return m
+		}},
+		{"resume_preview", func(m Model) Model {
+			m = m.WithRenderer(output.PlainRenderer{})
+			m.state = stateResultSuccess
+			m.resultContent = snapshotResume
+			return typeText(m, "v")
+		}},
+	}
+
+	// Pin what the views read from the environment: the key check, the
+	// configuration directory shown on the consent screen, hyperlinks,
+	// which are stripped with the other escape codes and must not move text,
+	// and emoji icons
+	t.Setenv("GEMINI_API_KEY", "AIza"+strings.Repeat("x", 35))
+	t.Setenv(config.DirEnvVar, "/config/resumake")
+	t.Setenv(layout.HyperlinksEnvVar, "1")
+	t.Setenv(layout.EmojiEnvVar, "1")
+
+	// Each screen is checked at a common width and at the narrowest one
+	for _, width := range []int{100, 40} {
+		for _, tt := range tests {
+			t.Run(tt.name+"_"+strconv.Itoa(width), func(t *testing.T) {
+				m := tt.setup(NewModel())
+				m.width, m.height = width, 40
+				if m.state == stateResumePreview {
+					m = showResumePreview(m, m.renderer, "")
+				}
+				assertGolden(t, tt.name+"_"+strconv.Itoa(width), m.View(), width)
+			})
+		}
+	}
+}
+
+// TestNormalizeView checks that escape codes and block padding are removed
+func TestNormalizeView(t *testing.T) {
+	view := "\x1b[1mTitle\x1b[0m   \r\n  body  \n\n"
+	if got := normalizeView(view); got != "Title\n  body\n" {
+		t.Errorf("Expected escape codes and trailing spaces removed, got %q", got)
+	}
+}
@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+)
+
+// openJSONFixer switches to the fix-it view for a JSON Resume export that
+// breaks the schema. The Markdown resume has already been written; the
+// JSON file is only written once every violation is fixed.
+func openJSONFixer(m Model, resume document.JSONResume) (Model, tea.Cmd) {
+	m.state = stateFixJSONResume
+	m.jsonResume = resume
+	m.jsonCursor = 0
+	m.jsonErr = ""
+
+	m.jsonFixInput = textinput.New()
+	m.jsonFixInput.CharLimit = 200
+	m.jsonFixInput.Width = 50
+
+	m = refreshJSONViolations(m)
+	cmd := m.jsonFixInput.Focus()
+	return m, cmd
+}
+
+// refreshJSONViolations revalidates the export and loads the value of the
+// selected violation into the input.
+func refreshJSONViolations(m Model) Model {
+	m.jsonViolations = m.jsonResume.Validate()
+	if m.jsonCursor >= len(m.jsonViolations) {
+		m.jsonCursor = len(m.jsonViolations) - 1
+	}
+	if m.jsonCursor < 0 {
+		m.jsonCursor = 0
+	}
+	if len(m.jsonViolations) > 0 {
+		m.jsonFixInput.SetValue(m.jsonViolations[m.jsonCursor].Value)
+		m.jsonFixInput.CursorEnd()
+	}
+	return m
+}
+
+// saveJSONResume writes the fixed export and shows the success screen.
+// A write error is shown in the fix-it view so the user can retry or skip.
+func saveJSONResume(m Model) Model {
+	jsonPath, err := output.WriteJSONResume(m.jsonResume, m.outputPath)
+	if err != nil {
+		m.jsonErr = err.Error()
+		return m
+	}
+
+	m.jsonPath = jsonPath
+	m.jsonWarnings = m.jsonResume.Warnings()
+	m.jsonErr = ""
+	m.jsonFixInput.Blur()
+	m.state = stateResultSuccess
+	return m
+}
+
+// jsonWarningsNote names the fields the JSON Resume export left empty, or
+// returns "" when it left none empty. The schema allows them, so the export
+// is written either way.
+func jsonWarningsNote(warnings []document.Violation) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	fields := make([]string, len(warnings))
+	for i, warning := range warnings {
+		fields[i] = warning.Field
+	}
+	return "Warning: the JSON Resume export has no " + strings.Join(fields, ", ") + ". Add them to the resume to include them."
+}
+
+// updateJSONFixer handles key presses in the JSON Resume fix-it view.
+func updateJSONFixer(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := m.keyMap()
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.jsonCursor > 0 {
+			m.jsonCursor--
+			m = refreshJSONViolations(m)
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Down):
+		if m.jsonCursor < len(m.jsonViolations)-1 {
+			m.jsonCursor++
+			m = refreshJSONViolations(m)
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Accept):
+		// Apply the new value, then save once nothing is left to fix
+		if m.jsonCursor < len(m.jsonViolations) {
+			m.jsonViolations[m.jsonCursor].Fix(&m.jsonResume, m.jsonFixInput.Value())
+		}
+		m = refreshJSONViolations(m)
+		if len(m.jsonViolations) == 0 {
+			m = saveJSONResume(m)
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Remove):
+		// Skip the JSON export; the Markdown resume is already saved
+		m.jsonFixInput.Blur()
+		m.jsonErr = ""
+		m.state = stateResultSuccess
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.jsonFixInput, cmd = m.jsonFixInput.Update(msg)
+	return m, cmd
+}
+
+// renderJSONFixerView renders the list of schema violations above an input
+// for correcting the selected one.
+func renderJSONFixerView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(1).
+		Width(displayWidth - 4).
+		Align(lipgloss.Center).
+		Render("🛠 Fix JSON Resume Export")
+
+	description := layout.Wrap("Your Markdown resume is saved, but the JSON Resume export does not match the schema yet, "+
+		"so it has not been written. Correct each field below; clearing an optional field such as a date or URL removes it.", displayWidth-8)
+
+	var rows strings.Builder
+	for i, violation := range m.jsonViolations {
+		if i > 0 {
+			rows.WriteString("\n")
+		}
+		row := layout.Wrap(violation.String(), displayWidth-14)
+		if i == m.jsonCursor {
+			rows.WriteString(lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("▸ " + row))
+		} else {
+			rows.WriteString("  " + row)
+		}
+	}
+
+	listBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(errorColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(rows.String())
+
+	form := ""
+	if m.jsonCursor < len(m.jsonViolations) {
+		form = lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render(m.jsonViolations[m.jsonCursor].Field+": ") +
+			m.jsonFixInput.View()
+	}
+	if m.jsonErr != "" {
+		form += "\n\n" + errorStyle.Render(layout.Wrap(m.jsonErr, displayWidth-14))
+	}
+
+	formBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(focusBorderColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(form)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		description,
+		"",
+		listBox,
+		"",
+		formBox,
+	)
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/document"
+)
+
+// invalidJSONResume returns an export with a bad email, a bad date, and a
+// missing employer
+func invalidJSONResume() document.JSONResume {
+	return document.JSONResume{
+		Basics: document.JSONBasics{Name: "Jane Doe", Email: "jane at example"},
+		Work:   []document.JSONWork{{Position: "Engineer", StartDate: "Spring 2019"}},
+	}
+}
+
+func TestJSONFixer(t *testing.T) {
+	markdownPath := filepath.Join(t.TempDir(), "resume.md")
+	m := NewModel()
+	m.width = 100
+
+	// Test case 1: A pending export opens the fix-it view instead of the success screen
+	pending := invalidJSONResume()
+	updated, _ := m.Update(APIResultMsg{Success: true, Content: "# Jane Doe", OutputPath: markdownPath, PendingJSON: &pending})
+	m = updated.(Model)
+	if m.state != stateFixJSONResume {
+		t.Fatalf("Expected state to be stateFixJSONResume, got %v", m.state)
+	}
+	if len(m.jsonViolations) != 2 || m.jsonViolations[0].Field != "basics.email" {
+		t.Fatalf("Expected the basics.email and work[0].startDate violations, got %v", m.jsonViolations)
+	}
+	view := renderJSONFixerView(m)
+	if !strings.Contains(view, "Spring 2019") || !strings.Contains(view, "YYYY-MM") {
+		t.Error("The fix-it view should show the invalid value and the expected format")
+	}
+
+	// Test case 2: Applying a fix removes that violation and selects the next
+	m.jsonFixInput.SetValue("jane@example.com")
+	m = pressKey(m, tea.KeyEnter)
+	if len(m.jsonViolations) != 1 || m.jsonFixInput.Value() != "Spring 2019" {
+		t.Fatalf("Expected the date violation to remain selected, got %v", m.jsonViolations)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(markdownPath), "resume.json")); !os.IsNotExist(err) {
+		t.Error("The export should not be written while violations remain")
+	}
+
+	// Test case 3: An invalid fix keeps the violation
+	m = pressKey(m, tea.KeyEnter)
+	if m.state != stateFixJSONResume || len(m.jsonViolations) != 1 {
+		t.Fatal("Expected the unfixed date to keep the fix-it view open")
+	}
+
+	// Test case 4: Fixing the last violation writes the export
+	m.jsonFixInput.SetValue("2019-04")
+	m = pressKey(m, tea.KeyEnter)
+	if m.state != stateResultSuccess {
+		t.Fatalf("Expected the success screen after the last fix, got %v", m.state)
+	}
+	data, err := os.ReadFile(m.jsonPath)
+	if err != nil || !strings.Contains(string(data), `"startDate": "2019-04"`) {
+		t.Errorf("Expected the fixed export at %q, got %s (%v)", m.jsonPath, data, err)
+	}
+	if !strings.Contains(renderSuccessView(m), "JSON Resume") {
+		t.Error("The success screen should mention the JSON Resume export")
+	}
+
+	// Test case 5: The missing employer does not stop the export, but is named
+	if len(m.jsonWarnings) != 1 || m.jsonWarnings[0].Field != "work[0].name" {
+		t.Errorf("Expected a warning about the missing employer, got %v", m.jsonWarnings)
+	}
+	if !strings.Contains(renderSuccessView(m), "has no work[0].name") {
+		t.Error("The success screen should name the missing employer")
+	}
+}
+
+func TestJSONWarningsNote(t *testing.T) {
+	// Test case 1: An export with nothing missing has no note
+	if note := jsonWarningsNote(nil); note != "" {
+		t.Errorf("Expected no note, got %q", note)
+	}
+
+	// Test case 2: The note names each empty field
+	warnings := document.JSONResume{Education: []document.JSONEducation{{Area: "History"}}}.Warnings()
+	m := NewModel()
+	m.width = 100
+	m.jsonPath, m.jsonWarnings = "resume.json", warnings
+	if view := renderSuccessView(m); !strings.Contains(view, "basics.name, education[0].institution") {
+		t.Errorf("Expected the success screen to name the empty fields, got:\n%s", view)
+	}
+}
+
+func TestJSONFixerSkip(t *testing.T) {
+	m := NewModel()
+	m, _ = openJSONFixer(m, invalidJSONResume())
+
+	m = pressKey(m, tea.KeyCtrlX)
+	if m.state != stateResultSuccess || m.jsonPath != "" {
+		t.Errorf("Expected Ctrl+X to skip the export, got state %v and path %q", m.state, m.jsonPath)
+	}
+}
@@ -0,0 +1,278 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// KeyMap holds the key bindings of every screen. Key presses are matched
+// against it and the status bar describes the keys from it, so changing a
+// binding here changes both. Bindings are named after what they do rather
+// than the key they use; screens that reuse a binding for a different
+// action relabel it in the status bar.
+type KeyMap struct {
+	Quit       key.Binding // Leave resumake (or cancel generation) from any screen
+	Help       key.Binding // Show every shortcut of the current screen
+	Accept     key.Binding // The screen's main action: begin, continue, generate, save, or go back
+	Finish     key.Binding // Finish writing notes or skills
+	Snippets   key.Binding // Open the snippets picker while writing notes
+	Up         key.Binding // Select the previous item
+	Down       key.Binding // Select the next item
+	Next       key.Binding // Move to the next field or item
+	Previous   key.Binding // Move to the previous field
+	Cycle      key.Binding // Choose the next proficiency
+	CycleBack  key.Binding // Choose the previous proficiency
+	Scroll     key.Binding // Scroll the prompt preview a page at a time
+	Remove     key.Binding // Remove a skill, skip the JSON export, leave the section editor, or leave out a section under review
+	Yes        key.Binding // Agree or confirm
+	No         key.Binding // Decline or cancel
+	Cancel     key.Binding // Close the snippets picker or a confirmation dialog
+	Skills     key.Binding // Open the structured skills form
+	Prompt     key.Binding // Show or hide the prompt preview
+	Analysis   key.Binding // Open the keyword analysis
+	Timeline   key.Binding // Open the experience timeline
+	Changes    key.Binding // Compare with the previous resume
+	Section    key.Binding // Regenerate one section
+	Edit       key.Binding // Edit the section under review
+	View       key.Binding // View the generated resume
+	Again      key.Binding // Generate the resume again
+	Workspace  key.Binding // Open the workspace dashboard, or leave it
+	Tailor     key.Binding // Tailor the selected resume to a job description
+	Delete     key.Binding // Delete the selected resume's history
+	Recent     key.Binding // Start from one of the recent files listed on the welcome screen, by its number
+	Strengthen key.Binding // Strengthen one of the weak bullets listed under review, by its number
+}
+
+// DefaultKeyMap returns the standard key bindings.
+//
+// Returns:
+//   - KeyMap: The default bindings
+//
+// Example:
+//
+//	keys := tui.DefaultKeyMap()
+//	keys.Again = key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "generate again"))
+//	model = model.WithKeyMap(keys)
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit:       key.NewBinding(key.WithKeys("esc", "ctrl+c"), key.WithHelp("esc", "quit")),
+		Help:       key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Accept:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "continue")),
+		Finish:     key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "finish")),
+		Snippets:   key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", "snippets")),
+		Up:         key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "previous")),
+		Down:       key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "next")),
+		Next:       key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next field")),
+		Previous:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous field")),
+		Cycle:      key.NewBinding(key.WithKeys("right", " "), key.WithHelp("→", "next proficiency")),
+		CycleBack:  key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "previous proficiency")),
+		Scroll:     key.NewBinding(key.WithKeys("pgup", "pgdown"), key.WithHelp("pgup/pgdn", "scroll a page")),
+		Remove:     key.NewBinding(key.WithKeys("ctrl+x"), key.WithHelp("ctrl+x", "remove")),
+		Yes:        key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y", "confirm")),
+		No:         key.NewBinding(key.WithKeys("n", "N"), key.WithHelp("n", "cancel")),
+		Cancel:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		Skills:     key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "skills")),
+		Prompt:     key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "prompt")),
+		Analysis:   key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "keyword analysis")),
+		Timeline:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "timeline")),
+		Changes:    key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "changes")),
+		Section:    key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "redo a section")),
+		Edit:       key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit")),
+		View:       key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "view resume")),
+		Again:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "generate again")),
+		Workspace:  key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "workspace")),
+		Tailor:     key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tailor")),
+		Delete:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "delete")),
+		Recent:     key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "recent file")),
+		Strengthen: key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "strengthen a bullet")),
+	}
+}
+
+// defaultKeys are the bindings used until WithKeyMap sets others.
+var defaultKeys = DefaultKeyMap()
+
+// keyMap returns the model's key bindings.
+func (m Model) keyMap() KeyMap {
+	if m.keys != nil {
+		return *m.keys
+	}
+	return defaultKeys
+}
+
+// WithKeyMap returns a copy of the model with the given key bindings
+// Used to remap keys; the status bar follows the new bindings
+func (m Model) WithKeyMap(keys KeyMap) Model {
+	m.keys = &keys
+	return m
+}
+
+// screenKeys are the shortcuts of one screen, in the form the help bubble
+// renders: the most useful ones in the status bar, and every one, grouped
+// into columns, when help is open.
+type screenKeys struct {
+	actions    []key.Binding // What the screen offers, most important first
+	navigation []key.Binding // Moving around the screen (full help only)
+	general    []key.Binding // Quitting and help
+}
+
+// ShortHelp returns the bindings shown in the status bar.
+func (s screenKeys) ShortHelp() []key.Binding {
+	return append(append([]key.Binding{}, s.actions...), s.general...)
+}
+
+// FullHelp returns the bindings shown when help is open, one column per group.
+func (s screenKeys) FullHelp() [][]key.Binding {
+	var groups [][]key.Binding
+	for _, group := range [][]key.Binding{s.actions, s.navigation, s.general} {
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// relabel returns a copy of the binding described as desc, for screens that
+// use it for a different action than its default description says.
+func relabel(binding key.Binding, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(binding.Keys()...), key.WithHelp(binding.Help().Key, desc))
+}
+
+// pair combines two bindings into one status bar entry, such as "↑/↓ select".
+func pair(first, second key.Binding, desc string) key.Binding {
+	keys := append(append([]string{}, first.Keys()...), second.Keys()...)
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(first.Help().Key+"/"+second.Help().Key, desc))
+}
+
+// helpAvailable reports whether '?' opens help on the current screen. It
+// does not where '?' is typed into a text field or an overlay has the keys.
+func helpAvailable(m Model) bool {
+	if m.snippetPickerActive || m.pendingConfirm != confirmNone {
+		return false
+	}
+	switch m.state {
+	case stateInputSourcePath, stateInputStdin, stateInputSkills, stateFixJSONResume, stateSaveFallback, stateRegenerateSection:
+		return false
+	case stateReviewSections:
+		return !m.reviewEditing
+	case stateDashboard:
+		return !m.workspaceTailoring
+	}
+	// Screens added with RegisterState may type '?' into their own fields
+	return !onCustomScreen(m)
+}
+
+// screenKeyMap returns the shortcuts available in the current state.
+// Esc and Ctrl+C quit from every screen except the snippet picker and
+// confirmation dialogs, where Esc closes the overlay instead.
+func screenKeyMap(m Model) screenKeys {
+	keys := m.keyMap()
+	selection := pair(keys.Up, keys.Down, "select")
+
+	if m.snippetPickerActive {
+		return screenKeys{actions: []key.Binding{selection, relabel(keys.Accept, "insert"), keys.Cancel}}
+	}
+	if m.pendingConfirm != confirmNone {
+		return screenKeys{actions: []key.Binding{keys.Yes, pair(keys.No, keys.Cancel, "cancel")}}
+	}
+
+	general := []key.Binding{keys.Quit}
+	if helpAvailable(m) {
+		general = append(general, keys.Help)
+	}
+	screen := func(actions []key.Binding, navigation ...key.Binding) screenKeys {
+		return screenKeys{actions: actions, navigation: navigation, general: general}
+	}
+
+	switch m.state {
+	case stateWelcome:
+		actions := []key.Binding{relabel(keys.Accept, "begin")}
+		if len(m.recent) > 0 {
+			actions = append(actions, keys.Recent)
+		}
+		if m.historyDir != "" {
+			actions = append(actions, keys.Workspace)
+		}
+		return screen(actions)
+	case stateDashboard:
+		if m.workspaceTailoring {
+			return screen([]key.Binding{relabel(keys.Accept, "generate"), relabel(keys.Remove, "back")})
+		}
+		if len(m.workspace) == 0 {
+			return screen([]key.Binding{relabel(keys.Workspace, "back")})
+		}
+		return screen([]key.Binding{relabel(keys.Accept, "open"), relabel(keys.Again, "regenerate"), keys.Tailor, keys.Delete, relabel(keys.Workspace, "back")}, selection)
+	case stateInputSourcePath:
+		return screen([]key.Binding{keys.Accept})
+	case stateInputStdin:
+		return screen([]key.Binding{keys.Finish, keys.Snippets})
+	case stateConfirmGenerate:
+		if m.promptPreviewOpen {
+			return screen([]key.Binding{relabel(keys.Accept, "generate"), pair(keys.Up, keys.Down, "scroll prompt"), relabel(keys.Prompt, "hide prompt")}, keys.Scroll)
+		}
+		return screen([]key.Binding{relabel(keys.Accept, "generate"), keys.Skills, keys.Prompt})
+	case stateConsent:
+		return screen([]key.Binding{pair(keys.Yes, keys.Accept, "agree"), relabel(keys.No, "back")})
+	case stateInputSkills:
+		return screen([]key.Binding{relabel(keys.Accept, "add"), keys.Remove, relabel(keys.Finish, "done")},
+			keys.Next, keys.Previous, pair(keys.CycleBack, keys.Cycle, "proficiency"), selection)
+	case stateGenerating:
+		return screenKeys{general: []key.Binding{relabel(keys.Quit, "cancel"), keys.Help}}
+	case stateResultSuccess:
+		actions := []key.Binding{relabel(keys.Accept, "quit"), keys.View, keys.Analysis, keys.Timeline}
+		if m.previousRevision != nil {
+			actions = append(actions, keys.Changes)
+		}
+		if canRegenerateSection(m) {
+			actions = append(actions, keys.Section)
+		}
+		return screenKeys{actions: append(actions, keys.Again), general: []key.Binding{keys.Help}}
+	case stateAnalysis:
+		return screen([]key.Binding{pair(keys.Accept, keys.Analysis, "back")})
+	case stateTimeline:
+		return screen([]key.Binding{pair(keys.Accept, keys.Timeline, "back")})
+	case stateRevisionDiff:
+		return screen([]key.Binding{pair(keys.Accept, keys.Changes, "back")})
+	case stateResumePreview:
+		return screen([]key.Binding{pair(keys.Accept, keys.View, "back"), pair(keys.Up, keys.Down, "scroll")}, keys.Scroll)
+	case stateResultError:
+		actions := []key.Binding{relabel(keys.Accept, "quit")}
+		if m.generateAttempted {
+			actions = append(actions, relabel(keys.Again, "try again"))
+		}
+		if !m.retryAt.IsZero() {
+			actions = append(actions, relabel(keys.Remove, "stop retrying"))
+		}
+		return screenKeys{actions: actions, general: []key.Binding{keys.Help}}
+	case stateFixJSONResume:
+		return screen([]key.Binding{selection, relabel(keys.Accept, "apply fix"), relabel(keys.Remove, "skip export")})
+	case stateSaveFallback:
+		return screen([]key.Binding{selection, relabel(keys.Accept, "save")}, relabel(keys.Next, "next option"))
+	case stateRegenerateSection:
+		return screen([]key.Binding{selection, relabel(keys.Accept, "regenerate"), relabel(keys.Remove, "back")}, relabel(keys.Next, "next section"))
+	case stateReviewSections:
+		if m.reviewEditing {
+			return screen([]key.Binding{relabel(keys.Finish, "done"), relabel(keys.Remove, "discard edit")})
+		}
+		actions := []key.Binding{relabel(keys.Accept, "accept"), keys.Edit, relabel(keys.Again, "regenerate"), relabel(keys.Remove, "leave out")}
+		if len(weakBullets(m.review)) > 0 {
+			actions = append(actions, keys.Strengthen)
+		}
+		return screen(actions, relabel(keys.Up, "previous section"))
+	}
+	if onCustomScreen(m) {
+		return screen(customScreenKeys(m))
+	}
+	return screen(nil)
+}
+
+// newHelp returns the help bubble styled like the rest of the interface.
+func newHelp(width int) help.Model {
+	h := help.New()
+	h.Width = width
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	h.Styles.ShortKey, h.Styles.FullKey = keyStyle, keyStyle
+	h.Styles.ShortDesc, h.Styles.FullDesc = keyboardHintStyle, keyboardHintStyle
+	h.Styles.ShortSeparator, h.Styles.FullSeparator, h.Styles.Ellipsis = keyboardHintStyle, keyboardHintStyle, keyboardHintStyle
+	return h
+}
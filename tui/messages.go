@@ -1,23 +1,107 @@
 package tui
 
+import (
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+	"github.com/phrazzld/resumake/snippets"
+	"github.com/phrazzld/resumake/usage"
+)
+
 // This file defines the message types used by the Bubble Tea commands.
 // Messages are returned by commands to update the model state.
 
 // FileReadResultMsg is returned when a file read operation completes.
 type FileReadResultMsg struct {
-	Success bool   // Whether the file read was successful
-	Content string // The content of the file (if successful)
-	Error   error  // The error that occurred (if unsuccessful)
+	Success   bool             // Whether the file read was successful
+	Content   string           // The content of the file (if successful)
+	PDF       bool             // The file is a PDF, uploaded as a document instead of read as text
+	Warning   string           // A warning about the file, such as an unsupported extension
+	Structure *document.Resume // The structured form from the sidecar of a resume resumake wrote (nil for others)
+	Error     error            // The error that occurred (if unsuccessful)
 }
 
 
 // APIResultMsg is returned when an API request completes.
 type APIResultMsg struct {
-	Success      bool   // Whether the API request was successful
-	Content      string // The generated content (if successful)
-	OutputPath   string // The path where the content was written
-	TruncatedMsg string // Warning message if the output was truncated
-	Error        error  // The error that occurred (if unsuccessful)
+	Success         bool                  // Whether the API request was successful
+	Content         string                // The generated content (if successful)
+	OutputPath      string                // The path where the content was written
+	CoverLetterPath string                // The path of the cover letter (bundle mode only)
+	HTMLPath        string                // The path of the HTML resume (--layout only)
+	JSONPath        string                // The path of the JSON Resume export (--json only)
+	JSONWarnings    []document.Violation  // Fields the JSON Resume export left empty
+	Exports         []output.Export       // The document exports that were written (--export only)
+	PendingJSON     *document.JSONResume  // A JSON Resume export awaiting schema fixes before it is written
+	TruncatedMsg    string                // Warning message if the output was truncated
+	TrimmedInputs   []string              // What was trimmed from the inputs to fit the context window
+	Company         prompt.CompanyProfile // The employer the resume was tailored to (--company only)
+	CompanyNote     string                // Why company research fell short, if it did
+	MissingKeywords []string              // Emphasized keywords the resume does not include (--emphasize only)
+	HeadingIssues   []string              // Heading problems that could not be fixed, such as a missing required section
+	Uncited         []string              // Bullets that cite none of the inputs in strict grounding, dropped in drop mode
+	LanguageWarning string                // Why the resume may be in the wrong language, if its language differs from the inputs'
+	Explanation     string                // Why the major changes were made, as written to the notes file (--explain only)
+	ExplanationNote string                // Why no explanation was saved, if none was
+	NotesPath       string                // The path of the notes explaining the changes (--explain only)
+	Gaps            []string              // Job description keywords the resume does not mention (--gaps only)
+	Suggestions     string                // How to close those gaps, as written to the gaps appendix (--gaps only)
+	GapsNote        string                // Why no gaps appendix was saved, if none was
+	GapsPath        string                // The path of the gaps appendix (--gaps only)
+	Documents       []GeneratedDocument   // The documents written alongside the resume, such as a thank-you email (--documents only)
+	DocumentsNote   string                // Why a requested document was not saved, if one was not
+	PackPath        string                // The path of the zipped resume pack (--pack only)
+	UploadedFile    string                // The Files API name of the uploaded source, deleted on exit (if uploaded)
+	ModelName       string                // The model that produced the content
+	Previous        *history.Revision     // The resume saved before this one for the same candidate, if any
+	Session         *api.Session          // The conversation that produced the content, for follow-up turns
+	Error           error                 // The error that occurred (if unsuccessful)
+}
+
+// SaveFailedMsg is returned when a resume was generated but could not be
+// written, for example because the output directory is read-only. The
+// generated content is kept so it can be saved somewhere else.
+type SaveFailedMsg struct {
+	Result      APIResultMsg // The generated result, without the paths that could not be written
+	CoverLetter string       // The generated cover letter (bundle mode only)
+	Path        string       // The output path that could not be written (empty for the default)
+	Error       error        // The error that occurred
+}
+
+// ReviewReadyMsg is returned instead of saving when a resume was generated
+// with --review, so its sections can be accepted one at a time before the
+// resume is assembled from them and saved.
+type ReviewReadyMsg struct {
+	Result      APIResultMsg // The generated result, without the paths that are not written yet
+	CoverLetter string       // The generated cover letter (bundle mode only)
+	Path        string       // The output path the resume will be saved to (empty for the default)
+}
+
+// SectionRevisedMsg is returned when a section under review has been
+// written again.
+type SectionRevisedMsg struct {
+	Index   int    // The position of the section in the review
+	Section string // The new section, starting with its heading (if successful)
+	Error   error  // The error that occurred (if unsuccessful)
+}
+
+// BulletStrengthenedMsg is returned when a weak bullet of a section under
+// review has been written again.
+type BulletStrengthenedMsg struct {
+	Index    int    // The position of the section in the review
+	Original string // The bullet that was strengthened, without its list marker
+	Bullet   string // The new bullet, without its list marker (if successful)
+	Error    error  // The error that occurred (if unsuccessful)
+}
+
+// SectionRegeneratedMsg is returned when one section of the generated resume
+// has been rewritten and the resume saved again.
+type SectionRegeneratedMsg struct {
+	Heading string // The heading of the rewritten section
+	Content string // The whole resume with the new section (if successful)
+	Error   error  // The error that occurred (if unsuccessful)
 }
 
 // StdinSubmitMsg is sent when the user submits stdin input.
@@ -29,4 +113,28 @@ type StdinSubmitMsg struct {
 type ProgressUpdateMsg struct {
 	Step    string // The current step being executed
 	Message string // Additional message about the progress
-}
\ No newline at end of file
+}
+
+// APIKeyCheckedMsg is returned when the API key has been checked with the API.
+type APIKeyCheckedMsg struct {
+	Diagnosis api.KeyDiagnosis // What the check found
+}
+
+// QuotaCheckedMsg is returned when the usage ledger has been read for the
+// confirm screen.
+type QuotaCheckedMsg struct {
+	Status   usage.Status  // Recent requests to the model and its free-tier limits
+	Fallback *usage.Status // The same for the fallback model (nil without one)
+	Error    error         // The error that occurred (if unsuccessful)
+}
+
+// pagerClosedMsg is returned when the pager showing the resume exits.
+type pagerClosedMsg struct {
+	err error // Why the pager failed, if it did
+}
+
+// SnippetsLoadedMsg is returned when the snippets library has been loaded.
+type SnippetsLoadedMsg struct {
+	Snippets []snippets.Snippet // The loaded snippets
+	Error    error              // The error that occurred (if unsuccessful)
+}
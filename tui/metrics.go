@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// RequestMetrics measures a resume generation as it runs: how much was sent
+// to the model, how much of the response has streamed back, and how long it
+// has taken. The generation command updates it from its goroutine while the
+// generating screen reads it on each spinner tick, so it is safe for
+// concurrent use. A nil *RequestMetrics ignores updates.
+type RequestMetrics struct {
+	mu             sync.Mutex
+	started        time.Time
+	bytesSent      int
+	tokensSent     int
+	bytesReceived  int
+	tokensReceived int
+	sent           bool
+	streaming      bool
+}
+
+// NewRequestMetrics returns metrics for a generation started at started.
+//
+// Parameters:
+//   - started: When generation started, from which the elapsed time is measured
+//
+// Returns:
+//   - *RequestMetrics: The metrics, with nothing sent or received yet
+func NewRequestMetrics(started time.Time) *RequestMetrics {
+	return &RequestMetrics{started: started}
+}
+
+// Sent records the text of the prompt sent to the model. Its tokens are
+// estimated, since the API only counts them in the response.
+func (r *RequestMetrics) Sent(text string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bytesSent, r.tokensSent, r.sent = len(text), prompt.EstimateTokens(text), true
+}
+
+// Received records the text of the response streamed so far.
+func (r *RequestMetrics) Received(text string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bytesReceived, r.tokensReceived, r.streaming = len(text), prompt.EstimateTokens(text), true
+}
+
+// Lines describes the request for the generating screen: what was sent,
+// what has been received, and the time elapsed at now.
+//
+// Parameters:
+//   - now: The current time
+//
+// Returns:
+//   - sent: The size of the prompt, or an empty string before it is sent
+//   - received: The size of the response so far, or a note that none has arrived
+//   - elapsed: The time since generation started, such as "Elapsed: 12s"
+//
+// Example:
+//
+//	sent, received, elapsed := metrics.Lines(time.Now())
+//	// sent == "Sent: 12.3 KB (~3100 tokens)"
+func (r *RequestMetrics) Lines(now time.Time) (sent, received, elapsed string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.sent {
+		sent = fmt.Sprintf("Sent: %s (~%d tokens)", formatBytes(r.bytesSent), r.tokensSent)
+		received = "Received: waiting for the first tokens..."
+	}
+	if r.streaming {
+		received = fmt.Sprintf("Received: %s (~%d tokens so far)", formatBytes(r.bytesReceived), r.tokensReceived)
+	}
+	elapsed = "Elapsed: " + now.Sub(r.started).Round(time.Second).String()
+	return sent, received, elapsed
+}
+
+// formatBytes formats a size in bytes for reading at a glance, such as
+// "512 B" or "12.3 KB".
+func formatBytes(size int) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
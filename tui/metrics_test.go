@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestMetrics(t *testing.T) {
+	started := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	metrics := NewRequestMetrics(started)
+
+	// Test case 1: Before the prompt is sent only the elapsed time is known
+	sent, received, elapsed := metrics.Lines(started.Add(1500 * time.Millisecond))
+	if sent != "" || received != "" || elapsed != "Elapsed: 2s" {
+		t.Errorf("Unexpected lines before sending: %q, %q, %q", sent, received, elapsed)
+	}
+
+	// Test case 2: The prompt's size is shown while waiting for the response
+	metrics.Sent(strings.Repeat("a", 2048))
+	sent, received, _ = metrics.Lines(started)
+	if sent != "Sent: 2.0 KB (~512 tokens)" || received != "Received: waiting for the first tokens..." {
+		t.Errorf("Unexpected lines after sending: %q, %q", sent, received)
+	}
+
+	// Test case 3: The streamed response is counted as it arrives
+	metrics.Received("Jane Doe")
+	if _, received, _ = metrics.Lines(started); received != "Received: 8 B (~2 tokens so far)" {
+		t.Errorf("Unexpected received line: %q", received)
+	}
+
+	// Test case 4: Nil metrics ignore updates
+	var none *RequestMetrics
+	none.Sent("prompt")
+	none.Received("text")
+}
+
+func TestGeneratingViewMetrics(t *testing.T) {
+	m := NewModel()
+	m.state = stateGenerating
+	m.width, m.height = 100, 60
+	m.metrics = NewRequestMetrics(time.Now().Add(-12 * time.Second))
+	m.metrics.Sent(strings.Repeat("a", 4096))
+	m.metrics.Received(strings.Repeat("b", 512))
+
+	// Test case 1: The request, the response so far, and the elapsed time replace the estimate
+	view := renderGeneratingView(m)
+	for _, element := range []string{"Sent: 4.0 KB (~1024 tokens)", "Received: 512 B (~128 tokens so far)", "⏱ Elapsed: 12s"} {
+		if !strings.Contains(view, element) {
+			t.Errorf("Generating view should contain %q", element)
+		}
+	}
+	if strings.Contains(view, "60 seconds") {
+		t.Error("Generating view should not guess how long generation takes")
+	}
+}
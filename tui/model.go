@@ -2,15 +2,30 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 	
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/generative-ai-go/genai"
 	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/logging"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+	"github.com/phrazzld/resumake/snippets"
+	"github.com/phrazzld/resumake/usage"
 )
 
 // State represents the different states of the application.
@@ -37,6 +52,42 @@ const (
 	
 	// stateResultError shows error details if something went wrong.
 	stateResultError
+	
+	// stateAnalysis shows the keyword density and buzzword report for the generated resume.
+	stateAnalysis
+	
+	// stateInputSkills allows the user to enter structured skills with years and proficiency.
+	stateInputSkills
+	
+	// stateFixJSONResume lists JSON Resume schema violations for the user to fix before export.
+	stateFixJSONResume
+	
+	// stateTimeline shows the roles and education of the generated resume on a timeline.
+	stateTimeline
+	
+	// stateSaveFallback offers other ways to keep a generated resume that could not be written.
+	stateSaveFallback
+	
+	// stateRevisionDiff compares the generated resume with the last one saved for the same candidate.
+	stateRevisionDiff
+	
+	// stateConsent shows what will be sent to the API and asks for consent before the first request.
+	stateConsent
+	
+	// stateRegenerateSection lets the user choose one section of the generated resume to write again.
+	stateRegenerateSection
+	
+	// stateResumePreview shows the generated resume, rendered for the terminal.
+	stateResumePreview
+	
+	// stateReviewSections shows the generated resume one section at a time to accept, edit, or regenerate before saving.
+	stateReviewSections
+	
+	// stateDashboard lists the resumes saved on this computer, to open, regenerate, tailor, or delete.
+	stateDashboard
+	
+	// stateCustom is the state of the first screen added with RegisterState; the others follow it in the order they were added.
+	stateCustom
 )
 
 // Model is the main model for the Bubble Tea application.
@@ -44,7 +95,11 @@ type Model struct {
 	// Application state
 	state         State
 	apiKeyOk      bool
+	keyDiagnosis  api.KeyDiagnosis // Why the API key is or isn't usable
+	verifyKey     bool             // Check the key with the API on startup (--check-key)
+	keyChecking   bool             // The key check is in progress
 	errorMsg      string
+	err           error  // The error behind errorMsg, when there is one
 	appVersion    string // Version information
 	
 	// Input components
@@ -53,34 +108,200 @@ type Model struct {
 	
 	// Content
 	sourceContent string // Content read from file
+	sourcePDF     bool   // The source file is a PDF, uploaded instead of read as text
+	sourceWarning string // A warning about the source file, such as an unsupported extension
 	stdinContent  string // Content from stdin textarea
 	
 	// Output
-	outputPath    string
-	resultMessage string
+	outputPath       string
+	coverLetterPath  string                // Set when a cover letter was generated (bundle mode)
+	htmlPath         string                // Set when an HTML version was rendered (--layout)
+	jsonPath         string                // Set when a JSON Resume export was written (--json)
+	jsonWarnings     []document.Violation  // Fields the JSON Resume export left empty
+	exports          []output.Export       // Set when document exports were written (--export)
+	modelName        string                // The model that produced the resume
+	trimmedInputs    []string              // What was trimmed from the inputs to fit the context window
+	truncatedMsg     string                // Why the resume may be incomplete, such as reaching the time limit
+	company          prompt.CompanyProfile // The employer the resume was tailored to (--company)
+	companyNote      string                // Why company research fell short, if it did
+	missingKeywords  []string              // Emphasized keywords the resume does not include
+	headingIssues    []string              // Heading problems that could not be fixed, such as a missing required section
+	uncited          []string              // Bullets that cite none of the inputs in strict grounding, dropped in drop mode
+	languageWarning  string                // Why the resume may be in the wrong language
+	notesPath        string                // Set when the notes explaining the changes were written (--explain)
+	explanationNote  string                // Why no notes were saved, if none were
+	gapsPath         string                // Set when the appendix on closing the gaps with the job was written (--gaps)
+	gapsNote         string                // Why no gaps appendix was saved, if none was
+	documents        []GeneratedDocument   // Set when documents such as a thank-you email were written (--documents)
+	documentsNote    string                // Why a requested document was not saved, if one was not
+	resultMessage    string
+	resultContent    string            // Generated resume content, used by the analysis view
+	previousRevision *history.Revision // The resume saved before this one for the same candidate, if any
 	
 	// UI components
 	spinner       spinner.Model
 	width         int
 	height        int
+	resizeSeq     int // Counts resizes, so only the last of a burst is applied
 	
 	// Styling
 	mainStyle     lipgloss.Style
+	hyperlinks    bool // Whether paths and documentation links are written as terminal hyperlinks
+	emoji         bool // Whether icons are shown as emoji, or replaced with ASCII for consoles that cannot draw them
 	
 	// Flag-provided values
-	flagSourcePath string
-	flagOutputPath string
+	flagSourcePath   string
+	flagOutputPath   string
+	flagBundle       bool
+	flagLegacyOutput bool                  // Write to resume_out.md when no output path is given
+	fallbackModel    string                // Model retried once if the primary model fails
+	jobDescription   string                // Job description content for keyword comparison
+	jobPath          string                // The file the job description was read from
+	jobWarning       string                // A warning about the job description chosen on the dashboard
+	flagPack         bool                  // Also zip the resume files, job description, and metadata (--pack)
+	maxDuration      time.Duration         // Stop generating after this long, keeping complete sections (0 for no limit)
+	seed             *int64                // Sample deterministically with this seed (nil for the model's usual sampling)
+	flagCompany      string                // Company name or job posting URL to research and tailor to
+	flagEmphasize    []string              // Keywords to feature where the inputs support them
+	academics        document.Academics    // GPA and coursework from -gpa and -coursework
+	preset           prompt.Preset         // The kind of candidate the resume is written for
+	audience         prompt.Audience       // Who reads the resume first
+	structure        *document.Resume      // The source's structured form from its sidecar, if it has one
+	flagExplain      bool                  // Also ask why the major changes were made and save the notes
+	flagGaps         bool                  // Also ask how to close the gaps with the job description and save the suggestions
+	flagDocuments    []prompt.DocumentType // Also write these documents, such as a thank-you email, and save them next to the resume
+	flagReview       bool                  // Accept the generated resume one section at a time before it is saved
+	flagAmend        bool                  // The source is the previous resume and the notes are updates to it
+	flagLayout       output.Layout         // HTML layout to render alongside the Markdown (empty to skip)
+	flagTimeline     bool                  // Add a timeline of roles and education to the HTML resume
+	flagPageBreaks   bool                  // Mark estimated page breaks in the HTML resume
+	flagProseWidth   int                   // Write prose one sentence per line, wrapped at this column (0 to keep the model's lines)
+	flagGrounding    prompt.Grounding      // Whether bullets must cite the input sentences they come from
+	flagQR           string                // URL to link with a QR code in the HTML and PDF header (empty to skip)
+	flagQRLayouts    []output.Layout       // Layouts that show the QR code (nil for all)
+	flagJSONResume   bool                  // Also export the resume in JSON Resume format
+	flagExports      []output.ExportFormat // Document formats to convert the resume to
+	fixtures         api.Fixtures          // Records API responses to, or replays them from, disk fixtures
+	trimOrder        []prompt.TrimStep     // Order to trim input that exceeds the context window
+	historyDir       string                // Directory saved resumes are kept in for comparison (empty to skip)
+	recentPath       string                // List of recently used files shown on the welcome screen (empty to skip)
+	recent           []config.RecentFile   // Recently used files that still exist, in the order they are listed
+	usagePath        string                // Ledger of API requests, checked against the quota before generating (empty to skip)
+	packPath         string                // Set when the resume pack was written (--pack)
 	
 	// Status messages
 	progressStep  string
 	progressMsg   string
+	metrics       *RequestMetrics // Size and duration of the request being generated (nil before generating)
 	
 	// API client instances
 	apiClient     *genai.Client       // Initialized API client instance
 	apiModel      *genai.GenerativeModel // Initialized model instance
+	apiSession    *api.Session           // Conversation that produced the resume, reused for follow-up turns
+	uploadedFiles []string               // Sources uploaded with the Files API, deleted on exit
+	promptCache   *api.PromptCache       // System instructions cached for the session, deleted on exit (nil without caching)
 	
 	// Context for cancellation and value propagation
 	ctx           context.Context
+	
+	// Snippets picker
+	snippetsDir         string             // Directory the snippets library is loaded from
+	snippetLibrary      []snippets.Snippet // Loaded snippets
+	snippetPickerActive bool               // Whether the picker overlay is open
+	snippetQuery        textinput.Model    // Fuzzy search input for the picker
+	snippetCursor       int                // Index of the highlighted match
+	snippetErr          string             // Error from loading snippets, if any
+	
+	// Structured skills form
+	skills          []document.Skill     // Skills entered in the skills form
+	skillNameInput  textinput.Model      // Skill name field
+	skillYearsInput textinput.Model      // Years of experience field
+	skillLevel      document.Proficiency // Selected proficiency
+	skillFocus      int                  // Focused form field
+	skillCursor     int                  // Selected row in the skills table
+	skillErr        string               // Validation error for the entry form
+	
+	// JSON Resume fix-it view
+	jsonResume     document.JSONResume  // Export awaiting schema fixes
+	jsonViolations []document.Violation // Remaining schema violations
+	jsonCursor     int                  // Selected violation
+	jsonFixInput   textinput.Model      // Corrected value for the selected violation
+	jsonErr        string               // Error from writing the export, if any
+	
+	// Quota use on the confirm screen
+	quota         *usage.Status // Recent requests to the model, from the usage ledger (nil until checked)
+	fallbackQuota *usage.Status // Recent requests to the fallback model (nil without one)
+	
+	// Prompt preview on the confirm screen
+	promptPreviewOpen bool           // Whether the preview is expanded
+	promptViewport    viewport.Model // Scrollable view of the composed prompt
+	promptTokens      int            // Estimated size of the composed prompt
+	
+	// Preview of the generated resume from the success screen
+	renderer          output.PreviewRenderer // Renders the preview, or pages it (nil for the styled renderer)
+	previewViewport   viewport.Model         // Scrollable view of the rendered resume
+	previewNote       string                 // Why the pager could not show the resume, if it failed
+	previewToneIssues int                    // Phrases flagged by the tone check, shown under their lines in the preview
+	
+	// Save fallback after a failed write
+	saveResult    APIResultMsg    // Generated result awaiting a place to be saved
+	saveLetter    string          // Generated cover letter awaiting a place to be saved (bundle mode)
+	savePathInput textinput.Model // New location for the resume
+	saveCursor    int             // Selected fallback option
+	saveErr       string          // Why the last save failed
+	saveNote      string          // Confirmation of a copy to the clipboard
+	saveCopied    bool            // Whether the content has been copied to the clipboard
+	
+	// Section regeneration from the success screen
+	sectionHeadings []string        // Sections of the resume that can be regenerated
+	sectionCursor   int             // Selected section
+	sectionInput    textinput.Model // Extra instructions for the new version
+	sectionBusy     bool            // Whether the selected section is being regenerated
+	sectionErr      string          // Why the last regeneration failed
+	sectionNote     string          // Confirmation shown on the success screen after a section was replaced
+	
+	// Section-by-section review before saving (--review)
+	review        sectionReview  // The sections of the generated resume and which were accepted
+	reviewResult  APIResultMsg   // The generated result, saved once every section is decided
+	reviewLetter  string         // The generated cover letter (bundle mode only)
+	reviewPath    string         // The output path the resume will be saved to
+	reviewEditor  textarea.Model // The section being edited
+	reviewEditing bool           // Whether the section under review is being edited
+	reviewBusy    string         // What is in progress, such as regenerating the section (empty when idle)
+	reviewErr     string         // Why the last action failed
+	
+	// Data consent before the first request
+	consentRequired bool // Whether to ask before the inputs are first sent to the API
+	
+	// Confirmation dialog
+	pendingConfirm    confirmAction // Action awaiting confirmation (confirmNone when no dialog is open)
+	generateAttempted bool          // Whether generation has been started, so it can be run again
+	
+	// Guards against generating twice
+	generating  bool      // A generation is in flight, so another is not started
+	acceptGuard time.Time // Enter is ignored on the generation screens until then
+	
+	// Automatic retry after a rate limit that says how long to wait
+	retryAt     time.Time // When the failed generation is sent again (zero when no retry is counting down)
+	retrySeq    int       // Counts countdowns, so the ticks of a stopped one are ignored
+	autoRetries int       // Automatic retries since generation was last started by hand
+	
+	// Key bindings
+	keys     *KeyMap // Bindings set with WithKeyMap (nil for DefaultKeyMap)
+	helpOpen bool    // Whether the status bar lists every shortcut of the screen
+	
+	// Workspace dashboard
+	workspace          []workspaceEntry // The latest resume of each profile, newest first
+	workspaceLoaded    bool             // Whether the resumes have been read from the history
+	workspaceCursor    int              // Selected resume
+	workspaceErr       string           // Why the history could not be read, or the last action failed
+	workspaceNote      string           // Confirmation of the last action, such as a deletion
+	workspaceTailoring bool             // Whether the job description to tailor to is being entered
+	workspaceJobInput  textinput.Model  // Path of the job description to tailor the selected resume to
+	previewBack        State            // The screen the resume preview returns to
+	
+	// Screens added with RegisterState
+	screen Screen // The screen shown, as its last update returned it (nil when none is)
 }
 
 // NewModel creates a new Model with default values.
@@ -100,42 +321,91 @@ func NewModel() Model {
 	// Initialize spinner for loading state with more visible spinner
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#F2C94C")).Bold(true)
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.CompleteColor{TrueColor: "#F2C94C", ANSI256: "221", ANSI: "11"}).Bold(true)
 	// Important: use a spinner with more visible animation frames
 	sp.Spinner = spinner.Spinner{
 		Frames: []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"},
 		FPS:    12, // Faster animation
 	}
 	
-	// Check API key on startup
-	apiKeyOk := checkAPIKey()
+	// Braille frames are missing from the fonts of consoles that cannot draw emoji
+	emoji := layout.EmojiSupported()
+	if !emoji {
+		sp.Spinner = spinner.Line
+	}
+	
+	// Initialize the snippets picker search input
+	snippetQuery := textinput.New()
+	snippetQuery.Placeholder = "Search snippets"
+	snippetQuery.CharLimit = 50
+	
+	// Locate the snippets library; a missing config directory just disables it
+	snippetsDir, _ := snippets.DefaultDir()
+	
+	// Initialize the skills form fields
+	skillNameInput := textinput.New()
+	skillNameInput.Placeholder = "e.g. Go"
+	skillNameInput.CharLimit = 50
+	skillNameInput.Width = 30
+	
+	skillYearsInput := textinput.New()
+	skillYearsInput.Placeholder = "0"
+	skillYearsInput.CharLimit = 2
+	skillYearsInput.Width = 4
+	
+	// Check the API key's form on startup; WithKeyVerification also checks it with the API
+	keyDiagnosis := checkAPIKey()
 	
 	return Model{
 		state:          stateWelcome,
-		apiKeyOk:       apiKeyOk,
+		apiKeyOk:       keyDiagnosis.Usable(),
+		keyDiagnosis:   keyDiagnosis,
 		appVersion:     "1.0.0", // Default version
 		sourcePathInput: sourceInput,
 		stdinInput:     stdinTA,
 		spinner:        sp,
 		mainStyle:      lipgloss.NewStyle().Bold(true),
+		hyperlinks:     layout.HyperlinksSupported(),
+		emoji:          emoji,
 		// Flag values will be populated with WithSourcePath/WithOutputPath
 		flagSourcePath: "",
 		flagOutputPath: "",
+		fallbackModel:  api.DefaultFallbackModelName,
 		// API client instances start as nil and will be initialized as needed
 		apiClient:      nil,
 		apiModel:       nil,
 		// Initialize with a background context
 		ctx:            context.Background(),
+		snippetsDir:    snippetsDir,
+		snippetQuery:   snippetQuery,
+		skillNameInput: skillNameInput,
+		skillYearsInput: skillYearsInput,
 	}
 }
 
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
 	// Initial commands like spinner spinning or cursor blinking
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		tea.Cmd(m.spinner.Tick),
 		m.sourcePathInput.Focus(),
-	)
+	}
+	
+	// Check the key with the API while the welcome screen is shown
+	if m.keyChecking {
+		cmds = append(cmds, VerifyAPIKeyCmd(m.ctx))
+	}
+	
+	// The dashboard command starts on the dashboard, which lists the history
+	if m.state == stateDashboard {
+		cmds = append(cmds, loadWorkspaceCmd(m.historyDir))
+	}
+	
+	// The welcome screen lists the files used recently
+	if m.recentPath != "" {
+		cmds = append(cmds, loadRecentCmd(m.recentPath))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages and updates the model.
@@ -152,8 +422,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case FileReadResultMsg:
 		if msg.Success {
 			m.sourceContent = msg.Content
+			m.sourcePDF = msg.PDF
+			m.sourceWarning = msg.Warning
+			m = withStructure(m, msg.Structure)
 		} else {
 			m.state = stateResultError
+			m.err = msg.Error
 			m.errorMsg = msg.Error.Error()
 			return m, nil
 		}
@@ -164,74 +438,199 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.state == stateGenerating {
 			m.spinner, _ = m.spinner.Update(nil)
 		}
+		m.generating = false
+		m.acceptGuard = time.Now().Add(acceptDebounce)
 		
 		if msg.Success {
 			m.state = stateResultSuccess
 			m.outputPath = msg.OutputPath
+			m.coverLetterPath = msg.CoverLetterPath
+			m.htmlPath = msg.HTMLPath
+			m.jsonPath = msg.JSONPath
+			m.jsonWarnings = msg.JSONWarnings
+			m.exports = msg.Exports
+			m.modelName = msg.ModelName
+			m.trimmedInputs = msg.TrimmedInputs
+			m.truncatedMsg = msg.TruncatedMsg
+			m.company = msg.Company
+			m.companyNote = msg.CompanyNote
+			m.missingKeywords = msg.MissingKeywords
+			m.headingIssues = msg.HeadingIssues
+			m.uncited = msg.Uncited
+			m.languageWarning = msg.LanguageWarning
+			m.notesPath = msg.NotesPath
+			m.packPath = msg.PackPath
+			m.explanationNote = msg.ExplanationNote
+			m.gapsPath = msg.GapsPath
+			m.gapsNote = msg.GapsNote
+			m.documents = msg.Documents
+			m.documentsNote = msg.DocumentsNote
+			m = rememberUpload(m, msg.UploadedFile)
+			m.apiSession = msg.Session
 			m.resultMessage = fmt.Sprintf("%d", len(msg.Content))
+			m.resultContent = msg.Content
+			m.previousRevision = msg.Previous
+			m.sectionNote = ""
+			
+			// An export that breaks the schema is fixed before it is written
+			if msg.PendingJSON != nil {
+				return openJSONFixer(m, *msg.PendingJSON)
+			}
 		} else {
 			m.state = stateResultError
+			m.err = msg.Error
 			m.errorMsg = msg.Error.Error()
+			return scheduleRetry(m, msg.Error, time.Now())
+		}
+		return m, nil
+		
+	case retryTickMsg:
+		return handleRetryTick(m, msg, time.Now())
+		
+	case SaveFailedMsg:
+		m.generating = false
+		return openSaveFallback(m, msg)
+		
+	case ReviewReadyMsg:
+		if m.state == stateGenerating {
+			m.spinner, _ = m.spinner.Update(nil)
+		}
+		m.generating = false
+		return openSectionReview(m, msg)
+		
+	case SectionRevisedMsg:
+		return applyRevisedSection(m, msg), nil
+		
+	case BulletStrengthenedMsg:
+		return applyStrengthenedBullet(m, msg), nil
+		
+	case ScreenDoneMsg:
+		return finishScreen(m, msg)
+		
+	case SectionRegeneratedMsg:
+		m.sectionBusy = false
+		if msg.Error != nil {
+			m.sectionErr = fmt.Sprintf("The %s section could not be regenerated: %v", msg.Heading, msg.Error)
+			return m, nil
 		}
+		m.resultContent = msg.Content
+		m.resultMessage = fmt.Sprintf("%d", len(msg.Content))
+		m.sectionNote = fmt.Sprintf("🔁 Regenerated the %s section", msg.Heading)
+		m.sectionInput.Blur()
+		m.state = stateResultSuccess
 		return m, nil
 		
 	case StdinSubmitMsg:
 		m.stdinContent = msg.Content
 		m.state = stateConfirmGenerate
+		return m, checkQuota(m)
+		
+	case QuotaCheckedMsg:
+		// The quota line is informational, so an unreadable ledger just hides it
+		if msg.Error != nil {
+			logging.Debugf("Could not check the quota: %v", msg.Error)
+			return m, nil
+		}
+		m.quota = &msg.Status
+		m.fallbackQuota = msg.Fallback
 		return m, nil
 		
+	case pagerClosedMsg:
+		return handlePagerClosed(m, msg), nil
+		
+	case workspaceLoadedMsg:
+		return handleWorkspaceLoaded(m, msg), nil
+		
+	case APIKeyCheckedMsg:
+		m.keyChecking = false
+		m.keyDiagnosis = msg.Diagnosis
+		m.apiKeyOk = msg.Diagnosis.Usable()
+		
 	case ProgressUpdateMsg:
 		m.progressStep = msg.Step
 		m.progressMsg = msg.Message
 		
+	case recentLoadedMsg:
+		m.recent = msg.files
+		
+	case SnippetsLoadedMsg:
+		m.snippetLibrary = msg.Snippets
+		m.snippetCursor = 0
+		m.snippetErr = ""
+		if msg.Error != nil {
+			m.snippetErr = msg.Error.Error()
+		}
+		
 	case tea.KeyMsg:
+		keys := m.keyMap()
+		
+		// The snippet picker captures all keys except Ctrl+C while it is open
+		if m.snippetPickerActive && msg.Type != tea.KeyCtrlC {
+			return updateSnippetPicker(m, msg)
+		}
+		
+		// An open confirmation dialog captures all keys
+		if m.pendingConfirm != confirmNone {
+			var confirmCmd tea.Cmd
+			m, confirmCmd = updateConfirmDialog(m, msg)
+			if confirmCmd != nil {
+				cmds = append(cmds, confirmCmd)
+			}
+			break
+		}
+		
+		// A repeated Enter is not taken as the answer to the next screen
+		if key.Matches(msg, keys.Accept) && acceptGuarded(m) {
+			return m, nil
+		}
+		
 		// Global key handlers
-		switch msg.Type {
-		case tea.KeyCtrlC, tea.KeyEsc:
+		if key.Matches(msg, keys.Quit) {
+			// Ask before discarding notes that were typed but never used
+			if hasUnsavedInput(m) {
+				return openConfirmDialog(m, confirmQuit), nil
+			}
 			m = cleanupAPIClient(m)
 			return m, tea.Quit
 		}
 		
+		// '?' lists every shortcut of the screen, where it is not typed into a field
+		if helpAvailable(m) && key.Matches(msg, keys.Help) {
+			m.helpOpen = !m.helpOpen
+			return m, nil
+		}
+		
 		// State-specific key handling
 		switch m.state {
 		case stateWelcome:
-			if msg.Type == tea.KeyEnter {
-				if m.apiKeyOk {
-					// Initialize API client here when we confirm a valid API key
-					// This is the earliest point where we need the API client
-					var err error
-					m, err = initializeAPIClient(m)
-					if err != nil {
-						m.state = stateResultError
-						m.errorMsg = err.Error()
-						return m, nil
-					}
-					
-					// If a source path was provided via flags, we can pre-fill it
-					if m.flagSourcePath != "" {
-						// We'll still go to the input screen but with pre-filled value
-						m.state = stateInputSourcePath
-						cmds = append(cmds, m.sourcePathInput.Focus())
-					} else {
-						// Otherwise, prompt for source path
-						m.state = stateInputSourcePath
-						cmds = append(cmds, m.sourcePathInput.Focus())
-					}
-				} else {
-					m.state = stateResultError
-					m.errorMsg = "API key is missing or invalid. Set GEMINI_API_KEY environment variable."
-				}
+			if key.Matches(msg, keys.Accept) {
+				return beginInputs(m)
+			}
+			
+			// 'w' lists the resumes saved on this computer
+			if m.historyDir != "" && key.Matches(msg, keys.Workspace) {
+				return openDashboard(m)
+			}
+			
+			// A number starts from one of the recent files listed
+			if n := recentNumber(m, msg); n > 0 {
+				return beginInputs(useRecent(m, n))
 			}
 		
+		case stateDashboard:
+			return updateDashboard(m, msg)
+		
 		case stateInputSourcePath:
 			// Update source input component
 			var inputCmd tea.Cmd
 			m.sourcePathInput, inputCmd = m.sourcePathInput.Update(msg)
 			cmds = append(cmds, inputCmd)
 			
-			if msg.Type == tea.KeyEnter {
-				// Use the file reading command to read the source file
-				filePath := m.sourcePathInput.Value()
+			if key.Matches(msg, keys.Accept) {
+				// Use the file reading command to read the source file, with ~
+				// and environment variables expanded as a shell would
+				filePath := input.ExpandPath(strings.TrimSpace(m.sourcePathInput.Value()))
+				m.sourcePathInput.SetValue(filePath)
 				m.state = stateInputStdin
 				cmds = append(cmds, 
 					ReadSourceFileCmd(filePath),  // Read the file asynchronously
@@ -240,83 +639,163 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		
 		case stateInputStdin:
+			// Ctrl+O opens the snippets picker
+			if key.Matches(msg, keys.Snippets) {
+				return openSnippetPicker(m)
+			}
+			
 			// Update textarea component
 			var textareaCmd tea.Cmd
 			m.stdinInput, textareaCmd = m.stdinInput.Update(msg)
 			cmds = append(cmds, textareaCmd)
 			
 			// Ctrl+D to finish input and proceed
-			if msg.Type == tea.KeyCtrlD {
+			if key.Matches(msg, keys.Finish) {
 				// Submit the stdin input using our command
 				cmds = append(cmds, SubmitStdinInputCmd(m.stdinInput.Value()))
 			}
 		
+		case stateInputSkills:
+			return updateSkillsEditor(m, msg)
+		
+		case stateFixJSONResume:
+			return updateJSONFixer(m, msg)
+		
+		case stateSaveFallback:
+			return updateSaveFallback(m, msg)
+		
+		case stateConsent:
+			return updateConsent(m, msg)
+		
+		case stateRegenerateSection:
+			return updateSectionEditor(m, msg)
+		
+		case stateReviewSections:
+			return updateSectionReview(m, msg)
+		
 		case stateConfirmGenerate:
-			if msg.Type == tea.KeyEnter {
-				m.state = stateGenerating
-				
-				// Use provided output path from flags if available
-				outputPath := ""
-				if m.flagOutputPath != "" {
-					outputPath = m.flagOutputPath
-				}
-				
-				// Add progress update and API commands
-				// Pass the model's context to GenerateResumeCmd for cancellation support
-				cmds = append(cmds, 
-					SendProgressUpdateCmd("Starting", "Initializing resume generation..."),
-					GenerateResumeCmd(m.ctx, m.apiClient, m.apiModel, m.sourceContent, m.stdinContent, outputPath, false),
-				)
-			} else if msg.Type == tea.KeyEsc {
-				m.state = stateInputStdin
-				cmds = append(cmds, m.stdinInput.Focus())
+			// 's' opens the structured skills form
+			if key.Matches(msg, keys.Skills) {
+				m.promptPreviewOpen = false
+				return openSkillsEditor(m)
+			}
+			
+			// 'p' expands or collapses the prompt preview
+			if key.Matches(msg, keys.Prompt) {
+				return togglePromptPreview(m), nil
+			}
+			
+			// Other keys scroll the open preview
+			if m.promptPreviewOpen && !key.Matches(msg, keys.Accept) {
+				return updatePromptPreview(m, msg)
+			}
+			
+			if key.Matches(msg, keys.Accept) {
+				// Ask for consent and before replacing a resume from an earlier run
+				m.acceptGuard = time.Now().Add(acceptDebounce)
+				return beginGeneration(m)
 			}
 			
 		case stateResultSuccess, stateResultError:
-			// Any key in final states quits the application
-			if msg.Type == tea.KeyEnter {
+			// Enter in final states quits the application
+			if key.Matches(msg, keys.Accept) {
 				m = cleanupAPIClient(m)
 				return m, tea.Quit
 			}
+			
+			// 'r' generates the resume again once confirmed, since it uses API quota
+			if (m.state == stateResultSuccess || m.generateAttempted) && key.Matches(msg, keys.Again) {
+				return openConfirmDialog(stopRetry(m), confirmRegenerate), nil
+			}
+			
+			// Ctrl+X stops the countdown to an automatic retry
+			if !m.retryAt.IsZero() && key.Matches(msg, keys.Remove) {
+				return stopRetry(m), nil
+			}
+			
+			// 'a' opens the keyword analysis for a successfully generated resume
+			if m.state == stateResultSuccess && key.Matches(msg, keys.Analysis) {
+				m.state = stateAnalysis
+			}
+			
+			// 'd' compares the resume with the one saved before it for the same candidate
+			if m.state == stateResultSuccess && m.previousRevision != nil && key.Matches(msg, keys.Changes) {
+				m.state = stateRevisionDiff
+			}
+			
+			// 't' opens the experience timeline for a successfully generated resume
+			if m.state == stateResultSuccess && key.Matches(msg, keys.Timeline) {
+				m.state = stateTimeline
+			}
+			
+			// 's' chooses a section of the resume to regenerate on its own
+			if m.state == stateResultSuccess && canRegenerateSection(m) && key.Matches(msg, keys.Section) {
+				return openSectionEditor(m)
+			}
+			
+			// 'v' shows the resume itself, in the preview or the pager
+			if m.state == stateResultSuccess && key.Matches(msg, keys.View) {
+				return openResumePreview(m)
+			}
+			
+		case stateAnalysis:
+			// Enter or 'a' returns to the success view
+			if key.Matches(msg, keys.Accept, keys.Analysis) {
+				m.state = stateResultSuccess
+			}
+			
+		case stateRevisionDiff:
+			// Enter or 'd' returns to the success view
+			if key.Matches(msg, keys.Accept, keys.Changes) {
+				m.state = stateResultSuccess
+			}
+			
+		case stateTimeline:
+			// Enter or 't' returns to the success view
+			if key.Matches(msg, keys.Accept, keys.Timeline) {
+				m.state = stateResultSuccess
+			}
+			
+		case stateResumePreview:
+			return updateResumePreview(m, msg)
+		
+		default:
+			// Screens added with RegisterState handle their own keys
+			if onCustomScreen(m) {
+				return updateCustomScreen(m, msg)
+			}
 		}
 	
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		
-		// Update component dimensions with minimum widths to prevent text truncation
-		inputWidth := msg.Width - 20
-		if inputWidth < 60 {
-			inputWidth = 60
+		// Resizes are debounced so dragging a window edge doesn't flicker
+		var resizeCmd tea.Cmd
+		m, resizeCmd = handleWindowSize(m, msg)
+		if resizeCmd != nil {
+			cmds = append(cmds, resizeCmd)
 		}
 		
-		// Fixed textarea height to avoid pushing content out of view
-		// Keep as a constant 10 rows regardless of window height
-		textareaHeight := 10
-		
-		m.sourcePathInput.Width = inputWidth
-		m.stdinInput.SetWidth(inputWidth)
-		m.stdinInput.SetHeight(textareaHeight)
+	case windowResizedMsg:
+		m = handleWindowResized(m, msg)
 	}
 	
-	// Handle spinner updates based on state
-	if m.state == stateGenerating {
+	// Screens added with RegisterState get every other message too, such as
+	// the results of their own commands and window sizes
+	if _, isKey := msg.(tea.KeyMsg); !isKey && onCustomScreen(m) {
+		var screenCmd tea.Cmd
+		m, screenCmd = updateCustomScreen(m, msg)
+		cmds = append(cmds, screenCmd)
+	}
+	
+	// Advance the spinner on its own ticks only; ticking it for other
+	// messages, such as progress updates or resizes, makes it stutter
+	if tick, ok := msg.(spinner.TickMsg); ok && m.state == stateGenerating {
 		var spinnerCmd tea.Cmd
-		// Update the spinner regardless of msg type to ensure animation consistency
-		m.spinner, spinnerCmd = m.spinner.Update(msg)
-		
-		// Always ensure the spinner keeps ticking by adding the tick command
-		// This is crucial to keep animation going
-		if len(cmds) == 0 {
-			// If no other commands were queued, return just the spinner commands
-			return m, tea.Batch(spinnerCmd, m.spinner.Tick)
-		}
-		
-		// Otherwise, append spinner commands to the existing command batch
-		cmds = append(cmds, spinnerCmd, m.spinner.Tick)
-	} else if len(cmds) == 0 {
-		// If not in generating state and no commands were queued, return nil command
-		// This ensures we don't keep ticking when we don't need the spinner
+		m.spinner, spinnerCmd = m.spinner.Update(tick)
+		cmds = append(cmds, spinnerCmd)
+	}
+	if len(cmds) == 0 {
+		// Returning no command when nothing was queued keeps the spinner
+		// from ticking when it isn't shown
 		return m, nil
 	}
 	
@@ -351,25 +830,255 @@ func (m Model) View() string {
 	case stateResultError:
 		content = renderErrorView(m)
 	
+	case stateAnalysis:
+		content = renderAnalysisView(m)
+	
+	case stateInputSkills:
+		content = renderSkillsInputView(m)
+	
+	case stateFixJSONResume:
+		content = renderJSONFixerView(m)
+	
+	case stateTimeline:
+		content = renderTimelineView(m)
+	
+	case stateSaveFallback:
+		content = renderSaveFallbackView(m)
+	
+	case stateConsent:
+		content = renderConsentView(m)
+	
+	case stateRevisionDiff:
+		content = renderRevisionDiffView(m)
+	
+	case stateRegenerateSection:
+		content = renderSectionEditorView(m)
+	
+	case stateResumePreview:
+		content = renderResumePreviewView(m)
+	
+	case stateReviewSections:
+		content = renderSectionReviewView(m)
+	
+	case stateDashboard:
+		content = renderDashboardView(m)
+	
 	default:
 		content = "Unknown state"
+		if onCustomScreen(m) {
+			content = m.screen.View()
+		}
+	}
+	
+	// Show an open confirmation dialog below the current screen
+	if m.pendingConfirm != confirmNone {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, "", renderConfirmDialog(m, getConstrainedWidth(m.width)-4))
 	}
 	
 	// Apply main style to the content
-	return m.mainStyle.Render(content)
+	body := m.mainStyle.Render(content)
+	statusBar := renderStatusBar(m)
+	
+	// Push the status bar to the bottom of the terminal when the content is shorter
+	if gap := m.height - lipgloss.Height(body) - lipgloss.Height(statusBar); gap > 0 {
+		body += strings.Repeat("\n", gap)
+	}
+	
+	view := lipgloss.JoinVertical(lipgloss.Left, body, statusBar)
+	
+	// Consoles that cannot draw emoji get ASCII icons of the same width
+	if !m.emoji {
+		view = layout.ASCIIIcons(view)
+	}
+	return view
+}
+
+// Err returns the error that ended the session, or nil if it did not end in
+// an error. API failures are returned as *api.APIError, so callers can map
+// them to exit codes.
+func (m Model) Err() error {
+	if m.state != stateResultError {
+		return nil
+	}
+	return m.currentError()
+}
+
+// currentError returns the error shown on the error screen.
+func (m Model) currentError() error {
+	if m.err != nil {
+		return m.err
+	}
+	return errors.New(m.errorMsg)
+}
+
+// acceptDebounce is how long Enter is ignored after it confirms generation
+// and after the result arrives, so pressing it repeatedly while waiting
+// neither agrees to the next screen nor quits from the result.
+const acceptDebounce = 750 * time.Millisecond
+
+// acceptGuarded reports whether Enter is ignored: while a generation is in
+// flight, and on the screens before and after it until the guard expires.
+func acceptGuarded(m Model) bool {
+	if m.generating {
+		return true
+	}
+	switch m.state {
+	case stateConfirmGenerate, stateConsent, stateResultSuccess, stateResultError:
+		return time.Now().Before(m.acceptGuard)
+	}
+	return false
+}
+
+// beginInputs checks the API key and, when it is usable, shows the screens
+// added before the inputs, then asks for the source path, pre-filled if it
+// was given by flag. Without a usable key it shows the error screen.
+func beginInputs(m Model) (Model, tea.Cmd) {
+	if !m.apiKeyOk && m.fixtures.Mode != api.FixtureReplay {
+		m.state = stateResultError
+		m.errorMsg = "API key is missing or invalid. Set GEMINI_API_KEY environment variable."
+		if m.keyDiagnosis.Status != "" {
+			m.errorMsg = "API key error: " + m.keyDiagnosis.String()
+		}
+		return m, nil
+	}
+	
+	// Initialize API client here when we confirm a valid API key
+	// This is the earliest point where we need the API client
+	var err error
+	m, err = initializeAPIClient(m)
+	if err != nil {
+		m.state = stateResultError
+		m.err = err
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	return showScreens(m, HookBeforeInput, 0)
+}
+
+// startGeneration shows the screens added before generation, then starts
+// generating the resume from the collected inputs. It is used for the first
+// run and for runs confirmed from the overwrite and regenerate dialogs.
+func startGeneration(m Model) (Model, tea.Cmd) {
+	return showScreens(m, HookBeforeGenerate, 0)
+}
+
+// runGeneration moves to the generating screen and starts generating the
+// resume from the collected inputs.
+func runGeneration(m Model) (Model, tea.Cmd) {
+	// Only one expensive request is in flight at a time
+	if m.generating {
+		return m, nil
+	}
+	m.state = stateGenerating
+	m.generating = true
+	m.generateAttempted = true
+	m.metrics = NewRequestMetrics(time.Now())
+	
+	// Add progress update and API commands
+	// Pass the model's context to GenerateResumeCmd for cancellation support
+	return m, tea.Batch(
+		m.spinner.Tick,
+		SendProgressUpdateCmd("Starting", "Initializing resume generation..."),
+		GenerateResumeWithOptionsCmd(m.ctx, m.apiClient, m.apiModel, m.sourceContent, m.stdinContent, generateOptions(m)),
+	)
+}
+
+// withStructure keeps the structured source loaded from a sidecar and
+// restores the skills and academics saved with it, unless they were already
+// given for this run.
+func withStructure(m Model, structure *document.Resume) Model {
+	m.structure = structure
+	if structure == nil {
+		return m
+	}
+	if len(m.skills) == 0 {
+		m.skills = append([]document.Skill(nil), structure.Skills...)
+	}
+	if m.academics.GPA == "" && len(m.academics.Coursework) == 0 {
+		m.academics = structure.Academics
+	}
+	return m
+}
+
+// generateOptions returns the generation settings chosen by flags and on the
+// confirm screen.
+func generateOptions(m Model) GenerateOptions {
+	return GenerateOptions{
+		OutputPath:    m.flagOutputPath,
+		LegacyOutput:  m.flagLegacyOutput,
+		Bundle:        m.flagBundle,
+		FallbackModel: m.fallbackModel,
+		Skills:        m.skills,
+		Academics:     m.academics,
+		Preset:        m.preset,
+		Audience:      m.audience,
+		Structure:     m.structure,
+		Layout:        m.flagLayout,
+		Timeline:      m.flagTimeline,
+		PageBreaks:    m.flagPageBreaks,
+		ProseWidth:    m.flagProseWidth,
+		QR:            m.flagQR,
+		QRLayouts:     m.flagQRLayouts,
+		JSONResume:    m.flagJSONResume,
+		Exports:       m.flagExports,
+		Company:       m.flagCompany,
+		Emphasize:     m.flagEmphasize,
+		Grounding:     m.flagGrounding,
+		Explain:       m.flagExplain,
+		Gaps:          m.flagGaps,
+		Documents:     m.flagDocuments,
+		Review:        m.flagReview,
+		Amend:         m.flagAmend,
+		Fixtures:      m.fixtures,
+		TrimOrder:     m.trimOrder,
+		HistoryDir:    m.historyDir,
+		RecentPath:    m.recentPath,
+		SourcePath:    m.sourcePathInput.Value(),
+		SourcePDF:     m.sourcePDF,
+		Pack:          m.flagPack,
+		JobPath:       m.jobPath,
+		Job:           m.jobDescription,
+		MaxDuration:   m.maxDuration,
+		Seed:          m.seed,
+		PromptCache:   m.promptCache,
+		Metrics:       m.metrics,
+	}
+}
+
+// checkQuota returns a command that reads the usage ledger for the confirm
+// screen, or nil when there is no ledger or responses are replayed, which
+// uses no quota. It checks the model generation sends the prompt to and the
+// fallback model retried when that one fails.
+func checkQuota(m Model) tea.Cmd {
+	if m.usagePath == "" || m.fixtures.Mode == api.FixtureReplay {
+		return nil
+	}
+	return CheckQuotaCmd(m.usagePath, api.DefaultModelName, m.fallbackModel)
+}
+
+// plannedRequests returns how many requests generation sends with the
+// chosen options when nothing fails.
+func plannedRequests(m Model) int {
+	requests := 1
+	for _, extra := range []bool{m.flagCompany != "", m.flagBundle, m.flagExplain, m.flagGaps} {
+		if extra {
+			requests++
+		}
+	}
+	return requests + len(m.flagDocuments)
 }
 
-// Helper function to check if the API key is available and valid
-func checkAPIKey() bool {
-	_, err := api.GetAPIKey()
-	return err == nil
+// checkAPIKey diagnoses the API key in GEMINI_API_KEY without contacting the API
+func checkAPIKey() api.KeyDiagnosis {
+	apiKey, _ := api.GetAPIKey()
+	return api.DiagnoseAPIKey(apiKey)
 }
 
 // initializeAPIClient initializes the API client and model if needed
 // Returns the modified model and any error that occurred
 func initializeAPIClient(m Model) (Model, error) {
-	// Skip initialization if already done
-	if m.apiClient != nil && m.apiModel != nil {
+	// Skip initialization if already done, or if responses are replayed from fixtures
+	if (m.apiClient != nil && m.apiModel != nil) || m.fixtures.Mode == api.FixtureReplay {
 		return m, nil
 	}
 	
@@ -390,17 +1099,38 @@ func initializeAPIClient(m Model) (Model, error) {
 	m.apiClient = client
 	m.apiModel = model
 	
+	// Generating again reads the system instructions from a cache, where
+	// the cached-content API can be reached
+	if api.CachingAvailable() {
+		m.promptCache = api.NewPromptCache(client, api.DefaultCacheTTL)
+	}
+	
 	return m, nil
 }
 
 // Make cleanupAPIClient a variable so it can be mocked in tests
 var cleanupAPIClient = func(m Model) Model {
 	if m.apiClient != nil {
+		// Uploaded sources are only needed while the program runs
+		if len(m.uploadedFiles) > 0 {
+			deleteUploads(m.apiClient, m.uploadedFiles)
+			m.uploadedFiles = nil
+		}
+		
+		// Cached instructions are only needed while the program runs
+		if m.promptCache != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), uploadDeleteTimeout)
+			m.promptCache.Close(ctx)
+			cancel()
+			m.promptCache = nil
+		}
+		
 		// Call Close method
 		m.apiClient.Close()
 		
 		m.apiClient = nil
 		m.apiModel = nil
+		m.apiSession = nil
 	}
 	return m
 }
@@ -420,6 +1150,266 @@ func (m Model) WithOutputPath(path string) Model {
 	return m
 }
 
+// WithLegacyOutput returns a copy of the model that writes to resume_out.md
+// when no output path is given, instead of naming the file after the candidate and date
+// Used when --legacy-output is provided
+func (m Model) WithLegacyOutput(enabled bool) Model {
+	m.flagLegacyOutput = enabled
+	return m
+}
+
+// WithBundle returns a copy of the model with bundle mode set
+// Used when --bundle is provided to also generate a matching cover letter
+func (m Model) WithBundle(bundle bool) Model {
+	m.flagBundle = bundle
+	return m
+}
+
+// WithFallbackModel returns a copy of the model with the fallback model set
+// Used when --fallback-model is provided; an empty name disables the retry
+func (m Model) WithFallbackModel(name string) Model {
+	m.fallbackModel = name
+	return m
+}
+
+// WithJobDescription returns a copy of the model with the job description set
+// Used when --job is provided to compare resume keywords against a job posting
+func (m Model) WithJobDescription(content string) Model {
+	m.jobDescription = content
+	return m
+}
+
+// WithJobPath returns a copy of the model that knows which file the job
+// description was read from
+// Used to name the job description in the resume pack
+func (m Model) WithJobPath(path string) Model {
+	m.jobPath = path
+	return m
+}
+
+// WithMaxDuration returns a copy of the model that stops generating after d,
+// keeping the complete sections streamed so far and marking the rest TODO
+// Used when --max-duration is provided to bound how long generation takes
+func (m Model) WithMaxDuration(d time.Duration) Model {
+	m.maxDuration = d
+	return m
+}
+
+// WithSeed returns a copy of the model that samples every request with seed
+// and a temperature of 0, so the same inputs give the same resume
+// Used when --seed is provided to make generation reproducible
+func (m Model) WithSeed(seed int64) Model {
+	m.seed = &seed
+	return m
+}
+
+// WithRenderer returns a copy of the model that shows the generated resume with renderer
+// A PagerRenderer hands the terminal to the pager until it exits
+// Used when --renderer is provided to choose between styled, plain, and pager previews
+func (m Model) WithRenderer(renderer output.PreviewRenderer) Model {
+	m.renderer = renderer
+	return m
+}
+
+// WithPack returns a copy of the model that zips the resume files, the job
+// description, and a metadata file into one dated archive after saving
+// Used when --pack is provided to keep each application's files together
+func (m Model) WithPack(pack bool) Model {
+	m.flagPack = pack
+	return m
+}
+
+// WithCompany returns a copy of the model with the target employer set
+// Used when --company is provided to research the employer and tailor the resume to it
+func (m Model) WithCompany(target string) Model {
+	m.flagCompany = target
+	return m
+}
+
+// WithEmphasize returns a copy of the model with the keywords to emphasize set
+// Used when --emphasize is provided to feature keywords and check they were included
+func (m Model) WithEmphasize(keywords []string) Model {
+	m.flagEmphasize = keywords
+	return m
+}
+
+// WithPreset returns a copy of the model with the resume preset set
+// Used when --preset is provided to change the tone and section order
+func (m Model) WithPreset(preset prompt.Preset) Model {
+	m.preset = preset
+	return m
+}
+
+// WithAudience returns a copy of the model with the resume's audience set
+// Used when --audience is provided to change what the resume emphasizes
+func (m Model) WithAudience(audience prompt.Audience) Model {
+	m.audience = audience
+	return m
+}
+
+// WithAcademics returns a copy of the model with the GPA and coursework set
+// Used when --gpa or --coursework is provided for the Education section
+func (m Model) WithAcademics(academics document.Academics) Model {
+	m.academics = academics
+	return m
+}
+
+// WithExplain returns a copy of the model with the explanation notes enabled or disabled
+// Used when --explain is provided to save why the major changes were made
+func (m Model) WithExplain(enabled bool) Model {
+	m.flagExplain = enabled
+	return m
+}
+
+// WithGaps returns a copy of the model with the gaps appendix enabled or disabled
+// Used when --gaps is provided to save how to close the gaps with the job description
+func (m Model) WithGaps(enabled bool) Model {
+	m.flagGaps = enabled
+	return m
+}
+
+// WithDocuments returns a copy of the model with the documents to write alongside the resume set
+// Used when --documents is provided to also write documents such as a thank-you email
+func (m Model) WithDocuments(documents []prompt.DocumentType) Model {
+	m.flagDocuments = documents
+	return m
+}
+
+// WithReview returns a copy of the model with the section-by-section review enabled or disabled
+// Used when --review is provided to accept each section before the resume is saved
+func (m Model) WithReview(enabled bool) Model {
+	m.flagReview = enabled
+	return m
+}
+
+// WithAmend returns a copy of the model in amend mode or out of it
+// Used when --amend is provided to update the previous resume with the notes typed
+func (m Model) WithAmend(enabled bool) Model {
+	m.flagAmend = enabled
+	if enabled {
+		m.stdinInput.Placeholder = "Describe what changed, e.g. \"Add my new job at Acme as Staff Engineer since March\""
+	}
+	return m
+}
+
+// WithLayout returns a copy of the model with the HTML layout set
+// Used when --layout is provided to also render the resume as HTML
+func (m Model) WithLayout(layout output.Layout) Model {
+	m.flagLayout = layout
+	return m
+}
+
+// WithTimeline returns a copy of the model with the HTML timeline section enabled or disabled
+// Used when --timeline is provided alongside --layout
+func (m Model) WithTimeline(enabled bool) Model {
+	m.flagTimeline = enabled
+	return m
+}
+
+// WithPageBreaks returns a copy of the model that marks estimated page breaks in the HTML resume
+// Used when --page-breaks is provided alongside --layout
+func (m Model) WithPageBreaks(enabled bool) Model {
+	m.flagPageBreaks = enabled
+	return m
+}
+
+// WithProseWidth returns a copy of the model that writes prose one sentence per line, wrapped at width
+// Used when --wrap is provided, for resumes kept in version control
+func (m Model) WithProseWidth(width int) Model {
+	m.flagProseWidth = width
+	return m
+}
+
+// WithGrounding returns a copy of the model with the grounding mode set
+// Used when --grounding is provided, so every bullet must cite the inputs it comes from
+func (m Model) WithGrounding(grounding prompt.Grounding) Model {
+	m.flagGrounding = grounding
+	return m
+}
+
+// WithQR returns a copy of the model that links to url with a QR code in the given layouts
+// Used when --qr or the qr setting names a URL for the HTML and PDF header
+func (m Model) WithQR(url string, layouts []output.Layout) Model {
+	m.flagQR = url
+	m.flagQRLayouts = layouts
+	return m
+}
+
+// WithJSONResume returns a copy of the model with JSON Resume export enabled or disabled
+// Used when --json is provided to also export the resume in JSON Resume format
+func (m Model) WithJSONResume(enabled bool) Model {
+	m.flagJSONResume = enabled
+	return m
+}
+
+// WithExports returns a copy of the model with the document formats to export to
+// Used when --export is provided to also convert the resume to DOCX, PDF, or ODT
+func (m Model) WithExports(formats []output.ExportFormat) Model {
+	m.flagExports = formats
+	return m
+}
+
+// WithFixtures returns a copy of the model with API fixture recording or replay set
+// Used when --record or --replay is provided; replay needs no API key
+func (m Model) WithFixtures(fixtures api.Fixtures) Model {
+	m.fixtures = fixtures
+	return m
+}
+
+// WithTrimOrder returns a copy of the model with the order used to trim input
+// that exceeds the context window. Used when --trim-order is provided
+func (m Model) WithTrimOrder(order []prompt.TrimStep) Model {
+	m.trimOrder = order
+	return m
+}
+
+// WithKeyVerification returns a copy of the model that checks the API key
+// with a lightweight API request on startup, so the welcome screen can tell
+// an invalid or expired key from an unreachable API
+// Keys that are missing or malformed are reported without a request
+func (m Model) WithKeyVerification(verify bool) Model {
+	m.verifyKey = verify
+	m.keyChecking = verify && m.keyDiagnosis.Status == api.KeyUnverified
+	return m
+}
+
+// WithConsentRequired returns a copy of the model that shows what will be sent
+// to the API and asks for consent before the first request
+// Used when consent has not been recorded in the configuration directory
+func (m Model) WithConsentRequired(required bool) Model {
+	m.consentRequired = required
+	return m
+}
+
+// WithHistoryDir returns a copy of the model that keeps saved resumes in dir,
+// so each new resume can be compared with the last one for the same candidate
+func (m Model) WithHistoryDir(dir string) Model {
+	m.historyDir = dir
+	return m
+}
+
+// WithRecentPath returns a copy of the model that lists the files used
+// recently, kept at path, on the welcome screen and adds to them
+func (m Model) WithRecentPath(path string) Model {
+	m.recentPath = path
+	return m
+}
+
+// WithDashboard returns a copy of the model that starts on the workspace
+// dashboard instead of the welcome screen
+// Used by the dashboard command
+func (m Model) WithDashboard() Model {
+	m.state = stateDashboard
+	return m
+}
+
+// WithUsagePath returns a copy of the model that checks the usage ledger at
+// path against the quota before generating
+func (m Model) WithUsagePath(path string) Model {
+	m.usagePath = path
+	return m
+}
+
 // WithContext returns a copy of the model with the context set
 // This allows passing a cancellable context for API operations
 func (m Model) WithContext(ctx context.Context) Model {
@@ -427,6 +1417,12 @@ func (m Model) WithContext(ctx context.Context) Model {
 	return m
 }
 
+// WithSnippetsDir returns a copy of the model that loads snippets from dir
+func (m Model) WithSnippetsDir(dir string) Model {
+	m.snippetsDir = dir
+	return m
+}
+
 // WithVersion returns a copy of the model with the version set
 func (m Model) WithVersion(version string) Model {
 	m.appVersion = version
@@ -2,43 +2,14 @@ package tui
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/phrazzld/resumake/api"
 )
 
-// TestModelStateBehavior uses our behavior-focused utilities to test model transitions
-func TestModelStateBehavior(t *testing.T) {
-	// Simplified for now while working on the test overhaul
-	t.Skip("Temporarily skipping transition test while views are being updated")
-	
-	// Create a model in welcome state with valid API key
-	model := NewModel()
-	model.apiKeyOk = true
-	
-	// Create assertions helper for the initial state
-	ma := NewModelAssertions(t, model)
-	ma.AssertState(stateWelcome)
-	
-	// Test the welcome view structure
-	welcomeView := model.View()
-	welcomeElements := []string{"R E S U M A K E", "API key"}
-	for _, element := range welcomeElements {
-		if !ContainsViewElement(welcomeView, element) {
-			t.Errorf("Welcome view should contain '%s'", element)
-		}
-	}
-	
-	// Simulate transition to source input state
-	updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
-	model, _ = updatedModel.(Model)
-	
-	// Check new state
-	ma = NewModelAssertions(t, model)
-	ma.AssertState(stateInputSourcePath)
-}
-
 // TestProgressUpdateBehavior tests specifically how progress updates affect the model
 func TestProgressUpdateBehavior(t *testing.T) {
 	// Create a model in generating state
@@ -152,4 +123,39 @@ func TestSuccessStateBehavior(t *testing.T) {
 	// Note: '42' is the length of "# Generated Resume\n\nThis is a test resume."
 	expectedLength := "42"
 	ma.AssertResultContains(expectedLength)
-}
\ No newline at end of file
+}
+// TestAPIKeyCheck tests that the key check result replaces the startup diagnosis
+func TestAPIKeyCheck(t *testing.T) {
+	model := NewModel()
+	model.keyDiagnosis = api.KeyDiagnosis{Status: api.KeyUnverified}
+	model.apiKeyOk = true
+	
+	// Only a key that looks right is checked with the API
+	if !model.WithKeyVerification(true).keyChecking {
+		t.Error("Expected a well-formed key to be checked")
+	}
+	missing := model
+	missing.keyDiagnosis = api.DiagnoseAPIKey("")
+	if missing.WithKeyVerification(true).keyChecking {
+		t.Error("Expected a missing key not to be checked")
+	}
+	
+	model = model.WithKeyVerification(true)
+	updatedModel, _ := model.Update(APIKeyCheckedMsg{Diagnosis: api.KeyDiagnosis{Status: api.KeyInvalid, Summary: "API key was rejected"}})
+	model = updatedModel.(Model)
+	if model.keyChecking {
+		t.Error("Expected the check to be finished")
+	}
+	if model.apiKeyOk {
+		t.Error("Expected a rejected key not to be usable")
+	}
+	
+	// Enter shows the diagnosis instead of continuing with a rejected key
+	updatedModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if result := updatedModel.(Model); result.state != stateResultError || !strings.Contains(result.errorMsg, "rejected") {
+		t.Errorf("Expected the error screen with the diagnosis, got state %v and %q", result.state, result.errorMsg)
+	}
+	if info := statusAPIInfo(model); !strings.Contains(info, "key invalid") {
+		t.Errorf("Expected the status bar to say the key is invalid, got %q", info)
+	}
+}
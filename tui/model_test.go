@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/output"
 )
 
 func TestModelImplementsTea(t *testing.T) {
@@ -404,8 +406,9 @@ func TestContextPassedToAPIClient(t *testing.T) {
 		t.Error("API client initialization should use the model's context")
 	}
 	
-	// Check if GenerateResumeCmd is called with the model's context
-	if !strings.Contains(string(fileContent), "GenerateResumeCmd(m.ctx,") {
+	// Check if GenerateResumeCmd (or its options variant) is called with the model's context
+	if !strings.Contains(string(fileContent), "GenerateResumeCmd(m.ctx,") &&
+		!strings.Contains(string(fileContent), "GenerateResumeWithOptionsCmd(m.ctx,") {
 		t.Error("GenerateResumeCmd should be called with the model's context")
 	}
 }
@@ -430,145 +433,73 @@ func TestModelFieldInitialization(t *testing.T) {
 	}
 }
 
-func TestModelView(t *testing.T) {
-	// Skip this test temporarily while we overhaul the testing approach
-	t.Skip("Temporarily skipping while views are being updated")
-	
-	// Test that View returns appropriate content based on application state
-	stateTests := []struct {
-		name            string
-		setupModel      func() Model
-		expectedContent []string
-	}{
-		{
-			name: "Welcome State View",
-			setupModel: func() Model {
-				m := NewModel()
-				m.state = stateWelcome
-				m.apiKeyOk = true
-				m.width = 100
-				m.height = 40
-				return m
-			},
-			expectedContent: []string{
-				"R E S U M A K E",
-				"API key",
-				"valid",
-			},
-		},
-		{
-			name: "Source Input State View",
-			setupModel: func() Model {
-				m := NewModel()
-				m.state = stateInputSourcePath
-				m.width = 80
-				m.height = 24
-				return m
-			},
-			expectedContent: []string{
-				"Source File",
-				"optional",
-				"Enter",
-			},
-		},
-		{
-			name: "Stdin Input State View",
-			setupModel: func() Model {
-				m := NewModel()
-				m.state = stateInputStdin
-				m.width = 80
-				m.height = 24
-				return m
-			},
-			expectedContent: []string{
-				"Resume Details",
-				"experience",
-				"skills",
-			},
-		},
-		{
-			name: "Confirm Generate State View",
-			setupModel: func() Model {
-				m := NewModel()
-				m.state = stateConfirmGenerate
-				m.sourcePathInput.SetValue("/path/to/source.md")
-				m.sourceContent = "Sample source content"
-				m.stdinContent = "Sample stdin content"
-				m.width = 80
-				m.height = 24
-				return m
-			},
-			expectedContent: []string{
-				"Ready to Generate",
-				"/path/to/source.md",
-				"Enter",
-			},
-		},
-		{
-			name: "Generating State View",
-			setupModel: func() Model {
-				m := NewModel()
-				m.state = stateGenerating
-				m.progressStep = "2 of 4"
-				m.progressMsg = "Sending request to Gemini AI..."
-				m.width = 80
-				m.height = 24
-				return m
-			},
-			expectedContent: []string{
-				"Generating Your Resume",
-				"Step",
-				"2 of 4",
-				"Sending request",
-			},
-		},
-		{
-			name: "Success State View",
-			setupModel: func() Model {
-				m := NewModel()
-				m.state = stateResultSuccess
-				m.outputPath = "/tmp/resume_output.md"
-				m.resultMessage = "1500"
-				m.width = 80
-				m.height = 24
-				return m
-			},
-			expectedContent: []string{
-				"Success",
-				"/tmp/resume_output.md",
-				"Enter",
-			},
-		},
-		{
-			name: "Error State View",
-			setupModel: func() Model {
-				m := NewModel()
-				m.state = stateResultError
-				m.errorMsg = "API connection failed"
-				m.width = 80
-				m.height = 24
-				return m
-			},
-			expectedContent: []string{
-				"Error",
-				"API connection failed",
-				"Enter",
-			},
-		},
+// TestModelBundleMode verifies bundle mode is carried from flags to the result
+func TestModelBundleMode(t *testing.T) {
+	m := NewModel().WithBundle(true)
+	if !m.flagBundle {
+		t.Fatal("Expected WithBundle to enable bundle mode")
 	}
-	
-	// Run each state test
-	for _, tt := range stateTests {
-		t.Run(tt.name, func(t *testing.T) {
-			model := tt.setupModel()
-			view := model.View()
-			
-			// Check for expected content
-			for _, content := range tt.expectedContent {
-				if !strings.Contains(view, content) {
-					t.Errorf("View should contain '%s'", content)
-				}
-			}
-		})
+
+	m.state = stateGenerating
+	updated, _ := m.Update(APIResultMsg{
+		Success:         true,
+		Content:         "# Resume",
+		OutputPath:      "resumake-2024-06-01/resume.md",
+		CoverLetterPath: "resumake-2024-06-01/cover_letter.md",
+	})
+	m = updated.(Model)
+
+	if m.coverLetterPath != "resumake-2024-06-01/cover_letter.md" {
+		t.Errorf("Expected cover letter path to be stored, got %q", m.coverLetterPath)
+	}
+}
+
+func TestModelFallbackModel(t *testing.T) {
+	// Test case 1: The default fallback model is configured
+	m := NewModel()
+	if m.fallbackModel != api.DefaultFallbackModelName {
+		t.Errorf("Expected default fallback model %q, got %q", api.DefaultFallbackModelName, m.fallbackModel)
+	}
+
+	// Test case 2: The fallback can be disabled
+	if m.WithFallbackModel("").fallbackModel != "" {
+		t.Error("Expected WithFallbackModel(\"\") to disable the fallback")
 	}
-}
\ No newline at end of file
+
+	// Test case 3: The producing model is stored from the result
+	m.state = stateGenerating
+	updated, _ := m.Update(APIResultMsg{
+		Success:    true,
+		Content:    "# Resume",
+		OutputPath: "resume_out.md",
+		ModelName:  api.DefaultFallbackModelName,
+	})
+	if got := updated.(Model).modelName; got != api.DefaultFallbackModelName {
+		t.Errorf("Expected model name %q, got %q", api.DefaultFallbackModelName, got)
+	}
+}
+
+// TestModelLayout verifies the layout is passed through and the HTML path is stored
+func TestModelLayout(t *testing.T) {
+	m := NewModel().WithLayout(output.LayoutTwoColumn)
+	if m.flagLayout != output.LayoutTwoColumn {
+		t.Fatalf("Expected WithLayout to set the layout, got %q", m.flagLayout)
+	}
+
+	m.state = stateGenerating
+	updated, _ := m.Update(APIResultMsg{
+		Success:    true,
+		Content:    "# Resume",
+		OutputPath: "resume_out.md",
+		HTMLPath:   "resume_out.html",
+	})
+	m = updated.(Model)
+	m.width = 100
+
+	if m.htmlPath != "resume_out.html" {
+		t.Errorf("Expected HTML path to be stored, got %q", m.htmlPath)
+	}
+	if view := renderSuccessView(m); !strings.Contains(view, "resume_out.html") || !strings.Contains(view, "two-column") {
+		t.Error("Success view should show where the HTML version was saved")
+	}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/layout"
+)
+
+// notesOutlineWidth is the width of the outline sidebar beside the notes.
+const notesOutlineWidth = 34
+
+// notesOutlineSidebar reports whether the terminal is wide enough to show
+// the notes outline beside the input box rather than below the textarea.
+func notesOutlineSidebar(m Model) bool {
+	return m.width >= getConstrainedWidth(m.width)+notesOutlineWidth
+}
+
+// missingNoteTopics returns the sections the resume needs that the notes do
+// not mention yet. Nothing is reported when a source resume is given or
+// the notes are updates to the last resume, since the sections come from
+// the resume then.
+func missingNoteTopics(m Model, outline []document.NoteSection) []string {
+	if m.flagAmend || m.sourceContent != "" || m.sourcePDF {
+		return nil
+	}
+	return document.MissingNoteTopics(outline, m.preset.RequiredSections())
+}
+
+// noteSectionLabel describes a detected section, such as "Experience · 2 lines".
+func noteSectionLabel(section document.NoteSection) string {
+	switch section.Lines {
+	case 0:
+		return section.Heading + " · empty"
+	case 1:
+		return section.Heading + " · 1 line"
+	}
+	return fmt.Sprintf("%s · %d lines", section.Heading, section.Lines)
+}
+
+// renderNotesOutline draws the sections detected in the notes typed so
+// far, and the required sections they do not mention, as a sidebar box of
+// the given width.
+func renderNotesOutline(m Model, width int) string {
+	outline := document.OutlineNotes(m.stdinInput.Value())
+	textWidth := width - 6
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(highlightColor).Render("📋 Outline") + "\n\n")
+	if len(outline) == 0 {
+		b.WriteString(italicStyle.Render(layout.Wrap(`Start a line with a heading such as "Experience:" to see it here.`, textWidth)))
+	}
+	for i, section := range outline {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		marker := "✓ "
+		if section.Topic == "" || section.Lines == 0 {
+			marker = "• "
+		}
+		b.WriteString(layout.Wrap(marker+noteSectionLabel(section), textWidth))
+	}
+
+	if missing := missingNoteTopics(m, outline); len(missing) > 0 {
+		b.WriteString("\n\n" + lipgloss.NewStyle().Foreground(accentColor).Render("Not mentioned yet:"))
+		for _, topic := range missing {
+			b.WriteString("\n○ " + topic)
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(1, 2).
+		Width(width - 2).
+		Render(b.String())
+}
+
+// renderNotesOutlineLine summarizes the notes outline on one wrapped line,
+// shown below the textarea when there is no room for the sidebar.
+func renderNotesOutlineLine(m Model, width int) string {
+	outline := document.OutlineNotes(m.stdinInput.Value())
+	if len(outline) == 0 {
+		return ""
+	}
+
+	headings := make([]string, len(outline))
+	for i, section := range outline {
+		headings[i] = section.Heading
+	}
+	line := "📋 Outline: " + strings.Join(headings, " · ")
+	if missing := missingNoteTopics(m, outline); len(missing) > 0 {
+		line += "\nNot mentioned yet: " + strings.Join(missing, ", ")
+	}
+	return lipgloss.NewStyle().Foreground(subtleColor).Render(layout.Wrap(line, width))
+}
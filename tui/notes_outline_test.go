@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+func TestNotesOutline(t *testing.T) {
+	m := NewModel()
+	m.state = stateInputStdin
+	m.stdinInput.SetValue("Work Experience:\n- Engineer at Acme\n\nSkills: Go, SQL")
+
+	// Test case 1: A wide terminal shows the sections and the missing ones in a sidebar
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 60})
+	m = updated.(Model)
+	view := renderStdinInputView(m)
+	for _, element := range []string{"📋 Outline", "✓ Work Experience · 1 line", "✓ Skills · 1 line", "Not mentioned yet:", "○ Education"} {
+		if !strings.Contains(view, element) {
+			t.Errorf("Input view should contain %q", element)
+		}
+	}
+
+	// Test case 2: A narrow terminal summarizes the outline below the textarea
+	m.width = 80
+	view = renderStdinInputView(m)
+	if !strings.Contains(view, "Outline: Work Experience · Skills") || !strings.Contains(view, "Not mentioned yet: Education") || strings.Contains(view, "○") {
+		t.Errorf("Expected the outline on one line, got %q", view)
+	}
+
+	// Test case 3: The preset's sections are suggested, and none with a source resume
+	m.preset = prompt.PresetNewGrad
+	if view := renderNotesOutline(m, notesOutlineWidth); !strings.Contains(view, "○ Projects") {
+		t.Error("Expected the new-grad preset to suggest Projects")
+	}
+	m.sourceContent = "# Jane Doe"
+	if view := renderNotesOutline(m, notesOutlineWidth); strings.Contains(view, "Not mentioned yet") {
+		t.Error("Expected no missing sections with a source resume")
+	}
+
+	// Test case 4: Notes without headings explain how to add them
+	m.stdinInput.SetValue("I built things")
+	if view := renderNotesOutline(m, notesOutlineWidth); !strings.Contains(view, "Experience:") || renderNotesOutlineLine(m, 60) != "" {
+		t.Error("Expected a hint and no outline line for notes without headings")
+	}
+}
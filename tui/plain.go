@@ -0,0 +1,464 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/logging"
+	"github.com/phrazzld/resumake/output"
+)
+
+// plainNotesEnd is the line that finishes the notes in plain mode.
+const plainNotesEnd = "."
+
+// RunPlain generates a resume with a series of line prompts instead of the
+// full-screen interface, for terminals where it misbehaves and for screen
+// readers. It asks for the same inputs in the same order, generates with
+// the same options, and prints the results as plain text without colors,
+// boxes, or emoji. The follow-up actions on the success screen are not
+// offered.
+//
+// Parameters:
+//   - m: The model configured from the command-line flags
+//   - editor: The line editor the answers are read with
+//   - out: Where prompts and results are written
+//
+// Returns:
+//   - error: Why no resume was saved, or nil on success
+//
+// Example:
+//
+//	err := tui.RunPlain(model, input.NewLineEditor(os.Stdin, os.Stdout), os.Stdout)
+func RunPlain(m Model, editor *input.LineEditor, out io.Writer) error {
+	fmt.Fprintln(out, "Resumake plain mode. Press Ctrl+C at any prompt to quit.")
+
+	// Replayed responses never reach the API, so they need no key
+	if m.fixtures.Mode != api.FixtureReplay {
+		diagnosis := checkAPIKey()
+		if m.verifyKey && diagnosis.Usable() {
+			fmt.Fprintln(out, "Checking your API key...")
+			diagnosis = VerifyAPIKeyCmd(m.ctx)().(APIKeyCheckedMsg).Diagnosis
+			if diagnosis.Status == api.KeyUnreachable {
+				fmt.Fprintln(out, diagnosis)
+			}
+		}
+		if !diagnosis.Usable() {
+			return fmt.Errorf("API key error: %s", diagnosis)
+		}
+	}
+
+	m, err := plainSource(m, editor, out)
+	if err != nil {
+		return err
+	}
+
+	if m.stdinContent, err = plainNotes(m, editor, out); err != nil {
+		return err
+	}
+	if m.stdinContent == "" && m.sourceContent == "" && !m.sourcePDF {
+		return errors.New("nothing to generate from; give an existing resume or type some notes")
+	}
+
+	if ok, err := plainConfirmGeneration(m, editor, out); err != nil || !ok {
+		if err == nil {
+			err = errors.New("generation cancelled; nothing was sent")
+		}
+		return err
+	}
+
+	if m, err = initializeAPIClient(m); err != nil {
+		return err
+	}
+	defer func() { cleanupAPIClient(m) }()
+
+	fmt.Fprintln(out, "\nGenerating your resume. This usually takes under a minute.")
+	opts := generateOptions(m)
+	msg := GenerateResumeWithOptionsCmd(m.ctx, m.apiClient, m.apiModel, m.sourceContent, m.stdinContent, opts)()
+
+	// A failed write keeps the resume, so another path can be tried
+	for {
+		switch result := msg.(type) {
+		case APIResultMsg:
+			if !result.Success {
+				return result.Error
+			}
+			m = rememberUpload(m, result.UploadedFile)
+			if result.PendingJSON != nil {
+				if result.JSONPath, err = plainFixJSON(result.PendingJSON, result.OutputPath, editor, out); err != nil {
+					return err
+				}
+				if result.JSONPath != "" {
+					result.JSONWarnings = result.PendingJSON.Warnings()
+				}
+			}
+			m.resultContent = result.Content
+			m.previousRevision = result.Previous
+			fmt.Fprint(out, plainResult(m, result))
+
+			// A resume in another language than the inputs can be generated again
+			if result.LanguageWarning == "" {
+				return nil
+			}
+			again, err := plainYesNo(editor, "Generate it again? (y/N): ", false)
+			if err != nil || !again {
+				// The resume is saved either way
+				return nil
+			}
+			fmt.Fprintln(out, "\nGenerating your resume again.")
+			msg = GenerateResumeWithOptionsCmd(m.ctx, m.apiClient, m.apiModel, m.sourceContent, m.stdinContent, opts)()
+
+		case ReviewReadyMsg:
+			m = rememberUpload(m, result.Result.UploadedFile)
+			if result.Result.Content, err = plainReview(m, result, editor, out); err != nil {
+				return fmt.Errorf("the resume was not saved: %w", err)
+			}
+			msg = SaveResumeCmd(result.Result, result.CoverLetter, result.Path, opts)()
+
+		case SaveFailedMsg:
+			m = rememberUpload(m, result.Result.UploadedFile)
+			fmt.Fprintf(out, "\nThe resume was generated but could not be saved: %v\n", result.Error)
+			path, err := editor.ReadLine("Save it to another path instead: ")
+			if err != nil {
+				return fmt.Errorf("the resume was not saved: %w", result.Error)
+			}
+			msg = SaveResumeCmd(result.Result, result.CoverLetter, strings.TrimSpace(path), opts)()
+
+		default:
+			return fmt.Errorf("unexpected generation result %T", msg)
+		}
+	}
+}
+
+// plainSource reads the existing resume given with -source or, without one,
+// asks for its path. An unreadable path typed at the prompt is asked for
+// again; an unreadable -source path is an error, as it is in the full-screen
+// interface.
+func plainSource(m Model, editor *input.LineEditor, out io.Writer) (Model, error) {
+	path := m.sourcePathInput.Value()
+	fromFlag := path != ""
+
+	for {
+		if path == "" {
+			line, err := editor.ReadLine("\nPath to an existing resume (press Enter to skip): ")
+			if err != nil && err != io.EOF {
+				return m, err
+			}
+			if path = input.ExpandPath(strings.TrimSpace(line)); path == "" {
+				return m, nil
+			}
+		}
+
+		result, _ := ReadSourceFileCmd(path)().(FileReadResultMsg)
+		if result.Success {
+			m.sourcePathInput.SetValue(path)
+			m.sourceContent = result.Content
+			m.sourcePDF = result.PDF
+			m = withStructure(m, result.Structure)
+			if result.Warning != "" {
+				fmt.Fprintln(out, "Warning: "+result.Warning)
+			}
+			return m, nil
+		}
+
+		if fromFlag {
+			return m, result.Error
+		}
+		fmt.Fprintf(out, "Could not read %s: %v\n", path, result.Error)
+		path = ""
+	}
+}
+
+// plainNotes asks for the notes and reads them one line at a time until a
+// line holding only plainNotesEnd, or the end of the input.
+func plainNotes(m Model, editor *input.LineEditor, out io.Writer) (string, error) {
+	if m.flagAmend {
+		fmt.Fprintln(out, "\nDescribe what has changed since your last resume: new roles, projects, skills, or results.")
+	} else {
+		fmt.Fprintln(out, "\nDescribe your experience, projects, skills, and education in your own words.")
+	}
+	fmt.Fprintf(out, "Finish with a line holding only a period (%s), or press Ctrl+D on an empty line.\n", plainNotesEnd)
+	return plainLines(editor)
+}
+
+// plainLines reads lines until one holding only plainNotesEnd, or the end
+// of the input, and returns them as one trimmed text.
+func plainLines(editor *input.LineEditor) (string, error) {
+	var lines []string
+	for {
+		line, err := editor.ReadLine("> ")
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(line) == plainNotesEnd {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+// plainReview shows the generated resume one section at a time and asks
+// whether to keep, regenerate, edit, or leave out each, as the review
+// screen does, then returns the resume assembled from the sections kept.
+func plainReview(m Model, ready ReviewReadyMsg, editor *input.LineEditor, out io.Writer) (string, error) {
+	review := newSectionReview(ready.Result.Content)
+	if len(review.sections) == 0 {
+		return ready.Result.Content, nil
+	}
+
+	fmt.Fprintln(out, "\nReview your resume one section at a time. Only the sections you keep are saved.")
+	for !review.done() {
+		section := review.current()
+		fmt.Fprintf(out, "\nSection %d of %d: %s\n\n%s\n\n", review.index+1, len(review.sections), section.Heading, section.Body)
+		if placeholders := review.placeholders(); len(placeholders) > 0 {
+			fmt.Fprintf(out, "Placeholders to replace: %s\n", strings.Join(placeholders, ", "))
+		}
+		question := "Keep it? (Y)es, (n)o, (r)egenerate, or (e)dit: "
+		weak := weakBullets(review)
+		if len(weak) > 0 {
+			fmt.Fprintln(out, "Weak bullets:")
+			for i, bullet := range weak {
+				fmt.Fprintf(out, "%d. %s (%s)\n", i+1, bullet.Text, describeWeakBullet(bullet))
+			}
+			question = "Keep it? (Y)es, (n)o, (r)egenerate, (e)dit, or a bullet's number to strengthen it: "
+		}
+
+		answer, err := editor.ReadLine(question)
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(weak) {
+			fmt.Fprintf(out, "Strengthening bullet %d...\n", n)
+			bullet, err := rewriteBullet(m.ctx, ready.Result.Session, section.Heading, weak[n-1], generateOptions(m))
+			if err != nil {
+				fmt.Fprintf(out, "The bullet could not be strengthened: %v\n", err)
+				continue
+			}
+			review.sections[review.index].Body, _ = output.ReplaceBullet(section.Body, weak[n-1].Text, bullet)
+			continue
+		}
+		switch answer {
+		case "", "y", "yes":
+			if placeholders := review.placeholders(); len(placeholders) > 0 {
+				fmt.Fprintf(out, "Replace %s before keeping the %s: edit or regenerate it, or answer n to leave it out.\n", strings.Join(placeholders, ", "), reviewedName(review))
+				continue
+			}
+			review.accepted[review.index] = true
+			review.index++
+		case "n", "no":
+			review.accepted[review.index] = false
+			review.index++
+		case "r", "regenerate":
+			if review.onHeader() {
+				fmt.Fprintln(out, "The title and contact details are not regenerated. Edit them instead.")
+				continue
+			}
+			fmt.Fprintln(out, "Regenerating the section...")
+			revised, err := rewriteSection(m.ctx, ready.Result.Session, section.Heading, section.Body, "", generateOptions(m))
+			if err != nil {
+				fmt.Fprintf(out, "The section could not be regenerated: %v\n", err)
+				continue
+			}
+			if parsed := document.Parse(revised).Sections; len(parsed) > 0 {
+				review.sections[review.index].Body = parsed[0].Body
+			}
+		case "e", "edit":
+			what := "the section without its heading"
+			if review.onHeader() {
+				what = "the title and contact details"
+			}
+			fmt.Fprintf(out, "Type %s. Finish with a line holding only a period (%s), or press Ctrl+D on an empty line.\n", what, plainNotesEnd)
+			body, err := plainLines(editor)
+			if err != nil {
+				return "", err
+			}
+			review.sections[review.index].Body = body
+		default:
+			fmt.Fprintln(out, "Answer y, n, r, or e.")
+		}
+
+		if review.done() && len(review.decidedHeadings(true)) == 0 {
+			fmt.Fprintln(out, "\nAccept at least one section to save the resume.")
+			review.index = len(review.sections) - 1
+		}
+	}
+	return review.assemble(), nil
+}
+
+// plainConfirmGeneration lists what will be sent before the first request
+// and asks for consent, or otherwise asks to go ahead, and then asks before
+// replacing a resume from an earlier run.
+func plainConfirmGeneration(m Model, editor *input.LineEditor, out io.Writer) (bool, error) {
+	if m.consentRequired && m.fixtures.Mode != api.FixtureReplay {
+		fmt.Fprintf(out, "\nGenerating your resume sends the following to Google's Gemini API (%s). "+
+			"Google processes it under the Gemini API terms, so leave out anything you may not share.\n", consentModels(m))
+		for _, item := range consentSummary(m) {
+			fmt.Fprintln(out, "- "+plainText(item))
+		}
+		if m.jobDescription != "" {
+			fmt.Fprintln(out, jobDescriptionNote(m))
+		}
+
+		ok, err := plainYesNo(editor, "Agree and generate? (y/N): ", false)
+		if err != nil || !ok {
+			return false, err
+		}
+		// Consent that cannot be saved still holds for this run
+		if err := config.RecordConsent(time.Now()); err != nil {
+			logging.Debugf("Could not remember consent: %v", err)
+		}
+	} else {
+		ok, err := plainYesNo(editor, "\nGenerate the resume now? (Y/n): ", true)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if path := existingOutputPath(m); path != "" {
+		return plainYesNo(editor, fmt.Sprintf("%s already exists. Replace it? (y/N): ", path), false)
+	}
+	return true, nil
+}
+
+// plainYesNo asks a yes or no question; an empty answer or the end of the
+// input gives the default.
+func plainYesNo(editor *input.LineEditor, question string, defaultYes bool) (bool, error) {
+	answer, err := editor.ReadLine(question)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	case "":
+		return defaultYes, nil
+	}
+	return false, nil
+}
+
+// plainFixJSON asks for a corrected value for each part of the JSON Resume
+// export that breaks the schema, fixing resume in place, then writes it.
+// Ctrl+D skips the export; the Markdown resume is already saved.
+func plainFixJSON(resume *document.JSONResume, outputPath string, editor *input.LineEditor, out io.Writer) (string, error) {
+	fmt.Fprintln(out, "\nThe JSON Resume export breaks the schema. Enter a corrected value for each problem, or press Ctrl+D to skip the export.")
+
+	for violations := resume.Validate(); len(violations) > 0; violations = resume.Validate() {
+		violation := violations[0]
+		fmt.Fprintf(out, "%s: %s (currently %q)\n", violation.Field, violation.Message, violation.Value)
+
+		value, err := editor.ReadLine("Corrected value: ")
+		if err == io.EOF {
+			fmt.Fprintln(out, "Skipped the JSON Resume export.")
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		violation.Fix(resume, strings.TrimSpace(value))
+	}
+	return output.WriteJSONResume(*resume, outputPath)
+}
+
+// plainResult describes a saved resume as the success screen does, one fact
+// per line.
+func plainResult(m Model, result APIResultMsg) string {
+	var b strings.Builder
+	if result.OutputPath == output.StdoutPath {
+		b.WriteString("\nYour resume was written to standard output\n")
+	} else {
+		fmt.Fprintf(&b, "\nYour resume is saved at %s\n", result.OutputPath)
+	}
+	if result.CoverLetterPath != "" {
+		fmt.Fprintf(&b, "Your cover letter is saved at %s\n", result.CoverLetterPath)
+	}
+	if result.HTMLPath != "" {
+		fmt.Fprintf(&b, "The HTML version (%s layout) is saved at %s\n", m.flagLayout, result.HTMLPath)
+	}
+	if result.JSONPath != "" {
+		fmt.Fprintf(&b, "The JSON Resume export is saved at %s\n", result.JSONPath)
+		if note := jsonWarningsNote(result.JSONWarnings); note != "" {
+			fmt.Fprintf(&b, "%s\n", note)
+		}
+	}
+	for _, export := range result.Exports {
+		fmt.Fprintf(&b, "The %s export is saved at %s\n", strings.ToUpper(string(export.Format)), export.Path)
+		if export.Note != "" {
+			b.WriteString(export.Note + "\n")
+		}
+	}
+	if result.NotesPath != "" {
+		fmt.Fprintf(&b, "The notes on what changed and why are saved at %s\n", result.NotesPath)
+	}
+	if result.GapsPath != "" {
+		fmt.Fprintf(&b, "Courses, certifications, and projects to close the gaps with the job are saved at %s\n", result.GapsPath)
+	}
+	for _, document := range result.Documents {
+		fmt.Fprintf(&b, "Your %s is saved at %s\n", document.Type.Title(), document.Path)
+	}
+	if result.PackPath != "" {
+		fmt.Fprintf(&b, "Everything for this application is zipped at %s\n", result.PackPath)
+	}
+
+	var notes []string
+	if result.ModelName != "" && result.ModelName != api.DefaultModelName {
+		notes = append(notes, fmt.Sprintf("Written by the fallback model, %s.", result.ModelName))
+	}
+	if result.TruncatedMsg != "" {
+		notes = append(notes, result.TruncatedMsg)
+	}
+	if len(result.TrimmedInputs) > 0 {
+		notes = append(notes, "Trimmed to fit the context window: "+strings.Join(result.TrimmedInputs, "; "))
+	}
+	if name := result.Company.Name; name != "" {
+		notes = append(notes, "Tailored to "+name)
+	}
+	for _, note := range []string{result.CompanyNote, result.ExplanationNote, result.GapsNote, result.DocumentsNote} {
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	if len(result.MissingKeywords) > 0 {
+		notes = append(notes, "Not included, since your inputs do not show them: "+strings.Join(result.MissingKeywords, ", "))
+	}
+	notes = append(notes, result.HeadingIssues...)
+	if note := groundingNote(m.flagGrounding, result.Uncited); note != "" {
+		notes = append(notes, plainText(note))
+	}
+	if result.LanguageWarning != "" {
+		notes = append(notes, result.LanguageWarning)
+	}
+	if m.previousRevision != nil {
+		notes = append(notes, plainText(revisionSummary(m)))
+	}
+	if len(notes) > 0 {
+		b.WriteString("\n" + strings.Join(notes, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// plainText drops the emoji that starts a line written for the full-screen
+// interface, which screen readers would otherwise read out.
+func plainText(line string) string {
+	if first, rest, ok := strings.Cut(line, " "); ok && !strings.ContainsFunc(first, isWordRune) {
+		return rest
+	}
+	return line
+}
+
+// isWordRune reports whether r can start a word, as opposed to an emoji or symbol.
+func isWordRune(r rune) bool {
+	return r < 0x2000 && r != 0xfe0f
+}
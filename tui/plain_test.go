@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+func TestRunPlain(t *testing.T) {
+	dir := t.TempDir()
+	resume := api.ResumeStartDelimiter + "\n# Jane Doe\n\n## Experience\n\n- Acme\n" + api.ResumeEndDelimiter
+
+	// Record the response to the prompt built from the typed notes
+	chat := &fakeChatSender{replies: []*genai.GenerateContentResponse{{
+		Candidates: []*genai.Candidate{{
+			Content:      &genai.Content{Parts: []genai.Part{genai.Text(resume)}},
+			FinishReason: genai.FinishReasonStop,
+		}},
+	}}}
+	recorder := api.Fixtures{Mode: api.FixtureRecord, Dir: dir}.WrapSender(chat, api.DefaultModelName)
+	if _, err := api.NewSessionWithSender(recorder).Send(context.Background(), prompt.GeneratePromptContent("", "Built things at Acme")); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "resume.md")
+	m := NewModel().
+		WithOutputPath(outputPath).
+		WithFixtures(api.Fixtures{Mode: api.FixtureReplay, Dir: dir})
+
+	// Test case 1: The answers are read line by line and the resume is saved
+	var out strings.Builder
+	editor := input.NewLineEditor(strings.NewReader("\nBuilt things at Acme\n.\n\n"), &out)
+	if err := RunPlain(m, editor, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if content, err := os.ReadFile(outputPath); err != nil || !strings.Contains(string(content), "# Jane Doe") {
+		t.Errorf("Expected the resume to be saved, got %q (%v)", content, err)
+	}
+	for _, want := range []string{"Path to an existing resume", "Describe your experience", "Your resume is saved at " + outputPath} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the output to contain %q, got %q", want, out.String())
+		}
+	}
+
+	// Test case 2: Declining sends nothing
+	editor = input.NewLineEditor(strings.NewReader("\nBuilt things at Acme\n.\nn\n"), &out)
+	if err := RunPlain(m, editor, &out); err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("Expected generation to be cancelled, got %v", err)
+	}
+
+	// Test case 3: Without a resume or notes there is nothing to generate from
+	editor = input.NewLineEditor(strings.NewReader("\n.\n"), &out)
+	if err := RunPlain(m, editor, &out); err == nil {
+		t.Error("Expected an error without inputs")
+	}
+}
+
+func TestPlainSourceRetriesUnreadablePath(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "resume.md")
+	if err := os.WriteFile(source, []byte("# Jane Doe"), 0600); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	var out strings.Builder
+	editor := input.NewLineEditor(strings.NewReader(filepath.Join(t.TempDir(), "missing.md")+"\n"+source+"\n"), &out)
+	m, err := plainSource(NewModel(), editor, &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if m.sourceContent != "# Jane Doe" || m.sourcePathInput.Value() != source {
+		t.Errorf("Expected the second path to be read, got %q from %q", m.sourceContent, m.sourcePathInput.Value())
+	}
+	if !strings.Contains(out.String(), "Could not read") {
+		t.Errorf("Expected the unreadable path to be reported, got %q", out.String())
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := map[string]string{
+		"📄 Your existing resume":     "Your existing resume",
+		"✏️ The notes you typed":     "The notes you typed",
+		"Trimmed to fit the context": "Trimmed to fit the context",
+	}
+	for line, want := range tests {
+		if got := plainText(line); got != want {
+			t.Errorf("plainText(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
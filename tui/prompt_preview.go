@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/pkg/resume"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// promptPreviewHeight is the number of prompt lines shown at once.
+const promptPreviewHeight = 12
+
+// composedPrompt returns the exact text sent to the API for the current
+// inputs: the system instructions, then each part of the prompt after the
+// inputs are trimmed to fit the context window.
+func composedPrompt(m Model) (string, error) {
+	opts := generateOptions(m)
+	fitted, err := fitPrompt(m.sourceContent, m.stdinContent, opts)
+	if err != nil {
+		return "", err
+	}
+
+	// An uploaded source is shown as an attachment; nothing is uploaded here
+	source, notes, _ := resume.NumberInputs(fitted.SourceContent, fitted.StdinContent, opts.Grounding)
+	content := prompt.GeneratePromptContent(source, notes)
+	if uploadsSource(fitted.SourceContent, opts) {
+		content = prompt.GenerateUploadedPromptContent(genai.FileData{MIMEType: sourceMIMEType(opts)}, notes)
+	}
+	content = prompt.AddSkillsToContent(content, opts.Skills)
+	content = prompt.AddAcademicsToContent(content, opts.Academics)
+	content = prompt.AddEmphasisToContent(content, opts.Emphasize)
+	content = prompt.AddPresetToContent(content, opts.Preset)
+	content = prompt.AddAudienceToContent(content, opts.Audience)
+	content = prompt.AddStructureToContent(content, sourceHeadings(opts))
+	content = prompt.AddRelevanceToContent(content, relevantExperiences(fitted.SourceContent, fitted.StdinContent, opts))
+	if opts.Amend {
+		content = prompt.AddAmendmentToContent(content)
+	}
+	content = prompt.AddGroundingToContent(content, opts.Grounding)
+
+	return "SYSTEM INSTRUCTIONS:\n" + api.SystemInstructions + "\n\n" + contentText(content), nil
+}
+
+// togglePromptPreview expands or collapses the prompt preview on the confirm
+// screen. The prompt is composed when the preview opens, so it reflects any
+// skills edited since it was last shown.
+func togglePromptPreview(m Model) Model {
+	m.promptPreviewOpen = !m.promptPreviewOpen
+	if !m.promptPreviewOpen {
+		return m
+	}
+
+	text, err := composedPrompt(m)
+	if err != nil {
+		text = fmt.Sprintf("The prompt could not be composed: %v", err)
+	}
+	m.promptTokens = prompt.EstimateTokens(text)
+
+	width := getConstrainedWidth(m.width) - 12
+	m.promptViewport = viewport.New(width, promptPreviewHeight)
+	m.promptViewport.SetContent(lipgloss.NewStyle().Width(width).Render(text))
+	return m
+}
+
+// updatePromptPreview scrolls the open prompt preview with the arrow and
+// page keys.
+func updatePromptPreview(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.promptViewport, cmd = m.promptViewport.Update(msg)
+	return m, cmd
+}
+
+// renderPromptPreview renders the expanded prompt preview, or a hint on how
+// to expand it.
+func renderPromptPreview(m Model) string {
+	if !m.promptPreviewOpen {
+		return italicStyle.Render(layout.Wrap("Press P to preview the exact prompt sent to the API", getConstrainedWidth(m.width)-8))
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Render(fmt.Sprintf("🔎 Prompt Preview (about %d tokens)", m.promptTokens))
+
+	position := italicStyle.Render(fmt.Sprintf("%3.f%% · ↑/↓ scroll · P to hide", m.promptViewport.ScrollPercent()*100))
+	if m.flagCompany != "" {
+		position += "\n" + italicStyle.Render("Company research is added to the prompt when generating")
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(0, 2).
+		Width(getConstrainedWidth(m.width) - 4).
+		Render(title + "\n\n" + m.promptViewport.View() + "\n\n" + position)
+}
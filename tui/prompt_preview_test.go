@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+)
+
+func TestComposedPrompt(t *testing.T) {
+	model := createTestModelWithAllFields()
+	model.skills = []document.Skill{{Name: "Go", Years: 6}}
+	model.flagEmphasize = []string{"kubernetes"}
+	model.flagAmend = true
+
+	text, err := composedPrompt(model)
+	if err != nil {
+		t.Fatalf("composedPrompt() error = %v", err)
+	}
+
+	// The sections appear in the order they are sent
+	sections := []string{
+		"SYSTEM INSTRUCTIONS:\n" + api.SystemInstructions,
+		"EXISTING RESUME:\nSample source content",
+		"USER INPUT:\nSample stdin content",
+		"STRUCTURED SKILLS",
+		"KEYWORDS TO EMPHASIZE",
+		"UPDATE MODE",
+	}
+	last := -1
+	for _, section := range sections {
+		index := strings.Index(text, section)
+		if index <= last {
+			t.Fatalf("Expected %q after the previous section, got:\n%s", section, text)
+		}
+		last = index
+	}
+}
+
+func TestComposedPromptRelevance(t *testing.T) {
+	model := createTestModelWithAllFields()
+	model.sourceContent = "# Jane Doe\n\n- Organized the annual office holiday party\n- Built the Kubernetes deployment pipeline for payments"
+	model.stdinContent = "Wrote the onboarding guide for new support staff"
+
+	// Test case 1: Without a job description nothing is ranked
+	text, err := composedPrompt(model)
+	if err != nil {
+		t.Fatalf("composedPrompt() error = %v", err)
+	}
+	if strings.Contains(text, "MOST RELEVANT EXPERIENCE") {
+		t.Error("Expected no relevance section without a job description")
+	}
+
+	// Test case 2: The matching experience is featured, but the job description is not sent
+	model.jobDescription = "Platform engineer to own our Kubernetes deployment tooling"
+	text, err = composedPrompt(model)
+	if err != nil {
+		t.Fatalf("composedPrompt() error = %v", err)
+	}
+	if !strings.Contains(text, "MOST RELEVANT EXPERIENCE") || !strings.Contains(text, "- Built the Kubernetes deployment pipeline for payments") {
+		t.Errorf("Expected the Kubernetes experience to be featured, got:\n%s", text)
+	}
+	section := text[strings.Index(text, "MOST RELEVANT EXPERIENCE"):]
+	if strings.Contains(section, "holiday party") || strings.Contains(text, "Platform engineer") {
+		t.Errorf("Expected only matching experiences and no job description text, got:\n%s", text)
+	}
+	if view := renderConfirmGenerateView(model); !strings.Contains(view, "Job match") {
+		t.Error("Expected the confirm screen to mention the job match")
+	}
+}
+
+func TestPromptPreview(t *testing.T) {
+	model := createTestModelWithAllFields()
+	model.width = 100
+	model.stdinContent = strings.Repeat("Led the checkout rewrite.\n", 40)
+	press := func(m Model, msg tea.KeyMsg) Model {
+		updated, _ := m.Update(msg)
+		return updated.(Model)
+	}
+
+	// Test case 1: The preview is collapsed at first
+	if view := renderConfirmGenerateView(model); !strings.Contains(view, "Press P to preview") || strings.Contains(view, "SYSTEM INSTRUCTIONS") {
+		t.Error("Expected a collapsed preview with a hint")
+	}
+
+	// Test case 2: P expands the preview with the composed prompt
+	model = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !model.promptPreviewOpen || model.promptTokens == 0 {
+		t.Fatal("Expected P to open the preview")
+	}
+	if view := renderConfirmGenerateView(model); !strings.Contains(view, "Prompt Preview") || !strings.Contains(view, "SYSTEM INSTRUCTIONS") {
+		t.Error("Expected the preview to show the start of the prompt")
+	}
+
+	// Test case 3: The arrow keys scroll the preview
+	model = press(model, tea.KeyMsg{Type: tea.KeyDown})
+	if model.promptViewport.YOffset != 1 {
+		t.Errorf("Expected the preview to scroll down one line, got offset %d", model.promptViewport.YOffset)
+	}
+
+	// Test case 4: P collapses it again
+	model = press(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if model.promptPreviewOpen || model.state != stateConfirmGenerate {
+		t.Error("Expected P to collapse the preview and stay on the confirm screen")
+	}
+}
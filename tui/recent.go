@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/config"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/logging"
+)
+
+// recentOrder is the order the welcome screen lists the kinds of recent
+// files in, which the numbers that pick them follow.
+var recentOrder = map[config.RecentKind]int{
+	config.RecentSource:  0,
+	config.RecentProfile: 1,
+	config.RecentOutput:  2,
+}
+
+// recentHeadings are the headings the welcome screen lists each kind under.
+var recentHeadings = map[config.RecentKind]string{
+	config.RecentSource:  "Sources",
+	config.RecentProfile: "Profiles",
+	config.RecentOutput:  "Outputs",
+}
+
+// recentLoadedMsg carries the recently used files that still exist.
+type recentLoadedMsg struct {
+	files []config.RecentFile
+}
+
+// loadRecentCmd reads the recent files list at path. The list is a
+// shortcut, so a list that cannot be read only leaves it empty.
+func loadRecentCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := config.LoadRecent(path)
+		if err != nil {
+			logging.Debugf("Not listing recent files: %v", err)
+		}
+		return recentLoadedMsg{files: recentEntries(files)}
+	}
+}
+
+// recentEntries orders the recent files by kind for the welcome screen,
+// leaving out files that no longer exist and outputs already listed as a
+// profile's latest resume.
+func recentEntries(files []config.RecentFile) []config.RecentFile {
+	profilePaths := make(map[string]bool)
+	for _, file := range files {
+		if file.Kind == config.RecentProfile {
+			profilePaths[file.Path] = true
+		}
+	}
+
+	var entries []config.RecentFile
+	for _, file := range files {
+		if _, known := recentOrder[file.Kind]; !known || (file.Kind == config.RecentOutput && profilePaths[file.Path]) {
+			continue
+		}
+		if _, err := os.Stat(file.Path); err != nil {
+			continue
+		}
+		entries = append(entries, file)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return recentOrder[entries[i].Kind] < recentOrder[entries[j].Kind] })
+	return entries
+}
+
+// recordRecent adds the files a resume was generated from and written to to
+// the recent files list. The list is a shortcut, so failures are logged
+// rather than returned.
+func recordRecent(content, outputPath string, opts GenerateOptions) {
+	if opts.RecentPath == "" {
+		return
+	}
+
+	now := time.Now()
+	var files []config.RecentFile
+	if outputPath != "" {
+		outputPath = input.ResolvePath(outputPath)
+		files = append(files,
+			config.RecentFile{Kind: config.RecentProfile, Label: history.Profile(content), Path: outputPath, Used: now},
+			config.RecentFile{Kind: config.RecentOutput, Path: outputPath, Used: now})
+	}
+	if opts.SourcePath != "" {
+		files = append(files, config.RecentFile{Kind: config.RecentSource, Path: input.ResolvePath(opts.SourcePath), Used: now})
+	}
+	if err := config.RecordRecent(opts.RecentPath, files...); err != nil {
+		logging.Debugf("Could not record the recent files: %v", err)
+	}
+}
+
+// recentNumber returns the number of the recent file a key press picks, or
+// 0 when it does not pick one.
+func recentNumber(m Model, msg tea.KeyMsg) int {
+	if !key.Matches(msg, m.keyMap().Recent) {
+		return 0
+	}
+	n, err := strconv.Atoi(msg.String())
+	if err != nil || n < 1 || n > len(m.recent) {
+		return 0
+	}
+	return n
+}
+
+// useRecent sets the recent file numbered n on the welcome screen as the
+// source resume, so only the new notes have to be added.
+func useRecent(m Model, n int) Model {
+	path := m.recent[n-1].Path
+	m.flagSourcePath = path
+	m.sourcePathInput.SetValue(path)
+	return m
+}
+
+// renderRecent lists the recent files on the welcome screen, grouped by
+// kind and numbered for the keys that pick them, wrapped to width.
+func renderRecent(files []config.RecentFile, width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Start from a recent file:"))
+	for i, file := range files {
+		if i == 0 || files[i-1].Kind != file.Kind {
+			b.WriteString("\n\n" + tipStyle.Render(recentHeadings[file.Kind]))
+		}
+		line := fmt.Sprintf("%d. %s", i+1, filepath.Base(file.Path))
+		if file.Kind == config.RecentProfile {
+			line = fmt.Sprintf("%d. %s · %s", i+1, file.Label, filepath.Base(file.Path))
+		}
+		b.WriteString("\n" + layout.Wrap(line, width))
+	}
+	return b.String()
+}
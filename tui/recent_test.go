@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/config"
+)
+
+func TestRecentFiles(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", "AIza"+strings.Repeat("x", 35))
+
+	// old.md was the source of Jane's latest resume, jane-v1.md is an
+	// earlier one, and john.md has since been deleted
+	setup := func(t *testing.T) (Model, string, string) {
+		work := t.TempDir()
+		recentPath := filepath.Join(t.TempDir(), config.RecentFileName)
+		sourcePath := filepath.Join(work, "old.md")
+		firstPath := filepath.Join(work, "jane-v1.md")
+		latestPath := filepath.Join(work, "jane-v2.md")
+		for _, path := range []string{sourcePath, firstPath, latestPath} {
+			if err := os.WriteFile(path, []byte("# Jane Doe"), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", path, err)
+			}
+		}
+
+		opts := GenerateOptions{RecentPath: recentPath}
+		recordRecent("# John Roe", filepath.Join(work, "john.md"), opts)
+		recordRecent("# Jane Doe", firstPath, opts)
+		// The source path is typed with a variable, and recorded expanded
+		t.Setenv("RECENT_WORK", work)
+		opts.SourcePath = "${RECENT_WORK}/old.md"
+		recordRecent("# Jane Doe", latestPath, opts)
+
+		m := NewModel().WithRecentPath(recentPath)
+		m.apiKeyOk = true
+		updated, _ := m.Update(loadRecentCmd(recentPath)())
+		return updated.(Model), sourcePath, latestPath
+	}
+
+	t.Run("Lists each existing file once, grouped by kind", func(t *testing.T) {
+		m, sourcePath, latestPath := setup(t)
+		m.width = 100
+
+		// Test case 1: The source, Jane's latest resume, and her earlier one
+		if len(m.recent) != 3 {
+			t.Fatalf("Expected 3 recent files, got %+v", m.recent)
+		}
+		if m.recent[0].Kind != config.RecentSource || m.recent[0].Path != sourcePath {
+			t.Errorf("Expected the source first, got %+v", m.recent[0])
+		}
+		if m.recent[1].Kind != config.RecentProfile || m.recent[1].Label != "jane-doe" || m.recent[1].Path != latestPath {
+			t.Errorf("Expected Jane's latest resume second, got %+v", m.recent[1])
+		}
+		if m.recent[2].Kind != config.RecentOutput || filepath.Base(m.recent[2].Path) != "jane-v1.md" {
+			t.Errorf("Expected Jane's earlier resume third, got %+v", m.recent[2])
+		}
+
+		// Test case 2: The welcome screen numbers them
+		view := renderWelcomeView(m)
+		for _, want := range []string{"Sources", "1. old.md", "Profiles", "2. jane-doe · jane-v2.md", "Outputs", "3. jane-v1.md"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected the welcome screen to show %q, got:\n%s", want, view)
+			}
+		}
+	})
+
+	t.Run("A number starts from the file it lists", func(t *testing.T) {
+		m, _, latestPath := setup(t)
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+		m = updated.(Model)
+		if m.state != stateInputSourcePath || m.sourcePathInput.Value() != latestPath {
+			t.Errorf("Expected the source path step with %s, got state %v and %q", latestPath, m.state, m.sourcePathInput.Value())
+		}
+	})
+
+	t.Run("A number past the list is ignored", func(t *testing.T) {
+		m, _, _ := setup(t)
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")})
+		if m = updated.(Model); m.state != stateWelcome {
+			t.Errorf("Expected to stay on the welcome screen, got state %v", m.state)
+		}
+	})
+
+	t.Run("No list shows nothing", func(t *testing.T) {
+		updated, _ := NewModel().Update(loadRecentCmd(filepath.Join(t.TempDir(), config.RecentFileName))())
+		if m := updated.(Model); len(m.recent) != 0 || strings.Contains(renderWelcomeView(m), "recent file") {
+			t.Errorf("Expected no recent files, got %+v", m.recent)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resizeDebounce is how long the terminal size must stay the same before the
+// view is laid out for it. Dragging a window edge sends a burst of sizes, and
+// laying out for each of them makes the screen flicker.
+const resizeDebounce = 80 * time.Millisecond
+
+// windowResizedMsg is sent resizeDebounce after a terminal resize, carrying
+// the size and the resize it belongs to.
+type windowResizedMsg struct {
+	seq    int
+	width  int
+	height int
+}
+
+// handleWindowSize records a new terminal size. The first size is applied
+// right away so the first screen is laid out correctly; later sizes are
+// applied once no other resize follows within resizeDebounce.
+func handleWindowSize(m Model, msg tea.WindowSizeMsg) (Model, tea.Cmd) {
+	if m.width == 0 && m.height == 0 {
+		return applyWindowSize(m, msg.Width, msg.Height), nil
+	}
+
+	// A resize back to the current size cancels a pending one
+	m.resizeSeq++
+	if msg.Width == m.width && msg.Height == m.height {
+		return m, nil
+	}
+
+	seq := m.resizeSeq
+	return m, tea.Tick(resizeDebounce, func(time.Time) tea.Msg {
+		return windowResizedMsg{seq: seq, width: msg.Width, height: msg.Height}
+	})
+}
+
+// handleWindowResized applies a debounced size unless a later resize
+// superseded it.
+func handleWindowResized(m Model, msg windowResizedMsg) Model {
+	if msg.seq != m.resizeSeq {
+		return m
+	}
+	return applyWindowSize(m, msg.width, msg.height)
+}
+
+// applyWindowSize lays out the model for a terminal of the given size.
+func applyWindowSize(m Model, width, height int) Model {
+	m.width = width
+	m.height = height
+
+	// Update component dimensions with minimum widths to prevent text truncation
+	inputWidth := width - 20
+	if inputWidth < 60 {
+		inputWidth = 60
+	}
+
+	// Fixed textarea height to avoid pushing content out of view
+	// Keep as a constant 10 rows regardless of window height
+	textareaHeight := 10
+
+	m.sourcePathInput.Width = inputWidth
+	m.stdinInput.SetWidth(inputWidth)
+	m.stdinInput.SetHeight(textareaHeight)
+	return m
+}
+
+// minFitWidth is the narrowest layout fitWidth returns.
+const minFitWidth = 24
+
+// fitWidth returns the display width for a terminal width. It is the usual
+// constrained width, except on terminals narrower than that width's minimum,
+// where boxes as wide as the minimum would wrap and lose their borders.
+func fitWidth(width int) int {
+	constrained := getConstrainedWidth(width)
+	if width <= 0 || width >= constrained {
+		return constrained
+	}
+	if width < minFitWidth {
+		return minFitWidth
+	}
+	return width
+}
+
+// fitSections joins sections vertically, separated by blank lines, dropping
+// sections from the end until the result is at most maxHeight lines tall.
+// The first keep sections are always shown, and a maxHeight of zero or less
+// means the height is unknown, so every section is shown.
+func fitSections(sections []string, maxHeight, keep int) string {
+	join := func(sections []string) string {
+		parts := make([]string, 0, 2*len(sections))
+		for i, section := range sections {
+			if i > 0 {
+				parts = append(parts, "")
+			}
+			parts = append(parts, section)
+		}
+		return lipgloss.JoinVertical(lipgloss.Center, parts...)
+	}
+
+	view := join(sections)
+	for maxHeight > 0 && len(sections) > keep && lipgloss.Height(view) > maxHeight {
+		sections = sections[:len(sections)-1]
+		view = join(sections)
+	}
+	return view
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resize sends a window size to the model and delivers the debounced resize
+// it schedules, as the program would once the resize settles.
+func resize(t *testing.T, m Model, width, height int) Model {
+	t.Helper()
+	updated, cmd := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	m = updated.(Model)
+	if cmd != nil {
+		updated, _ = m.Update(cmd())
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestWindowSizeDebounce(t *testing.T) {
+	m := NewModel()
+
+	// The first size is applied at once
+	updated, cmd := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(Model)
+	if cmd != nil || m.width != 100 || m.height != 40 {
+		t.Fatalf("first size: width %d, height %d, command %v; want 100x40 applied without a command", m.width, m.height, cmd != nil)
+	}
+
+	// A burst of sizes is applied once, at its last size
+	var pending []tea.Cmd
+	for _, width := range []int{90, 80, 70} {
+		updated, cmd = m.Update(tea.WindowSizeMsg{Width: width, Height: 30})
+		m = updated.(Model)
+		if cmd == nil {
+			t.Fatalf("resize to %d returned no command", width)
+		}
+		pending = append(pending, cmd)
+	}
+	if m.width != 100 {
+		t.Errorf("width = %d before the resize settled, want 100", m.width)
+	}
+	for _, cmd := range pending {
+		updated, _ = m.Update(cmd())
+		m = updated.(Model)
+		if m.width != 100 && m.width != 70 {
+			t.Errorf("an earlier size of the burst was applied: width %d", m.width)
+		}
+	}
+	if m.width != 70 || m.height != 30 {
+		t.Errorf("size = %dx%d after the resize settled, want 70x30", m.width, m.height)
+	}
+
+	// Resizing back to the current size cancels a pending resize
+	updated, cmd = m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 70, Height: 30})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	if m = updated.(Model); m.width != 70 {
+		t.Errorf("width = %d after resizing back, want 70", m.width)
+	}
+}
+
+func TestGeneratingViewFitsTerminal(t *testing.T) {
+	m := NewModel()
+	m.state = stateGenerating
+	m.progressStep = "2/4"
+	m.progressMsg = "Sending request to Gemini AI..."
+
+	// Shrinking the terminal during generation lays the view out again
+	for _, size := range []struct{ width, height int }{{120, 50}, {60, 24}, {32, 16}} {
+		m = resize(t, m, size.width, size.height)
+		view := m.View()
+		for _, line := range strings.Split(view, "\n") {
+			if width := lipgloss.Width(line); width > size.width {
+				t.Errorf("%dx%d: line is %d cells wide: %q", size.width, size.height, width, line)
+				break
+			}
+		}
+		if height := lipgloss.Height(view); height > size.height {
+			t.Errorf("%dx%d: view is %d lines tall", size.width, size.height, height)
+		}
+		if !strings.Contains(view, "Sending request") {
+			t.Errorf("%dx%d: view is missing the progress message", size.width, size.height)
+		}
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	tests := []struct {
+		width    int
+		expected int
+	}{
+		{0, 40},
+		{200, 100},
+		{60, 60},
+		{30, 30},
+		{10, minFitWidth},
+	}
+	for _, tt := range tests {
+		if got := fitWidth(tt.width); got != tt.expected {
+			t.Errorf("fitWidth(%d) = %d, want %d", tt.width, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/phrazzld/resumake/analysis"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+)
+
+// Lines of the terminal the preview screen uses for its title, border, and
+// scroll position, and the height of the preview when the terminal's is unknown.
+const (
+	previewChromeHeight  = 12
+	defaultPreviewHeight = 20
+)
+
+// openResumePreview shows the generated resume with the model's renderer.
+// A pager takes over the terminal until it exits; other renderers are shown
+// in the preview screen.
+func openResumePreview(m Model) (Model, tea.Cmd) {
+	width := getConstrainedWidth(m.width) - 12
+	if pager, ok := m.renderer.(output.PagerRenderer); ok {
+		cmd, err := pager.Cmd(m.resultContent, width)
+		if err == nil {
+			return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+				return pagerClosedMsg{err: err}
+			})
+		}
+		return showResumePreview(m, output.StyledRenderer{}, fmt.Sprintf("The pager could not be started: %v", err)), nil
+	}
+
+	renderer := m.renderer
+	if renderer == nil {
+		renderer = output.StyledRenderer{}
+	}
+	return showResumePreview(m, renderer, ""), nil
+}
+
+// handlePagerClosed returns to the success screen when the pager exits, or
+// shows the resume in the preview screen if the pager failed.
+func handlePagerClosed(m Model, msg pagerClosedMsg) Model {
+	if msg.err == nil {
+		return m
+	}
+	return showResumePreview(m, output.StyledRenderer{}, fmt.Sprintf("The pager failed: %v", msg.err))
+}
+
+// showResumePreview renders the resume into the preview screen, noting why
+// it is shown there instead of in the pager when note is set.
+func showResumePreview(m Model, renderer output.PreviewRenderer, note string) Model {
+	width := getConstrainedWidth(m.width) - 12
+	height := defaultPreviewHeight
+	if m.height > 0 {
+		height = max(m.height-previewChromeHeight, 3)
+	}
+
+	text, err := renderer.Render(m.resultContent, width)
+	if err != nil {
+		text = m.resultContent
+		note = fmt.Sprintf("The resume could not be rendered: %v", err)
+	}
+	text, m.previewToneIssues = annotateTone(text, width)
+
+	m.previewViewport = viewport.New(width, height)
+	m.previewViewport.SetContent(text)
+	m.previewNote = note
+	m.previewBack = m.state
+	m.state = stateResumePreview
+	return m
+}
+
+// annotateTone checks the tone of a rendered resume and adds a warning with
+// a professional alternative under each line with a flagged phrase. The
+// rendered text is checked, rather than the Markdown, so each warning lands
+// under the line it is about however the renderer wrapped the resume.
+func annotateTone(text string, width int) (string, int) {
+	issues := analysis.CheckTone(ansi.Strip(text))
+	if len(issues) == 0 {
+		return text, 0
+	}
+
+	warningStyle := lipgloss.NewStyle().Foreground(accentColor)
+	lines := strings.Split(text, "\n")
+	var b strings.Builder
+	next := 0
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(line)
+		for ; next < len(issues) && issues[next].Line == i+1; next++ {
+			issue := issues[next]
+			warning := fmt.Sprintf("⚠️ %s: %q; try %s", issue.Kind, issue.Text, issue.Suggestion)
+			b.WriteString("\n" + warningStyle.Render(layout.Wrap(warning, width)))
+		}
+	}
+	return b.String(), len(issues)
+}
+
+// updateResumePreview scrolls the preview, and returns to the screen it was
+// opened from, the success screen or the workspace dashboard, on Enter or 'v'.
+func updateResumePreview(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := m.keyMap()
+	if key.Matches(msg, keys.Accept, keys.View) {
+		m.state = m.previewBack
+		m.previewNote = ""
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.previewViewport, cmd = m.previewViewport.Update(msg)
+	return m, cmd
+}
+
+// renderResumePreviewView draws the rendered resume in a scrollable box.
+func renderResumePreviewView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+	title := titleStyle.Render("📄 Resume Preview")
+
+	status := fmt.Sprintf("%3.f%% · ↑/↓ scroll · Enter or V to go back", m.previewViewport.ScrollPercent()*100)
+	if m.previewToneIssues == 1 {
+		status = "1 tone warning · " + status
+	} else if m.previewToneIssues > 1 {
+		status = fmt.Sprintf("%d tone warnings · %s", m.previewToneIssues, status)
+	}
+	position := italicStyle.Render(layout.Wrap(status, displayWidth))
+	if m.previewNote != "" {
+		position = lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render(layout.Wrap("⚠️ "+m.previewNote, displayWidth-4)) + "\n" + position
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(0, 2).
+		Width(displayWidth - 4).
+		Render(m.previewViewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Center, title, "", box, "", position)
+}
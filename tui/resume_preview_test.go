@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/output"
+)
+
+const previewResume = "# Jane Doe\n\n## Experience\n\n- Led the **checkout** rewrite"
+
+func TestResumePreviewStateTransitions(t *testing.T) {
+	model := NewModel().WithRenderer(output.PlainRenderer{})
+	model.state = stateResultSuccess
+	model.resultContent = previewResume
+	keyV := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")}
+
+	// Test case 1: 'v' renders the resume into the preview screen
+	updated, _ := model.Update(keyV)
+	m := updated.(Model)
+	if m.state != stateResumePreview {
+		t.Fatalf("Expected state to be stateResumePreview, got %v", m.state)
+	}
+	view := renderResumePreviewView(m)
+	for _, element := range []string{"Resume Preview", "EXPERIENCE", "- Led the checkout rewrite"} {
+		if !strings.Contains(view, element) {
+			t.Errorf("Preview should contain %q", element)
+		}
+	}
+
+	// Test case 2: 'v' returns to the success view
+	updated, _ = m.Update(keyV)
+	if updated.(Model).state != stateResultSuccess {
+		t.Errorf("Expected state to be stateResultSuccess, got %v", updated.(Model).state)
+	}
+}
+
+func TestResumePreviewPager(t *testing.T) {
+	model := NewModel()
+	model.state = stateResultSuccess
+	model.resultContent = previewResume
+	model.width = 100
+
+	// Test case 1: A pager takes over the terminal instead of the preview screen
+	m, cmd := openResumePreview(model.WithRenderer(output.PagerRenderer{Command: []string{"cat"}}))
+	if cmd == nil || m.state != stateResultSuccess {
+		t.Errorf("Expected a command running the pager from the success screen, got state %v", m.state)
+	}
+
+	// Test case 2: A pager that is not installed falls back to the preview screen
+	m, cmd = openResumePreview(model.WithRenderer(output.PagerRenderer{Command: []string{"resumake-missing-pager"}}))
+	if cmd != nil || m.state != stateResumePreview || !strings.Contains(m.previewNote, "resumake-missing-pager") {
+		t.Errorf("Expected the preview screen with a note, got state %v and note %q", m.state, m.previewNote)
+	}
+
+	// Test case 3: A pager that fails also falls back to the preview screen
+	updated, _ := model.Update(pagerClosedMsg{err: errors.New("exit status 2")})
+	if m = updated.(Model); m.state != stateResumePreview || !strings.Contains(renderResumePreviewView(m), "exit status 2") {
+		t.Errorf("Expected the preview screen to explain the pager failure, got state %v", m.state)
+	}
+
+	// Test case 4: A pager that exits normally returns to the success screen
+	updated, _ = model.Update(pagerClosedMsg{})
+	if updated.(Model).state != stateResultSuccess {
+		t.Errorf("Expected state to be stateResultSuccess, got %v", updated.(Model).state)
+	}
+}
+
+func TestResumePreviewTone(t *testing.T) {
+	model := NewModel().WithRenderer(output.PlainRenderer{})
+	model.state = stateResultSuccess
+	model.resultContent = "# Jane Doe\n\n## Experience\n\n- Shipped a lot of features\n- Led the checkout rewrite"
+
+	// Test case 1: A flagged phrase gets a warning under its line
+	m, _ := openResumePreview(model)
+	view := m.previewViewport.View()
+	wantOrder := []string{"- Shipped a lot of features", `informal: "a lot of"`, "- Led the checkout rewrite"}
+	last := -1
+	for _, want := range wantOrder {
+		index := strings.Index(view, want)
+		if index <= last {
+			t.Fatalf("Expected %q after the previous line in the preview, got:\n%s", want, view)
+		}
+		last = index
+	}
+	if !strings.Contains(renderResumePreviewView(m), "1 tone warning ·") {
+		t.Error("Expected the preview to count the tone warnings")
+	}
+
+	// Test case 2: A professional resume has no warnings
+	model.resultContent = previewResume
+	m, _ = openResumePreview(model)
+	if m.previewToneIssues != 0 || strings.Contains(renderResumePreviewView(m), "tone warning") {
+		t.Errorf("Expected no tone warnings, got %d", m.previewToneIssues)
+	}
+}
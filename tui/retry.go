@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/api"
+)
+
+// maxAutoRetries is how many times in a row a rate-limited generation is
+// retried automatically before the error screen waits for the user.
+const maxAutoRetries = 3
+
+// maxAutoRetryWait is the longest wait counted down on the error screen.
+// Longer waits, such as for a daily quota, are shown as advice instead.
+const maxAutoRetryWait = 10 * time.Minute
+
+// retryTickMsg is sent every second while a retry counts down, carrying the
+// countdown it belongs to.
+type retryTickMsg struct {
+	seq int
+}
+
+// retryTickCmd returns a command that sends the next tick of a countdown.
+func retryTickCmd(seq int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return retryTickMsg{seq: seq}
+	})
+}
+
+// scheduleRetry starts counting down to an automatic retry when a
+// generation failed with an error that says how long to wait, as rate limit
+// errors usually do. Nothing is scheduled for other errors, for waits longer
+// than maxAutoRetryWait, or after maxAutoRetries retries in a row.
+func scheduleRetry(m Model, err error, now time.Time) (Model, tea.Cmd) {
+	m.retryAt = time.Time{}
+	apiErr := api.ClassifyError(err)
+	if !m.generateAttempted || apiErr == nil || !apiErr.Retryable || apiErr.RetryAfter <= 0 {
+		return m, nil
+	}
+	if apiErr.RetryAfter > maxAutoRetryWait || m.autoRetries >= maxAutoRetries {
+		return m, nil
+	}
+
+	m.retryAt = now.Add(apiErr.RetryAfter)
+	m.retrySeq++
+	return m, retryTickCmd(m.retrySeq)
+}
+
+// handleRetryTick counts down to an automatic retry and generates the resume
+// again once the wait is over. Ticks of a stopped countdown are ignored, and
+// the retry waits while a confirmation dialog is open.
+func handleRetryTick(m Model, msg retryTickMsg, now time.Time) (Model, tea.Cmd) {
+	if msg.seq != m.retrySeq || m.retryAt.IsZero() || m.state != stateResultError {
+		return m, nil
+	}
+	if now.Before(m.retryAt) || m.pendingConfirm != confirmNone {
+		return m, retryTickCmd(m.retrySeq)
+	}
+
+	m.retryAt = time.Time{}
+	m.autoRetries++
+	return startGeneration(m)
+}
+
+// stopRetry stops the countdown to an automatic retry, if one is running.
+func stopRetry(m Model) Model {
+	if !m.retryAt.IsZero() {
+		m.retryAt = time.Time{}
+		m.retrySeq++
+	}
+	return m
+}
+
+// retryCountdown describes the countdown to an automatic retry for the
+// error screen, or returns an empty string when none is running.
+func retryCountdown(m Model, now time.Time) string {
+	if m.retryAt.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("⏳ Trying again automatically in %s, as the API asked (attempt %d of %d)",
+		api.FormatWait(max(m.retryAt.Sub(now), 0)), m.autoRetries+1, maxAutoRetries)
+}
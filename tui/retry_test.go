@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestAutomaticRetry(t *testing.T) {
+	rateLimited := api.ClassifyError(errors.New("RESOURCE_EXHAUSTED: Quota exceeded. Please retry in 30s."))
+	failed := func() Model {
+		m := NewModel()
+		m.width = 100
+		m.state = stateGenerating
+		m.generating = true
+		m.generateAttempted = true
+		updated, _ := m.Update(APIResultMsg{Success: false, Error: rateLimited})
+		return updated.(Model)
+	}
+
+	// Test case 1: A rate limit that says how long to wait counts down on the error screen
+	m := failed()
+	if m.state != stateResultError || m.retryAt.IsZero() {
+		t.Fatalf("Expected a countdown on the error screen, got state %v", m.state)
+	}
+	view := renderErrorView(m)
+	for _, want := range []string{"Trying again automatically in 30s", "attempt 1 of 3", "The API asks to wait 30s before trying again"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the error screen to show %q, got:\n%s", want, view)
+		}
+	}
+
+	// Test case 2: Ticks before the wait is over keep counting down
+	start := m.retryAt.Add(-30 * time.Second)
+	m, cmd := handleRetryTick(m, retryTickMsg{seq: m.retrySeq}, start.Add(10*time.Second))
+	if m.state != stateResultError || cmd == nil {
+		t.Fatalf("Expected the countdown to continue, got state %v", m.state)
+	}
+	if got := retryCountdown(m, start.Add(10*time.Second)); !strings.Contains(got, "in 20s") {
+		t.Errorf("Expected 20s left, got %q", got)
+	}
+
+	// Test case 3: A tick of a stopped countdown is ignored
+	if _, cmd := handleRetryTick(m, retryTickMsg{seq: m.retrySeq - 1}, m.retryAt); cmd != nil {
+		t.Error("Expected a stale tick to be ignored")
+	}
+
+	// Test case 4: Once the wait is over the resume is generated again
+	m, _ = handleRetryTick(m, retryTickMsg{seq: m.retrySeq}, m.retryAt)
+	if m.state != stateGenerating || m.autoRetries != 1 || !m.retryAt.IsZero() {
+		t.Errorf("Expected generation to start again, got state %v after %d retries", m.state, m.autoRetries)
+	}
+
+	// Test case 5: Ctrl+X stops the countdown
+	m = failed()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	if m = updated.(Model); !m.retryAt.IsZero() || strings.Contains(renderErrorView(m), "Trying again automatically") {
+		t.Error("Expected Ctrl+X to stop the countdown")
+	}
+
+	// Test case 6: Nothing is scheduled after the last automatic retry, for long waits, or for other errors
+	m = failed()
+	m.autoRetries = maxAutoRetries
+	if m, _ = scheduleRetry(m, rateLimited, time.Now()); !m.retryAt.IsZero() {
+		t.Error("Expected no retry after the last automatic one")
+	}
+	m.autoRetries = 0
+	if m, _ = scheduleRetry(m, errors.New("RESOURCE_EXHAUSTED: Please retry in 3600s."), time.Now()); !m.retryAt.IsZero() {
+		t.Error("Expected no countdown for an hour's wait")
+	}
+	if m, _ = scheduleRetry(m, errors.New("RESOURCE_EXHAUSTED: Quota exceeded"), time.Now()); !m.retryAt.IsZero() {
+		t.Error("Expected no countdown without a wait")
+	}
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/analysis"
+	"github.com/phrazzld/resumake/layout"
+)
+
+// revisionTimeLayout formats when the previous resume was saved.
+const revisionTimeLayout = "Jan 2, 2006 at 15:04"
+
+// revisionSummary describes how many items changed since the previous
+// resume, for the success screen.
+func revisionSummary(m Model) string {
+	changes := analysis.CountChangedItems(analysis.CompareRevisions(m.previousRevision.Content, m.resultContent))
+	saved := m.previousRevision.Saved.Format(revisionTimeLayout)
+	switch changes {
+	case 0:
+		return fmt.Sprintf("🔀 No changes since the resume saved %s", saved)
+	case 1:
+		return fmt.Sprintf("🔀 1 change since the resume saved %s", saved)
+	}
+	return fmt.Sprintf("🔀 %d changes since the resume saved %s", changes, saved)
+}
+
+// renderRevisionDiffView compares the generated resume with the one saved
+// before it for the same candidate, section by section. Only the bullets
+// that were removed, reworded, or added are listed; unchanged sections are
+// named on a single line.
+func renderRevisionDiffView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+	textWidth := displayWidth - 16
+
+	title := titleStyle.Render(layout.Wrap("🔀 Changes Since "+m.previousRevision.Saved.Format(revisionTimeLayout), displayWidth-4))
+
+	changes := analysis.CompareRevisions(m.previousRevision.Content, m.resultContent)
+
+	var body strings.Builder
+	var unchanged []string
+	for _, change := range changes {
+		if change.Kind == analysis.DiffSame {
+			unchanged = append(unchanged, change.Heading)
+			continue
+		}
+
+		if body.Len() > 0 {
+			body.WriteString("\n\n")
+		}
+		body.WriteString(sectionChangeHeading(change))
+		for _, row := range change.Items {
+			switch row.Kind {
+			case analysis.DiffOnlyA:
+				body.WriteString("\n" + errorStyle.Render(layout.Wrap("- "+itemText(row.A), textWidth)))
+			case analysis.DiffOnlyB:
+				body.WriteString("\n" + successStyle.Render(layout.Wrap("+ "+itemText(row.B), textWidth)))
+			case analysis.DiffChanged:
+				body.WriteString("\n" + errorStyle.Render(layout.Wrap("- "+itemText(row.A), textWidth)))
+				body.WriteString("\n" + successStyle.Render(layout.Wrap("+ "+itemText(row.B), textWidth)))
+			}
+		}
+	}
+	if body.Len() == 0 {
+		body.WriteString(successStyle.Render("✓ The new resume has the same content as the previous one"))
+	}
+	if len(unchanged) > 0 {
+		body.WriteString("\n\n" + italicStyle.Render(layout.Wrap("Unchanged: "+strings.Join(unchanged, ", "), textWidth)))
+	}
+
+	diffBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 2).
+		Width(displayWidth - 10).
+		Render(body.String())
+
+	savedIn := italicStyle.Render(layout.Wrap("Previous resume: "+m.previousRevision.Path, displayWidth-8))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		diffBox,
+		"",
+		savedIn,
+	)
+}
+
+// sectionChangeHeading labels a changed section with what happened to it.
+func sectionChangeHeading(change analysis.SectionChange) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(highlightColor)
+	switch change.Kind {
+	case analysis.DiffOnlyA:
+		return heading.Render("✖ " + change.Heading + " (section removed)")
+	case analysis.DiffOnlyB:
+		return heading.Render("✚ " + change.Heading + " (new section)")
+	}
+
+	count := analysis.CountDifferences(change.Items)
+	if count == 1 {
+		return heading.Render("✎ " + change.Heading + " (1 change)")
+	}
+	return heading.Render(fmt.Sprintf("✎ %s (%d changes)", change.Heading, count))
+}
+
+// itemText returns an item without its Markdown list marker, so the diff
+// marker takes its place.
+func itemText(item string) string {
+	trimmed := strings.TrimSpace(item)
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(trimmed, marker) {
+			return strings.TrimSpace(strings.TrimPrefix(trimmed, marker))
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/history"
+)
+
+func TestSaveResumeRecordsRevisions(t *testing.T) {
+	dir := t.TempDir()
+	opts := GenerateOptions{HistoryDir: filepath.Join(dir, "history")}
+
+	// Test case 1: The first resume for a candidate has nothing to compare with
+	first := APIResultMsg{Success: true, Content: "# Jane Doe\n\n## Experience\n- Built X"}
+	if err := saveResume(&first, "", filepath.Join(dir, "first.md"), opts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.Previous != nil {
+		t.Errorf("Expected no previous revision, got %+v", first.Previous)
+	}
+
+	// Test case 2: The next resume for the same candidate is compared with it
+	second := APIResultMsg{Success: true, Content: "# Jane Doe\n\n## Experience\n- Led X"}
+	if err := saveResume(&second, "", filepath.Join(dir, "second.md"), opts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if second.Previous == nil || second.Previous.Content != first.Content {
+		t.Errorf("Expected the first resume as the previous revision, got %+v", second.Previous)
+	}
+
+	// Test case 3: Without a history directory nothing is kept
+	third := APIResultMsg{Success: true, Content: "# Jane Doe"}
+	if err := saveResume(&third, "", filepath.Join(dir, "third.md"), GenerateOptions{}); err != nil || third.Previous != nil {
+		t.Errorf("Expected no history without a directory, got %+v (%v)", third.Previous, err)
+	}
+}
+
+func TestRevisionDiffView(t *testing.T) {
+	m := NewModel()
+	m.width = 100
+	updated, _ := m.Update(APIResultMsg{
+		Success: true,
+		Content: "# Jane Doe\n\n## Summary\n\nGo engineer.\n\n## Experience\n- Mentored three engineers\n- Cut latency by 40%",
+		Previous: &history.Revision{
+			Content: "# Jane Doe\n\n## Experience\n- Mentored two engineers\n- Wrote docs",
+			Saved:   time.Date(2024, 6, 1, 9, 30, 0, 0, time.Local),
+			Path:    "/config/history/jane-doe/2024-06-01T093000.000.md",
+		},
+	})
+	m = updated.(Model)
+
+	// Test case 1: The success screen counts the changes and offers the diff
+	if !strings.Contains(renderSuccessView(m), "4 changes since the resume saved Jun 1, 2024") {
+		t.Error("Expected the success screen to count the changes since the previous resume")
+	}
+
+	// Test case 2: 'd' opens the diff with removed, reworded, and added bullets
+	m = typeText(m, "d")
+	if m.state != stateRevisionDiff {
+		t.Fatalf("Expected the revision diff, got state %v", m.state)
+	}
+	view := renderRevisionDiffView(m)
+	for _, want := range []string{"Summary (new section)", "Experience (3 changes)", "- Wrote docs", "- Mentored two engineers", "+ Mentored three engineers", "+ Cut latency by 40%", "Unchanged: Header"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the diff to contain %q", want)
+		}
+	}
+
+	// Test case 3: Enter returns to the success screen
+	m = pressKey(m, tea.KeyEnter)
+	if m.state != stateResultSuccess {
+		t.Errorf("Expected Enter to return to the success screen, got state %v", m.state)
+	}
+}
+
+func TestRevisionDiffNeedsPreviousResume(t *testing.T) {
+	m := NewModel()
+	m.state = stateResultSuccess
+
+	// Without a previous resume there is nothing to compare
+	m = typeText(m, "d")
+	if m.state != stateResultSuccess {
+		t.Errorf("Expected 'd' to do nothing without a previous resume, got state %v", m.state)
+	}
+}
@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+)
+
+// Options offered on the save fallback screen, in the order they are listed.
+const (
+	saveOptionNewPath = iota
+	saveOptionTempDir
+	saveOptionClipboard
+	saveOptionCount
+)
+
+// copyToClipboard copies text to the system clipboard. It is a variable so
+// tests can replace it.
+var copyToClipboard = clipboard.WriteAll
+
+// openSaveFallback switches to the save fallback screen after a generated
+// resume could not be written. The resume stays in memory until it is saved
+// to a new path or a temporary directory, or copied to the clipboard.
+func openSaveFallback(m Model, msg SaveFailedMsg) (Model, tea.Cmd) {
+	// A retry that fails again remembers an earlier copy to the clipboard
+	if m.state != stateSaveFallback {
+		m.saveCopied = false
+	}
+
+	m.state = stateSaveFallback
+	m.saveResult = msg.Result
+	m = rememberUpload(m, msg.Result.UploadedFile)
+	m.saveLetter = msg.CoverLetter
+	m.saveErr = msg.Error.Error()
+	m.saveNote = ""
+	m.saveCursor = saveOptionNewPath
+
+	path := msg.Path
+	if path == "" {
+		path = output.DefaultOutputPath
+	}
+	if m.flagBundle {
+		path = filepath.Dir(path)
+	}
+	m.savePathInput = textinput.New()
+	m.savePathInput.CharLimit = 200
+	m.savePathInput.Width = 50
+	m.savePathInput.SetValue(path)
+	m.savePathInput.CursorEnd()
+
+	cmd := m.savePathInput.Focus()
+	return m, cmd
+}
+
+// saveFallbackPath returns the output path for a location typed on the
+// fallback screen. In bundle mode the location is the directory the bundle
+// is created in, so a file name inside it is returned.
+func saveFallbackPath(m Model, location string) string {
+	if m.flagBundle {
+		return filepath.Join(location, output.DefaultOutputPath)
+	}
+	return location
+}
+
+// tempOutputPath creates a new temporary directory and returns the output
+// path for the resume inside it.
+func tempOutputPath(m Model) (string, error) {
+	dir, err := os.MkdirTemp("", "resumake-")
+	if err != nil {
+		return "", fmt.Errorf("could not create a temporary directory: %w", err)
+	}
+
+	name := output.DefaultOutputPath
+	if path, err := resolveOutputPath(m.saveResult.Content, generateOptions(m)); err == nil && path != "" && !m.flagBundle {
+		name = filepath.Base(path)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// clipboardText returns the text copied to the clipboard: the resume, and in
+// bundle mode the cover letter after it.
+func clipboardText(m Model) string {
+	if m.saveLetter == "" {
+		return m.saveResult.Content
+	}
+	return m.saveResult.Content + "\n\n---\n\n" + m.saveLetter
+}
+
+// updateSaveFallback handles key presses on the save fallback screen. The
+// path input takes typed keys while the new path option is selected.
+func updateSaveFallback(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := m.keyMap()
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.saveCursor > 0 {
+			m.saveCursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Down, keys.Next):
+		if m.saveCursor < saveOptionCount-1 {
+			m.saveCursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Accept):
+		switch m.saveCursor {
+		case saveOptionNewPath:
+			location := strings.TrimSpace(m.savePathInput.Value())
+			if location == "" {
+				m.saveErr = "Enter a path to save the resume to."
+				return m, nil
+			}
+			return m, SaveResumeCmd(m.saveResult, m.saveLetter, saveFallbackPath(m, location), generateOptions(m))
+
+		case saveOptionTempDir:
+			path, err := tempOutputPath(m)
+			if err != nil {
+				m.saveErr = err.Error()
+				return m, nil
+			}
+			return m, SaveResumeCmd(m.saveResult, m.saveLetter, path, generateOptions(m))
+
+		case saveOptionClipboard:
+			if err := copyToClipboard(clipboardText(m)); err != nil {
+				m.saveErr = fmt.Sprintf("Could not copy to the clipboard: %v", err)
+				m.saveNote = ""
+				return m, nil
+			}
+			m.saveCopied = true
+			m.saveErr = ""
+			m.saveNote = "Copied to the clipboard. Paste it somewhere safe before quitting, or save it to a file as well."
+			return m, nil
+		}
+	}
+
+	if m.saveCursor != saveOptionNewPath {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.savePathInput, cmd = m.savePathInput.Update(msg)
+	return m, cmd
+}
+
+// renderSaveFallbackView renders why the resume could not be written and the
+// ways it can still be kept.
+func renderSaveFallbackView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(1).
+		Width(displayWidth - 4).
+		Align(lipgloss.Center).
+		Render("💾 Save Your Resume Elsewhere")
+
+	description := "Your resume was generated but could not be saved, so it is being kept in memory. " +
+		"Choose another way to keep it."
+	if m.saveLetter != "" {
+		description = "Your resume and cover letter were generated but could not be saved, so they are being kept in memory. " +
+			"Choose another way to keep them."
+	}
+	description = layout.Wrap(description, displayWidth-8)
+
+	pathLabel := "Save to a new path"
+	if m.flagBundle {
+		pathLabel = "Save the bundle in a new directory"
+	}
+	options := []string{
+		pathLabel,
+		"Save to a temporary directory",
+		"Copy to the clipboard",
+	}
+
+	var rows strings.Builder
+	for i, option := range options {
+		if i > 0 {
+			rows.WriteString("\n")
+		}
+		if i == m.saveCursor {
+			rows.WriteString(lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("▸ " + option))
+		} else {
+			rows.WriteString("  " + option)
+		}
+		if i == saveOptionNewPath {
+			rows.WriteString("\n    " + m.savePathInput.View())
+		}
+	}
+
+	optionsBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(focusBorderColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(rows.String())
+
+	status := ""
+	if m.saveErr != "" {
+		status = errorStyle.Render(layout.Wrap(m.saveErr, displayWidth-8))
+	}
+	if m.saveNote != "" {
+		status = successStyle.Render(layout.Wrap(m.saveNote, displayWidth-8))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		description,
+		"",
+		optionsBox,
+		"",
+		status,
+	)
+}
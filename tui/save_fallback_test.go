@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/output"
+)
+
+// unwritablePath returns an output path inside a regular file, which cannot
+// be written even when the tests run as root
+func unwritablePath(t *testing.T) string {
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, []byte("not a directory"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(blocker, "resume.md")
+}
+
+// pressEnterAndSave presses Enter and runs the save command it returns
+func pressEnterAndSave(t *testing.T, m Model) Model {
+	t.Helper()
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected Enter to return a save command")
+	}
+	updated, _ = m.Update(cmd())
+	return updated.(Model)
+}
+
+func TestSaveResumeKeepsContentOnFailure(t *testing.T) {
+	result := APIResultMsg{Success: true, Content: "# Jane Doe"}
+	err := saveResume(&result, "", unwritablePath(t), GenerateOptions{})
+	if err == nil || !strings.Contains(err.Error(), "error writing output file") {
+		t.Fatalf("Expected a write error, got %v", err)
+	}
+	if result.Content != "# Jane Doe" || result.OutputPath != "" {
+		t.Errorf("Expected the content to be kept without an output path, got %+v", result)
+	}
+}
+
+func TestSaveFallback(t *testing.T) {
+	badPath := unwritablePath(t)
+	m := NewModel()
+	m.width = 100
+
+	// Test case 1: A failed save opens the fallback screen with the content kept
+	updated, _ := m.Update(SaveFailedMsg{
+		Result: APIResultMsg{Success: true, Content: "# Jane Doe", ModelName: "test-model"},
+		Path:   badPath,
+		Error:  errors.New("error writing output file: not a directory"),
+	})
+	m = updated.(Model)
+	if m.state != stateSaveFallback {
+		t.Fatalf("Expected state to be stateSaveFallback, got %v", m.state)
+	}
+	if m.savePathInput.Value() != badPath || m.saveResult.Content != "# Jane Doe" {
+		t.Errorf("Expected the failed path and content to be kept, got %q and %q", m.savePathInput.Value(), m.saveResult.Content)
+	}
+	view := renderSaveFallbackView(m)
+	for _, want := range []string{"Save Your Resume Elsewhere", "not a directory", "temporary directory", "clipboard"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the fallback view to contain %q", want)
+		}
+	}
+
+	// Test case 2: Saving to another unwritable path stays on the fallback screen
+	m = pressEnterAndSave(t, m)
+	if m.state != stateSaveFallback || m.saveErr == "" {
+		t.Fatalf("Expected the fallback screen to show the new error, got state %v", m.state)
+	}
+
+	// Test case 3: Saving to a writable path finishes on the success screen
+	newPath := filepath.Join(t.TempDir(), "saved.md")
+	m.savePathInput.SetValue("")
+	m = typeText(m, newPath)
+	m = pressEnterAndSave(t, m)
+	if m.state != stateResultSuccess || m.outputPath != newPath || m.modelName != "test-model" {
+		t.Fatalf("Expected the success screen for %s, got state %v and path %q", newPath, m.state, m.outputPath)
+	}
+	if data, err := os.ReadFile(newPath); err != nil || string(data) != "# Jane Doe" {
+		t.Errorf("Expected the resume at %s, got %q (%v)", newPath, data, err)
+	}
+}
+
+func TestSaveFallbackTempDir(t *testing.T) {
+	m := NewModel()
+	m, _ = openSaveFallback(m, SaveFailedMsg{
+		Result: APIResultMsg{Success: true, Content: "# Jane Doe"},
+		Error:  errors.New("permission denied"),
+	})
+
+	m = pressKey(m, tea.KeyDown)
+	m = pressEnterAndSave(t, m)
+	if m.state != stateResultSuccess {
+		t.Fatalf("Expected the success screen, got state %v (%s)", m.state, m.saveErr)
+	}
+	defer os.RemoveAll(filepath.Dir(m.outputPath))
+
+	want := output.DatedFileName("Jane Doe", time.Now())
+	if !strings.HasPrefix(m.outputPath, os.TempDir()) || filepath.Base(m.outputPath) != want {
+		t.Errorf("Expected %s in a temporary directory, got %s", want, m.outputPath)
+	}
+	if data, err := os.ReadFile(m.outputPath); err != nil || string(data) != "# Jane Doe" {
+		t.Errorf("Expected the resume in the temporary directory, got %q (%v)", data, err)
+	}
+}
+
+func TestSaveFallbackClipboard(t *testing.T) {
+	var copied string
+	original := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = original }()
+
+	m := NewModel()
+	m, _ = openSaveFallback(m, SaveFailedMsg{
+		Result:      APIResultMsg{Success: true, Content: "# Jane Doe"},
+		CoverLetter: "Dear Hiring Manager",
+		Error:       errors.New("permission denied"),
+	})
+
+	// Test case 1: Quitting before the content is kept anywhere asks first
+	if !hasUnsavedInput(m) {
+		t.Error("Expected the unsaved resume to need confirmation before quitting")
+	}
+	m = pressKey(m, tea.KeyEsc)
+	if m.pendingConfirm != confirmQuit {
+		t.Fatal("Expected Esc to open the quit confirmation")
+	}
+	if title, _ := confirmDialogContent(m); title != "Quit without saving?" {
+		t.Errorf("Unexpected dialog title %q", title)
+	}
+	m = pressKey(m, tea.KeyEsc)
+
+	// Test case 2: Copying puts the resume and cover letter on the clipboard
+	m = pressKey(m, tea.KeyDown)
+	m = pressKey(m, tea.KeyDown)
+	m = pressKey(m, tea.KeyEnter)
+	if copied != "# Jane Doe\n\n---\n\nDear Hiring Manager" {
+		t.Errorf("Unexpected clipboard text %q", copied)
+	}
+	if !m.saveCopied || !strings.Contains(renderSaveFallbackView(m), "Copied to the clipboard") {
+		t.Error("Expected the copy to be confirmed")
+	}
+	if hasUnsavedInput(m) {
+		t.Error("Expected quitting to need no confirmation once the resume is copied")
+	}
+
+	// Test case 3: A clipboard failure is reported
+	copyToClipboard = func(string) error { return errors.New("no clipboard utility") }
+	m = pressKey(m, tea.KeyEnter)
+	if !strings.Contains(m.saveErr, "no clipboard utility") {
+		t.Errorf("Expected the clipboard error to be shown, got %q", m.saveErr)
+	}
+}
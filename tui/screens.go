@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"context"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Hook is a point of the workflow where screens added with RegisterState
+// are shown.
+type Hook int
+
+const (
+	// HookBeforeInput shows screens after the welcome screen, before the
+	// source file is asked for.
+	HookBeforeInput Hook = iota
+
+	// HookBeforeGenerate shows screens once generation is confirmed, after
+	// the consent screen and the overwrite dialog, before the inputs are
+	// sent to the API. They are shown again each time generation is run.
+	HookBeforeGenerate
+)
+
+// Screen is a workflow step added to the interface by a fork or plugin,
+// such as a company-internal compliance check. It works like a Bubble Tea
+// model: Update returns the screen to show next, and the screen ends by
+// returning ScreenDone as a command.
+//
+// Every message reaches the active screen except the keys the interface
+// handles itself: Esc and Ctrl+C, which quit. A screen that also implements
+// ShortHelp() []key.Binding lists those bindings in the status bar.
+type Screen interface {
+	// Enter is called each time the screen is shown, with the session so
+	// far, and returns the screen's first command, if any.
+	Enter(info ScreenInfo) tea.Cmd
+
+	// Update handles a message and returns the updated screen.
+	Update(msg tea.Msg) (Screen, tea.Cmd)
+
+	// View renders the screen, above the status bar.
+	View() string
+}
+
+// ScreenInfo describes the session to a screen when it is shown.
+type ScreenInfo struct {
+	Context       context.Context // Cancelled when the interface quits
+	SourcePath    string          // The existing resume, once it is chosen
+	SourceContent string          // The existing resume's text, once it is read
+	Notes         string          // The notes typed, once they are submitted
+	OutputPath    string          // The -output path, or empty for the default name
+	Width         int             // The terminal width
+	Height        int             // The terminal height
+}
+
+// ScreenDoneMsg ends the active screen added with RegisterState. It is sent
+// by the command ScreenDone returns.
+type ScreenDoneMsg struct {
+	// Err stops the workflow with this error, shown on the error screen.
+	// Nil continues with the next screen, or the built-in step that follows.
+	Err error
+}
+
+// ScreenDone returns a command that ends the active screen, continuing the
+// workflow when err is nil and stopping it with err otherwise.
+//
+// Parameters:
+//   - err: Why the workflow cannot continue, or nil to continue
+//
+// Returns:
+//   - tea.Cmd: A command that sends ScreenDoneMsg
+func ScreenDone(err error) tea.Cmd {
+	return func() tea.Msg {
+		return ScreenDoneMsg{Err: err}
+	}
+}
+
+// registeredScreen is a screen added with RegisterState.
+type registeredScreen struct {
+	hook   Hook
+	name   string
+	screen Screen
+}
+
+// registeredScreens holds the screens added with RegisterState, in the
+// order they were added, which is the order they are shown in at a hook.
+var registeredScreens []registeredScreen
+
+// RegisterState adds a screen to the workflow at hook, after any screens
+// already added there. It must be called before the interface starts, for
+// example from an init function. Plain mode has no screens, so it does not
+// show them.
+//
+// Parameters:
+//   - hook: Where in the workflow the screen is shown
+//   - name: The screen's label in the status bar, such as "Compliance"
+//   - screen: The screen, as it is when first shown
+//
+// Returns:
+//   - State: The state of the screen, which Model.State returns while it is shown
+//
+// Example:
+//
+//	var complianceState = tui.RegisterState(tui.HookBeforeGenerate, "Compliance", complianceScreen{})
+func RegisterState(hook Hook, name string, screen Screen) State {
+	registeredScreens = append(registeredScreens, registeredScreen{hook: hook, name: name, screen: screen})
+	return stateCustom + State(len(registeredScreens)-1)
+}
+
+// State returns the screen being shown, to compare with the states returned
+// by RegisterState.
+func (m Model) State() State {
+	return m.state
+}
+
+// onCustomScreen reports whether a screen added with RegisterState is shown.
+func onCustomScreen(m Model) bool {
+	return m.state >= stateCustom && m.screen != nil
+}
+
+// showScreens shows the first screen added at hook from the registered
+// screen at index from on, or continues with the step after the hook when
+// there are none left.
+func showScreens(m Model, hook Hook, from int) (Model, tea.Cmd) {
+	for i := from; i < len(registeredScreens); i++ {
+		if registeredScreens[i].hook != hook {
+			continue
+		}
+		m.state = stateCustom + State(i)
+		m.screen = registeredScreens[i].screen
+		m.helpOpen = false
+		return m, m.screen.Enter(screenInfo(m))
+	}
+
+	m.screen = nil
+	switch hook {
+	case HookBeforeInput:
+		m.state = stateInputSourcePath
+		return m, m.sourcePathInput.Focus()
+	}
+	return runGeneration(m)
+}
+
+// finishScreen ends the active screen, continuing with the next one at its
+// hook, or showing the error the screen stopped with.
+func finishScreen(m Model, msg ScreenDoneMsg) (Model, tea.Cmd) {
+	if !onCustomScreen(m) {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.screen = nil
+		m.state = stateResultError
+		m.err = msg.Err
+		m.errorMsg = msg.Err.Error()
+		return m, nil
+	}
+	index := int(m.state - stateCustom)
+	return showScreens(m, registeredScreens[index].hook, index+1)
+}
+
+// updateCustomScreen passes a message to the active screen.
+func updateCustomScreen(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.screen, cmd = m.screen.Update(msg)
+	return m, cmd
+}
+
+// customScreenName returns the status bar label of the active screen.
+func customScreenName(m Model) string {
+	return registeredScreens[m.state-stateCustom].name
+}
+
+// customScreenKeys returns the bindings the active screen lists in the
+// status bar, if it lists any.
+func customScreenKeys(m Model) []key.Binding {
+	if helper, ok := m.screen.(interface{ ShortHelp() []key.Binding }); ok {
+		return helper.ShortHelp()
+	}
+	return nil
+}
+
+// screenInfo describes the session to a screen being shown.
+func screenInfo(m Model) ScreenInfo {
+	return ScreenInfo{
+		Context:       m.ctx,
+		SourcePath:    m.sourcePathInput.Value(),
+		SourceContent: m.sourceContent,
+		Notes:         m.stdinContent,
+		OutputPath:    m.flagOutputPath,
+		Width:         m.width,
+		Height:        m.height,
+	}
+}
@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/api"
+)
+
+// complianceScreen asks to confirm the inputs may be sent: 'y' continues,
+// 'n' stops, and other keys and messages are recorded.
+type complianceScreen struct {
+	received []string
+}
+
+type complianceNoticeMsg string
+
+func (s complianceScreen) Enter(info ScreenInfo) tea.Cmd {
+	return func() tea.Msg { return complianceNoticeMsg("checked " + info.SourcePath) }
+}
+
+func (s complianceScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y":
+			return s, ScreenDone(nil)
+		case "n":
+			return s, ScreenDone(errors.New("the compliance check was declined"))
+		}
+		s.received = append(s.received, msg.String())
+	case complianceNoticeMsg:
+		s.received = append(s.received, string(msg))
+	}
+	return s, nil
+}
+
+func (s complianceScreen) View() string {
+	return "Compliance check: " + strings.Join(s.received, ",")
+}
+
+func (s complianceScreen) ShortHelp() []key.Binding {
+	return []key.Binding{key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "approve"))}
+}
+
+// useScreens replaces the registered screens for the length of a test.
+func useScreens(t *testing.T) {
+	t.Helper()
+	previous := registeredScreens
+	registeredScreens = nil
+	t.Cleanup(func() { registeredScreens = previous })
+}
+
+// runCmd runs cmd and passes its message to the model, as the program would.
+func runCmd(m Model, cmd tea.Cmd) Model {
+	if cmd == nil {
+		return m
+	}
+	updated, _ := m.Update(cmd())
+	return updated.(Model)
+}
+
+// collectMsgs runs cmd and the commands it batches, returning their messages.
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, collectMsgs(c)...)
+	}
+	return msgs
+}
+
+func TestRegisteredScreens(t *testing.T) {
+	useScreens(t)
+	inputState := RegisterState(HookBeforeInput, "Compliance", complianceScreen{})
+	generateState := RegisterState(HookBeforeGenerate, "Approval", complianceScreen{})
+	if inputState == generateState || inputState < stateCustom {
+		t.Fatalf("Expected distinct custom states, got %v and %v", inputState, generateState)
+	}
+
+	m := NewModel()
+	m.apiKeyOk = true
+	m.fixtures = api.Fixtures{Mode: api.FixtureReplay}
+	m = m.WithSourcePath("resume.md")
+
+	// Test case 1: The screen added before the inputs follows the welcome screen
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.State() != inputState {
+		t.Fatalf("Expected the compliance screen, got state %v", m.State())
+	}
+
+	// Test case 2: The screen gets the results of its own commands, with the session so far
+	var enterMsg tea.Msg
+	for _, msg := range collectMsgs(cmd) {
+		if notice, ok := msg.(complianceNoticeMsg); ok {
+			enterMsg = notice
+		}
+	}
+	if enterMsg == nil {
+		t.Fatal("Expected the screen's first command to be returned")
+	}
+	updated, _ = m.Update(enterMsg)
+	m = updated.(Model)
+	if view := m.View(); !strings.Contains(view, "Compliance check: checked resume.md") {
+		t.Errorf("Expected the screen's view with its message, got %q", view)
+	}
+
+	// Test case 3: The status bar names the screen and lists its keys, and '?' reaches the screen
+	m = typeText(m, "?")
+	view := m.View()
+	if !strings.Contains(view, "Compliance") || !strings.Contains(view, "approve") {
+		t.Errorf("Expected the screen's name and keys in the status bar, got %q", view)
+	}
+	if !strings.Contains(view, "checked resume.md,?") || m.helpOpen {
+		t.Errorf("Expected '?' to be passed to the screen, got %q", view)
+	}
+
+	// Test case 4: Finishing the screen continues with the source path
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = runCmd(updated.(Model), cmd)
+	if m.state != stateInputSourcePath || m.screen != nil {
+		t.Fatalf("Expected the source path screen after the compliance screen, got state %v", m.state)
+	}
+
+	// Test case 5: The screen added before generation comes before the request
+	m.state = stateConfirmGenerate
+	m, _ = startGeneration(m)
+	if m.State() != generateState {
+		t.Fatalf("Expected the approval screen before generating, got state %v", m.State())
+	}
+	if statusStep(m) != "Approval" {
+		t.Errorf("Expected the screen's name as the step, got %q", statusStep(m))
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = updated.(Model)
+	if doneMsg, ok := cmd().(ScreenDoneMsg); !ok || doneMsg.Err != nil {
+		t.Fatalf("Expected the screen to finish, got %v", doneMsg)
+	}
+	updated, _ = m.Update(ScreenDoneMsg{})
+	m = updated.(Model)
+	if m.state != stateGenerating {
+		t.Errorf("Expected generation to start after the approval screen, got state %v", m.state)
+	}
+
+	// Test case 6: A screen that stops the workflow shows its error
+	m.state = stateConfirmGenerate
+	m, _ = startGeneration(m)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = runCmd(updated.(Model), cmd)
+	if m.state != stateResultError || m.Err() == nil || !strings.Contains(m.Err().Error(), "declined") {
+		t.Errorf("Expected the screen's error, got state %v and %v", m.state, m.Err())
+	}
+
+	// Test case 7: A stray ScreenDoneMsg on a built-in screen is ignored
+	m.state = stateConfirmGenerate
+	updated, _ = m.Update(ScreenDoneMsg{})
+	if updated.(Model).state != stateConfirmGenerate {
+		t.Errorf("Expected the confirm screen to stay, got state %v", updated.(Model).state)
+	}
+}
+
+func TestNoRegisteredScreens(t *testing.T) {
+	useScreens(t)
+
+	// Test case 1: Without screens, generation starts at once
+	m := NewModel()
+	m.state = stateConfirmGenerate
+	m, _ = startGeneration(m)
+	if m.state != stateGenerating {
+		t.Errorf("Expected generation to start, got state %v", m.state)
+	}
+}
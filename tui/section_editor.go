@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/faults"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// regenerableSections returns the headings of the resume's sections that can
+// be regenerated. A Skills section rendered from the skills form is left out,
+// since it is not the model's writing.
+func regenerableSections(m Model) []string {
+	var headings []string
+	for _, section := range document.Parse(m.resultContent).Sections {
+		if len(m.skills) > 0 && strings.Contains(strings.ToLower(section.Heading), "skills") {
+			continue
+		}
+		headings = append(headings, section.Heading)
+	}
+	return headings
+}
+
+// canRegenerateSection reports whether the success screen offers to
+// regenerate a section: the conversation that wrote the resume must still be
+// open, the resume must have been saved to a file rather than standard
+// output, and it must have a section to choose.
+func canRegenerateSection(m Model) bool {
+	return m.apiSession != nil && m.outputPath != "" && m.outputPath != output.StdoutPath && len(regenerableSections(m)) > 0
+}
+
+// openSectionEditor switches to the screen for choosing a section of the
+// generated resume to regenerate, with optional instructions for it.
+func openSectionEditor(m Model) (Model, tea.Cmd) {
+	m.state = stateRegenerateSection
+	m.sectionHeadings = regenerableSections(m)
+	m.sectionCursor = 0
+	m.sectionBusy = false
+	m.sectionErr = ""
+
+	m.sectionInput = textinput.New()
+	m.sectionInput.Placeholder = "Extra instructions (optional)"
+	m.sectionInput.CharLimit = 300
+	m.sectionInput.Width = 50
+
+	cmd := m.sectionInput.Focus()
+	return m, cmd
+}
+
+// updateSectionEditor handles key presses on the section screen. The
+// instructions input takes typed keys; nothing is accepted while a section
+// is being regenerated.
+func updateSectionEditor(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.sectionBusy {
+		return m, nil
+	}
+
+	keys := m.keyMap()
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.sectionCursor > 0 {
+			m.sectionCursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Down, keys.Next):
+		if m.sectionCursor < len(m.sectionHeadings)-1 {
+			m.sectionCursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Accept):
+		if m.sectionCursor >= len(m.sectionHeadings) {
+			return m, nil
+		}
+		m.sectionBusy = true
+		m.sectionErr = ""
+		heading := m.sectionHeadings[m.sectionCursor]
+		return m, RegenerateSectionCmd(m.ctx, m.apiSession, m.resultContent, heading, m.sectionInput.Value(), m.outputPath, generateOptions(m))
+
+	case key.Matches(msg, keys.Remove):
+		// Back to the success screen without changing anything
+		m.sectionInput.Blur()
+		m.sectionErr = ""
+		m.state = stateResultSuccess
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.sectionInput, cmd = m.sectionInput.Update(msg)
+	return m, cmd
+}
+
+// RegenerateSectionCmd returns a command that asks the model to rewrite one
+// section of the resume on the conversation that produced it, splices the new
+// section into the resume, and saves it over the Markdown file, any HTML
+// version, and its sidecar.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - session: The conversation that produced the resume
+//   - resume: The current Markdown resume
+//   - heading: The heading of the section to rewrite
+//   - instructions: Extra instructions for the new version (can be empty)
+//   - outputPath: The Markdown file the resume was saved to
+//   - opts: The generation options, for the HTML layout
+//
+// Returns:
+//   - tea.Cmd: A command that returns a SectionRegeneratedMsg
+func RegenerateSectionCmd(ctx context.Context, session *api.Session, resume, heading, instructions, outputPath string, opts GenerateOptions) tea.Cmd {
+	return func() tea.Msg {
+		current := ""
+		for _, section := range document.Parse(resume).Sections {
+			if strings.EqualFold(section.Heading, heading) {
+				current = section.Body
+				break
+			}
+		}
+
+		section, err := rewriteSection(ctx, session, heading, current, instructions, opts)
+		if err != nil {
+			return SectionRegeneratedMsg{Heading: heading, Error: err}
+		}
+
+		content := output.NormalizeSkills(output.ReplaceSectionHeading(resume, heading, section))
+		if _, err := output.WriteOutput(content, outputPath); err != nil {
+			return SectionRegeneratedMsg{Heading: heading, Error: fmt.Errorf("error writing output file: %w", err)}
+		}
+		if _, err := writeLayout(content, outputPath, opts); err != nil {
+			return SectionRegeneratedMsg{Heading: heading, Error: fmt.Errorf("error writing HTML file: %w", err)}
+		}
+		if sidecar, ok, _ := output.ReadSidecar(outputPath); ok {
+			writeSidecar(content, outputPath, sidecar)
+		}
+		return SectionRegeneratedMsg{Heading: heading, Content: content}
+	}
+}
+
+// rewriteSection asks the model, on the conversation that produced the
+// resume, to write one section again, and returns the new section with its
+// heading and canonical credential names.
+func rewriteSection(ctx context.Context, session *api.Session, heading, current, instructions string, opts GenerateOptions) (string, error) {
+	if session == nil {
+		return "", errors.New("the conversation that wrote the resume is no longer open")
+	}
+
+	response, err := session.Send(faults.WithStage(seededContext(ctx, opts), faults.StageSection), prompt.GenerateSectionPromptContent(heading, current, instructions))
+	if err != nil {
+		return "", err
+	}
+	text, err := api.ProcessResponse(response)
+	if err != nil {
+		return "", err
+	}
+	section, err := regeneratedSection(text, heading)
+	if err != nil {
+		return "", err
+	}
+	return output.NormalizeCredentials(section), nil
+}
+
+// regeneratedSection extracts the rewritten section from the model's reply
+// and gives it the original heading, so it replaces the section it was asked
+// for even if the model renamed it. Only the first section is kept when the
+// reply repeats more of the resume.
+func regeneratedSection(text, heading string) (string, error) {
+	parsed := document.Parse(output.StripCitations(output.ExtractFencedContent(text)))
+
+	body := parsed.Header
+	if len(parsed.Sections) > 0 {
+		body = parsed.Sections[0].Body
+		for _, section := range parsed.Sections {
+			if strings.EqualFold(section.Heading, heading) {
+				body = section.Body
+				break
+			}
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return "", errors.New("the response did not contain the section")
+	}
+	return "## " + heading + "\n\n" + strings.TrimSpace(body), nil
+}
+
+// renderSectionEditorView renders the sections of the resume to choose from
+// and the instructions for the new version.
+func renderSectionEditorView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(1).
+		Width(displayWidth - 4).
+		Align(lipgloss.Center).
+		Render("🔁 Regenerate a Section")
+
+	description := layout.Wrap("Choose a section to write again. Only that section is replaced; the rest of your resume stays as it is.", displayWidth-8)
+
+	var rows strings.Builder
+	for i, heading := range m.sectionHeadings {
+		if i > 0 {
+			rows.WriteString("\n")
+		}
+		if i == m.sectionCursor {
+			rows.WriteString(lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("▸ " + heading))
+		} else {
+			rows.WriteString("  " + heading)
+		}
+	}
+
+	listBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(rows.String())
+
+	form := m.sectionInput.View()
+	if m.sectionBusy && m.sectionCursor < len(m.sectionHeadings) {
+		form = italicStyle.Render(fmt.Sprintf("Regenerating the %s section…", m.sectionHeadings[m.sectionCursor]))
+	}
+	if m.sectionErr != "" {
+		form += "\n\n" + errorStyle.Render(layout.Wrap(m.sectionErr, displayWidth-14))
+	}
+
+	formBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(focusBorderColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(form)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		description,
+		"",
+		listBox,
+		"",
+		formBox,
+	)
+}
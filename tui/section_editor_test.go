@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+)
+
+// textReply returns a finished response with the given text
+func textReply(text string) *genai.GenerateContentResponse {
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			Content:      &genai.Content{Parts: []genai.Part{genai.Text(text)}},
+			FinishReason: genai.FinishReasonStop,
+		}},
+	}
+}
+
+func TestRegeneratedSection(t *testing.T) {
+	// Test case 1: Chatter is dropped and the original heading is kept
+	section, err := regeneratedSection("Sure! Here it is:\n\n## Professional Summary\n\nGo engineer who ships.", "Summary")
+	if err != nil || section != "## Summary\n\nGo engineer who ships." {
+		t.Errorf("Unexpected section %q (%v)", section, err)
+	}
+
+	// Test case 2: A reply that repeats the resume keeps only the section asked for
+	section, _ = regeneratedSection("# Jane Doe\n\n## Summary\n\nNew summary.\n\n## Experience\n\n- Acme", "Summary")
+	if section != "## Summary\n\nNew summary." {
+		t.Errorf("Unexpected section %q", section)
+	}
+
+	// Test case 3: A reply without a heading is used as the body
+	section, _ = regeneratedSection("New summary.", "Summary")
+	if section != "## Summary\n\nNew summary." {
+		t.Errorf("Unexpected section %q", section)
+	}
+
+	// Test case 4: An empty reply is an error
+	if _, err := regeneratedSection("  ", "Summary"); err == nil {
+		t.Error("Expected an error for an empty reply")
+	}
+}
+
+func TestRegenerateSectionCmd(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "resume.md")
+	resume := "# Jane Doe\n\n## Summary\n\nGo engineer.\n\n## Experience\n\n- Acme"
+	session := api.NewSessionWithSender(&fakeChatSender{replies: []*genai.GenerateContentResponse{
+		textReply("## Summary\n\nGo engineer with ten years of open source work."),
+	}})
+
+	msg, ok := RegenerateSectionCmd(context.Background(), session, resume, "Summary", "Mention open source", outputPath, GenerateOptions{})().(SectionRegeneratedMsg)
+	if !ok || msg.Error != nil {
+		t.Fatalf("Expected the section to be regenerated, got %+v", msg)
+	}
+
+	want := "# Jane Doe\n\n## Summary\n\nGo engineer with ten years of open source work.\n\n## Experience\n\n- Acme"
+	if msg.Content != want {
+		t.Errorf("Content = %q, want %q", msg.Content, want)
+	}
+	if saved, err := os.ReadFile(outputPath); err != nil || string(saved) != want {
+		t.Errorf("Expected the resume to be saved again, got %q (%v)", saved, err)
+	}
+}
+
+func TestSectionEditor(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "resume.md")
+	m := NewModel()
+	m.width = 100
+	m.state = stateResultSuccess
+	m.outputPath = outputPath
+	m.resultContent = "# Jane Doe\n\n## Summary\n\nGo engineer.\n\n## Skills\n\n- Go\n\n## Experience\n\n- Acme"
+	m.skills = []document.Skill{{Name: "Go"}}
+
+	// Test case 1: Without the conversation there is nothing to regenerate with
+	m = typeText(m, "s")
+	if m.state != stateResultSuccess {
+		t.Fatalf("Expected 's' to do nothing without a session, got state %v", m.state)
+	}
+
+	// Test case 2: 's' lists the sections, leaving out skills from the form
+	m.apiSession = api.NewSessionWithSender(&fakeChatSender{replies: []*genai.GenerateContentResponse{
+		textReply("## Experience\n\n- Led the Acme platform team"),
+	}})
+	m = typeText(m, "s")
+	if m.state != stateRegenerateSection {
+		t.Fatalf("Expected the section screen, got state %v", m.state)
+	}
+	if got := strings.Join(m.sectionHeadings, ","); got != "Summary,Experience" {
+		t.Errorf("Expected Summary and Experience, got %q", got)
+	}
+
+	// Test case 3: Enter regenerates the selected section with the typed instructions
+	m = pressKey(m, tea.KeyDown)
+	m = typeText(m, "Stress leadership")
+	if m.sectionInput.Value() != "Stress leadership" {
+		t.Errorf("Expected the instructions to be typed, got %q", m.sectionInput.Value())
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.sectionBusy || cmd == nil {
+		t.Fatal("Expected Enter to start regenerating the section")
+	}
+	if !strings.Contains(renderSectionEditorView(m), "Regenerating the Experience section") {
+		t.Error("Expected the view to show the section being regenerated")
+	}
+
+	// Test case 4: The new section is spliced in and noted on the success screen
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.state != stateResultSuccess || !strings.Contains(m.resultContent, "- Led the Acme platform team") || !strings.Contains(m.resultContent, "## Summary\n\nGo engineer.") {
+		t.Fatalf("Unexpected result in state %v: %q", m.state, m.resultContent)
+	}
+	if !strings.Contains(renderSuccessView(m), "Regenerated the Experience section") {
+		t.Error("Expected the success screen to note the regenerated section")
+	}
+
+	// Test case 5: A failed regeneration stays on the section screen with the error
+	m, _ = openSectionEditor(m)
+	m.sectionBusy = true
+	updated, _ = m.Update(SectionRegeneratedMsg{Heading: "Summary", Error: os.ErrDeadlineExceeded})
+	m = updated.(Model)
+	if m.state != stateRegenerateSection || m.sectionBusy || !strings.Contains(renderSectionEditorView(m), "could not be regenerated") {
+		t.Errorf("Expected the error on the section screen, got state %v (%q)", m.state, m.sectionErr)
+	}
+
+	// Test case 6: Ctrl+X returns to the success screen
+	m = pressKey(m, tea.KeyCtrlX)
+	if m.state != stateResultSuccess {
+		t.Errorf("Expected Ctrl+X to return to the success screen, got state %v", m.state)
+	}
+}
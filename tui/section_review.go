@@ -0,0 +1,367 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/analysis"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/layout"
+)
+
+// maxReviewLines is the number of lines of a section shown on the review
+// screen. Longer sections are cut short; editing shows all of it.
+const maxReviewLines = 18
+
+// headerHeading names the title and contact details when they are reviewed
+// like a section, because they hold placeholders to replace.
+const headerHeading = "Header"
+
+// sectionReview holds a generated resume split into its sections while they
+// are reviewed one at a time, and which of them were accepted.
+type sectionReview struct {
+	preamble string             // The title and contact details before the first section, always kept
+	sections []document.Section // The sections in document order, with any edits
+	accepted []bool             // Whether each section was accepted
+	index    int                // The section under review
+	header   bool               // Whether the first section is the title and contact details, reviewed for their placeholders
+}
+
+// newSectionReview splits a Markdown resume into the part before its first
+// section and its sections, none of them accepted yet. A title and contact
+// details with placeholders, such as "[Your Name]", are reviewed first, like
+// a section, so they can be fixed before the resume is saved.
+func newSectionReview(content string) sectionReview {
+	preamble := content
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "## ") {
+			preamble = strings.Join(lines[:i], "\n")
+			break
+		}
+	}
+
+	preamble = strings.TrimSpace(preamble)
+	sections := document.Parse(content).Sections
+	header := len(sections) > 0 && len(analysis.FindPlaceholders(preamble)) > 0
+	if header {
+		sections = append([]document.Section{{Heading: headerHeading, Body: preamble}}, sections...)
+		preamble = ""
+	}
+	return sectionReview{
+		preamble: preamble,
+		sections: sections,
+		accepted: make([]bool, len(sections)),
+		header:   header,
+	}
+}
+
+// current returns the section under review.
+func (r sectionReview) current() document.Section {
+	return r.sections[r.index]
+}
+
+// onHeader reports whether the title and contact details are under review.
+func (r sectionReview) onHeader() bool {
+	return r.header && r.index == 0
+}
+
+// placeholders returns the placeholders left in the section under review,
+// which must be replaced before it can be accepted.
+func (r sectionReview) placeholders() []string {
+	return analysis.FindPlaceholders(r.current().Body)
+}
+
+// done reports whether every section has been accepted or left out.
+func (r sectionReview) done() bool {
+	return r.index >= len(r.sections)
+}
+
+// assemble returns the resume built from the part before the first section
+// and the accepted sections, in their original order.
+func (r sectionReview) assemble() string {
+	var parts []string
+	if r.preamble != "" {
+		parts = append(parts, r.preamble)
+	}
+	for i, section := range r.sections {
+		if !r.accepted[i] {
+			continue
+		}
+		if r.header && i == 0 {
+			if section.Body != "" {
+				parts = append(parts, section.Body)
+			}
+			continue
+		}
+		part := "## " + section.Heading
+		if section.Body != "" {
+			part += "\n\n" + section.Body
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// decidedHeadings returns the headings of the sections before the one under
+// review that were accepted, or left out when accepted is false.
+func (r sectionReview) decidedHeadings(accepted bool) []string {
+	var headings []string
+	for i := 0; i < r.index && i < len(r.sections); i++ {
+		if r.accepted[i] == accepted {
+			headings = append(headings, r.sections[i].Heading)
+		}
+	}
+	return headings
+}
+
+// openSectionReview switches to the review screen for a resume generated
+// with --review. A resume without sections has nothing to review, so it is
+// saved as it is.
+func openSectionReview(m Model, msg ReviewReadyMsg) (Model, tea.Cmd) {
+	m = rememberUpload(m, msg.Result.UploadedFile)
+	m.reviewResult = msg.Result
+	m.reviewLetter = msg.CoverLetter
+	m.reviewPath = msg.Path
+	m.apiSession = msg.Result.Session
+	m.review = newSectionReview(msg.Result.Content)
+	m.reviewEditing = false
+	m.reviewBusy = ""
+	m.reviewErr = ""
+
+	if len(m.review.sections) == 0 {
+		m.state = stateGenerating
+		return m, SaveResumeCmd(m.reviewResult, m.reviewLetter, m.reviewPath, generateOptions(m))
+	}
+	m.state = stateReviewSections
+	return m, nil
+}
+
+// updateSectionReview handles key presses on the review screen. While a
+// section is being edited the editor takes typed keys; nothing is accepted
+// while a section is being regenerated or the resume saved.
+func updateSectionReview(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.reviewBusy != "" {
+		return m, nil
+	}
+
+	keys := m.keyMap()
+	if m.reviewEditing {
+		switch {
+		case key.Matches(msg, keys.Finish):
+			m.review.sections[m.review.index].Body = strings.TrimSpace(m.reviewEditor.Value())
+			m.reviewEditing = false
+			m.reviewEditor.Blur()
+			return m, nil
+
+		case key.Matches(msg, keys.Remove):
+			// Keep the section as it was before editing
+			m.reviewEditing = false
+			m.reviewEditor.Blur()
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.reviewEditor, cmd = m.reviewEditor.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, keys.Accept):
+		// Placeholders such as "[Your Name]" must not reach the saved resume
+		if placeholders := m.review.placeholders(); len(placeholders) > 0 {
+			m.reviewErr = fmt.Sprintf("Replace %s before accepting the %s: press E to edit it, R to regenerate it, or Ctrl+X to leave it out.",
+				strings.Join(placeholders, ", "), reviewedName(m.review))
+			return m, nil
+		}
+		return decideSection(m, true)
+
+	case key.Matches(msg, keys.Remove):
+		return decideSection(m, false)
+
+	case key.Matches(msg, keys.Up):
+		if m.review.index > 0 {
+			m.review.index--
+		}
+		m.reviewErr = ""
+		return m, nil
+
+	case key.Matches(msg, keys.Edit):
+		m.reviewEditor = textarea.New()
+		m.reviewEditor.CharLimit = 0
+		m.reviewEditor.SetWidth(getConstrainedWidth(m.width) - 10)
+		m.reviewEditor.SetHeight(maxReviewLines)
+		m.reviewEditor.SetValue(m.review.current().Body)
+		m.reviewEditing = true
+		m.reviewErr = ""
+		return m, m.reviewEditor.Focus()
+
+	case key.Matches(msg, keys.Again):
+		// A Skills section rendered from the skills form is not the model's writing
+		heading := m.review.current().Heading
+		if m.review.onHeader() {
+			m.reviewErr = "The title and contact details are not regenerated. Edit them instead."
+			return m, nil
+		}
+		if len(m.skills) > 0 && strings.Contains(strings.ToLower(heading), "skills") {
+			m.reviewErr = fmt.Sprintf("The %s section comes from the skills form, so it is not regenerated. Edit it instead.", heading)
+			return m, nil
+		}
+		m.reviewBusy = fmt.Sprintf("Regenerating the %s section…", heading)
+		m.reviewErr = ""
+		return m, ReviseSectionCmd(m.ctx, m.apiSession, m.review.index, m.review.current(), generateOptions(m))
+
+	case key.Matches(msg, keys.Strengthen):
+		n, err := strconv.Atoi(msg.String())
+		if err != nil {
+			return m, nil
+		}
+		return strengthenBullet(m, n)
+	}
+	return m, nil
+}
+
+// decideSection accepts the section under review or leaves it out, and
+// moves to the next one. After the last section the resume is assembled
+// from the accepted sections and saved; at least one must be accepted.
+func decideSection(m Model, accept bool) (Model, tea.Cmd) {
+	m.review.accepted[m.review.index] = accept
+	m.review.index++
+	m.reviewErr = ""
+	if !m.review.done() {
+		return m, nil
+	}
+
+	if len(m.review.decidedHeadings(true)) == 0 {
+		m.review.index = len(m.review.sections) - 1
+		m.reviewErr = "Accept at least one section to save the resume."
+		return m, nil
+	}
+
+	m.review.index = len(m.review.sections) - 1
+	m.reviewBusy = "Saving your resume…"
+	result := m.reviewResult
+	result.Content = m.review.assemble()
+	return m, SaveResumeCmd(result, m.reviewLetter, m.reviewPath, generateOptions(m))
+}
+
+// applyRevisedSection puts a regenerated section in place of the one under
+// review, which stays under review so it can be accepted or changed again.
+func applyRevisedSection(m Model, msg SectionRevisedMsg) Model {
+	m.reviewBusy = ""
+	if msg.Error != nil {
+		m.reviewErr = fmt.Sprintf("The section could not be regenerated: %v", msg.Error)
+		return m
+	}
+	if revised := document.Parse(msg.Section).Sections; len(revised) > 0 && msg.Index < len(m.review.sections) {
+		m.review.sections[msg.Index].Body = revised[0].Body
+	}
+	return m
+}
+
+// ReviseSectionCmd returns a command that asks the model to write a section
+// under review again, on the conversation that produced the resume. Unlike
+// RegenerateSectionCmd, nothing is saved; the new section replaces the one
+// under review.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - session: The conversation that produced the resume
+//   - index: The position of the section in the review
+//   - section: The section to write again
+//   - opts: The generation options, for the seed
+//
+// Returns:
+//   - tea.Cmd: A command that returns a SectionRevisedMsg
+func ReviseSectionCmd(ctx context.Context, session *api.Session, index int, section document.Section, opts GenerateOptions) tea.Cmd {
+	return func() tea.Msg {
+		revised, err := rewriteSection(ctx, session, section.Heading, section.Body, "", opts)
+		return SectionRevisedMsg{Index: index, Section: revised, Error: err}
+	}
+}
+
+// reviewedName names the section under review in messages, such as "Summary
+// section" or "title and contact details".
+func reviewedName(r sectionReview) string {
+	if r.onHeader() {
+		return "title and contact details"
+	}
+	return r.current().Heading + " section"
+}
+
+// renderSectionReviewView renders the section under review, the decisions
+// made so far, and the section editor while it is open.
+func renderSectionReviewView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+	section := m.review.current()
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(1).
+		Width(displayWidth - 4).
+		Align(lipgloss.Center).
+		Render("📝 Review Your Resume")
+
+	description := layout.Wrap("Accept each section to keep it, or edit, regenerate, or leave it out. "+
+		"Only the sections you accept are saved.", displayWidth-8)
+
+	progress := lipgloss.NewStyle().Bold(true).Foreground(accentColor).
+		Render(fmt.Sprintf("Section %d of %d: %s", m.review.index+1, len(m.review.sections), section.Heading))
+
+	body := section.Body
+	if m.reviewEditing {
+		body = m.reviewEditor.View()
+	} else {
+		lines := strings.Split(layout.Wrap(body, displayWidth-12), "\n")
+		if len(lines) > maxReviewLines {
+			more := len(lines) - maxReviewLines
+			lines = append(lines[:maxReviewLines], italicStyle.Render(fmt.Sprintf("… %d more lines; press E to see and edit them all", more)))
+		}
+		body = strings.Join(lines, "\n")
+		if strings.TrimSpace(body) == "" {
+			body = italicStyle.Render("This section is empty.")
+		}
+	}
+
+	sectionBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(focusBorderColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(body)
+
+	var status []string
+	if accepted := m.review.decidedHeadings(true); len(accepted) > 0 {
+		status = append(status, layout.Wrap("✓ Accepted: "+strings.Join(accepted, ", "), displayWidth-8))
+	}
+	if left := m.review.decidedHeadings(false); len(left) > 0 {
+		status = append(status, layout.Wrap("✗ Left out: "+strings.Join(left, ", "), displayWidth-8))
+	}
+	if placeholders := m.review.placeholders(); len(placeholders) > 0 && !m.reviewEditing {
+		status = append(status, errorStyle.Render(layout.Wrap("⚠️ Placeholders to replace: "+strings.Join(placeholders, ", "), displayWidth-8)))
+	}
+	if weak := renderWeakBullets(weakBullets(m.review), displayWidth-8); weak != "" && !m.reviewEditing {
+		status = append(status, weak)
+	}
+	if m.reviewBusy != "" {
+		status = append(status, italicStyle.Render(m.reviewBusy))
+	}
+	if m.reviewErr != "" {
+		status = append(status, errorStyle.Render(layout.Wrap(m.reviewErr, displayWidth-8)))
+	}
+
+	parts := []string{title, "", description, "", progress, "", sectionBox}
+	if len(status) > 0 {
+		parts = append(parts, "", strings.Join(status, "\n"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, parts...)
+}
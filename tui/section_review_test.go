@@ -0,0 +1,275 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// reviewResume is a generated resume with three sections to review.
+const reviewResume = "# Jane Doe\n\njane@example.com\n\n## Summary\n\nGo engineer.\n\n## Experience\n\n- Acme\n\n## Hobbies\n\n- Chess\n"
+
+func TestSectionReviewAssemble(t *testing.T) {
+	review := newSectionReview(reviewResume)
+	if review.preamble != "# Jane Doe\n\njane@example.com" || len(review.sections) != 3 {
+		t.Fatalf("Unexpected review %+v", review)
+	}
+
+	// Only the accepted sections are kept, in their original order
+	review.accepted = []bool{true, true, false}
+	review.sections[0].Body = "Go engineer who ships."
+	want := "# Jane Doe\n\njane@example.com\n\n## Summary\n\nGo engineer who ships.\n\n## Experience\n\n- Acme\n"
+	if got := review.assemble(); got != want {
+		t.Errorf("assemble() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateWithReview(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	chat := &fakeChatSender{replies: []*genai.GenerateContentResponse{
+		textReply(api.ResumeStartDelimiter + "\n" + reviewResume + api.ResumeEndDelimiter),
+	}}
+	recorder := api.Fixtures{Mode: api.FixtureRecord, Dir: dir}.WrapSender(chat, api.DefaultModelName)
+	if _, err := api.NewSessionWithSender(recorder).Send(ctx, prompt.GeneratePromptContent("source", "stdin")); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+
+	// The resume is returned for review instead of being saved
+	outputPath := filepath.Join(t.TempDir(), "resume.md")
+	msg, ok := GenerateResumeWithOptionsCmd(ctx, nil, nil, "source", "stdin", GenerateOptions{
+		OutputPath: outputPath,
+		Review:     true,
+		Fixtures:   api.Fixtures{Mode: api.FixtureReplay, Dir: dir},
+	})().(ReviewReadyMsg)
+	if !ok || msg.Path != outputPath || !strings.Contains(msg.Result.Content, "## Hobbies") || msg.Result.Session == nil {
+		t.Fatalf("Expected a ReviewReadyMsg with the resume, got %+v", msg)
+	}
+	if _, err := os.Stat(outputPath); err == nil {
+		t.Error("Expected nothing to be saved before the review")
+	}
+}
+
+func TestSectionReview(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "resume.md")
+	m := NewModel().WithReview(true)
+	m.width = 100
+	m.state = stateGenerating
+	session := api.NewSessionWithSender(&fakeChatSender{replies: []*genai.GenerateContentResponse{
+		textReply("## Experience\n\n- Led the Acme platform team"),
+	}})
+
+	// Test case 1: A generated resume opens the review at its first section
+	updated, _ := m.Update(ReviewReadyMsg{Result: APIResultMsg{Success: true, Content: reviewResume, Session: session}, Path: outputPath})
+	m = updated.(Model)
+	if m.state != stateReviewSections || !strings.Contains(renderSectionReviewView(m), "Section 1 of 3: Summary") {
+		t.Fatalf("Expected the review of the first section, got state %v", m.state)
+	}
+
+	// Test case 2: Editing replaces the section body
+	m = typeText(m, "e")
+	if !m.reviewEditing {
+		t.Fatal("Expected 'e' to open the section editor")
+	}
+	m.reviewEditor.SetValue("Go engineer who ships.")
+	m = pressKey(m, tea.KeyCtrlD)
+	if m.reviewEditing || m.review.current().Body != "Go engineer who ships." {
+		t.Errorf("Expected the edit to be kept, got %q", m.review.current().Body)
+	}
+
+	// Test case 3: Regenerating replaces the section under review without saving
+	m = pressKey(m, tea.KeyEnter)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = updated.(Model)
+	if m.reviewBusy == "" || cmd == nil {
+		t.Fatal("Expected 'r' to start regenerating the section")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.review.current().Body != "- Led the Acme platform team" {
+		t.Errorf("Expected the regenerated section, got %q", m.review.current().Body)
+	}
+	if _, err := os.Stat(outputPath); err == nil {
+		t.Error("Expected nothing to be saved during the review")
+	}
+
+	// Test case 4: The resume is saved from the accepted sections only
+	m = pressKey(m, tea.KeyEnter)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected the last decision to save the resume")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.state != stateResultSuccess {
+		t.Fatalf("Expected the success screen, got state %v", m.state)
+	}
+	saved, err := os.ReadFile(outputPath)
+	if err != nil || !strings.Contains(string(saved), "Go engineer who ships.") || !strings.Contains(string(saved), "Led the Acme platform team") || strings.Contains(string(saved), "Hobbies") {
+		t.Errorf("Expected only the accepted sections to be saved, got %q (%v)", saved, err)
+	}
+}
+
+func TestSectionReviewNeedsAcceptedSection(t *testing.T) {
+	m := NewModel()
+	m, _ = openSectionReview(m, ReviewReadyMsg{Result: APIResultMsg{Success: true, Content: reviewResume}})
+
+	for range 3 {
+		m = pressKey(m, tea.KeyCtrlX)
+	}
+	if m.state != stateReviewSections || m.reviewErr == "" || m.review.index != 2 {
+		t.Errorf("Expected to stay on the last section with an error, got state %v (%q)", m.state, m.reviewErr)
+	}
+
+	// Quitting asks first, since the resume has not been saved
+	if !hasUnsavedInput(m) {
+		t.Error("Expected a resume under review to count as unsaved")
+	}
+}
+
+func TestPlainReview(t *testing.T) {
+	session := api.NewSessionWithSender(&fakeChatSender{replies: []*genai.GenerateContentResponse{
+		textReply("## Summary\n\nGo engineer with ten years of open source work."),
+	}})
+	ready := ReviewReadyMsg{Result: APIResultMsg{Success: true, Content: reviewResume, Session: session}}
+
+	// Regenerate and keep the summary, edit the experience, and leave out the hobbies
+	var out strings.Builder
+	editor := input.NewLineEditor(strings.NewReader("r\ny\ne\n- Led the Acme platform team\n.\n\nn\n"), &out)
+	content, err := plainReview(NewModel(), ready, editor, &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := "# Jane Doe\n\njane@example.com\n\n## Summary\n\nGo engineer with ten years of open source work.\n\n## Experience\n\n- Led the Acme platform team\n"
+	if content != want {
+		t.Errorf("plainReview() = %q, want %q", content, want)
+	}
+	if !strings.Contains(out.String(), "Section 3 of 3: Hobbies") {
+		t.Errorf("Expected every section to be shown, got %q", out.String())
+	}
+}
+
+// placeholderResume is a generated resume with template fields left in it.
+const placeholderResume = "# [Your Name]\n\n[Email]\n\n## Summary\n\nGrew revenue by X%.\n\n## Experience\n\n- Acme\n"
+
+func TestSectionReviewPlaceholders(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "resume.md")
+	m := NewModel()
+	m.width = 100
+	m, _ = openSectionReview(m, ReviewReadyMsg{Result: APIResultMsg{Success: true, Content: placeholderResume}, Path: outputPath})
+
+	// Test case 1: A header with placeholders is reviewed first, like a section
+	view := renderSectionReviewView(m)
+	if !strings.Contains(view, "Section 1 of 3: Header") || !strings.Contains(view, "Placeholders to replace: [Your Name], [Email]") {
+		t.Fatalf("Expected the header and its placeholders, got %q", view)
+	}
+
+	// Test case 2: Accepting is blocked until the placeholders are replaced
+	m = pressKey(m, tea.KeyEnter)
+	if m.review.index != 0 || !strings.Contains(m.reviewErr, "Replace [Your Name], [Email] before accepting the title and contact details") {
+		t.Fatalf("Expected the header to stay under review with an error, got %d (%q)", m.review.index, m.reviewErr)
+	}
+	m = typeText(m, "r")
+	if m.reviewBusy != "" || !strings.Contains(m.reviewErr, "not regenerated") {
+		t.Errorf("Expected the header not to be regenerated, got %q", m.reviewErr)
+	}
+	m = typeText(m, "e")
+	m.reviewEditor.SetValue("# Jane Doe\n\njane@example.com")
+	m = pressKey(m, tea.KeyCtrlD)
+	m = pressKey(m, tea.KeyEnter)
+	if m.review.index != 1 {
+		t.Fatalf("Expected the edited header to be accepted, got %q", m.reviewErr)
+	}
+
+	// Test case 3: A section with placeholders can be left out
+	m = pressKey(m, tea.KeyEnter)
+	if m.review.index != 1 || !strings.Contains(m.reviewErr, "X%") {
+		t.Errorf("Expected the Summary to be blocked, got %q", m.reviewErr)
+	}
+	m = pressKey(m, tea.KeyCtrlX)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected the last decision to save the resume")
+	}
+	m.Update(cmd())
+
+	saved, err := os.ReadFile(outputPath)
+	if want := "# Jane Doe\n\njane@example.com\n\n## Experience\n\n- Acme\n"; err != nil || string(saved) != want {
+		t.Errorf("Expected %q to be saved, got %q (%v)", want, saved, err)
+	}
+}
+
+func TestPlainReviewPlaceholders(t *testing.T) {
+	ready := ReviewReadyMsg{Result: APIResultMsg{Success: true, Content: placeholderResume}}
+
+	// Keeping the header is refused until it is edited; the summary is left out
+	var out strings.Builder
+	editor := input.NewLineEditor(strings.NewReader("y\ne\n# Jane Doe\n.\ny\nn\ny\n"), &out)
+	content, err := plainReview(NewModel(), ready, editor, &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if want := "# Jane Doe\n\n## Experience\n\n- Acme\n"; content != want {
+		t.Errorf("plainReview() = %q, want %q", content, want)
+	}
+	if !strings.Contains(out.String(), "Replace [Your Name], [Email] before keeping the title and contact details") {
+		t.Errorf("Expected the placeholders to block keeping the header, got %q", out.String())
+	}
+}
+
+func TestStrengthenBullet(t *testing.T) {
+	m := NewModel().WithReview(true)
+	m.width = 100
+	m.state = stateGenerating
+	session := api.NewSessionWithSender(&fakeChatSender{replies: []*genai.GenerateContentResponse{
+		textReply("- Cut failed payments by 30% by rebuilding the retry queue [S4]"),
+	}})
+	resume := "# Jane Doe\n\n## Experience\n\n- Worked on billing\n- Cut build times by 40%, saving 200 hours a month\n\n## Skills\n\n- Go\n"
+	updated, _ := m.Update(ReviewReadyMsg{Result: APIResultMsg{Success: true, Content: resume, Session: session}})
+	m = updated.(Model)
+
+	// Test case 1: Only the weak bullet is listed, with what it lacks
+	view := renderSectionReviewView(m)
+	if !strings.Contains(view, "1. Worked on billing") || !strings.Contains(view, "needs an action verb, a metric and an outcome") {
+		t.Errorf("Expected the weak bullet to be listed, got:\n%s", view)
+	}
+	if strings.Contains(view, "2. Cut build times") {
+		t.Errorf("Expected the strong bullet not to be listed, got:\n%s", view)
+	}
+
+	// Test case 2: Its number strengthens it in place, keeping the other bullets
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	m = updated.(Model)
+	if m.reviewBusy == "" || cmd == nil {
+		t.Fatal("Expected '1' to start strengthening the bullet")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	want := "- Cut failed payments by 30% by rebuilding the retry queue\n- Cut build times by 40%, saving 200 hours a month"
+	if m.review.current().Body != want || m.reviewErr != "" {
+		t.Errorf("Expected the strengthened bullet, got %q (%s)", m.review.current().Body, m.reviewErr)
+	}
+
+	// Test case 3: A number without a weak bullet does nothing
+	if updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}}); cmd != nil {
+		t.Error("Expected no request without weak bullets")
+	}
+
+	// Test case 4: Sections such as Skills are not scored
+	m = pressKey(updated.(Model), tea.KeyEnter)
+	if weak := weakBullets(m.review); m.review.current().Heading != "Skills" || weak != nil {
+		t.Errorf("Expected no weak bullets in Skills, got %+v", weak)
+	}
+}
@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/document"
+	"github.com/phrazzld/resumake/layout"
+)
+
+// Fields of the skills entry form, in focus order.
+const (
+	skillFieldName = iota
+	skillFieldYears
+	skillFieldLevel
+	skillFieldCount
+)
+
+// openSkillsEditor switches to the skills entry step and focuses the name field.
+func openSkillsEditor(m Model) (Model, tea.Cmd) {
+	m.state = stateInputSkills
+	m.skillErr = ""
+	return focusSkillField(m, skillFieldName)
+}
+
+// focusSkillField moves focus to the given field of the skills entry form.
+func focusSkillField(m Model, field int) (Model, tea.Cmd) {
+	m.skillFocus = (field + skillFieldCount) % skillFieldCount
+	m.skillNameInput.Blur()
+	m.skillYearsInput.Blur()
+
+	switch m.skillFocus {
+	case skillFieldName:
+		return m, m.skillNameInput.Focus()
+	case skillFieldYears:
+		return m, m.skillYearsInput.Focus()
+	}
+	return m, nil
+}
+
+// addSkillFromForm validates the entry form and appends its skill to the table.
+func addSkillFromForm(m Model) (Model, tea.Cmd) {
+	skill := document.Skill{
+		Name:        strings.TrimSpace(m.skillNameInput.Value()),
+		Proficiency: m.skillLevel,
+	}
+
+	if years := strings.TrimSpace(m.skillYearsInput.Value()); years != "" {
+		n, err := strconv.Atoi(years)
+		if err != nil {
+			m.skillErr = "Years must be a whole number"
+			return m, nil
+		}
+		skill.Years = n
+	}
+
+	if err := skill.Validate(); err != nil {
+		m.skillErr = err.Error()
+		return m, nil
+	}
+
+	m.skills = append(m.skills, skill)
+	m.skillCursor = len(m.skills) - 1
+	m.skillErr = ""
+	m.skillNameInput.SetValue("")
+	m.skillYearsInput.SetValue("")
+	m.skillLevel = document.ProficiencyUnspecified
+	return focusSkillField(m, skillFieldName)
+}
+
+// updateSkillsEditor handles key presses in the skills entry step.
+func updateSkillsEditor(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	keys := m.keyMap()
+	switch {
+	case key.Matches(msg, keys.Finish):
+		// Finish the step and return to the confirmation screen
+		m.skillNameInput.Blur()
+		m.skillYearsInput.Blur()
+		m.state = stateConfirmGenerate
+		return m, nil
+
+	case key.Matches(msg, keys.Next):
+		return focusSkillField(m, m.skillFocus+1)
+
+	case key.Matches(msg, keys.Previous):
+		return focusSkillField(m, m.skillFocus-1)
+
+	case key.Matches(msg, keys.Accept):
+		return addSkillFromForm(m)
+
+	case key.Matches(msg, keys.Up):
+		if m.skillCursor > 0 {
+			m.skillCursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Down):
+		if m.skillCursor < len(m.skills)-1 {
+			m.skillCursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Remove):
+		// Remove the selected row
+		if m.skillCursor >= 0 && m.skillCursor < len(m.skills) {
+			m.skills = append(m.skills[:m.skillCursor:m.skillCursor], m.skills[m.skillCursor+1:]...)
+			if m.skillCursor >= len(m.skills) && m.skillCursor > 0 {
+				m.skillCursor--
+			}
+		}
+		return m, nil
+	}
+
+	// The proficiency field is a selector cycled with the arrow keys
+	if m.skillFocus == skillFieldLevel {
+		levels := len(document.Proficiencies)
+		switch {
+		case key.Matches(msg, keys.Cycle):
+			m.skillLevel = document.Proficiencies[(int(m.skillLevel)+1)%levels]
+		case key.Matches(msg, keys.CycleBack):
+			m.skillLevel = document.Proficiencies[(int(m.skillLevel)+levels-1)%levels]
+		}
+		return m, nil
+	}
+
+	// Any other key edits the focused text field
+	var cmd tea.Cmd
+	if m.skillFocus == skillFieldName {
+		m.skillNameInput, cmd = m.skillNameInput.Update(msg)
+	} else {
+		m.skillYearsInput, cmd = m.skillYearsInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// renderSkillsInputView renders the skills entry step: a table of entered
+// skills above a form for adding the next one.
+func renderSkillsInputView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(1).
+		Width(displayWidth - 4).
+		Align(lipgloss.Center).
+		Render("🧰 Skills")
+
+	description := layout.Wrap("List your key skills with years of experience and proficiency. "+
+		"They are rendered as a consistent Skills section in the generated resume.", displayWidth-8)
+
+	// Skills table
+	header := lipgloss.NewStyle().Bold(true).Foreground(highlightColor).
+		Render(fmt.Sprintf("  %-28s %-6s %s", "Skill", "Years", "Proficiency"))
+
+	var rows strings.Builder
+	if len(m.skills) == 0 {
+		rows.WriteString(italicStyle.Render("  No skills added yet"))
+	}
+	for i, skill := range m.skills {
+		if i > 0 {
+			rows.WriteString("\n")
+		}
+		years := ""
+		if skill.Years > 0 {
+			years = strconv.Itoa(skill.Years)
+		}
+		row := fmt.Sprintf("%-28s %-6s %s", skill.Name, years, skill.Proficiency)
+		if i == m.skillCursor {
+			rows.WriteString(lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("▸ " + row))
+		} else {
+			rows.WriteString("  " + row)
+		}
+	}
+
+	tableBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(header + "\n" + rows.String())
+
+	// Entry form
+	label := func(field int, text string) string {
+		if m.skillFocus == field {
+			return lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render(text)
+		}
+		return text
+	}
+
+	level := m.skillLevel.String()
+	if level == "" {
+		level = "(not set)"
+	}
+
+	form := lipgloss.JoinVertical(
+		lipgloss.Left,
+		label(skillFieldName, "Skill: ")+m.skillNameInput.View(),
+		label(skillFieldYears, "Years: ")+m.skillYearsInput.View(),
+		label(skillFieldLevel, "Proficiency: ")+"◂ "+level+" ▸",
+	)
+	if m.skillErr != "" {
+		form += "\n\n" + errorStyle.Render(m.skillErr)
+	}
+
+	formBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(focusBorderColor).
+		Padding(1, 2).
+		Width(displayWidth - 4).
+		Render(form)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		description,
+		"",
+		tableBox,
+		"",
+		formBox,
+	)
+}
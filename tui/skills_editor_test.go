@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/document"
+)
+
+// typeText sends each rune of text to the model as a key press
+func typeText(m Model, text string) Model {
+	for _, r := range text {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	return m
+}
+
+// pressKey sends a single special key to the model
+func pressKey(m Model, key tea.KeyType) Model {
+	updated, _ := m.Update(tea.KeyMsg{Type: key})
+	return updated.(Model)
+}
+
+func TestSkillsEditor(t *testing.T) {
+	m := NewModel()
+	m.state = stateConfirmGenerate
+
+	// Test case 1: 's' on the confirm screen opens the skills form
+	m = typeText(m, "s")
+	if m.state != stateInputSkills {
+		t.Fatalf("Expected state to be stateInputSkills, got %v", m.state)
+	}
+	if m.skillFocus != skillFieldName {
+		t.Errorf("Expected the name field to be focused, got %d", m.skillFocus)
+	}
+
+	// Test case 2: Fill in all fields and add the skill
+	m = typeText(m, "Go")
+	m = pressKey(m, tea.KeyTab)
+	m = typeText(m, "6")
+	m = pressKey(m, tea.KeyTab)
+	for i := 0; i < int(document.ProficiencyExpert); i++ {
+		m = pressKey(m, tea.KeyRight)
+	}
+	m = pressKey(m, tea.KeyEnter)
+
+	if len(m.skills) != 1 {
+		t.Fatalf("Expected 1 skill, got %d", len(m.skills))
+	}
+	want := document.Skill{Name: "Go", Years: 6, Proficiency: document.ProficiencyExpert}
+	if m.skills[0] != want {
+		t.Errorf("Expected skill %+v, got %+v", want, m.skills[0])
+	}
+	if m.skillNameInput.Value() != "" || m.skillFocus != skillFieldName {
+		t.Error("Expected the form to reset after adding a skill")
+	}
+
+	// Test case 3: Invalid input shows an error and adds nothing
+	m = pressKey(m, tea.KeyEnter)
+	if m.skillErr == "" || len(m.skills) != 1 {
+		t.Errorf("Expected a validation error for an empty name, got err=%q skills=%d", m.skillErr, len(m.skills))
+	}
+
+	m = typeText(m, "SQL")
+	m = pressKey(m, tea.KeyTab)
+	m = typeText(m, "x")
+	m = pressKey(m, tea.KeyEnter)
+	if !strings.Contains(m.skillErr, "whole number") {
+		t.Errorf("Expected a years error, got %q", m.skillErr)
+	}
+
+	// Test case 4: Ctrl+X removes the selected row
+	m = pressKey(m, tea.KeyCtrlX)
+	if len(m.skills) != 0 {
+		t.Errorf("Expected the skill to be removed, got %d skills", len(m.skills))
+	}
+
+	// Test case 5: Ctrl+D returns to the confirm screen
+	m = pressKey(m, tea.KeyCtrlD)
+	if m.state != stateConfirmGenerate {
+		t.Errorf("Expected state to be stateConfirmGenerate, got %v", m.state)
+	}
+}
+
+func TestSkillsInputView(t *testing.T) {
+	m := NewModel()
+	m.state = stateInputSkills
+	m.width = 100
+	m.skills = []document.Skill{{Name: "Kubernetes", Years: 3, Proficiency: document.ProficiencyAdvanced}}
+
+	view := renderSkillsInputView(m)
+	for _, element := range []string{"Skills", "Kubernetes", "Advanced", "Proficiency"} {
+		if !strings.Contains(view, element) {
+			t.Errorf("Skills view should contain %q", element)
+		}
+	}
+
+	// The confirm screen summarizes the entered skills
+	if !strings.Contains(renderConfirmGenerateView(m), "Skills: 1 entered") {
+		t.Error("Confirm view should summarize the structured skills")
+	}
+}
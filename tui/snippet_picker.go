@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/snippets"
+)
+
+// maxSnippetMatches is the number of matches shown in the snippets picker.
+const maxSnippetMatches = 8
+
+// openSnippetPicker opens the snippets picker over the notes textarea and
+// starts loading the snippets library.
+func openSnippetPicker(m Model) (Model, tea.Cmd) {
+	m.snippetPickerActive = true
+	m.snippetCursor = 0
+	m.snippetQuery.SetValue("")
+	m.stdinInput.Blur()
+
+	return m, tea.Batch(
+		LoadSnippetsCmd(m.snippetsDir),
+		m.snippetQuery.Focus(),
+	)
+}
+
+// closeSnippetPicker closes the snippets picker and returns focus to the textarea.
+func closeSnippetPicker(m Model) (Model, tea.Cmd) {
+	m.snippetPickerActive = false
+	m.snippetQuery.Blur()
+	return m, m.stdinInput.Focus()
+}
+
+// snippetMatches returns the snippets matching the current picker query.
+func snippetMatches(m Model) []snippets.Snippet {
+	return snippets.Search(m.snippetLibrary, m.snippetQuery.Value())
+}
+
+// updateSnippetPicker handles key presses while the snippets picker is open.
+func updateSnippetPicker(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	matches := snippetMatches(m)
+	keys := m.keyMap()
+
+	switch {
+	case key.Matches(msg, keys.Cancel):
+		return closeSnippetPicker(m)
+
+	case key.Matches(msg, keys.Up):
+		if m.snippetCursor > 0 {
+			m.snippetCursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Down):
+		if m.snippetCursor < len(matches)-1 && m.snippetCursor < maxSnippetMatches-1 {
+			m.snippetCursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Accept):
+		if m.snippetCursor < len(matches) {
+			m.stdinInput.InsertString(matches[m.snippetCursor].Content)
+		}
+		return closeSnippetPicker(m)
+	}
+
+	// Any other key edits the search query
+	var cmd tea.Cmd
+	m.snippetQuery, cmd = m.snippetQuery.Update(msg)
+	m.snippetCursor = 0
+	return m, cmd
+}
+
+// renderSnippetPicker renders the snippets picker box
+func renderSnippetPicker(m Model, width int) string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Render("Insert Snippet")
+
+	var body strings.Builder
+	matches := snippetMatches(m)
+
+	switch {
+	case m.snippetErr != "":
+		body.WriteString(errorStyle.Render(layout.Wrap(m.snippetErr, width-8)))
+	case len(m.snippetLibrary) == 0:
+		body.WriteString(layout.Wrap(fmt.Sprintf("No snippets found. Save .md or .txt files in %s to reuse them here.", m.snippetsDir), width-8))
+	case len(matches) == 0:
+		body.WriteString("No snippets match your search")
+	default:
+		for i, s := range matches {
+			if i >= maxSnippetMatches {
+				body.WriteString(fmt.Sprintf("\n  … %d more", len(matches)-maxSnippetMatches))
+				break
+			}
+			if i > 0 {
+				body.WriteString("\n")
+			}
+			name := layout.Truncate(s.Name, width-10)
+			if i == m.snippetCursor {
+				body.WriteString(lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render("▸ " + name))
+			} else {
+				body.WriteString("  " + name)
+			}
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accentColor).
+		Padding(1, 2).
+		Width(width).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			"",
+			m.snippetQuery.View(),
+			"",
+			body.String(),
+		))
+}
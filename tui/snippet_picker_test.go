@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/phrazzld/resumake/snippets"
+)
+
+func TestSnippetPicker(t *testing.T) {
+	// Create a snippets library on disk
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "summary.md"), []byte("Seasoned backend engineer."), 0644); err != nil {
+		t.Fatalf("Failed to write snippet: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "certifications.md"), []byte("- CKA"), 0644); err != nil {
+		t.Fatalf("Failed to write snippet: %v", err)
+	}
+
+	newStdinModel := func() Model {
+		m := NewModel().WithSnippetsDir(dir)
+		m.state = stateInputStdin
+		m.stdinInput.Focus()
+		return m
+	}
+
+	t.Run("Ctrl+O opens the picker and loads snippets", func(t *testing.T) {
+		m := newStdinModel()
+
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+		m = updated.(Model)
+
+		if !m.snippetPickerActive {
+			t.Fatal("Expected snippet picker to be active")
+		}
+		if cmd == nil {
+			t.Fatal("Expected a command to load snippets")
+		}
+
+		msg := LoadSnippetsCmd(dir)()
+		updated, _ = m.Update(msg)
+		m = updated.(Model)
+
+		if len(m.snippetLibrary) != 2 {
+			t.Errorf("Expected 2 snippets loaded, got %d", len(m.snippetLibrary))
+		}
+
+		view := renderStdinInputView(m)
+		if !strings.Contains(view, "Insert Snippet") || !strings.Contains(view, "summary") {
+			t.Error("Expected stdin view to render the snippet picker")
+		}
+	})
+
+	t.Run("Typing filters and Enter inserts the snippet", func(t *testing.T) {
+		m := newStdinModel()
+		m, _ = openSnippetPicker(m)
+		updated, _ := m.Update(LoadSnippetsCmd(dir)())
+		m = updated.(Model)
+
+		for _, r := range "sum" {
+			updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			m = updated.(Model)
+		}
+
+		matches := snippetMatches(m)
+		if len(matches) != 1 || matches[0].Name != "summary" {
+			t.Fatalf("Expected only the summary snippet to match, got %v", matches)
+		}
+
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+
+		if m.snippetPickerActive {
+			t.Error("Expected picker to close after inserting")
+		}
+		if m.state != stateInputStdin {
+			t.Errorf("Expected to remain in stdin state, got %v", m.state)
+		}
+		if !strings.Contains(m.stdinInput.Value(), "Seasoned backend engineer.") {
+			t.Errorf("Expected snippet to be inserted, got %q", m.stdinInput.Value())
+		}
+	})
+
+	t.Run("Esc closes the picker without quitting", func(t *testing.T) {
+		m := newStdinModel()
+		m, _ = openSnippetPicker(m)
+		m.snippetLibrary = []snippets.Snippet{{Name: "summary", Content: "text"}}
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = updated.(Model)
+
+		if m.snippetPickerActive {
+			t.Error("Expected picker to close on Esc")
+		}
+		if m.stdinInput.Value() != "" {
+			t.Errorf("Expected no text inserted, got %q", m.stdinInput.Value())
+		}
+	})
+}
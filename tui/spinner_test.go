@@ -2,26 +2,19 @@ package tui
 
 import (
 	"testing"
-	
-	tea "github.com/charmbracelet/bubbletea"
 )
 
-// TestSpinnerTicking tests that the spinner ticking command is properly added
-// during the generating state
+// TestSpinnerTicking tests that the spinner keeps ticking on its own ticks
+// during the generating state and advances only on them
 func TestSpinnerTicking(t *testing.T) {
 	// Create a new model in generating state
 	m := NewModel()
 	m.state = stateGenerating
 	
-	// Create a mock spinner message
-	msg := tea.WindowSizeMsg{Width: 80, Height: 24}
-	
-	// Update the model with the message
-	newModel, cmd := m.Update(msg)
-	
-	// Verify that a command was returned (should include spinner.Tick)
+	// A spinner tick advances the spinner and schedules the next tick
+	newModel, cmd := m.Update(m.spinner.Tick())
 	if cmd == nil {
-		t.Fatal("Expected a command to be returned, got nil")
+		t.Fatal("Expected a command scheduling the next tick, got nil")
 	}
 	
 	// Convert the model to the correct type
@@ -35,49 +28,41 @@ func TestSpinnerTicking(t *testing.T) {
 		t.Errorf("Expected model to remain in stateGenerating, got %v", updatedModel.state)
 	}
 	
-	// This test will fail because we need to ensure the spinner command is always included
-	// We'll fix this in the implementation
-	
-	// TODO: Enhance this test to verify the specific spinner command is included
+	// Other messages must not tick the spinner, or it skips frames
+	frame := updatedModel.spinner.View()
+	newModel, _ = updatedModel.Update(ProgressUpdateMsg{Step: "2/4", Message: "Sending request..."})
+	if got := newModel.(Model).spinner.View(); got != frame {
+		t.Errorf("Expected a progress update to leave the spinner at %q, got %q", frame, got)
+	}
 }
 
-// TestSpinnerStateTransitions tests that the spinner animation is properly
-// handled during state transitions
+// TestSpinnerStateTransitions tests that the spinner starts ticking when
+// generation starts and stops once it ends
 func TestSpinnerStateTransitions(t *testing.T) {
 	// Create a new model in a non-generating state
 	m := NewModel()
 	m.state = stateConfirmGenerate
 	
-	// Transition to generating state
-	m.state = stateGenerating
-	
-	// Update model with a message that doesn't trigger specific case handlers
-	msg := tea.WindowSizeMsg{Width: 80, Height: 24}
-	newModel, cmd := m.Update(msg)
-	
-	// Verify command was returned
+	// Starting generation returns commands, including the first tick
+	m, cmd := startGeneration(m)
 	if cmd == nil {
-		t.Fatal("Expected command to be returned for generating state, got nil")
+		t.Fatal("Expected commands to be returned when generation starts, got nil")
 	}
-	
-	// Transition to result success state
-	updatedModel, ok := newModel.(Model)
-	if !ok {
-		t.Fatalf("Expected model of type Model, got %T", newModel)
+	if m.state != stateGenerating {
+		t.Fatalf("Expected stateGenerating, got %v", m.state)
 	}
 	
 	// This transition should stop spinner animation
-	updatedModel.state = stateResultSuccess
-	finalModel, finalCmd := updatedModel.Update(msg)
+	m.state = stateResultSuccess
+	finalModel, finalCmd := m.Update(m.spinner.Tick())
 	
-	// For non-generating states with no specific handlers,
-	// we expect nil command when no other commands are queued
-	if updatedModel.state != stateGenerating && finalCmd != nil {
-		t.Errorf("Expected nil command after transition from generating state, got non-nil")
+	// Ticks arriving after generation are dropped, ending the tick loop
+	if finalCmd != nil {
+		t.Errorf("Expected nil command for a tick after leaving the generating state, got non-nil")
 	}
 	
 	// Verify model type
-	_, ok = finalModel.(Model)
+	_, ok := finalModel.(Model)
 	if !ok {
 		t.Fatalf("Expected final model of type Model, got %T", finalModel)
 	}
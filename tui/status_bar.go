@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/api"
+)
+
+// totalWizardSteps is the number of steps from the welcome screen to generation.
+const totalWizardSteps = 5
+
+// statusStep returns the progress label for the current state, such as "Step 2/5".
+// Result screens are labeled instead of numbered.
+func statusStep(m Model) string {
+	step := 0
+	switch m.state {
+	case stateWelcome:
+		step = 1
+	case stateInputSourcePath:
+		step = 2
+	case stateInputStdin:
+		step = 3
+	case stateConfirmGenerate, stateInputSkills, stateConsent:
+		step = 4
+	case stateGenerating:
+		step = 5
+	case stateResultSuccess, stateAnalysis, stateTimeline, stateRevisionDiff, stateRegenerateSection, stateResumePreview:
+		return "Done"
+	case stateResultError:
+		return "Failed"
+	case stateFixJSONResume:
+		return "Fix export"
+	case stateReviewSections:
+		return "Review"
+	case stateDashboard:
+		return "Workspace"
+	}
+	if onCustomScreen(m) {
+		return customScreenName(m)
+	}
+	return fmt.Sprintf("Step %d/%d", step, totalWizardSteps)
+}
+
+// statusAPIInfo describes the API provider, key status, and model.
+// The model is the one that produced the resume once generation finishes,
+// and the default model before that. Replayed responses need no key, so
+// replay mode is shown in place of the key status. A key that is not usable
+// is labeled with what is wrong with it, such as "key invalid".
+func statusAPIInfo(m Model) string {
+	keyStatus := successStyle.Render("key ✓")
+	fixtureStyle := lipgloss.NewStyle().Foreground(accentColor)
+	switch {
+	case m.fixtures.Mode == api.FixtureReplay:
+		keyStatus = fixtureStyle.Render("replaying fixtures")
+	case !m.apiKeyOk && m.keyDiagnosis.Status != "" && m.keyDiagnosis.Status != api.KeyMissing:
+		keyStatus = errorStyle.Render("key " + string(m.keyDiagnosis.Status))
+	case !m.apiKeyOk:
+		keyStatus = errorStyle.Render("key missing")
+	case m.keyChecking:
+		keyStatus = fixtureStyle.Render("checking key")
+	case m.keyDiagnosis.Status == api.KeyUnreachable:
+		keyStatus = fixtureStyle.Render("key unchecked (offline)")
+	case m.fixtures.Mode == api.FixtureRecord:
+		keyStatus += " " + fixtureStyle.Render("recording")
+	}
+
+	modelName := m.modelName
+	if modelName == "" {
+		modelName = api.DefaultModelName
+	}
+	return "Gemini " + keyStatus + " • " + modelName
+}
+
+// renderStatusBar renders the status bar shown at the bottom of every view:
+// the current step, API status and model on the first line, and the key
+// hints for the current screen below, or every shortcut when help is open.
+func renderStatusBar(m Model) string {
+	displayWidth := fitWidth(m.width)
+
+	step := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(highlightColor).
+		Background(primaryColor).
+		Padding(0, 1).
+		Render(statusStep(m))
+
+	info := lipgloss.NewStyle().Padding(0, 1).Render(statusAPIInfo(m))
+
+	help := newHelp(displayWidth)
+	help.ShowAll = m.helpOpen && helpAvailable(m)
+	keys := help.View(screenKeyMap(m))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), true, false, false, false).
+		BorderForeground(subtleColor).
+		Width(displayWidth).
+		Render(lipgloss.JoinHorizontal(lipgloss.Top, step, info) + "\n" + keys)
+}
@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/phrazzld/resumake/api"
+)
+
+func TestStatusStep(t *testing.T) {
+	tests := []struct {
+		state    State
+		expected string
+	}{
+		{stateWelcome, "Step 1/5"},
+		{stateInputSourcePath, "Step 2/5"},
+		{stateInputStdin, "Step 3/5"},
+		{stateConfirmGenerate, "Step 4/5"},
+		{stateInputSkills, "Step 4/5"},
+		{stateGenerating, "Step 5/5"},
+		{stateResultSuccess, "Done"},
+		{stateAnalysis, "Done"},
+		{stateTimeline, "Done"},
+		{stateResultError, "Failed"},
+		{stateFixJSONResume, "Fix export"},
+	}
+
+	for _, tt := range tests {
+		if got := statusStep(Model{state: tt.state}); got != tt.expected {
+			t.Errorf("statusStep(%v) = %q, want %q", tt.state, got, tt.expected)
+		}
+	}
+}
+
+func TestScreenKeyMap(t *testing.T) {
+	// hintKeys joins the keys of the status bar hints for the given model
+	hintKeys := func(m Model) string {
+		var keys []string
+		for _, binding := range screenKeyMap(m).ShortHelp() {
+			keys = append(keys, binding.Help().Key)
+		}
+		return strings.Join(keys, " ")
+	}
+
+	tests := []struct {
+		name     string
+		model    Model
+		expected string
+	}{
+		{"confirm screen", Model{state: stateConfirmGenerate}, "enter s p esc ?"},
+		{"prompt preview", Model{state: stateConfirmGenerate, promptPreviewOpen: true}, "enter ↑/↓ p esc ?"},
+		{"details entry has no help key", Model{state: stateInputStdin}, "ctrl+d ctrl+o esc"},
+		{"snippet picker overrides the screen", Model{state: stateInputStdin, snippetPickerActive: true}, "↑/↓ enter esc"},
+		{"success screen", Model{state: stateResultSuccess}, "enter v a t r ?"},
+		{"timeline", Model{state: stateTimeline}, "enter/t esc ?"},
+		{"error screen", Model{state: stateResultError}, "enter ?"},
+		{"error after generating", Model{state: stateResultError, generateAttempted: true}, "enter r ?"},
+		{"JSON Resume fix-it view", Model{state: stateFixJSONResume}, "↑/↓ enter ctrl+x esc"},
+		{"confirmation dialog overrides the screen", Model{state: stateResultSuccess, pendingConfirm: confirmRegenerate}, "y n/esc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hintKeys(tt.model); got != tt.expected {
+				t.Errorf("Expected hint keys %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestKeyMapRemapping(t *testing.T) {
+	keys := DefaultKeyMap()
+	keys.Again = key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "generate again"))
+	m := NewModel().WithKeyMap(keys)
+	m.state = stateResultSuccess
+	m.width = 100
+
+	// Test case 1: The status bar describes the new binding
+	if bar := renderStatusBar(m); !strings.Contains(bar, "g") || !strings.Contains(bar, "generate again") {
+		t.Errorf("Expected the status bar to show the remapped key, got %q", bar)
+	}
+
+	// Test case 2: The old key no longer acts and the new one does
+	if typeText(m, "r").pendingConfirm != confirmNone {
+		t.Error("Expected 'r' to do nothing once remapped")
+	}
+	if typeText(m, "g").pendingConfirm != confirmRegenerate {
+		t.Error("Expected 'g' to ask to generate again")
+	}
+}
+
+func TestHelpToggle(t *testing.T) {
+	m := NewModel()
+	m.width = 100
+	m.state = stateConfirmGenerate
+	m.promptPreviewOpen = true
+	if strings.Contains(renderStatusBar(m), "pgup/pgdn") {
+		t.Error("Expected the status bar to leave out navigation keys until help is open")
+	}
+
+	// Test case 1: '?' lists every shortcut, including navigation
+	m = typeText(m, "?")
+	if bar := renderStatusBar(m); !m.helpOpen || !strings.Contains(bar, "pgup/pgdn") || !strings.Contains(bar, "scroll a page") {
+		t.Errorf("Expected '?' to open the full help, got %q", bar)
+	}
+
+	// Test case 2: '?' again closes it
+	m = typeText(m, "?")
+	if m.helpOpen {
+		t.Error("Expected '?' to close the full help")
+	}
+
+	// Test case 3: In a text field '?' is typed, not taken as help
+	m.state = stateInputSourcePath
+	m.sourcePathInput.Focus()
+	m = typeText(m, "?")
+	if m.helpOpen || m.sourcePathInput.Value() != "?" {
+		t.Errorf("Expected '?' to be typed into the path, got %q", m.sourcePathInput.Value())
+	}
+}
+
+func TestRenderStatusBar(t *testing.T) {
+	// Test case 1: API status and default model before generation
+	m := Model{state: stateInputSourcePath, apiKeyOk: true, width: 100}
+	bar := renderStatusBar(m)
+	for _, element := range []string{"Step 2/5", "Gemini", "key ✓", api.DefaultModelName, "enter", "continue"} {
+		if !strings.Contains(bar, element) {
+			t.Errorf("Status bar should contain %q", element)
+		}
+	}
+
+	// Test case 2: Missing key and the model that produced the resume
+	m = Model{state: stateResultSuccess, modelName: api.DefaultFallbackModelName, width: 100}
+	bar = renderStatusBar(m)
+	if !strings.Contains(bar, "key missing") || !strings.Contains(bar, api.DefaultFallbackModelName) {
+		t.Errorf("Status bar should show the missing key and the producing model, got %q", bar)
+	}
+
+	// Test case 3: Replay mode needs no key
+	m = Model{state: stateWelcome, fixtures: api.Fixtures{Mode: api.FixtureReplay, Dir: "fixtures"}, width: 100}
+	bar = renderStatusBar(m)
+	if !strings.Contains(bar, "replaying fixtures") || strings.Contains(bar, "key missing") {
+		t.Errorf("Status bar should show replay mode instead of the key status, got %q", bar)
+	}
+}
+
+func TestViewIncludesStatusBar(t *testing.T) {
+	m := NewModel()
+	m.width = 100
+	m.height = 80
+	m.state = stateConfirmGenerate
+
+	view := m.View()
+	if !strings.Contains(view, "Step 4/5") || !strings.Contains(view, "generate") {
+		t.Error("Every view should end with the status bar")
+	}
+
+	// The status bar is pushed to the bottom of the terminal
+	if lines := strings.Count(view, "\n") + 1; lines != m.height {
+		t.Errorf("Expected the view to fill %d lines, got %d", m.height, lines)
+	}
+}
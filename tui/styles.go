@@ -1,32 +1,42 @@
 package tui
 
 import (
-	"strings"
-	
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Define a consistent color palette with high contrast for both light and dark themes
+// Define a consistent color palette with high contrast for both light and dark themes.
+// Terminals without truecolor get the nearest of the 256 colors, and terminals
+// with only the 16 standard colors get ones picked by hand to keep the contrast
+// (lipgloss detects which the terminal supports).
 var (
 	// Primary brand colors with high contrast
-	primaryColor   = lipgloss.AdaptiveColor{Light: "#0550AE", Dark: "#4C8FFF"} // Blue with good contrast in both modes
-	secondaryColor = lipgloss.AdaptiveColor{Light: "#0B6E63", Dark: "#25D1B7"} // Teal with good contrast in both modes
-	accentColor    = lipgloss.AdaptiveColor{Light: "#B07C00", Dark: "#FFCC3E"} // Gold with good contrast in both modes
+	primaryColor   = paletteColor("#0550AE", "25", "4", "#4C8FFF", "69", "12")  // Blue with good contrast in both modes
+	secondaryColor = paletteColor("#0B6E63", "23", "6", "#25D1B7", "43", "14")  // Teal with good contrast in both modes
+	accentColor    = paletteColor("#B07C00", "136", "3", "#FFCC3E", "221", "11") // Gold with good contrast in both modes
 	
 	// Semantic colors with high contrast
-	successColor   = lipgloss.AdaptiveColor{Light: "#1E6B38", Dark: "#4AE583"} // Green with good contrast in both modes
-	errorColor     = lipgloss.AdaptiveColor{Light: "#AE1F3D", Dark: "#FF6B80"} // Red with good contrast in both modes
+	successColor   = paletteColor("#1E6B38", "28", "2", "#4AE583", "78", "10")  // Green with good contrast in both modes
+	errorColor     = paletteColor("#AE1F3D", "125", "1", "#FF6B80", "204", "9") // Red with good contrast in both modes
 	
 	// Neutral colors for text and backgrounds
-	subtleColor    = lipgloss.AdaptiveColor{Light: "#777777", Dark: "#AAAAAA"} // Gray for subtle elements
-	textColor      = lipgloss.AdaptiveColor{Light: "#222222", Dark: "#E8E8E8"} // Main text color
-	bgAccentColor  = lipgloss.AdaptiveColor{Light: "#E8E8E8", Dark: "#333333"} // Slight contrast from background
-	highlightColor = lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"} // Maximum contrast
+	subtleColor    = paletteColor("#777777", "243", "8", "#AAAAAA", "248", "7")  // Gray for subtle elements
+	textColor      = paletteColor("#222222", "235", "0", "#E8E8E8", "254", "15") // Main text color
+	bgAccentColor  = paletteColor("#E8E8E8", "254", "7", "#333333", "236", "0")  // Slight contrast from background
+	highlightColor = paletteColor("#000000", "16", "0", "#FFFFFF", "231", "15")  // Maximum contrast
 	
 	// Focus colors
-	focusBorderColor = lipgloss.AdaptiveColor{Light: "#0550AE", Dark: "#4C8FFF"} // Same as primary for consistent styling
+	focusBorderColor = primaryColor // Same as primary for consistent styling
 )
 
+// paletteColor returns a color for light and dark backgrounds, each given in
+// truecolor, as one of the 256 colors, and as one of the 16 standard colors.
+func paletteColor(light, light256, light16, dark, dark256, dark16 string) lipgloss.CompleteAdaptiveColor {
+	return lipgloss.CompleteAdaptiveColor{
+		Light: lipgloss.CompleteColor{TrueColor: light, ANSI256: light256, ANSI: light16},
+		Dark:  lipgloss.CompleteColor{TrueColor: dark, ANSI256: dark256, ANSI: dark16},
+	}
+}
+
 // Base styles to be composed into more complex styles
 var (
 	// Italic text style
@@ -174,14 +184,3 @@ func VersionInfo(version string) string {
 		Italic(true).
 		Render("v" + version)
 }
-
-// KeyboardShortcuts formats a set of keyboard shortcuts consistently
-func KeyboardShortcuts(shortcuts map[string]string) string {
-	var lines []string
-	for key, description := range shortcuts {
-		lines = append(lines, lipgloss.NewStyle().Bold(true).Render(key+": ")+description)
-	}
-	
-	return keyboardHintStyle.
-		Render(strings.Join(lines, "\n"))
-}
\ No newline at end of file
@@ -3,6 +3,12 @@ package tui
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+	"github.com/phrazzld/resumake/prompt"
 )
 
 func TestEnhancedSuccessView(t *testing.T) {
@@ -25,7 +31,6 @@ func TestEnhancedSuccessView(t *testing.T) {
 		"Success",                   // Title element
 		"/tmp/resume_out.md",        // Output path
 		"2500 characters",           // Content length with label
-		"Enter",                     // Quit instruction
 	}
 	
 	for _, element := range essentialElements {
@@ -101,4 +106,282 @@ func TestEnhancedSuccessView(t *testing.T) {
 	if !strings.Contains(viewRelPath, "./relative/path/resume_out.md") {
 		t.Errorf("Success view should display relative paths correctly")
 	}
-}
\ No newline at end of file
+}
+func TestSuccessViewWithCoverLetter(t *testing.T) {
+	model := Model{
+		state:           stateResultSuccess,
+		outputPath:      "/tmp/resumake-2024-06-01/resume.md",
+		coverLetterPath: "/tmp/resumake-2024-06-01/cover_letter.md",
+		resultMessage:   "2500",
+		width:           100,
+		height:          24,
+	}
+
+	successView := renderSuccessView(model)
+
+	if !strings.Contains(successView, "cover letter") || !strings.Contains(successView, "cover_letter.md") {
+		t.Error("Success view should show where the cover letter was saved")
+	}
+}
+
+func TestSuccessViewModelName(t *testing.T) {
+	// Test case 1: The primary model is shown as-is
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		modelName:     api.DefaultModelName,
+		width:         100,
+	}
+
+	successView := renderSuccessView(model)
+	if !strings.Contains(successView, api.DefaultModelName) || strings.Contains(successView, "(fallback)") {
+		t.Error("Success view should show the primary model without a fallback note")
+	}
+
+	// Test case 2: The fallback model is labelled as such
+	model.modelName = api.DefaultFallbackModelName
+	successView = renderSuccessView(model)
+	if !strings.Contains(successView, api.DefaultFallbackModelName+" (fallback)") {
+		t.Error("Success view should show that the fallback model produced the resume")
+	}
+}
+
+func TestSuccessViewExports(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         120,
+		exports: []output.Export{
+			{Format: output.FormatDOCX, Path: "/tmp/resume_out.docx", Pandoc: true},
+			{Format: output.FormatPDF, Path: "/tmp/resume_out.html", Note: "pandoc is not installed"},
+		},
+	}
+
+	successView := renderSuccessView(model)
+	for _, element := range []string{"DOCX export", "/tmp/resume_out.docx", "PDF export", "/tmp/resume_out.html", "pandoc is not installed"} {
+		if !strings.Contains(successView, element) {
+			t.Errorf("Success view should contain %q", element)
+		}
+	}
+}
+
+func TestSuccessViewEmphasis(t *testing.T) {
+	model := Model{
+		state:           stateResultSuccess,
+		outputPath:      "/tmp/resume_out.md",
+		resultMessage:   "2500",
+		width:           120,
+		flagEmphasize:   []string{"kubernetes", "leadership", "grpc"},
+		missingKeywords: []string{"grpc"},
+	}
+
+	// Test case 1: Keywords the inputs could not support are named
+	successView := renderSuccessView(model)
+	if !strings.Contains(successView, "Not included, since your inputs do not show them: grpc") {
+		t.Error("Success view should warn about the missing keyword")
+	}
+
+	// Test case 2: Without missing keywords the emphasized ones are listed
+	model.missingKeywords = nil
+	successView = renderSuccessView(model)
+	if !strings.Contains(successView, "Emphasized: kubernetes, leadership, grpc") {
+		t.Error("Success view should list the emphasized keywords")
+	}
+}
+
+func TestSuccessViewNotes(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         120,
+		notesPath:     "/tmp/resume_out_notes.md",
+	}
+
+	// Test case 1: The notes file is listed with the resume
+	if !strings.Contains(renderSuccessView(model), "/tmp/resume_out_notes.md") {
+		t.Error("Success view should contain the notes path")
+	}
+
+	// Test case 2: A failed explanation says why there are no notes
+	model.notesPath = ""
+	model.explanationNote = "The explanation of the changes could not be generated (timeout), so no notes were saved"
+	if !strings.Contains(renderSuccessView(model), "could not be generated") {
+		t.Error("Success view should explain why no notes were saved")
+	}
+}
+
+func TestSuccessViewGaps(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         120,
+		gapsPath:      "/tmp/resume_out_gaps.md",
+	}
+
+	// Test case 1: The gaps appendix is listed with the resume
+	if !strings.Contains(renderSuccessView(model), "/tmp/resume_out_gaps.md") {
+		t.Error("Success view should contain the gaps appendix path")
+	}
+
+	// Test case 2: A resume covering the job says why there is no appendix
+	model.gapsPath = ""
+	model.gapsNote = "Your resume mentions every top keyword of the job description, so no gaps appendix was saved"
+	if !strings.Contains(renderSuccessView(model), "every top keyword") {
+		t.Error("Success view should explain why no gaps appendix was saved")
+	}
+}
+
+func TestSuccessViewLanguageWarning(t *testing.T) {
+	model := Model{
+		state:           stateResultSuccess,
+		outputPath:      "/tmp/resume_out.md",
+		resultMessage:   "2500",
+		width:           120,
+		languageWarning: "The resume is written in English, but your inputs are mostly in Spanish.",
+	}
+
+	// The warning offers to generate the resume again
+	view := renderSuccessView(model)
+	if !strings.Contains(view, "mostly in Spanish") || !strings.Contains(view, "Press R to generate it again") {
+		t.Error("Success view should warn about the language and offer to generate again")
+	}
+}
+
+func TestSuccessViewHeadingIssues(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         120,
+		headingIssues: []string{"There is no Skills section."},
+	}
+
+	// The sections the resume lacks are listed
+	if view := renderSuccessView(model); !strings.Contains(view, "There is no Skills section.") {
+		t.Error("Success view should list the heading issues")
+	}
+}
+
+func TestSuccessViewGrounding(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         120,
+		flagGrounding: prompt.GroundingFlag,
+		uncited:       []string{"Won an industry award"},
+	}
+
+	// Flagged bullets are listed for review
+	view := renderSuccessView(model)
+	if !strings.Contains(view, "1 bullet cites none of your inputs") || !strings.Contains(view, "- Won an industry award") {
+		t.Errorf("Success view should list the uncited bullets, got:\n%s", view)
+	}
+
+	// Dropped bullets are reported as left out
+	model.flagGrounding = prompt.GroundingDrop
+	if view := renderSuccessView(model); !strings.Contains(view, "Left out 1 bullet that cites none of your inputs") {
+		t.Errorf("Success view should report the dropped bullets, got:\n%s", view)
+	}
+
+	// A resume whose every bullet cites the inputs says so
+	model.uncited = nil
+	if view := renderSuccessView(model); !strings.Contains(view, "Every bullet cites your inputs") {
+		t.Error("Success view should confirm every bullet cites the inputs")
+	}
+}
+
+func TestSuccessViewCompany(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         120,
+		company:       prompt.CompanyProfile{Name: "Acme Corp", Keywords: []string{"Go", "Kafka"}},
+		companyNote:   "The job posting could not be read (404 Not Found), so only its URL was researched",
+	}
+
+	successView := renderSuccessView(model)
+	for _, element := range []string{"Tailored to Acme Corp", "Keywords: Go, Kafka", "could not be read"} {
+		if !strings.Contains(successView, element) {
+			t.Errorf("Success view should contain %q", element)
+		}
+	}
+}
+
+func TestSuccessViewHyperlinks(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         100,
+	}
+
+	// Without hyperlink support the path is plain text
+	plainView := renderSuccessView(model)
+	if strings.Contains(plainView, "\x1b]8;") {
+		t.Error("Success view should not write hyperlinks when the terminal does not support them")
+	}
+
+	// With it the path links to the file, and the text is otherwise unchanged
+	model.hyperlinks = true
+	linkedView := renderSuccessView(model)
+	if !strings.Contains(linkedView, "\x1b]8;;"+layout.FileURL("/tmp/resume_out.md")+"\x1b\\/tmp/resume_out.md") {
+		t.Error("Success view should link the output path to the file")
+	}
+	if ansi.Strip(linkedView) != ansi.Strip(plainView) {
+		t.Error("Hyperlinks should not change the text of the success view")
+	}
+}
+
+func TestSuccessViewASCIIIcons(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         100,
+		height:        40,
+		emoji:         true,
+	}
+	emojiView := model.View()
+	if !strings.Contains(emojiView, "🎉") {
+		t.Fatal("Success view should show emoji when the terminal draws them")
+	}
+
+	// Consoles without emoji get ASCII icons, and every line keeps its width
+	model.emoji = false
+	asciiView := model.View()
+	if strings.Contains(asciiView, "🎉") || strings.Contains(asciiView, "📄") {
+		t.Error("Success view should not show emoji when the terminal cannot draw them")
+	}
+	emojiLines, asciiLines := strings.Split(emojiView, "\n"), strings.Split(asciiView, "\n")
+	if len(emojiLines) != len(asciiLines) {
+		t.Fatalf("Expected %d lines, got %d", len(emojiLines), len(asciiLines))
+	}
+	for i := range emojiLines {
+		if ansi.StringWidth(emojiLines[i]) != ansi.StringWidth(asciiLines[i]) {
+			t.Errorf("Line %d changed width: %q", i, ansi.Strip(asciiLines[i]))
+		}
+	}
+}
+
+func TestSuccessViewSeed(t *testing.T) {
+	model := Model{
+		state:         stateResultSuccess,
+		outputPath:    "/tmp/resume_out.md",
+		resultMessage: "2500",
+		width:         120,
+	}
+	if strings.Contains(renderSuccessView(model), "Seed") {
+		t.Error("Success view should not mention a seed when none was given")
+	}
+
+	model = model.WithSeed(7)
+	if view := renderSuccessView(model); !strings.Contains(view, "Seed: 7") || !strings.Contains(view, "-seed 7") {
+		t.Error("Success view should show the seed and how to reuse it")
+	}
+}
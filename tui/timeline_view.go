@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/document"
+)
+
+// renderTimelineView draws the roles and education of the generated resume
+// on a timeline, with any gaps between them listed below the chart.
+func renderTimelineView(m Model) string {
+	displayWidth := getConstrainedWidth(m.width)
+	timeline := document.Parse(m.resultContent).Timeline(time.Now())
+
+	title := titleStyle.Render("🗓 Experience Timeline")
+
+	chart := timeline.Chart(displayWidth - 16)
+	if chart == "" {
+		chart = italicStyle.Render("No dated roles or education found in the generated resume.")
+	}
+	chartBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 2).
+		Width(displayWidth - 10).
+		Render(chart)
+
+	var gaps string
+	if len(timeline.Entries) == 0 {
+		gaps = italicStyle.Render("Add dates to your roles to check for gaps.")
+	} else if len(timeline.Gaps) == 0 {
+		gaps = successStyle.Render("✓ No gaps between roles")
+	} else {
+		var b strings.Builder
+		for _, gap := range timeline.Gaps {
+			b.WriteString("⚠️ " + gap.String() + "\n")
+		}
+		gaps = strings.TrimRight(b.String(), "\n") + "\n\n" +
+			italicStyle.Render("Consider explaining gaps, for example with study, freelance work, or caregiving.")
+	}
+	gapsBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accentColor).
+		Padding(1, 2).
+		Width(displayWidth - 10).
+		Render(lipgloss.NewStyle().Bold(true).Foreground(highlightColor).Render("⏳ Gaps") + "\n\n" + gaps)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		title,
+		"",
+		chartBox,
+		"",
+		gapsBox,
+	)
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTimelineView(t *testing.T) {
+	resume := "# Jane Doe\n\n## Experience\n\n### Senior Engineer | Globex | Jun 2019 – Present\n\n### Engineer | Initech | 2015 - Dec 2018"
+
+	// Test case 1: Roles are charted and the gap between them is listed
+	view := renderTimelineView(Model{state: stateTimeline, resultContent: resume, width: 100})
+	for _, element := range []string{"Experience Timeline", "Engineer · Initech", "█", "5-month gap: Jan 2019 – May 2019"} {
+		if !strings.Contains(view, element) {
+			t.Errorf("Timeline view should contain %q", element)
+		}
+	}
+
+	// Test case 2: A resume without dates explains why the chart is empty
+	view = renderTimelineView(Model{state: stateTimeline, resultContent: "# Jane Doe", width: 100})
+	if !strings.Contains(view, "No dated roles") {
+		t.Error("Timeline view should explain that no dated entries were found")
+	}
+}
+
+func TestTimelineStateTransitions(t *testing.T) {
+	model := NewModel()
+	model.state = stateResultSuccess
+	keyT := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}
+
+	// Test case 1: 't' opens the timeline from the success view
+	updated, _ := model.Update(keyT)
+	m := updated.(Model)
+	if m.state != stateTimeline {
+		t.Fatalf("Expected state to be stateTimeline, got %v", m.state)
+	}
+
+	// Test case 2: 't' returns to the success view
+	updated, _ = m.Update(keyT)
+	if updated.(Model).state != stateResultSuccess {
+		t.Errorf("Expected state to be stateResultSuccess, got %v", updated.(Model).state)
+	}
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/logging"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// uploadDeleteTimeout bounds deleting the uploaded sources on exit, so an
+// unreachable API cannot keep the program from quitting.
+const uploadDeleteTimeout = 5 * time.Second
+
+// uploadsSource reports whether the source is uploaded with the Files API
+// instead of inlined in the prompt: PDFs always are, and text sources are
+// when they are larger than api.UploadThreshold, unless strict grounding
+// numbers their sentences in the prompt.
+func uploadsSource(sourceContent string, opts GenerateOptions) bool {
+	if opts.Grounding.Strict() && !opts.SourcePDF {
+		return false
+	}
+	return opts.SourcePDF || len(sourceContent) > api.UploadThreshold
+}
+
+// sourceMIMEType returns the MIME type the source is uploaded as.
+func sourceMIMEType(opts GenerateOptions) string {
+	if opts.SourcePDF {
+		return input.PDFMIMEType
+	}
+	return input.TextMIMEType
+}
+
+// sourcePromptContent builds the prompt for the inputs, uploading the source
+// first when uploadsSource says so. It returns the name of the uploaded file,
+// which is deleted when the program exits. A text source that fails to
+// upload is inlined instead; a PDF has no text to fall back on, so its
+// failure is returned.
+//
+// Replayed requests are matched on their text alone, so with fixtures
+// replayed nothing is uploaded and the file part has no URI.
+func sourcePromptContent(ctx context.Context, uploader api.FileUploader, sourceContent, stdinContent string, opts GenerateOptions) (*genai.Content, string, error) {
+	if !uploadsSource(sourceContent, opts) {
+		return prompt.GeneratePromptContent(sourceContent, stdinContent), "", nil
+	}
+
+	mimeType := sourceMIMEType(opts)
+	if opts.Fixtures.Mode == api.FixtureReplay {
+		return prompt.GenerateUploadedPromptContent(genai.FileData{MIMEType: mimeType}, stdinContent), "", nil
+	}
+
+	var r io.Reader = strings.NewReader(sourceContent)
+	if opts.SourcePDF {
+		file, err := os.Open(opts.SourcePath)
+		if err != nil {
+			return nil, "", fmt.Errorf("error opening %s: %w", opts.SourcePath, err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	name := filepath.Base(opts.SourcePath)
+	if opts.SourcePath == "" {
+		name = "resume.txt"
+	}
+	file, err := api.UploadSource(ctx, uploader, name, mimeType, r)
+	if err != nil {
+		if opts.SourcePDF {
+			return nil, "", err
+		}
+		logging.Debugf("Inlining the source after the upload failed: %v", err)
+		return prompt.GeneratePromptContent(sourceContent, stdinContent), "", nil
+	}
+	return prompt.GenerateUploadedPromptContent(genai.FileData{MIMEType: file.MIMEType, URI: file.URI}, stdinContent), file.Name, nil
+}
+
+// keepUpload records the uploaded source on a generated result, so it is
+// kept for follow-up turns and deleted on exit. When generation failed the
+// upload is no longer needed and is deleted straight away.
+func keepUpload(msg tea.Msg, uploader api.FileUploader, name string) tea.Msg {
+	if name == "" {
+		return msg
+	}
+
+	switch result := msg.(type) {
+	case APIResultMsg:
+		if result.Success {
+			result.UploadedFile = name
+			return result
+		}
+	case SaveFailedMsg:
+		result.Result.UploadedFile = name
+		return result
+	}
+
+	deleteUploads(uploader, []string{name})
+	return msg
+}
+
+// rememberUpload adds an uploaded source to the files deleted on exit.
+func rememberUpload(m Model, name string) Model {
+	if name != "" && !slices.Contains(m.uploadedFiles, name) {
+		m.uploadedFiles = append(m.uploadedFiles, name)
+	}
+	return m
+}
+
+// deleteUploads removes uploaded sources from the Files API. Google deletes
+// them after 48 hours anyway, so failures are logged rather than returned.
+func deleteUploads(uploader api.FileUploader, names []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadDeleteTimeout)
+	defer cancel()
+
+	for _, name := range names {
+		if err := api.DeleteUpload(ctx, uploader, name); err != nil {
+			logging.Debugf("Could not delete the uploaded source %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/prompt"
+)
+
+// fakeUploader is a Files API that processes uploads immediately.
+type fakeUploader struct {
+	uploads   []string // Content of each upload
+	mimeTypes []string // MIME type of each upload
+	uploadErr error
+	deleted   []string
+}
+
+func (f *fakeUploader) UploadFile(ctx context.Context, name string, r io.Reader, opts *genai.UploadFileOptions) (*genai.File, error) {
+	if f.uploadErr != nil {
+		return nil, f.uploadErr
+	}
+	data, _ := io.ReadAll(r)
+	f.uploads = append(f.uploads, string(data))
+	f.mimeTypes = append(f.mimeTypes, opts.MIMEType)
+	return &genai.File{Name: "files/abc", URI: "https://example.com/files/abc", MIMEType: opts.MIMEType, State: genai.FileStateActive}, nil
+}
+
+func (f *fakeUploader) GetFile(ctx context.Context, name string) (*genai.File, error) {
+	return nil, errors.New("not processing")
+}
+
+func (f *fakeUploader) DeleteFile(ctx context.Context, name string) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func TestSourcePromptContent(t *testing.T) {
+	ctx := context.Background()
+	large := strings.Repeat("Built distributed systems in Go. ", api.UploadThreshold/32+1)
+
+	// Test case 1: A small text source is inlined
+	uploader := &fakeUploader{}
+	content, name, err := sourcePromptContent(ctx, uploader, "# Jane Doe", "notes", GenerateOptions{})
+	if err != nil || name != "" || len(content.Parts) != 1 || len(uploader.uploads) != 0 {
+		t.Errorf("Expected the source inlined without an upload, got %d parts, %q, %v", len(content.Parts), name, err)
+	}
+
+	// Test case 2: A large text source is uploaded and referenced
+	content, name, err = sourcePromptContent(ctx, uploader, large, "notes", GenerateOptions{SourcePath: "/tmp/resume.md"})
+	if err != nil || name != "files/abc" {
+		t.Fatalf("Expected the source to be uploaded, got %q, %v", name, err)
+	}
+	if file, ok := content.Parts[0].(genai.FileData); !ok || file.URI != "https://example.com/files/abc" {
+		t.Errorf("Expected the uploaded file first, got %v", content.Parts[0])
+	}
+	if strings.Contains(contentText(content), "distributed systems") {
+		t.Error("Expected the uploaded source to be left out of the prompt text")
+	}
+	if uploader.uploads[0] != large || uploader.mimeTypes[0] != input.TextMIMEType {
+		t.Errorf("Expected the text to be uploaded as %s, got %s", input.TextMIMEType, uploader.mimeTypes[0])
+	}
+
+	// Test case 3: A PDF is uploaded from its file
+	pdfPath := filepath.Join(t.TempDir(), "resume.pdf")
+	if err := os.WriteFile(pdfPath, []byte("%PDF-1.7"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	uploader = &fakeUploader{}
+	if _, name, err = sourcePromptContent(ctx, uploader, "", "notes", GenerateOptions{SourcePath: pdfPath, SourcePDF: true}); err != nil || name == "" {
+		t.Fatalf("Expected the PDF to be uploaded, got %q, %v", name, err)
+	}
+	if uploader.uploads[0] != "%PDF-1.7" || uploader.mimeTypes[0] != input.PDFMIMEType {
+		t.Errorf("Expected the PDF to be uploaded as %s, got %q as %s", input.PDFMIMEType, uploader.uploads[0], uploader.mimeTypes[0])
+	}
+
+	// Test case 4: A failed upload inlines text, but fails for a PDF
+	uploader = &fakeUploader{uploadErr: errors.New("connection reset")}
+	content, name, err = sourcePromptContent(ctx, uploader, large, "notes", GenerateOptions{})
+	if err != nil || name != "" || !strings.Contains(contentText(content), "distributed systems") {
+		t.Errorf("Expected the text to be inlined after a failed upload, got %q, %v", name, err)
+	}
+	if _, _, err = sourcePromptContent(ctx, uploader, "", "notes", GenerateOptions{SourcePath: pdfPath, SourcePDF: true}); err == nil {
+		t.Error("Expected a failed PDF upload to be an error")
+	}
+}
+
+func TestKeepUpload(t *testing.T) {
+	uploader := &fakeUploader{}
+
+	// Test case 1: A generated resume keeps the upload for follow-up turns
+	msg := keepUpload(APIResultMsg{Success: true}, uploader, "files/abc")
+	if result := msg.(APIResultMsg); result.UploadedFile != "files/abc" || len(uploader.deleted) != 0 {
+		t.Errorf("Expected the upload to be kept, got %+v (deleted %v)", result, uploader.deleted)
+	}
+
+	// Test case 2: A failed generation deletes the upload straight away
+	msg = keepUpload(APIResultMsg{Success: false, Error: errors.New("quota")}, uploader, "files/abc")
+	if msg.(APIResultMsg).UploadedFile != "" || len(uploader.deleted) != 1 {
+		t.Errorf("Expected the upload to be deleted, got %+v (deleted %v)", msg, uploader.deleted)
+	}
+}
+
+func TestReadSourceFileCmdPDF(t *testing.T) {
+	pdfPath := filepath.Join(t.TempDir(), "resume.pdf")
+	if err := os.WriteFile(pdfPath, []byte("%PDF-1.7\n..."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := ReadSourceFileCmd(pdfPath)().(FileReadResultMsg)
+	if !ok || !msg.Success || !msg.PDF || msg.Content != "" {
+		t.Fatalf("Expected a PDF without text content, got %+v", msg)
+	}
+
+	// The PDF is listed as uploaded before consent is given
+	m := NewModel().WithSourcePath(pdfPath)
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	items := consentSummary(m)
+	if len(items) != 1 || !strings.Contains(items[0], "uploaded as a PDF document") {
+		t.Errorf("Expected the PDF upload to be listed, got %v", items)
+	}
+}
+
+func TestGenerateReplaysUploadedSource(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	resume := api.ResumeStartDelimiter + "\n# Jane Doe\n\n## Experience\n\n- Acme\n" + api.ResumeEndDelimiter
+
+	// Record the response to the prompt that references the uploaded PDF
+	chat := &fakeChatSender{replies: []*genai.GenerateContentResponse{{
+		Candidates: []*genai.Candidate{{
+			Content:      &genai.Content{Parts: []genai.Part{genai.Text(resume)}},
+			FinishReason: genai.FinishReasonStop,
+		}},
+	}}}
+	recorder := api.Fixtures{Mode: api.FixtureRecord, Dir: dir}.WrapSender(chat, api.DefaultModelName)
+	content := prompt.GenerateUploadedPromptContent(genai.FileData{MIMEType: input.PDFMIMEType, URI: "https://example.com/files/abc"}, "stdin")
+	if _, err := api.NewSessionWithSender(recorder).Send(ctx, content); err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+
+	cmd := GenerateResumeWithOptionsCmd(ctx, nil, nil, "", "stdin", GenerateOptions{
+		OutputPath: filepath.Join(t.TempDir(), "resume.md"),
+		SourcePath: "resume.pdf",
+		SourcePDF:  true,
+		Fixtures:   api.Fixtures{Mode: api.FixtureReplay, Dir: dir},
+	})
+
+	msg, ok := cmd().(APIResultMsg)
+	if !ok || !msg.Success || !strings.Contains(msg.Content, "# Jane Doe") {
+		t.Fatalf("Expected the replayed resume, got %+v", msg)
+	}
+	if msg.UploadedFile != "" {
+		t.Errorf("Expected nothing to be uploaded while replaying, got %q", msg.UploadedFile)
+	}
+}
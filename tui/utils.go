@@ -1,64 +1,34 @@
 package tui
 
-import (
-	"strings"
+import "github.com/phrazzld/resumake/layout"
+
+// Input previews show about previewLines wrapped lines of text, and never
+// fewer than minPreviewLength characters on narrow terminals.
+const (
+	previewLines     = 3
+	minPreviewLength = 40
 )
 
-// wrapText wraps text at the specified width to ensure it fits in the terminal
-// It handles word wrapping, respecting word boundaries where possible
-func wrapText(text string, width int) string {
-	if width <= 0 {
-		width = 80 // Default to 80 if we don't have width info
-	}
-	
-	// Simple word wrapping
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return ""
-	}
-	
-	var lines []string
-	currentLine := ""
-	
-	for _, word := range words {
-		// Handle words longer than the width by breaking them
-		if len(word) > width {
-			// If we have content on the current line, add it to lines and start fresh
-			if currentLine != "" {
-				lines = append(lines, currentLine)
-				currentLine = ""
-			}
-			
-			// Split the long word into chunks
-			for len(word) > 0 {
-				if len(word) <= width {
-					// Last piece fits on its own line
-					lines = append(lines, word)
-					word = ""
-				} else {
-					// Take a width-sized chunk and continue
-					lines = append(lines, word[:width])
-					word = word[width:]
-				}
-			}
-		} else if len(currentLine)+len(word)+1 > width && currentLine != "" {
-			// Word would exceed line width, start a new line
-			lines = append(lines, currentLine)
-			currentLine = word
-		} else {
-			// Add word to current line with space if needed
-			if currentLine == "" {
-				currentLine = word
-			} else {
-				currentLine += " " + word
-			}
-		}
+// previewLength returns how many characters of input to preview in a box
+// whose text is width characters wide, so larger terminals show more.
+func previewLength(width int) int {
+	return max(width*previewLines, minPreviewLength)
+}
+
+// pathLink returns a file path to show, linked to the file when the
+// terminal supports hyperlinks so clicking it opens the file.
+func pathLink(m Model, path string) string {
+	if !m.hyperlinks {
+		return path
 	}
-	
-	// Add the last line if it has content
-	if currentLine != "" {
-		lines = append(lines, currentLine)
+	return layout.Hyperlink(layout.FileURL(path), path)
+}
+
+// linkURLs makes the web addresses in text clickable when the terminal
+// supports hyperlinks.
+func linkURLs(m Model, text string) string {
+	if !m.hyperlinks {
+		return text
 	}
-	
-	return strings.Join(lines, "\n")
-}
\ No newline at end of file
+	return layout.LinkURLs(text)
+}
@@ -3,8 +3,15 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 	
 	"github.com/charmbracelet/lipgloss"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/prompt"
+	"github.com/phrazzld/resumake/output"
 )
 
 // Helper function to constrain display width within reasonable bounds
@@ -21,11 +28,6 @@ func getConstrainedWidth(width int) int {
 
 // renderWelcomeView generates the welcome screen content
 func renderWelcomeView(m Model) string {
-	// Use the shared wrapText utility for consistent text wrapping
-	wrap := func(text string, width int) string {
-		return wrapText(text, width)
-	}
-	
 	// Calculate display width
 	displayWidth := getConstrainedWidth(m.width)
 	
@@ -46,23 +48,8 @@ func renderWelcomeView(m Model) string {
 		Align(lipgloss.Center).
 		Render("Create Professional Resumes with AI")
 		
-	// API key status
-	var apiStatus string
-	if m.apiKeyOk {
-		apiStatus = successStyle.Render("✓ API key is valid and ready to use")
-	} else {
-		apiStatus = errorStyle.Render("✗ API key is missing")
-		apiStatus += "\n\n" + errorStyle.Render("To use Resumake, you need a Google Gemini API key")
-		apiStatus += "\n" + pathStyle.Render("export GEMINI_API_KEY=your_key_here")
-	}
-	
-	// Choose border color based on API key status
-	var borderColor lipgloss.AdaptiveColor
-	if m.apiKeyOk {
-		borderColor = successColor
-	} else {
-		borderColor = errorColor
-	}
+	// API key status, with the specific diagnosis when there is one
+	apiStatus, borderColor := renderAPIKeyStatus(m, displayWidth-24)
 	
 	apiBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -73,9 +60,9 @@ func renderWelcomeView(m Model) string {
 		
 	// Steps section
 	stepsText := lipgloss.NewStyle().Bold(true).Render("How it works:") + "\n\n" +
-		"1. " + wrap("Optionally provide an existing resume to enhance", displayWidth-20) + "\n\n" +
-		"2. " + wrap("Tell us about your experience and skills", displayWidth-20) + "\n\n" +
-		"3. " + wrap("Get your polished resume in markdown format", displayWidth-20)
+		layout.Wrap("1. Optionally provide an existing resume to enhance\n\n"+
+			"2. Tell us about your experience and skills\n\n"+
+			"3. Get your polished resume in markdown format", displayWidth-20)
 	
 	stepsBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -84,6 +71,17 @@ func renderWelcomeView(m Model) string {
 		Width(displayWidth-20).
 		Render(stepsText)
 	
+	// Recently used files, when there are any
+	var recentBox string
+	if len(m.recent) > 0 {
+		recentBox = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(secondaryColor).
+			Padding(1).
+			Width(displayWidth-20).
+			Render(renderRecent(m.recent, displayWidth-24))
+	}
+	
 	// Call to action
 	callToAction := lipgloss.NewStyle().
 		Bold(true).
@@ -103,22 +101,60 @@ func renderWelcomeView(m Model) string {
 		"",
 		stepsBox,
 		"",
-		callToAction,
 	)
+	if recentBox != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, recentBox, "")
+	}
+	content = lipgloss.JoinVertical(lipgloss.Center, content, callToAction)
 	
 	return docStyle.Render(content)
 }
 
+// renderAPIKeyStatus describes the API key on the welcome screen: what the
+// key check found and what to do about it, wrapped to width, and the border
+// color of the box it is shown in.
+func renderAPIKeyStatus(m Model, width int) (string, lipgloss.CompleteAdaptiveColor) {
+	diagnosis := m.keyDiagnosis
+	switch {
+	case m.keyChecking:
+		return tipStyle.Render(layout.Wrap("⋯ Checking your API key with the Gemini API...", width)), secondaryColor
+	case diagnosis.Status == "" && m.apiKeyOk:
+		return successStyle.Render("✓ API key is valid and ready to use"), successColor
+	case diagnosis.Status == "":
+		diagnosis = api.DiagnoseAPIKey("")
+	}
+	
+	switch diagnosis.Status {
+	case api.KeyValid, api.KeyUnverified:
+		status := successStyle.Render(layout.Wrap("✓ "+diagnosis.Summary, width))
+		if diagnosis.Advice != "" {
+			status += "\n\n" + tipStyle.Render(layout.Wrap(diagnosis.Advice, width))
+		}
+		return status, successColor
+	case api.KeyUnreachable:
+		warningStyle := lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+		status := warningStyle.Render(layout.Wrap("⚠️ "+diagnosis.Summary, width))
+		status += "\n\n" + tipStyle.Render(layout.Wrap(diagnosis.Advice, width))
+		return status, accentColor
+	case api.KeyMissing:
+		status := errorStyle.Render("✗ API key is missing")
+		status += "\n\n" + errorStyle.Render(layout.Wrap("To use Resumake, you need a Google Gemini API key", width))
+		status += "\n" + pathStyle.Render("export GEMINI_API_KEY=your_key_here")
+		return status, errorColor
+	}
+	
+	status := errorStyle.Render(layout.Wrap("✗ "+diagnosis.Summary, width))
+	if diagnosis.Advice != "" {
+		status += "\n\n" + layout.Wrap(diagnosis.Advice, width)
+	}
+	return status, errorColor
+}
+
 // renderSourceFileInputView generates the enhanced source file input view content
 func renderSourceFileInputView(m Model) string {
 	// Calculate display width
 	displayWidth := getConstrainedWidth(m.width)
 	
-	// Use the shared wrapText utility for consistent text wrapping
-	wrap := func(text string, width int) string {
-		return wrapText(text, width)
-	}
-	
 	// Create a centered title with high contrast
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -130,7 +166,7 @@ func renderSourceFileInputView(m Model) string {
 		Render("📄 Source File Input")
 	
 	// Create a description section explaining the purpose
-	description := wrap(
+	description := layout.Wrap(
 		"Provide an existing resume file to enhance. Resumake will use this as a " +
 		"starting point to generate an improved version with better formatting and content.",
 		displayWidth - 8)
@@ -171,23 +207,15 @@ func renderSourceFileInputView(m Model) string {
 	
 	tipsContent := "• This step is optional. Press Enter to continue without a source file\n" +
 		"• Supported file formats: .txt, .md, .markdown\n" +
-		"• Example path: /home/user/documents/my_resume.md or ./resume.txt\n" +
+		"• Example path: /home/user/documents/my_resume.md, ~/resume.md, or ./resume.txt\n" +
+		"• ~ and environment variables such as $HOME are expanded\n" +
 		"• Maximum file size: 10MB\n" +
 		"• Using a source file can significantly improve the quality of your generated resume"
 	
 	// If terminal is narrow, wrap the tips content
-	tipsContent = wrap(tipsContent, displayWidth - 12)
+	tipsContent = layout.Wrap(tipsContent, displayWidth - 12)
 	
-	// Keyboard shortcuts section
-	shortcutsTitle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(highlightColor).
-		Render("Keyboard Shortcuts")
-	
-	shortcutsContent := "• Enter: Continue to next step\n" +
-		"• Ctrl+C: Quit application"
-	
-	// Put instructions, input, and shortcuts in a main content box
+	// Put instructions and input in a main content box
 	mainContent := lipgloss.JoinVertical(
 		lipgloss.Left,
 		instructionsTitle,
@@ -195,10 +223,6 @@ func renderSourceFileInputView(m Model) string {
 		instructionsContent,
 		"",
 		styledInputView,
-		"",
-		shortcutsTitle,
-		"",
-		shortcutsContent,
 	)
 	
 	mainContentBox := lipgloss.NewStyle().
@@ -239,11 +263,6 @@ func renderStdinInputView(m Model) string {
 	// Calculate display width
 	displayWidth := getConstrainedWidth(m.width)
 	
-	// Use the shared wrapText utility for consistent text wrapping
-	wrap := func(text string, width int) string {
-		return wrapText(text, width)
-	}
-	
 	// Create a centered title with high contrast
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -258,12 +277,22 @@ func renderStdinInputView(m Model) string {
 	keyboardGuide := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(accentColor).
-		Render("💡 Tip: Enter your details below, then press Ctrl+D when finished")
+		Render(layout.Wrap("💡 Tip: Enter your details below, then press Ctrl+D when finished", displayWidth-8))
 	
 	// Create a description section explaining the purpose
-	description := wrap(
-		"Tell us about your professional background. Include your experience, skills, education, and achievements.",
-		displayWidth - 8)
+	descriptionText := "Tell us about your professional background. Include your experience, skills, education, and achievements."
+	if m.flagAmend {
+		descriptionText = "Tell us what has changed since your last resume, such as a new job, a promotion, or a finished project. " +
+			"The rest of your resume is kept as it is."
+	}
+	description := layout.Wrap(descriptionText, displayWidth - 8)
+	
+	// Warnings about the source file are shown here rather than printed over the screen
+	for _, warning := range []string{m.sourceWarning, m.jobWarning} {
+		if warning != "" {
+			description += "\n\n" + errorStyle.Render(layout.Wrap("⚠️ "+warning, displayWidth - 8))
+		}
+	}
 	
 	// Style for the textarea container with focus-aware styling
 	textareaContent := m.stdinInput.View()
@@ -290,6 +319,13 @@ func renderStdinInputView(m Model) string {
 		styledTextareaView,
 	)
 	
+	// The sections detected in the notes are summarized below the textarea
+	// when there is no room for the outline sidebar
+	sidebar := notesOutlineSidebar(m)
+	if outlineLine := renderNotesOutlineLine(m, displayWidth - 12); !sidebar && outlineLine != "" {
+		inputSection = lipgloss.JoinVertical(lipgloss.Left, inputSection, "", outlineLine)
+	}
+	
 	// Style the input section box
 	inputSectionBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -298,6 +334,11 @@ func renderStdinInputView(m Model) string {
 		Width(displayWidth - 4).
 		Render(inputSection)
 	
+	// Wide terminals show the outline of the notes beside them as they are typed
+	if sidebar {
+		inputSectionBox = lipgloss.JoinHorizontal(lipgloss.Top, inputSectionBox, " ", renderNotesOutline(m, notesOutlineWidth))
+	}
+	
 	// Create a suggestions section
 	suggestionsTitle := lipgloss.NewStyle().
 		Bold(true).
@@ -312,7 +353,7 @@ func renderStdinInputView(m Model) string {
 		"• Highlight metrics and results when possible (e.g., 'increased sales by 20%')"
 	
 	// If terminal is narrow, wrap the suggestions content
-	suggestionsContent = wrap(suggestionsContent, displayWidth - 12)
+	suggestionsContent = layout.Wrap(suggestionsContent, displayWidth - 12)
 	
 	// Create a formatting examples section
 	examplesTitle := lipgloss.NewStyle().
@@ -320,7 +361,7 @@ func renderStdinInputView(m Model) string {
 		Foreground(highlightColor).
 		Render("Example Format:")
 	
-	examplesContent := wrap(
+	examplesContent := layout.Wrap(
 		"Work Experience:\n"+
 		"- Senior Software Engineer at XYZ Corp (2019-2023)\n"+
 		"- Led a team of 5 developers to deliver a new product feature\n"+
@@ -349,6 +390,11 @@ func renderStdinInputView(m Model) string {
 		Width(displayWidth - 4).
 		Render(tipsContent)
 	
+	// Show the snippets picker in place of the tips while it is open
+	if m.snippetPickerActive {
+		tipsBox = renderSnippetPicker(m, displayWidth - 4)
+	}
+	
 	// Compose the complete view with the keyboard guide at the top for visibility
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -369,11 +415,6 @@ func renderConfirmGenerateView(m Model) string {
 	// Calculate display width
 	displayWidth := getConstrainedWidth(m.width)
 	
-	// Use the shared wrapText utility for consistent text wrapping
-	wrap := func(text string, width int) string {
-		return wrapText(text, width)
-	}
-	
 	// Create a centered title with high contrast
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -394,30 +435,81 @@ func renderConfirmGenerateView(m Model) string {
 	var summaryContent strings.Builder
 	
 	// Add source file info if provided
-	if m.sourceContent != "" {
-		sourceInfo := fmt.Sprintf("📄 Source file: %s", m.sourcePathInput.Value())
-		summaryContent.WriteString(wrap(sourceInfo, displayWidth - 16) + "\n\n")
+	if m.sourceContent != "" || m.sourcePDF {
+		sourceInfo := fmt.Sprintf("📄 Source file: %s", input.ResolvePath(m.sourcePathInput.Value()))
+		if m.sourcePDF {
+			sourceInfo += " (PDF, sent as a document)"
+		}
+		if m.structure != nil {
+			sourceInfo += fmt.Sprintf(" (written by resumake; its %d sections are kept in order)", len(m.structure.Sections))
+		}
+		summaryContent.WriteString(layout.Wrap(sourceInfo, displayWidth - 16) + "\n\n")
 	}
 	
-	// Add input content summary (truncated)
-	inputLength := len(m.stdinContent)
+	// Add input content summary (truncated to fit the terminal)
+	inputLength := utf8.RuneCountInString(m.stdinContent)
 	if inputLength > 0 {
-		var contentPreview string
-		if inputLength > 100 {
-			contentPreview = m.stdinContent[:97] + "..."
-		} else {
-			contentPreview = m.stdinContent
-		}
+		// The preview is a single paragraph, whatever line breaks the input has
+		contentPreview := layout.Truncate(strings.Join(strings.Fields(m.stdinContent), " "), previewLength(displayWidth - 16))
 		
 		contentInfo := fmt.Sprintf("✏️ Input: %d characters\n\n", inputLength)
 		summaryContent.WriteString(contentInfo)
-		summaryContent.WriteString(wrap("Preview: "+contentPreview, displayWidth - 16))
+		summaryContent.WriteString(layout.Wrap("Preview: "+contentPreview, displayWidth - 16))
 	}
 	
 	// Add output path info if provided via flags
-	if m.flagOutputPath != "" {
-		outputInfo := fmt.Sprintf("\n\n📁 Output path: %s", m.flagOutputPath)
-		summaryContent.WriteString(wrap(outputInfo, displayWidth - 16))
+	if m.flagOutputPath == output.StdoutPath {
+		summaryContent.WriteString(layout.Wrap("\n\n📁 Output: standard output, when resumake exits", displayWidth - 16))
+	} else if m.flagOutputPath != "" {
+		outputInfo := fmt.Sprintf("\n\n📁 Output path: %s", input.ResolvePath(m.flagOutputPath))
+		summaryContent.WriteString(layout.Wrap(outputInfo, displayWidth - 16))
+	} else {
+		// The default file name is only known once the resume is written
+		outputInfo := fmt.Sprintf("\n\n📁 Output folder: %s", input.ResolvePath("."))
+		summaryContent.WriteString(layout.Wrap(outputInfo, displayWidth - 16))
+	}
+	
+	// Structured skills are optional and edited from this screen
+	skillsInfo := "🧰 Skills: none entered (press S to add structured skills)"
+	if len(m.skills) > 0 {
+		skillsInfo = fmt.Sprintf("🧰 Skills: %d entered (press S to edit)", len(m.skills))
+	}
+	summaryContent.WriteString("\n\n" + layout.Wrap(skillsInfo, displayWidth - 16))
+	
+	// Experiences matching the job description are ranked here, not by the API
+	if matches := relevantExperiences(m.sourceContent, m.stdinContent, generateOptions(m)); len(matches) > 0 {
+		matchInfo := fmt.Sprintf("🎯 Job match: the %d experiences closest to the job description will be featured (press P to see them)", len(matches))
+		if len(matches) == 1 {
+			matchInfo = "🎯 Job match: the experience closest to the job description will be featured (press P to see it)"
+		}
+		summaryContent.WriteString("\n\n" + layout.Wrap(matchInfo, displayWidth - 16))
+	}
+	
+	// Bundle mode makes a second API call for the cover letter
+	if m.flagBundle {
+		summaryContent.WriteString("\n\n" + layout.Wrap("✉️ Bundle mode: a matching cover letter will also be generated", displayWidth - 16))
+	}
+	
+	// Each document is another request on the resume's conversation
+	if len(m.flagDocuments) > 0 {
+		summaryContent.WriteString("\n\n" + layout.Wrap("📝 Documents: "+documentTitles(m.flagDocuments)+" will also be written", displayWidth - 16))
+	}
+	
+	// Free tiers allow few requests, so show what the ledger says is left
+	if m.quota != nil {
+		summaryContent.WriteString("\n\n" + layout.Wrap("📊 Quota for "+m.quota.Model+": "+m.quota.Summary(), displayWidth - 16))
+		warning := m.quota.Warning(plannedRequests(m))
+		if warning != "" {
+			summaryContent.WriteString("\n" + errorStyle.Render(layout.Wrap("⚠️ "+warning, displayWidth - 16)))
+		}
+		
+		// The fallback model is only warned about when the run is likely to need it
+		if m.fallbackQuota != nil {
+			summaryContent.WriteString("\n" + layout.Wrap("📊 Quota for the fallback model, "+m.fallbackQuota.Model+": "+m.fallbackQuota.Summary(), displayWidth - 16))
+			if fallbackWarning := m.fallbackQuota.Warning(plannedRequests(m)); warning != "" && fallbackWarning != "" {
+				summaryContent.WriteString("\n" + errorStyle.Render(layout.Wrap("⚠️ "+fallbackWarning, displayWidth - 16)))
+			}
+		}
 	}
 	
 	// Build the summary box
@@ -437,10 +529,7 @@ func renderConfirmGenerateView(m Model) string {
 	instruction := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(accentColor).
-		Render("Press Enter to confirm and generate your resume")
-	
-	// Add hint about ESC
-	hint := italicStyle.Render("Press ESC to go back and edit your input")
+		Render(layout.Wrap("Press Enter to confirm and generate your resume", displayWidth-8))
 	
 	// Compose the complete view
 	return lipgloss.JoinVertical(
@@ -449,21 +538,17 @@ func renderConfirmGenerateView(m Model) string {
 		"",
 		summaryBox,
 		"",
-		instruction,
+		renderPromptPreview(m),
 		"",
-		hint,
+		instruction,
 	)
 }
 
 // renderGeneratingView generates the view during resume generation
 func renderGeneratingView(m Model) string {
-	// Use the shared wrapText utility for consistent text wrapping
-	wrap := func(text string, width int) string {
-		return wrapText(text, width)
-	}
-	
-	// Calculate display width
-	displayWidth := getConstrainedWidth(m.width)
+	// Calculate display width, narrower than usual on small terminals so
+	// the boxes keep their borders
+	displayWidth := fitWidth(m.width)
 	
 	// Create a title with high contrast
 	title := lipgloss.NewStyle().
@@ -500,7 +585,7 @@ func renderGeneratingView(m Model) string {
 			lipgloss.Center,
 			stepTitle,
 			"",
-			wrap(m.progressMsg, displayWidth - 10),
+			layout.Wrap(m.progressMsg, displayWidth - 10),
 		)
 		
 		// Put it in a nice box
@@ -512,20 +597,30 @@ func renderGeneratingView(m Model) string {
 		progressIndicator = spinnerIcon + " " + lipgloss.NewStyle().Bold(true).Render("Processing your information...")
 	}
 	
-	// Display input information
-	inputInfo := lipgloss.JoinVertical(
-		lipgloss.Left,
-		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Processing %d characters of input", totalChars)),
-	)
+	// Display input information: the size of the request and of the
+	// response streamed so far once the prompt is sent
+	inputInfo := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Processing %d characters of input", totalChars))
+	var elapsed string
+	if m.metrics != nil {
+		var sent, received string
+		sent, received, elapsed = m.metrics.Lines(time.Now())
+		if sent != "" {
+			inputInfo = lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.NewStyle().Bold(true).Render(sent),
+				received,
+			)
+		}
+	}
 	
 	// Show source file info if provided
-	if m.sourceContent != "" {
+	if m.sourceContent != "" || m.sourcePDF {
 		sourceInfo := "Source file: " + m.sourcePathInput.Value()
 		inputInfo = lipgloss.JoinVertical(
 			lipgloss.Left,
 			inputInfo,
 			"",
-			wrap(sourceInfo, displayWidth-8),
+			layout.Wrap(sourceInfo, displayWidth-8),
 		)
 	}
 	
@@ -534,15 +629,15 @@ func renderGeneratingView(m Model) string {
 		Width(displayWidth - 6).
 		Render(inputInfo)
 	
-	// Show estimated time
-	estimatedTime := tipStyle.Render(wrap("This may take up to 60 seconds depending on the input size.", displayWidth-8))
+	// Show how long generation has taken so far
+	elapsedTime := tipStyle.Render(layout.Wrap("⏱ "+elapsed, displayWidth-8))
 	
 	// Additional information about the generation process
 	processInfo := lipgloss.JoinVertical(
 		lipgloss.Left,
-		wrap("The Gemini API is analyzing your experience and crafting a professional resume.", displayWidth-8),
+		layout.Wrap("The Gemini API is analyzing your experience and crafting a professional resume.", displayWidth-8),
 		"",
-		wrap("You'll be able to review and save the result when it's complete.", displayWidth-8),
+		layout.Wrap("You'll be able to review and save the result when it's complete.", displayWidth-8),
 	)
 	
 	// Create a styled process info box
@@ -550,19 +645,13 @@ func renderGeneratingView(m Model) string {
 		Width(displayWidth - 6).
 		Render(processInfo)
 	
-	// Compose the complete view with all sections
-	return lipgloss.JoinVertical(
-		lipgloss.Center,
-		title,
-		"",
-		progressIndicator,
-		"",
-		inputInfoBox,
-		"",
-		estimatedTime,
-		"",
-		processInfoBox,
-	)
+	// Compose the view, leaving out the least important boxes when the
+	// terminal is too short to show them all above the status bar
+	sections := []string{title, progressIndicator, inputInfoBox, processInfoBox}
+	if elapsed != "" {
+		sections = []string{title, progressIndicator, inputInfoBox, elapsedTime, processInfoBox}
+	}
+	return fitSections(sections, m.height-lipgloss.Height(renderStatusBar(m)), 2)
 }
 
 // renderSuccessView generates the enhanced success view with celebratory elements
@@ -570,11 +659,6 @@ func renderSuccessView(m Model) string {
 	// Calculate display width
 	displayWidth := getConstrainedWidth(m.width)
 	
-	// Use the shared wrapText utility for consistent text wrapping
-	wrap := func(text string, width int) string {
-		return wrapText(text, width)
-	}
-	
 	// Create a celebratory title with high contrast
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -602,7 +686,7 @@ func renderSuccessView(m Model) string {
 	
 	// Calculate input stats
 	sourceFileInfo := ""
-	if m.sourceContent != "" {
+	if m.sourceContent != "" || m.sourcePDF {
 		sourceFile := m.sourcePathInput.Value()
 		sourceFileInfo = fmt.Sprintf("📄 Source file: %s\n\n", sourceFile)
 	}
@@ -615,6 +699,87 @@ func renderSuccessView(m Model) string {
 	
 	statsContent := fmt.Sprintf("%s📏 Size: %s\n\n⏱️ Generated in seconds", sourceFileInfo, contentLength)
 	
+	// Show which model produced the resume, noting when the fallback was used
+	if m.modelName != "" {
+		modelInfo := m.modelName
+		if m.modelName != api.DefaultModelName {
+			modelInfo += " (fallback)"
+		}
+		statsContent += fmt.Sprintf("\n\n🤖 Model: %s", modelInfo)
+	}
+	
+	// A seeded run can be repeated exactly, so show how
+	if m.seed != nil {
+		statsContent += "\n\n" + layout.Wrap(fmt.Sprintf("🎲 Seed: %d (temperature 0); run again with -seed %d and the same inputs to get this resume again", *m.seed, *m.seed), displayWidth-20)
+	}
+
+	// List what was trimmed from the inputs to fit the model's context window
+	if len(m.trimmedInputs) > 0 {
+		statsContent += "\n\n✂️ Trimmed to fit the context window:"
+		for _, trimmed := range m.trimmedInputs {
+			statsContent += "\n" + layout.Wrap("• "+trimmed, displayWidth-20)
+		}
+	}
+
+	// Warn that the resume may be incomplete, such as when the time limit cut it short
+	if m.truncatedMsg != "" {
+		statsContent += "\n\n" + errorStyle.Render(layout.Wrap("⚠️ "+m.truncatedMsg, displayWidth-20))
+	}
+
+	// Show the employer the resume was tailored to, or why it was not
+	if !m.company.Empty() {
+		name := m.company.Name
+		if name == "" {
+			name = m.flagCompany
+		}
+		statsContent += "\n\n" + layout.Wrap("🏢 Tailored to "+name, displayWidth-20)
+		if len(m.company.Keywords) > 0 {
+			statsContent += "\n" + layout.Wrap("Keywords: "+strings.Join(m.company.Keywords, ", "), displayWidth-20)
+		}
+	}
+	if m.companyNote != "" {
+		statsContent += "\n\n" + italicStyle.Render(layout.Wrap(m.companyNote, displayWidth-20))
+	}
+
+	// Warn about emphasized keywords the inputs could not support
+	if len(m.missingKeywords) > 0 {
+		statsContent += "\n\n" + errorStyle.Render(layout.Wrap("⚠️ Not included, since your inputs do not show them: "+strings.Join(m.missingKeywords, ", "), displayWidth-20))
+	} else if len(m.flagEmphasize) > 0 {
+		statsContent += "\n\n" + layout.Wrap("🔑 Emphasized: "+strings.Join(m.flagEmphasize, ", "), displayWidth-20)
+	}
+
+	// Warn about heading problems that could not be fixed, such as a missing required section
+	if len(m.headingIssues) > 0 {
+		statsContent += "\n\n" + errorStyle.Render(layout.Wrap("⚠️ "+strings.Join(m.headingIssues, " "), displayWidth-20))
+	}
+
+	// List the bullets strict grounding found citing none of the inputs
+	if note := groundingNote(m.flagGrounding, m.uncited); note != "" {
+		style := lipgloss.NewStyle()
+		if len(m.uncited) > 0 {
+			style = errorStyle
+		}
+		statsContent += "\n\n" + style.Render(layout.Wrap(note, displayWidth-20))
+	}
+	
+	// Warn about a resume in another language than the inputs, and offer to try again
+	if m.languageWarning != "" {
+		statsContent += "\n\n" + errorStyle.Render(layout.Wrap("⚠️ "+m.languageWarning+" Press R to generate it again.", displayWidth-20))
+	}
+
+	// Count the changes since the last resume saved for the same candidate
+	if m.previousRevision != nil {
+		statsContent += "\n\n" + layout.Wrap(revisionSummary(m)+" (press D to review)", displayWidth-20)
+	}
+	
+	// Confirm a section regenerated on its own, which only updates the Markdown and HTML
+	if m.sectionNote != "" {
+		statsContent += "\n\n" + layout.Wrap(m.sectionNote+" and saved the resume again", displayWidth-20)
+		if m.jsonPath != "" || len(m.exports) > 0 || m.packPath != "" {
+			statsContent += "\n" + italicStyle.Render(layout.Wrap("The JSON Resume, document exports, and pack still have the earlier version", displayWidth-20))
+		}
+	}
+
 	statsBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(successColor).
@@ -632,7 +797,100 @@ func renderSuccessView(m Model) string {
 		lipgloss.NewStyle().
 			Background(bgAccentColor).
 			Padding(0, 1).
-			Render(m.outputPath))
+			Render(pathLink(m, m.outputPath)))
+	if m.outputPath == output.StdoutPath {
+		pathText = "Your resume will be written to standard output when resumake exits."
+	}
+	
+	// Mention the cover letter when one was generated alongside the resume
+	if m.coverLetterPath != "" {
+		pathText += fmt.Sprintf("\n\nYour cover letter is saved at:\n\n%s",
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, m.coverLetterPath)))
+	}
+	
+	// Mention the HTML version when a layout was requested
+	if m.htmlPath != "" {
+		pathText += fmt.Sprintf("\n\nThe HTML version (%s layout) is saved at:\n\n%s",
+			m.flagLayout,
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, m.htmlPath)))
+	}
+	
+	// Mention the JSON Resume export when one was written
+	if m.jsonPath != "" {
+		pathText += fmt.Sprintf("\n\nThe JSON Resume export is saved at:\n\n%s",
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, m.jsonPath)))
+		if note := jsonWarningsNote(m.jsonWarnings); note != "" {
+			pathText += "\n\n" + tipStyle.Render(layout.Wrap(note, displayWidth - 8))
+		}
+	}
+	
+	// Mention the notes explaining the changes, or why there are none
+	if m.notesPath != "" {
+		pathText += fmt.Sprintf("\n\nThe notes on what changed and why are saved at:\n\n%s",
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, m.notesPath)))
+	}
+	if m.explanationNote != "" {
+		pathText += "\n\n" + italicStyle.Render(layout.Wrap(m.explanationNote, displayWidth-20))
+	}
+	
+	// Mention the suggestions for closing the gaps with the job, or why there are none
+	if m.gapsPath != "" {
+		pathText += fmt.Sprintf("\n\nCourses, certifications, and projects to close the gaps with the job are saved at:\n\n%s",
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, m.gapsPath)))
+	}
+	if m.gapsNote != "" {
+		pathText += "\n\n" + italicStyle.Render(layout.Wrap(m.gapsNote, displayWidth-20))
+	}
+	
+	// List the documents written alongside the resume, or why one is missing
+	for _, document := range m.documents {
+		pathText += fmt.Sprintf("\n\nYour %s is saved at:\n\n%s",
+			document.Type.Title(),
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, document.Path)))
+	}
+	if m.documentsNote != "" {
+		pathText += "\n\n" + italicStyle.Render(layout.Wrap(m.documentsNote, displayWidth-20))
+	}
+	
+	// List the document exports, with the reason for any fallback
+	for _, export := range m.exports {
+		pathText += fmt.Sprintf("\n\nThe %s export is saved at:\n\n%s",
+			strings.ToUpper(string(export.Format)),
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, export.Path)))
+		if export.Note != "" {
+			pathText += "\n" + italicStyle.Render(layout.Wrap(export.Note, displayWidth-20))
+		}
+	}
+	
+	// Mention the pack holding all of the files
+	if m.packPath != "" {
+		pathText += fmt.Sprintf("\n\nEverything for this application is zipped at:\n\n%s",
+			lipgloss.NewStyle().
+				Background(bgAccentColor).
+				Padding(0, 1).
+				Render(pathLink(m, m.packPath)))
+	}
 	
 	outputPathBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -651,7 +909,8 @@ func renderSuccessView(m Model) string {
 		"2. You can convert it to other formats:\n" +
 		"   • PDF: Use a markdown editor or online converter\n" +
 		"   • DOCX: Import to Word or Google Docs\n" +
-		"   • HTML: Use a markdown to HTML converter\n\n" +
+		"   • HTML: Use a markdown to HTML converter\n" +
+		"   • Or run resumake with -export docx,pdf,odt\n\n" +
 		"3. Review and customize before sending to employers"
 	
 	nextStepsBox := lipgloss.NewStyle().
@@ -659,10 +918,7 @@ func renderSuccessView(m Model) string {
 		BorderForeground(secondaryColor).
 		Padding(1, 2).
 		Width(displayWidth - 10).
-		Render(nextStepsTitle + "\n\n" + wrap(nextStepsContent, displayWidth - 20))
-	
-	// Exit instructions
-	exitInstructions := italicStyle.Render("Press Enter to quit or run again")
+		Render(nextStepsTitle + "\n\n" + layout.Wrap(nextStepsContent, displayWidth - 20))
 	
 	// Compose the view with all sections
 	return lipgloss.JoinVertical(
@@ -676,23 +932,48 @@ func renderSuccessView(m Model) string {
 		outputPathBox,
 		"",
 		nextStepsBox,
-		"",
-		exitInstructions,
 	)
 }
 
+// maxUncitedShown is the number of uncited bullets the success screen lists.
+const maxUncitedShown = 5
+
+// groundingNote reports what strict grounding found: that every bullet cites
+// the inputs, or the bullets that cite none of them, which are listed for
+// review, or were left out in drop mode. It is empty without strict grounding.
+func groundingNote(grounding prompt.Grounding, uncited []string) string {
+	if !grounding.Strict() {
+		return ""
+	}
+	if len(uncited) == 0 {
+		return "🔗 Every bullet cites your inputs"
+	}
+
+	bullets, cite := "bullets", "cite"
+	if len(uncited) == 1 {
+		bullets, cite = "bullet", "cites"
+	}
+	note := fmt.Sprintf("⚠️ %d %s %s none of your inputs; check them before you send the resume:", len(uncited), bullets, cite)
+	if grounding == prompt.GroundingDrop {
+		note = fmt.Sprintf("✂️ Left out %d %s that %s none of your inputs:", len(uncited), bullets, cite)
+	}
+	for i, bullet := range uncited {
+		if i == maxUncitedShown {
+			note += fmt.Sprintf("\n… %d more", len(uncited)-maxUncitedShown)
+			break
+		}
+		note += "\n- " + bullet
+	}
+	return note
+}
+
 // renderErrorView generates the error view with contextual troubleshooting
 func renderErrorView(m Model) string {
 	// Calculate display width
 	displayWidth := getConstrainedWidth(m.width)
 	
-	// Use the shared wrapText utility for consistent text wrapping
-	wrap := func(text string, width int) string {
-		return wrapText(text, width)
-	}
-	
 	// Analyze the error to determine the category and troubleshooting hints
-	category, hints, docRef := analyzeError(m.errorMsg)
+	category, hints, docRef := analyzeError(m.currentError())
 	
 	// Create a title with high contrast that includes the error category
 	title := lipgloss.NewStyle().
@@ -708,7 +989,7 @@ func renderErrorView(m Model) string {
 		BorderForeground(errorColor).
 		Padding(1, 2).
 		Width(displayWidth - 4).
-		Render(errorStyle.Render(wrap(m.errorMsg, displayWidth - 10)))
+		Render(errorStyle.Render(layout.Wrap(m.errorMsg, displayWidth - 10)))
 	
 	// Create a troubleshooting box with hints
 	troubleshootingTitle := lipgloss.NewStyle().
@@ -722,12 +1003,12 @@ func renderErrorView(m Model) string {
 		if i > 0 {
 			hintsContent.WriteString("\n\n")
 		}
-		hintsContent.WriteString("• " + hint)
+		hintsContent.WriteString("• " + linkURLs(m, hint))
 	}
 	
 	// Add doc reference if available
 	if docRef != "" {
-		hintsContent.WriteString("\n\n" + italicStyle.Render(docRef))
+		hintsContent.WriteString("\n\n" + italicStyle.Render(linkURLs(m, docRef)))
 	}
 	
 	troubleshootingBox := lipgloss.NewStyle().
@@ -737,15 +1018,14 @@ func renderErrorView(m Model) string {
 		Width(displayWidth - 4).
 		Render(troubleshootingTitle + "\n\n" + hintsContent.String())
 	
+	parts := []string{title, "", errorBox}
+	
+	// A rate limit that said how long to wait counts down to the retry
+	if countdown := retryCountdown(m, time.Now()); countdown != "" {
+		parts = append(parts, "", lipgloss.NewStyle().Bold(true).Foreground(accentColor).Render(layout.Wrap(countdown, displayWidth - 6)))
+	}
+	
 	// Compose the view with all sections
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		title,
-		"",
-		errorBox,
-		"",
-		troubleshootingBox,
-		"",
-		italicStyle.Render("Press Enter to quit"),
-	)
+	parts = append(parts, "", troubleshootingBox)
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
\ No newline at end of file
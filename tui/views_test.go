@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/layout"
 )
 
 func TestRenderWelcomeView(t *testing.T) {
@@ -233,7 +235,6 @@ func TestRenderSuccessView(t *testing.T) {
 	expectedElements := []string{
 		"Success",                   // Title element
 		"/tmp/resume_out.md",        // Output path
-		"Enter",                     // Quit instruction
 	}
 	
 	// Check for all expected elements
@@ -273,7 +274,6 @@ func TestRenderErrorView(t *testing.T) {
 	expectedElements := []string{
 		"Error",                     // Title element
 		"Failed to connect to API",  // Error message
-		"Enter",                     // Quit instruction
 	}
 	
 	// Check for all expected elements
@@ -300,9 +300,6 @@ func TestRenderErrorView(t *testing.T) {
 }
 
 func TestTextWrappingInAllViews(t *testing.T) {
-	// Temporarily skip test as we're in the process of updating views
-	t.Skip("Temporarily skipping text wrapping test while views are being updated")
-	
 	// Create a model with required fields and a narrow width to force wrapping
 	model := Model{
 		width:         30,
@@ -317,37 +314,83 @@ func TestTextWrappingInAllViews(t *testing.T) {
 		progressStep:  "Testing",
 		progressMsg:   "Test progress message",
 		sourceContent: "Source content",
-		stdinContent:  "Stdin content",
+		stdinContent:  strings.Repeat("Notes about a self-directed engineering career. ", 10),
 	}
 	
 	// Get all rendered views
-	welcomeView := renderWelcomeView(model)
-	sourceFileView := renderSourceFileInputView(model)
-	stdinView := renderStdinInputView(model)
-	generatingView := renderGeneratingView(model)
-	successView := renderSuccessView(model)
-	errorView := renderErrorView(model)
-	
-	// Maximum line length for any view - we allow some extra characters for styling
-	// This is a more resilient way to test wrapping than checking for exact strings
-	maxLineLength := 250
-	
-	// Test all views
 	allViews := map[string]string{
-		"welcomeView":       welcomeView,
-		"sourceFileView":    sourceFileView,
-		"stdinView":         stdinView,
-		"generatingView":    generatingView,
-		"successView":       successView,
-		"errorView":         errorView,
+		"welcomeView":    renderWelcomeView(model),
+		"sourceFileView": renderSourceFileInputView(model),
+		"stdinView":      renderStdinInputView(model),
+		"confirmView":    renderConfirmGenerateView(model),
+		"generatingView": renderGeneratingView(model),
+		"successView":    renderSuccessView(model),
+		"errorView":      renderErrorView(model),
 	}
 	
+	// No line may be wider than the constrained display width, measured in
+	// terminal cells so styling and emoji count as they are shown
+	maxLineWidth := getConstrainedWidth(model.width)
+	
 	for viewName, viewContent := range allViews {
 		lines := strings.Split(viewContent, "\n")
 		for i, line := range lines {
-			if len(line) > maxLineLength {
-				t.Errorf("Line too long in %s (line %d): %d chars", viewName, i+1, len(line))
+			if width := layout.Width(line); width > maxLineWidth {
+				t.Errorf("Line too wide in %s (line %d): %d cells: %q", viewName, i+1, width, line)
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestRenderWelcomeViewKeyDiagnosis(t *testing.T) {
+	tests := []struct {
+		name      string
+		diagnosis api.KeyDiagnosis
+		checking  bool
+		expected  []string
+	}{
+		{
+			name:     "checking",
+			checking: true,
+			expected: []string{"Checking your API key"},
+		},
+		{
+			name:      "malformed",
+			diagnosis: api.DiagnoseAPIKey(`"AIza-quoted"`),
+			expected:  []string{"malformed", "quotes"},
+		},
+		{
+			name:      "invalid",
+			diagnosis: api.KeyDiagnosis{Status: api.KeyInvalid, Summary: "API key was rejected: it is invalid, expired, or not enabled", Advice: "Create a new key"},
+			expected:  []string{"✗", "rejected", "Create a new key"},
+		},
+		{
+			name:      "unreachable",
+			diagnosis: api.KeyDiagnosis{Status: api.KeyUnreachable, Summary: "Could not reach the Gemini API to check the key", Advice: "Check your internet connection"},
+			expected:  []string{"Could not reach", "internet connection"},
+		},
+		{
+			name:      "valid",
+			diagnosis: api.KeyDiagnosis{Status: api.KeyValid, Summary: "API key is valid and ready to use"},
+			expected:  []string{"✓", "valid"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := Model{
+				apiKeyOk:     tt.diagnosis.Usable() || tt.checking,
+				keyDiagnosis: tt.diagnosis,
+				keyChecking:  tt.checking,
+				width:        100,
+				height:       40,
+			}
+			view := renderWelcomeView(model)
+			for _, element := range tt.expected {
+				if !strings.Contains(view, element) {
+					t.Errorf("Welcome view should contain %q", element)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/usage"
+)
+
+// runUsage describes the requests in the usage ledger at path to w: how many
+// each model received in the last 24 hours and the last minute, against its
+// free-tier limits. Without a model it lists the default and fallback models
+// and any other model the ledger has requests to.
+func runUsage(path, model string, now time.Time, w io.Writer) error {
+	entries, err := usage.Load(path)
+	if err != nil {
+		return err
+	}
+
+	models := []string{model}
+	if model == "" {
+		models = usageModels(entries, now)
+	}
+
+	fmt.Fprintf(w, "Requests in the last 24 hours, from %s:\n", path)
+	for _, name := range models {
+		fmt.Fprintf(w, "  %s: %s\n", name, usage.Check(entries, name, now).Summary())
+	}
+	fmt.Fprintln(w, "Requests sent with the same key from other programs or computers are not counted.")
+	return nil
+}
+
+// usageModels returns the default and fallback models followed by the other
+// models the ledger has requests to within usage.Window, in name order.
+func usageModels(entries []usage.Entry, now time.Time) []string {
+	models := []string{api.DefaultModelName, api.DefaultFallbackModelName}
+	var others []string
+	for _, entry := range entries {
+		if now.Sub(entry.Time) >= usage.Window {
+			continue
+		}
+		listed := func(name string) bool { return strings.EqualFold(name, entry.Model) }
+		if entry.Model != "" && !slices.ContainsFunc(models, listed) && !slices.ContainsFunc(others, listed) {
+			others = append(others, entry.Model)
+		}
+	}
+	slices.Sort(others)
+	return append(models, others...)
+}
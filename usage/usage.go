@@ -0,0 +1,259 @@
+// Package usage keeps a local ledger of the requests resumake sends to the
+// Gemini API, and estimates from it how much of a free-tier quota is left.
+//
+// The Gemini API does not report remaining quota, so the ledger is the only
+// way to tell before a run whether it is likely to hit a rate limit. It is a
+// JSON file in the resumake configuration directory (see config.Dir) holding
+// the requests of the last day. Requests made from other programs or
+// computers with the same key are not in it, so the estimate is a lower
+// bound on what has been used.
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/phrazzld/resumake/config"
+)
+
+// FileName is the name of the ledger inside the configuration directory.
+const FileName = "usage.json"
+
+// Window is how long requests are kept in the ledger, the period of the
+// daily limits.
+const Window = 24 * time.Hour
+
+// Entry is one request sent to the API.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Model  string    `json:"model"`
+	Tokens int       `json:"tokens,omitempty"` // Prompt and response tokens (0 if the response did not say)
+}
+
+// Limits are the rate limits of a model. Zero means no limit is known.
+type Limits struct {
+	RequestsPerMinute int
+	RequestsPerDay    int
+	TokensPerMinute   int
+}
+
+// FreeTierLimits are the published free-tier rate limits of each model
+// family, keyed by the start of the model name.
+var FreeTierLimits = map[string]Limits{
+	"gemini-2.5-pro":   {RequestsPerMinute: 5, RequestsPerDay: 25, TokensPerMinute: 250_000},
+	"gemini-2.5-flash": {RequestsPerMinute: 10, RequestsPerDay: 250, TokensPerMinute: 250_000},
+	"gemini-2.0-flash": {RequestsPerMinute: 15, RequestsPerDay: 1500, TokensPerMinute: 1_000_000},
+	"gemini-1.5-flash": {RequestsPerMinute: 15, RequestsPerDay: 1500, TokensPerMinute: 1_000_000},
+	"gemini-1.5-pro":   {RequestsPerMinute: 2, RequestsPerDay: 50, TokensPerMinute: 32_000},
+}
+
+// mu serializes updates to the ledger, since requests can finish at the same time.
+var mu sync.Mutex
+
+// DefaultPath returns the path of the ledger in the configuration directory.
+//
+// Returns:
+//   - string: The ledger path
+//   - error: An error if the configuration directory cannot be determined
+func DefaultPath() (string, error) {
+	return config.Path(FileName)
+}
+
+// Load reads the requests in the ledger, oldest first. A missing ledger is
+// not an error; it has no requests.
+//
+// Parameters:
+//   - path: The ledger file
+//
+// Returns:
+//   - []Entry: The recorded requests
+//   - error: An error if the ledger could not be read or parsed
+//
+// Example:
+//
+//	path, _ := usage.DefaultPath()
+//	entries, err := usage.Load(path)
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read usage ledger: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("cannot parse usage ledger %s: %w", path, err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, nil
+}
+
+// Record adds a request to the ledger, dropping requests older than Window.
+// A ledger that cannot be parsed is started again rather than left to
+// block every later request.
+//
+// Parameters:
+//   - path: The ledger file
+//   - entry: The request
+//
+// Returns:
+//   - error: An error if the ledger could not be written
+//
+// Example:
+//
+//	err := usage.Record(path, usage.Entry{Time: time.Now(), Model: "gemini-1.5-flash", Tokens: 4200})
+func Record(path string, entry Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, _ := Load(path)
+	kept := make([]Entry, 0, len(entries)+1)
+	for _, e := range entries {
+		if entry.Time.Sub(e.Time) < Window {
+			kept = append(kept, e)
+		}
+	}
+	kept = append(kept, entry)
+
+	data, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode usage ledger: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("cannot create configuration directory: %w", err)
+	}
+
+	// Write a temporary file and rename it, so a crash never leaves half a ledger
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("cannot write usage ledger: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("cannot write usage ledger: %w", err)
+	}
+	return nil
+}
+
+// LimitsFor returns the free-tier limits of a model, matched by the longest
+// family name the model name starts with.
+//
+// Parameters:
+//   - model: The model name, such as "gemini-2.5-pro-exp-03-25"
+//
+// Returns:
+//   - Limits: The model's limits
+//   - bool: Whether the model's limits are known
+func LimitsFor(model string) (Limits, bool) {
+	model = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(model)), "models/")
+	best := ""
+	for family := range FreeTierLimits {
+		if strings.HasPrefix(model, family) && len(family) > len(best) {
+			best = family
+		}
+	}
+	if best == "" {
+		return Limits{}, false
+	}
+	return FreeTierLimits[best], true
+}
+
+// Status is how much of a model's quota the ledger shows as used.
+type Status struct {
+	Model        string // The model checked
+	Limits       Limits // Its free-tier limits (zero when unknown)
+	Known        bool   // Whether the model's limits are known
+	LastMinute   int    // Requests in the last minute
+	LastDay      int    // Requests in the last Window
+	MinuteTokens int    // Tokens used in the last minute
+}
+
+// Check counts the requests to a model in the ledger.
+//
+// Parameters:
+//   - entries: The ledger, as returned by Load
+//   - model: The model to check
+//   - now: The current time
+//
+// Returns:
+//   - Status: The model's recent usage and limits
+//
+// Example:
+//
+//	status := usage.Check(entries, api.DefaultModelName, time.Now())
+//	fmt.Println(status.Summary())
+func Check(entries []Entry, model string, now time.Time) Status {
+	status := Status{Model: model}
+	status.Limits, status.Known = LimitsFor(model)
+	for _, e := range entries {
+		if !strings.EqualFold(e.Model, model) {
+			continue
+		}
+		age := now.Sub(e.Time)
+		if age < 0 || age >= Window {
+			continue
+		}
+		status.LastDay++
+		if age < time.Minute {
+			status.LastMinute++
+			status.MinuteTokens += e.Tokens
+		}
+	}
+	return status
+}
+
+// Summary describes the usage, such as "3 of 25 requests in the last 24
+// hours, 1 of 5 in the last minute", or only the counts when the limits
+// are not known.
+//
+// Returns:
+//   - string: The description
+func (s Status) Summary() string {
+	if !s.Known {
+		return fmt.Sprintf("%s in the last 24 hours (free-tier limits unknown for this model)", requests(s.LastDay))
+	}
+	return fmt.Sprintf("%d of %s in the last 24 hours, %d of %d in the last minute",
+		s.LastDay, requests(s.Limits.RequestsPerDay), s.LastMinute, s.Limits.RequestsPerMinute)
+}
+
+// Warning explains why a run that sends needed more requests is likely to
+// exceed the free-tier limits.
+//
+// Parameters:
+//   - needed: The number of requests the run sends
+//
+// Returns:
+//   - string: The warning, or an empty string if the run fits the limits or they are unknown
+func (s Status) Warning(needed int) string {
+	if !s.Known {
+		return ""
+	}
+	switch {
+	case s.LastDay+needed > s.Limits.RequestsPerDay:
+		return fmt.Sprintf("This run sends %s, but only %d of today's free-tier quota for %s are left, so it will probably fail. Wait for the quota to reset or use a paid key.",
+			requests(needed), max(s.Limits.RequestsPerDay-s.LastDay, 0), s.Model)
+	case s.LastMinute+needed > s.Limits.RequestsPerMinute:
+		return fmt.Sprintf("This run sends %s, more than the %d per minute left for %s. Wait a minute before generating to avoid a rate limit error.",
+			requests(needed), max(s.Limits.RequestsPerMinute-s.LastMinute, 0), s.Model)
+	case s.MinuteTokens >= s.Limits.TokensPerMinute:
+		return fmt.Sprintf("The last minute used all %d tokens per minute allowed for %s. Wait a minute before generating to avoid a rate limit error.",
+			s.Limits.TokensPerMinute, s.Model)
+	}
+	return ""
+}
+
+// requests formats a number of requests, such as "1 request" or "3 requests".
+func requests(n int) string {
+	if n == 1 {
+		return "1 request"
+	}
+	return fmt.Sprintf("%d requests", n)
+}
@@ -0,0 +1,122 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resumake", FileName)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Test case 1: A missing ledger has no requests
+	entries, err := Load(path)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Load() = %v, %v; want no entries", entries, err)
+	}
+
+	// Test case 2: Requests older than a day are dropped as new ones are added
+	for _, e := range []Entry{
+		{Time: now.Add(-25 * time.Hour), Model: "gemini-1.5-flash"},
+		{Time: now.Add(-time.Hour), Model: "gemini-1.5-flash", Tokens: 900},
+		{Time: now, Model: "gemini-2.5-pro-exp-03-25", Tokens: 4000},
+	} {
+		if err := Record(path, e); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	entries, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Tokens != 900 || entries[1].Model != "gemini-2.5-pro-exp-03-25" {
+		t.Errorf("Expected the two requests of the last day, got %+v", entries)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected the ledger to be private, got %o", perm)
+	}
+
+	// Test case 3: A corrupt ledger is reported by Load and replaced by Record
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error loading a corrupt ledger")
+	}
+	if err := Record(path, Entry{Time: now, Model: "gemini-1.5-flash"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if entries, err := Load(path); err != nil || len(entries) != 1 {
+		t.Errorf("Expected a fresh ledger with one request, got %v, %v", entries, err)
+	}
+}
+
+func TestLimitsFor(t *testing.T) {
+	tests := []struct {
+		model    string
+		expected int // Requests per day
+		known    bool
+	}{
+		{"gemini-2.5-pro-exp-03-25", 25, true},
+		{"models/gemini-1.5-flash-002", 1500, true},
+		{"gemini-1.5-pro", 50, true},
+		{"custom-model", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			limits, known := LimitsFor(tt.model)
+			if known != tt.known || limits.RequestsPerDay != tt.expected {
+				t.Errorf("LimitsFor(%q) = %+v, %v; want %d requests per day, %v", tt.model, limits, known, tt.expected, tt.known)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Time: now.Add(-2 * time.Hour), Model: "gemini-1.5-pro", Tokens: 5000},
+		{Time: now.Add(-30 * time.Second), Model: "gemini-1.5-pro", Tokens: 30000},
+		{Time: now.Add(-10 * time.Second), Model: "gemini-1.5-flash", Tokens: 800},
+		{Time: now.Add(-30 * time.Hour), Model: "gemini-1.5-pro"},
+	}
+
+	status := Check(entries, "gemini-1.5-pro", now)
+	if status.LastDay != 2 || status.LastMinute != 1 || status.MinuteTokens != 30000 {
+		t.Errorf("Check() = %+v, want 2 requests today, 1 in the last minute of 30000 tokens", status)
+	}
+	if got := status.Summary(); got != "2 of 50 requests in the last 24 hours, 1 of 2 in the last minute" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	// Test case 1: One more request fits both limits
+	if warning := status.Warning(1); warning != "" {
+		t.Errorf("Expected no warning for one request, got %q", warning)
+	}
+
+	// Test case 2: Two more exceed the per-minute limit
+	if warning := status.Warning(2); !strings.Contains(warning, "Wait a minute") {
+		t.Errorf("Expected a per-minute warning, got %q", warning)
+	}
+
+	// Test case 3: An exhausted daily quota is reported first
+	status.LastDay = 50
+	if warning := status.Warning(1); !strings.Contains(warning, "only 0 of today's free-tier quota") {
+		t.Errorf("Expected a daily quota warning, got %q", warning)
+	}
+
+	// Test case 4: Models without known limits only show the count
+	unknown := Check(entries, "custom-model", now)
+	if unknown.Warning(10) != "" || !strings.Contains(unknown.Summary(), "0 requests in the last 24 hours") {
+		t.Errorf("Expected only a count for an unknown model, got %q", unknown.Summary())
+	}
+}
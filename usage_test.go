@@ -0,0 +1,60 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/api"
+	"github.com/phrazzld/resumake/usage"
+)
+
+func TestRunUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), usage.FileName)
+	now := time.Now()
+	for _, entry := range []usage.Entry{
+		{Time: now.Add(-2 * time.Hour), Model: api.DefaultModelName},
+		{Time: now.Add(-30 * time.Second), Model: api.DefaultModelName},
+		{Time: now.Add(-time.Hour), Model: "gemini-2.0-flash"},
+	} {
+		if err := usage.Record(path, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Test case 1: Every model is listed against its limits, the defaults first
+	var out strings.Builder
+	if err := runUsage(path, "", now, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"from " + path,
+		api.DefaultModelName + ": 2 of 25 requests in the last 24 hours, 1 of 5 in the last minute",
+		api.DefaultFallbackModelName + ": 0 of 1500 requests in the last 24 hours",
+		"gemini-2.0-flash: 1 of 1500 requests",
+		"not counted",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the usage to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Index(out.String(), api.DefaultFallbackModelName) > strings.Index(out.String(), "gemini-2.0-flash") {
+		t.Errorf("Expected the default models before the others, got:\n%s", out.String())
+	}
+
+	// Test case 2: A model given by name is the only one listed
+	out.Reset()
+	if err := runUsage(path, "gemini-2.0-flash", now, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Contains(out.String(), api.DefaultModelName) || !strings.Contains(out.String(), "gemini-2.0-flash: 1 of 1500") {
+		t.Errorf("Expected only gemini-2.0-flash, got:\n%s", out.String())
+	}
+
+	// Test case 3: No ledger yet is no requests
+	out.Reset()
+	if err := runUsage(filepath.Join(t.TempDir(), usage.FileName), "", now, &out); err != nil || !strings.Contains(out.String(), api.DefaultModelName+": 0 of 25") {
+		t.Errorf("Expected no requests without a ledger, got %q (%v)", out.String(), err)
+	}
+}
@@ -0,0 +1,117 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// The key is stored in the keychain under this service and account.
+const (
+	keychainService = "resumake"
+	keychainAccount = "encryption-key"
+)
+
+// errNoKeychainKey is returned by the keychain lookups when no key is stored.
+var errNoKeychainKey = errors.New("no key in the keychain")
+
+// systemKeychainKey returns the key stored in the operating system keychain:
+// the login keychain on macOS, or the Secret Service, through secret-tool,
+// on Linux. When create is true and no key is stored yet, a random key is
+// generated and stored.
+func systemKeychainKey(create bool) ([]byte, error) {
+	var lookup func() (string, error)
+	var store func(string) error
+	switch runtime.GOOS {
+	case "darwin":
+		lookup, store = macLookup, macStore
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := exec.LookPath("secret-tool"); err != nil {
+			return nil, errors.New("the keychain needs secret-tool from libsecret; install it, or set encryption = passphrase")
+		}
+		lookup, store = secretToolLookup, secretToolStore
+	default:
+		return nil, fmt.Errorf("the keychain is not supported on %s; set encryption = passphrase", runtime.GOOS)
+	}
+
+	encoded, err := lookup()
+	if errors.Is(err, errNoKeychainKey) && create {
+		key := make([]byte, chacha20poly1305.KeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := store(base64.StdEncoding.EncodeToString(key)); err != nil {
+			return nil, fmt.Errorf("cannot store the key in the keychain: %w", err)
+		}
+		return key, nil
+	}
+	if errors.Is(err, errNoKeychainKey) {
+		return nil, fmt.Errorf("%w: the key in the keychain is gone", ErrLocked)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the key from the keychain: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != chacha20poly1305.KeySize {
+		return nil, errors.New("the key in the keychain is damaged")
+	}
+	return key, nil
+}
+
+// macLookup reads the key from the macOS login keychain.
+func macLookup() (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", keychainService, "-a", keychainAccount, "-w").Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 44 { // The item could not be found
+		return "", errNoKeychainKey
+	}
+	return strings.TrimSpace(string(out)), err
+}
+
+// macStore adds the key to the macOS login keychain. The command is passed
+// to security's interactive mode on standard input, so the key does not show
+// in the process list as an argument of -w would.
+func macStore(encoded string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -s %s -a %s -w %s\n", keychainService, keychainAccount, encoded))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+	// Interactive mode exits successfully when a command fails, so its errors are read from stderr
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return errors.New(message)
+	}
+	return nil
+}
+
+// secretToolLookup reads the key from the Secret Service.
+func secretToolLookup() (string, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", keychainService, "account", keychainAccount).Output()
+	if err != nil || len(bytes.TrimSpace(out)) == 0 {
+		// secret-tool exits with 1 and prints nothing when there is no such secret
+		var exitErr *exec.ExitError
+		if err == nil || errors.As(err, &exitErr) && len(exitErr.Stderr) == 0 {
+			return "", errNoKeychainKey
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// secretToolStore adds the key to the Secret Service. The key is passed on
+// standard input so it does not show in the process list.
+func secretToolStore(encoded string) error {
+	cmd := exec.Command("secret-tool", "store", "--label=resumake encryption key", "service", keychainService, "account", keychainAccount)
+	cmd.Stdin = strings.NewReader(encoded)
+	return cmd.Run()
+}
@@ -0,0 +1,393 @@
+// Package vault encrypts the files resumake keeps about the candidate, such
+// as the resume history, so other people using the same computer cannot read
+// them.
+//
+// Encryption is off unless the encryption setting turns it on. Files are
+// sealed with XChaCha20-Poly1305 under a 256-bit key that comes either from
+// the operating system keychain, where a random key is stored the first time
+// it is needed, or from a passphrase stretched with Argon2id. Reading is
+// transparent: encrypted files are opened with their key whatever the
+// current setting, and files written before encryption was turned on are
+// read as they are.
+package vault
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/phrazzld/resumake/config"
+)
+
+// Mode is where the encryption key comes from.
+type Mode string
+
+const (
+	// ModeOff saves files unencrypted.
+	ModeOff Mode = "off"
+
+	// ModeKeychain keeps a random key in the operating system keychain.
+	ModeKeychain Mode = "keychain"
+
+	// ModePassphrase derives the key from a passphrase asked for once per run.
+	ModePassphrase Mode = "passphrase"
+)
+
+// PassphraseEnv is the environment variable that supplies the passphrase
+// instead of a prompt, for scripts and scheduled runs.
+const PassphraseEnv = "RESUMAKE_PASSPHRASE"
+
+// SaltFileName is the file in the configuration directory that holds the
+// salt for deriving the key from a passphrase, and a check value that tells
+// a mistyped passphrase apart from a damaged file.
+const SaltFileName = "vault"
+
+// ErrLocked is returned for an encrypted file when its key is not available.
+var ErrLocked = errors.New("the file is encrypted and no key is available to open it")
+
+// magic starts every encrypted file, so encrypted and plain files can be told apart.
+var magic = []byte("RSMKENC1")
+
+// File layout after the magic: the key kind, the salt for passphrase keys,
+// and the nonce, followed by the ciphertext. The header is authenticated.
+const (
+	kindKeychain   = 'k'
+	kindPassphrase = 'p'
+	saltSize       = 16
+	headerSize     = 8 + 1 + saltSize + chacha20poly1305.NonceSizeX
+)
+
+// Argon2id parameters: one pass over 64 MiB, as RFC 9106 recommends when
+// memory is limited.
+const (
+	argonTime    = 1
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+)
+
+// checkText is sealed in the salt file to verify a passphrase.
+const checkText = "resumake"
+
+// PassphraseFunc asks for the passphrase. confirm is true when no passphrase
+// has been set yet, so it should be asked for twice.
+type PassphraseFunc func(confirm bool) (string, error)
+
+var (
+	mu          sync.Mutex
+	mode        = ModeOff
+	askPass     PassphraseFunc
+	keychainKey = systemKeychainKey // Replaced in tests
+	saltPath    = func() (string, error) { return config.Path(SaltFileName) }
+	keys        = make(map[string][]byte) // Derived keys by kind and salt
+)
+
+// ParseMode parses the encryption setting. An empty setting is ModeOff.
+//
+// Parameters:
+//   - value: "off", "keychain", "passphrase", or ""
+//
+// Returns:
+//   - Mode: The mode
+//   - error: An error naming the valid modes if value is not one of them
+//
+// Example:
+//
+//	mode, err := vault.ParseMode(settings[config.SettingEncryption])
+func ParseMode(value string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(value))); m {
+	case "", ModeOff:
+		return ModeOff, nil
+	case ModeKeychain, ModePassphrase:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown encryption %q; use off, keychain, or passphrase", value)
+}
+
+// Set selects where the key for new files comes from, and how to ask for a
+// passphrase. The passphrase is asked for when encrypted files written with
+// one are opened, even if m is not ModePassphrase.
+//
+// Parameters:
+//   - m: The mode for files saved from now on
+//   - ask: Asks for the passphrase, or nil if it cannot be asked for
+//
+// Example:
+//
+//	vault.Set(vault.ModePassphrase, promptPassphrase)
+func Set(m Mode, ask PassphraseFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	mode = m
+	askPass = ask
+	clear(keys)
+}
+
+// Enabled reports whether new files are encrypted.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return mode != ModeOff
+}
+
+// Unlock gets the key for new files now, so a passphrase is asked for before
+// the TUI starts rather than in the middle of it. With encryption off it
+// does nothing.
+//
+// Returns:
+//   - error: An error if the key could not be found, created, or derived,
+//     such as a mistyped passphrase
+func Unlock() error {
+	_, _, _, err := sealKey()
+	return err
+}
+
+// Seal encrypts data for saving. With encryption off, data is returned as
+// it is.
+//
+// Parameters:
+//   - data: The file content
+//
+// Returns:
+//   - []byte: The content to write
+//   - error: An error if the key is not available
+//
+// Example:
+//
+//	sealed, err := vault.Seal([]byte(markdown))
+//	err = os.WriteFile(path, sealed, 0600)
+func Seal(data []byte) ([]byte, error) {
+	key, kind, salt, err := sealKey()
+	if err != nil || key == nil {
+		return data, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, kind)
+	header = append(header, salt...)
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("cannot encrypt: %w", err)
+	}
+	header = append(header, nonce...)
+	return aead.Seal(header, nonce, data, header), nil
+}
+
+// Open decrypts data read from a file. Data that is not encrypted is
+// returned as it is.
+//
+// Parameters:
+//   - data: The file content
+//
+// Returns:
+//   - []byte: The decrypted content
+//   - error: ErrLocked if no key is available, or an error if the key is
+//     wrong or the file is damaged
+//
+// Example:
+//
+//	data, err := os.ReadFile(path)
+//	content, err := vault.Open(data)
+func Open(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if len(data) < headerSize {
+		return nil, errors.New("the encrypted file is damaged")
+	}
+	header := data[:headerSize]
+	kind := header[len(magic)]
+	salt := header[len(magic)+1 : len(magic)+1+saltSize]
+	nonce := header[len(magic)+1+saltSize:]
+
+	mu.Lock()
+	key, err := keyFor(kind, salt)
+	mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, errors.New("cannot decrypt: the key is wrong or the file is damaged")
+	}
+	return plain, nil
+}
+
+// IsEncrypted reports whether data was written by Seal with encryption on.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// sealKey returns the key, kind, and salt for new files, or a nil key with
+// encryption off.
+func sealKey() ([]byte, byte, []byte, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	switch mode {
+	case ModeKeychain:
+		salt := make([]byte, saltSize) // Keychain keys are not derived
+		key, err := keyFor(kindKeychain, salt)
+		return key, kindKeychain, salt, err
+	case ModePassphrase:
+		salt, err := passphraseSalt()
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		key, err := keyFor(kindPassphrase, salt)
+		return key, kindPassphrase, salt, err
+	}
+	return nil, 0, nil, nil
+}
+
+// keyFor returns the key of the given kind and salt, fetching or deriving it
+// the first time. The caller holds mu.
+func keyFor(kind byte, salt []byte) ([]byte, error) {
+	id := keyID(kind, salt)
+	if key, ok := keys[id]; ok {
+		return key, nil
+	}
+
+	var key []byte
+	switch kind {
+	case kindKeychain:
+		var err error
+		if key, err = keychainKey(mode == ModeKeychain); err != nil {
+			return nil, err
+		}
+	case kindPassphrase:
+		var err error
+		if key, err = passphraseKey(salt); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("the encrypted file uses an unknown key kind %q", kind)
+	}
+	keys[id] = key
+	return key, nil
+}
+
+// keyID identifies a key in the cache of fetched and derived keys.
+func keyID(kind byte, salt []byte) string {
+	return string(append([]byte{kind}, salt...))
+}
+
+// passphraseKey asks for the passphrase and derives the key for salt. When
+// salt is the one in the salt file, the passphrase is checked against it.
+func passphraseKey(salt []byte) ([]byte, error) {
+	stored, check, err := readSaltFile()
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := passphrase(stored == nil)
+	if err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey([]byte(passphrase), salt, argonTime, argonMemory, argonThreads, chacha20poly1305.KeySize)
+
+	if bytes.Equal(salt, stored) {
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return nil, err
+		}
+		nonce, sealed := check[:chacha20poly1305.NonceSizeX], check[chacha20poly1305.NonceSizeX:]
+		if _, err := aead.Open(nil, nonce, sealed, nil); err != nil {
+			return nil, errors.New("wrong passphrase")
+		}
+	}
+	return key, nil
+}
+
+// passphrase returns the passphrase from PassphraseEnv, or else asks for it.
+func passphrase(confirm bool) (string, error) {
+	if value := os.Getenv(PassphraseEnv); value != "" {
+		return value, nil
+	}
+	if askPass == nil {
+		return "", fmt.Errorf("%w; set %s to the passphrase", ErrLocked, PassphraseEnv)
+	}
+	value, err := askPass(confirm)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", errors.New("the passphrase cannot be empty")
+	}
+	return value, nil
+}
+
+// passphraseSalt returns the salt for passphrase keys, creating the salt
+// file with a check value for the passphrase the first time.
+func passphraseSalt() ([]byte, error) {
+	salt, _, err := readSaltFile()
+	if err != nil || salt != nil {
+		return salt, err
+	}
+
+	salt = make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	passphrase, err := passphrase(true)
+	if err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey([]byte(passphrase), salt, argonTime, argonMemory, argonThreads, chacha20poly1305.KeySize)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	path, err := saltPath()
+	if err != nil {
+		return nil, err
+	}
+	content := append(append(salt, nonce...), aead.Seal(nil, nonce, []byte(checkText), nil)...)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("cannot create configuration directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return nil, fmt.Errorf("cannot save the passphrase salt: %w", err)
+	}
+	keys[keyID(kindPassphrase, salt)] = key
+	return salt, nil
+}
+
+// readSaltFile returns the salt and the sealed check value from the salt
+// file, or nil if there is no salt file yet.
+func readSaltFile() ([]byte, []byte, error) {
+	path, err := saltPath()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read the passphrase salt: %w", err)
+	}
+	if len(data) < saltSize+chacha20poly1305.NonceSizeX+chacha20poly1305.Overhead {
+		return nil, nil, fmt.Errorf("the passphrase salt in %s is damaged", path)
+	}
+	return data[:saltSize], data[saltSize:], nil
+}
@@ -0,0 +1,133 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useTempVault keeps the salt file in a temporary directory and turns
+// encryption off again when the test ends.
+func useTempVault(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), SaltFileName)
+	originalSaltPath, originalKeychainKey := saltPath, keychainKey
+	saltPath = func() (string, error) { return path, nil }
+	t.Cleanup(func() {
+		saltPath, keychainKey = originalSaltPath, originalKeychainKey
+		Set(ModeOff, nil)
+	})
+	return path
+}
+
+func TestParseMode(t *testing.T) {
+	for value, want := range map[string]Mode{"": ModeOff, "off": ModeOff, "Keychain": ModeKeychain, " passphrase ": ModePassphrase} {
+		if mode, err := ParseMode(value); err != nil || mode != want {
+			t.Errorf("ParseMode(%q) = %q, %v; want %q", value, mode, err, want)
+		}
+	}
+	if _, err := ParseMode("yes"); err == nil {
+		t.Error("Expected an error for an unknown mode")
+	}
+}
+
+func TestSealOff(t *testing.T) {
+	useTempVault(t)
+	data := []byte("# Jane Doe")
+	sealed, err := Seal(data)
+	if err != nil || !bytes.Equal(sealed, data) {
+		t.Errorf("Expected data to be saved as it is with encryption off, got %q (%v)", sealed, err)
+	}
+	if opened, err := Open(data); err != nil || !bytes.Equal(opened, data) {
+		t.Errorf("Expected unencrypted data to be read as it is, got %q (%v)", opened, err)
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	saltFile := useTempVault(t)
+	asked := 0
+	Set(ModePassphrase, func(confirm bool) (string, error) {
+		asked++
+		if !confirm {
+			t.Error("Expected the first passphrase to be confirmed")
+		}
+		return "correct horse", nil
+	})
+
+	data := []byte("# Jane Doe\n\n## Experience")
+	sealed, err := Seal(data)
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if !IsEncrypted(sealed) || bytes.Contains(sealed, []byte("Jane")) {
+		t.Fatalf("Expected encrypted content, got %q", sealed)
+	}
+	if _, err := os.Stat(saltFile); err != nil {
+		t.Errorf("Expected the salt file to be created: %v", err)
+	}
+	if _, err := Seal(data); err != nil || asked != 1 {
+		t.Errorf("Expected the passphrase to be asked for once, asked %d times (%v)", asked, err)
+	}
+	opened, err := Open(sealed)
+	if err != nil || !bytes.Equal(opened, data) {
+		t.Errorf("Expected the data back, got %q (%v)", opened, err)
+	}
+
+	// A new run asks again, and tells a wrong passphrase apart
+	Set(ModeOff, func(confirm bool) (string, error) {
+		if confirm {
+			t.Error("Expected no confirmation once a passphrase is set")
+		}
+		return "wrong horse", nil
+	})
+	if _, err := Open(sealed); err == nil || err.Error() != "wrong passphrase" {
+		t.Errorf("Expected a wrong passphrase error, got %v", err)
+	}
+
+	// The environment variable replaces the prompt
+	Set(ModeOff, nil)
+	if _, err := Open(sealed); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked without a passphrase, got %v", err)
+	}
+	t.Setenv(PassphraseEnv, "correct horse")
+	if opened, err := Open(sealed); err != nil || !bytes.Equal(opened, data) {
+		t.Errorf("Expected the passphrase from %s to open the file, got %q (%v)", PassphraseEnv, opened, err)
+	}
+}
+
+func TestKeychain(t *testing.T) {
+	useTempVault(t)
+	stored := bytes.Repeat([]byte{7}, 32)
+	var created []bool
+	keychainKey = func(create bool) ([]byte, error) {
+		created = append(created, create)
+		return stored, nil
+	}
+
+	Set(ModeKeychain, nil)
+	data := []byte("# Jane Doe")
+	sealed, err := Seal(data)
+	if err != nil || !IsEncrypted(sealed) {
+		t.Fatalf("Expected encrypted content, got %q (%v)", sealed, err)
+	}
+
+	// Files stay readable after encryption is turned off, without creating a key
+	Set(ModeOff, nil)
+	if opened, err := Open(sealed); err != nil || !bytes.Equal(opened, data) {
+		t.Errorf("Expected the data back, got %q (%v)", opened, err)
+	}
+	if len(created) != 2 || !created[0] || created[1] {
+		t.Errorf("Expected the key to be created only for saving, got %v", created)
+	}
+
+	// Tampering is detected
+	sealed[len(sealed)-1] ^= 1
+	if _, err := Open(sealed); err == nil {
+		t.Error("Expected an error for a damaged file")
+	}
+	if _, err := Open(sealed[:headerSize-1]); err == nil {
+		t.Error("Expected an error for a truncated file")
+	}
+}
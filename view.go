@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+	"github.com/phrazzld/resumake/layout"
+	"github.com/phrazzld/resumake/output"
+)
+
+// runView shows a resume with the chosen renderer: the resume named on the
+// command line, or else the one most recently generated. A pager is only
+// run when stdout is a terminal; otherwise the text it would be given is
+// written to w, so the output can be piped or redirected.
+func runView(flags input.ViewFlags, historyDir string, w io.Writer) error {
+	content := flags.SourceContent
+	if flags.SourcePath == "" {
+		path, err := lastResumePath(historyDir)
+		if err != nil {
+			return err
+		}
+		if content, err = input.ReadSourceFile(path); err != nil {
+			return err
+		}
+	}
+
+	renderer, err := output.ParseRenderer(flags.Renderer)
+	if err != nil {
+		return err
+	}
+
+	terminal := false
+	if f, ok := w.(*os.File); ok {
+		terminal = term.IsTerminal(f.Fd())
+	}
+	width := flags.Width
+	if width == 0 {
+		width = layout.DefaultWidth
+		if f, ok := w.(*os.File); ok && terminal {
+			if columns, _, err := term.GetSize(f.Fd()); err == nil && columns > 0 {
+				width = columns
+			}
+		}
+	}
+
+	if pager, ok := renderer.(output.PagerRenderer); ok && terminal {
+		cmd, err := pager.Cmd(content, width)
+		if err != nil {
+			return err
+		}
+		cmd.Stdout, cmd.Stderr = w, os.Stderr
+		return cmd.Run()
+	}
+
+	text, err := renderer.Render(content, width)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, text)
+	return err
+}
+
+// lastResumePath returns the resume most recently saved to the history.
+func lastResumePath(historyDir string) (string, error) {
+	if historyDir != "" {
+		newest, ok, err := history.Newest(historyDir)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return newest.Path, nil
+		}
+	}
+	return "", errors.New("there is no resume to view; generate one first, or pass the path of a resume")
+}
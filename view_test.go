@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phrazzld/resumake/history"
+	"github.com/phrazzld/resumake/input"
+)
+
+func TestRunView(t *testing.T) {
+	historyDir := filepath.Join(t.TempDir(), "history")
+	var out strings.Builder
+
+	// Test case 1: Nothing to view is an error
+	if err := runView(input.ViewFlags{}, historyDir, &out); err == nil {
+		t.Error("Expected an error without a resume")
+	}
+
+	// Test case 2: The newest resume in the history is shown
+	if _, err := history.Save(historyDir, "# Jane Doe\n\n## Experience\n- Built **X**", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := runView(input.ViewFlags{Renderer: "plain"}, historyDir, &out); err != nil {
+		t.Fatalf("runView returned error: %v", err)
+	}
+	if text := out.String(); !strings.Contains(text, "EXPERIENCE") || !strings.Contains(text, "- Built X") {
+		t.Errorf("Expected the plain resume, got:\n%s", text)
+	}
+
+	// Test case 3: Without a terminal, a pager's input is written instead of running it
+	out.Reset()
+	flags := input.ViewFlags{SourcePath: "resume.md", SourceContent: "# Ada\n\n- Built **Y**", Renderer: "bat"}
+	if err := runView(flags, historyDir, &out); err != nil {
+		t.Fatalf("runView returned error: %v", err)
+	}
+	if out.String() != flags.SourceContent {
+		t.Errorf("Expected the Markdown bat would be given, got %q", out.String())
+	}
+}